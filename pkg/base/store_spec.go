@@ -169,6 +169,13 @@ type StoreSpec struct {
 	BallastSize *SizeSpec
 	InMemory    bool
 	Attributes  roachpb.Attributes
+	// RaftLogPath, if set, is the directory of a dedicated engine holding the
+	// Raft log and the other unreplicated Raft state (HardState and
+	// TruncatedState) of the store's replicas, which are otherwise kept in the
+	// store's engine along with the replicated data. Placing it on a separate
+	// disk keeps log writes from competing with compactions of the state
+	// machine.
+	RaftLogPath string
 	// StickyInMemoryEngineID is a unique identifier associated with a given
 	// store which will remain in memory even after the default Engine close
 	// until it has been explicitly cleaned up by CleanupStickyInMemEngine[s]
@@ -225,6 +232,9 @@ func (ss StoreSpec) String() string {
 		}
 		fmt.Fprintf(&buffer, ",")
 	}
+	if len(ss.RaftLogPath) != 0 {
+		fmt.Fprintf(&buffer, "raft-log-path=%s,", ss.RaftLogPath)
+	}
 	if len(ss.PebbleOptions) > 0 {
 		optsStr := strings.Replace(ss.PebbleOptions, "\n", " ", -1)
 		fmt.Fprint(&buffer, "pebble=")
@@ -273,6 +283,8 @@ var fractionRegex = regexp.MustCompile(`^([-]?([0-9]+\.[0-9]*|[0-9]*\.[0-9]+|[0-
 //   - 20%             -> 20% of the available space
 //   - 0.2             -> 20% of the available space
 // - attrs=xxx:yyy:zzz A colon separated list of optional attributes.
+// - raft-log-path=xxx The optional directory of a separate engine holding
+//   the Raft log of the store's replicas.
 // Note that commas are forbidden within any field name or value.
 func NewStoreSpec(value string) (StoreSpec, error) {
 	const pathField = "path"
@@ -342,6 +354,12 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 				return StoreSpec{}, err
 			}
 			ss.BallastSize = &ballastSize
+		case "raft-log-path":
+			var err error
+			ss.RaftLogPath, err = GetAbsoluteStorePath(field, value)
+			if err != nil {
+				return StoreSpec{}, err
+			}
 		case "attrs":
 			// Check to make sure there are no duplicate attributes.
 			attrMap := make(map[string]struct{})
@@ -414,8 +432,13 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 		if ss.BallastSize != nil {
 			return StoreSpec{}, fmt.Errorf("ballast-size specified for in memory store")
 		}
+		if ss.RaftLogPath != "" {
+			return StoreSpec{}, fmt.Errorf("raft-log-path specified for in memory store")
+		}
 	} else if ss.Path == "" {
 		return StoreSpec{}, fmt.Errorf("no path specified")
+	} else if ss.RaftLogPath == ss.Path {
+		return StoreSpec{}, fmt.Errorf("raft-log-path must differ from the store path")
 	}
 	return ss, nil
 }
@@ -135,6 +135,15 @@ target_file_size=2097152`
 		{"path=/mnt/hda1,ballast-size=100.000%", "ballast size (100.000%) must be between 0.000000% and 50.000000%", StoreSpec{}},
 		{"ballast-size=20GiB,path=/mnt/hda1,ballast-size=20GiB", "ballast-size field was used twice in store definition", StoreSpec{}},
 
+		// raft log path
+		{"path=/mnt/hda1,raft-log-path=/mnt/hdb1", "", StoreSpec{Path: "/mnt/hda1", RaftLogPath: "/mnt/hdb1"}},
+		{"raft-log-path=/mnt/hdb1,path=/mnt/hda1", "", StoreSpec{Path: "/mnt/hda1", RaftLogPath: "/mnt/hdb1"}},
+		{"path=/mnt/hda1,raft-log-path=", "no value specified for raft-log-path", StoreSpec{}},
+		{"path=/mnt/hda1,raft-log-path=~/raft", "raft-log-path cannot start with '~': ~/raft", StoreSpec{}},
+		{"path=/mnt/hda1,raft-log-path=/mnt/hda1", "raft-log-path must differ from the store path", StoreSpec{}},
+		{"raft-log-path=/mnt/hdb1", "no path specified", StoreSpec{}},
+		{"type=mem,size=20GiB,raft-log-path=/mnt/hdb1", "raft-log-path specified for in memory store", StoreSpec{}},
+
 		// type
 		{"type=mem,size=20GiB", "", StoreSpec{Size: SizeSpec{InBytes: 21474836480}, InMemory: true}},
 		{"size=20GiB,type=mem", "", StoreSpec{Size: SizeSpec{InBytes: 21474836480}, InMemory: true}},
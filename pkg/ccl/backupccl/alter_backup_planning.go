@@ -58,6 +58,7 @@ func alterBackupPlanHook(
 
 	var newKmsFn func() ([]string, error)
 	var oldKmsFn func() ([]string, error)
+	var setOptsFn func() (map[string]string, error)
 
 	for _, cmd := range alterBackupStmt.Cmds {
 		switch v := cmd.(type) {
@@ -70,6 +71,11 @@ func alterBackupPlanHook(
 			if err != nil {
 				return nil, nil, nil, false, err
 			}
+		case *tree.AlterBackupSetOptions:
+			setOptsFn, err = p.TypeAsStringOpts(ctx, v.Options, alterBackupSetOptionExpectValues)
+			if err != nil {
+				return nil, nil, nil, false, err
+			}
 		}
 	}
 
@@ -109,19 +115,34 @@ func alterBackupPlanHook(
 			}
 		}
 
-		var newKms []string
-		newKms, err = newKmsFn()
-		if err != nil {
-			return err
-		}
+		if newKmsFn != nil {
+			var newKms []string
+			newKms, err = newKmsFn()
+			if err != nil {
+				return err
+			}
 
-		var oldKms []string
-		oldKms, err = oldKmsFn()
-		if err != nil {
-			return err
+			var oldKms []string
+			oldKms, err = oldKmsFn()
+			if err != nil {
+				return err
+			}
+
+			if err := doAlterBackupPlan(ctx, alterBackupStmt, p, backup, newKms, oldKms); err != nil {
+				return err
+			}
 		}
 
-		return doAlterBackupPlan(ctx, alterBackupStmt, p, backup, newKms, oldKms)
+		if setOptsFn != nil {
+			opts, err := setOptsFn()
+			if err != nil {
+				return err
+			}
+			if err := alterBackupRateLimits(ctx, p, backup, opts); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
 	return fn, nil, nil, false, nil
@@ -0,0 +1,220 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/featureflag"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	alterBackupOptReadRateLimit   = "read_rate_limit"
+	alterBackupOptWriteRateLimit  = "write_rate_limit"
+	alterBackupOptIngestRateLimit = "ingest_rate_limit"
+)
+
+var alterBackupSetOptionExpectValues = map[string]sql.KVStringOptValidate{
+	alterBackupOptReadRateLimit:   sql.KVStringOptRequireValue,
+	alterBackupOptWriteRateLimit:  sql.KVStringOptRequireValue,
+	alterBackupOptIngestRateLimit: sql.KVStringOptRequireValue,
+}
+
+// alterBackupRateLimits sets the rate limits given by the options of ALTER
+// BACKUP ... SET on every BACKUP job that is writing to the backup at uri, or
+// to a backup under it, and on every RESTORE job that is reading from it. The
+// read and write limits apply to the backups and the ingest limit to the
+// restores. The processors of the jobs pick up the new limits the next time
+// they reload their job.
+func alterBackupRateLimits(
+	ctx context.Context, p sql.PlanHookState, uri string, opts map[string]string,
+) error {
+	limits, err := parseRateLimitOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	txn := p.ExtendedEvalContext().Txn
+	rows, err := p.ExecCfg().InternalExecutor.QueryBufferedEx(
+		ctx, "alter-backup-find-jobs", txn,
+		sessiondata.InternalExecutorOverride{User: security.NodeUserName()},
+		`SELECT job_id FROM crdb_internal.jobs WHERE job_type IN ($1, $2) AND status IN ($3, $4, $5, $6)`,
+		jobspb.TypeBackup.String(), jobspb.TypeRestore.String(),
+		string(jobs.StatusPending), string(jobs.StatusRunning),
+		string(jobs.StatusPaused), string(jobs.StatusPauseRequested),
+	)
+	if err != nil {
+		return errors.Wrap(err, "finding the jobs of the backup")
+	}
+
+	var altered int
+	for _, row := range rows {
+		jobID := jobspb.JobID(tree.MustBeDInt(row[0]))
+		job, err := p.ExecCfg().JobRegistry.LoadJobWithTxn(ctx, jobID, txn)
+		if err != nil {
+			return errors.Wrapf(err, "could not load job with job id %d", jobID)
+		}
+
+		var jobLimits jobspb.BulkIORateLimits
+		var uses bool
+		switch details := job.Details().(type) {
+		case jobspb.BackupDetails:
+			uses = backupLocationContains(uri, details.URI)
+			jobLimits = details.RateLimits
+		case jobspb.RestoreDetails:
+			for _, u := range details.URIs {
+				uses = uses || backupLocationContains(uri, u)
+			}
+			jobLimits = details.RateLimits
+		}
+		if !uses || !applyRateLimitOptions(&jobLimits, job.Payload().Type(), limits) {
+			continue
+		}
+
+		feature := featureBackupEnabled
+		if job.Payload().Type() == jobspb.TypeRestore {
+			feature = featureRestoreEnabled
+		}
+		if err := featureflag.CheckEnabled(ctx, p.ExecCfg(), feature, "ALTER BACKUP"); err != nil {
+			return err
+		}
+		if err := checkControlJobPrivilege(ctx, p, job); err != nil {
+			return err
+		}
+
+		if err := p.ExecCfg().JobRegistry.UpdateJobWithTxn(ctx, jobID, txn, false, /* useReadLock */
+			func(txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+				if md.Status.Terminal() {
+					return nil
+				}
+				switch {
+				case md.Payload.GetBackup() != nil:
+					md.Payload.GetBackup().RateLimits = jobLimits
+				case md.Payload.GetRestore() != nil:
+					md.Payload.GetRestore().RateLimits = jobLimits
+				}
+				ju.UpdatePayload(md.Payload)
+				return nil
+			}); err != nil {
+			return err
+		}
+		altered++
+	}
+
+	if altered == 0 {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"no running BACKUP or RESTORE job to which the options apply uses %s",
+			RedactURIForErrorMessage(uri))
+	}
+	return nil
+}
+
+// parseRateLimitOptions parses the byte sizes per second given by the options
+// of ALTER BACKUP ... SET, where 0 removes a limit.
+func parseRateLimitOptions(opts map[string]string) (map[string]int64, error) {
+	limits := make(map[string]int64, len(opts))
+	for opt, value := range opts {
+		bytes, err := humanizeutil.ParseBytes(value)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid value for %q", opt)
+		}
+		if bytes < 0 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"%q cannot be negative, got %s", opt, value)
+		}
+		limits[opt] = bytes
+	}
+	return limits, nil
+}
+
+// applyRateLimitOptions sets those of the given limits that apply to a job of
+// type typ, and returns whether any of them did.
+func applyRateLimitOptions(
+	jobLimits *jobspb.BulkIORateLimits, typ jobspb.Type, limits map[string]int64,
+) bool {
+	var applied bool
+	for opt, bytes := range limits {
+		switch {
+		case opt == alterBackupOptReadRateLimit && typ == jobspb.TypeBackup:
+			jobLimits.ReadBytesPerSecond = bytes
+		case opt == alterBackupOptWriteRateLimit && typ == jobspb.TypeBackup:
+			jobLimits.WriteBytesPerSecond = bytes
+		case opt == alterBackupOptIngestRateLimit && typ == jobspb.TypeRestore:
+			jobLimits.IngestBytesPerSecond = bytes
+		default:
+			continue
+		}
+		applied = true
+	}
+	return applied
+}
+
+// backupLocationContains returns whether the location uri is, or is a parent
+// of, the location of a job. Query parameters are ignored, since they hold
+// credentials and options that need not be given the same way each time.
+func backupLocationContains(location, uri string) bool {
+	l, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	if l.Scheme != u.Scheme || l.Host != u.Host {
+		return false
+	}
+	lPath, uPath := path.Clean("/"+l.Path), path.Clean("/"+u.Path)
+	return lPath == uPath || strings.HasPrefix(uPath, strings.TrimSuffix(lPath, "/")+"/")
+}
+
+// checkControlJobPrivilege checks that the user may control the job, as for
+// PAUSE JOB: admins may alter any job, and users with the CONTROLJOB role
+// option may alter the jobs of non-admin users.
+func checkControlJobPrivilege(ctx context.Context, p sql.PlanHookState, job *jobs.Job) error {
+	userIsAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if userIsAdmin {
+		return nil
+	}
+	hasControlJob, err := p.HasRoleOption(ctx, roleoption.CONTROLJOB)
+	if err != nil {
+		return err
+	}
+	if !hasControlJob {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"user %s does not have %s privilege", p.User(), roleoption.CONTROLJOB)
+	}
+	ownerIsAdmin, err := p.UserHasAdminRole(ctx, job.Payload().UsernameProto.Decode())
+	if err != nil {
+		return err
+	}
+	if ownerIsAdmin {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only admins can control jobs owned by other admins")
+	}
+	return nil
+}
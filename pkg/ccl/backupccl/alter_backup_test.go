@@ -209,3 +209,26 @@ func TestAlterBackupIncremental(t *testing.T) {
 	sqlDB.Exec(t, query)
 	sqlDB.ExecRowsAffected(t, 2, "SELECT * FROM bank")
 }
+
+func TestBackupLocationContains(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		location, uri string
+		expected      bool
+	}{
+		{"nodelocal://0/foo", "nodelocal://0/foo", true},
+		{"nodelocal://0/foo/", "nodelocal://0/foo", true},
+		{"nodelocal://0/foo", "nodelocal://0/foo/2022/01/01-120000.00", true},
+		{"s3://bucket/foo?AUTH=implicit", "s3://bucket/foo/bar?AWS_ACCESS_KEY_ID=x", true},
+		{"nodelocal://0/", "nodelocal://0/foo", true},
+		{"nodelocal://0/foo", "nodelocal://0/foobar", false},
+		{"nodelocal://0/foo/bar", "nodelocal://0/foo", false},
+		{"nodelocal://1/foo", "nodelocal://0/foo", false},
+		{"s3://bucket/foo", "gs://bucket/foo", false},
+	} {
+		require.Equal(t, tc.expected, backupLocationContains(tc.location, tc.uri),
+			"%s contains %s", tc.location, tc.uri)
+	}
+}
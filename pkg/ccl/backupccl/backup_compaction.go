@@ -0,0 +1,856 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// compactedBackupSuffix is appended to the name of the directory a compacted
+// backup is written to, so that it cannot collide with a regular full backup
+// taken at the same end time.
+const compactedBackupSuffix = "-compacted"
+
+// compactBackupChain runs a backup job with the compact option. It merges the
+// full backup in details.Destination.Subdir and the incremental backups
+// appended to it, up to the last one that ends at or before the job's end
+// time, into a new full backup in the same collection. For every key, the new
+// backup only keeps the latest revision as of its end time, so restoring it is
+// equivalent to restoring the chain at that time while reading a single layer.
+func (b *backupResumer) compactBackupChain(ctx context.Context, p sql.JobExecContext) error {
+	details := b.job.Details().(jobspb.BackupDetails)
+	execCfg := p.ExecCfg()
+	user := p.User()
+
+	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+
+	// If this is the first time the job runs, resolve the chain to compact and
+	// the directory of the compacted backup, and persist them so that resuming
+	// the job compacts the same layers into the same directory.
+	if details.URI == "" {
+		initialDetails := details
+		var err error
+		details, err = resolveBackupCompaction(ctx, &mem, execCfg, user, details)
+		if err != nil {
+			return err
+		}
+
+		// Only check that the directory is unused before we claim it: once the
+		// details are persisted, a resumed job finds the files it wrote itself.
+		if err := func() error {
+			defaultStore, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, details.URI, user)
+			if err != nil {
+				return errors.Wrapf(err, "make storage")
+			}
+			defer defaultStore.Close()
+			return checkForPreviousBackup(ctx, defaultStore, details.URI)
+		}(); err != nil {
+			return err
+		}
+
+		// As in Resume, replace an unresolved LATEST in the description with the
+		// chain it resolved to.
+		description := b.job.Payload().Description
+		const unresolvedText = "INTO 'LATEST' IN"
+		if initialDetails.Destination.Subdir == latestFileName && strings.Count(description, unresolvedText) == 1 {
+			description = strings.ReplaceAll(description, unresolvedText, fmt.Sprintf("INTO '%s' IN", details.Destination.Subdir))
+		}
+		if err := b.job.Update(ctx, nil, func(txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			md.Payload.Details = jobspb.WrapPayloadDetails(details)
+			md.Payload.Description = description
+			ju.UpdatePayload(md.Payload)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	chainURIs, err := backupChainURIs(details.Destination.To, details.Destination.Subdir)
+	if err != nil {
+		return err
+	}
+	layerURIs, manifests, localityInfo, wholeChain, memSize, err := readBackupChainForCompaction(
+		ctx, &mem, execCfg, user, details, chainURIs,
+	)
+	if err != nil {
+		return err
+	}
+	defer mem.Shrink(ctx, memSize)
+
+	defaultStore, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, details.URI, user)
+	if err != nil {
+		return errors.Wrapf(err, "make storage")
+	}
+	defer defaultStore.Close()
+
+	// A job that is resumed after it wrote the manifest of the compacted backup
+	// only has to replace LATEST with it. Otherwise, it writes the compacted
+	// backup from scratch, overwriting the files it wrote before it was resumed.
+	backupManifest, manifestSize, err := readBackupManifest(
+		ctx, &mem, defaultStore, backupManifestName, details.EncryptionOptions,
+	)
+	if err != nil {
+		if !errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return errors.Wrapf(err, "reading compacted backup manifest")
+		}
+		backupManifest, err = b.writeCompactedBackup(
+			ctx, execCfg, user, details, defaultStore, chainURIs, layerURIs, manifests, localityInfo,
+		)
+		if err != nil {
+			return err
+		}
+	} else {
+		defer mem.Shrink(ctx, manifestSize)
+	}
+	if err := execCfg.JobRegistry.CheckPausepoint("backup_compaction.after_write_manifest"); err != nil {
+		return err
+	}
+
+	// If the chain we compacted is still the latest one in the collection and
+	// nothing was appended to it past what we compacted, the compacted backup
+	// takes its place: later incremental backups are appended to it, and
+	// restores from LATEST read it rather than the chain.
+	if wholeChain {
+		if err := maybeReplaceLatestWithCompactedBackup(ctx, execCfg, user, details, len(manifests)); err != nil {
+			return err
+		}
+	}
+
+	b.backupStats = backupManifest.EntryCounts
+	telemetry.Count("backup.total.compacted")
+
+	return b.maybeNotifyScheduledJobCompletion(ctx, jobs.StatusSucceeded, execCfg)
+}
+
+// writeCompactedBackup writes the compacted backup of the given layers of the
+// chain at chainURIs to details.URI, and returns its manifest.
+func (b *backupResumer) writeCompactedBackup(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user security.SQLUsername,
+	details jobspb.BackupDetails,
+	defaultStore cloud.ExternalStorage,
+	chainURIs []string,
+	layerURIs []string,
+	manifests []BackupManifest,
+	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo,
+) (BackupManifest, error) {
+	encryption := details.EncryptionOptions
+	var fileEncryption *roachpb.FileEncryptionOptions
+	if encryption != nil {
+		// The compacted backup reuses the encryption of the chain, so it needs the
+		// same ENCRYPTION-INFO files to be decrypted by the same passphrase or KMS.
+		if err := func() error {
+			chainStore, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, chainURIs[0], user)
+			if err != nil {
+				return err
+			}
+			defer chainStore.Close()
+			return copyEncryptionInfo(ctx, chainStore, defaultStore)
+		}(); err != nil {
+			return BackupManifest{}, errors.Wrapf(err, "copying encryption info to %s", RedactURIForErrorMessage(details.URI))
+		}
+		key, err := getEncryptionKey(ctx, encryption, execCfg.Settings, defaultStore.ExternalIOConf())
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		fileEncryption = &roachpb.FileEncryptionOptions{Key: key}
+	}
+
+	backupManifest := makeCompactedBackupManifest(ctx, execCfg.Settings, manifests)
+
+	layerToStoreByLocalityKV, err := makeBackupLocalityMap(localityInfo, user)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	cover := makeSimpleImportSpans(backupManifest.Spans, manifests, layerToStoreByLocalityKV, nil /* lowWaterMark */)
+
+	// Files that were written to the store of a locality are written to the
+	// store of the same locality in the compacted backup.
+	localityByDir := make(map[string]string)
+	for _, storesByLocalityKV := range layerToStoreByLocalityKV {
+		for kv, conf := range storesByLocalityKV {
+			localityByDir[conf.String()] = kv
+		}
+	}
+
+	// The files of all sinks are numbered in the order they are opened, which
+	// only depends on the chain, so that their names are unique across the
+	// stores of all localities, and a resumed job overwrites the files it
+	// opened before it was resumed rather than leaving them behind.
+	names := &compactionFileNames{jobID: b.job.ID()}
+	storageByLocalityKV := make(map[string]*roachpb.ExternalStorage)
+	sinks := map[string]*compactionSink{
+		"": {settings: execCfg.Settings, dest: defaultStore, enc: fileEncryption, names: names},
+	}
+	defer func() {
+		for _, s := range sinks {
+			s.Close()
+		}
+	}()
+	for kv, uri := range details.URIsByLocalityKV {
+		conf, err := cloud.ExternalStorageConfFromURI(uri, user)
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		storageByLocalityKV[kv] = &conf
+		store, err := execCfg.DistSQLSrv.ExternalStorage(ctx, conf)
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		defer store.Close()
+		sinks[kv] = &compactionSink{
+			settings: execCfg.Settings, dest: store, localityKV: kv, enc: fileEncryption, names: names,
+		}
+	}
+
+	pkIDs := make(map[uint64]bool)
+	for i := range backupManifest.Descriptors {
+		if t, _, _, _ := descpb.FromDescriptor(&backupManifest.Descriptors[i]); t != nil {
+			pkIDs[roachpb.BulkOpSummaryID(uint64(t.ID), uint64(t.PrimaryIndex.ID))] = true
+		}
+	}
+
+	lastProgress := timeutil.Now()
+	for i, entry := range cover {
+		sink, ok := sinks[entryLocalityKV(entry, localityByDir)]
+		if !ok {
+			sink = sinks[""]
+		}
+		f, ok, err := sink.writeEntry(ctx, execCfg, entry, backupManifest.EndTime, fileEncryption, pkIDs)
+		if err != nil {
+			return BackupManifest{}, errors.Wrapf(err, "compacting span %s", entry.Span)
+		}
+		if ok {
+			// As in the backup processor, a span that picks up in the same file
+			// where the last one ended extends its entry rather than adding one.
+			if l := len(backupManifest.Files) - 1; l >= 0 && backupManifest.Files[l].Path == f.Path &&
+				backupManifest.Files[l].LocalityKV == f.LocalityKV &&
+				backupManifest.Files[l].Span.EndKey.Equal(f.Span.Key) {
+				backupManifest.Files[l].Span.EndKey = f.Span.EndKey
+				backupManifest.Files[l].EntryCounts.Add(f.EntryCounts)
+			} else {
+				backupManifest.Files = append(backupManifest.Files, f)
+			}
+			backupManifest.EntryCounts.Add(f.EntryCounts)
+		}
+
+		if timeutil.Since(lastProgress) > BackupCheckpointInterval {
+			lastProgress = timeutil.Now()
+			if err := b.job.FractionProgressed(ctx, nil, /* txn */
+				jobs.FractionUpdater(float32(i+1)/float32(len(cover))),
+			); err != nil {
+				log.Warningf(ctx, "failed to update compaction progress: %+v", err)
+			}
+		}
+	}
+	for _, s := range sinks {
+		if err := s.flush(ctx); err != nil {
+			return BackupManifest{}, err
+		}
+	}
+
+	backupManifest.ID = uuid.MakeV4()
+	if len(storageByLocalityKV) > 0 {
+		if err := writeBackupPartitionDescriptors(
+			ctx, &backupManifest, storageByLocalityKV, execCfg.DistSQLSrv.ExternalStorage, encryption,
+		); err != nil {
+			return BackupManifest{}, err
+		}
+	}
+	// The statistics are written before the manifest, since a resumed job that
+	// finds the manifest does not write the compacted backup again.
+	if err := copyTableStatistics(
+		ctx, execCfg, user, layerURIs[len(layerURIs)-1], defaultStore, encryption, backupManifest,
+	); err != nil {
+		return BackupManifest{}, err
+	}
+	if err := writeBackupManifest(
+		ctx, execCfg.Settings, defaultStore, backupManifestName, encryption, &backupManifest,
+	); err != nil {
+		return BackupManifest{}, err
+	}
+	return backupManifest, nil
+}
+
+// resolveBackupCompaction resolves the backup chain a compaction job compacts,
+// the end time of the compacted backup and the directory to write it to.
+func resolveBackupCompaction(
+	ctx context.Context,
+	mem *mon.BoundAccount,
+	execCfg *sql.ExecutorConfig,
+	user security.SQLUsername,
+	details jobspb.BackupDetails,
+) (jobspb.BackupDetails, error) {
+	makeCloudStorage := execCfg.DistSQLSrv.ExternalStorageFromURI
+
+	collectionURI, _, err := getURIsByLocalityKV(details.Destination.To, "")
+	if err != nil {
+		return jobspb.BackupDetails{}, err
+	}
+	if details.Destination.Subdir == latestFileName {
+		latest, err := readLatestFile(ctx, collectionURI, makeCloudStorage, user)
+		if err != nil {
+			return jobspb.BackupDetails{}, err
+		}
+		details.Destination.Subdir = latest
+	}
+	chainURIs, err := backupChainURIs(details.Destination.To, details.Destination.Subdir)
+	if err != nil {
+		return jobspb.BackupDetails{}, err
+	}
+
+	kmsEnv := &backupKMSEnv{settings: execCfg.Settings, conf: &execCfg.ExternalIODirConfig}
+	details.EncryptionOptions, err = getEncryptionFromBase(ctx, user, makeCloudStorage, chainURIs[0],
+		*details.EncryptionOptions, kmsEnv)
+	if err != nil {
+		return jobspb.BackupDetails{}, err
+	}
+
+	_, manifests, _, _, memSize, err := readBackupChainForCompaction(
+		ctx, mem, execCfg, user, details, chainURIs,
+	)
+	if err != nil {
+		return jobspb.BackupDetails{}, err
+	}
+	defer mem.Shrink(ctx, memSize)
+
+	clusterID := execCfg.ClusterID()
+	for i := range manifests {
+		if fromCluster := manifests[i].ClusterID; !fromCluster.Equal(clusterID) {
+			return jobspb.BackupDetails{}, errors.Newf("cannot compact a BACKUP belonging to cluster %s", fromCluster.String())
+		}
+	}
+
+	details.EndTime = manifests[len(manifests)-1].EndTime
+	compactedSubdir := details.EndTime.GoTime().Format(DateBasedIntoFolderName) + compactedBackupSuffix
+	details.URI, details.URIsByLocalityKV, err = getURIsByLocalityKV(details.Destination.To, compactedSubdir)
+	if err != nil {
+		return jobspb.BackupDetails{}, err
+	}
+	details.CollectionURI = collectionURI
+	return details, nil
+}
+
+// countIncrementalBackups returns the number of incremental backups appended
+// to the full backup in subdir of the collection at to, which are stored in
+// incrementalStorage if it is not empty.
+func countIncrementalBackups(
+	ctx context.Context,
+	makeCloudStorage cloud.ExternalStorageFromURIFactory,
+	user security.SQLUsername,
+	to []string,
+	incrementalStorage []string,
+	subdir string,
+) (int, error) {
+	chainBase := to
+	if len(incrementalStorage) > 0 {
+		chainBase = incrementalStorage
+	}
+	chainURI, _, err := getURIsByLocalityKV(chainBase, subdir)
+	if err != nil {
+		return 0, err
+	}
+	store, err := makeCloudStorage(ctx, chainURI, user)
+	if err != nil {
+		return 0, err
+	}
+	defer store.Close()
+	priors, err := FindPriorBackups(ctx, store, OmitManifest)
+	if err != nil {
+		return 0, err
+	}
+	return len(priors), nil
+}
+
+// backupChainURIs returns the URI of each partition of the backup in subdir of
+// the collection whose partitions are at collectionURIs. The URI of the
+// partition in the default locality comes first.
+func backupChainURIs(collectionURIs []string, subdir string) ([]string, error) {
+	uris := make([]string, 0, len(collectionURIs))
+	for _, uri := range collectionURIs {
+		localityKV, _, err := getLocalityAndBaseURI(uri, "")
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return nil, err
+		}
+		parsed.Path = path.Join(parsed.Path, subdir)
+		if localityKV == "" || localityKV == defaultLocalityValue {
+			uris = append([]string{parsed.String()}, uris...)
+		} else {
+			uris = append(uris, parsed.String())
+		}
+	}
+	return uris, nil
+}
+
+// readBackupChainForCompaction reads the manifests of the layers of the backup
+// chain at chainURIs that end at or before details.EndTime, or of all of them
+// if the end time is empty. It also returns the default URI and the locality
+// info of each of these layers, and whether they are the whole chain.
+func readBackupChainForCompaction(
+	ctx context.Context,
+	mem *mon.BoundAccount,
+	execCfg *sql.ExecutorConfig,
+	user security.SQLUsername,
+	details jobspb.BackupDetails,
+	chainURIs []string,
+) (
+	layerURIs []string,
+	manifests []BackupManifest,
+	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo,
+	wholeChain bool,
+	reservedMemSize int64,
+	_ error,
+) {
+	baseStores := make([]cloud.ExternalStorage, len(chainURIs))
+	for i := range chainURIs {
+		store, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, chainURIs[i], user)
+		if err != nil {
+			return nil, nil, nil, false, 0, errors.Wrapf(err, "failed to open backup storage location")
+		}
+		defer store.Close()
+		baseStores[i] = store
+	}
+
+	var incFrom []string
+	if len(details.Destination.IncrementalStorage) > 0 {
+		var err error
+		incFrom, err = backupChainURIs(details.Destination.IncrementalStorage, details.Destination.Subdir)
+		if err != nil {
+			return nil, nil, nil, false, 0, err
+		}
+	}
+
+	// Layers are only ever compacted whole, so the chain is resolved without an
+	// end time and truncated below, rather than requiring that the end time
+	// falls on the end of a layer or within its revision history.
+	layerURIs, manifests, localityInfo, memSize, err := resolveBackupManifests(
+		ctx, mem, baseStores, execCfg.DistSQLSrv.ExternalStorageFromURI, [][]string{chainURIs},
+		incFrom, hlc.Timestamp{}, details.EncryptionOptions, user,
+	)
+	if err != nil {
+		return nil, nil, nil, false, 0, err
+	}
+
+	n := len(manifests)
+	if endTime := details.EndTime; !endTime.IsEmpty() {
+		for n > 0 && endTime.Less(manifests[n-1].EndTime) {
+			n--
+		}
+		if n == 0 {
+			mem.Shrink(ctx, memSize)
+			return nil, nil, nil, false, 0, errors.Errorf(
+				"no backup in %s ends at or before %s", details.Destination.Subdir,
+				timeutil.Unix(0, endTime.WallTime).UTC(),
+			)
+		}
+	}
+	return layerURIs[:n], manifests[:n], localityInfo[:n], n == len(manifests), memSize, nil
+}
+
+// makeCompactedBackupManifest returns the manifest, without any files, of the
+// full backup that compacts the given chain of backups.
+func makeCompactedBackupManifest(
+	ctx context.Context, settings *cluster.Settings, manifests []BackupManifest,
+) BackupManifest {
+	last := manifests[len(manifests)-1]
+	descs, _ := loadSQLDescsFromBackupsAtTime(manifests, last.EndTime)
+	descriptorProtos := make([]descpb.Descriptor, len(descs))
+	for i, desc := range descs {
+		descriptorProtos[i] = *desc.DescriptorProto()
+	}
+
+	return BackupManifest{
+		EndTime:             last.EndTime,
+		MVCCFilter:          MVCCFilter_Latest,
+		Descriptors:         descriptorProtos,
+		Tenants:             last.Tenants,
+		TenantsDeprecated:   last.TenantsDeprecated,
+		CompleteDbs:         last.CompleteDbs,
+		Spans:               last.Spans,
+		FormatVersion:       BackupFormatDescriptorTrackingVersion,
+		BuildInfo:           build.GetInfo(),
+		ClusterVersion:      settings.Version.ActiveVersion(ctx).Version,
+		ClusterID:           last.ClusterID,
+		StatisticsFilenames: last.StatisticsFilenames,
+		DescriptorCoverage:  last.DescriptorCoverage,
+	}
+}
+
+// entryLocalityKV returns the locality whose store holds every file of entry,
+// or the empty string if they are not all in the store of one locality.
+func entryLocalityKV(entry execinfrapb.RestoreSpanEntry, localityByDir map[string]string) string {
+	var localityKV string
+	for i, f := range entry.Files {
+		kv := localityByDir[f.Dir.String()]
+		if i > 0 && kv != localityKV {
+			return ""
+		}
+		localityKV = kv
+	}
+	return localityKV
+}
+
+// compactionFileNames names the SSTs of a compacted backup.
+type compactionFileNames struct {
+	jobID jobspb.JobID
+	seq   int
+}
+
+// next returns the name of the next SST of the compacted backup.
+func (n *compactionFileNames) next() string {
+	n.seq++
+	return fmt.Sprintf("data/%d-%d.sst", n.jobID, n.seq)
+}
+
+// compactionSink writes the compacted data of consecutive spans to SSTs in one
+// of the stores of a compacted backup, starting a new file once the current
+// one reaches the target backup file size.
+type compactionSink struct {
+	settings   *cluster.Settings
+	dest       cloud.ExternalStorage
+	localityKV string
+	enc        *roachpb.FileEncryptionOptions
+	names      *compactionFileNames
+
+	ctx     context.Context
+	cancel  func()
+	out     io.WriteCloser
+	outName string
+	sst     storage.SSTWriter
+}
+
+func (s *compactionSink) open(ctx context.Context) error {
+	s.outName = s.names.next()
+	// The writer is created with its own context so that Close can cancel it,
+	// and thus abandon the file, if the sink is closed before it is flushed.
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	w, err := s.dest.Writer(s.ctx, s.outName)
+	if err != nil {
+		return err
+	}
+	if s.enc != nil {
+		w, err = storageccl.EncryptingWriter(w, s.enc.Key)
+		if err != nil {
+			return err
+		}
+	}
+	s.out = w
+	s.sst = storage.MakeBackupSSTWriter(ctx, s.settings, s.out)
+	return nil
+}
+
+// writeEntry writes, for each key in the span of entry, the latest revision in
+// the files of entry as of endTime, unless that revision is a deletion, either
+// by a point tombstone or by an MVCC range tombstone. It returns the manifest
+// file describing what it wrote, if it wrote anything.
+//
+// The compacted backup is a full backup, which restores into empty spans, so
+// the range tombstones themselves are not written to it.
+func (s *compactionSink) writeEntry(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	entry execinfrapb.RestoreSpanEntry,
+	endTime hlc.Timestamp,
+	encryption *roachpb.FileEncryptionOptions,
+	pkIDs map[uint64]bool,
+) (BackupManifest_File, bool, error) {
+	var iters []storage.SimpleMVCCIterator
+	defer func() {
+		for _, iter := range iters {
+			iter.Close()
+		}
+	}()
+	// Incremental backups export the range tombstones in their time bounds to
+	// their files, where they are not surfaced by the point key iterators.
+	var rangeTombstones []*storage.MVCCRangeTombstones
+	for _, file := range entry.Files {
+		dir, err := execCfg.DistSQLSrv.ExternalStorage(ctx, file.Dir)
+		if err != nil {
+			return BackupManifest_File{}, false, err
+		}
+		defer dir.Close()
+		iter, err := storageccl.ExternalSSTReader(ctx, dir, file.Path, encryption)
+		if err != nil {
+			return BackupManifest_File{}, false, err
+		}
+		iters = append(iters, iter)
+		t, err := storage.ReadSSTRangeTombstones(iter, entry.Span.Key, entry.Span.EndKey)
+		if err != nil {
+			return BackupManifest_File{}, false, errors.Wrapf(err, "reading range tombstones of %s", file.Path)
+		}
+		if !t.Empty() {
+			rangeTombstones = append(rangeTombstones, t)
+		}
+	}
+	iter := storage.MakeMultiIterator(iters)
+	defer iter.Close()
+
+	var rows storage.RowCounter
+	startKeyMVCC, endKeyMVCC := storage.MVCCKey{Key: entry.Span.Key},
+		storage.MVCCKey{Key: entry.Span.EndKey}
+	for iter.SeekGE(startKeyMVCC); ; {
+		ok, err := iter.Valid()
+		if err != nil {
+			return BackupManifest_File{}, false, err
+		}
+		if !ok || !iter.UnsafeKey().Less(endKeyMVCC) {
+			break
+		}
+		key := iter.UnsafeKey()
+		if endTime.Less(key.Timestamp) {
+			iter.Next()
+			continue
+		}
+		value := iter.UnsafeValue()
+		if len(value) == 0 || deletedByRangeTombstone(rangeTombstones, key, endTime) {
+			// The key was deleted as of endTime.
+			iter.NextKey()
+			continue
+		}
+
+		if s.out == nil {
+			if err := s.open(ctx); err != nil {
+				return BackupManifest_File{}, false, err
+			}
+		}
+		if key.Timestamp.IsEmpty() {
+			err = s.sst.PutUnversioned(key.Key, value)
+		} else {
+			err = s.sst.PutMVCC(key, value)
+		}
+		if err != nil {
+			return BackupManifest_File{}, false, err
+		}
+		if err := rows.Count(key.Key); err != nil {
+			return BackupManifest_File{}, false, err
+		}
+		rows.DataSize += int64(len(key.Key)) + int64(len(value))
+		iter.NextKey()
+	}
+
+	if rows.DataSize == 0 {
+		return BackupManifest_File{}, false, nil
+	}
+	f := BackupManifest_File{
+		Span:        entry.Span,
+		Path:        s.outName,
+		EntryCounts: countRows(rows.BulkOpSummary, pkIDs),
+		LocalityKV:  s.localityKV,
+	}
+	if s.sst.DataSize >= targetFileSize.Get(&s.settings.SV) {
+		if err := s.flush(ctx); err != nil {
+			return BackupManifest_File{}, false, err
+		}
+	}
+	return f, true, nil
+}
+
+// deletedByRangeTombstone returns whether the given version of a key was
+// deleted by any of the given range tombstones as of endTime.
+func deletedByRangeTombstone(
+	rangeTombstones []*storage.MVCCRangeTombstones, key storage.MVCCKey, endTime hlc.Timestamp,
+) bool {
+	if key.Timestamp.IsEmpty() {
+		return false
+	}
+	for _, t := range rangeTombstones {
+		if ts, ok := t.DeletedAt(key.Key, key.Timestamp); ok && ts.LessEq(endTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// flush finishes the file currently being written, if any.
+func (s *compactionSink) flush(ctx context.Context) error {
+	if s.out == nil {
+		return nil
+	}
+	if err := s.sst.Finish(); err != nil {
+		return err
+	}
+	if err := s.out.Close(); err != nil {
+		return errors.Wrap(err, "writing SST")
+	}
+	s.out = nil
+	s.cancel()
+	return nil
+}
+
+// Close abandons the file currently being written, if any.
+func (s *compactionSink) Close() {
+	if s.out == nil {
+		return
+	}
+	s.cancel()
+	s.sst.Close()
+	_ = s.out.Close()
+	s.out = nil
+}
+
+// copyEncryptionInfo copies the ENCRYPTION-INFO files of the backup in src to
+// the backup in dest.
+func copyEncryptionInfo(ctx context.Context, src, dest cloud.ExternalStorage) error {
+	files, err := getEncryptionInfoFiles(ctx, src)
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for i, file := range files {
+		if i > 0 && files[i-1] == file {
+			continue
+		}
+		buf, err := func() ([]byte, error) {
+			r, err := src.ReadFile(ctx, file)
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close(ctx)
+			return ioctx.ReadAll(ctx, r)
+		}()
+		if err != nil {
+			return err
+		}
+		if err := cloud.WriteFile(ctx, dest, file, bytes.NewReader(buf)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTableStatistics copies the table statistics of the backup at srcURI,
+// the last layer of the compacted chain, to the compacted backup.
+func copyTableStatistics(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user security.SQLUsername,
+	srcURI string,
+	dest cloud.ExternalStorage,
+	encryption *jobspb.BackupEncryptionOptions,
+	backupManifest BackupManifest,
+) error {
+	src, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, srcURI, user)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	copied := make(map[string]struct{})
+	for _, filename := range backupManifest.StatisticsFilenames {
+		if _, ok := copied[filename]; ok {
+			continue
+		}
+		copied[filename] = struct{}{}
+		statsTable, err := readTableStatistics(ctx, src, filename, encryption)
+		if err != nil {
+			// As when backing up, statistics can be recomputed after a restore, so
+			// failing to carry them over should not fail the compaction.
+			log.Warningf(ctx, "failed to read table statistics %s of compacted backup: %v", filename, err)
+			continue
+		}
+		if err := writeTableStatistics(ctx, dest, filename, encryption, statsTable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maybeReplaceLatestWithCompactedBackup points the LATEST file of the
+// collection at the compacted backup if it still points at the chain that was
+// compacted, and no backup was appended to the chain since it was compacted,
+// given the number of layers of the chain that were compacted.
+//
+// Scheduled compactions never run concurrently with the backups of their
+// schedule, but a backup started by hand may be appended to the chain while it
+// is compacted. Since the chain is not compacted whole anymore, LATEST is left
+// pointing at it so that the backup is not orphaned.
+func maybeReplaceLatestWithCompactedBackup(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	user security.SQLUsername,
+	details jobspb.BackupDetails,
+	numLayers int,
+) error {
+	makeCloudStorage := execCfg.DistSQLSrv.ExternalStorageFromURI
+	latest, err := readLatestFile(ctx, details.CollectionURI, makeCloudStorage, user)
+	if err != nil {
+		return err
+	}
+	if path.Clean("/"+latest) != path.Clean("/"+details.Destination.Subdir) {
+		log.Infof(ctx, "not replacing LATEST with compacted backup: it now points to %s", latest)
+		return nil
+	}
+	numIncrementals, err := countIncrementalBackups(
+		ctx, makeCloudStorage, user, details.Destination.To, details.Destination.IncrementalStorage,
+		details.Destination.Subdir,
+	)
+	if err != nil {
+		return err
+	}
+	if numIncrementals+1 != numLayers {
+		log.Infof(ctx, "not replacing LATEST with compacted backup: %d backups were appended to %s since it was compacted",
+			numIncrementals+1-numLayers, latest)
+		return nil
+	}
+
+	backupURI, err := url.Parse(details.URI)
+	if err != nil {
+		return err
+	}
+	collectionURI, err := url.Parse(details.CollectionURI)
+	if err != nil {
+		return err
+	}
+	suffix := strings.TrimPrefix(path.Clean(backupURI.Path), path.Clean(collectionURI.Path))
+
+	c, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, details.CollectionURI, user)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	return writeNewLatestFile(ctx, execCfg.Settings, c, suffix)
+}
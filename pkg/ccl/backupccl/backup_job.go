@@ -99,7 +99,8 @@ func clusterNodeCount(gw gossip.OptionalGossip) (int, error) {
 	return nodes, nil
 }
 
-// backup exports a snapshot of every kv entry into ranged sstables.
+// backup exports a snapshot of every kv entry into ranged sstables, which are
+// written to each of the mirrorURIs as well.
 //
 // The output is an sstable per range with files in the following locations:
 // - <dir>/<unique_int>.sst
@@ -111,6 +112,7 @@ func backup(
 	execCtx sql.JobExecContext,
 	defaultURI string,
 	urisByLocalityKV map[string]string,
+	mirrorURIs []string,
 	db *kv.DB,
 	settings *cluster.Settings,
 	defaultStore cloud.ExternalStorage,
@@ -153,9 +155,9 @@ func backup(
 	evalCtx := execCtx.ExtendedEvalContext()
 	dsp := execCtx.DistSQLPlanner()
 
-	// We don't return the compatible nodes here since PartitionSpans will
-	// filter out incompatible nodes.
-	planCtx, _, err := dsp.SetupAllNodesPlanning(ctx, evalCtx, execCtx.ExecCfg())
+	// PartitionSpans filters out incompatible nodes itself, so the nodes are
+	// only counted here, to divide the cluster-wide rate limits among them.
+	planCtx, sqlInstanceIDs, err := dsp.SetupAllNodesPlanning(ctx, evalCtx, execCtx.ExecCfg())
 	if err != nil {
 		return roachpb.RowCount{}, errors.Wrap(err, "failed to determine nodes on which to run")
 	}
@@ -165,11 +167,14 @@ func backup(
 		planCtx,
 		execCtx,
 		dsp,
+		len(sqlInstanceIDs),
+		job.ID(),
 		spans,
 		introducedSpans,
 		pkIDs,
 		defaultURI,
 		urisByLocalityKV,
+		mirrorURIs,
 		encryption,
 		roachpb.MVCCFilter(backupManifest.MVCCFilter),
 		backupManifest.StartTime,
@@ -257,42 +262,14 @@ func backup(
 		return roachpb.RowCount{}, errors.Wrapf(err, "exporting %d ranges", errors.Safe(numTotalSpans))
 	}
 
-	backupID := uuid.MakeV4()
-	backupManifest.ID = backupID
+	backupManifest.ID = uuid.MakeV4()
 	// Write additional partial descriptors to each node for partitioned backups.
 	if len(storageByLocalityKV) > 0 {
 		resumerSpan.RecordStructured(&types.StringValue{Value: "writing partition descriptors for partitioned backup"})
-		filesByLocalityKV := make(map[string][]BackupManifest_File)
-		for _, file := range backupManifest.Files {
-			filesByLocalityKV[file.LocalityKV] = append(filesByLocalityKV[file.LocalityKV], file)
-		}
-
-		nextPartitionedDescFilenameID := 1
-		for kv, conf := range storageByLocalityKV {
-			backupManifest.LocalityKVs = append(backupManifest.LocalityKVs, kv)
-			// Set a unique filename for each partition backup descriptor. The ID
-			// ensures uniqueness, and the kv string appended to the end is for
-			// readability.
-			filename := fmt.Sprintf("%s_%d_%s",
-				backupPartitionDescriptorPrefix, nextPartitionedDescFilenameID, sanitizeLocalityKV(kv))
-			nextPartitionedDescFilenameID++
-			backupManifest.PartitionDescriptorFilenames = append(backupManifest.PartitionDescriptorFilenames, filename)
-			desc := BackupPartitionDescriptor{
-				LocalityKV: kv,
-				Files:      filesByLocalityKV[kv],
-				BackupID:   backupID,
-			}
-
-			if err := func() error {
-				store, err := makeExternalStorage(ctx, *conf)
-				if err != nil {
-					return err
-				}
-				defer store.Close()
-				return writeBackupPartitionDescriptor(ctx, store, filename, encryption, &desc)
-			}(); err != nil {
-				return roachpb.RowCount{}, err
-			}
+		if err := writeBackupPartitionDescriptors(
+			ctx, backupManifest, storageByLocalityKV, makeExternalStorage, encryption,
+		); err != nil {
+			return roachpb.RowCount{}, err
 		}
 	}
 
@@ -334,6 +311,51 @@ func backup(
 	return backupManifest.EntryCounts, nil
 }
 
+// writeBackupPartitionDescriptors writes a partition descriptor, listing the
+// files of the backup stored in that locality, to the store of each locality of
+// a partitioned backup, and records their names in backupManifest.
+func writeBackupPartitionDescriptors(
+	ctx context.Context,
+	backupManifest *BackupManifest,
+	storageByLocalityKV map[string]*roachpb.ExternalStorage,
+	makeExternalStorage cloud.ExternalStorageFactory,
+	encryption *jobspb.BackupEncryptionOptions,
+) error {
+	filesByLocalityKV := make(map[string][]BackupManifest_File)
+	for _, file := range backupManifest.Files {
+		filesByLocalityKV[file.LocalityKV] = append(filesByLocalityKV[file.LocalityKV], file)
+	}
+
+	nextPartitionedDescFilenameID := 1
+	for kv, conf := range storageByLocalityKV {
+		backupManifest.LocalityKVs = append(backupManifest.LocalityKVs, kv)
+		// Set a unique filename for each partition backup descriptor. The ID
+		// ensures uniqueness, and the kv string appended to the end is for
+		// readability.
+		filename := fmt.Sprintf("%s_%d_%s",
+			backupPartitionDescriptorPrefix, nextPartitionedDescFilenameID, sanitizeLocalityKV(kv))
+		nextPartitionedDescFilenameID++
+		backupManifest.PartitionDescriptorFilenames = append(backupManifest.PartitionDescriptorFilenames, filename)
+		desc := BackupPartitionDescriptor{
+			LocalityKV: kv,
+			Files:      filesByLocalityKV[kv],
+			BackupID:   backupManifest.ID,
+		}
+
+		if err := func() error {
+			store, err := makeExternalStorage(ctx, *conf)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+			return writeBackupPartitionDescriptor(ctx, store, filename, encryption, &desc)
+		}(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func releaseProtectedTimestamp(
 	ctx context.Context, txn *kv.Txn, pts protectedts.Storage, ptsID *uuid.UUID,
 ) error {
@@ -374,6 +396,10 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 	details := b.job.Details().(jobspb.BackupDetails)
 	p := execCtx.(sql.JobExecContext)
 
+	if details.Compact {
+		return b.compactBackupChain(ctx, p)
+	}
+
 	var backupManifest *BackupManifest
 
 	// If planning didn't resolve the external destination, then we need to now.
@@ -503,74 +529,102 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		}
 	}()
 
-	if backupManifest == nil || util.ConstantWithMetamorphicTestBool("backup-read-manifest", false) {
-		backupManifest, memSize, err = b.readManifestOnResume(ctx, &mem, p.ExecCfg(), defaultStore, details)
-		if err != nil {
+	// A backup with mirrors whose job was resumed after writing the backup's
+	// manifest only has the copies of the backup left to make, and a continuous
+	// one only its revision log to carry on.
+	var backupComplete bool
+	if len(details.MirrorURIs) > 0 || details.Continuous {
+		if backupComplete, err = containsManifest(ctx, defaultStore); err != nil {
 			return err
 		}
 	}
 
-	statsCache := p.ExecCfg().TableStatsCache
-	// We retry on pretty generic failures -- any rpc error. If a worker node were
-	// to restart, it would produce this kind of error, but there may be other
-	// errors that are also rpc errors. Don't retry to aggressively.
-	retryOpts := retry.Options{
-		MaxBackoff: 1 * time.Second,
-		MaxRetries: 5,
-	}
-
-	// We want to retry a backup if there are transient failures (i.e. worker nodes
-	// dying), so if we receive a retryable error, re-plan and retry the backup.
 	var res roachpb.RowCount
-	var retryCount int32
-	for r := retry.StartWithCtx(ctx, retryOpts); r.Next(); {
-		retryCount++
-		resumerSpan.RecordStructured(&roachpb.RetryTracingEvent{
-			Operation:     "backupResumer.Resume",
-			AttemptNumber: retryCount,
-			RetryError:    tracing.RedactAndTruncateError(err),
-		})
-		res, err = backup(
-			ctx,
-			p,
-			details.URI,
-			details.URIsByLocalityKV,
-			p.ExecCfg().DB,
-			p.ExecCfg().Settings,
-			defaultStore,
-			storageByLocalityKV,
-			b.job,
-			backupManifest,
-			p.ExecCfg().DistSQLSrv.ExternalStorage,
-			details.EncryptionOptions,
-			statsCache,
-		)
-		if err == nil {
-			break
+	if backupComplete {
+		m, size, err := readBackupManifest(ctx, &mem, defaultStore, backupManifestName,
+			details.EncryptionOptions)
+		if err != nil {
+			return err
 		}
-
-		if joberror.IsPermanentBulkJobError(err) {
-			return errors.Wrap(err, "failed to run backup")
+		memSize = size
+		backupManifest = &m
+		res = backupManifest.EntryCounts
+	} else {
+		if backupManifest == nil || util.ConstantWithMetamorphicTestBool("backup-read-manifest", false) {
+			backupManifest, memSize, err = b.readManifestOnResume(ctx, &mem, p.ExecCfg(), defaultStore, details)
+			if err != nil {
+				return err
+			}
 		}
 
-		log.Warningf(ctx, `BACKUP job encountered retryable error: %+v`, err)
+		statsCache := p.ExecCfg().TableStatsCache
+		// We retry on pretty generic failures -- any rpc error. If a worker node were
+		// to restart, it would produce this kind of error, but there may be other
+		// errors that are also rpc errors. Don't retry to aggressively.
+		retryOpts := retry.Options{
+			MaxBackoff: 1 * time.Second,
+			MaxRetries: 5,
+		}
+
+		// We want to retry a backup if there are transient failures (i.e. worker nodes
+		// dying), so if we receive a retryable error, re-plan and retry the backup.
+		var retryCount int32
+		for r := retry.StartWithCtx(ctx, retryOpts); r.Next(); {
+			retryCount++
+			resumerSpan.RecordStructured(&roachpb.RetryTracingEvent{
+				Operation:     "backupResumer.Resume",
+				AttemptNumber: retryCount,
+				RetryError:    tracing.RedactAndTruncateError(err),
+			})
+			res, err = backup(
+				ctx,
+				p,
+				details.URI,
+				details.URIsByLocalityKV,
+				details.MirrorURIs,
+				p.ExecCfg().DB,
+				p.ExecCfg().Settings,
+				defaultStore,
+				storageByLocalityKV,
+				b.job,
+				backupManifest,
+				p.ExecCfg().DistSQLSrv.ExternalStorage,
+				details.EncryptionOptions,
+				statsCache,
+			)
+			if err == nil {
+				break
+			}
 
-		// Reload the backup manifest to pick up any spans we may have completed on
-		// previous attempts.
-		var reloadBackupErr error
-		mem.Shrink(ctx, memSize)
-		memSize = 0
-		backupManifest, memSize, reloadBackupErr = b.readManifestOnResume(ctx, &mem, p.ExecCfg(), defaultStore, details)
-		if reloadBackupErr != nil {
-			return errors.Wrap(reloadBackupErr, "could not reload backup manifest when retrying")
+			if joberror.IsPermanentBulkJobError(err) {
+				return errors.Wrap(err, "failed to run backup")
+			}
+
+			log.Warningf(ctx, `BACKUP job encountered retryable error: %+v`, err)
+
+			// Reload the backup manifest to pick up any spans we may have completed on
+			// previous attempts.
+			var reloadBackupErr error
+			mem.Shrink(ctx, memSize)
+			memSize = 0
+			backupManifest, memSize, reloadBackupErr = b.readManifestOnResume(ctx, &mem, p.ExecCfg(), defaultStore, details)
+			if reloadBackupErr != nil {
+				return errors.Wrap(reloadBackupErr, "could not reload backup manifest when retrying")
+			}
+		}
+		if err != nil {
+			return errors.Wrap(err, "exhausted retries")
 		}
-	}
-	if err != nil {
-		return errors.Wrap(err, "exhausted retries")
 	}
 
 	b.deleteCheckpoint(ctx, p.ExecCfg(), p.User())
 
+	if len(details.MirrorURIs) > 0 {
+		if err := b.mirrorBackup(ctx, p, details, defaultStore); err != nil {
+			return err
+		}
+	}
+
 	var backupDetails jobspb.BackupDetails
 	var ok bool
 	if backupDetails, ok = b.job.Details().(jobspb.BackupDetails); !ok {
@@ -581,7 +635,9 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		return err
 	}
 
-	if ptsID != nil && !b.testingKnobs.ignoreProtectedTimestamps {
+	// The protected timestamp of a continuous backup is kept, and advanced, by
+	// its revision log.
+	if ptsID != nil && !b.testingKnobs.ignoreProtectedTimestamps && !details.Continuous {
 		if err := p.ExecCfg().DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
 			details := b.job.Details().(jobspb.BackupDetails)
 			return releaseProtectedTimestamp(ctx, txn, p.ExecCfg().ProtectedTimestampProvider,
@@ -620,10 +676,29 @@ func (b *backupResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		if err := writeNewLatestFile(ctx, p.ExecCfg().Settings, c, suffix); err != nil {
 			return err
 		}
+
+		// The mirrors of a backup in a collection are collections themselves.
+		for _, mirror := range details.Destination.Mirrors {
+			if err := func() error {
+				m, err := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, mirror, p.User())
+				if err != nil {
+					return err
+				}
+				defer m.Close()
+				return writeNewLatestFile(ctx, p.ExecCfg().Settings, m, suffix)
+			}(); err != nil {
+				return errors.Wrapf(err, "writing %s file to mirror %s", latestFileName,
+					RedactURIForErrorMessage(mirror))
+			}
+		}
 	}
 
 	b.backupStats = res
 
+	if details.Continuous {
+		return b.tailRevisionLog(ctx, p, details, backupManifest)
+	}
+
 	// Collect telemetry.
 	{
 		numClusterNodes, err := clusterNodeCount(p.ExecCfg().Gossip)
@@ -0,0 +1,237 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// resolveMirrorURIs returns the URIs to which the backup written to defaultURI
+// is copied. Each mirror is resolved like the destination of the backup: the
+// path that resolution appended to the destination to get defaultURI, e.g. the
+// subdirectory of a backup in a collection, is appended to every mirror.
+func resolveMirrorURIs(
+	dest jobspb.BackupDetails_Destination, defaultURI string,
+) ([]string, error) {
+	if len(dest.Mirrors) == 0 {
+		return nil, nil
+	}
+	baseURI, _, err := getURIsByLocalityKV(dest.To, "")
+	if err != nil {
+		return nil, err
+	}
+	base, err := url.Parse(baseURI)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := url.Parse(defaultURI)
+	if err != nil {
+		return nil, err
+	}
+	basePath, resolvedPath := path.Clean(base.Path), path.Clean(resolved.Path)
+	if !strings.HasPrefix(resolvedPath, basePath) {
+		return nil, errors.AssertionFailedf("backup path %s is not within its destination %s",
+			resolvedPath, basePath)
+	}
+	suffix := strings.TrimPrefix(resolvedPath, basePath)
+
+	mirrorURIs := make([]string, len(dest.Mirrors))
+	for i, mirror := range dest.Mirrors {
+		if mirrorURIs[i], _, err = getURIsByLocalityKV([]string{mirror}, suffix); err != nil {
+			return nil, err
+		}
+	}
+	return mirrorURIs, nil
+}
+
+// listBackupFilesToMirror returns the files of the backup in store which are
+// copied to its mirrors, in the order in which they are copied. The data files
+// are not copied: the backup processors wrote them to every mirror already.
+// The manifest and its checksum are copied last, so that a mirror only looks
+// like a complete backup once every other file was copied to it. Checkpoints,
+// which only matter to the job writing the backup, are not copied either.
+func listBackupFilesToMirror(ctx context.Context, store cloud.ExternalStorage) ([]string, error) {
+	var files, manifestFiles []string
+	if err := store.List(ctx, "", "", func(name string) error {
+		name = strings.TrimPrefix(name, "/")
+		switch {
+		case strings.HasPrefix(name, backupDataDirectory+"/"),
+			strings.HasPrefix(name, backupProgressDirectory+"/"),
+			strings.HasPrefix(name, backupManifestCheckpointName):
+		case strings.HasPrefix(name, backupManifestName):
+			manifestFiles = append(manifestFiles, name)
+		default:
+			files = append(files, name)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "listing backup files")
+	}
+	sort.Strings(files)
+	// Sorted, BACKUP_MANIFEST comes before BACKUP_MANIFEST-CHECKSUM, which is
+	// checked against it when read.
+	sort.Strings(manifestFiles)
+	if len(manifestFiles) == 0 {
+		return nil, errors.Newf("backup has no %s to mirror", backupManifestName)
+	}
+	return append(files, manifestFiles...), nil
+}
+
+// mirrorBackup copies the files of the completed backup in defaultStore which
+// the backup processors did not write to every mirror, i.e. its metadata, to
+// each of the mirrors of the backup that do not have a complete copy yet. The
+// mirrors are copied to concurrently, and each is retried independently. The
+// progress of every mirror is kept in the job's progress, so that a resumed
+// job carries on where it left off. mirrorBackup fails if any mirror could not
+// be copied to.
+func (b *backupResumer) mirrorBackup(
+	ctx context.Context,
+	p sql.JobExecContext,
+	details jobspb.BackupDetails,
+	defaultStore cloud.ExternalStorage,
+) error {
+	files, err := listBackupFilesToMirror(ctx, defaultStore)
+	if err != nil {
+		return err
+	}
+
+	var mirrorsProgress []jobspb.BackupMirrorProgress
+	if prog := b.job.Progress().GetBackup(); prog != nil {
+		mirrorsProgress = append(mirrorsProgress, prog.Mirrors...)
+	}
+	for len(mirrorsProgress) < len(details.MirrorURIs) {
+		mirrorsProgress = append(mirrorsProgress, jobspb.BackupMirrorProgress{})
+	}
+
+	var mu struct {
+		syncutil.Mutex
+		progress []jobspb.BackupMirrorProgress
+	}
+	mu.progress = mirrorsProgress
+	checkpoint := func(ctx context.Context, i int, update func(*jobspb.BackupMirrorProgress)) {
+		mu.Lock()
+		defer mu.Unlock()
+		update(&mu.progress[i])
+		if err := b.job.Update(ctx, nil /* txn */, func(
+			txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			md.Progress.GetBackup().Mirrors = append([]jobspb.BackupMirrorProgress(nil), mu.progress...)
+			ju.UpdateProgress(md.Progress)
+			return nil
+		}); err != nil {
+			log.Warningf(ctx, "failed to checkpoint backup mirror progress: %+v", err)
+		}
+	}
+
+	grp := ctxgroup.WithContext(ctx)
+	for i := range details.MirrorURIs {
+		if mirrorsProgress[i].Done {
+			continue
+		}
+		i := i
+		grp.GoCtx(func(ctx context.Context) error {
+			return b.mirrorBackupTo(ctx, p, defaultStore, files, details.MirrorURIs[i],
+				mirrorsProgress[i], func(ctx context.Context, update func(*jobspb.BackupMirrorProgress)) {
+					checkpoint(ctx, i, update)
+				})
+		})
+	}
+	return grp.Wait()
+}
+
+// mirrorBackupTo copies the files of the backup in defaultStore to the mirror
+// at uri, retrying on failure. It starts at the file that prog, the progress of
+// the mirror so far, is at, and reports its progress through checkpoint.
+func (b *backupResumer) mirrorBackupTo(
+	ctx context.Context,
+	p sql.JobExecContext,
+	defaultStore cloud.ExternalStorage,
+	files []string,
+	uri string,
+	prog jobspb.BackupMirrorProgress,
+	checkpoint func(context.Context, func(*jobspb.BackupMirrorProgress)),
+) error {
+	redactedURI := RedactURIForErrorMessage(uri)
+	makeMirrorStore := func(ctx context.Context) (cloud.ExternalStorage, error) {
+		return p.ExecCfg().DistSQLSrv.ExternalStorageFromURI(ctx, uri, p.User())
+	}
+
+	retryOpts := retry.Options{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		MaxRetries:     5,
+	}
+	filesCopied, bytesCopied := prog.FilesCopied, prog.BytesCopied
+	var err error
+	for r := retry.StartWithCtx(ctx, retryOpts); r.Next(); {
+		checkpoint(ctx, func(prog *jobspb.BackupMirrorProgress) { prog.Attempts++ })
+
+		err = func() error {
+			mirrorStore, err := makeMirrorStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer mirrorStore.Close()
+
+			lastCheckpoint := timeutil.Now()
+			for ; filesCopied < int64(len(files)); filesCopied++ {
+				n, err := cloud.CopyFile(ctx, defaultStore, mirrorStore, files[filesCopied])
+				if err != nil {
+					return err
+				}
+				bytesCopied += n
+				if timeutil.Since(lastCheckpoint) > BackupCheckpointInterval {
+					lastCheckpoint = timeutil.Now()
+					copied, copiedBytes := filesCopied+1, bytesCopied
+					checkpoint(ctx, func(prog *jobspb.BackupMirrorProgress) {
+						prog.FilesCopied = copied
+						prog.BytesCopied = copiedBytes
+					})
+				}
+			}
+			checkpoint(ctx, func(prog *jobspb.BackupMirrorProgress) {
+				prog.FilesCopied = filesCopied
+				prog.BytesCopied = bytesCopied
+				prog.Done = true
+				prog.LastError = ""
+			})
+			return nil
+		}()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Warningf(ctx, "copying backup to mirror %s failed: %+v", redactedURI, err)
+		errMsg := err.Error()
+		checkpoint(ctx, func(prog *jobspb.BackupMirrorProgress) { prog.LastError = errMsg })
+	}
+	return errors.Wrapf(err, "exhausted retries copying backup to mirror %s", redactedURI)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobsprotectedts"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
@@ -325,6 +326,8 @@ func resolveOptionsForBackupJobDescription(
 	newOpts := tree.BackupOptions{
 		CaptureRevisionHistory: opts.CaptureRevisionHistory,
 		Detached:               opts.Detached,
+		Compact:                opts.Compact,
+		Continuous:             opts.Continuous,
 	}
 
 	if opts.EncryptionPassphrase != nil {
@@ -416,12 +419,17 @@ func backupJobDescription(
 	kmsURIs []string,
 	resolvedSubdir string,
 	incrementalStorage []string,
+	mirrors []string,
 ) (string, error) {
 	b, err := GetRedactedBackupNode(backup, to, incrementalFrom, kmsURIs,
 		resolvedSubdir, incrementalStorage, true /* hasBeenPlanned */)
 	if err != nil {
 		return "", err
 	}
+	b.Options.Mirror, err = sanitizeURIList(mirrors)
+	if err != nil {
+		return "", err
+	}
 
 	ann := p.ExtendedEvalContext().Annotations
 	return tree.AsStringWithFQNames(b, ann), nil
@@ -572,6 +580,101 @@ func requireEnterprise(execCfg *sql.ExecutorConfig, feature string) error {
 	return nil
 }
 
+// checkBackupCompaction checks that a BACKUP statement with the compact option
+// names a backup chain in a collection, and that the user may compact it.
+func checkBackupCompaction(
+	ctx context.Context, p sql.PlanHookState, backupStmt *annotatedBackupStatement,
+) error {
+	if !backupStmt.Nested || (backupStmt.Subdir == nil && !backupStmt.AppendToLatest) {
+		return errors.New("compact requires the BACKUP INTO LATEST IN or BACKUP INTO <subdir> IN syntax")
+	}
+	if backupStmt.Targets != nil {
+		return errors.New("compact does not accept targets; the targets of the compacted backup chain are used")
+	}
+	if backupStmt.Options.CaptureRevisionHistory {
+		return errors.New("compact cannot be used with revision_history")
+	}
+	if len(backupStmt.IncrementalFrom) > 0 {
+		return errors.New("compact cannot be used with INCREMENTAL FROM")
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.BackupResolutionInJob) {
+		return errors.Newf("compact requires the cluster to be upgraded to %s",
+			clusterversion.BackupResolutionInJob.String())
+	}
+	// A compaction reads and writes the backup collection as a whole, so it is
+	// held to the same privileges as a full cluster backup.
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasAdmin {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"only users with the admin role are allowed to compact backups")
+	}
+	return nil
+}
+
+// checkBackupMirrors checks that the destination of a BACKUP statement with the
+// mirror option can be mirrored. Each mirror is resolved like the destination,
+// so a backup with a single destination URI can have any number of mirrors.
+func checkBackupMirrors(
+	backupStmt *annotatedBackupStatement, to []string, incrementalStorage []string, mirrors []string,
+) error {
+	if len(to) > 1 {
+		return errors.New("mirror cannot be used with locality-aware backups")
+	}
+	if len(incrementalStorage) > 0 {
+		return errors.New("mirror cannot be used with incremental_location")
+	}
+	if backupStmt.Options.Compact {
+		return errors.New("mirror cannot be used with compact")
+	}
+	seen := make(map[string]struct{}, len(mirrors)+1)
+	for _, uri := range append(to[:len(to):len(to)], mirrors...) {
+		if _, ok := seen[uri]; ok {
+			return errors.Newf("mirror %s is specified more than once or is the backup destination",
+				RedactURIForErrorMessage(uri))
+		}
+		seen[uri] = struct{}{}
+	}
+	return nil
+}
+
+// checkContinuousBackup checks that a BACKUP statement with the continuous
+// option starts a new full backup next to which a revision log can be kept.
+func checkContinuousBackup(
+	p sql.PlanHookState,
+	backupStmt *annotatedBackupStatement,
+	to []string,
+	incrementalStorage []string,
+	mirrors []string,
+) error {
+	if !backupStmt.Nested || backupStmt.Subdir != nil || backupStmt.AppendToLatest {
+		return errors.New("continuous requires the BACKUP INTO <collection> syntax")
+	}
+	// The job runs until it is canceled, so waiting for it would never return.
+	if !backupStmt.Options.Detached {
+		return errors.New("continuous requires the detached option")
+	}
+	if len(to) > 1 {
+		return errors.New("continuous cannot be used with locality-aware backups")
+	}
+	if len(incrementalStorage) > 0 {
+		return errors.New("continuous cannot be used with incremental_location")
+	}
+	if len(mirrors) > 0 {
+		return errors.New("continuous cannot be used with mirror")
+	}
+	if backupStmt.Options.Compact {
+		return errors.New("continuous cannot be used with compact")
+	}
+	// The revision log is written from a rangefeed over the backed up spans.
+	if !kvserver.RangefeedEnabled.Get(&p.ExecCfg().Settings.SV) {
+		return errors.Errorf("continuous backups require the %s setting", kvserver.RangefeedEnabled.Key())
+	}
+	return nil
+}
+
 // backupPlanHook implements PlanHookFn.
 func backupPlanHook(
 	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
@@ -614,6 +717,11 @@ func backupPlanHook(
 		return nil, nil, nil, false, err
 	}
 
+	mirrorFn, err := p.TypeAsStringArray(ctx, tree.Exprs(backupStmt.Options.Mirror), "BACKUP")
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
 	encryptionParams := jobspb.BackupEncryptionOptions{Mode: jobspb.EncryptionMode_None}
 
 	var pwFn func() (string, error)
@@ -684,6 +792,19 @@ func backupPlanHook(
 			return errors.New("incremental_location option not supported with `BACKUP TO` syntax")
 		}
 
+		mirrors, err := mirrorFn()
+		if err != nil {
+			return err
+		}
+		if len(mirrors) > 0 {
+			if err := checkBackupMirrors(backupStmt, to, incrementalStorage, mirrors); err != nil {
+				return err
+			}
+			if err := requireEnterprise(p.ExecCfg(), "mirror"); err != nil {
+				return err
+			}
+		}
+
 		endTime := p.ExecCfg().Clock.Now()
 		if backupStmt.AsOf.Expr != nil {
 			asOf, err := p.EvalAsOfTimestamp(ctx, backupStmt.AsOf)
@@ -721,17 +842,38 @@ func backupPlanHook(
 			revisionHistory = true
 		}
 
+		if backupStmt.Options.Compact {
+			if err := checkBackupCompaction(ctx, p, backupStmt); err != nil {
+				return err
+			}
+			if err := requireEnterprise(p.ExecCfg(), "compact"); err != nil {
+				return err
+			}
+		}
+
+		if backupStmt.Options.Continuous {
+			if err := checkContinuousBackup(p, backupStmt, to, incrementalStorage, mirrors); err != nil {
+				return err
+			}
+			if err := requireEnterprise(p.ExecCfg(), "continuous"); err != nil {
+				return err
+			}
+		}
+
 		var targetDescs []catalog.Descriptor
 		var completeDBs []descpb.ID
 
-		switch backupStmt.Coverage() {
-		case tree.RequestedDescriptors:
+		// A compaction backs up no data of its own: its targets are those of the
+		// backup chain it compacts, which are only known once the job reads it.
+		switch {
+		case backupStmt.Options.Compact:
+		case backupStmt.Coverage() == tree.RequestedDescriptors:
 			var err error
 			targetDescs, completeDBs, err = backupresolver.ResolveTargetsToDescriptors(ctx, p, endTime, backupStmt.Targets)
 			if err != nil {
 				return errors.Wrap(err, "failed to resolve targets specified in the BACKUP stmt")
 			}
-		case tree.AllDescriptors:
+		case backupStmt.Coverage() == tree.AllDescriptors:
 			var err error
 			targetDescs, completeDBs, err = fullClusterTargetsBackup(ctx, p.ExecCfg(), endTime)
 			if err != nil {
@@ -741,22 +883,34 @@ func backupPlanHook(
 			return errors.AssertionFailedf("unexpected descriptor coverage %v", backupStmt.Coverage())
 		}
 
-		// Check BACKUP privileges.
-		err = checkPrivilegesForBackup(ctx, backupStmt, p, targetDescs, to)
+		// Check BACKUP privileges. The mirrors are written to just like the
+		// destination.
+		err = checkPrivilegesForBackup(ctx, backupStmt, p, targetDescs, append(to[:len(to):len(to)], mirrors...))
 		if err != nil {
 			return err
 		}
 
 		initialDetails := jobspb.BackupDetails{
-			Destination:         jobspb.BackupDetails_Destination{To: to, IncrementalStorage: incrementalStorage},
+			Destination: jobspb.BackupDetails_Destination{
+				To: to, IncrementalStorage: incrementalStorage, Mirrors: mirrors,
+			},
 			EndTime:             endTime,
 			RevisionHistory:     revisionHistory,
 			IncrementalFrom:     incrementalFrom,
 			FullCluster:         backupStmt.Coverage() == tree.AllDescriptors,
 			ResolvedCompleteDbs: completeDBs,
 			EncryptionOptions:   &encryptionParams,
+			Compact:             backupStmt.Options.Compact,
+			Continuous:          backupStmt.Options.Continuous,
+		}
+		if backupStmt.Options.Compact && backupStmt.AsOf.Expr == nil {
+			// Without an explicit end time, every layer of the chain is compacted.
+			initialDetails.EndTime = hlc.Timestamp{}
 		}
-		if backupStmt.CreatedByInfo != nil && backupStmt.CreatedByInfo.Name == jobs.CreatedByScheduledJobs {
+		// Compactions do not take part in the schedule's chaining of protected
+		// timestamps, as they do not read any data from the cluster.
+		if backupStmt.CreatedByInfo != nil && backupStmt.CreatedByInfo.Name == jobs.CreatedByScheduledJobs &&
+			!backupStmt.Options.Compact {
 			initialDetails.ScheduleID = backupStmt.CreatedByInfo.ID
 		}
 
@@ -796,6 +950,7 @@ func backupPlanHook(
 				encryptionParams.RawKmsUris,
 				initialDetails.Destination.Subdir,
 				initialDetails.Destination.IncrementalStorage,
+				mirrors,
 			)
 			if err != nil {
 				return err
@@ -864,7 +1019,7 @@ func backupPlanHook(
 			return err
 		}
 
-		description, err := backupJobDescription(p, backupStmt.Backup, to, incrementalFrom, encryptionParams.RawKmsUris, backupDetails.Destination.Subdir, initialDetails.Destination.IncrementalStorage)
+		description, err := backupJobDescription(p, backupStmt.Backup, to, incrementalFrom, encryptionParams.RawKmsUris, backupDetails.Destination.Subdir, initialDetails.Destination.IncrementalStorage, mirrors)
 		if err != nil {
 			return err
 		}
@@ -1556,6 +1711,25 @@ func getBackupDetailAndManifest(
 		return jobspb.BackupDetails{}, BackupManifest{}, err
 	}
 
+	mirrorURIs, err := resolveMirrorURIs(initialDetails.Destination, defaultURI)
+	if err != nil {
+		return jobspb.BackupDetails{}, BackupManifest{}, err
+	}
+	// Never write a mirror over another backup. This is checked before the
+	// backup starts, as the backup processors write to the mirrors.
+	for _, uri := range mirrorURIs {
+		if err := func() error {
+			mirrorStore, err := execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, uri, user)
+			if err != nil {
+				return err
+			}
+			defer mirrorStore.Close()
+			return checkForPreviousBackup(ctx, mirrorStore, uri)
+		}(); err != nil {
+			return jobspb.BackupDetails{}, BackupManifest{}, err
+		}
+	}
+
 	kmsEnv := &backupKMSEnv{settings: execCfg.Settings, conf: &execCfg.ExternalIODirConfig}
 
 	mem := execCfg.RootMemoryMonitor.MakeBoundAccount()
@@ -1744,7 +1918,9 @@ func getBackupDetailAndManifest(
 	}
 
 	return jobspb.BackupDetails{
-		Destination:       jobspb.BackupDetails_Destination{Subdir: resolvedSubdir},
+		Destination: jobspb.BackupDetails_Destination{
+			Subdir: resolvedSubdir, Mirrors: initialDetails.Destination.Mirrors,
+		},
 		StartTime:         startTime,
 		EndTime:           endTime,
 		URI:               defaultURI,
@@ -1752,6 +1928,8 @@ func getBackupDetailAndManifest(
 		EncryptionOptions: encryptionOptions,
 		EncryptionInfo:    encryptionInfo,
 		CollectionURI:     collectionURI,
+		MirrorURIs:        mirrorURIs,
+		Continuous:        initialDetails.Continuous,
 	}, backupManifest, nil
 }
 
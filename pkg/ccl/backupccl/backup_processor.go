@@ -315,6 +315,17 @@ func runBackupProcessor(
 	if err != nil {
 		return err
 	}
+	mirrors := make([]roachpb.ExternalStorage, len(spec.MirrorURIs))
+	for i, uri := range spec.MirrorURIs {
+		if mirrors[i], err = cloud.ExternalStorageConfFromURI(uri, spec.User()); err != nil {
+			return err
+		}
+	}
+
+	readThrottler := makeBulkIOThrottler(ctx, &clusterSettings.SV, flowCtx.Cfg.JobRegistry,
+		spec.JobID, spec.NumNodes, spec.NumProcessors, bulkIORead)
+	writeThrottler := makeBulkIOThrottler(ctx, &clusterSettings.SV, flowCtx.Cfg.JobRegistry,
+		spec.JobID, spec.NumNodes, spec.NumProcessors, bulkIOWrite)
 
 	returnedSSTs := make(chan returnedSST, 1)
 
@@ -405,18 +416,27 @@ func runBackupProcessor(
 					// after creating a single SST.
 					header.TargetBytes = 1
 					admissionHeader := roachpb.AdmissionHeader{
-						// Export requests are currently assigned NormalPri.
+						// Export requests are assigned BulkNormalPri, which makes them
+						// elastic work that is throttled when the disk bandwidth
+						// utilization of the store is high.
 						//
 						// TODO(bulkio): the priority should vary based on the urgency of
-						// these background requests. These exports should get LowPri,
-						// unless they are being retried and need to be completed in a
-						// timely manner for compliance with RPO and data retention
-						// policies. Consider deriving this from the UserPriority field.
-						Priority:                 int32(admission.NormalPri),
+						// these background requests. Exports that are being retried and
+						// need to be completed in a timely manner for compliance with RPO
+						// and data retention policies should not be elastic. Consider
+						// deriving this from the UserPriority field.
+						Priority:                 int32(admission.BulkNormalPri),
 						CreateTime:               timeutil.Now().UnixNano(),
 						Source:                   roachpb.AdmissionHeader_ROOT_KV,
 						NoMemoryReservedAtSource: true,
 					}
+					// Reserve what the export may read against the read rate limits
+					// before sending it, so that the reads themselves are throttled,
+					// and settle the reservation with what was read once it returns.
+					readReservation, err := readThrottler.reserve(ctx, req.TargetFileSize)
+					if err != nil {
+						return err
+					}
 					log.Infof(ctx, "sending ExportRequest for span %s (attempt %d, priority %s)",
 						span.span, span.attempts+1, header.UserPriority.String())
 					var rawRes roachpb.Response
@@ -442,6 +462,15 @@ func runBackupProcessor(
 							}
 							return nil
 						})
+					var exportedBytes int64
+					if exportRequestErr == nil {
+						for _, file := range rawRes.(*roachpb.ExportResponse).Files {
+							exportedBytes += int64(len(file.SST))
+						}
+					}
+					if err := readReservation.settle(ctx, exportedBytes); err != nil {
+						return err
+					}
 					if exportRequestErr != nil {
 						if intentErr, ok := pErr.GetDetail().(*roachpb.WriteIntentError); ok {
 							span.lastTried = timeutil.Now()
@@ -562,21 +591,38 @@ func runBackupProcessor(
 	// contents to cloud storage.
 	grp.GoCtx(func(ctx context.Context) error {
 		sinkConf := sstSinkConf{
-			id:       flowCtx.NodeID.SQLInstanceID(),
-			enc:      spec.Encryption,
-			progCh:   progCh,
-			settings: &flowCtx.Cfg.Settings.SV,
+			id:        flowCtx.NodeID.SQLInstanceID(),
+			enc:       spec.Encryption,
+			progCh:    progCh,
+			settings:  &flowCtx.Cfg.Settings.SV,
+			throttler: writeThrottler,
 		}
 
 		storage, err := flowCtx.Cfg.ExternalStorage(ctx, dest)
 		if err != nil {
 			return err
 		}
+		mirrorStorages := make([]cloud.ExternalStorage, 0, len(mirrors))
+		defer func() {
+			for _, m := range mirrorStorages {
+				if err := m.Close(); err != nil {
+					log.Warningf(ctx, "failed to close backup mirror: % #v", pretty.Formatter(err))
+				}
+			}
+		}()
+		for _, m := range mirrors {
+			mirrorStorage, err := flowCtx.Cfg.ExternalStorage(ctx, m)
+			if err != nil {
+				return errors.CombineErrors(err, storage.Close())
+			}
+			mirrorStorages = append(mirrorStorages, mirrorStorage)
+		}
 
 		sink, err := makeSSTSink(ctx, sinkConf, storage, memAcc)
 		if err != nil {
 			return err
 		}
+		sink.mirrors = mirrorStorages
 
 		defer func() {
 			err := sink.Close()
@@ -603,11 +649,16 @@ type sstSinkConf struct {
 	enc      *roachpb.FileEncryptionOptions
 	id       base.SQLInstanceID
 	settings *settings.Values
+	// throttler, if set, limits the rate at which the sink writes.
+	throttler *bulkIOThrottler
 }
 
 type sstSink struct {
 	dest cloud.ExternalStorage
-	conf sstSinkConf
+	// mirrors are the mirrors of the backup, to which every file written to
+	// dest is written as well.
+	mirrors []cloud.ExternalStorage
+	conf    sstSinkConf
 
 	queue []returnedSST
 	// queueCap is the maximum byte size that the queue can grow to.
@@ -785,6 +836,21 @@ func (s *sstSink) open(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if len(s.mirrors) > 0 {
+		writers := []io.WriteCloser{w}
+		for _, m := range s.mirrors {
+			mw, err := m.Writer(s.ctx, s.outName)
+			if err != nil {
+				for _, w := range writers {
+					_ = w.Close()
+				}
+				return errors.Wrap(err, "opening backup file in mirror")
+			}
+			writers = append(writers, mw)
+		}
+		// The file is encrypted once, so that every copy of it is the same.
+		w = makeFanOutWriter(writers)
+	}
 	if s.conf.enc != nil {
 		var err error
 		w, err = storageccl.EncryptingWriter(w, s.conf.enc.Key)
@@ -825,6 +891,11 @@ func (s *sstSink) write(ctx context.Context, resp returnedSST) error {
 		}
 	}
 
+	// The data is written to the destination and to each mirror.
+	if err := s.conf.throttler.acquire(ctx, int64(len(resp.sst)*(1+len(s.mirrors)))); err != nil {
+		return err
+	}
+
 	log.VEventf(ctx, 2, "writing %s to backup file %s", span, s.outName)
 
 	// Copy SST content.
@@ -887,10 +958,33 @@ func (s *sstSink) write(ctx context.Context, resp returnedSST) error {
 	return nil
 }
 
+// fanOutWriter writes whatever is written to it to each of its writers.
+type fanOutWriter struct {
+	io.Writer
+	writers []io.WriteCloser
+}
+
+func makeFanOutWriter(writers []io.WriteCloser) *fanOutWriter {
+	ws := make([]io.Writer, len(writers))
+	for i := range writers {
+		ws[i] = writers[i]
+	}
+	return &fanOutWriter{Writer: io.MultiWriter(ws...), writers: writers}
+}
+
+// Close closes every writer, and returns the errors of those that failed.
+func (w *fanOutWriter) Close() error {
+	var err error
+	for _, out := range w.writers {
+		err = errors.CombineErrors(err, out.Close())
+	}
+	return err
+}
+
 func generateUniqueSSTName(nodeID base.SQLInstanceID) string {
 	// The data/ prefix, including a /, is intended to group SSTs in most of the
 	// common file/bucket browse UIs.
-	return fmt.Sprintf("data/%d.sst", builtins.GenerateUniqueInt(nodeID))
+	return fmt.Sprintf("%s/%d.sst", backupDataDirectory, builtins.GenerateUniqueInt(nodeID))
 }
 
 func init() {
@@ -32,11 +32,14 @@ func distBackupPlanSpecs(
 	planCtx *sql.PlanningCtx,
 	execCtx sql.JobExecContext,
 	dsp *sql.DistSQLPlanner,
+	numNodes int,
+	jobID jobspb.JobID,
 	spans roachpb.Spans,
 	introducedSpans roachpb.Spans,
 	pkIDs map[uint64]bool,
 	defaultURI string,
 	urisByLocalityKV map[string]string,
+	mirrorURIs []string,
 	encryption *jobspb.BackupEncryptionOptions,
 	mvccFilter roachpb.MVCCFilter,
 	startTime, endTime hlc.Timestamp,
@@ -95,12 +98,14 @@ func distBackupPlanSpecs(
 			Spans:            partition.Spans,
 			DefaultURI:       defaultURI,
 			URIsByLocalityKV: urisByLocalityKV,
+			MirrorURIs:       mirrorURIs,
 			MVCCFilter:       mvccFilter,
 			Encryption:       fileEncryption,
 			PKIDs:            pkIDs,
 			BackupStartTime:  startTime,
 			BackupEndTime:    endTime,
 			UserProto:        user.EncodeProto(),
+			JobID:            jobID,
 		}
 		sqlInstanceIDToSpec[partition.SQLInstanceID] = spec
 	}
@@ -116,12 +121,14 @@ func distBackupPlanSpecs(
 				IntroducedSpans:  partition.Spans,
 				DefaultURI:       defaultURI,
 				URIsByLocalityKV: urisByLocalityKV,
+				MirrorURIs:       mirrorURIs,
 				MVCCFilter:       mvccFilter,
 				Encryption:       fileEncryption,
 				PKIDs:            pkIDs,
 				BackupStartTime:  startTime,
 				BackupEndTime:    endTime,
 				UserProto:        user.EncodeProto(),
+				JobID:            jobID,
 			}
 			sqlInstanceIDToSpec[partition.SQLInstanceID] = spec
 		}
@@ -131,6 +138,8 @@ func distBackupPlanSpecs(
 		NodeToNumSpans: make(map[int32]int64),
 	}
 	for node, spec := range sqlInstanceIDToSpec {
+		spec.NumNodes = int32(numNodes)
+		spec.NumProcessors = int32(len(sqlInstanceIDToSpec))
 		numSpans := int64(len(spec.Spans) + len(spec.IntroducedSpans))
 		backupPlanningTraceEvent.NodeToNumSpans[int32(node)] = numSpans
 		backupPlanningTraceEvent.TotalNumSpans += numSpans
@@ -0,0 +1,1047 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/diskmap"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	revisionLogFlushInterval = settings.RegisterDurationSetting(
+		settings.TenantWritable,
+		"bulkio.backup.revision_log.flush_interval",
+		"how often continuous backups write the revisions they received to their revision log",
+		30*time.Second,
+		settings.PositiveDuration,
+	).WithPublic()
+	revisionLogBufferSize = settings.RegisterByteSizeSetting(
+		settings.TenantWritable,
+		"bulkio.backup.revision_log.buffer_size",
+		"the size of the revisions a continuous backup buffers in memory before it spills them "+
+			"to disk and writes them to its revision log, even if it is not time to do so yet",
+		64<<20,
+		settings.PositiveInt,
+	)
+)
+
+// revisionLogDirectory is the directory, within the directory of the full
+// backup of a continuous backup, to which its revision log is written. Each
+// segment of the log is in a subdirectory named after the time it starts at,
+// and is laid out like an incremental backup from that time to its end time,
+// with MVCCFilter_All. Like those of a backup, the SSTs of a segment have
+// unique names, so a segment written again when a job is resumed never
+// overwrites the files of an earlier attempt; only those listed in the
+// manifest of the segment are read.
+const revisionLogDirectory = "revlog"
+
+// revisionLogScanPageSize is the number of keys read at a time when reading
+// the keys deleted by a range tombstone.
+const revisionLogScanPageSize = 10000
+
+// errRevisionLogSpansChanged is returned by revisionLogWriter.run when the
+// spans watched by the revision log must change.
+var errRevisionLogSpansChanged = errors.New("revision log spans changed")
+
+// revisionLogSegmentName returns the name of the directory of the segment of
+// a revision log starting at start. Segments are named after their start time
+// alone, so that a resumed job writing the segment after its high water again
+// replaces any segment it wrote there before.
+func revisionLogSegmentName(start hlc.Timestamp) string {
+	return fmt.Sprintf("%d.%010d", start.WallTime, start.Logical)
+}
+
+// revisionLogSegmentURI returns the URI of the segment of the revision log of
+// the full backup at backupURI starting at start.
+func revisionLogSegmentURI(backupURI string, start hlc.Timestamp) (string, error) {
+	u, err := url.Parse(backupURI)
+	if err != nil {
+		return "", err
+	}
+	u.Path = path.Join(u.Path, revisionLogDirectory, revisionLogSegmentName(start))
+	return u.String(), nil
+}
+
+// listRevisionLogSegments returns the start times, in order, of the segments
+// of the revision log of the full backup in store. Only segments whose
+// manifest was written are listed.
+func listRevisionLogSegments(
+	ctx context.Context, store cloud.ExternalStorage,
+) ([]hlc.Timestamp, error) {
+	var starts []hlc.Timestamp
+	pattern := path.Join("/", revisionLogDirectory, "*", backupManifestName)
+	if err := store.List(ctx, "", listingDelimDataSlash, func(name string) error {
+		name = "/" + strings.TrimPrefix(name, "/")
+		if ok, err := path.Match(pattern, name); err != nil || !ok {
+			return err
+		}
+		start, err := tree.ParseHLC(path.Base(path.Dir(name)))
+		if err != nil {
+			return errors.Wrapf(err, "parsing the start time of revision log segment %s", name)
+		}
+		starts = append(starts, start)
+		return nil
+	}); err != nil {
+		if errors.Is(err, cloud.ErrListingUnsupported) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "listing revision log segments")
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Less(starts[j]) })
+	return starts, nil
+}
+
+// resolveRevisionLog returns the URIs and manifests of the segments of the
+// revision log of the full backup at backupURI, in store, that are needed to
+// restore it as of endTime: the contiguous segments starting at the end time
+// of the backup, up to the first one that ends at or after endTime. It returns
+// no segments if the backup has no revision log. The size of the manifests,
+// which are accounted for in mem, is returned too.
+func resolveRevisionLog(
+	ctx context.Context,
+	mem *mon.BoundAccount,
+	store cloud.ExternalStorage,
+	mkStore cloud.ExternalStorageFromURIFactory,
+	backupURI string,
+	backupEndTime, endTime hlc.Timestamp,
+	encryption *jobspb.BackupEncryptionOptions,
+	user security.SQLUsername,
+) (_ []string, _ []BackupManifest, _ int64, err error) {
+	starts, err := listRevisionLogSegments(ctx, store)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var uris []string
+	var manifests []BackupManifest
+	var reserved int64
+	defer func() {
+		if err != nil && reserved != 0 {
+			mem.Shrink(ctx, reserved)
+		}
+	}()
+	next := backupEndTime
+	for _, start := range starts {
+		if start.Less(backupEndTime) {
+			continue
+		}
+		if !start.Less(endTime) {
+			break
+		}
+		if !start.Equal(next) {
+			return nil, nil, 0, errors.Errorf(
+				"revision log of backup %s is missing revisions from %s to %s",
+				RedactURIForErrorMessage(backupURI), next, start)
+		}
+		uri, err := revisionLogSegmentURI(backupURI, start)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		m, size, err := ReadBackupManifestFromURI(ctx, mem, uri, user, mkStore, encryption)
+		if err != nil {
+			return nil, nil, 0, errors.Wrapf(err, "reading revision log segment starting at %s", start)
+		}
+		reserved += size
+		uris = append(uris, uri)
+		manifests = append(manifests, m)
+		next = m.EndTime
+	}
+	return uris, manifests, reserved, nil
+}
+
+// tailRevisionLog runs the revision log of a continuous backup once its full
+// backup was written. It watches the backed up spans with a rangefeed and
+// periodically writes the revisions it received, up to the frontier of the
+// rangefeed, to a new segment of the log. After each segment, the high water
+// of the job and its protected timestamp are advanced to the end of the
+// segment. It only returns if the job is paused or canceled, or fails.
+func (b *backupResumer) tailRevisionLog(
+	ctx context.Context,
+	p sql.JobExecContext,
+	details jobspb.BackupDetails,
+	backupManifest *BackupManifest,
+) error {
+	w := &revisionLogWriter{
+		execCfg: p.ExecCfg(),
+		user:    p.User(),
+		job:     b.job,
+		details: details,
+		backup:  backupManifest,
+	}
+	if details.EncryptionOptions != nil {
+		store, err := w.execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, details.URI, w.user)
+		if err != nil {
+			return err
+		}
+		key, err := getEncryptionKey(ctx, details.EncryptionOptions, w.execCfg.Settings,
+			store.ExternalIOConf())
+		store.Close()
+		if err != nil {
+			return err
+		}
+		w.encryption = &roachpb.FileEncryptionOptions{Key: key}
+	}
+	if err := w.init(ctx); err != nil {
+		return err
+	}
+
+	var added roachpb.Spans
+	for {
+		err := w.run(ctx, added)
+		if !errors.Is(err, errRevisionLogSpansChanged) {
+			return err
+		}
+		added = filterSpans(w.spans, w.lastSpans)
+		log.Infof(ctx, "restarting the revision log rangefeed at %s over %d spans",
+			w.start, len(w.spans))
+	}
+}
+
+// revisionLogWriter writes the segments of the revision log of a continuous
+// backup.
+type revisionLogWriter struct {
+	execCfg    *sql.ExecutorConfig
+	user       security.SQLUsername
+	job        *jobs.Job
+	details    jobspb.BackupDetails
+	backup     *BackupManifest
+	encryption *roachpb.FileEncryptionOptions
+
+	// start is the time up to which the revision log is complete, which is the
+	// start time of its next segment.
+	start hlc.Timestamp
+	// descs are the backed up descriptors as of start.
+	descs []catalog.Descriptor
+	// spans are the spans watched by the rangefeed, and lastSpans those of the
+	// last segment written.
+	spans, lastSpans roachpb.Spans
+}
+
+// init sets the writer up to write the segment after the job's high water, or
+// the first segment if the job has not written any yet.
+func (w *revisionLogWriter) init(ctx context.Context) error {
+	w.start = w.backup.EndTime
+	raw, spans := w.backup.Descriptors, w.backup.Spans
+
+	if hw := w.job.Progress().GetHighWater(); hw != nil && w.start.Less(*hw) {
+		// The job was resumed: carry on from the last segment it wrote, which is
+		// the last one starting before its high water. A segment starting at the
+		// high water may have been written too, but the job failed before
+		// recording it, so it is written again.
+		store, err := w.execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, w.details.URI, w.user)
+		if err != nil {
+			return err
+		}
+		starts, err := listRevisionLogSegments(ctx, store)
+		store.Close()
+		if err != nil {
+			return err
+		}
+		var last hlc.Timestamp
+		for _, start := range starts {
+			if start.Less(*hw) {
+				last = start
+			}
+		}
+		if last.IsEmpty() {
+			return errors.AssertionFailedf("revision log has no segment ending at its high water %s", *hw)
+		}
+		uri, err := revisionLogSegmentURI(w.details.URI, last)
+		if err != nil {
+			return err
+		}
+		m, _, err := ReadBackupManifestFromURI(ctx, nil /* mem */, uri, w.user,
+			w.execCfg.DistSQLSrv.ExternalStorageFromURI, w.details.EncryptionOptions)
+		if err != nil {
+			return err
+		}
+		if !m.EndTime.Equal(*hw) {
+			return errors.AssertionFailedf("last revision log segment ends at %s, not at its high water %s",
+				m.EndTime, *hw)
+		}
+		w.start = *hw
+		raw, spans = m.Descriptors, m.Spans
+	}
+
+	w.descs = make([]catalog.Descriptor, len(raw))
+	for i := range raw {
+		w.descs[i] = descbuilder.NewBuilder(&raw[i]).BuildImmutable()
+	}
+	w.spans, w.lastSpans = spans, spans
+	return nil
+}
+
+// run runs the rangefeed of the revision log, and writes a segment of the log
+// every revisionLogFlushInterval, or whenever the revisions buffered in memory
+// grow over revisionLogBufferSize and are spilled to disk. The spans in added,
+// which the revision log did not watch before, are scanned as of the start of
+// the rangefeed too, as the segment introducing them must hold their whole
+// content. It returns
+// errRevisionLogSpansChanged when the spans to watch change, after which it
+// must run again.
+func (w *revisionLogWriter) run(ctx context.Context, added roachpb.Spans) error {
+	sv := &w.execCfg.Settings.SV
+	buf := &revisionLogBuffer{
+		limit:       revisionLogBufferSize.Get(sv),
+		tempStorage: w.execCfg.DistSQLSrv.TempStorage,
+		full:        make(chan struct{}, 1),
+		errCh:       make(chan error, 1),
+	}
+	buf.mu.acc = w.execCfg.RootMemoryMonitor.MakeBoundAccount()
+	defer buf.close(ctx)
+
+	name := fmt.Sprintf("backup-revision-log-%d", w.job.ID())
+	for _, feed := range []struct {
+		spans roachpb.Spans
+		opts  []rangefeed.Option
+	}{
+		{spans: filterSpans(w.spans, added)},
+		{spans: added, opts: []rangefeed.Option{rangefeed.WithInitialScan(nil)}},
+	} {
+		if len(feed.spans) == 0 {
+			continue
+		}
+		i := len(buf.mu.frontiers)
+		buf.mu.frontiers = append(buf.mu.frontiers, hlc.Timestamp{})
+		opts := append([]rangefeed.Option{
+			rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+				buf.onFrontierAdvance(i, ts)
+			}),
+			rangefeed.WithOnDeleteRange(buf.onDeleteRange),
+			rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+				select {
+				case buf.errCh <- err:
+				default:
+				}
+			}),
+		}, feed.opts...)
+		rf, err := w.execCfg.RangeFeedFactory.RangeFeed(ctx, name, feed.spans, w.start,
+			buf.onValue, opts...)
+		if err != nil {
+			return errors.Wrap(err, "starting revision log rangefeed")
+		}
+		defer rf.Close()
+	}
+
+	timer := timeutil.NewTimer()
+	defer timer.Stop()
+	for {
+		timer.Reset(revisionLogFlushInterval.Get(sv))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-buf.errCh:
+			return errors.Wrap(err, "revision log rangefeed")
+		case <-buf.full:
+		case <-timer.C:
+			timer.Read = true
+		}
+		if err := w.flush(ctx, buf); err != nil {
+			return err
+		}
+	}
+}
+
+// flush writes the buffered revisions up to the frontier of the rangefeed to a
+// new segment of the revision log, and advances the job to the end of the
+// segment. It returns errRevisionLogSpansChanged if the backed up descriptors
+// changed such that the rangefeed must watch other spans.
+func (w *revisionLogWriter) flush(ctx context.Context, buf *revisionLogBuffer) error {
+	end, err := buf.frontier()
+	if err != nil {
+		return err
+	}
+	if !w.start.Less(end) {
+		return nil
+	}
+
+	revs, err := getRelevantDescChanges(ctx, w.execCfg, w.start, end, w.descs,
+		w.backup.CompleteDbs, make(map[descpb.ID]descpb.ID), w.details.FullCluster)
+	if err != nil {
+		return err
+	}
+	descs := applyDescriptorRevisions(w.descs, revs)
+	spans, err := w.requiredSpans(descs, revs)
+	if err != nil {
+		return err
+	}
+	if len(filterSpans(spans, w.spans)) > 0 {
+		// Objects whose spans are not watched were added to the backup, e.g. a
+		// table was created in a backed up database. The rangefeed restarts from
+		// the start of this segment, so that it has the revisions of the new
+		// spans too.
+		var merged roachpb.Spans
+		merged = append(merged, w.spans...)
+		merged = append(merged, spans...)
+		w.spans, _ = roachpb.MergeSpans(&merged)
+		return errRevisionLogSpansChanged
+	}
+
+	m := BackupManifest{
+		ID:                 uuid.MakeV4(),
+		StartTime:          w.start,
+		EndTime:            end,
+		MVCCFilter:         MVCCFilter_All,
+		RevisionStartTime:  w.start,
+		Spans:              w.spans,
+		IntroducedSpans:    filterSpans(w.spans, w.lastSpans),
+		DescriptorChanges:  revs,
+		CompleteDbs:        w.backup.CompleteDbs,
+		Tenants:            w.backup.Tenants,
+		FormatVersion:      w.backup.FormatVersion,
+		BuildInfo:          w.backup.BuildInfo,
+		ClusterVersion:     w.backup.ClusterVersion,
+		ClusterID:          w.backup.ClusterID,
+		DescriptorCoverage: w.backup.DescriptorCoverage,
+	}
+	for _, desc := range descs {
+		m.Descriptors = append(m.Descriptors, *desc.DescriptorProto())
+	}
+	if err := w.writeSegment(ctx, &m, buf); err != nil {
+		return err
+	}
+
+	if err := w.execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		if err := w.job.Update(ctx, txn, func(
+			txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+		) error {
+			if err := md.CheckRunningOrReverting(); err != nil {
+				return err
+			}
+			hw := end
+			md.Progress.Progress = &jobspb.Progress_HighWater{HighWater: &hw}
+			ju.UpdateProgress(md.Progress)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if ptsID := w.details.ProtectedTimestampRecord; ptsID != nil {
+			return w.execCfg.ProtectedTimestampProvider.UpdateTimestamp(ctx, txn, *ptsID, end)
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "advancing the high water of the revision log")
+	}
+
+	w.start, w.descs, w.lastSpans = end, descs, w.spans
+	if len(filterSpans(w.spans, spans)) > 0 {
+		// Some watched spans are no longer backed up, e.g. their table was
+		// dropped. They are no longer watched, so that the rangefeed is not
+		// disrupted when their data is cleared.
+		w.spans = spans
+		return errRevisionLogSpansChanged
+	}
+	return nil
+}
+
+// requiredSpans returns the spans the revision log must watch to back up descs
+// and the descriptor revisions revs.
+func (w *revisionLogWriter) requiredSpans(
+	descs []catalog.Descriptor, revs []BackupManifest_DescriptorRevision,
+) (roachpb.Spans, error) {
+	var tables []catalog.TableDescriptor
+	for _, desc := range descs {
+		if table, ok := desc.(catalog.TableDescriptor); ok {
+			tables = append(tables, table)
+		}
+	}
+	spans, err := spansForAllTableIndexes(w.execCfg, tables, revs)
+	if err != nil {
+		return nil, err
+	}
+	for _, tenant := range w.backup.Tenants {
+		prefix := keys.MakeTenantPrefix(roachpb.MakeTenantID(tenant.ID))
+		spans = append(spans, roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()})
+	}
+	spans, _ = roachpb.MergeSpans(&spans)
+	return spans, nil
+}
+
+// applyDescriptorRevisions returns the descriptors, sorted by ID, that result
+// from applying revs to descs. Descriptors that were deleted, or tables that
+// were dropped, are left out.
+func applyDescriptorRevisions(
+	descs []catalog.Descriptor, revs []BackupManifest_DescriptorRevision,
+) []catalog.Descriptor {
+	byID := make(map[descpb.ID]catalog.Descriptor, len(descs))
+	for _, desc := range descs {
+		byID[desc.GetID()] = desc
+	}
+	for _, rev := range revs {
+		if rev.Desc == nil {
+			delete(byID, rev.ID)
+			continue
+		}
+		byID[rev.ID] = descbuilder.NewBuilder(rev.Desc).BuildImmutable()
+	}
+	res := make([]catalog.Descriptor, 0, len(byID))
+	for _, desc := range byID {
+		if table, ok := desc.(catalog.TableDescriptor); ok && table.Dropped() {
+			continue
+		}
+		res = append(res, desc)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].GetID() < res[j].GetID() })
+	return res
+}
+
+// writeSegment writes the segment described by m, with the revisions in buf up
+// to the end of the segment, to the revision log. Revisions after the end of
+// the segment are left in buf for the next one. The manifest is written last,
+// so that the segment is only listed once it is complete.
+func (w *revisionLogWriter) writeSegment(
+	ctx context.Context, m *BackupManifest, buf *revisionLogBuffer,
+) error {
+	uri, err := revisionLogSegmentURI(w.details.URI, m.StartTime)
+	if err != nil {
+		return err
+	}
+	store, err := w.execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, uri, w.user)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := w.addRangeTombstones(ctx, buf, m.Spans, m.EndTime); err != nil {
+		return err
+	}
+
+	pkIDs := make(map[uint64]bool)
+	for i := range m.Descriptors {
+		if t, _, _, _ := descpb.FromDescriptor(&m.Descriptors[i]); t != nil {
+			pkIDs[roachpb.BulkOpSummaryID(uint64(t.ID), uint64(t.PrimaryIndex.ID))] = true
+		}
+	}
+	data := &revisionLogDataWriter{
+		w:          w,
+		store:      store,
+		spans:      m.Spans,
+		pkIDs:      pkIDs,
+		targetSize: targetFileSize.Get(&w.execCfg.Settings.SV),
+		cur:        -1,
+	}
+	defer data.close()
+
+	batch := buf.take()
+	defer buf.release(ctx, batch)
+	if err := batch.forEach(func(ev revisionLogEvent) error {
+		if m.EndTime.Less(ev.key.Timestamp) {
+			return buf.add(ctx, ev)
+		}
+		return data.add(ctx, ev)
+	}); err != nil {
+		return errors.Wrap(err, "writing revision log segment data")
+	}
+	if err := data.finish(); err != nil {
+		return errors.Wrap(err, "writing revision log segment data")
+	}
+	m.Files = data.files
+	for _, f := range m.Files {
+		m.EntryCounts.Add(f.EntryCounts)
+	}
+	return writeBackupManifest(ctx, w.execCfg.Settings, store, backupManifestName,
+		w.details.EncryptionOptions, m)
+}
+
+// addRangeTombstones adds to buf, for each range tombstone in buf up to end,
+// a point tombstone at the time of the range tombstone for every key within
+// spans that it deleted. Restore only reads the point keys of backups, so the
+// revision log records range tombstones as the point tombstones they amount
+// to.
+func (w *revisionLogWriter) addRangeTombstones(
+	ctx context.Context, buf *revisionLogBuffer, spans roachpb.Spans, end hlc.Timestamp,
+) error {
+	for _, del := range buf.takeDeleteRanges(ctx, end) {
+		for _, sp := range spans {
+			sp = sp.Intersect(del.Span)
+			if !sp.Valid() {
+				continue
+			}
+			ts := del.Timestamp
+			if err := w.execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+				if err := txn.SetFixedTimestamp(ctx, ts.Prev()); err != nil {
+					return err
+				}
+				return txn.Iterate(ctx, sp.Key, sp.EndKey, revisionLogScanPageSize,
+					func(rows []kv.KeyValue) error {
+						for _, row := range rows {
+							ev := revisionLogEvent{key: storage.MVCCKey{Key: row.Key, Timestamp: ts}}
+							if err := buf.add(ctx, ev); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			}); err != nil {
+				return errors.Wrapf(err, "reading the keys deleted by the range tombstone over %s at %s",
+					sp, ts)
+			}
+		}
+	}
+	return nil
+}
+
+// revisionLogDataWriter writes the sorted revisions of a segment to its SSTs.
+// Each file covers a part of one of the spans of the segment that has
+// revisions, and a new file is started once the current one grows over
+// targetSize. Revisions outside the spans, which the rangefeed may have
+// received over spans that are no longer watched, are left out.
+type revisionLogDataWriter struct {
+	w          *revisionLogWriter
+	store      cloud.ExternalStorage
+	spans      roachpb.Spans
+	pkIDs      map[uint64]bool
+	targetSize int64
+
+	// files are the files written so far.
+	files []BackupManifest_File
+	// i is the index of the span of the last revision added, and cur that of
+	// the span of the open file, or -1 if none was opened yet.
+	i, cur int
+	// out and sst write the open file, if out is not nil, whose path and start
+	// key are recorded in file.
+	out     io.WriteCloser
+	sst     storage.SSTWriter
+	file    BackupManifest_File
+	rows    storage.RowCounter
+	lastKey roachpb.Key
+}
+
+func (d *revisionLogDataWriter) add(ctx context.Context, ev revisionLogEvent) error {
+	for d.i < len(d.spans) && d.spans[d.i].EndKey.Compare(ev.key.Key) <= 0 {
+		d.i++
+	}
+	if d.i == len(d.spans) || ev.key.Key.Compare(d.spans[d.i].Key) < 0 {
+		return nil
+	}
+	if d.out != nil {
+		if d.i != d.cur {
+			if err := d.finishFile(d.spans[d.cur].EndKey); err != nil {
+				return err
+			}
+		} else if d.sst.DataSize >= d.targetSize && !ev.key.Key.Equal(d.lastKey) {
+			// The revisions of a key are never split across files.
+			if err := d.finishFile(ev.key.Key); err != nil {
+				return err
+			}
+		}
+	}
+	if d.out == nil {
+		start := d.spans[d.i].Key
+		if d.i == d.cur {
+			start = ev.key.Key
+		}
+		if err := d.openFile(ctx, start); err != nil {
+			return err
+		}
+	}
+	if err := d.sst.PutMVCC(ev.key, ev.value); err != nil {
+		return err
+	}
+	if err := d.rows.Count(ev.key.Key); err != nil {
+		return err
+	}
+	d.rows.DataSize += int64(len(ev.key.Key)) + int64(len(ev.value))
+	d.lastKey = ev.key.Key
+	return nil
+}
+
+func (d *revisionLogDataWriter) openFile(ctx context.Context, start roachpb.Key) error {
+	path := generateUniqueSSTName(d.w.execCfg.NodeID.SQLInstanceID())
+	out, err := d.store.Writer(ctx, path)
+	if err != nil {
+		return err
+	}
+	if d.w.encryption != nil {
+		if out, err = storageccl.EncryptingWriter(out, d.w.encryption.Key); err != nil {
+			return err
+		}
+	}
+	d.out = out
+	d.sst = storage.MakeBackupSSTWriter(ctx, d.w.execCfg.Settings, out)
+	d.file = BackupManifest_File{Span: roachpb.Span{Key: start}, Path: path}
+	d.cur = d.i
+	return nil
+}
+
+// finishFile finishes the open file, which covers its span up to end.
+func (d *revisionLogDataWriter) finishFile(end roachpb.Key) error {
+	if err := d.sst.Finish(); err != nil {
+		return err
+	}
+	d.sst.Close()
+	out := d.out
+	d.out = nil
+	if err := out.Close(); err != nil {
+		return errors.Wrap(err, "writing SST")
+	}
+	d.file.Span.EndKey = end
+	d.file.EntryCounts = countRows(d.rows.BulkOpSummary, d.pkIDs)
+	d.files = append(d.files, d.file)
+	d.rows = storage.RowCounter{}
+	return nil
+}
+
+// finish finishes the open file, if any, after the last revision was added.
+func (d *revisionLogDataWriter) finish() error {
+	if d.out == nil {
+		return nil
+	}
+	return d.finishFile(d.spans[d.cur].EndKey)
+}
+
+// close releases the open file, if any, if the segment could not be written.
+func (d *revisionLogDataWriter) close() {
+	if d.out != nil {
+		d.sst.Close()
+	}
+}
+
+// revisionLogEvent is a revision received by the rangefeed of a revision log.
+// A deletion has an empty value.
+type revisionLogEvent struct {
+	key   storage.MVCCKey
+	value []byte
+}
+
+// revisionLogEventOverhead is the memory accounted for each buffered revision
+// in addition to its key and value.
+const revisionLogEventOverhead = 48
+
+func (ev revisionLogEvent) size() int64 {
+	return int64(len(ev.key.Key)+len(ev.value)) + revisionLogEventOverhead
+}
+
+// encodeRevisionLogKey encodes key such that the encoded keys sort like the
+// keys themselves, so that revisions spilled to disk are read back in order.
+func encodeRevisionLogKey(key storage.MVCCKey) []byte {
+	b := encoding.EncodeBytesAscending(nil, key.Key)
+	b = encoding.EncodeUint64Descending(b, uint64(key.Timestamp.WallTime))
+	return encoding.EncodeUint32Descending(b, uint32(key.Timestamp.Logical))
+}
+
+// decodeRevisionLogEvent decodes a revision spilled to disk.
+func decodeRevisionLogEvent(k, v []byte) (revisionLogEvent, error) {
+	k, key, err := encoding.DecodeBytesAscendingDeepCopy(k, nil)
+	if err != nil {
+		return revisionLogEvent{}, err
+	}
+	k, wallTime, err := encoding.DecodeUint64Descending(k)
+	if err != nil {
+		return revisionLogEvent{}, err
+	}
+	_, logical, err := encoding.DecodeUint32Descending(k)
+	if err != nil {
+		return revisionLogEvent{}, err
+	}
+	return revisionLogEvent{
+		key: storage.MVCCKey{
+			Key:       key,
+			Timestamp: hlc.Timestamp{WallTime: int64(wallTime), Logical: int32(logical)},
+		},
+		value: append([]byte(nil), v...),
+	}, nil
+}
+
+// revisionLogBuffer buffers the revisions received by the rangefeeds of a
+// revision log until they are written to a segment. The revisions are kept in
+// memory until they grow over limit, at which point they are spilled to disk,
+// so that revisions the log cannot write yet, because some rangefeed lags
+// behind, never exhaust the memory of the node.
+type revisionLogBuffer struct {
+	limit       int64
+	tempStorage diskmap.Factory
+	// full is signaled when the revisions in memory grow over limit, or when
+	// they could not be buffered.
+	full  chan struct{}
+	errCh chan error
+
+	mu struct {
+		syncutil.Mutex
+		acc mon.BoundAccount
+		// events are the revisions buffered in memory, whose size is eventsSize.
+		events     []revisionLogEvent
+		eventsSize int64
+		// spilled holds the revisions spilled to disk, if any.
+		spilled diskmap.SortedDiskMap
+		// deleteRanges are the buffered range tombstones.
+		deleteRanges []roachpb.RangeFeedDeleteRange
+		// frontiers are the frontiers of each rangefeed.
+		frontiers []hlc.Timestamp
+		err       error
+	}
+}
+
+func (b *revisionLogBuffer) onValue(ctx context.Context, value *roachpb.RangeFeedValue) {
+	// An error is recorded in the buffer, and returned by frontier.
+	_ = b.add(ctx, revisionLogEvent{
+		key: storage.MVCCKey{
+			Key:       append(roachpb.Key(nil), value.Key...),
+			Timestamp: value.Value.Timestamp,
+		},
+		value: append([]byte(nil), value.Value.RawBytes...),
+	})
+}
+
+func (b *revisionLogBuffer) onDeleteRange(
+	ctx context.Context, value *roachpb.RangeFeedDeleteRange,
+) {
+	del := roachpb.RangeFeedDeleteRange{
+		Span: roachpb.Span{
+			Key:    append(roachpb.Key(nil), value.Span.Key...),
+			EndKey: append(roachpb.Key(nil), value.Span.EndKey...),
+		},
+		Timestamp: value.Timestamp,
+	}
+	size := int64(len(del.Span.Key)+len(del.Span.EndKey)) + revisionLogEventOverhead
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mu.err != nil {
+		return
+	}
+	if err := b.mu.acc.Grow(ctx, size); err != nil {
+		b.failLocked(errors.Wrap(err, "buffering range tombstones"))
+		return
+	}
+	b.mu.deleteRanges = append(b.mu.deleteRanges, del)
+}
+
+// add buffers ev. The revisions in memory are spilled to disk once they grow
+// over the limit, or the memory budget runs out.
+func (b *revisionLogBuffer) add(ctx context.Context, ev revisionLogEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mu.err != nil {
+		return b.mu.err
+	}
+	if err := b.mu.acc.Grow(ctx, ev.size()); err != nil {
+		if err := b.spillLocked(ctx); err != nil {
+			return b.failLocked(err)
+		}
+		if err := b.mu.acc.Grow(ctx, ev.size()); err != nil {
+			return b.failLocked(errors.Wrap(err, "buffering revisions"))
+		}
+	}
+	b.mu.events = append(b.mu.events, ev)
+	b.mu.eventsSize += ev.size()
+	if b.mu.eventsSize >= b.limit {
+		b.signalFull()
+		if err := b.spillLocked(ctx); err != nil {
+			return b.failLocked(err)
+		}
+	}
+	return nil
+}
+
+// spillLocked moves the revisions in memory to disk.
+func (b *revisionLogBuffer) spillLocked(ctx context.Context) error {
+	if len(b.mu.events) == 0 {
+		return nil
+	}
+	if b.mu.spilled == nil {
+		b.mu.spilled = b.tempStorage.NewSortedDiskMap()
+	}
+	w := b.mu.spilled.NewBatchWriter()
+	for _, ev := range b.mu.events {
+		if err := w.Put(encodeRevisionLogKey(ev.key), ev.value); err != nil {
+			_ = w.Close(ctx)
+			return errors.Wrap(err, "spilling revisions to disk")
+		}
+	}
+	if err := w.Close(ctx); err != nil {
+		return errors.Wrap(err, "spilling revisions to disk")
+	}
+	b.mu.acc.Shrink(ctx, b.mu.eventsSize)
+	b.mu.events, b.mu.eventsSize = nil, 0
+	return nil
+}
+
+func (b *revisionLogBuffer) failLocked(err error) error {
+	b.mu.err = err
+	b.signalFull()
+	return err
+}
+
+func (b *revisionLogBuffer) signalFull() {
+	select {
+	case b.full <- struct{}{}:
+	default:
+	}
+}
+
+func (b *revisionLogBuffer) onFrontierAdvance(i int, ts hlc.Timestamp) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.frontiers[i].Forward(ts)
+}
+
+// frontier returns the time up to which every rangefeed has delivered all the
+// revisions, or an empty timestamp if some rangefeed has not yet advanced.
+func (b *revisionLogBuffer) frontier() (hlc.Timestamp, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mu.err != nil {
+		return hlc.Timestamp{}, b.mu.err
+	}
+	var frontier hlc.Timestamp
+	for i, ts := range b.mu.frontiers {
+		if i == 0 || ts.Less(frontier) {
+			frontier = ts
+		}
+	}
+	return frontier, nil
+}
+
+// takeDeleteRanges removes the buffered range tombstones at or before end from
+// the buffer, and returns them.
+func (b *revisionLogBuffer) takeDeleteRanges(
+	ctx context.Context, end hlc.Timestamp,
+) []roachpb.RangeFeedDeleteRange {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var taken []roachpb.RangeFeedDeleteRange
+	var kept []roachpb.RangeFeedDeleteRange
+	var size int64
+	for _, del := range b.mu.deleteRanges {
+		if end.Less(del.Timestamp) {
+			kept = append(kept, del)
+			continue
+		}
+		taken = append(taken, del)
+		size += int64(len(del.Span.Key)+len(del.Span.EndKey)) + revisionLogEventOverhead
+	}
+	b.mu.deleteRanges = kept
+	b.mu.acc.Shrink(ctx, size)
+	return taken
+}
+
+// revisionLogBatch holds the revisions taken out of a revisionLogBuffer.
+type revisionLogBatch struct {
+	events  []revisionLogEvent
+	size    int64
+	spilled diskmap.SortedDiskMap
+}
+
+// take removes all the buffered revisions from the buffer. The revisions of
+// the batch stay accounted for until it is released.
+func (b *revisionLogBuffer) take() revisionLogBatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	batch := revisionLogBatch{events: b.mu.events, size: b.mu.eventsSize, spilled: b.mu.spilled}
+	b.mu.events, b.mu.eventsSize, b.mu.spilled = nil, 0, nil
+	return batch
+}
+
+// release releases the resources of a batch returned by take.
+func (b *revisionLogBuffer) release(ctx context.Context, batch revisionLogBatch) {
+	if batch.spilled != nil {
+		batch.spilled.Close(ctx)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.acc.Shrink(ctx, batch.size)
+}
+
+// forEach calls fn with each revision of the batch, sorted and without
+// duplicates, merging the revisions in memory with those spilled to disk.
+func (batch *revisionLogBatch) forEach(fn func(revisionLogEvent) error) error {
+	events := batch.events
+	sort.Slice(events, func(i, j int) bool { return events[i].key.Less(events[j].key) })
+
+	var it diskmap.SortedDiskMapIterator
+	var spilled revisionLogEvent
+	var spilledOK bool
+	nextSpilled := func() error {
+		if it == nil {
+			return nil
+		}
+		ok, err := it.Valid()
+		if err != nil || !ok {
+			spilledOK = false
+			return err
+		}
+		spilled, err = decodeRevisionLogEvent(it.UnsafeKey(), it.UnsafeValue())
+		spilledOK = err == nil
+		it.Next()
+		return err
+	}
+	if batch.spilled != nil {
+		it = batch.spilled.NewIterator()
+		defer it.Close()
+		it.Rewind()
+	}
+	if err := nextSpilled(); err != nil {
+		return err
+	}
+
+	var last storage.MVCCKey
+	for len(events) > 0 || spilledOK {
+		var ev revisionLogEvent
+		if len(events) > 0 && (!spilledOK || !spilled.key.Less(events[0].key)) {
+			ev, events = events[0], events[1:]
+		} else {
+			ev = spilled
+			if err := nextSpilled(); err != nil {
+				return err
+			}
+		}
+		if ev.key.Equal(last) {
+			continue
+		}
+		last = ev.key
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *revisionLogBuffer) close(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.mu.events = nil
+	b.mu.deleteRanges = nil
+	if b.mu.spilled != nil {
+		b.mu.spilled.Close(ctx)
+		b.mu.spilled = nil
+	}
+	b.mu.acc.Close(ctx)
+}
@@ -9813,3 +9813,568 @@ func TestBackupRestoreSystemUsers(t *testing.T) {
 		})
 	})
 }
+
+// TestBackupCompaction tests that compacting a backup chain produces a full
+// backup that restores to the same data as the chain, and that it replaces the
+// chain as the latest backup in the collection.
+func TestBackupCompaction(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	const collection = localFoo + "/compaction"
+	sqlDB.Exec(t, `BACKUP INTO $1`, collection)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1 WHERE id < 5`)
+	sqlDB.Exec(t, `BACKUP INTO LATEST IN $1`, collection)
+	sqlDB.Exec(t, `DELETE FROM data.bank WHERE id = 7`)
+	sqlDB.Exec(t, `INSERT INTO data.bank VALUES (100, 100, 'new')`)
+	sqlDB.Exec(t, `BACKUP INTO LATEST IN $1`, collection)
+
+	var chain string
+	sqlDB.QueryRow(t, `SELECT * FROM [SHOW BACKUPS IN $1] ORDER BY path DESC LIMIT 1`, collection).Scan(&chain)
+
+	sqlDB.Exec(t, `BACKUP INTO LATEST IN $1 WITH compact`, collection)
+
+	var compacted string
+	sqlDB.QueryRow(t, `SELECT * FROM [SHOW BACKUPS IN $1] ORDER BY path DESC LIMIT 1`, collection).Scan(&compacted)
+	require.NotEqual(t, chain, compacted)
+	require.True(t, strings.HasSuffix(compacted, compactedBackupSuffix), compacted)
+
+	// The compacted backup is a single full backup.
+	sqlDB.CheckQueryResults(t,
+		`SELECT DISTINCT backup_type FROM [SHOW BACKUP LATEST IN $1]`, [][]string{{"full"}}, collection)
+
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 WITH new_db_name = 'restored'`, collection)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM restored.bank ORDER BY id`,
+		sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`))
+
+	// Incremental backups are appended to the compacted backup.
+	sqlDB.Exec(t, `INSERT INTO data.bank VALUES (101, 101, 'newer')`)
+	sqlDB.Exec(t, `BACKUP INTO LATEST IN $1`, collection)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 WITH new_db_name = 'restored2'`, collection)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM restored2.bank ORDER BY id`,
+		sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`))
+
+	t.Run("errors", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "compact does not accept targets",
+			`BACKUP DATABASE data INTO LATEST IN $1 WITH compact`, collection)
+		sqlDB.ExpectErr(t, "compact cannot be used with revision_history",
+			`BACKUP INTO LATEST IN $1 WITH compact, revision_history`, collection)
+		sqlDB.ExpectErr(t, "compact requires the BACKUP INTO LATEST IN or BACKUP INTO <subdir> IN syntax",
+			`BACKUP TO $1 WITH compact`, localFoo+"/compaction-to")
+	})
+}
+
+// TestBackupCompactionRangeTombstones tests that a compaction applies the MVCC
+// range tombstones exported by the incremental backups of the chain.
+func TestBackupCompactionRangeTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	tc, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+	ctx := context.Background()
+	sqlDB.Exec(t, `SET CLUSTER SETTING storage.mvcc.range_tombstones.enabled = true`)
+
+	const collection = localFoo + "/compaction-range-tombstones"
+	sqlDB.Exec(t, `BACKUP INTO $1`, collection)
+
+	// Delete all rows of the table with a range tombstone, then write one above
+	// it.
+	tableID := sqlutils.QueryTableID(t, tc.ServerConn(0), "data", "public", "bank")
+	prefix := keys.SystemSQLCodec.IndexPrefix(tableID, 1)
+	require.NoError(t, tc.Server(0).DB().DelRangeUsingTombstone(ctx, prefix, prefix.PrefixEnd()))
+	sqlDB.Exec(t, `INSERT INTO data.bank VALUES (100, 100, 'new')`)
+	sqlDB.Exec(t, `BACKUP INTO LATEST IN $1`, collection)
+
+	sqlDB.Exec(t, `BACKUP INTO LATEST IN $1 WITH compact`, collection)
+	sqlDB.CheckQueryResults(t,
+		`SELECT DISTINCT backup_type FROM [SHOW BACKUP LATEST IN $1]`, [][]string{{"full"}}, collection)
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 WITH new_db_name = 'restored'`, collection)
+	sqlDB.CheckQueryResults(t, `SELECT * FROM restored.bank ORDER BY id`, [][]string{{"100", "100", "new"}})
+}
+
+// TestBackupCompactionResume tests that a compaction job can be resumed once
+// it wrote the compacted backup, and that it only replaces LATEST with it if no
+// backup was appended to the chain in the meantime.
+func TestBackupCompactionResume(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	tc, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	const collection = localFoo + "/compaction-resume"
+	sqlDB.Exec(t, `BACKUP INTO $1`, collection)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1 WHERE id < 5`)
+	sqlDB.Exec(t, `BACKUP INTO LATEST IN $1`, collection)
+
+	compactUntilPaused := func() jobspb.JobID {
+		sqlDB.Exec(t, `SET CLUSTER SETTING jobs.debug.pausepoints = 'backup_compaction.after_write_manifest'`)
+		defer sqlDB.Exec(t, `RESET CLUSTER SETTING jobs.debug.pausepoints`)
+		var jobID jobspb.JobID
+		sqlDB.QueryRow(t, `BACKUP INTO LATEST IN $1 WITH compact, detached`, collection).Scan(&jobID)
+		tc.Server(0).JobRegistry().(*jobs.Registry).TestingNudgeAdoptionQueue()
+		testutils.SucceedsSoon(t, func() error {
+			var status string
+			sqlDB.QueryRow(t, `SELECT status FROM [SHOW JOBS] WHERE job_id = $1`, jobID).Scan(&status)
+			if status != string(jobs.StatusPaused) {
+				return errors.Newf("job %d is %s", jobID, status)
+			}
+			return nil
+		})
+		return jobID
+	}
+
+	t.Run("appended", func(t *testing.T) {
+		jobID := compactUntilPaused()
+		// A backup appended to the chain while it is compacted keeps the chain as
+		// the latest backup.
+		sqlDB.Exec(t, `INSERT INTO data.bank VALUES (100, 100, 'new')`)
+		sqlDB.Exec(t, `BACKUP INTO LATEST IN $1`, collection)
+		sqlDB.Exec(t, `RESUME JOB $1`, jobID)
+		waitForSuccessfulJob(t, tc, jobID)
+
+		sqlDB.CheckQueryResults(t,
+			`SELECT DISTINCT backup_type FROM [SHOW BACKUP LATEST IN $1] ORDER BY 1`,
+			[][]string{{"full"}, {"incremental"}}, collection)
+		sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 WITH new_db_name = 'restored'`, collection)
+		sqlDB.CheckQueryResults(t, `SELECT * FROM restored.bank ORDER BY id`,
+			sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`))
+	})
+
+	t.Run("resumed", func(t *testing.T) {
+		jobID := compactUntilPaused()
+		sqlDB.Exec(t, `RESUME JOB $1`, jobID)
+		waitForSuccessfulJob(t, tc, jobID)
+
+		sqlDB.CheckQueryResults(t,
+			`SELECT DISTINCT backup_type FROM [SHOW BACKUP LATEST IN $1]`, [][]string{{"full"}}, collection)
+		sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 WITH new_db_name = 'restored2'`, collection)
+		sqlDB.CheckQueryResults(t, `SELECT * FROM restored2.bank ORDER BY id`,
+			sqlDB.QueryStr(t, `SELECT * FROM data.bank ORDER BY id`))
+	})
+}
+
+func TestRestoreVerifyOnly(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	_, sqlDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	full, inc := localFoo+"/verify-full", localFoo+"/verify-inc"
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, full)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1 WHERE id < 5`)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1 INCREMENTAL FROM $2`, inc, full)
+
+	// An intact backup verifies, and verifying it does not restore anything.
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM $1, $2 WITH verify_only`, full, inc)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.bank`, [][]string{{"10"}})
+
+	sstsIn := func(subdir string) []string {
+		var ssts []string
+		require.NoError(t, filepath.Walk(filepath.Join(dir, "foo", subdir),
+			func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if strings.HasSuffix(path, ".sst") {
+					ssts = append(ssts, path)
+				}
+				return nil
+			}))
+		require.NotEmpty(t, ssts)
+		return ssts
+	}
+
+	// Corrupt a file of the incremental backup.
+	require.NoError(t, ioutil.WriteFile(sstsIn("verify-inc")[0], []byte("garbage"), 0644))
+	sqlDB.ExpectErr(t, `1 of \d+ backup files failed verification\nlayer 1 .*: corrupt file`,
+		`RESTORE DATABASE data FROM $1, $2 WITH verify_only`, full, inc)
+
+	// Remove a file of the full backup.
+	require.NoError(t, os.Remove(sstsIn("verify-full")[0]))
+	sqlDB.ExpectErr(t, `2 of \d+ backup files failed verification\nlayer 0 .*: missing file .*\nlayer 1 .*: corrupt file`,
+		`RESTORE DATABASE data FROM $1, $2 WITH verify_only`, full, inc)
+
+	// A failed verification does not leave anything behind.
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.bank`, [][]string{{"10"}})
+
+	sqlDB.ExpectErr(t, `cannot use "new_db_name" option with "verify_only" option`,
+		`RESTORE DATABASE data FROM $1 WITH verify_only, new_db_name = 'other'`, full)
+}
+
+func TestShowBackupCheckFiles(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	_, sqlDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	collection := localFoo + "/check"
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1`, collection)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1 WHERE id < 5`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO LATEST IN $1`, collection)
+
+	// Every file of both layers of an intact backup checks out.
+	sqlDB.CheckQueryResults(t,
+		`SELECT DISTINCT layer, status, error FROM [SHOW BACKUP CHECK FILES 'LATEST' IN $1] ORDER BY layer`,
+		[][]string{{"0", "ok", "NULL"}, {"1", "ok", "NULL"}}, collection)
+
+	var ssts []string
+	require.NoError(t, filepath.Walk(filepath.Join(dir, "foo", "check"),
+		func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if strings.HasSuffix(path, ".sst") {
+				ssts = append(ssts, path)
+			}
+			return nil
+		}))
+	require.GreaterOrEqual(t, len(ssts), 2)
+
+	require.NoError(t, ioutil.WriteFile(ssts[0], []byte("garbage"), 0644))
+	require.NoError(t, os.Remove(ssts[1]))
+	rows := sqlDB.QueryStr(t,
+		`SELECT path, status FROM [SHOW BACKUP CHECK FILES 'LATEST' IN $1] WHERE status != 'ok' ORDER BY status`,
+		collection)
+	require.Len(t, rows, 2)
+	require.Equal(t, "corrupt", rows[0][1])
+	require.True(t, strings.HasSuffix(ssts[0], rows[0][0]), "%s is not %s", rows[0][0], ssts[0])
+	require.Equal(t, "missing", rows[1][1])
+	require.True(t, strings.HasSuffix(ssts[1], rows[1][0]), "%s is not %s", rows[1][0], ssts[1])
+
+	sqlDB.ExpectErr(t, `as_json cannot be used with SHOW BACKUP CHECK FILES`,
+		`SHOW BACKUP CHECK FILES 'LATEST' IN $1 WITH as_json`, collection)
+}
+
+func TestRestoreWhere(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 100
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `CREATE INDEX balance_idx ON data.bank (balance)`)
+	sqlDB.Exec(t, `CREATE TABLE data.rev (k INT, v STRING, PRIMARY KEY (k DESC), INDEX v_idx (v))`)
+	sqlDB.Exec(t, `INSERT INTO data.rev SELECT i, i::STRING FROM generate_series(1, 10) AS g(i)`)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+	sqlDB.Exec(t, `CREATE DATABASE restored`)
+
+	sqlDB.Exec(t, `RESTORE TABLE data.bank FROM $1
+WHERE id < 3 OR id IN (20, 30) OR (id BETWEEN 50 AND 60 AND 52 >= id)
+WITH into_db = 'restored'`, localFoo)
+	expected := [][]string{{"0"}, {"1"}, {"2"}, {"20"}, {"30"}, {"50"}, {"51"}, {"52"}}
+	sqlDB.CheckQueryResults(t, `SELECT id FROM restored.bank ORDER BY id`, expected)
+	// The secondary index is rebuilt from the restored rows.
+	sqlDB.CheckQueryResultsRetry(t, `SELECT id FROM restored.bank@balance_idx ORDER BY id`, expected)
+
+	sqlDB.Exec(t, `RESTORE TABLE data.rev FROM $1 WHERE k > 7 WITH into_db = 'restored'`, localFoo)
+	expected = [][]string{{"8", "8"}, {"9", "9"}, {"10", "10"}}
+	sqlDB.CheckQueryResults(t, `SELECT k, v FROM restored.rev ORDER BY k`, expected)
+	sqlDB.CheckQueryResultsRetry(t, `SELECT k, v FROM restored.rev@v_idx ORDER BY k`, expected)
+
+	t.Run("errors", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "RESTORE ... WHERE can only be used with RESTORE TABLE of a single table",
+			`RESTORE DATABASE data FROM $1 WHERE id = 1`, localFoo)
+		sqlDB.ExpectErr(t, "RESTORE ... WHERE can only be used to restore a single table",
+			`RESTORE TABLE data.* FROM $1 WHERE id = 1 WITH into_db = 'restored'`, localFoo)
+		sqlDB.ExpectErr(t, `only supports comparisons of the leading primary key column "id"`,
+			`RESTORE TABLE data.bank FROM $1 WHERE balance = 1 WITH into_db = 'other'`, localFoo)
+		sqlDB.ExpectErr(t, "matches no rows",
+			`RESTORE TABLE data.bank FROM $1 WHERE id > 5 AND id < 3 WITH into_db = 'other'`, localFoo)
+	})
+}
+
+func TestRestoreTableAs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	_, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	sqlDB.Exec(t, `CREATE SCHEMA data.sc`)
+	sqlDB.Exec(t, `CREATE VIEW data.v AS SELECT id FROM data.bank`)
+	sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo)
+	sqlDB.Exec(t, `UPDATE data.bank SET balance = balance + 1`)
+
+	// A point-in-time copy of the table can be restored next to the live one.
+	sqlDB.Exec(t, `RESTORE TABLE data.bank AS bank_copy FROM $1`, localFoo)
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*) FROM data.bank JOIN data.bank_copy USING (id) WHERE data.bank.balance = bank_copy.balance + 1`,
+		[][]string{{strconv.Itoa(numAccounts)}})
+
+	// The copy can also be restored into another schema, given either by the
+	// new_schema option or by qualifying the new name.
+	sqlDB.Exec(t, `RESTORE TABLE data.bank FROM $1 WITH new_schema = 'sc'`, localFoo)
+	sqlDB.Exec(t, `RESTORE TABLE data.bank AS data.sc.bank_copy FROM $1`, localFoo)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.sc.bank`, [][]string{{strconv.Itoa(numAccounts)}})
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM data.sc.bank_copy`, [][]string{{strconv.Itoa(numAccounts)}})
+
+	t.Run("errors", func(t *testing.T) {
+		sqlDB.ExpectErr(t, `relation "bank" already exists`,
+			`RESTORE TABLE data.bank FROM $1`, localFoo)
+		sqlDB.ExpectErr(t, `RESTORE TABLE ... AS can only be used to restore a single table`,
+			`RESTORE TABLE data.* AS foo FROM $1`, localFoo)
+		sqlDB.ExpectErr(t, `schema "missing" does not exist in database "data"`,
+			`RESTORE TABLE data.bank AS bank_copy2 FROM $1 WITH new_schema = 'missing'`, localFoo)
+		sqlDB.ExpectErr(t, `cannot restore view "v" with the "new_schema" option`,
+			`RESTORE TABLE data.v FROM $1 WITH new_schema = 'sc', skip_missing_views`, localFoo)
+		sqlDB.ExpectErr(t, `"new_schema" option can only be used for RESTORE TABLE`,
+			`RESTORE DATABASE data FROM $1 WITH new_schema = 'sc'`, localFoo)
+		sqlDB.ExpectErr(t, `cannot use "into_db" option when the new table name is qualified with a database`,
+			`RESTORE TABLE data.bank AS data.bank_copy2 FROM $1 WITH into_db = 'data'`, localFoo)
+	})
+}
+
+func TestBackupMirror(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const numAccounts = 10
+	_, sqlDB, dir, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+
+	collection, mirror1, mirror2 := localFoo+"/collection", localFoo+"/mirror1", localFoo+"/mirror2"
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO $1 WITH mirror = ($2, $3)`, collection, mirror1, mirror2)
+
+	// The backup processors write the data files to the mirrors, so the job
+	// only copies the other files of the backup to them.
+	var fullJobID jobspb.JobID
+	sqlDB.QueryRow(t, `SELECT job_id FROM [SHOW JOBS] WHERE job_type = 'BACKUP'`).Scan(&fullJobID)
+	fullMirrors := jobutils.GetJobProgress(t, sqlDB, fullJobID).GetBackup().Mirrors
+	require.Len(t, fullMirrors, 2)
+	for i, mirror := range []string{"mirror1", "mirror2"} {
+		var subdir string
+		sqlDB.QueryRow(t, `SELECT * FROM [SHOW BACKUPS IN $1]`, localFoo+"/"+mirror).Scan(&subdir)
+		root := filepath.Join(dir, "foo", mirror, subdir)
+		var dataFiles, otherFiles int64
+		require.NoError(t, filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			if strings.HasPrefix(path, filepath.Join(root, backupDataDirectory)+"/") {
+				dataFiles++
+			} else {
+				otherFiles++
+			}
+			return nil
+		}))
+		require.NotZero(t, dataFiles)
+		require.Equal(t, otherFiles, fullMirrors[i].FilesCopied)
+	}
+	sqlDB.Exec(t, `INSERT INTO data.bank VALUES (1000, 1, 'new')`)
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO LATEST IN $1 WITH mirror = ($2, $3)`, collection, mirror1, mirror2)
+
+	// The job records that each mirror holds a complete copy.
+	var jobID jobspb.JobID
+	sqlDB.QueryRow(t, `SELECT job_id FROM [SHOW JOBS] WHERE job_type = 'BACKUP' ORDER BY created DESC LIMIT 1`).Scan(&jobID)
+	mirrors := jobutils.GetJobProgress(t, sqlDB, jobID).GetBackup().Mirrors
+	require.Len(t, mirrors, 2)
+	for _, m := range mirrors {
+		require.True(t, m.Done)
+		require.NotZero(t, m.FilesCopied)
+	}
+
+	// The whole backup chain can be restored from each mirror.
+	for _, uri := range []string{collection, mirror1, mirror2} {
+		sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 WITH new_db_name = 'restored'`, uri)
+		sqlDB.CheckQueryResults(t, `SELECT count(*) FROM restored.bank`, [][]string{{strconv.Itoa(numAccounts + 1)}})
+		sqlDB.Exec(t, `DROP DATABASE restored CASCADE`)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "is specified more than once or is the backup destination",
+			`BACKUP DATABASE data INTO $1 WITH mirror = $1`, collection)
+		sqlDB.ExpectErr(t, "mirror cannot be used with incremental_location",
+			`BACKUP DATABASE data INTO LATEST IN $1 WITH mirror = $2, incremental_location = $3`,
+			collection, mirror1, localFoo+"/inc")
+
+		// A mirror is never written over another backup, and the backup fails if
+		// it cannot be mirrored.
+		sqlDB.Exec(t, `BACKUP DATABASE data TO $1`, localFoo+"/plain")
+		sqlDB.ExpectErr(t, "already contains a BACKUP_MANIFEST file",
+			`BACKUP DATABASE data TO $1 WITH mirror = $2`, localFoo+"/plain2", localFoo+"/plain")
+	})
+}
+
+// TestAlterBackupRateLimits tests that ALTER BACKUP ... SET changes the rate
+// limits of a running backup, that the processors of BACKUP and RESTORE jobs
+// throttle to their job and cluster limits, and that the time spent throttled
+// is reported.
+func TestAlterBackupRateLimits(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// Have the processors reload the limits of their job on every acquisition.
+	defer func(oldInterval time.Duration) {
+		jobRateLimitsRefreshInterval = oldInterval
+	}(jobRateLimitsRefreshInterval)
+	jobRateLimitsRefreshInterval = 0
+
+	exporting := make(chan struct{}, 1)
+	allowExports := make(chan struct{})
+	params := base.TestClusterArgs{}
+	params.ServerArgs.Knobs = base.TestingKnobs{
+		DistSQL: &execinfra.TestingKnobs{
+			BackupRestoreTestingKnobs: &sql.BackupRestoreTestingKnobs{
+				RunAfterExportingSpanEntry: func(_ context.Context, _ *roachpb.ExportResponse) {
+					select {
+					case exporting <- struct{}{}:
+					default:
+					}
+					<-allowExports
+				},
+			},
+		},
+		JobsTestingKnobs: jobs.NewTestingKnobsWithShortIntervals(),
+	}
+
+	const numAccounts = 100
+	tc, sqlDB, _, cleanupFn := backupRestoreTestSetupWithParams(t, singleNode, numAccounts,
+		InitManualReplication, params)
+	defer cleanupFn()
+	registry := tc.Server(0).JobRegistry().(*jobs.Registry)
+	metrics := registry.MetricsStruct().Backup.(*bulkIOMetrics)
+
+	// Every exported span is written separately, so that the writes are
+	// throttled one at a time.
+	sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.backup.file_size = '1'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.backup.merge_file_buffer_size = '1'`)
+
+	var backupID jobspb.JobID
+	sqlDB.QueryRow(t, `BACKUP DATABASE data TO $1 WITH detached`, localFoo).Scan(&backupID)
+	<-exporting
+
+	sqlDB.ExpectErr(t, `no running BACKUP or RESTORE job to which the options apply`,
+		`ALTER BACKUP $1 SET ingest_rate_limit = '1MiB'`, localFoo)
+	sqlDB.ExpectErr(t, `no running BACKUP or RESTORE job to which the options apply`,
+		`ALTER BACKUP $1 SET read_rate_limit = '1MiB'`, localFoo+"/other")
+	sqlDB.ExpectErr(t, `invalid value for "read_rate_limit"`,
+		`ALTER BACKUP $1 SET read_rate_limit = 'fast'`, localFoo)
+	sqlDB.ExpectErr(t, `invalid option "rate_limit"`,
+		`ALTER BACKUP $1 SET rate_limit = '1MiB'`, localFoo)
+
+	sqlDB.Exec(t, `ALTER BACKUP $1 SET read_rate_limit = '8KiB', write_rate_limit = '8KiB'`, localFoo)
+	job, err := registry.LoadJob(context.Background(), backupID)
+	require.NoError(t, err)
+	require.Equal(t, jobspb.BulkIORateLimits{
+		ReadBytesPerSecond:  8 << 10,
+		WriteBytesPerSecond: 8 << 10,
+	}, job.Details().(jobspb.BackupDetails).RateLimits)
+
+	close(allowExports)
+	jobutils.WaitForJob(t, sqlDB, backupID)
+	require.Greater(t, metrics.ReadThrottledNanos.Count(), int64(0))
+	require.Greater(t, metrics.WriteThrottledNanos.Count(), int64(0))
+
+	// Terminal jobs can no longer be altered.
+	sqlDB.ExpectErr(t, `no running BACKUP or RESTORE job to which the options apply`,
+		`ALTER BACKUP $1 SET read_rate_limit = '0'`, localFoo)
+
+	// The cluster limit throttles restores.
+	sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.restore.ingest_rate_limit = '8KiB'`)
+	sqlDB.Exec(t, `CREATE DATABASE restoredb`)
+	sqlDB.Exec(t, `RESTORE data.bank FROM $1 WITH into_db = 'restoredb'`, localFoo)
+	require.Greater(t, metrics.IngestThrottledNanos.Count(), int64(0))
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM restoredb.bank`,
+		[][]string{{strconv.Itoa(numAccounts)}})
+}
+
+// TestContinuousBackup tests that a continuous backup keeps a revision log of
+// the backed up data, including tables created after its full backup and keys
+// deleted by range tombstones, and that its backup can be restored as of any
+// time the log covers, even with incremental backups appended to it.
+func TestContinuousBackup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const numAccounts = 10
+	tc, sqlDB, _, cleanupFn := backupRestoreTestSetup(t, singleNode, numAccounts, InitManualReplication)
+	defer cleanupFn()
+	kvDB := tc.Server(0).DB()
+
+	collection := localFoo + "/continuous"
+	sqlDB.ExpectErr(t, "continuous backups require the kv.rangefeed.enabled setting",
+		`BACKUP DATABASE data INTO $1 WITH continuous, detached`, collection)
+
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.backup.revision_log.flush_interval = '100ms'`)
+	// Spill the buffered revisions to disk all the time.
+	sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.backup.revision_log.buffer_size = '1KiB'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING storage.mvcc.range_tombstones.enabled = true`)
+
+	sqlDB.ExpectErr(t, "continuous requires the detached option",
+		`BACKUP DATABASE data INTO $1 WITH continuous`, collection)
+	sqlDB.ExpectErr(t, "continuous requires the BACKUP INTO <collection> syntax",
+		`BACKUP DATABASE data TO $1 WITH continuous, detached`, collection)
+	sqlDB.ExpectErr(t, "continuous cannot be used with mirror",
+		`BACKUP DATABASE data INTO $1 WITH continuous, detached, mirror = $2`,
+		collection, localFoo+"/mirror")
+
+	var jobID int64
+	sqlDB.QueryRow(t, `BACKUP DATABASE data INTO $1 WITH continuous, detached`, collection).Scan(&jobID)
+
+	var ts1, ts2, ts3 string
+	sqlDB.QueryRow(t, `UPDATE data.bank SET balance = 100 RETURNING cluster_logical_timestamp()`).Scan(&ts1)
+	// An incremental backup without revision history appended to the full
+	// backup does not stop the times it covers from being restored from the
+	// revision log.
+	sqlDB.Exec(t, `BACKUP DATABASE data INTO LATEST IN $1`, collection)
+	sqlDB.Exec(t, `CREATE TABLE data.t2 (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `INSERT INTO data.t2 VALUES (1), (2)`)
+	sqlDB.QueryRow(t, `DELETE FROM data.bank WHERE id < 5 RETURNING cluster_logical_timestamp()`).Scan(&ts2)
+
+	t2 := desctestutils.TestingGetPublicTableDescriptor(kvDB, keys.SystemSQLCodec, "data", "t2")
+	t2Span := t2.TableSpan(keys.SystemSQLCodec)
+	require.NoError(t, kvDB.DelRangeUsingTombstone(ctx, t2Span.Key, t2Span.EndKey))
+	sqlDB.QueryRow(t, `SELECT cluster_logical_timestamp()`).Scan(&ts3)
+
+	// Wait for the revision log to cover the changes.
+	testutils.SucceedsSoon(t, func() error {
+		var covered bool
+		sqlDB.QueryRow(t, `SELECT COALESCE(high_water_timestamp >= $1::DECIMAL, false)
+FROM crdb_internal.jobs WHERE job_id = $2`, ts3, jobID).Scan(&covered)
+		if !covered {
+			return errors.New("revision log does not cover the changes yet")
+		}
+		return nil
+	})
+	sqlDB.Exec(t, `CANCEL JOB $1`, jobID)
+	require.NoError(t, waitForStatus(t, sqlDB, jobID, jobs.StatusCanceled))
+
+	sqlDB.Exec(t, fmt.Sprintf(`RESTORE DATABASE data FROM LATEST IN $1 AS OF SYSTEM TIME %s
+WITH new_db_name = 'restored1'`, ts1), collection)
+	sqlDB.CheckQueryResults(t, `SELECT count(*), sum(balance) FROM restored1.bank`,
+		[][]string{{strconv.Itoa(numAccounts), strconv.Itoa(numAccounts * 100)}})
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM [SHOW TABLES FROM restored1] WHERE table_name = 't2'`,
+		[][]string{{"0"}})
+
+	sqlDB.Exec(t, fmt.Sprintf(`RESTORE DATABASE data FROM LATEST IN $1 AS OF SYSTEM TIME %s
+WITH new_db_name = 'restored2'`, ts2), collection)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM restored2.bank`,
+		[][]string{{strconv.Itoa(numAccounts - 5)}})
+	sqlDB.CheckQueryResults(t, `SELECT k FROM restored2.t2 ORDER BY k`, [][]string{{"1"}, {"2"}})
+
+	sqlDB.Exec(t, fmt.Sprintf(`RESTORE DATABASE data FROM LATEST IN $1 AS OF SYSTEM TIME %s
+WITH new_db_name = 'restored3'`, ts3), collection)
+	sqlDB.CheckQueryResults(t, `SELECT count(*) FROM restored3.t2`, [][]string{{"0"}})
+
+	// Without a time, the full backup and its incremental backup are restored.
+	sqlDB.Exec(t, `RESTORE DATABASE data FROM LATEST IN $1 WITH new_db_name = 'restored4'`, collection)
+	sqlDB.CheckQueryResults(t, `SELECT count(*), sum(balance) FROM restored4.bank`,
+		[][]string{{strconv.Itoa(numAccounts), strconv.Itoa(numAccounts * 100)}})
+}
@@ -0,0 +1,431 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+)
+
+var (
+	backupReadRateLimit = settings.RegisterByteSizeSetting(
+		settings.TenantWritable,
+		"bulkio.backup.read_rate_limit",
+		"limit on the bytes per second that BACKUP jobs read across the cluster; 0 disables the limit",
+		0,
+		settings.NonNegativeInt,
+	).WithPublic()
+	backupWriteRateLimit = settings.RegisterByteSizeSetting(
+		settings.TenantWritable,
+		"bulkio.backup.write_rate_limit",
+		"limit on the bytes per second that BACKUP jobs write to external storage across the cluster; 0 disables the limit",
+		0,
+		settings.NonNegativeInt,
+	).WithPublic()
+	restoreIngestRateLimit = settings.RegisterByteSizeSetting(
+		settings.TenantWritable,
+		"bulkio.restore.ingest_rate_limit",
+		"limit on the bytes per second that RESTORE jobs ingest across the cluster; 0 disables the limit",
+		0,
+		settings.NonNegativeInt,
+	).WithPublic()
+)
+
+// jobRateLimitsRefreshInterval is how often a processor reloads its job to
+// pick up rate limits that were changed with ALTER BACKUP.
+var jobRateLimitsRefreshInterval = 10 * time.Second
+
+// bulkIOKind is a kind of data movement of BACKUP and RESTORE jobs that can be
+// rate limited.
+type bulkIOKind int
+
+const (
+	// bulkIORead is the data a backup exports.
+	bulkIORead bulkIOKind = iota
+	// bulkIOWrite is the data a backup writes to external storage.
+	bulkIOWrite
+	// bulkIOIngest is the data a restore ingests.
+	bulkIOIngest
+	numBulkIOKinds
+)
+
+func (k bulkIOKind) String() string {
+	switch k {
+	case bulkIORead:
+		return "read"
+	case bulkIOWrite:
+		return "write"
+	case bulkIOIngest:
+		return "ingest"
+	default:
+		return fmt.Sprintf("bulkIOKind(%d)", int(k))
+	}
+}
+
+// clusterLimit returns the cluster setting that limits the rate of the kind of
+// data movement across the cluster.
+func (k bulkIOKind) clusterLimit() *settings.ByteSizeSetting {
+	switch k {
+	case bulkIORead:
+		return backupReadRateLimit
+	case bulkIOWrite:
+		return backupWriteRateLimit
+	default:
+		return restoreIngestRateLimit
+	}
+}
+
+// jobLimit returns the limit, among the limits of a job, on the rate of the kind
+// of data movement.
+func (k bulkIOKind) jobLimit(limits jobspb.BulkIORateLimits) int64 {
+	switch k {
+	case bulkIORead:
+		return limits.ReadBytesPerSecond
+	case bulkIOWrite:
+		return limits.WriteBytesPerSecond
+	default:
+		return limits.IngestBytesPerSecond
+	}
+}
+
+// bulkIOMetrics are the metrics of the throttling of BACKUP and RESTORE jobs.
+type bulkIOMetrics struct {
+	ReadThrottledNanos   *metric.Counter
+	WriteThrottledNanos  *metric.Counter
+	IngestThrottledNanos *metric.Counter
+}
+
+var _ metric.Struct = (*bulkIOMetrics)(nil)
+
+// MetricStruct implements the metric.Struct interface.
+func (*bulkIOMetrics) MetricStruct() {}
+
+func makeBulkIOMetrics(time.Duration) metric.Struct {
+	makeMeta := func(job string, k bulkIOKind) metric.Metadata {
+		return metric.Metadata{
+			Name:        fmt.Sprintf("jobs.%s.%s_throttled_nanos", job, k),
+			Help:        fmt.Sprintf("Total time %s jobs spent waiting for %s rate limits", job, k),
+			Measurement: "Nanoseconds",
+			Unit:        metric.Unit_NANOSECONDS,
+		}
+	}
+	return &bulkIOMetrics{
+		ReadThrottledNanos:   metric.NewCounter(makeMeta("backup", bulkIORead)),
+		WriteThrottledNanos:  metric.NewCounter(makeMeta("backup", bulkIOWrite)),
+		IngestThrottledNanos: metric.NewCounter(makeMeta("restore", bulkIOIngest)),
+	}
+}
+
+func (m *bulkIOMetrics) throttledNanos(k bulkIOKind) *metric.Counter {
+	switch k {
+	case bulkIORead:
+		return m.ReadThrottledNanos
+	case bulkIOWrite:
+		return m.WriteThrottledNanos
+	default:
+		return m.IngestThrottledNanos
+	}
+}
+
+// newBulkIORateLimiter returns a rate limiter that admits limit bytes per
+// second, or everything if limit is 0.
+func newBulkIORateLimiter(name string, limit int64) *quotapool.RateLimiter {
+	rl := quotapool.NewRateLimiter(name, 0, 0,
+		quotapool.OnSlowAcquisition(500*time.Millisecond, quotapool.LogSlowAcquisition))
+	updateBulkIORateLimit(rl, limit)
+	return rl
+}
+
+// updateBulkIORateLimit sets the rate of rl to limit bytes per second, or
+// removes its limit if limit is 0. The burst is a second worth of bytes, and a
+// larger acquisition puts the limiter in debt.
+func updateBulkIORateLimit(rl *quotapool.RateLimiter, limit int64) {
+	if limit <= 0 {
+		rl.UpdateLimit(quotapool.Limit(math.MaxInt64), math.MaxInt64)
+		return
+	}
+	rl.UpdateLimit(quotapool.Limit(limit), limit)
+}
+
+// shareOfBulkIORateLimit returns the share of limit of one of n nodes or
+// processors that divide it evenly. A share is never rounded down to 0, which
+// would remove the limit.
+func shareOfBulkIORateLimit(limit int64, n int32) int64 {
+	if limit <= 0 || n <= 1 {
+		return limit
+	}
+	if share := limit / int64(n); share > 0 {
+		return share
+	}
+	return 1
+}
+
+// nodeBulkIOLimiters are the rate limiters shared by every job on the node,
+// by the settings of the node. They are keyed by settings, rather than global,
+// so that the nodes of a test cluster running in one process are limited
+// independently.
+var nodeBulkIOLimiters struct {
+	syncutil.Mutex
+	bySettings map[*settings.Values]*bulkIONodeLimiters
+}
+
+// bulkIONodeLimiters are the rate limiters that enforce, on one node, the share
+// of the node in the cluster-wide limits of the cluster settings. Each node
+// enforces an equal share of the limits, which are divided by the number of
+// nodes of the cluster that the last job planned on the node counted.
+type bulkIONodeLimiters struct {
+	sv       *settings.Values
+	limiters [numBulkIOKinds]*quotapool.RateLimiter
+
+	mu struct {
+		syncutil.Mutex
+		numNodes int32
+	}
+}
+
+// getNodeBulkIOLimiters returns the rate limiters shared by every job on the
+// node, which enforce the share of the node of the limits of the cluster
+// settings among numNodes nodes.
+func getNodeBulkIOLimiters(sv *settings.Values, numNodes int32) *bulkIONodeLimiters {
+	nodeBulkIOLimiters.Lock()
+	defer nodeBulkIOLimiters.Unlock()
+	l, ok := nodeBulkIOLimiters.bySettings[sv]
+	if !ok {
+		if nodeBulkIOLimiters.bySettings == nil {
+			nodeBulkIOLimiters.bySettings = make(map[*settings.Values]*bulkIONodeLimiters)
+		}
+		l = &bulkIONodeLimiters{sv: sv}
+		l.mu.numNodes = 1
+		for i := range l.limiters {
+			setting := bulkIOKind(i).clusterLimit()
+			l.limiters[i] = newBulkIORateLimiter(setting.Key(), 0)
+			// Update the limiter when the setting changes.
+			setting.SetOnChange(sv, func(ctx context.Context) {
+				l.mu.Lock()
+				defer l.mu.Unlock()
+				l.updateLocked()
+			})
+		}
+		l.mu.Lock()
+		l.updateLocked()
+		l.mu.Unlock()
+		nodeBulkIOLimiters.bySettings[sv] = l
+	}
+	l.setNumNodes(numNodes)
+	return l
+}
+
+// setNumNodes divides the limits among numNodes nodes from now on.
+func (l *bulkIONodeLimiters) setNumNodes(numNodes int32) {
+	if numNodes < 1 {
+		numNodes = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.mu.numNodes == numNodes {
+		return
+	}
+	l.mu.numNodes = numNodes
+	l.updateLocked()
+}
+
+func (l *bulkIONodeLimiters) updateLocked() {
+	for i, rl := range l.limiters {
+		limit := bulkIOKind(i).clusterLimit().Get(l.sv)
+		updateBulkIORateLimit(rl, shareOfBulkIORateLimit(limit, l.mu.numNodes))
+	}
+}
+
+// bulkIOThrottler throttles one kind of data movement of a processor of a
+// BACKUP or RESTORE job to both the share of its node in the cluster-wide limit
+// and its own share of the limit of the job. The limits of the job are
+// reloaded from the job record periodically, so that changes made with ALTER
+// BACKUP take effect while it runs.
+type bulkIOThrottler struct {
+	kind          bulkIOKind
+	jobID         jobspb.JobID
+	numProcessors int32
+	registry      *jobs.Registry
+	node          *quotapool.RateLimiter
+	job           *quotapool.RateLimiter
+	waitNanos     *metric.Counter
+
+	mu struct {
+		syncutil.Mutex
+		lastRefresh time.Time
+	}
+}
+
+// makeBulkIOThrottler returns a throttler of the kind of data movement of the
+// job with the given ID, one of numProcessors processors of the job in a
+// cluster of numNodes nodes. It returns nil, which throttles nothing, if the
+// processor was not given a job or runs without a job registry.
+func makeBulkIOThrottler(
+	ctx context.Context,
+	sv *settings.Values,
+	registry *jobs.Registry,
+	jobID jobspb.JobID,
+	numNodes, numProcessors int32,
+	k bulkIOKind,
+) *bulkIOThrottler {
+	if registry == nil || jobID == 0 {
+		return nil
+	}
+	metrics, ok := registry.MetricsStruct().Backup.(*bulkIOMetrics)
+	if !ok {
+		return nil
+	}
+	t := &bulkIOThrottler{
+		kind:          k,
+		jobID:         jobID,
+		numProcessors: numProcessors,
+		registry:      registry,
+		node:          getNodeBulkIOLimiters(sv, numNodes).limiters[k],
+		job:           newBulkIORateLimiter(fmt.Sprintf("job-%d-%s", jobID, k), 0),
+		waitNanos:     metrics.throttledNanos(k),
+	}
+	t.maybeRefresh(ctx)
+	return t
+}
+
+// maybeRefresh reloads the limit of the job if it was last loaded more than
+// jobRateLimitsRefreshInterval ago. Failing to load the job leaves the limit as
+// it was, as the job may be only briefly unavailable.
+func (t *bulkIOThrottler) maybeRefresh(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.mu.lastRefresh.IsZero() && timeutil.Since(t.mu.lastRefresh) < jobRateLimitsRefreshInterval {
+		return
+	}
+	t.mu.lastRefresh = timeutil.Now()
+
+	job, err := t.registry.LoadJob(ctx, t.jobID)
+	if err != nil {
+		log.Warningf(ctx, "failed to load rate limits of job %d: %v", t.jobID, err)
+		return
+	}
+	var limits jobspb.BulkIORateLimits
+	switch details := job.Details().(type) {
+	case jobspb.BackupDetails:
+		limits = details.RateLimits
+	case jobspb.RestoreDetails:
+		limits = details.RateLimits
+	}
+	updateBulkIORateLimit(t.job, shareOfBulkIORateLimit(t.kind.jobLimit(limits), t.numProcessors))
+}
+
+// acquire blocks until both the node and the job may move n more bytes. A nil
+// throttler admits everything.
+func (t *bulkIOThrottler) acquire(ctx context.Context, n int64) error {
+	if t == nil {
+		return nil
+	}
+	t.maybeRefresh(ctx)
+	nodeAdmitted := t.node.AdmitN(n)
+	jobAdmitted := t.job.AdmitN(n)
+	if nodeAdmitted && jobAdmitted {
+		return nil
+	}
+
+	// Slow case.
+	var span *tracing.Span
+	ctx, span = tracing.ChildSpan(ctx, fmt.Sprintf("bulkio-%s-quota-wait", t.kind))
+	defer span.Finish()
+
+	start := timeutil.Now()
+	defer func() {
+		t.waitNanos.Inc(int64(timeutil.Since(start)))
+	}()
+	if !nodeAdmitted {
+		if err := t.node.WaitN(ctx, n); err != nil {
+			return err
+		}
+	}
+	if !jobAdmitted {
+		return t.job.WaitN(ctx, n)
+	}
+	return nil
+}
+
+// bulkIOReservation is quota reserved from the limiters of a throttler before
+// a data movement whose size is not known in advance, such as an export.
+type bulkIOReservation struct {
+	t    *bulkIOThrottler
+	n    int64
+	node *quotapool.RateAlloc
+	job  *quotapool.RateAlloc
+}
+
+// reserve blocks until both the node and the job may move n more bytes, and
+// reserves them. This throttles a data movement whose size is only known once
+// it is done before it starts, rather than after, so that it is the movement
+// itself that is limited. The reservation must then be settled with the
+// actual size. A nil throttler reserves nothing.
+func (t *bulkIOThrottler) reserve(ctx context.Context, n int64) (*bulkIOReservation, error) {
+	if t == nil {
+		return nil, nil
+	}
+	t.maybeRefresh(ctx)
+
+	var span *tracing.Span
+	ctx, span = tracing.ChildSpan(ctx, fmt.Sprintf("bulkio-%s-quota-reserve", t.kind))
+	defer span.Finish()
+
+	start := timeutil.Now()
+	defer func() {
+		t.waitNanos.Inc(int64(timeutil.Since(start)))
+	}()
+	node, err := t.node.Acquire(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	job, err := t.job.Acquire(ctx, n)
+	if err != nil {
+		node.Return()
+		return nil, err
+	}
+	return &bulkIOReservation{t: t, n: n, node: node, job: job}, nil
+}
+
+// settle charges the n bytes that were actually moved to the reservation. If
+// more were moved than reserved, it blocks until the excess may move too, and
+// if fewer were, the unused quota goes back to the limiters. A nil reservation
+// settles nothing.
+func (r *bulkIOReservation) settle(ctx context.Context, n int64) error {
+	if r == nil {
+		return nil
+	}
+	if n >= r.n {
+		r.node.Consume()
+		r.job.Consume()
+		return r.t.acquire(ctx, n-r.n)
+	}
+	// A RateAlloc can only be returned in full, so return the reservation and
+	// charge what was moved instead. This does not wait unless another
+	// processor took the returned quota in the meantime.
+	r.node.Return()
+	r.job.Return()
+	return r.t.acquire(ctx, n)
+}
+
+func init() {
+	jobs.MakeBackupMetricsHook = makeBulkIOMetrics
+}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -48,6 +49,7 @@ const (
 	optOnPreviousRunning       = "on_previous_running"
 	optIgnoreExistingBackups   = "ignore_existing_backups"
 	optUpdatesLastBackupMetric = "updates_cluster_last_backup_time_metric"
+	optCompactAfter            = "compact_after"
 )
 
 var scheduledBackupOptionExpectValues = map[string]sql.KVStringOptValidate{
@@ -56,6 +58,7 @@ var scheduledBackupOptionExpectValues = map[string]sql.KVStringOptValidate{
 	optOnPreviousRunning:       sql.KVStringOptRequireValue,
 	optIgnoreExistingBackups:   sql.KVStringOptRequireNoValue,
 	optUpdatesLastBackupMetric: sql.KVStringOptRequireNoValue,
+	optCompactAfter:            sql.KVStringOptRequireValue,
 }
 
 // scheduledBackupGCProtectionEnabled is used to enable and disable the chaining
@@ -282,6 +285,15 @@ func doCreateBackupSchedules(
 		return err
 	}
 
+	if eval.BackupOptions.Compact {
+		return errors.WithHint(
+			errors.New("backup schedules cannot be created with the compact option"),
+			"use the compact_after schedule option to compact the backups of a schedule")
+	}
+	if eval.BackupOptions.Continuous {
+		return errors.New("backup schedules cannot be created with the continuous option")
+	}
+
 	if eval.ScheduleLabelSpec.IfNotExists {
 		scheduleLabel, err := eval.scheduleLabel()
 		if err != nil {
@@ -407,6 +419,11 @@ func doCreateBackupSchedules(
 
 	_, updateMetricOnSuccess := scheduleOptions[optUpdatesLastBackupMetric]
 
+	compactAfter, err := parseCompactAfterOption(scheduleOptions, incRecurrence != nil)
+	if err != nil {
+		return err
+	}
+
 	if updateMetricOnSuccess {
 		// NB: as of 20.2, schedule creation requires admin so this is duplicative
 		// but in the future we might relax so you can schedule anything that you
@@ -426,6 +443,12 @@ func doCreateBackupSchedules(
 	if err != nil {
 		return err
 	}
+	// The schedule compacts its backup chain in place of one of its incremental
+	// backups, and relies on waiting for the compaction to complete before it
+	// appends the next incremental backup to the chain.
+	if compactAfter > 0 && details.Wait == jobspb.ScheduleDetails_NO_WAIT {
+		return errors.Newf("%s cannot be used with %s = 'start'", optCompactAfter, optOnPreviousRunning)
+	}
 
 	ex := p.ExecCfg().InternalExecutor
 
@@ -451,7 +474,7 @@ func doCreateBackupSchedules(
 		}
 		inc, incScheduledBackupArgs, err = makeBackupSchedule(
 			env, p.User(), scheduleLabel, incRecurrence, details, unpauseOnSuccessID,
-			updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords)
+			updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords, compactAfter)
 		if err != nil {
 			return err
 		}
@@ -475,7 +498,7 @@ func doCreateBackupSchedules(
 	var fullScheduledBackupArgs *ScheduledBackupExecutionArgs
 	full, fullScheduledBackupArgs, err := makeBackupSchedule(
 		env, p.User(), scheduleLabel, fullRecurrence, details, unpauseOnSuccessID,
-		updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords)
+		updateMetricOnSuccess, backupNode, chainProtectedTimestampRecords, 0 /* compactAfter */)
 	if err != nil {
 		return err
 	}
@@ -571,6 +594,24 @@ func checkForExistingBackupsInCollection(
 	return nil
 }
 
+// parseCompactAfterOption returns the number of incremental backups after
+// which the incremental backup schedule compacts its backup chain, or 0 if the
+// compact_after option was not specified.
+func parseCompactAfterOption(scheduleOptions map[string]string, hasIncremental bool) (int64, error) {
+	v, ok := scheduleOptions[optCompactAfter]
+	if !ok {
+		return 0, nil
+	}
+	if !hasIncremental {
+		return 0, errors.Newf("%s requires a schedule with incremental backups", optCompactAfter)
+	}
+	compactAfter, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || compactAfter < 1 {
+		return 0, errors.Newf("%s must be a positive integer, found %q", optCompactAfter, v)
+	}
+	return compactAfter, nil
+}
+
 func makeBackupSchedule(
 	env scheduledjobs.JobSchedulerEnv,
 	owner security.SQLUsername,
@@ -581,6 +622,7 @@ func makeBackupSchedule(
 	updateLastMetricOnSuccess bool,
 	backupNode *tree.Backup,
 	chainProtectedTimestampRecords bool,
+	compactAfter int64,
 ) (*jobs.ScheduledJob, *ScheduledBackupExecutionArgs, error) {
 	sj := jobs.NewScheduledJob(env)
 	sj.SetScheduleLabel(label)
@@ -591,6 +633,7 @@ func makeBackupSchedule(
 		UnpauseOnSuccess:               unpauseOnSuccess,
 		UpdatesLastBackupMetric:        updateLastMetricOnSuccess,
 		ChainProtectedTimestampRecords: chainProtectedTimestampRecords,
+		CompactAfter:                   compactAfter,
 	}
 	if backupNode.AppendToLatest {
 		args.BackupType = ScheduledBackupExecutionArgs_INCREMENTAL
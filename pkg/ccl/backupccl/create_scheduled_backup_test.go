@@ -588,6 +588,14 @@ func TestSerializesScheduledBackupExecutionArgs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "compact-after-without-waiting",
+			user: enterpriseUser,
+			query: `
+		CREATE SCHEDULE FOR BACKUP INTO 'nodelocal://0/backup' RECURRING '@hourly' FULL BACKUP '@daily'
+		WITH SCHEDULE OPTIONS compact_after = '3', on_previous_running = 'start'`,
+			errMsg: "compact_after cannot be used with on_previous_running = 'start'",
+		},
 		{
 			name:   "missing-destination-placeholder",
 			query:  `CREATE SCHEDULE FOR BACKUP TABLE system.public.jobs INTO $1 RECURRING '@hourly'`,
@@ -79,6 +79,10 @@ const (
 	// them.
 	backupProgressDirectory = "progress"
 
+	// backupDataDirectory is the directory in which the backup processors
+	// write the SSTs holding the data of a backup.
+	backupDataDirectory = "data"
+
 	// DateBasedIncFolderName is the date format used when creating sub-directories
 	// storing incremental backups for auto-appendable backups.
 	// It is exported for testing backup inspection tooling.
@@ -921,6 +925,29 @@ func resolveBackupManifests(
 				}
 			}
 		}
+
+		// A continuous backup has a revision log next to its full backup, which
+		// covers every revision from the end of the full backup up to the end of
+		// the log. A time the log covers is restored from the full backup and the
+		// log alone, which has revision history even where the incremental
+		// backups appended to the full backup do not.
+		if !endTime.IsEmpty() && baseManifest.EndTime.Less(endTime) {
+			logURIs, logManifests, memSize, err := resolveRevisionLog(
+				ctx, mem, baseStores[0], mkStore, from[0][0], baseManifest.EndTime, endTime, encryption, user,
+			)
+			if err != nil {
+				return nil, nil, nil, 0, err
+			}
+			if n := len(logManifests); n > 0 && endTime.LessEq(logManifests[n-1].EndTime) {
+				ownedMemSize += memSize
+				defaultURIs = append(defaultURIs[:1], logURIs...)
+				mainBackupManifests = append(mainBackupManifests[:1], logManifests...)
+				localityInfo = append(localityInfo[:1],
+					make([]jobspb.RestoreDetails_BackupLocalityInfo, len(logManifests))...)
+			} else if memSize != 0 {
+				mem.Shrink(ctx, memSize)
+			}
+		}
 	}
 
 	// Check that the requested target time, if specified, is valid for the list
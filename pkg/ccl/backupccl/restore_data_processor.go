@@ -12,6 +12,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"runtime"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl"
@@ -32,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/errors"
@@ -73,6 +75,10 @@ type restoreDataProcessor struct {
 	// progress updates are accumulated on this channel. It is populated by the
 	// concurrent workers and sent down the flow by the processor.
 	progCh chan RestoreProgress
+
+	// ingestThrottler limits the rate at which the workers ingest data. It is
+	// shared by the workers.
+	ingestThrottler *bulkIOThrottler
 }
 
 var (
@@ -84,6 +90,10 @@ const restoreDataProcName = "restoreDataProcessor"
 
 const maxConcurrentRestoreWorkers = 32
 
+// ingestThrottleChunkSize is the number of bytes a restore worker adds to its
+// batcher between acquisitions from the ingest rate limits.
+const ingestThrottleChunkSize = 1 << 20
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -183,6 +193,19 @@ func (rd *restoreDataProcessor) Start(ctx context.Context) {
 		return inputReader(ctx, rd.input, entries, rd.metaCh)
 	})
 
+	if rd.spec.ValidateOnly {
+		// The files of each entry are checked one at a time by the workers, so
+		// there are no merged SSTs to open.
+		rd.phaseGroup.GoCtx(func(ctx context.Context) error {
+			defer close(rd.progCh)
+			return rd.runValidationWorkers(ctx, entries)
+		})
+		return
+	}
+
+	rd.ingestThrottler = makeBulkIOThrottler(ctx, &rd.flowCtx.Cfg.Settings.SV,
+		rd.flowCtx.Cfg.JobRegistry, rd.spec.JobID, rd.spec.NumNodes, rd.spec.NumProcessors, bulkIOIngest)
+
 	rd.phaseGroup.GoCtx(func(ctx context.Context) error {
 		defer close(rd.sstCh)
 		for entry := range entries {
@@ -439,6 +462,9 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 	defer batcher.Close()
 
 	var keyScratch, valueScratch []byte
+	// unchargedBytes is the size of the data added to the batcher that was not
+	// charged to the ingest rate limits yet.
+	var unchargedBytes int64
 
 	startKeyMVCC, endKeyMVCC := storage.MVCCKey{Key: entry.Span.Key},
 		storage.MVCCKey{Key: entry.Span.EndKey}
@@ -499,6 +525,16 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 		if err := batcher.AddMVCCKey(ctx, key, value.RawBytes); err != nil {
 			return summary, errors.Wrapf(err, "adding to batch: %s -> %s", key, value.PrettyPrint())
 		}
+		unchargedBytes += int64(key.Len() + len(value.RawBytes))
+		if unchargedBytes >= ingestThrottleChunkSize {
+			if err := rd.ingestThrottler.acquire(ctx, unchargedBytes); err != nil {
+				return summary, err
+			}
+			unchargedBytes = 0
+		}
+	}
+	if err := rd.ingestThrottler.acquire(ctx, unchargedBytes); err != nil {
+		return summary, err
 	}
 	// Flush out the last batch.
 	if err := batcher.Flush(ctx); err != nil {
@@ -514,6 +550,147 @@ func (rd *restoreDataProcessor) processRestoreSpanEntry(
 	return batcher.GetSummary(), nil
 }
 
+// runValidationWorkers validates the files of the entries it reads, rather than
+// ingesting them, and reports the files that failed validation in the progress
+// update of their entry.
+func (rd *restoreDataProcessor) runValidationWorkers(
+	ctx context.Context, entries chan execinfrapb.RestoreSpanEntry,
+) error {
+	return ctxgroup.GroupWorkers(ctx, rd.numWorkers, func(ctx context.Context, _ int) error {
+		for entry := range entries {
+			var summary roachpb.BulkOpSummary
+			var failures []BackupFileVerificationFailure
+			for _, file := range entry.Files {
+				fileSummary, failure, err := rd.validateFile(ctx, entry.Span, file)
+				if err != nil {
+					return err
+				}
+				summary.Add(fileSummary)
+				if failure != nil {
+					failures = append(failures, *failure)
+				}
+			}
+
+			progDetails := makeProgressUpdate(summary, entry, rd.spec.PKIDs)
+			progDetails.VerificationFailures = failures
+			select {
+			case rd.progCh <- progDetails:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+}
+
+// validateFile opens the storage of a backup file and validates it with
+// validateBackupFile.
+func (rd *restoreDataProcessor) validateFile(
+	ctx context.Context, span roachpb.Span, file execinfrapb.RestoreFileSpec,
+) (roachpb.BulkOpSummary, *BackupFileVerificationFailure, error) {
+	dir, err := rd.flowCtx.Cfg.ExternalStorage(ctx, file.Dir)
+	if err != nil {
+		return roachpb.BulkOpSummary{}, nil, err
+	}
+	defer func() {
+		if err := dir.Close(); err != nil {
+			log.Warningf(ctx, "close export storage failed %v", err)
+		}
+	}()
+	return validateBackupFile(ctx, dir, file.Path, span, rd.spec.Encryption)
+}
+
+// validateBackupFile reads every key of a backup file, checking that it can be
+// read and decrypted, that its keys lie within span, and that the checksums of
+// its values match. It returns a failure describing the problem if the file is
+// missing, corrupt or mis-encrypted, and an error only if the file could not be
+// checked at all.
+func validateBackupFile(
+	ctx context.Context,
+	dir cloud.ExternalStorage,
+	filePath string,
+	span roachpb.Span,
+	encryption *roachpb.FileEncryptionOptions,
+) (roachpb.BulkOpSummary, *BackupFileVerificationFailure, error) {
+	var summary roachpb.BulkOpSummary
+	fail := func(kind BackupFileVerificationFailure_Kind, err error) (
+		roachpb.BulkOpSummary, *BackupFileVerificationFailure, error,
+	) {
+		log.Warningf(ctx, "backup file %s failed validation: %v", filePath, err)
+		return summary, &BackupFileVerificationFailure{Kind: kind, Path: filePath, Error: err.Error()}, nil
+	}
+
+	// Check the header of the file before trying to open it, so that a file
+	// that was not encrypted the way the backup is can be told apart from a
+	// corrupt one.
+	header, err := func() ([]byte, error) {
+		r, err := dir.ReadFile(ctx, filePath)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close(ctx)
+		buf := make([]byte, 16)
+		n, err := io.ReadFull(ioctx.ReaderCtxAdapter(ctx, r), buf)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return buf[:n], nil
+	}()
+	if err != nil {
+		if errors.Is(err, cloud.ErrFileDoesNotExist) {
+			return fail(BackupFileVerificationFailure_MISSING, err)
+		}
+		return summary, nil, err
+	}
+	if encrypted := storageccl.AppearsEncrypted(header); encrypted && encryption == nil {
+		return fail(BackupFileVerificationFailure_MIS_ENCRYPTED,
+			errors.New("file is encrypted but the backup is not"))
+	} else if !encrypted && encryption != nil {
+		return fail(BackupFileVerificationFailure_MIS_ENCRYPTED,
+			errors.New("file is not encrypted but the backup is"))
+	}
+
+	failedToRead := func(err error) (roachpb.BulkOpSummary, *BackupFileVerificationFailure, error) {
+		if storageccl.IsDecryptionError(err) {
+			return fail(BackupFileVerificationFailure_MIS_ENCRYPTED, err)
+		}
+		return fail(BackupFileVerificationFailure_CORRUPT, err)
+	}
+
+	iter, err := storageccl.ExternalSSTReader(ctx, dir, filePath, encryption)
+	if err != nil {
+		return failedToRead(err)
+	}
+	defer iter.Close()
+
+	var rows storage.RowCounter
+	for iter.SeekGE(storage.MVCCKey{}); ; iter.Next() {
+		ok, err := iter.Valid()
+		if err != nil {
+			return failedToRead(err)
+		}
+		if !ok {
+			break
+		}
+		key := iter.UnsafeKey()
+		if !span.ContainsKey(key.Key) {
+			return fail(BackupFileVerificationFailure_CORRUPT,
+				errors.Newf("key %s is outside of the span %s of the file", key.Key, span))
+		}
+		if value := iter.UnsafeValue(); len(value) > 0 {
+			if err := (roachpb.Value{RawBytes: value}).Verify(key.Key); err != nil {
+				return fail(BackupFileVerificationFailure_CORRUPT, err)
+			}
+			summary.DataSize += int64(len(key.Key) + len(value))
+		}
+		if err := rows.Count(key.Key); err != nil {
+			return fail(BackupFileVerificationFailure_CORRUPT, err)
+		}
+	}
+	rows.BulkOpSummary.DataSize = summary.DataSize
+	return rows.BulkOpSummary, nil, nil
+}
+
 func makeProgressUpdate(
 	summary roachpb.BulkOpSummary, entry execinfrapb.RestoreSpanEntry, pkIDs map[uint64]bool,
 ) (progDetails RestoreProgress) {
@@ -263,27 +263,7 @@ func restore(
 	}
 	mu.requestsCompleted = make([]bool, len(importSpans))
 
-	// TODO(pbardea): This not super principled. I just wanted something that
-	// wasn't a constant and grew slower than linear with the length of
-	// importSpans. It seems to be working well for BenchmarkRestore2TB but
-	// worth revisiting.
-	// It tries to take the cluster size into account so that larger clusters
-	// distribute more chunks amongst them so that after scattering there isn't
-	// a large varience in the distribution of entries.
-	chunkSize := int(math.Sqrt(float64(len(importSpans)))) / numNodes
-	if chunkSize == 0 {
-		chunkSize = 1
-	}
-	importSpanChunks := make([][]execinfrapb.RestoreSpanEntry, 0, len(importSpans)/chunkSize)
-	for start := 0; start < len(importSpans); {
-		importSpanChunk := importSpans[start:]
-		end := start + chunkSize
-		if end < len(importSpans) {
-			importSpanChunk = importSpans[start:end]
-		}
-		importSpanChunks = append(importSpanChunks, importSpanChunk)
-		start = end
-	}
+	importSpanChunks := chunkRestoreSpanEntries(importSpans, numNodes)
 
 	requestFinishedCh := make(chan struct{}, len(importSpans)) // enough buffer to never block
 	progCh := make(chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
@@ -357,12 +337,14 @@ func restore(
 		return distRestore(
 			ctx,
 			execCtx,
+			job.ID(),
 			importSpanChunks,
 			dataToRestore.getPKIDs(),
 			encryption,
 			dataToRestore.getRekeys(),
 			dataToRestore.getTenantRekeys(),
 			endTime,
+			false, /* validateOnly */
 			progCh,
 		)
 	}
@@ -378,6 +360,35 @@ func restore(
 	return mu.res, nil
 }
 
+// chunkRestoreSpanEntries groups the entries to restore into the chunks that are
+// scattered amongst the nodes of the cluster.
+func chunkRestoreSpanEntries(
+	importSpans []execinfrapb.RestoreSpanEntry, numNodes int,
+) [][]execinfrapb.RestoreSpanEntry {
+	// TODO(pbardea): This not super principled. I just wanted something that
+	// wasn't a constant and grew slower than linear with the length of
+	// importSpans. It seems to be working well for BenchmarkRestore2TB but
+	// worth revisiting.
+	// It tries to take the cluster size into account so that larger clusters
+	// distribute more chunks amongst them so that after scattering there isn't
+	// a large varience in the distribution of entries.
+	chunkSize := int(math.Sqrt(float64(len(importSpans)))) / numNodes
+	if chunkSize == 0 {
+		chunkSize = 1
+	}
+	importSpanChunks := make([][]execinfrapb.RestoreSpanEntry, 0, len(importSpans)/chunkSize)
+	for start := 0; start < len(importSpans); {
+		importSpanChunk := importSpans[start:]
+		end := start + chunkSize
+		if end < len(importSpans) {
+			importSpanChunk = importSpans[start:end]
+		}
+		importSpanChunks = append(importSpanChunks, importSpanChunk)
+		start = end
+	}
+	return importSpanChunks
+}
+
 // loadBackupSQLDescs extracts the backup descriptors, the latest backup
 // descriptor, and all the Descriptors for a backup to be restored. It upgrades
 // the table descriptors to the new FK representation if necessary. FKs that
@@ -713,6 +724,10 @@ func createImportingDescriptors(
 	// that is, in the 'old' keyspace, before we reassign the table IDs.
 	preRestoreSpans := spansForAllRestoreTableIndexes(backupCodec, preRestoreTables, nil)
 	postRestoreSpans := spansForAllRestoreTableIndexes(backupCodec, postRestoreTables, nil)
+	if len(details.KeyFilter) > 0 {
+		preRestoreSpans = intersectSpans(preRestoreSpans, details.KeyFilter)
+		postRestoreSpans = intersectSpans(postRestoreSpans, details.KeyFilter)
+	}
 
 	log.Eventf(ctx, "starting restore for %d tables", len(mutableTables))
 
@@ -1213,6 +1228,10 @@ func (r *restoreResumer) doResume(ctx context.Context, execCtx interface{}) erro
 	p := execCtx.(sql.JobExecContext)
 	r.execCfg = p.ExecCfg()
 
+	if details.VerifyOnly {
+		return r.verifyBackup(ctx, p)
+	}
+
 	mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
 	defer mem.Close(ctx)
 
@@ -1437,6 +1456,18 @@ func (r *restoreResumer) doResume(ctx context.Context, execCtx interface{}) erro
 		}
 		devalidateIndexes = bad
 	}
+	if len(details.KeyFilter) > 0 {
+		// Only the rows matching the WHERE clause of the RESTORE were restored,
+		// and only in the primary index, so the secondary indexes of the table are
+		// rebuilt from them once it is published.
+		devalidateIndexes = make(map[descpb.ID][]descpb.IndexID)
+		for _, tbl := range details.TableDescs {
+			table := tabledesc.NewBuilder(tbl).BuildImmutableTable()
+			for _, idx := range table.PublicNonPrimaryIndexes() {
+				devalidateIndexes[table.GetID()] = append(devalidateIndexes[table.GetID()], idx.GetID())
+			}
+		}
+	}
 
 	publishDescriptors := func(ctx context.Context, txn *kv.Txn, descsCol *descs.Collection) (err error) {
 		err = r.publishDescriptors(ctx, txn, p.ExecCfg(), p.User(), descsCol, details, devalidateIndexes)
@@ -1922,6 +1953,12 @@ func emitRestoreJobEvent(
 // change stuff to delete the keys in the background.
 func (r *restoreResumer) OnFailOrCancel(ctx context.Context, execCtx interface{}) error {
 	p := execCtx.(sql.JobExecContext)
+	// A restore that only verified the backup did not write anything, so there
+	// is nothing to clean up.
+	if r.job.Details().(jobspb.RestoreDetails).VerifyOnly {
+		emitRestoreJobEvent(ctx, p, jobs.StatusFailed, r.job)
+		return nil
+	}
 	// Emit to the event log that the job has started reverting.
 	emitRestoreJobEvent(ctx, p, jobs.StatusReverting, r.job)
 
@@ -0,0 +1,289 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// keyFilterBuilder turns the WHERE clause of a RESTORE of a single table into
+// the spans of the primary index of the table holding the rows to restore.
+// Only comparisons of the leading column of the primary key with constants,
+// combined with AND and OR, are supported, as only those map directly onto
+// spans of the primary index.
+type keyFilterBuilder struct {
+	semaCtx *tree.SemaContext
+	evalCtx *tree.EvalContext
+
+	col    catalog.Column
+	dir    encoding.Direction
+	prefix roachpb.Key
+	// index is the span of the primary index of the table.
+	index roachpb.Span
+}
+
+// restoreKeyFilterSpans returns the spans of the primary index of the table,
+// as it is encoded in the backup by codec, holding the rows that satisfy the
+// predicate of the WHERE clause of the RESTORE.
+func restoreKeyFilterSpans(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *tree.EvalContext,
+	codec keys.SQLCodec,
+	table catalog.TableDescriptor,
+	where tree.Expr,
+) (roachpb.Spans, error) {
+	pk := table.GetPrimaryIndex()
+	col, err := table.FindColumnWithID(pk.GetKeyColumnID(0))
+	if err != nil {
+		return nil, err
+	}
+	dir, err := pk.GetKeyColumnDirection(0).ToEncodingDirection()
+	if err != nil {
+		return nil, err
+	}
+	prefix := roachpb.Key(rowenc.MakeIndexKeyPrefix(codec, table.GetID(), pk.GetID()))
+	b := keyFilterBuilder{
+		semaCtx: semaCtx,
+		evalCtx: evalCtx,
+		col:     col,
+		dir:     dir,
+		prefix:  prefix,
+		index:   roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()},
+	}
+	spans, err := b.spans(ctx, where)
+	if err != nil {
+		return nil, err
+	}
+	spans, _ = roachpb.MergeSpans(&spans)
+	if len(spans) == 0 {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"RESTORE ... WHERE %s matches no rows", where)
+	}
+	return spans, nil
+}
+
+// planRestoreKeyFilter returns the spans, in the keyspace of the backup, of
+// the primary index of the table being restored that hold the rows matching
+// the WHERE clause of the RESTORE.
+func planRestoreKeyFilter(
+	ctx context.Context,
+	p sql.PlanHookState,
+	latestBackupManifest BackupManifest,
+	sqlDescs []catalog.Descriptor,
+	where tree.Expr,
+) (roachpb.Spans, error) {
+	var table catalog.TableDescriptor
+	for _, desc := range sqlDescs {
+		if tbl, ok := desc.(catalog.TableDescriptor); ok {
+			if table != nil {
+				return nil, errors.New("RESTORE ... WHERE can only be used to restore a single table")
+			}
+			table = tbl
+		}
+	}
+	if table == nil || !table.IsTable() {
+		return nil, errors.New("RESTORE ... WHERE can only be used to restore a table")
+	}
+
+	// The keys in the backup are encoded with the codec of the tenant in which
+	// it was taken.
+	backupCodec := keys.SystemSQLCodec
+	if len(latestBackupManifest.Spans) != 0 && !latestBackupManifest.HasTenants() {
+		_, backupTenantID, err := keys.DecodeTenantPrefix(latestBackupManifest.Spans[0].Key)
+		if err != nil {
+			return nil, err
+		}
+		backupCodec = keys.MakeSQLCodec(backupTenantID)
+	}
+	return restoreKeyFilterSpans(
+		ctx, p.SemaCtx(), &p.ExtendedEvalContext().EvalContext, backupCodec, table, where,
+	)
+}
+
+// intersectSpans returns the parts of spans that are also covered by filter.
+func intersectSpans(spans []roachpb.Span, filter []roachpb.Span) []roachpb.Span {
+	var res []roachpb.Span
+	for _, sp := range spans {
+		for _, f := range filter {
+			if i := sp.Intersect(f); i.Valid() {
+				res = append(res, i)
+			}
+		}
+	}
+	res, _ = roachpb.MergeSpans(&res)
+	return res
+}
+
+func (b *keyFilterBuilder) spans(ctx context.Context, expr tree.Expr) ([]roachpb.Span, error) {
+	switch e := expr.(type) {
+	case *tree.ParenExpr:
+		return b.spans(ctx, e.Expr)
+
+	case *tree.AndExpr:
+		left, err := b.spans(ctx, e.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := b.spans(ctx, e.Right)
+		if err != nil {
+			return nil, err
+		}
+		return intersectSpans(left, right), nil
+
+	case *tree.OrExpr:
+		left, err := b.spans(ctx, e.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := b.spans(ctx, e.Right)
+		if err != nil {
+			return nil, err
+		}
+		res := append(left, right...)
+		res, _ = roachpb.MergeSpans(&res)
+		return res, nil
+
+	case *tree.ComparisonExpr:
+		op, operand := e.Operator.Symbol, e.Right
+		if !b.isKeyColumn(e.Left) {
+			if !b.isKeyColumn(e.Right) {
+				return nil, b.unsupported(expr)
+			}
+			operand = e.Left
+			switch op {
+			case treecmp.LT:
+				op = treecmp.GT
+			case treecmp.LE:
+				op = treecmp.GE
+			case treecmp.GT:
+				op = treecmp.LT
+			case treecmp.GE:
+				op = treecmp.LE
+			case treecmp.In:
+				return nil, b.unsupported(expr)
+			}
+		}
+		switch op {
+		case treecmp.EQ, treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE:
+			return b.comparisonSpans(ctx, op, operand)
+		case treecmp.In:
+			tuple, ok := operand.(*tree.Tuple)
+			if !ok {
+				return nil, b.unsupported(expr)
+			}
+			var res []roachpb.Span
+			for _, elem := range tuple.Exprs {
+				sp, err := b.comparisonSpans(ctx, treecmp.EQ, elem)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, sp...)
+			}
+			return res, nil
+		}
+
+	case *tree.RangeCond:
+		if e.Not || e.Symmetric || !b.isKeyColumn(e.Left) {
+			return nil, b.unsupported(expr)
+		}
+		return b.spans(ctx, &tree.AndExpr{
+			Left:  &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.GE), Left: e.Left, Right: e.From},
+			Right: &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.LE), Left: e.Left, Right: e.To},
+		})
+	}
+	return nil, b.unsupported(expr)
+}
+
+// comparisonSpans returns the spans of the primary index holding the rows
+// whose leading primary key column compares to the constant operand with op.
+func (b *keyFilterBuilder) comparisonSpans(
+	ctx context.Context, op treecmp.ComparisonOperatorSymbol, operand tree.Expr,
+) ([]roachpb.Span, error) {
+	typed, err := tree.TypeCheckAndRequire(ctx, operand, b.semaCtx, b.col.GetType(), "RESTORE ... WHERE")
+	if err != nil {
+		return nil, err
+	}
+	if !tree.IsConst(b.evalCtx, typed) {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"RESTORE ... WHERE can only compare %q with constants, found %s", b.col.GetName(), operand)
+	}
+	d, err := typed.Eval(b.evalCtx)
+	if err != nil {
+		return nil, err
+	}
+	if d == tree.DNull {
+		// No row compares to NULL.
+		return nil, nil
+	}
+	encoded, err := keyside.Encode(append(roachpb.Key(nil), b.prefix...), d, b.dir)
+	if err != nil {
+		return nil, err
+	}
+	key := roachpb.Key(encoded)
+
+	// The keys of the rows with the leading primary key column equal to the
+	// operand are exactly those prefixed by key. In a descending index, the
+	// keys of greater values sort before key.
+	if b.dir == encoding.Descending {
+		switch op {
+		case treecmp.LT:
+			op = treecmp.GT
+		case treecmp.LE:
+			op = treecmp.GE
+		case treecmp.GT:
+			op = treecmp.LT
+		case treecmp.GE:
+			op = treecmp.LE
+		}
+	}
+	var sp roachpb.Span
+	switch op {
+	case treecmp.EQ:
+		sp = roachpb.Span{Key: key, EndKey: key.PrefixEnd()}
+	case treecmp.LT:
+		sp = roachpb.Span{Key: b.index.Key, EndKey: key}
+	case treecmp.LE:
+		sp = roachpb.Span{Key: b.index.Key, EndKey: key.PrefixEnd()}
+	case treecmp.GT:
+		sp = roachpb.Span{Key: key.PrefixEnd(), EndKey: b.index.EndKey}
+	case treecmp.GE:
+		sp = roachpb.Span{Key: key, EndKey: b.index.EndKey}
+	}
+	if !sp.Valid() {
+		return nil, nil
+	}
+	return []roachpb.Span{sp}, nil
+}
+
+// isKeyColumn returns whether expr refers to the leading column of the
+// primary key.
+func (b *keyFilterBuilder) isKeyColumn(expr tree.Expr) bool {
+	name, ok := expr.(*tree.UnresolvedName)
+	return ok && !name.Star && name.NumParts == 1 && tree.Name(name.Parts[0]) == b.col.ColName()
+}
+
+func (b *keyFilterBuilder) unsupported(expr tree.Expr) error {
+	return pgerror.Newf(pgcode.FeatureNotSupported,
+		"RESTORE ... WHERE only supports comparisons of the leading primary key column %q "+
+			"with constants, combined with AND and OR, found %s", b.col.GetName(), expr)
+}
@@ -67,6 +67,9 @@ const (
 	restoreOptSkipLocalitiesCheck       = "skip_localities_check"
 	restoreOptDebugPauseOn              = "debug_pause_on"
 	restoreOptAsTenant                  = "tenant"
+	restoreOptNewDBName                 = "new_db_name"
+	restoreOptNewSchema                 = "new_schema"
+	restoreOptVerifyOnly                = "verify_only"
 
 	// The temporary database system tables will be restored into for full
 	// cluster backups.
@@ -172,6 +175,7 @@ func allocateDescriptorRewrites(
 	opts tree.RestoreOptions,
 	intoDB string,
 	newDBName string,
+	newSchemaName string,
 	restoreSystemUsers bool,
 ) (jobspb.DescRewriteMap, error) {
 	descriptorRewrites := make(jobspb.DescRewriteMap)
@@ -492,14 +496,6 @@ func allocateDescriptorRewrites(
 					}
 					parentID = newParentID
 				}
-				// Check that the table name is _not_ in use.
-				// This would fail the CPut later anyway, but this yields a prettier error.
-				tableName := tree.NewUnqualifiedTableName(tree.Name(table.GetName()))
-				err := col.Direct().CheckObjectCollision(ctx, txn, parentID, table.GetParentSchemaID(), tableName)
-				if err != nil {
-					return err
-				}
-
 				// Check privileges.
 				parentDB, err := col.Direct().MustGetDatabaseDescByID(ctx, txn, parentID)
 				if err != nil {
@@ -510,6 +506,24 @@ func allocateDescriptorRewrites(
 					return err
 				}
 
+				// If the tables are restored into another schema, it needs to exist.
+				parentSchemaID := table.GetParentSchemaID()
+				if newSchemaName != "" {
+					if parentSchemaID, err = resolveNewSchemaID(
+						ctx, txn, p, col, parentDB, newSchemaName,
+					); err != nil {
+						return err
+					}
+				}
+
+				// Check that the table name is _not_ in use.
+				// This would fail the CPut later anyway, but this yields a prettier error.
+				tableName := tree.NewUnqualifiedTableName(tree.Name(table.GetName()))
+				err = col.Direct().CheckObjectCollision(ctx, txn, parentID, parentSchemaID, tableName)
+				if err != nil {
+					return err
+				}
+
 				// We're restoring a table and not its parent database. We may block
 				// restoring multi-region tables to multi-region databases since
 				// regions may mismatch.
@@ -524,7 +538,9 @@ func allocateDescriptorRewrites(
 				// If we're restoring to a public schema of database that already exists
 				// we can populate the rewrite ParentSchemaID field here since we
 				// already have the database descriptor.
-				if table.GetParentSchemaID() == keys.PublicSchemaIDForBackup ||
+				if newSchemaName != "" {
+					descriptorRewrites[table.ID].ParentSchemaID = parentSchemaID
+				} else if table.GetParentSchemaID() == keys.PublicSchemaIDForBackup ||
 					table.GetParentSchemaID() == descpb.InvalidID {
 					publicSchemaID := parentDB.GetSchemaID(tree.PublicSchema)
 					descriptorRewrites[table.ID].ParentSchemaID = publicSchemaID
@@ -922,12 +938,50 @@ func maybeUpgradeDescriptorsInBackupManifests(
 	return nil
 }
 
+// resolveNewSchemaID returns the ID of the existing schema of the database
+// that the tables of a RESTORE with the new_schema option are restored into,
+// checking that the user can create tables in it.
+func resolveNewSchemaID(
+	ctx context.Context,
+	txn *kv.Txn,
+	p sql.PlanHookState,
+	col *descs.Collection,
+	parentDB catalog.DatabaseDescriptor,
+	newSchemaName string,
+) (descpb.ID, error) {
+	schemaID := parentDB.GetSchemaID(newSchemaName)
+	if schemaID == descpb.InvalidID {
+		var err error
+		schemaID, err = col.Direct().LookupSchemaID(ctx, txn, parentDB.GetID(), newSchemaName)
+		if err != nil {
+			return descpb.InvalidID, err
+		}
+	}
+	if schemaID == descpb.InvalidID {
+		return descpb.InvalidID, pgerror.Newf(pgcode.InvalidSchemaName,
+			"schema %q does not exist in database %q", newSchemaName, parentDB.GetName())
+	}
+	sc, err := col.Direct().MustGetSchemaDescByID(ctx, txn, schemaID)
+	if err != nil {
+		return descpb.InvalidID, err
+	}
+	if err := p.CheckPrivilege(ctx, sc, privilege.CREATE); err != nil {
+		return descpb.InvalidID, err
+	}
+	return schemaID, nil
+}
+
 // resolveOptionsForRestoreJobDescription creates a copy of
 // the options specified during a restore, after processing
 // them to be suitable for displaying in the jobs' description.
 // This includes redacting secrets from external storage URIs.
 func resolveOptionsForRestoreJobDescription(
-	opts tree.RestoreOptions, intoDB string, newDBName string, kmsURIs []string, incFrom []string,
+	opts tree.RestoreOptions,
+	intoDB string,
+	newDBName string,
+	newSchemaName string,
+	kmsURIs []string,
+	incFrom []string,
 ) (tree.RestoreOptions, error) {
 	if opts.IsDefault() {
 		return opts, nil
@@ -939,6 +993,7 @@ func resolveOptionsForRestoreJobDescription(
 		SkipMissingSequenceOwners: opts.SkipMissingSequenceOwners,
 		SkipMissingViews:          opts.SkipMissingViews,
 		Detached:                  opts.Detached,
+		VerifyOnly:                opts.VerifyOnly,
 	}
 
 	if opts.EncryptionPassphrase != nil {
@@ -953,6 +1008,10 @@ func resolveOptionsForRestoreJobDescription(
 		newOpts.NewDBName = tree.NewDString(newDBName)
 	}
 
+	if opts.NewSchemaName != nil {
+		newOpts.NewSchemaName = tree.NewDString(newSchemaName)
+	}
+
 	for _, uri := range kmsURIs {
 		redactedURI, err := cloud.RedactKMSURI(uri)
 		if err != nil {
@@ -980,6 +1039,7 @@ func restoreJobDescription(
 	opts tree.RestoreOptions,
 	intoDB string,
 	newDBName string,
+	newSchemaName string,
 	kmsURIs []string,
 ) (string, error) {
 	r := &tree.Restore{
@@ -987,13 +1047,15 @@ func restoreJobDescription(
 		DescriptorCoverage: restore.DescriptorCoverage,
 		AsOf:               restore.AsOf,
 		Targets:            restore.Targets,
+		NewTableName:       restore.NewTableName,
+		Where:              restore.Where,
 		From:               make([]tree.StringOrPlaceholderOptList, len(restore.From)),
 	}
 
 	var options tree.RestoreOptions
 	var err error
 	if options, err = resolveOptionsForRestoreJobDescription(opts, intoDB, newDBName,
-		kmsURIs, incFrom); err != nil {
+		newSchemaName, kmsURIs, incFrom); err != nil {
 		return "", err
 	}
 	r.Options = options
@@ -1107,6 +1169,36 @@ func restorePlanHook(
 		}
 	}
 
+	var newSchemaFn func() (string, error)
+	if restoreStmt.Options.NewSchemaName != nil {
+		if restoreStmt.DescriptorCoverage == tree.AllDescriptors || restoreStmt.Targets.Databases != nil ||
+			restoreStmt.Targets.Tenant.IsSet() || restoreStmt.SystemUsers {
+			err := errors.Errorf("%q option can only be used for RESTORE TABLE", restoreOptNewSchema)
+			return nil, nil, nil, false, err
+		}
+		newSchemaFn, err = p.TypeAsString(ctx, restoreStmt.Options.NewSchemaName, "RESTORE")
+		if err != nil {
+			return nil, nil, nil, false, err
+		}
+	}
+
+	if restoreStmt.NewTableName != nil && restoreStmt.Options.VerifyOnly {
+		err := errors.Errorf("RESTORE TABLE ... AS cannot be used with the %q option", restoreOptVerifyOnly)
+		return nil, nil, nil, false, err
+	}
+
+	if restoreStmt.Where != nil {
+		if restoreStmt.DescriptorCoverage == tree.AllDescriptors || restoreStmt.Targets.Databases != nil ||
+			restoreStmt.Targets.Tenant.IsSet() || len(restoreStmt.Targets.Tables) != 1 {
+			err := errors.New("RESTORE ... WHERE can only be used with RESTORE TABLE of a single table")
+			return nil, nil, nil, false, err
+		}
+		if restoreStmt.Options.VerifyOnly {
+			err := errors.Errorf("RESTORE ... WHERE cannot be used with the %q option", restoreOptVerifyOnly)
+			return nil, nil, nil, false, err
+		}
+	}
+
 	var newTenantIDFn func() (*roachpb.TenantID, error)
 	if restoreStmt.Options.AsTenant != nil {
 		if restoreStmt.DescriptorCoverage == tree.AllDescriptors || !restoreStmt.Targets.Tenant.IsSet() {
@@ -1211,6 +1303,25 @@ func restorePlanHook(
 			}
 		}
 
+		var newSchemaName string
+		if newSchemaFn != nil {
+			newSchemaName, err = newSchemaFn()
+			if err != nil {
+				return err
+			}
+		}
+
+		// The new name of the restored table may be qualified with the database
+		// and schema to restore it into, in the same way the targets of the
+		// RESTORE are qualified: `db.table` or `db.schema.table`.
+		var newTableName string
+		if restoreStmt.NewTableName != nil {
+			newTableName, err = resolveNewTableName(restoreStmt.NewTableName, &intoDB, &newSchemaName)
+			if err != nil {
+				return err
+			}
+		}
+
 		// incFrom will contain the directory URIs for incremental backups (i.e.
 		// <prefix>/<subdir>) iff len(From)==1, regardless of the
 		// 'incremental_location' param. len(From)=1 implies that the user has not
@@ -1260,7 +1371,7 @@ func restorePlanHook(
 		}
 
 		return doRestorePlan(ctx, restoreStmt, p, from, incFrom, passphrase, kms, intoDB,
-			newDBName, newTenantID, endTime, resultsCh)
+			newDBName, newTableName, newSchemaName, newTenantID, endTime, resultsCh)
 	}
 
 	if restoreStmt.Options.Detached {
@@ -1384,6 +1495,8 @@ func doRestorePlan(
 	kms []string,
 	intoDB string,
 	newDBName string,
+	newTableName string,
+	newSchemaName string,
 	newTenantID *roachpb.TenantID,
 	endTime hlc.Timestamp,
 	resultsCh chan<- tree.Datums,
@@ -1477,8 +1590,10 @@ func doRestorePlan(
 		return errors.Errorf("full cluster RESTORE can only be used on full cluster BACKUP files")
 	}
 
-	// Ensure that no user descriptors exist for a full cluster restore.
-	if restoreStmt.DescriptorCoverage == tree.AllDescriptors {
+	// Ensure that no user descriptors exist for a full cluster restore. A restore
+	// that only verifies the backup does not write anything, so it may run on any
+	// cluster.
+	if restoreStmt.DescriptorCoverage == tree.AllDescriptors && !restoreStmt.Options.VerifyOnly {
 		var allDescs []catalog.Descriptor
 		if err := sql.DescsTxn(ctx, p.ExecCfg(), func(ctx context.Context, txn *kv.Txn, col *descs.Collection) (err error) {
 			txn.SetDebugName("count-user-descs")
@@ -1562,6 +1677,26 @@ func doRestorePlan(
 		}
 	}
 
+	if restoreStmt.Options.VerifyOnly {
+		return planRestoreVerification(
+			ctx, restoreStmt, p, from, incFrom, kms, newTenantID, endTime, defaultURIs,
+			localityInfo, sqlDescs, tenants, encryption, resultsCh,
+		)
+	}
+
+	var keyFilter roachpb.Spans
+	if restoreStmt.Where != nil {
+		keyFilter, err = planRestoreKeyFilter(
+			ctx, p, mainBackupManifests[len(mainBackupManifests)-1], sqlDescs, restoreStmt.Where.Expr,
+		)
+		if err != nil {
+			return err
+		}
+		// All of the secondary indexes of the table are rebuilt after the restore,
+		// so there is no need to re-validate any of them.
+		revalidateIndexes = nil
+	}
+
 	databaseModifiers, newTypeDescs, err := planDatabaseModifiersForRestore(ctx, p, sqlDescs, restoreDBs)
 	if err != nil {
 		return err
@@ -1579,6 +1714,12 @@ func doRestorePlan(
 		}
 	}
 
+	if newTableName != "" {
+		if err := renameTargetTableDescriptor(sqlDescs, newTableName); err != nil {
+			return err
+		}
+	}
+
 	var oldTenantID *roachpb.TenantID
 	if len(tenants) > 0 {
 		if !p.ExecCfg().Codec.ForSystemTenant() {
@@ -1635,6 +1776,12 @@ func doRestorePlan(
 		}
 	}
 
+	if newSchemaName != "" {
+		if err := pruneSchemasForNewSchema(tablesByID, schemasByID, typesByID); err != nil {
+			return err
+		}
+	}
+
 	if !restoreStmt.Options.SkipLocalitiesCheck {
 		if err := checkClusterRegions(ctx, p, typesByID); err != nil {
 			return err
@@ -1688,13 +1835,14 @@ func doRestorePlan(
 		restoreStmt.Options,
 		intoDB,
 		newDBName,
+		newSchemaName,
 		restoreStmt.SystemUsers)
 	if err != nil {
 		return err
 	}
 	description, err := restoreJobDescription(p, restoreStmt, from, incFrom, restoreStmt.Options,
 		intoDB,
-		newDBName, kms)
+		newDBName, newSchemaName, kms)
 	if err != nil {
 		return err
 	}
@@ -1773,11 +1921,97 @@ func doRestorePlan(
 			DebugPauseOn:       debugPauseOn,
 			RestoreSystemUsers: restoreStmt.SystemUsers,
 			PreRewriteTenantId: oldTenantID,
+			KeyFilter:          keyFilter,
 		},
 		Progress: jobspb.RestoreProgress{},
 	}
 
-	if restoreStmt.Options.Detached {
+	return runRestoreJob(ctx, p, jr, restoreStmt.Options.Detached, collectTelemetry, resultsCh)
+}
+
+// planRestoreVerification creates the job of a restore with the verify_only
+// option. The job reads and checks the files of the backup holding data of the
+// targets of the restore, as they are in the backup, rather than restoring
+// them, so none of the descriptors is rewritten.
+func planRestoreVerification(
+	ctx context.Context,
+	restoreStmt *tree.Restore,
+	p sql.PlanHookState,
+	from [][]string,
+	incFrom []string,
+	kms []string,
+	newTenantID *roachpb.TenantID,
+	endTime hlc.Timestamp,
+	defaultURIs []string,
+	localityInfo []jobspb.RestoreDetails_BackupLocalityInfo,
+	sqlDescs []catalog.Descriptor,
+	tenants []descpb.TenantInfoWithUsage,
+	encryption *jobspb.BackupEncryptionOptions,
+	resultsCh chan<- tree.Datums,
+) error {
+	opts := restoreStmt.Options
+	if opts.IntoDB != nil {
+		return errors.Errorf("cannot use %q option with %q option", restoreOptIntoDB, restoreOptVerifyOnly)
+	}
+	if opts.NewDBName != nil {
+		return errors.Errorf("cannot use %q option with %q option", restoreOptNewDBName, restoreOptVerifyOnly)
+	}
+	if newTenantID != nil {
+		return errors.Errorf("cannot use %q option with %q option", restoreOptAsTenant, restoreOptVerifyOnly)
+	}
+	if restoreStmt.SystemUsers {
+		return errors.Errorf("cannot verify a backup with RESTORE SYSTEM USERS")
+	}
+	if len(tenants) > 0 && !p.ExecCfg().Codec.ForSystemTenant() {
+		return pgerror.Newf(pgcode.InsufficientPrivilege, "only the system tenant can restore other tenants")
+	}
+
+	var encodedTables []*descpb.TableDescriptor
+	for _, desc := range sqlDescs {
+		if tbl, ok := desc.(catalog.TableDescriptor); ok {
+			encodedTables = append(encodedTables, tbl.TableDesc())
+		}
+	}
+
+	description, err := restoreJobDescription(p, restoreStmt, from, incFrom, opts,
+		"" /* intoDB */, "" /* newDBName */, "" /* newSchemaName */, kms)
+	if err != nil {
+		return err
+	}
+
+	jr := jobs.Record{
+		Description: description,
+		Username:    p.User(),
+		Details: jobspb.RestoreDetails{
+			EndTime:            endTime,
+			URIs:               defaultURIs,
+			BackupLocalityInfo: localityInfo,
+			TableDescs:         encodedTables,
+			Tenants:            tenants,
+			DescriptorCoverage: restoreStmt.DescriptorCoverage,
+			Encryption:         encryption,
+			VerifyOnly:         true,
+		},
+		Progress: jobspb.RestoreProgress{},
+	}
+	collectTelemetry := func() {
+		telemetry.Count("restore.verify.started")
+	}
+	return runRestoreJob(ctx, p, jr, opts.Detached, collectTelemetry, resultsCh)
+}
+
+// runRestoreJob creates the restore job of the record. If detached, it only
+// returns the ID of the job, otherwise it starts the job and waits for it to
+// finish.
+func runRestoreJob(
+	ctx context.Context,
+	p sql.PlanHookState,
+	jr jobs.Record,
+	detached bool,
+	collectTelemetry func(),
+	resultsCh chan<- tree.Datums,
+) error {
+	if detached {
 		// When running in detached mode, we simply create the job record.
 		// We do not wait for the job to finish.
 		jobID := p.ExecCfg().JobRegistry.MakeJobID()
@@ -1900,6 +2134,93 @@ func renameTargetDatabaseDescriptor(
 	return nil
 }
 
+// renameTargetTableDescriptor renames the single table being restored by a
+// RESTORE TABLE ... AS.
+func renameTargetTableDescriptor(sqlDescs []catalog.Descriptor, newTableName string) error {
+	var table *tabledesc.Mutable
+	for _, desc := range sqlDescs {
+		tbl, isTable := desc.(*tabledesc.Mutable)
+		if !isTable {
+			continue
+		}
+		if table != nil {
+			return errors.New("RESTORE TABLE ... AS can only be used to restore a single table")
+		}
+		table = tbl
+	}
+	if table == nil {
+		return errors.NewAssertionErrorWithWrappedErrf(errors.New(
+			"expected a table to rename"), "assertion failed")
+	}
+	table.SetName(newTableName)
+	return nil
+}
+
+// resolveNewTableName returns the name of the table of a RESTORE TABLE ... AS.
+// If the name is qualified with a database, or a database and a schema, it
+// sets intoDB and newSchemaName to them, and fails if they were already set by
+// the into_db and new_schema options.
+func resolveNewTableName(
+	name *tree.UnresolvedObjectName, intoDB *string, newSchemaName *string,
+) (string, error) {
+	var db, schema string
+	switch name.NumParts {
+	case 1:
+	case 2:
+		db = name.Parts[1]
+	case 3:
+		db, schema = name.Parts[2], name.Parts[1]
+	default:
+		return "", errors.Errorf("invalid table name: %s", tree.ErrString(name))
+	}
+	if db != "" {
+		if *intoDB != "" {
+			return "", errors.Errorf("cannot use %q option when the new table name is qualified with a database",
+				restoreOptIntoDB)
+		}
+		*intoDB = db
+	}
+	if schema != "" {
+		if *newSchemaName != "" {
+			return "", errors.Errorf("cannot use %q option when the new table name is qualified with a schema",
+				restoreOptNewSchema)
+		}
+		*newSchemaName = schema
+	}
+	return name.Parts[0], nil
+}
+
+// pruneSchemasForNewSchema prepares the descriptors of a RESTORE with the
+// new_schema option, which restores the tables into an existing schema rather
+// than the schemas they are in in the backup. The schemas in the backup are
+// then only restored if a restored type is in them. Views are not supported,
+// as their queries refer to the objects they depend on by the names they have
+// in the backup.
+func pruneSchemasForNewSchema(
+	tablesByID map[descpb.ID]*tabledesc.Mutable,
+	schemasByID map[descpb.ID]*schemadesc.Mutable,
+	typesByID map[descpb.ID]*typedesc.Mutable,
+) error {
+	for _, table := range tablesByID {
+		if table.IsView() {
+			return errors.Errorf("cannot restore view %q with the %q option", table.GetName(), restoreOptNewSchema)
+		}
+	}
+	for id := range schemasByID {
+		used := false
+		for _, typ := range typesByID {
+			if typ.GetParentSchemaID() == id {
+				used = true
+				break
+			}
+		}
+		if !used {
+			delete(schemasByID, id)
+		}
+	}
+	return nil
+}
+
 func planDatabaseModifiersForRestore(
 	ctx context.Context,
 	p sql.PlanHookState,
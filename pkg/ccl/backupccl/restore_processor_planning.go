@@ -37,16 +37,21 @@ import (
 // should be routed to the node that is the leaseholder of that span. The
 // restore data processor will finally download and insert the data, and this is
 // reported back to the coordinator via the progCh.
+// If validateOnly is set, no range is split or scattered, the chunks are spread
+// evenly across the nodes, and the restore data processors only read and check
+// the files of the entries.
 // This method also closes the given progCh.
 func distRestore(
 	ctx context.Context,
 	execCtx sql.JobExecContext,
+	jobID jobspb.JobID,
 	chunks [][]execinfrapb.RestoreSpanEntry,
 	pkIDs map[uint64]bool,
 	encryption *jobspb.BackupEncryptionOptions,
 	tableRekeys []execinfrapb.TableRekey,
 	tenantRekeys []execinfrapb.TenantRekey,
 	restoreTime hlc.Timestamp,
+	validateOnly bool,
 	progCh chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress,
 ) error {
 	ctx = logtags.AddTag(ctx, "restore-distsql", nil)
@@ -83,7 +88,9 @@ func distRestore(
 		return err
 	}
 
-	splitAndScatterSpecs, err := makeSplitAndScatterSpecs(sqlInstanceIDs, chunks, tableRekeys, tenantRekeys)
+	splitAndScatterSpecs, err := makeSplitAndScatterSpecs(
+		sqlInstanceIDs, chunks, tableRekeys, tenantRekeys, validateOnly,
+	)
 	if err != nil {
 		return err
 	}
@@ -94,6 +101,11 @@ func distRestore(
 		TableRekeys:  tableRekeys,
 		TenantRekeys: tenantRekeys,
 		PKIDs:        pkIDs,
+		ValidateOnly: validateOnly,
+		JobID:        jobID,
+		// A restore data processor is planned on every node.
+		NumNodes:      int32(len(sqlInstanceIDs)),
+		NumProcessors: int32(len(sqlInstanceIDs)),
 	}
 
 	if len(splitAndScatterSpecs) == 0 {
@@ -243,6 +255,7 @@ func makeSplitAndScatterSpecs(
 	chunks [][]execinfrapb.RestoreSpanEntry,
 	tableRekeys []execinfrapb.TableRekey,
 	tenantRekeys []execinfrapb.TenantRekey,
+	validateOnly bool,
 ) (map[base.SQLInstanceID]*execinfrapb.SplitAndScatterSpec, error) {
 	specsBySQLInstanceID := make(map[base.SQLInstanceID]*execinfrapb.SplitAndScatterSpec)
 	for i, chunk := range chunks {
@@ -258,6 +271,10 @@ func makeSplitAndScatterSpecs(
 				}},
 				TableRekeys:  tableRekeys,
 				TenantRekeys: tenantRekeys,
+				ValidateOnly: validateOnly,
+			}
+			if validateOnly {
+				specsBySQLInstanceID[sqlInstanceID].ValidateSQLInstanceIDs = sqlInstanceIDs
 			}
 		}
 	}
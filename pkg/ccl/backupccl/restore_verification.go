@@ -0,0 +1,268 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package backupccl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+// maxReportedVerificationFailures is the maximum number of failed files listed
+// in the error of a restore job that verifies a backup.
+const maxReportedVerificationFailures = 20
+
+// layerVerificationFailure is a file of a layer of the backup that failed
+// verification.
+type layerVerificationFailure struct {
+	layer int
+	BackupFileVerificationFailure
+}
+
+// verifyBackup runs a restore job with the verify_only option. Rather than
+// restoring anything, it has the restore data processors read every file of
+// the layers of the backup that the restore would read, and that holds data of
+// the targets of the restore. The job fails, listing the files per layer, if
+// any of them is missing, corrupt or mis-encrypted.
+func (r *restoreResumer) verifyBackup(ctx context.Context, p sql.JobExecContext) error {
+	details := r.job.Details().(jobspb.RestoreDetails)
+
+	mem := p.ExecCfg().RootMemoryMonitor.MakeBoundAccount()
+	defer mem.Close(ctx)
+
+	backupManifests, memSize, err := loadBackupManifests(ctx, &mem, details.URIs,
+		p.User(), p.ExecCfg().DistSQLSrv.ExternalStorageFromURI, details.Encryption)
+	if err != nil {
+		return err
+	}
+	defer mem.Shrink(ctx, memSize)
+
+	lastBackupIndex, err := getBackupIndexAtTime(backupManifests, details.EndTime)
+	if err != nil {
+		return err
+	}
+	backupManifests = backupManifests[:lastBackupIndex+1]
+
+	backupLocalityMap, err := makeBackupLocalityMap(details.BackupLocalityInfo, p.User())
+	if err != nil {
+		return errors.Wrap(err, "resolving locality locations")
+	}
+
+	spans, pkIDs, err := spansToVerify(details, backupManifests[lastBackupIndex])
+	if err != nil {
+		return err
+	}
+	entries, layers := makeVerificationEntries(spans, backupManifests, backupLocalityMap)
+	if len(entries) == 0 {
+		log.Warning(ctx, "no backup files to verify")
+		emitRestoreJobEvent(ctx, p, jobs.StatusSucceeded, r.job)
+		return nil
+	}
+
+	var mu struct {
+		syncutil.Mutex
+		res      roachpb.RowCount
+		failures []layerVerificationFailure
+	}
+
+	requestFinishedCh := make(chan struct{}, len(entries)) // enough buffer to never block
+	progCh := make(chan *execinfrapb.RemoteProducerMetadata_BulkProcessorProgress)
+
+	progressLogger := jobs.NewChunkProgressLogger(r.job, len(entries), r.job.FractionCompleted(), nil)
+	jobProgressLoop := func(ctx context.Context) error {
+		return progressLogger.Loop(ctx, requestFinishedCh)
+	}
+
+	collectFailures := func(ctx context.Context) error {
+		defer close(requestFinishedCh)
+		for progress := range progCh {
+			var progDetails RestoreProgress
+			if err := pbtypes.UnmarshalAny(&progress.ProgressDetails, &progDetails); err != nil {
+				log.Errorf(ctx, "unable to unmarshal restore progress details: %+v", err)
+				continue
+			}
+			mu.Lock()
+			mu.res.Add(progDetails.Summary)
+			for _, f := range progDetails.VerificationFailures {
+				mu.failures = append(mu.failures, layerVerificationFailure{
+					layer: layers[progDetails.ProgressIdx], BackupFileVerificationFailure: f,
+				})
+			}
+			mu.Unlock()
+			requestFinishedCh <- struct{}{}
+		}
+		return nil
+	}
+
+	runVerification := func(ctx context.Context) error {
+		return distRestore(
+			ctx,
+			p,
+			r.job.ID(),
+			chunkVerificationEntries(entries),
+			pkIDs,
+			details.Encryption,
+			nil, /* tableRekeys */
+			nil, /* tenantRekeys */
+			details.EndTime,
+			true, /* validateOnly */
+			progCh,
+		)
+	}
+
+	if err := ctxgroup.GoAndWait(ctx, jobProgressLoop, collectFailures, runVerification); err != nil {
+		return errors.Wrapf(err, "verifying %d backup files", len(entries))
+	}
+
+	r.restoreStats = mu.res
+	if len(mu.failures) > 0 {
+		telemetry.Count("restore.verify.failed")
+		return makeVerificationError(details.URIs, mu.failures, len(entries))
+	}
+
+	telemetry.Count("restore.verify.succeeded")
+	emitRestoreJobEvent(ctx, p, jobs.StatusSucceeded, r.job)
+	return nil
+}
+
+// spansToVerify returns the spans of the backed up data of the targets of the
+// restore, and the IDs of their primary indexes.
+func spansToVerify(
+	details jobspb.RestoreDetails, latestBackupManifest BackupManifest,
+) ([]roachpb.Span, map[uint64]bool, error) {
+	// backupCodec is the codec that was used to encode the keys in the backup,
+	// as in doResume.
+	backupCodec := keys.SystemSQLCodec
+	if len(details.TableDescs) != 0 && len(latestBackupManifest.Spans) != 0 && !latestBackupManifest.HasTenants() {
+		_, backupTenantID, err := keys.DecodeTenantPrefix(latestBackupManifest.Spans[0].Key)
+		if err != nil {
+			return nil, nil, err
+		}
+		backupCodec = keys.MakeSQLCodec(backupTenantID)
+	}
+
+	pkIDs := make(map[uint64]bool)
+	tables := make([]catalog.TableDescriptor, 0, len(details.TableDescs))
+	for _, desc := range details.TableDescs {
+		table := tabledesc.NewBuilder(desc).BuildImmutableTable()
+		tables = append(tables, table)
+		pkIDs[roachpb.BulkOpSummaryID(uint64(table.GetID()), uint64(table.GetPrimaryIndexID()))] = true
+	}
+	spans := spansForAllRestoreTableIndexes(backupCodec, tables, nil /* revs */)
+
+	for _, tenant := range details.Tenants {
+		prefix := keys.MakeTenantPrefix(roachpb.MakeTenantID(tenant.ID))
+		spans = append(spans, roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()})
+	}
+	return spans, pkIDs, nil
+}
+
+// makeVerificationEntries returns an entry for every file of the backups that
+// holds data in spans. Unlike the entries of a restore, each entry holds
+// exactly one file, so that every file is read exactly once and every key it
+// holds is checked. Since the backup may list the same file more than once,
+// once per span it holds data of, the span of an entry covers all of the spans
+// listed for its file. It also returns the layer of the file of each entry.
+func makeVerificationEntries(
+	spans []roachpb.Span, backups []BackupManifest, backupLocalityMap map[int]storeByLocalityKV,
+) ([]execinfrapb.RestoreSpanEntry, []int) {
+	type fileKey struct {
+		layer      int
+		localityKV string
+		path       string
+	}
+	var entries []execinfrapb.RestoreSpanEntry
+	var layers []int
+	entryIdx := make(map[fileKey]int)
+	for layer := range backups {
+		for _, f := range backups[layer].Files {
+			overlaps := false
+			for _, sp := range spans {
+				if sp.Overlaps(f.Span) {
+					overlaps = true
+					break
+				}
+			}
+			if !overlaps {
+				continue
+			}
+			key := fileKey{layer: layer, localityKV: f.LocalityKV, path: f.Path}
+			if i, ok := entryIdx[key]; ok {
+				entries[i].Span = entries[i].Span.Combine(f.Span)
+				continue
+			}
+			fileSpec := execinfrapb.RestoreFileSpec{Path: f.Path, Dir: backups[layer].Dir}
+			if dir, ok := backupLocalityMap[layer][f.LocalityKV]; ok {
+				fileSpec.Dir = dir
+			}
+			entry := makeEntry(f.Span.Key, f.Span.EndKey, fileSpec)
+			entry.ProgressIdx = int64(len(entries))
+			entryIdx[key] = len(entries)
+			entries = append(entries, entry)
+			layers = append(layers, layer)
+		}
+	}
+	return entries, layers
+}
+
+// chunkVerificationEntries puts every entry in a chunk of its own. The files of
+// a backup can be checked in any order and on any node, so unlike the chunks
+// of a restore, which are laid out to keep the ingestion of a span on one node,
+// the chunks of a verification only serve to spread the files evenly across
+// the nodes.
+func chunkVerificationEntries(
+	entries []execinfrapb.RestoreSpanEntry,
+) [][]execinfrapb.RestoreSpanEntry {
+	chunks := make([][]execinfrapb.RestoreSpanEntry, len(entries))
+	for i := range entries {
+		chunks[i] = entries[i : i+1]
+	}
+	return chunks
+}
+
+// makeVerificationError returns the error of a restore job that found files of
+// the backup that failed verification, listing them by layer.
+func makeVerificationError(
+	uris []string, failures []layerVerificationFailure, numFiles int,
+) error {
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].layer != failures[j].layer {
+			return failures[i].layer < failures[j].layer
+		}
+		return failures[i].Path < failures[j].Path
+	})
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d of %d backup files failed verification", len(failures), numFiles)
+	for i, f := range failures {
+		if i == maxReportedVerificationFailures {
+			fmt.Fprintf(&buf, "\n... and %d more", len(failures)-i)
+			break
+		}
+		fmt.Fprintf(&buf, "\nlayer %d (%s): %s file %s: %s", f.layer,
+			RedactURIForErrorMessage(uris[f.layer]), strings.ToLower(f.Kind.String()), f.Path, f.Error)
+	}
+	return errors.Newf("%s", buf.String())
+}
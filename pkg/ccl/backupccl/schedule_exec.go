@@ -10,6 +10,7 @@ package backupccl
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs"
@@ -100,6 +101,9 @@ func (e *scheduledBackupExecutor) executeBackup(
 	// Invoke backup plan hook.
 	hook, cleanup := cfg.PlanHookMaker("exec-backup", txn, sj.Owner())
 	defer cleanup()
+	if compacting, err := maybeCompactBackupChain(ctx, hook.(sql.PlanHookState), sj, backupStmt); err != nil || compacting {
+		return err
+	}
 	backupFn, err := planBackup(ctx, hook.(sql.PlanHookState), backupStmt)
 	if err != nil {
 		return err
@@ -107,6 +111,97 @@ func (e *scheduledBackupExecutor) executeBackup(
 	return invokeBackup(ctx, backupFn)
 }
 
+// maybeCompactBackupChain starts a job compacting the latest backup chain in
+// the collection of an incremental backup schedule if the schedule was created
+// with the compact_after option, and the chain holds at least as many
+// incremental backups as that option specifies. It returns true if it started
+// the job.
+//
+// The compaction job takes the place of this run's incremental backup, and is
+// owned by the schedule. The schedule only runs once its previous job
+// completed, and cannot be created with on_previous_running = 'start' when it
+// compacts its chain, so the compaction never runs concurrently with the
+// incremental backups it compacts or those that follow it: the next one is
+// appended to the compacted backup. Once the compaction succeeds, the schedule
+// runs again right away rather than waiting for its next scheduled time, see
+// backupSucceeded.
+func maybeCompactBackupChain(
+	ctx context.Context, p sql.PlanHookState, sj *jobs.ScheduledJob, backupStmt *annotatedBackupStatement,
+) (bool, error) {
+	args := &ScheduledBackupExecutionArgs{}
+	if err := pbtypes.UnmarshalAny(sj.ExecutionArgs().Args, args); err != nil {
+		return false, errors.Wrap(err, "un-marshaling args")
+	}
+	if args.CompactAfter == 0 || !backupStmt.AppendToLatest {
+		return false, nil
+	}
+
+	numIncrementals, err := countLatestIncrementalBackups(ctx, p, backupStmt.Backup)
+	if err != nil {
+		// Failing to look at the chain only postpones its compaction to a later
+		// run of the schedule, so take the incremental backup instead.
+		log.Warningf(ctx, "schedule %d: failed to count incremental backups to compact: %v",
+			sj.ScheduleID(), err)
+		return false, nil
+	}
+	if int64(numIncrementals) < args.CompactAfter {
+		return false, nil
+	}
+
+	compactStmt := *backupStmt.Backup
+	compactStmt.Targets = nil
+	compactStmt.AsOf = tree.AsOfClause{}
+	compactStmt.Options.Compact = true
+	log.Infof(ctx, "Starting compaction of %d incremental backups for schedule %d: %s",
+		numIncrementals, sj.ScheduleID(), tree.AsString(&compactStmt))
+
+	compactFn, err := planBackup(ctx, p, &annotatedBackupStatement{
+		Backup:        &compactStmt,
+		CreatedByInfo: backupStmt.CreatedByInfo,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, invokeBackup(ctx, compactFn)
+}
+
+// countLatestIncrementalBackups returns the number of incremental backups in
+// the latest backup chain of the collection backupStmt appends to.
+func countLatestIncrementalBackups(
+	ctx context.Context, p sql.PlanHookState, backupStmt *tree.Backup,
+) (int, error) {
+	rawURIs := func(exprs tree.StringOrPlaceholderOptList) ([]string, error) {
+		uris := make([]string, len(exprs))
+		for i := range exprs {
+			uri, ok := exprs[i].(*tree.StrVal)
+			if !ok {
+				return nil, errors.Errorf("unexpected %T destination in backup statement", exprs[i])
+			}
+			uris[i] = uri.RawString()
+		}
+		return uris, nil
+	}
+	to, err := rawURIs(backupStmt.To)
+	if err != nil {
+		return 0, err
+	}
+	incrementalStorage, err := rawURIs(backupStmt.Options.IncrementalStorage)
+	if err != nil {
+		return 0, err
+	}
+
+	makeCloudStorage := p.ExecCfg().DistSQLSrv.ExternalStorageFromURI
+	collectionURI, _, err := getURIsByLocalityKV(to, "")
+	if err != nil {
+		return 0, err
+	}
+	latest, err := readLatestFile(ctx, collectionURI, makeCloudStorage, p.User())
+	if err != nil {
+		return 0, err
+	}
+	return countIncrementalBackups(ctx, makeCloudStorage, p.User(), to, incrementalStorage, latest)
+}
+
 func invokeBackup(ctx context.Context, backupFn sql.PlanHookRowFn) error {
 	resultCh := make(chan tree.Datums) // No need to close
 	g := ctxgroup.WithContext(ctx)
@@ -272,6 +367,23 @@ func (e *scheduledBackupExecutor) GetCreateScheduleStatement(
 		},
 	}
 
+	// The compact_after option is stored on the incremental schedule, which is
+	// either sj or its dependent schedule.
+	compactAfter := args.CompactAfter
+	if dependentSchedule != nil && !backupNode.AppendToLatest {
+		dependentArgs := &ScheduledBackupExecutionArgs{}
+		if err := pbtypes.UnmarshalAny(dependentSchedule.ExecutionArgs().Args, dependentArgs); err != nil {
+			return "", errors.Wrap(err, "un-marshaling args")
+		}
+		compactAfter = dependentArgs.CompactAfter
+	}
+	if compactAfter > 0 {
+		scheduleOptions = append(scheduleOptions, tree.KVOption{
+			Key:   optCompactAfter,
+			Value: tree.NewDString(strconv.FormatInt(compactAfter, 10)),
+		})
+	}
+
 	var destinations []string
 	for i := range backupNode.To {
 		dest, ok := backupNode.To[i].(*tree.StrVal)
@@ -330,6 +442,14 @@ func (e *scheduledBackupExecutor) backupSucceeded(
 		return errors.Wrap(err, "un-marshaling args")
 	}
 
+	// A compaction took the place of an incremental backup of the schedule, so
+	// take that backup right away. The compaction backs up no new data, so it
+	// does not update the RPO metric either.
+	if details.(jobspb.BackupDetails).Compact {
+		schedule.SetNextRun(env.Now())
+		return nil
+	}
+
 	// If this schedule is designated as maintaining the "LastBackup" metric used
 	// for monitoring an RPO SLA, update that metric.
 	if args.UpdatesLastBackupMetric {
@@ -10,6 +10,7 @@ package backupccl
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"path"
 	"strings"
@@ -100,11 +101,52 @@ func (m manifestInfoReader) showBackup(
 	incPaths []string,
 	resultsCh chan<- tree.Datums,
 ) error {
-	var memSize int64
-	defer func() {
-		mem.Shrink(ctx, memSize)
-	}()
+	manifests, memSize, err := readShowBackupManifests(ctx, mem, store, incStore, enc, incPaths)
+	if err != nil {
+		return err
+	}
+	defer mem.Shrink(ctx, memSize)
+
+	// Ensure that the descriptors in the backup manifests are up to date.
+	//
+	// This is necessary in particular for upgrading descriptors with old-style
+	// foreign keys which are no longer supported.
+	// If we are restoring a backup with old-style foreign keys, skip over the
+	// FKs for which we can't resolve the cross-table references. We can't
+	// display them anyway, because we don't have the referenced table names,
+	// etc.
+	err = maybeUpgradeDescriptorsInBackupManifests(manifests, true /* skipFKsWithNoMatchingTable */)
+	if err != nil {
+		return err
+	}
+
+	datums, err := m.shower.fn(manifests)
+	if err != nil {
+		return err
+	}
 
+	for _, row := range datums {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case resultsCh <- row:
+		}
+	}
+	return nil
+}
+
+// readShowBackupManifests reads the manifest of the base backup in store and
+// the manifests of the incremental backups at incPaths in incStore. The caller
+// must shrink mem by the returned size once it is done with the manifests.
+func readShowBackupManifests(
+	ctx context.Context,
+	mem *mon.BoundAccount,
+	store cloud.ExternalStorage,
+	incStore cloud.ExternalStorage,
+	enc *jobspb.BackupEncryptionOptions,
+	incPaths []string,
+) ([]BackupManifest, int64, error) {
+	var memSize int64
 	var err error
 	manifests := make([]BackupManifest, len(incPaths)+1)
 	manifests[0], memSize, err = ReadBackupManifestFromStore(ctx, mem, store, enc)
@@ -114,50 +156,135 @@ func (m manifestInfoReader) showBackup(
 			latestFileExists, errLatestFile := checkForLatestFileInCollection(ctx, store)
 
 			if errLatestFile == nil && latestFileExists {
-				return errors.WithHintf(err, "The specified path is the root of a backup collection. "+
+				return nil, 0, errors.WithHintf(err, "The specified path is the root of a backup collection. "+
 					"Use SHOW BACKUPS IN with this path to list all the backup subdirectories in the"+
 					" collection. SHOW BACKUP can be used with any of these subdirectories to inspect a"+
 					" backup.")
 			}
-			return errors.CombineErrors(err, errLatestFile)
+			return nil, 0, errors.CombineErrors(err, errLatestFile)
 		}
-		return err
+		return nil, 0, err
 	}
 
 	for i := range incPaths {
 		m, sz, err := readBackupManifest(ctx, mem, incStore, incPaths[i], enc)
 		if err != nil {
-			return err
+			mem.Shrink(ctx, memSize)
+			return nil, 0, err
 		}
 		memSize += sz
 		// Blank the stats to prevent memory blowup.
 		m.DeprecatedStatistics = nil
 		manifests[i+1] = m
 	}
+	return manifests, memSize, nil
+}
 
-	// Ensure that the descriptors in the backup manifests are up to date.
-	//
-	// This is necessary in particular for upgrading descriptors with old-style
-	// foreign keys which are no longer supported.
-	// If we are restoring a backup with old-style foreign keys, skip over the
-	// FKs for which we can't resolve the cross-table references. We can't
-	// display them anyway, because we don't have the referenced table names,
-	// etc.
-	err = maybeUpgradeDescriptorsInBackupManifests(manifests, true /* skipFKsWithNoMatchingTable */)
-	if err != nil {
-		return err
+// checkFilesInfoReader reads back every file of every layer of a backup, the
+// way RESTORE ... WITH verify_only does, and reports for each file whether it
+// is missing, corrupt or mis-encrypted.
+type checkFilesInfoReader struct{}
+
+var _ backupInfoReader = checkFilesInfoReader{}
+
+func (checkFilesInfoReader) header() colinfo.ResultColumns {
+	return colinfo.ResultColumns{
+		{Name: "layer", Typ: types.Int},
+		{Name: "path", Typ: types.String},
+		{Name: "status", Typ: types.String},
+		{Name: "error", Typ: types.String},
 	}
+}
 
-	datums, err := m.shower.fn(manifests)
+// showBackup implements backupInfoReader. Each file is read once, even if the
+// manifest of its layer lists it more than once. The files of a
+// locality-aware backup that were written to the store of a locality other
+// than the default one are reported as unchecked, since only the default store
+// is known to SHOW BACKUP.
+func (checkFilesInfoReader) showBackup(
+	ctx context.Context,
+	mem *mon.BoundAccount,
+	store cloud.ExternalStorage,
+	incStore cloud.ExternalStorage,
+	enc *jobspb.BackupEncryptionOptions,
+	incPaths []string,
+	resultsCh chan<- tree.Datums,
+) error {
+	manifests, memSize, err := readShowBackupManifests(ctx, mem, store, incStore, enc, incPaths)
 	if err != nil {
 		return err
 	}
+	defer mem.Shrink(ctx, memSize)
 
-	for _, row := range datums {
+	var encryption *roachpb.FileEncryptionOptions
+	if enc != nil {
+		key, err := getEncryptionKey(ctx, enc, store.Settings(), store.ExternalIOConf())
+		if err != nil {
+			return err
+		}
+		encryption = &roachpb.FileEncryptionOptions{Key: key}
+	}
+
+	emit := func(layer int, filePath, status string, errMsg tree.Datum) error {
+		row := tree.Datums{
+			tree.NewDInt(tree.DInt(layer)),
+			tree.NewDString(filePath),
+			tree.NewDString(status),
+			errMsg,
+		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case resultsCh <- row:
+			return nil
+		}
+	}
+
+	for layer := range manifests {
+		layerStore, layerDir := store, ""
+		if layer > 0 {
+			layerStore, layerDir = incStore, path.Dir(incPaths[layer-1])
+		}
+
+		type fileKey struct {
+			localityKV string
+			path       string
+		}
+		var files []fileKey
+		spans := make(map[fileKey]roachpb.Span)
+		for _, f := range manifests[layer].Files {
+			key := fileKey{localityKV: f.LocalityKV, path: f.Path}
+			if sp, ok := spans[key]; ok {
+				spans[key] = sp.Combine(f.Span)
+				continue
+			}
+			files = append(files, key)
+			spans[key] = f.Span
+		}
+
+		for _, f := range files {
+			if f.localityKV != "" {
+				if err := emit(layer, f.path, "unchecked", tree.NewDString(
+					fmt.Sprintf("file is stored in the backup of locality %s", f.localityKV),
+				)); err != nil {
+					return err
+				}
+				continue
+			}
+			_, failure, err := validateBackupFile(
+				ctx, layerStore, path.Join(layerDir, f.path), spans[f], encryption,
+			)
+			if err != nil {
+				return errors.Wrapf(err, "checking layer %d file %s", layer, f.path)
+			}
+			if failure != nil {
+				err = emit(layer, f.path, strings.ToLower(failure.Kind.String()), tree.NewDString(failure.Error))
+			} else {
+				err = emit(layer, f.path, "ok", tree.DNull)
+			}
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -207,6 +334,9 @@ func showBackupPlanHook(
 	}
 
 	if _, asJSON := opts[backupOptAsJSON]; asJSON {
+		if backup.Details == tree.BackupCheckFilesDetails {
+			return nil, nil, nil, false, errors.Newf("%s cannot be used with SHOW BACKUP CHECK FILES", backupOptAsJSON)
+		}
 		backup.Details = tree.BackupManifestAsJSON
 	}
 
@@ -219,10 +349,14 @@ func showBackupPlanHook(
 		shower = backupShowerFiles
 	case tree.BackupManifestAsJSON:
 		shower = jsonShower
+	case tree.BackupCheckFilesDetails:
+		infoReader = checkFilesInfoReader{}
 	default:
 		shower = backupShowerDefault(ctx, p, backup.ShouldIncludeSchemas, opts)
 	}
-	infoReader = manifestInfoReader{shower}
+	if infoReader == nil {
+		infoReader = manifestInfoReader{shower}
+	}
 
 	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
 		// TODO(dan): Move this span into sql.
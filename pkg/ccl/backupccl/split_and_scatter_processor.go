@@ -56,6 +56,52 @@ func (n noopSplitAndScatterer) scatter(
 	return 0, nil
 }
 
+// roundRobinScatterer neither splits nor scatters any range, and sends each
+// chunk to the next of the given nodes in turn. It is used when the entries are
+// only read to validate the files of a backup, which can be done on any node,
+// so that the reads are spread evenly across the cluster rather than placed
+// where the entries would be ingested.
+type roundRobinScatterer struct {
+	nodeIDs []roachpb.NodeID
+	next    int
+}
+
+var _ splitAndScatterer = &roundRobinScatterer{}
+
+// makeRoundRobinScatterer returns a roundRobinScatterer over the given SQL
+// instances that starts with the one after nodeID, so that the processors
+// planned on different nodes do not all send their first chunks to the same
+// node.
+func makeRoundRobinScatterer(
+	sqlInstanceIDs []base.SQLInstanceID, nodeID roachpb.NodeID,
+) *roundRobinScatterer {
+	s := &roundRobinScatterer{nodeIDs: make([]roachpb.NodeID, len(sqlInstanceIDs))}
+	for i, id := range sqlInstanceIDs {
+		s.nodeIDs[i] = roachpb.NodeID(id)
+		if s.nodeIDs[i] == nodeID {
+			s.next = i + 1
+		}
+	}
+	if len(s.nodeIDs) == 0 {
+		s.nodeIDs = []roachpb.NodeID{nodeID}
+	}
+	return s
+}
+
+// split implements splitAndScatterer.
+func (s *roundRobinScatterer) split(_ context.Context, _ keys.SQLCodec, _ roachpb.Key) error {
+	return nil
+}
+
+// scatter implements splitAndScatterer.
+func (s *roundRobinScatterer) scatter(
+	_ context.Context, _ keys.SQLCodec, _ roachpb.Key,
+) (roachpb.NodeID, error) {
+	nodeID := s.nodeIDs[s.next%len(s.nodeIDs)]
+	s.next++
+	return nodeID, nil
+}
+
 // dbSplitAndScatter is the production implementation of this processor's
 // scatterer. It actually issues the split and scatter requests against the KV
 // layer.
@@ -220,6 +266,11 @@ func newSplitAndScatterProcessor(
 	}
 
 	scatterer := makeSplitAndScatterer(db, kr)
+	if spec.ValidateOnly {
+		scatterer = makeRoundRobinScatterer(
+			spec.ValidateSQLInstanceIDs, roachpb.NodeID(flowCtx.NodeID.SQLInstanceID()),
+		)
+	}
 	ssp := &splitAndScatterProcessor{
 		flowCtx:   flowCtx,
 		spec:      spec,
@@ -11,6 +11,7 @@ package changefeedccl
 import (
 	"context"
 	"net/url"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/backupccl/backupresolver"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
@@ -111,15 +112,51 @@ func alterChangefeedPlanHook(
 		}
 
 		newDescs := make(map[descpb.ID]*tree.UnresolvedName)
+		prevTargets := make(map[descpb.ID]struct{})
 
 		for _, target := range AllTargets(prevDetails) {
 			desc := descResolver.DescByID[target.TableID]
 			newDescs[target.TableID] = tree.NewUnresolvedName(desc.GetName())
+			prevTargets[target.TableID] = struct{}{}
 		}
 
+		// initialScanTargets are the targets added WITH initial_scan.
+		initialScanTargets := make(map[descpb.ID]struct{})
+
 		for _, cmd := range alterChangefeedStmt.Cmds {
+			switch cmd.(type) {
+			case *tree.AlterChangefeedAddTarget, *tree.AlterChangefeedDropTarget:
+				// The tables of a changefeed on a database are those of the
+				// database, they are not managed by the user.
+				if prevDetails.DatabaseID != descpb.InvalidID {
+					return pgerror.Newf(pgcode.InvalidParameterValue,
+						`cannot add or drop targets of changefeed job %d on a database`, jobID)
+				}
+			}
 			switch v := cmd.(type) {
 			case *tree.AlterChangefeedAddTarget:
+				if len(v.Targets.Databases) > 0 {
+					return pgerror.Newf(pgcode.InvalidParameterValue,
+						`cannot add a database to changefeed job %d`, jobID)
+				}
+				targetOptsFn, err := p.TypeAsStringOpts(ctx, v.Options, changefeedbase.AlterChangefeedTargetOptionExpectValues)
+				if err != nil {
+					return err
+				}
+				targetOpts, err := targetOptsFn()
+				if err != nil {
+					return err
+				}
+				_, withInitialScan := targetOpts[changefeedbase.OptInitialScan]
+				_, withNoInitialScan := targetOpts[changefeedbase.OptNoInitialScan]
+				if withInitialScan && withNoInitialScan {
+					return pgerror.Newf(
+						pgcode.InvalidParameterValue,
+						`cannot specify both %q and %q`, changefeedbase.OptInitialScan,
+						changefeedbase.OptNoInitialScan,
+					)
+				}
+
 				for _, targetPattern := range v.Targets.Tables {
 					targetName, err := getTargetName(targetPattern)
 					if err != nil {
@@ -140,6 +177,9 @@ func alterChangefeedPlanHook(
 						return pgerror.Newf(pgcode.InvalidParameterValue, `target %q does not exist`, tree.ErrString(targetPattern))
 					}
 					newDescs[desc.GetID()] = tree.NewUnresolvedName(desc.GetName())
+					if withInitialScan {
+						initialScanTargets[desc.GetID()] = struct{}{}
+					}
 				}
 			case *tree.AlterChangefeedDropTarget:
 				for _, targetPattern := range v.Targets.Tables {
@@ -162,6 +202,7 @@ func alterChangefeedPlanHook(
 						return pgerror.Newf(pgcode.InvalidParameterValue, `target %q does not exist`, tree.ErrString(targetPattern))
 					}
 					delete(newDescs, desc.GetID())
+					delete(initialScanTargets, desc.GetID())
 				}
 			case *tree.AlterChangefeedSetOptions:
 				optsFn, err := p.TypeAsStringOpts(ctx, v.Options, changefeedbase.AlterChangefeedOptionExpectValues)
@@ -224,12 +265,21 @@ func alterChangefeedPlanHook(
 			}
 		}
 
-		if len(newDescs) == 0 {
-			return pgerror.Newf(pgcode.InvalidParameterValue, "cannot drop all targets for changefeed job %d", jobID)
-		}
+		if prevDetails.DatabaseID != descpb.InvalidID {
+			dbDesc, ok := descResolver.DescByID[prevDetails.DatabaseID]
+			if !ok {
+				return pgerror.Newf(pgcode.UndefinedDatabase,
+					"database %d of changefeed job %d does not exist", prevDetails.DatabaseID, jobID)
+			}
+			newChangefeedStmt.Targets.Databases = tree.NameList{tree.Name(dbDesc.GetName())}
+		} else {
+			if len(newDescs) == 0 {
+				return pgerror.Newf(pgcode.InvalidParameterValue, "cannot drop all targets for changefeed job %d", jobID)
+			}
 
-		for _, targetName := range newDescs {
-			newChangefeedStmt.Targets.Tables = append(newChangefeedStmt.Targets.Tables, targetName)
+			for _, targetName := range newDescs {
+				newChangefeedStmt.Targets.Tables = append(newChangefeedStmt.Targets.Tables, targetName)
+			}
 		}
 
 		for _, val := range optionsMap {
@@ -275,6 +325,13 @@ func alterChangefeedPlanHook(
 		// creation of the changefeed
 		newDetails.StatementTime = prevDetails.StatementTime
 
+		// The tables of a changefeed on a database are updated by the changefeed
+		// when it resumes, which also takes care of scanning the new tables.
+		if prevDetails.DatabaseID != descpb.InvalidID {
+			newDetails.Tables = prevDetails.Tables
+			newDetails.TargetSpecifications = prevDetails.TargetSpecifications
+		}
+
 		newPayload := job.Payload()
 		newPayload.Details = jobspb.WrapPayloadDetails(newDetails)
 		newPayload.Description = jobRecord.Description
@@ -284,6 +341,10 @@ func alterChangefeedPlanHook(
 			txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
 		) error {
 			ju.UpdatePayload(&newPayload)
+			newProgress := md.Progress
+			if updateTargetProgress(newProgress, newDescs, prevTargets, initialScanTargets) {
+				ju.UpdateProgress(newProgress)
+			}
 			return nil
 		})
 
@@ -305,6 +366,53 @@ func alterChangefeedPlanHook(
 	return fn, header, nil, false, nil
 }
 
+// updateTargetProgress updates the per-target progress of a changefeed whose
+// targets are being altered: targets that were dropped lose their progress, and
+// targets that were added WITH initial_scan are recorded as needing an initial
+// scan at the current high-water. It returns whether the progress changed.
+//
+// If the changefeed has no high-water yet, its initial scan has not completed
+// and will include the added targets, so no per-target progress is needed.
+func updateTargetProgress(
+	progress *jobspb.Progress,
+	newTargets map[descpb.ID]*tree.UnresolvedName,
+	prevTargets map[descpb.ID]struct{},
+	initialScanTargets map[descpb.ID]struct{},
+) bool {
+	changefeedProgress := progress.GetChangefeed()
+	if changefeedProgress == nil {
+		return false
+	}
+
+	changed := false
+	targets := changefeedProgress.Targets[:0]
+	for _, tp := range changefeedProgress.Targets {
+		if _, ok := newTargets[tp.TableID]; !ok {
+			changed = true
+			continue
+		}
+		targets = append(targets, tp)
+	}
+
+	highWater := progress.GetHighWater()
+	if highWater != nil && !highWater.IsEmpty() {
+		for id := range initialScanTargets {
+			if _, ok := prevTargets[id]; ok {
+				// The target was already watched by the changefeed.
+				continue
+			}
+			targets = append(targets, jobspb.ChangefeedProgress_TargetProgress{
+				TableID:              id,
+				InitialScanTimestamp: *highWater,
+			})
+			changed = true
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].TableID < targets[j].TableID })
+	changefeedProgress.Targets = targets
+	return changed
+}
+
 func getTargetName(targetPattern tree.TablePattern) (*tree.TableName, error) {
 	pattern, err := targetPattern.NormalizeTablePattern()
 	if err != nil {
@@ -12,16 +12,24 @@ import (
 	"context"
 	gosql "database/sql"
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/distsql"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/tests"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -62,6 +70,168 @@ func TestAlterChangefeedAddTarget(t *testing.T) {
 	t.Run(`kafka`, kafkaTest(testFn))
 }
 
+func TestAlterChangefeedAddTargetWithInitialScan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE TABLE bar (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES(1)`)
+		sqlDB.Exec(t, `INSERT INTO bar VALUES(1), (2)`)
+
+		testFeed := feed(t, f, `CREATE CHANGEFEED FOR foo WITH resolved = '100ms'`)
+		defer closeFeed(t, testFeed)
+
+		assertPayloads(t, testFeed, []string{
+			`foo: [1]->{"after": {"a": 1}}`,
+		})
+
+		feed, ok := testFeed.(cdctest.EnterpriseTestFeed)
+		require.True(t, ok)
+
+		// Wait for the high-water to be set so that bar is scanned on its own.
+		testutils.SucceedsSoon(t, func() error {
+			var highWater gosql.NullString
+			sqlDB.QueryRow(t, `SELECT high_water_timestamp FROM [SHOW JOBS] WHERE job_id = $1`,
+				feed.JobID()).Scan(&highWater)
+			if !highWater.Valid {
+				return errors.New("waiting for high-water")
+			}
+			return nil
+		})
+
+		sqlDB.Exec(t, `PAUSE JOB $1`, feed.JobID())
+		waitForJobStatus(sqlDB, t, feed.JobID(), `paused`)
+
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d ADD bar WITH initial_scan`, feed.JobID()))
+
+		sqlDB.Exec(t, fmt.Sprintf(`RESUME JOB %d`, feed.JobID()))
+		waitForJobStatus(sqlDB, t, feed.JobID(), `running`)
+
+		assertPayloads(t, testFeed, []string{
+			`bar: [1]->{"after": {"a": 1}}`,
+			`bar: [2]->{"after": {"a": 2}}`,
+		})
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES(2)`)
+		assertPayloads(t, testFeed, []string{
+			`foo: [2]->{"after": {"a": 2}}`,
+		})
+	}
+
+	t.Run(`kafka`, kafkaTest(testFn))
+}
+
+func TestAlterChangefeedAddTargetInitialScanProtectedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		ctx := context.Background()
+		changefeedbase.ProtectTimestampInterval.Override(
+			ctx, &f.Server().ClusterSettings().SV, 10*time.Millisecond)
+
+		// Block the initial scan of the added target until told otherwise.
+		var blockScan int32
+		unblockScan := make(chan struct{})
+		knobs := f.Server().TestingKnobs().
+			DistSQL.(*execinfra.TestingKnobs).
+			Changefeed.(*TestingKnobs)
+		knobs.FeedKnobs.BeforeScanRequest = func(b *kv.Batch) {
+			if atomic.LoadInt32(&blockScan) == 1 {
+				<-unblockScan
+			}
+		}
+
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE TABLE bar (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES(1)`)
+		sqlDB.Exec(t, `INSERT INTO bar VALUES(1), (2)`)
+
+		testFeed := feed(t, f, `CREATE CHANGEFEED FOR foo WITH resolved = '10ms'`)
+		defer closeFeed(t, testFeed)
+
+		assertPayloads(t, testFeed, []string{
+			`foo: [1]->{"after": {"a": 1}}`,
+		})
+
+		feed, ok := testFeed.(cdctest.EnterpriseTestFeed)
+		require.True(t, ok)
+
+		serverCfg := f.Server().DistSQLServer().(*distsql.ServerImpl).ServerConfig
+		loadProgress := func() (jobspb.Progress, *jobspb.ChangefeedProgress) {
+			j, err := serverCfg.JobRegistry.LoadJob(ctx, feed.JobID())
+			require.NoError(t, err)
+			progress := j.Progress()
+			return progress, progress.GetChangefeed()
+		}
+
+		testutils.SucceedsSoon(t, func() error {
+			if progress, _ := loadProgress(); progress.GetHighWater() == nil {
+				return errors.New("waiting for high-water")
+			}
+			return nil
+		})
+
+		sqlDB.Exec(t, `PAUSE JOB $1`, feed.JobID())
+		waitForJobStatus(sqlDB, t, feed.JobID(), `paused`)
+		sqlDB.Exec(t, fmt.Sprintf(`ALTER CHANGEFEED %d ADD bar WITH initial_scan`, feed.JobID()))
+
+		_, cf := loadProgress()
+		require.Len(t, cf.Targets, 1)
+		scanTS := cf.Targets[0].InitialScanTimestamp
+		require.False(t, scanTS.IsEmpty())
+
+		atomic.StoreInt32(&blockScan, 1)
+		sqlDB.Exec(t, fmt.Sprintf(`RESUME JOB %d`, feed.JobID()))
+		waitForJobStatus(sqlDB, t, feed.JobID(), `running`)
+
+		// The high-water advances past the timestamp bar is scanned at, but the
+		// protected timestamp record must not, or the data read by the scan
+		// could be garbage collected.
+		testutils.SucceedsSoon(t, func() error {
+			progress, cf := loadProgress()
+			if !scanTS.Less(*progress.GetHighWater()) {
+				return errors.New("waiting for the high-water to advance")
+			}
+			require.Equal(t, scanTS, cf.Targets[0].InitialScanTimestamp)
+			return serverCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+				r, err := serverCfg.ProtectedTimestampProvider.GetRecord(ctx, txn, cf.ProtectedTimestampRecord)
+				if err != nil {
+					return err
+				}
+				require.True(t, r.Timestamp.LessEq(scanTS),
+					"protected timestamp %s above the scan timestamp %s", r.Timestamp, scanTS)
+				return nil
+			})
+		})
+
+		atomic.StoreInt32(&blockScan, 0)
+		close(unblockScan)
+		assertPayloads(t, testFeed, []string{
+			`bar: [1]->{"after": {"a": 1}}`,
+			`bar: [2]->{"after": {"a": 2}}`,
+		})
+
+		// Once bar caught up with the changefeed, it is covered by the
+		// high-water.
+		testutils.SucceedsSoon(t, func() error {
+			_, cf := loadProgress()
+			if !cf.Targets[0].InitialScanTimestamp.IsEmpty() {
+				return errors.New("waiting for bar to catch up")
+			}
+			require.True(t, cf.Targets[0].Resolved.IsEmpty())
+			require.Empty(t, cf.Targets[0].Checkpoint)
+			return nil
+		})
+	}
+
+	t.Run(`kafka`, kafkaTest(testFn))
+}
+
 func TestAlterChangefeedDropTarget(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -202,6 +372,14 @@ func TestAlterChangefeedErrors(t *testing.T) {
 			`pq: target "baz" does not exist`,
 			fmt.Sprintf(`ALTER CHANGEFEED %d DROP baz`, feed.JobID()),
 		)
+		sqlDB.ExpectErr(t,
+			`pq: invalid option "qux"`,
+			fmt.Sprintf(`ALTER CHANGEFEED %d ADD bar WITH qux`, feed.JobID()),
+		)
+		sqlDB.ExpectErr(t,
+			`pq: cannot specify both "initial_scan" and "no_initial_scan"`,
+			fmt.Sprintf(`ALTER CHANGEFEED %d ADD bar WITH initial_scan, no_initial_scan`, feed.JobID()),
+		)
 		sqlDB.ExpectErr(t,
 			`pq: invalid option "qux"`,
 			fmt.Sprintf(`ALTER CHANGEFEED %d SET qux`, feed.JobID()),
@@ -55,13 +55,14 @@ func createProtectedTimestampRecord(
 	ctx context.Context,
 	codec keys.SQLCodec,
 	jobID jobspb.JobID,
-	targets []jobspb.ChangefeedTargetSpecification,
+	details jobspb.ChangefeedDetails,
 	resolved hlc.Timestamp,
 	progress *jobspb.ChangefeedProgress,
 ) *ptpb.Record {
 	progress.ProtectedTimestampRecord = uuid.MakeV4()
+	targets := AllTargets(details)
 	deprecatedSpansToProtect := makeSpansToProtect(codec, targets)
-	targetToProtect := makeTargetToProtect(targets)
+	targetToProtect := makeTargetToProtect(targets, details.DatabaseID)
 
 	log.VEventf(ctx, 2, "creating protected timestamp %v at %v", progress.ProtectedTimestampRecord, resolved)
 	return jobsprotectedts.MakeRecord(
@@ -69,14 +70,21 @@ func createProtectedTimestampRecord(
 		jobsprotectedts.Jobs, targetToProtect)
 }
 
-func makeTargetToProtect(targets []jobspb.ChangefeedTargetSpecification) *ptpb.Target {
-	// NB: We add 1 because we're also going to protect system.descriptors.
+func makeTargetToProtect(
+	targets []jobspb.ChangefeedTargetSpecification, databaseID descpb.ID,
+) *ptpb.Target {
+	// NB: We add 2 because we're also going to protect system.descriptors and,
+	// for changefeeds on a database, the database.
 	// We protect system.descriptors because a changefeed needs all of the history
-	// of table descriptors to version data.
-	tablesToProtect := make(descpb.IDs, 0, len(targets)+1)
+	// of table descriptors to version data. The database is protected so that
+	// the tables created in it after the record are protected as well.
+	tablesToProtect := make(descpb.IDs, 0, len(targets)+2)
 	for _, t := range targets {
 		tablesToProtect = append(tablesToProtect, t.TableID)
 	}
+	if databaseID != descpb.InvalidID {
+		tablesToProtect = append(tablesToProtect, databaseID)
+	}
 	tablesToProtect = append(tablesToProtect, keys.DescriptorTableID)
 	return ptpb.MakeSchemaObjectsTarget(tablesToProtect)
 }
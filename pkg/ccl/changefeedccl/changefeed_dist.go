@@ -16,7 +16,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -114,8 +116,69 @@ func distChangefeedFlow(
 	if cf := progress.GetChangefeed(); cf != nil && cf.Checkpoint != nil {
 		checkpoint = *cf.Checkpoint
 	}
+
+	// Targets added by ALTER CHANGEFEED which have not caught up with the
+	// changefeed yet are watched independently of the other targets. They are
+	// scanned at the timestamp recorded when they were added, which the
+	// protected timestamp record of the changefeed keeps from being garbage
+	// collected, so that a scan interrupted by a restart can be resumed.
+	var initialScans []execinfrapb.ChangeAggregatorSpec_InitialScan
+	if !initialHighWater.IsEmpty() {
+		var err error
+		initialScans, err = makeInitialScans(ctx, execCfg, details, progress)
+		if err != nil {
+			return err
+		}
+	}
+
 	return changefeeddist.StartDistChangefeed(
-		ctx, execCtx, jobID, details, trackedSpans, initialHighWater, checkpoint, resultsCh)
+		ctx, execCtx, jobID, details, trackedSpans, initialHighWater, checkpoint,
+		initialScans, resultsCh)
+}
+
+// makeInitialScans returns the initial scans of the targets of the changefeed
+// which were added by ALTER CHANGEFEED ... WITH initial_scan and which have not
+// caught up with the changefeed yet, resuming from their persisted progress.
+func makeInitialScans(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details jobspb.ChangefeedDetails,
+	progress jobspb.Progress,
+) ([]execinfrapb.ChangeAggregatorSpec_InitialScan, error) {
+	cf := progress.GetChangefeed()
+	if cf == nil || len(cf.Targets) == 0 {
+		return nil, nil
+	}
+	pending := make(map[descpb.ID]jobspb.ChangefeedProgress_TargetProgress, len(cf.Targets))
+	for _, tp := range cf.Targets {
+		if !tp.InitialScanTimestamp.IsEmpty() {
+			pending[tp.TableID] = tp
+		}
+	}
+	var scans []execinfrapb.ChangeAggregatorSpec_InitialScan
+	for _, t := range AllTargets(details) {
+		tp, ok := pending[t.TableID]
+		if !ok {
+			continue
+		}
+		// The target is scanned as of the timestamp following the one at which
+		// it was added, which is the earliest timestamp at which it is known to
+		// be watched: tables of a changefeed on a database may only have come
+		// online then.
+		scanTS := tp.InitialScanTimestamp.Next()
+		spans, err := fetchSpansForTargets(
+			ctx, execCfg, []jobspb.ChangefeedTargetSpecification{t}, scanTS)
+		if err != nil {
+			return nil, err
+		}
+		scans = append(scans, execinfrapb.ChangeAggregatorSpec_InitialScan{
+			Spans:      spans,
+			Timestamp:  scanTS,
+			Checkpoint: tp.Checkpoint,
+			Resolved:   tp.Resolved,
+		})
+	}
+	return scans, nil
 }
 
 func fetchSpansForTargets(
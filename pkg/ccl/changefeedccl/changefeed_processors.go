@@ -11,6 +11,7 @@ package changefeedccl
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcutils"
@@ -38,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/bufalloc"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
@@ -81,6 +83,13 @@ type changeAggregator struct {
 	// span was forwarded to the frontier
 	recentKVCount uint64
 
+	// dlq, if non-nil, receives the rows which could not be encoded or were
+	// rejected by the sink. recentDLQCount contains the number of entries
+	// flushed to it since the last time a resolved span was forwarded to the
+	// frontier.
+	dlq            deadLetterQueue
+	recentDLQCount uint64
+
 	// eventProducer produces the next event from the kv feed.
 	eventProducer kvevent.Reader
 	// eventConsumer consumes the event.
@@ -93,6 +102,9 @@ type changeAggregator struct {
 	// frontier keeps track of resolved timestamps for spans along with schema change
 	// boundary information.
 	frontier *schemaChangeFrontier
+	// initialScans are the targets added to the changefeed which are watched
+	// independently of the other spans until they caught up with them.
+	initialScans []execinfrapb.ChangeAggregatorSpec_InitialScan
 
 	metrics    *Metrics
 	sliMetrics *sliMetrics
@@ -218,7 +230,8 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		// spans for, and therefore should be 0 if an initial scan is needed
 		frontierHighWater = hlc.Timestamp{}
 	}
-	spans, err := ca.setupSpansAndFrontier(frontierHighWater)
+	spans, initialScans, err := ca.setupSpansAndFrontier(frontierHighWater)
+	ca.initialScans = initialScans
 
 	if err != nil {
 		ca.MoveToDraining(err)
@@ -229,6 +242,21 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		sf:                         ca.frontier.SpanFrontier(),
 		initialInclusiveLowerBound: ca.spec.Feed.StatementTime,
 	}
+	if len(initialScans) > 0 {
+		// The rows of the targets being backfilled are emitted at the timestamp
+		// of their scan, or after the timestamp they were resolved at if the
+		// scan completed before a restart, both of which may be below
+		// initialHighWater. The lowest of these timestamps is the lowest
+		// timestamp this aggregator may emit until the scans complete.
+		timestampOracle.initialInclusiveLowerBound = initialHighWater
+		for _, scan := range initialScans {
+			lowerBound := scan.Timestamp
+			if !scan.Resolved.IsEmpty() {
+				lowerBound = scan.Resolved.Next()
+			}
+			timestampOracle.initialInclusiveLowerBound.Backward(lowerBound)
+		}
+	}
 
 	if cfKnobs, ok := ca.flowCtx.TestingKnobs().Changefeed.(*TestingKnobs); ok {
 		ca.knobs = *cfKnobs
@@ -257,7 +285,8 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	}
 
 	ca.sink, err = getSink(ctx, ca.flowCtx.Cfg, ca.spec.Feed, timestampOracle,
-		ca.spec.User(), ca.spec.JobID, ca.sliMetrics)
+		ca.spec.User(), ca.spec.JobID,
+		aggregatorSinkOwner(ca.flowCtx.Cfg.NodeID.SQLInstanceID()), ca.sliMetrics)
 
 	if err != nil {
 		err = changefeedbase.MarkRetryableError(err)
@@ -273,9 +302,21 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 		ca.changedRowBuf = &b.buf
 	}
 
+	ca.dlq, err = makeDeadLetterQueue(ctx, ca.flowCtx.Cfg, ca.spec.Feed, ca.spec.JobID,
+		ca.spec.User(), ca.sliMetrics)
+	if err != nil {
+		ca.MoveToDraining(err)
+		ca.cancel()
+		return
+	}
+	if s, ok := ca.sink.(deadLetterQueueSink); ok && ca.dlq != nil {
+		s.setDeadLetterQueue(ca.dlq)
+	}
+
 	ca.sink = &errorWrapperSink{wrapped: ca.sink}
 
-	ca.eventProducer, err = ca.startKVFeed(ctx, spans, initialHighWater, needsInitialScan, ca.sliMetrics)
+	ca.eventProducer, err = ca.startKVFeed(
+		ctx, spans, initialScans, initialHighWater, needsInitialScan, ca.sliMetrics)
 	if err != nil {
 		// Early abort in the case that there is an error creating the sink.
 		ca.MoveToDraining(err)
@@ -288,13 +329,14 @@ func (ca *changeAggregator) Start(ctx context.Context) {
 	} else {
 		ca.eventConsumer = newKVEventToRowConsumer(
 			ctx, ca.flowCtx.Cfg, ca.frontier.SpanFrontier(), initialHighWater,
-			ca.sink, ca.encoder, ca.spec.Feed, ca.knobs)
+			ca.sink, ca.dlq, ca.encoder, ca.spec.Feed, ca.knobs, ca.sliMetrics)
 	}
 }
 
 func (ca *changeAggregator) startKVFeed(
 	ctx context.Context,
 	spans []roachpb.Span,
+	initialScans []execinfrapb.ChangeAggregatorSpec_InitialScan,
 	initialHighWater hlc.Timestamp,
 	needsInitialScan bool,
 	sm *sliMetrics,
@@ -306,7 +348,37 @@ func (ca *changeAggregator) startKVFeed(
 
 	// KVFeed takes ownership of the kvevent.Writer portion of the buffer, while
 	// we return the kvevent.Reader part to the caller.
-	kvfeedCfg := ca.makeKVFeedCfg(ctx, spans, buf, initialHighWater, needsInitialScan, sm)
+	var feeds []kvfeed.Config
+	if len(spans) > 0 {
+		feedCfg, err := ca.makeKVFeedCfg(ctx, spans, buf, initialHighWater, needsInitialScan, sm)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, feedCfg)
+	}
+	// Targets added to the changefeed with an initial scan get a kvfeed of their
+	// own, so that the rangefeeds of the other targets keep running while they
+	// are scanned. All feeds write into the same buffer.
+	//
+	// A target whose scan completed before the changefeed restarted is watched
+	// from the timestamp it was resolved at. Otherwise, the spans of the target
+	// which were not checkpointed are scanned.
+	for _, scan := range initialScans {
+		var backfillCfg kvfeed.Config
+		var err error
+		if !scan.Resolved.IsEmpty() {
+			backfillCfg, err = ca.makeKVFeedCfg(
+				ctx, scan.Spans, sharedBufferWriter{buf}, scan.Resolved, false /* needsInitialScan */, sm)
+		} else {
+			backfillCfg, err = ca.makeKVFeedCfg(
+				ctx, scan.Spans, sharedBufferWriter{buf}, scan.Timestamp, true /* needsInitialScan */, sm)
+		}
+		if err != nil {
+			return nil, err
+		}
+		backfillCfg.BackfillCheckpoint = scan.Checkpoint
+		feeds = append(feeds, backfillCfg)
+	}
 
 	// Give errCh enough buffer both possible errors from supporting goroutines,
 	// but only the first one is ever used.
@@ -314,9 +386,16 @@ func (ca *changeAggregator) startKVFeed(
 	ca.kvFeedDoneCh = make(chan struct{})
 	if err := ca.flowCtx.Stopper().RunAsyncTask(ctx, "changefeed-poller", func(ctx context.Context) {
 		defer close(ca.kvFeedDoneCh)
+		g := ctxgroup.WithContext(ctx)
+		for i := range feeds {
+			feedCfg := feeds[i]
+			g.GoCtx(func(ctx context.Context) error {
+				return kvfeed.Run(ctx, feedCfg)
+			})
+		}
 		// Trying to call MoveToDraining here is racy (`MoveToDraining called in
 		// state stateTrailingMeta`), so return the error via a channel.
-		ca.errCh <- kvfeed.Run(ctx, kvfeedCfg)
+		ca.errCh <- g.Wait()
 		ca.cancel()
 	}); err != nil {
 		// If err != nil then the RunAsyncTask closure never ran, which means we
@@ -331,6 +410,21 @@ func (ca *changeAggregator) startKVFeed(
 	return buf, nil
 }
 
+// sharedBufferWriter is a kvevent.Writer for a kvfeed which shares its buffer
+// with another kvfeed. The buffer is only closed if the feed failed; a normal
+// shutdown is left to the feed owning the buffer.
+type sharedBufferWriter struct {
+	kvevent.Writer
+}
+
+// CloseWithReason implements kvevent.Writer.
+func (w sharedBufferWriter) CloseWithReason(ctx context.Context, reason error) error {
+	if reason == nil || errors.Is(reason, kvevent.ErrNormalRestartReason) {
+		return nil
+	}
+	return w.Writer.CloseWithReason(ctx, reason)
+}
+
 func (ca *changeAggregator) makeKVFeedCfg(
 	ctx context.Context,
 	spans []roachpb.Span,
@@ -338,7 +432,7 @@ func (ca *changeAggregator) makeKVFeedCfg(
 	initialHighWater hlc.Timestamp,
 	needsInitialScan bool,
 	sm *sliMetrics,
-) kvfeed.Config {
+) (kvfeed.Config, error) {
 	schemaChangeEvents := changefeedbase.SchemaChangeEventClass(
 		ca.spec.Feed.Opts[changefeedbase.OptSchemaChangeEvents])
 	schemaChangePolicy := changefeedbase.SchemaChangePolicy(
@@ -346,12 +440,23 @@ func (ca *changeAggregator) makeKVFeedCfg(
 	_, withDiff := ca.spec.Feed.Opts[changefeedbase.OptDiff]
 	cfg := ca.flowCtx.Cfg
 
+	// Changefeeds on a database watch the descriptors of the whole database so
+	// that they notice tables being created or dropped.
+	var tableFilter *changefeedbase.TableFilter
+	if ca.spec.Feed.DatabaseID != descpb.InvalidID {
+		f, err := changefeedbase.MakeTableFilter(ca.spec.Feed.DatabaseID, ca.spec.Feed.Opts)
+		if err != nil {
+			return kvfeed.Config{}, err
+		}
+		tableFilter = &f
+	}
+
 	var sf schemafeed.SchemaFeed
 	if schemaChangePolicy == changefeedbase.OptSchemaChangePolicyIgnore {
 		sf = schemafeed.DoNothingSchemaFeed
 	} else {
 		sf = schemafeed.New(ctx, cfg, schemaChangeEvents, AllTargets(ca.spec.Feed),
-			initialHighWater, &ca.metrics.SchemaFeedMetrics)
+			initialHighWater, &ca.metrics.SchemaFeedMetrics, tableFilter)
 	}
 
 	return kvfeed.Config{
@@ -374,7 +479,7 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		SchemaChangePolicy: schemaChangePolicy,
 		SchemaFeed:         sf,
 		Knobs:              ca.knobs.FeedKnobs,
-	}
+	}, nil
 }
 
 // getKVFeedInitialParameters determines the starting timestamp for the kv and
@@ -410,17 +515,44 @@ func getKVFeedInitialParameters(
 // different SpanFrontier elsewhere for the entire changefeed. This object is
 // used to filter out some previously emitted rows, and by the cloudStorageSink
 // to name its output files in lexicographically monotonic fashion.
+//
+// The targets which still need their initial scan (see
+// ChangeAggregatorSpec.InitialScans) are returned separately; their spans start
+// out in the frontier at the timestamp they were resolved at, or, for the spans
+// which were not checkpointed yet, with an empty timestamp.
 func (ca *changeAggregator) setupSpansAndFrontier(
 	initialHighWater hlc.Timestamp,
-) (spans []roachpb.Span, err error) {
-	spans = make([]roachpb.Span, 0, len(ca.spec.Watches))
+) (
+	spans []roachpb.Span,
+	initialScans []execinfrapb.ChangeAggregatorSpec_InitialScan,
+	err error,
+) {
+	var sg roachpb.SpanGroup
+	allSpans := make([]roachpb.Span, 0, len(ca.spec.Watches))
 	for _, watch := range ca.spec.Watches {
-		spans = append(spans, watch.Span)
+		allSpans = append(allSpans, watch.Span)
+		sg.Add(watch.Span)
+	}
+	var backfillSpans []roachpb.Span
+	if !initialHighWater.IsEmpty() {
+		initialScans = ca.spec.InitialScans
+		for _, scan := range initialScans {
+			backfillSpans = append(backfillSpans, scan.Spans...)
+		}
 	}
+	sg.Sub(backfillSpans...)
+	spans = sg.Slice()
 
-	ca.frontier, err = makeSchemaChangeFrontier(initialHighWater, spans...)
+	ca.frontier, err = makeSchemaChangeFrontier(hlc.Timestamp{}, allSpans...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	ca.frontier.initialHighWater = initialHighWater
+	ca.frontier.latestTs = initialHighWater
+	for _, sp := range spans {
+		if _, err := ca.frontier.Forward(sp, initialHighWater); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// Checkpointed spans are spans that were above the highwater mark, and we
@@ -434,12 +566,33 @@ func (ca *changeAggregator) setupSpansAndFrontier(
 	} else {
 		checkpointedSpanTs = initialHighWater.Next()
 	}
-	for _, checkpointedSpan := range ca.spec.Checkpoint.Spans {
+	var checkpointed roachpb.SpanGroup
+	checkpointed.Add(ca.spec.Checkpoint.Spans...)
+	checkpointed.Sub(backfillSpans...)
+	for _, checkpointedSpan := range checkpointed.Slice() {
 		if _, err := ca.frontier.Forward(checkpointedSpan, checkpointedSpanTs); err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+	}
+
+	// The spans of added targets whose scan completed before the changefeed
+	// restarted are not scanned again.
+	for _, scan := range initialScans {
+		if !scan.Resolved.IsEmpty() {
+			for _, sp := range scan.Spans {
+				if _, err := ca.frontier.Forward(sp, scan.Resolved); err != nil {
+					return nil, nil, err
+				}
+			}
+			continue
+		}
+		for _, sp := range scan.Checkpoint {
+			if _, err := ca.frontier.Forward(sp, scan.Timestamp); err != nil {
+				return nil, nil, err
+			}
 		}
 	}
-	return spans, nil
+	return spans, initialScans, nil
 }
 
 // close has two purposes: to synchronize on the completion of the helper
@@ -461,6 +614,11 @@ func (ca *changeAggregator) close() {
 			log.Warningf(ca.Ctx, `error closing sink. goroutines may have leaked: %v`, err)
 		}
 	}
+	if ca.dlq != nil {
+		if err := ca.dlq.Close(); err != nil {
+			log.Warningf(ca.Ctx, `error closing dead letter queue: %v`, err)
+		}
+	}
 
 	ca.memAcc.Close(ca.Ctx)
 	if ca.kvFeedMemMon != nil {
@@ -518,6 +676,7 @@ func (ca *changeAggregator) tick() error {
 
 	queuedNanos := timeutil.Since(event.BufferAddTimestamp()).Nanoseconds()
 	ca.metrics.QueueTimeNanos.Inc(queuedNanos)
+	ca.sliMetrics.recordBufferedEvent(&event)
 
 	switch event.Type() {
 	case kvevent.TypeKV:
@@ -535,7 +694,10 @@ func (ca *changeAggregator) tick() error {
 			return ca.noteResolvedSpan(resolved)
 		}
 	case kvevent.TypeFlush:
-		return ca.sink.Flush(ca.Ctx)
+		if err := ca.sink.Flush(ca.Ctx); err != nil {
+			return err
+		}
+		return ca.flushDeadLetterQueue()
 	}
 
 	return nil
@@ -552,7 +714,11 @@ func (ca *changeAggregator) noteResolvedSpan(resolved *jobspb.ResolvedSpan) erro
 
 	forceFlush := resolved.BoundaryType != jobspb.ResolvedSpan_NONE
 
-	checkpointFrontier := advanced &&
+	// While the initial scan of added targets is running, the local frontier
+	// stays empty, but the progress of the other spans must still be forwarded.
+	scanningAddedTargets := len(ca.initialScans) > 0 && ca.frontier.Frontier().IsEmpty()
+
+	checkpointFrontier := (advanced || scanningAddedTargets) &&
 		(forceFlush || timeutil.Since(ca.lastFlush) > ca.flushFrequency)
 
 	// If backfilling we must also consider the Backfill Checkpointing frequency
@@ -579,6 +745,17 @@ func (ca *changeAggregator) flushFrontier() error {
 	if err := ca.sink.Flush(ca.Ctx); err != nil {
 		return err
 	}
+	// Likewise, the rows sent to the dead letter queue must be persisted before
+	// the resolved timestamps covering them are forwarded.
+	if err := ca.flushDeadLetterQueue(); err != nil {
+		return err
+	}
+	// Transactional sinks make the flushed messages visible only now that the
+	// resolved spans covering them are about to be checkpointed, rather than on
+	// every flush, which minimizes the messages a restart emits again.
+	if err := commitSinkTxn(ca.Ctx, ca.sink); err != nil {
+		return err
+	}
 
 	// Iterate frontier spans and build a list of spans to emit.
 	var batch jobspb.ResolvedSpans
@@ -599,6 +776,18 @@ func (ca *changeAggregator) flushFrontier() error {
 	return ca.emitResolved(batch)
 }
 
+func (ca *changeAggregator) flushDeadLetterQueue() error {
+	if ca.dlq == nil {
+		return nil
+	}
+	n, err := ca.dlq.Flush(ca.Ctx)
+	if err != nil {
+		return err
+	}
+	ca.recentDLQCount += uint64(n)
+	return nil
+}
+
 func (ca *changeAggregator) emitResolved(batch jobspb.ResolvedSpans) error {
 	// TODO(smiskin): Remove post-22.2
 	if !ca.flowCtx.Cfg.Settings.Version.IsActive(ca.Ctx, clusterversion.ChangefeedIdleness) {
@@ -626,7 +815,8 @@ func (ca *changeAggregator) emitResolved(batch jobspb.ResolvedSpans) error {
 	progressUpdate := jobspb.ResolvedSpans{
 		ResolvedSpans: batch.ResolvedSpans,
 		Stats: jobspb.ResolvedSpans_Stats{
-			RecentKvCount: ca.recentKVCount,
+			RecentKvCount:          ca.recentKVCount,
+			DeadLetterQueueEntries: ca.recentDLQCount,
 		},
 	}
 	updateBytes, err := protoutil.Marshal(&progressUpdate)
@@ -641,6 +831,7 @@ func (ca *changeAggregator) emitResolved(batch jobspb.ResolvedSpans) error {
 	})
 
 	ca.recentKVCount = 0
+	ca.recentDLQCount = 0
 	return nil
 }
 
@@ -660,11 +851,13 @@ type kvEventToRowConsumer struct {
 	encoder   Encoder
 	scratch   bufalloc.ByteAllocator
 	sink      Sink
+	dlq       deadLetterQueue
 	cursor    hlc.Timestamp
 	knobs     TestingKnobs
 	rfCache   *rowFetcherCache
 	details   jobspb.ChangefeedDetails
 	kvFetcher row.SpanKVFetcher
+	metrics   *sliMetrics
 }
 
 var _ kvEventConsumer = &kvEventToRowConsumer{}
@@ -675,9 +868,11 @@ func newKVEventToRowConsumer(
 	frontier *span.Frontier,
 	cursor hlc.Timestamp,
 	sink Sink,
+	dlq deadLetterQueue,
 	encoder Encoder,
 	details jobspb.ChangefeedDetails,
 	knobs TestingKnobs,
+	metrics *sliMetrics,
 ) kvEventConsumer {
 	rfCache := newRowFetcherCache(
 		ctx,
@@ -691,10 +886,12 @@ func newKVEventToRowConsumer(
 		frontier: frontier,
 		encoder:  encoder,
 		sink:     sink,
+		dlq:      dlq,
 		cursor:   cursor,
 		rfCache:  rfCache,
 		details:  details,
 		knobs:    knobs,
+		metrics:  metrics,
 	}
 }
 
@@ -727,26 +924,34 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 		return nil
 	}
 	var keyCopy, valueCopy []byte
+	encodeStart := timeutil.Now()
 	encodedKey, err := c.encoder.EncodeKey(ctx, r)
 	if err != nil {
-		return err
+		return c.maybeSendToDeadLetterQueue(ctx, ev, r, nil /* key */, nil /* value */, err)
 	}
 	c.scratch, keyCopy = c.scratch.Copy(encodedKey, 0 /* extraCap */)
 	encodedValue, err := c.encoder.EncodeValue(ctx, r)
 	if err != nil {
-		return err
+		return c.maybeSendToDeadLetterQueue(ctx, ev, r, keyCopy, nil /* value */, err)
 	}
 	c.scratch, valueCopy = c.scratch.Copy(encodedValue, 0 /* extraCap */)
+	c.metrics.recordEncode(encodeStart)
 
 	if c.knobs.BeforeEmitRow != nil {
 		if err := c.knobs.BeforeEmitRow(ctx); err != nil {
 			return err
 		}
 	}
-	if err := c.sink.EmitRow(
+	emitStart := timeutil.Now()
+	err = c.sink.EmitRow(
 		ctx, tableDescriptorTopic{r.tableDesc},
 		keyCopy, valueCopy, r.updated, r.mvccTimestamp, ev.DetachAlloc(),
-	); err != nil {
+	)
+	c.metrics.recordEmitRow(emitStart)
+	if err != nil {
+		if c.dlq != nil && errors.Is(err, errRowRejected) {
+			return c.dlq.Add(ctx, r.tableDesc.GetName(), keyCopy, valueCopy, r.updated, err)
+		}
 		return err
 	}
 	if log.V(3) {
@@ -755,6 +960,27 @@ func (c *kvEventToRowConsumer) ConsumeEvent(ctx context.Context, ev kvevent.Even
 	return nil
 }
 
+// maybeSendToDeadLetterQueue sends a row which could not be encoded to the dead
+// letter queue if the changefeed has one and the error only concerns this row;
+// otherwise the error is returned. The key and value default to the raw KV.
+func (c *kvEventToRowConsumer) maybeSendToDeadLetterQueue(
+	ctx context.Context, ev kvevent.Event, r encodeRow, key, value []byte, cause error,
+) error {
+	if c.dlq == nil || !isDeadLetterError(cause) {
+		return cause
+	}
+	if key == nil {
+		key = ev.KV().Key
+	}
+	if value == nil {
+		value = ev.KV().Value.RawBytes
+	}
+	a := ev.DetachAlloc()
+	a.Release(ctx)
+	log.VEventf(ctx, 2, "sending row of %s to the dead letter queue: %v", r.tableDesc.GetName(), cause)
+	return c.dlq.Add(ctx, r.tableDesc.GetName(), key, value, r.updated, cause)
+}
+
 func (c *kvEventToRowConsumer) eventToRow(
 	ctx context.Context, event kvevent.Event,
 ) (encodeRow, error) {
@@ -930,6 +1156,11 @@ type changeFrontier struct {
 	// frontier contains the current resolved timestamp high-water for the tracked
 	// span set.
 	frontier *schemaChangeFrontier
+	// addedTargets track the targets added by ALTER CHANGEFEED whose initial
+	// scan has not yet caught up with the rest of the changefeed. Their spans are
+	// excluded from frontier until then, so that the scan does not hold back the
+	// resolved timestamp of the other targets.
+	addedTargets []addedTargetFrontier
 	// encoder is the Encoder to use for resolved timestamp serialization.
 	encoder Encoder
 	// sink is the Sink to write resolved timestamps to. Rows are never written
@@ -943,6 +1174,9 @@ type changeFrontier struct {
 
 	// slowLogEveryN rate-limits the logging of slow spans
 	slowLogEveryN log.EveryN
+	// frontierSpansEveryN rate-limits the updates of the slowest spans exposed
+	// in crdb_internal.changefeed_frontier_spans.
+	frontierSpansEveryN log.EveryN
 
 	// lastProtectedTimestampUpdate is the last time the protected timestamp
 	// record was updated to the frontier's highwater mark
@@ -968,11 +1202,15 @@ type changeFrontier struct {
 	// metricsID is used as the unique id of this changefeed in the
 	// metrics.MaxBehindNanos map.
 	metricsID int
+	// pendingDLQEntries is the number of rows the aggregators sent to the dead
+	// letter queue which are not yet accounted for in the job progress.
+	pendingDLQEntries int64
 }
 
 const (
-	runStatusUpdateFrequency time.Duration = time.Minute
-	slowSpanMaxFrequency                   = 10 * time.Second
+	runStatusUpdateFrequency     time.Duration = time.Minute
+	slowSpanMaxFrequency                       = 10 * time.Second
+	frontierSpansUpdateFrequency               = time.Second
 )
 
 // jobState encapsulates changefeed job state.
@@ -1085,6 +1323,8 @@ func newChangeFrontierProcessor(
 		input:         input,
 		frontier:      sf,
 		slowLogEveryN: log.Every(slowSpanMaxFrequency),
+
+		frontierSpansEveryN: log.Every(frontierSpansUpdateFrequency),
 	}
 	if err := cf.Init(
 		cf,
@@ -1153,7 +1393,7 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 	}
 	cf.sliMetrics = sli
 	cf.sink, err = getSink(ctx, cf.flowCtx.Cfg, cf.spec.Feed, nilOracle,
-		cf.spec.User(), cf.spec.JobID, sli)
+		cf.spec.User(), cf.spec.JobID, frontierSinkOwner, sli)
 
 	if err != nil {
 		err = changefeedbase.MarkRetryableError(err)
@@ -1190,6 +1430,10 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 		p := job.Progress()
 		if ts := p.GetHighWater(); ts != nil {
 			cf.highWaterAtStart.Forward(*ts)
+			if err := cf.setupAddedTargets(p.GetChangefeed()); err != nil {
+				cf.MoveToDraining(err)
+				return
+			}
 			cf.frontier.initialHighWater = *ts
 			for _, span := range cf.spec.TrackedSpans {
 				if _, err := cf.frontier.Forward(span, *ts); err != nil {
@@ -1225,6 +1469,124 @@ func (cf *changeFrontier) Start(ctx context.Context) {
 	}()
 }
 
+// addedTargetFrontier tracks the spans of a target added by ALTER CHANGEFEED
+// while its initial scan is running.
+type addedTargetFrontier struct {
+	tableID  descpb.ID
+	frontier *span.Frontier
+}
+
+// setupAddedTargets splits the spans of the targets whose initial scan is still
+// pending off the changefeed frontier. The schema change boundaries of these
+// spans are ignored until they are merged back into the frontier. The frontiers
+// of the targets are restored from their persisted progress, which the change
+// aggregators resume from as well.
+func (cf *changeFrontier) setupAddedTargets(progress *jobspb.ChangefeedProgress) error {
+	if progress == nil {
+		return nil
+	}
+	var added roachpb.SpanGroup
+	for _, tp := range progress.Targets {
+		if tp.InitialScanTimestamp.IsEmpty() {
+			continue
+		}
+		tablePrefix := cf.flowCtx.Codec().TablePrefix(uint32(tp.TableID))
+		tableSpan := roachpb.Span{Key: tablePrefix, EndKey: tablePrefix.PrefixEnd()}
+		var spans []roachpb.Span
+		for _, sp := range cf.spec.TrackedSpans {
+			if i := sp.Intersect(tableSpan); i.Valid() {
+				spans = append(spans, i)
+			}
+		}
+		if len(spans) == 0 {
+			continue
+		}
+		sf, err := span.MakeFrontier(spans...)
+		if err != nil {
+			return err
+		}
+		if !tp.Resolved.IsEmpty() {
+			for _, sp := range spans {
+				if _, err := sf.Forward(sp, tp.Resolved); err != nil {
+					return err
+				}
+			}
+		} else {
+			for _, sp := range tp.Checkpoint {
+				if _, err := sf.Forward(sp, tp.InitialScanTimestamp.Next()); err != nil {
+					return err
+				}
+			}
+		}
+		added.Add(spans...)
+		cf.addedTargets = append(cf.addedTargets, addedTargetFrontier{tableID: tp.TableID, frontier: sf})
+	}
+	if len(cf.addedTargets) == 0 {
+		return nil
+	}
+
+	var remaining roachpb.SpanGroup
+	remaining.Add(cf.spec.TrackedSpans...)
+	remaining.Sub(added.Slice()...)
+	sf, err := makeSchemaChangeFrontier(hlc.Timestamp{}, remaining.Slice()...)
+	if err != nil {
+		return err
+	}
+	cf.frontier = sf
+	return nil
+}
+
+// forwardAddedTargets forwards the frontiers of the targets whose initial scan
+// is pending. A target is merged back into the changefeed frontier once its
+// frontier caught up with it, in which case true is returned.
+func (cf *changeFrontier) forwardAddedTargets(resolved jobspb.ResolvedSpan) (bool, error) {
+	merged := false
+	for i := 0; i < len(cf.addedTargets); {
+		t := cf.addedTargets[i]
+		if _, err := t.frontier.Forward(resolved.Span, resolved.Timestamp); err != nil {
+			return false, err
+		}
+		if ts := t.frontier.Frontier(); ts.IsEmpty() || ts.Less(cf.frontier.Frontier()) {
+			i++
+			continue
+		}
+		if err := cf.frontier.addSpans(t.frontier); err != nil {
+			return false, err
+		}
+		log.Infof(cf.Ctx, "initial scan of table %d completed", t.tableID)
+		cf.addedTargets = append(cf.addedTargets[:i], cf.addedTargets[i+1:]...)
+		merged = true
+	}
+	return merged, nil
+}
+
+// scannedSpans returns the spans of the target whose initial scan completed, or
+// nil once all of them completed, in which case the target is resolved.
+func (t addedTargetFrontier) scannedSpans() []roachpb.Span {
+	if !t.frontier.Frontier().IsEmpty() {
+		return nil
+	}
+	var scanned roachpb.SpanGroup
+	t.frontier.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+		if !ts.IsEmpty() {
+			scanned.Add(sp)
+		}
+		return span.ContinueMatch
+	})
+	return scanned.Slice()
+}
+
+// addedTarget returns the frontier of the added target with the given ID if its
+// initial scan is still pending.
+func (cf *changeFrontier) addedTarget(id descpb.ID) (addedTargetFrontier, bool) {
+	for _, t := range cf.addedTargets {
+		if t.tableID == id {
+			return t, true
+		}
+	}
+	return addedTargetFrontier{}, false
+}
+
 func (cf *changeFrontier) close() {
 	if cf.InternalClose() {
 		if cf.metrics != nil {
@@ -1250,6 +1612,7 @@ func (cf *changeFrontier) closeMetrics() {
 		cf.sliMetrics.RunningCount.Dec(1)
 	}
 	delete(cf.metrics.mu.resolved, cf.metricsID)
+	delete(cf.metrics.mu.frontierSpans, cf.metricsID)
 	cf.metricsID = -1
 	cf.metrics.mu.Unlock()
 }
@@ -1326,6 +1689,7 @@ func (cf *changeFrontier) noteAggregatorProgress(d rowenc.EncDatum) error {
 		}
 
 		cf.maybeMarkJobIdle(resolvedSpans.Stats.RecentKvCount)
+		cf.pendingDLQEntries += int64(resolvedSpans.Stats.DeadLetterQueueEntries)
 	} else { // TODO(smiskin): Remove post-22.2
 		// Progress used to be sent as individual ResolvedSpans
 		var resolved jobspb.ResolvedSpan
@@ -1365,8 +1729,17 @@ func (cf *changeFrontier) forwardFrontier(resolved jobspb.ResolvedSpan) error {
 	if err != nil {
 		return err
 	}
+	if len(cf.addedTargets) > 0 {
+		// Completing the initial scan of an added target is always checkpointed.
+		merged, err := cf.forwardAddedTargets(resolved)
+		if err != nil {
+			return err
+		}
+		frontierChanged = frontierChanged || merged
+	}
 
 	cf.maybeLogBehindSpan(frontierChanged)
+	cf.maybeUpdateFrontierSpans()
 
 	// If frontier changed, we emit resolved timestamp.
 	emitResolved := frontierChanged
@@ -1464,6 +1837,13 @@ func (cf *changeFrontier) checkpointJobProgress(
 	}
 	cf.metrics.FrontierUpdates.Inc(1)
 
+	dlqEntries := cf.pendingDLQEntries
+	defer func() {
+		if err == nil {
+			cf.pendingDLQEntries -= dlqEntries
+		}
+	}()
+
 	return cf.js.job.Update(cf.Ctx, nil, func(
 		txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
 	) error {
@@ -1479,6 +1859,18 @@ func (cf *changeFrontier) checkpointJobProgress(
 
 		changefeedProgress := progress.Details.(*jobspb.Progress_Changefeed).Changefeed
 		changefeedProgress.Checkpoint = &checkpoint
+		changefeedProgress.DeadLetterQueueEntries += dlqEntries
+		for i := range changefeedProgress.Targets {
+			tp := &changefeedProgress.Targets[i]
+			if t, ok := cf.addedTarget(tp.TableID); ok {
+				tp.Resolved = t.frontier.Frontier()
+				tp.Checkpoint = t.scannedSpans()
+			} else {
+				tp.InitialScanTimestamp = hlc.Timestamp{}
+				tp.Resolved = hlc.Timestamp{}
+				tp.Checkpoint = nil
+			}
+		}
 
 		if shouldProtectTimestamps(cf.flowCtx.Codec()) {
 			if err := cf.manageProtectedTimestamps(cf.Ctx, txn, changefeedProgress); err != nil {
@@ -1488,6 +1880,9 @@ func (cf *changeFrontier) checkpointJobProgress(
 
 		if updateRunStatus {
 			md.Progress.RunningStatus = fmt.Sprintf("running: resolved=%s", frontier)
+			if len(cf.addedTargets) > 0 {
+				md.Progress.RunningStatus += "; initial scan: " + cf.addedTargetsStatus()
+			}
 		}
 
 		ju.UpdateProgress(progress)
@@ -1504,9 +1899,36 @@ func (cf *changeFrontier) checkpointJobProgress(
 	})
 }
 
+// addedTargetsStatus describes the progress of the targets whose initial scan
+// is pending.
+func (cf *changeFrontier) addedTargetsStatus() string {
+	names := make(map[descpb.ID]string)
+	for _, t := range AllTargets(cf.spec.Feed) {
+		names[t.TableID] = t.StatementTimeName
+	}
+	var buf strings.Builder
+	for i, t := range cf.addedTargets {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		name, ok := names[t.tableID]
+		if !ok {
+			name = fmt.Sprintf("[%d]", t.tableID)
+		}
+		if ts := t.frontier.Frontier(); ts.IsEmpty() {
+			fmt.Fprintf(&buf, "%s=scanning", name)
+		} else {
+			fmt.Fprintf(&buf, "%s=%s", name, ts)
+		}
+	}
+	return buf.String()
+}
+
 // manageProtectedTimestamps periodically advances the protected timestamp for
-// the changefeed's targets to the current highwater mark.  The record is
-// cleared during changefeedResumer.OnFailOrCancel
+// the changefeed's targets to the current highwater mark, or to the earliest
+// timestamp an added target which has not caught up with the changefeed is
+// resumed from if that is lower.  The record is cleared during
+// changefeedResumer.OnFailOrCancel
 func (cf *changeFrontier) manageProtectedTimestamps(
 	ctx context.Context, txn *kv.Txn, progress *jobspb.ChangefeedProgress,
 ) error {
@@ -1523,10 +1945,22 @@ func (cf *changeFrontier) manageProtectedTimestamps(
 	if highWater.Less(cf.highWaterAtStart) {
 		highWater = cf.highWaterAtStart
 	}
+	// The scans of added targets read below the high-water, and must not have
+	// their data garbage collected from under them, including across restarts.
+	for _, tp := range progress.Targets {
+		if tp.InitialScanTimestamp.IsEmpty() {
+			continue
+		}
+		if !tp.Resolved.IsEmpty() {
+			highWater.Backward(tp.Resolved)
+		} else {
+			highWater.Backward(tp.InitialScanTimestamp)
+		}
+	}
 
 	recordID := progress.ProtectedTimestampRecord
 	if recordID == uuid.Nil {
-		ptr := createProtectedTimestampRecord(ctx, cf.flowCtx.Codec(), cf.spec.JobID, AllTargets(cf.spec.Feed), highWater, progress)
+		ptr := createProtectedTimestampRecord(ctx, cf.flowCtx.Codec(), cf.spec.JobID, cf.spec.Feed, highWater, progress)
 		if err := pts.Protect(ctx, txn, ptr); err != nil {
 			return err
 		}
@@ -1552,6 +1986,11 @@ func (cf *changeFrontier) maybeEmitResolved(newResolved hlc.Timestamp) error {
 	if err := emitResolvedTimestamp(cf.Ctx, cf.encoder, cf.sink, newResolved); err != nil {
 		return err
 	}
+	// Resolved timestamps are only emitted once they have been checkpointed,
+	// so transactional sinks can commit them right away.
+	if err := commitSinkTxn(cf.Ctx, cf.sink); err != nil {
+		return err
+	}
 	cf.lastEmitResolved = newResolved.GoTime()
 	return nil
 }
@@ -1598,6 +2037,58 @@ func (cf *changeFrontier) maybeLogBehindSpan(frontierChanged bool) {
 	}
 }
 
+// maybeUpdateFrontierSpans periodically publishes the slowest spans of the
+// frontier to crdb_internal.changefeed_frontier_spans.
+func (cf *changeFrontier) maybeUpdateFrontierSpans() {
+	if !cf.frontierSpansEveryN.ShouldProcess(timeutil.Now()) {
+		return
+	}
+	n := int(changefeedbase.FrontierSpansTracked.Get(&cf.flowCtx.Cfg.Settings.SV))
+	var spans []jobs.ChangefeedFrontierSpan
+	if n > 0 {
+		spans = slowestFrontierSpans(cf.frontier.SpanFrontier(), cf.spec.JobID, n)
+	}
+
+	cf.metrics.mu.Lock()
+	defer cf.metrics.mu.Unlock()
+	if cf.metricsID == -1 {
+		return
+	}
+	if len(spans) == 0 {
+		delete(cf.metrics.mu.frontierSpans, cf.metricsID)
+		return
+	}
+	cf.metrics.mu.frontierSpans[cf.metricsID] = spans
+}
+
+// slowestFrontierSpans returns the (at most) n spans of the frontier with the
+// lowest resolved timestamps, slowest first.
+func slowestFrontierSpans(
+	f *span.Frontier, jobID jobspb.JobID, n int,
+) []jobs.ChangefeedFrontierSpan {
+	spans := make([]jobs.ChangefeedFrontierSpan, 0, n)
+	f.Entries(func(sp roachpb.Span, ts hlc.Timestamp) span.OpResult {
+		if len(spans) == n && !ts.Less(spans[n-1].Resolved) {
+			return span.ContinueMatch
+		}
+		if len(spans) < n {
+			spans = append(spans, jobs.ChangefeedFrontierSpan{})
+		}
+		// Insert the span in order, dropping the fastest one if full.
+		i := len(spans) - 1
+		for ; i > 0 && ts.Less(spans[i-1].Resolved); i-- {
+			spans[i] = spans[i-1]
+		}
+		spans[i] = jobs.ChangefeedFrontierSpan{
+			JobID:    jobID,
+			Span:     roachpb.Span{Key: sp.Key.Clone(), EndKey: sp.EndKey.Clone()},
+			Resolved: ts,
+		}
+		return span.ContinueMatch
+	})
+	return spans
+}
+
 func (cf *changeFrontier) slownessThreshold() time.Duration {
 	clusterThreshold := changefeedbase.SlowSpanLogThreshold.Get(&cf.flowCtx.Cfg.Settings.SV)
 	if clusterThreshold > 0 {
@@ -1694,6 +2185,38 @@ func makeSchemaChangeFrontier(
 	return &schemaChangeFrontier{spanFrontier: &spanFrontier{sf}, initialHighWater: initialHighWater, latestTs: initialHighWater}, nil
 }
 
+// addSpans adds the spans tracked by other, along with their timestamps, to the
+// frontier.
+func (f *schemaChangeFrontier) addSpans(other *span.Frontier) error {
+	type entry struct {
+		sp roachpb.Span
+		ts hlc.Timestamp
+	}
+	var entries []entry
+	collect := func(s roachpb.Span, ts hlc.Timestamp) span.OpResult {
+		entries = append(entries, entry{sp: s, ts: ts})
+		return span.ContinueMatch
+	}
+	f.Entries(collect)
+	other.Entries(collect)
+
+	spans := make([]roachpb.Span, len(entries))
+	for i, e := range entries {
+		spans[i] = e.sp
+	}
+	sf, err := span.MakeFrontier(spans...)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if _, err := sf.Forward(e.sp, e.ts); err != nil {
+			return err
+		}
+	}
+	f.spanFrontier = &spanFrontier{sf}
+	return nil
+}
+
 // ForwardResolvedSpan advances the timestamp for a resolved span, taking care
 // of updating schema change boundary information.
 func (f *schemaChangeFrontier) ForwardResolvedSpan(r jobspb.ResolvedSpan) (bool, error) {
@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/flowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -47,6 +48,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
@@ -187,7 +189,7 @@ func changefeedPlanHook(
 			var protectedTimestampID uuid.UUID
 			codec := p.ExecCfg().Codec
 			if shouldProtectTimestamps(codec) {
-				ptr = createProtectedTimestampRecord(ctx, codec, jobID, AllTargets(details), details.StatementTime, progress.GetChangefeed())
+				ptr = createProtectedTimestampRecord(ctx, codec, jobID, details, details.StatementTime, progress.GetChangefeed())
 				protectedTimestampID = ptr.ID.GetUUID()
 			}
 
@@ -288,8 +290,18 @@ func createChangefeedJobRecord(
 		statementTime = initialHighWater
 	}
 
-	// This grabs table descriptors once to get their ids.
-	targetDescs, err := getTableDescriptors(ctx, p, &changefeedStmt.Targets, statementTime, initialHighWater)
+	var databaseID descpb.ID
+	var targetDescs []catalog.Descriptor
+	if len(changefeedStmt.Targets.Databases) > 0 {
+		if unspecifiedSink {
+			return nil, errors.Errorf(`CHANGEFEED FOR DATABASE requires a sink`)
+		}
+		databaseID, targetDescs, err = getDatabaseTableDescriptors(
+			ctx, p, &changefeedStmt.Targets, opts, statementTime, initialHighWater)
+	} else {
+		// This grabs table descriptors once to get their ids.
+		targetDescs, err = getTableDescriptors(ctx, p, &changefeedStmt.Targets, statementTime, initialHighWater)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -305,6 +317,7 @@ func createChangefeedJobRecord(
 		SinkURI:              sinkURI,
 		StatementTime:        statementTime,
 		TargetSpecifications: targets,
+		DatabaseID:           databaseID,
 	}
 
 	// TODO(dan): In an attempt to present the most helpful error message to the
@@ -379,6 +392,9 @@ func createChangefeedJobRecord(
 		telemetry.Count(telemetryPath + `.sink.` + telemetrySink)
 		telemetry.Count(telemetryPath + `.format.` + details.Opts[changefeedbase.OptFormat])
 		telemetry.CountBucketed(telemetryPath+`.num_tables`, int64(len(tables)))
+		if databaseID != descpb.InvalidID {
+			telemetry.Count(telemetryPath + `.database`)
+		}
 	}
 
 	if scope, ok := opts[changefeedbase.OptMetricsScope]; ok {
@@ -480,9 +496,8 @@ func getTableDescriptors(
 	statementTime hlc.Timestamp,
 	initialHighWater hlc.Timestamp,
 ) ([]catalog.Descriptor, error) {
-	// For now, disallow targeting a database or wildcard table selection.
-	// Getting it right as tables enter and leave the set over time is
-	// tricky.
+	// Disallow wildcard table selection. Changefeeds which should follow the
+	// tables entering and leaving a set over time target a database instead.
 	if len(targets.Databases) > 0 {
 		return nil, errors.Errorf(`CHANGEFEED cannot target %s`,
 			tree.AsString(targets))
@@ -517,6 +532,62 @@ func getTableDescriptors(
 	return targetDescs, err
 }
 
+// getDatabaseTableDescriptors resolves the database targeted by a CHANGEFEED
+// FOR DATABASE statement. It returns the ID of the database along with its
+// descriptor and the descriptors of the tables selected by the include_tables
+// and exclude_tables options.
+func getDatabaseTableDescriptors(
+	ctx context.Context,
+	p sql.PlanHookState,
+	targets *tree.TargetList,
+	opts map[string]string,
+	statementTime hlc.Timestamp,
+	initialHighWater hlc.Timestamp,
+) (descpb.ID, []catalog.Descriptor, error) {
+	if len(targets.Databases) != 1 {
+		return descpb.InvalidID, nil, errors.Errorf(`CHANGEFEED cannot target %s`,
+			tree.AsString(targets))
+	}
+	allDescs, _, err := backupresolver.ResolveTargetsToDescriptors(
+		ctx, p, statementTime, targets)
+	if err != nil {
+		err = errors.Wrap(err, "failed to resolve targets in the CHANGEFEED stmt")
+		if !initialHighWater.IsEmpty() {
+			err = errors.WithHintf(err,
+				"does the database exist at the specified cursor time %s?", initialHighWater)
+		}
+		return descpb.InvalidID, nil, err
+	}
+
+	var dbDesc catalog.DatabaseDescriptor
+	for _, desc := range allDescs {
+		if db, ok := desc.(catalog.DatabaseDescriptor); ok && db.GetName() == string(targets.Databases[0]) {
+			dbDesc = db
+			break
+		}
+	}
+	if dbDesc == nil {
+		return descpb.InvalidID, nil, errors.Errorf(`database %q does not exist`, targets.Databases[0])
+	}
+
+	filter, err := changefeedbase.MakeTableFilter(dbDesc.GetID(), opts)
+	if err != nil {
+		return descpb.InvalidID, nil, err
+	}
+	targetDescs := []catalog.Descriptor{dbDesc}
+	for _, desc := range allDescs {
+		if table, ok := desc.(catalog.TableDescriptor); ok && filter.Watches(table) {
+			targetDescs = append(targetDescs, table)
+		}
+	}
+	if len(targetDescs) == 1 {
+		return descpb.InvalidID, nil, errors.WithHint(
+			errors.Errorf(`database %q does not contain any table to watch`, dbDesc.GetName()),
+			"check the include_tables and exclude_tables options")
+	}
+	return dbDesc.GetID(), targetDescs, nil
+}
+
 func getTargetsAndTables(
 	ctx context.Context,
 	p sql.PlanHookState,
@@ -567,8 +638,11 @@ func validateSink(
 		return err
 	}
 	var nilOracle timestampLowerBoundOracle
+	// The canary sink never emits anything; it gets its own transactional ID
+	// (if any) so that it doesn't fence off the producers of a running
+	// changefeed being altered.
 	canarySink, err := getSink(ctx, &p.ExecCfg().DistSQLSrv.ServerConfig, details,
-		nilOracle, p.User(), jobID, sli)
+		nilOracle, p.User(), jobID, canarySinkOwner, sli)
 	if err != nil {
 		return changefeedbase.MaybeStripRetryableErrorMarker(err)
 	}
@@ -608,6 +682,12 @@ func changefeedJobDescription(
 		if k == changefeedbase.OptWebhookAuthHeader {
 			v = redactWebhookAuthHeader(v)
 		}
+		if k == changefeedbase.OptDeadLetterQueue && isCloudStorageDeadLetterQueue(v) {
+			if v, err = cloud.SanitizeExternalStorageURI(v, nil /* extraParams */); err != nil {
+				return "", err
+			}
+			v = redactUser(v)
+		}
 		opt := tree.KVOption{Key: tree.Name(k)}
 		if len(v) > 0 {
 			opt.Value = tree.NewDString(v)
@@ -737,6 +817,38 @@ func validateDetails(details jobspb.ChangefeedDetails) (jobspb.ChangefeedDetails
 				changefeedbase.OptOnErrorFail)
 		}
 	}
+	if dest, ok := details.Opts[changefeedbase.OptDeadLetterQueue]; ok {
+		if err := validateDeadLetterQueue(dest); err != nil {
+			return jobspb.ChangefeedDetails{}, err
+		}
+	}
+	if details.DatabaseID == descpb.InvalidID {
+		for _, opt := range []string{
+			changefeedbase.OptIncludeTables, changefeedbase.OptExcludeTables,
+			changefeedbase.OptNewTablesInitialScan,
+		} {
+			if _, ok := details.Opts[opt]; ok {
+				return jobspb.ChangefeedDetails{}, errors.Errorf(
+					`%s can only be used with CHANGEFEED FOR DATABASE`, opt)
+			}
+		}
+	} else {
+		if _, err := changefeedbase.MakeTableFilter(details.DatabaseID, details.Opts); err != nil {
+			return jobspb.ChangefeedDetails{}, err
+		}
+		const opt = changefeedbase.OptNewTablesInitialScan
+		switch v := changefeedbase.NewTablesInitialScanType(details.Opts[opt]); v {
+		case ``, changefeedbase.OptNewTablesInitialScanYes:
+			details.Opts[opt] = string(changefeedbase.OptNewTablesInitialScanYes)
+		case changefeedbase.OptNewTablesInitialScanNo:
+			// No-op.
+		default:
+			return jobspb.ChangefeedDetails{}, errors.Errorf(
+				`unknown %s: %s, valid values are '%s' and '%s'`, opt, v,
+				changefeedbase.OptNewTablesInitialScanYes,
+				changefeedbase.OptNewTablesInitialScanNo)
+		}
+	}
 	{
 		const opt = changefeedbase.OptVirtualColumns
 		switch v := changefeedbase.VirtualColumnVisibility(details.Opts[opt]); v {
@@ -852,8 +964,13 @@ func (b *changefeedResumer) resumeWithRetries(
 		// a dummy channel.
 		startedCh := make(chan tree.Datums, 1)
 
-		if err = distChangefeedFlow(ctx, jobExec, jobID, details, progress, startedCh); err == nil {
-			return nil
+		// The tables of a changefeed on a database may have changed since the
+		// flow last ran; typically the flow restarts precisely because a table
+		// was created or dropped.
+		if err = b.updateDatabaseTargets(ctx, execCfg, &details, &progress); err == nil {
+			if err = distChangefeedFlow(ctx, jobExec, jobID, details, progress, startedCh); err == nil {
+				return nil
+			}
 		}
 
 		if knobs, ok := execCfg.DistSQLSrv.TestingKnobs.Changefeed.(*TestingKnobs); ok {
@@ -907,6 +1024,149 @@ func (b *changefeedResumer) resumeWithRetries(
 	return errors.Wrap(err, `ran out of retries`)
 }
 
+// updateDatabaseTargets updates the targets of a changefeed on a database to
+// the tables of the database it should watch as of the timestamp following the
+// high-water. Tables which start being watched are scanned at the high-water
+// unless new_tables_initial_scan='no'. Tables created in the database which
+// changefeeds don't support are skipped with a warning. The job record is
+// updated if the targets changed.
+func (b *changefeedResumer) updateDatabaseTargets(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	details *jobspb.ChangefeedDetails,
+	progress *jobspb.Progress,
+) error {
+	highWater := progress.GetHighWater()
+	if details.DatabaseID == descpb.InvalidID || highWater == nil || highWater.IsEmpty() {
+		// Until the initial scan completes, the targets are the tables which
+		// were found when the changefeed was created.
+		return nil
+	}
+	filter, err := changefeedbase.MakeTableFilter(details.DatabaseID, details.Opts)
+	if err != nil {
+		return err
+	}
+	_, qualified := details.Opts[changefeedbase.OptFullTableName]
+
+	var tables jobspb.ChangefeedTargets
+	var targets []jobspb.ChangefeedTargetSpecification
+	if err := sql.DescsTxn(ctx, execCfg, func(
+		ctx context.Context, txn *kv.Txn, descriptors *descs.Collection,
+	) error {
+		tables = make(jobspb.ChangefeedTargets)
+		targets = targets[:0]
+		if err := txn.SetFixedTimestamp(ctx, highWater.Next()); err != nil {
+			return err
+		}
+		tableDescs, err := descriptors.GetAllTableDescriptorsInDatabase(ctx, txn, details.DatabaseID)
+		if err != nil {
+			return err
+		}
+		for _, desc := range tableDescs {
+			if !filter.Watches(desc) {
+				continue
+			}
+			target := jobspb.ChangefeedTargetSpecification{
+				Type:    jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+				TableID: desc.GetID(),
+			}
+			// Keep the names of the tables which were already watched so that
+			// their topics don't change.
+			t, ok := details.Tables[desc.GetID()]
+			if err := changefeedbase.ValidateTable(
+				[]jobspb.ChangefeedTargetSpecification{target}, desc,
+			); err != nil {
+				if ok {
+					return err
+				}
+				// A table created in the database which changefeeds don't
+				// support (e.g. one with several column families) must not fail
+				// the changefeed on the whole database; it is not watched.
+				log.Warningf(ctx, "CHANGEFEED job %d on database %d: not watching table %s: %v",
+					b.job.ID(), details.DatabaseID, desc.GetName(), err)
+				continue
+			}
+			if !ok {
+				if t.StatementTimeName, err = getChangefeedTargetName(
+					ctx, desc, execCfg, txn, qualified,
+				); err != nil {
+					return err
+				}
+			}
+			tables[desc.GetID()] = t
+			targets = append(targets, target)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var added, dropped []descpb.ID
+	for id := range tables {
+		if _, ok := details.Tables[id]; !ok {
+			added = append(added, id)
+		}
+	}
+	for id := range details.Tables {
+		if _, ok := tables[id]; !ok {
+			dropped = append(dropped, id)
+		}
+	}
+	if len(added) == 0 && len(dropped) == 0 {
+		return nil
+	}
+	if len(tables) == 0 {
+		return errors.Errorf(`database %d no longer contains any table to watch`, details.DatabaseID)
+	}
+	log.Infof(ctx, "CHANGEFEED job %d on database %d: adding tables %v, removing tables %v",
+		b.job.ID(), details.DatabaseID, added, dropped)
+
+	newDetails := *details
+	newDetails.Tables = tables
+	newDetails.TargetSpecifications = targets
+	newProgress := protoutil.Clone(progress).(*jobspb.Progress)
+	if cf := newProgress.GetChangefeed(); cf != nil {
+		targetProgress := cf.Targets[:0]
+		for _, tp := range cf.Targets {
+			if _, ok := tables[tp.TableID]; ok {
+				targetProgress = append(targetProgress, tp)
+			}
+		}
+		if changefeedbase.NewTablesInitialScanType(details.Opts[changefeedbase.OptNewTablesInitialScan]) !=
+			changefeedbase.OptNewTablesInitialScanNo {
+			for _, id := range added {
+				targetProgress = append(targetProgress, jobspb.ChangefeedProgress_TargetProgress{
+					TableID:              id,
+					InitialScanTimestamp: *highWater,
+				})
+			}
+		}
+		sort.Slice(targetProgress, func(i, j int) bool {
+			return targetProgress[i].TableID < targetProgress[j].TableID
+		})
+		cf.Targets = targetProgress
+	}
+
+	if err := b.job.Update(ctx, nil /* txn */, func(
+		txn *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+	) error {
+		payload := *md.Payload
+		payload.Details = jobspb.WrapPayloadDetails(newDetails)
+		payload.DescriptorIDs = []descpb.ID{details.DatabaseID}
+		for _, t := range targets {
+			payload.DescriptorIDs = append(payload.DescriptorIDs, t.TableID)
+		}
+		ju.UpdatePayload(&payload)
+		ju.UpdateProgress(newProgress)
+		return nil
+	}); err != nil {
+		return err
+	}
+	*details = newDetails
+	*progress = *newProgress
+	return nil
+}
+
 // OnFailOrCancel is part of the jobs.Resumer interface.
 func (b *changefeedResumer) OnFailOrCancel(ctx context.Context, jobExec interface{}) error {
 	exec := jobExec.(sql.JobExecContext)
@@ -5222,6 +5222,117 @@ func TestChangefeedOnErrorOption(t *testing.T) {
 	t.Run(`pubsub`, pubsubTest(testFn))
 }
 
+func TestChangefeedDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'a'), (2, repeat('b', 256)), (3, 'c')`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo `+
+			`WITH kafka_sink_config='{"MaxMessageBytes": 128}', dead_letter_queue='d.public.dlq', `+
+			`min_checkpoint_frequency='100ms', resolved='100ms'`)
+		defer closeFeed(t, foo)
+
+		// The row which is too large for the sink does not stop the changefeed.
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "a"}}`,
+			`foo: [3]->{"after": {"a": 3, "b": "c"}}`,
+		})
+
+		jobID := foo.(cdctest.EnterpriseTestFeed).JobID()
+		testutils.SucceedsSoon(t, func() error {
+			var dlqEntries int
+			sqlDB.QueryRow(t, `SELECT dead_letter_queue_entries FROM [SHOW CHANGEFEED JOB $1]`,
+				jobID).Scan(&dlqEntries)
+			if dlqEntries != 1 {
+				return errors.Newf("expected 1 dead letter queue entry, found %d", dlqEntries)
+			}
+			return nil
+		})
+		sqlDB.CheckQueryResults(t,
+			`SELECT job_id, topic, key, error LIKE '%exceeds the maximum message size of 128 bytes' `+
+				`FROM d.public.dlq`,
+			[][]string{{strconv.Itoa(int(jobID)), `foo`, `[2]`, `true`}},
+		)
+	}
+
+	t.Run(`kafka`, kafkaTest(testFn))
+}
+
+func TestChangefeedDatabase(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, db *gosql.DB, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(db)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE TABLE bar (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE TABLE skip_me (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE VIEW foo_view AS SELECT a FROM foo`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1)`)
+		sqlDB.Exec(t, `INSERT INTO bar VALUES (1)`)
+		sqlDB.Exec(t, `INSERT INTO skip_me VALUES (1)`)
+
+		cf := feed(t, f, `CREATE CHANGEFEED FOR DATABASE d WITH exclude_tables='skip_*'`)
+		defer closeFeed(t, cf)
+		assertPayloads(t, cf, []string{
+			`foo: [1]->{"after": {"a": 1}}`,
+			`bar: [1]->{"after": {"a": 1}}`,
+		})
+
+		// Tables created after the changefeed are picked up automatically,
+		// excluded ones are not.
+		sqlDB.Exec(t, `CREATE TABLE baz (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `CREATE TABLE skip_me_too (a INT PRIMARY KEY)`)
+		sqlDB.Exec(t, `INSERT INTO skip_me_too VALUES (2)`)
+		sqlDB.Exec(t, `INSERT INTO baz VALUES (2)`)
+		assertPayloads(t, cf, []string{
+			`baz: [2]->{"after": {"a": 2}}`,
+		})
+
+		// Tables which changefeeds don't support, such as tables with several
+		// column families, are not watched and don't fail the changefeed.
+		sqlDB.Exec(t, `CREATE TABLE families (a INT PRIMARY KEY, b INT, FAMILY f1 (a), FAMILY f2 (b))`)
+		sqlDB.Exec(t, `INSERT INTO families VALUES (3, 3)`)
+		sqlDB.Exec(t, `INSERT INTO baz VALUES (3)`)
+		assertPayloads(t, cf, []string{
+			`baz: [3]->{"after": {"a": 3}}`,
+		})
+
+		// Dropped tables stop being watched without failing the changefeed.
+		sqlDB.Exec(t, `DROP TABLE bar`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (3)`)
+		assertPayloads(t, cf, []string{
+			`foo: [3]->{"after": {"a": 3}}`,
+		})
+
+		// Targets of changefeeds on a database cannot be altered.
+		feedJob := cf.(cdctest.EnterpriseTestFeed)
+		require.NoError(t, feedJob.Pause())
+		sqlDB.ExpectErr(t, `cannot add or drop targets of changefeed job \d+ on a database`,
+			fmt.Sprintf(`ALTER CHANGEFEED %d ADD foo`, feedJob.JobID()))
+		sqlDB.ExpectErr(t, `cannot alter option "exclude_tables"`,
+			fmt.Sprintf(`ALTER CHANGEFEED %d SET exclude_tables='baz'`, feedJob.JobID()))
+		require.NoError(t, feedJob.Resume())
+
+		sqlDB.ExpectErr(t, `CHANGEFEED FOR DATABASE requires a sink`,
+			`EXPERIMENTAL CHANGEFEED FOR DATABASE d`)
+		sqlDB.ExpectErr(t, `include_tables can only be used with CHANGEFEED FOR DATABASE`,
+			`CREATE CHANGEFEED FOR foo INTO 'kafka://nope' WITH include_tables='foo'`)
+		sqlDB.ExpectErr(t, `database "d" does not contain any table to watch`,
+			`CREATE CHANGEFEED FOR DATABASE d INTO 'kafka://nope' WITH include_tables='nope_*'`)
+		sqlDB.ExpectErr(t, `invalid pattern "\[" in exclude_tables`,
+			`CREATE CHANGEFEED FOR DATABASE d INTO 'kafka://nope' WITH exclude_tables='['`)
+		sqlDB.ExpectErr(t, `unknown new_tables_initial_scan: maybe`,
+			`CREATE CHANGEFEED FOR DATABASE d INTO 'kafka://nope' WITH new_tables_initial_scan='maybe'`)
+	}
+
+	t.Run(`kafka`, kafkaTest(testFn))
+}
+
 func TestDistSenderRangeFeedPopulatesVirtualTable(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -5249,6 +5360,48 @@ CREATE CHANGEFEED FOR tbl INTO 'null://';
 	sqlDB.CheckQueryResultsRetry(t, numRangesQuery, [][]string{{"1"}})
 }
 
+func TestChangefeedFrontierSpansPopulatesVirtualTable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		Knobs: base.TestingKnobs{
+			JobsTestingKnobs: jobs.NewTestingKnobsWithShortIntervals(),
+		},
+	})
+	defer s.Stopper().Stop(context.Background())
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `
+SET CLUSTER SETTING kv.rangefeed.enabled='true';
+SET CLUSTER SETTING kv.closed_timestamp.target_duration='100ms';
+CREATE TABLE tbl (a INT PRIMARY KEY, b STRING);
+INSERT INTO tbl VALUES (1, 'one'), (2, 'two'), (3, 'three');
+ALTER TABLE tbl SPLIT AT VALUES (2);
+`)
+	var jobID int
+	sqlDB.QueryRow(t, `CREATE CHANGEFEED FOR tbl INTO 'null://' WITH resolved='100ms'`).Scan(&jobID)
+
+	// Both ranges of the table are tracked once the changefeed has resolved
+	// timestamps for them.
+	sqlDB.CheckQueryResultsRetry(t, fmt.Sprintf(`
+SELECT count(*), count(DISTINCT range_id), bool_and(lag > '0s')
+FROM crdb_internal.changefeed_frontier_spans WHERE job_id = %d`, jobID),
+		[][]string{{"2", "2", "true"}})
+
+	// Only the slowest spans are listed.
+	sqlDB.Exec(t, `SET CLUSTER SETTING changefeed.frontier_spans_tracked = 1`)
+	sqlDB.CheckQueryResultsRetry(t, fmt.Sprintf(
+		`SELECT count(*) FROM crdb_internal.changefeed_frontier_spans WHERE job_id = %d`, jobID),
+		[][]string{{"1"}})
+
+	// The spans are no longer listed once the changefeed stops.
+	sqlDB.Exec(t, `CANCEL JOB $1`, jobID)
+	sqlDB.CheckQueryResultsRetry(t, fmt.Sprintf(
+		`SELECT count(*) FROM crdb_internal.changefeed_frontier_spans WHERE job_id = %d`, jobID),
+		[][]string{{"0"}})
+}
+
 func TestChangefeedCaseInsensitiveOpts(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
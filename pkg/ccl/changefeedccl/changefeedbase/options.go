@@ -31,6 +31,10 @@ type SchemaChangePolicy string
 // include virtual columns in an event
 type VirtualColumnVisibility string
 
+// NewTablesInitialScanType defines whether tables which start being watched by
+// a changefeed on a database after its creation get an initial scan.
+type NewTablesInitialScanType string
+
 // Constants for the options.
 const (
 	OptAvroSchemaPrefix         = `avro_schema_prefix`
@@ -56,6 +60,29 @@ const (
 	OptMetricsScope             = `metrics_label`
 	OptVirtualColumns           = `virtual_columns`
 
+	// OptDeadLetterQueue is the destination of rows which could not be encoded
+	// or were rejected by the sink. It is either a cloud storage URI or the
+	// name of a SQL table. When set, such rows are written to the dead letter
+	// queue along with the error instead of failing the changefeed.
+	OptDeadLetterQueue = `dead_letter_queue`
+
+	// OptIncludeTables and OptExcludeTables restrict the tables watched by a
+	// changefeed on a database. Their value is a comma separated list of
+	// shell patterns (see path.Match) matched against table names; a table is
+	// watched if it matches one of the include patterns, if any, and none of
+	// the exclude patterns.
+	OptIncludeTables = `include_tables`
+	OptExcludeTables = `exclude_tables`
+
+	// OptNewTablesInitialScan configures whether tables which start being
+	// watched by a changefeed on a database after its creation, e.g. because
+	// they were created or were brought online by an IMPORT or a RESTORE, are
+	// scanned when the changefeed starts watching them.
+	OptNewTablesInitialScan = `new_tables_initial_scan`
+
+	OptNewTablesInitialScanYes NewTablesInitialScanType = `yes`
+	OptNewTablesInitialScanNo  NewTablesInitialScanType = `no`
+
 	OptVirtualColumnsOmitted VirtualColumnVisibility = `omitted`
 	OptVirtualColumnsNull    VirtualColumnVisibility = `null`
 
@@ -118,6 +145,14 @@ const (
 	OptKafkaSinkConfig   = `kafka_sink_config`
 	OptWebhookSinkConfig = `webhook_sink_config`
 
+	// OptKafkaTransactions makes the kafka sink emit messages inside kafka
+	// transactions which are committed whenever the changefeed checkpoints its
+	// progress. Consumers using isolation.level=read_committed then never
+	// observe messages from a transaction that was aborted by a restart.
+	// Messages committed right before a restart whose checkpoint was not yet
+	// persisted are emitted again.
+	OptKafkaTransactions = `kafka_transactions`
+
 	// OptSink allows users to alter the Sink URI of an existing changefeed.
 	// Note that this option is only allowed for alter changefeed statements.
 	OptSink = `sink`
@@ -182,12 +217,17 @@ var ChangefeedOptionExpectValues = map[string]sql.KVStringOptValidate{
 	OptNoInitialScan:            sql.KVStringOptRequireNoValue,
 	OptProtectDataFromGCOnPause: sql.KVStringOptRequireNoValue,
 	OptKafkaSinkConfig:          sql.KVStringOptRequireValue,
+	OptKafkaTransactions:        sql.KVStringOptRequireNoValue,
+	OptDeadLetterQueue:          sql.KVStringOptRequireValue,
 	OptWebhookSinkConfig:        sql.KVStringOptRequireValue,
 	OptWebhookAuthHeader:        sql.KVStringOptRequireValue,
 	OptWebhookClientTimeout:     sql.KVStringOptRequireValue,
 	OptOnError:                  sql.KVStringOptRequireValue,
 	OptMetricsScope:             sql.KVStringOptRequireValue,
 	OptVirtualColumns:           sql.KVStringOptRequireValue,
+	OptIncludeTables:            sql.KVStringOptRequireValue,
+	OptExcludeTables:            sql.KVStringOptRequireValue,
+	OptNewTablesInitialScan:     sql.KVStringOptRequireValue,
 }
 
 func makeStringSet(opts ...string) map[string]struct{} {
@@ -205,15 +245,16 @@ var CommonOptions = makeStringSet(OptCursor, OptEnvelope,
 	OptResolvedTimestamps, OptUpdatedTimestamps,
 	OptMVCCTimestamps, OptDiff,
 	OptSchemaChangeEvents, OptSchemaChangePolicy,
-	OptProtectDataFromGCOnPause, OptOnError,
+	OptProtectDataFromGCOnPause, OptOnError, OptDeadLetterQueue,
 	OptInitialScan, OptNoInitialScan,
-	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, Topics)
+	OptMinCheckpointFrequency, OptMetricsScope, OptVirtualColumns, Topics,
+	OptIncludeTables, OptExcludeTables, OptNewTablesInitialScan)
 
 // SQLValidOptions is options exclusive to SQL sink
 var SQLValidOptions map[string]struct{} = nil
 
 // KafkaValidOptions is options exclusive to Kafka sink
-var KafkaValidOptions = makeStringSet(OptAvroSchemaPrefix, OptConfluentSchemaRegistry, OptKafkaSinkConfig)
+var KafkaValidOptions = makeStringSet(OptAvroSchemaPrefix, OptConfluentSchemaRegistry, OptKafkaSinkConfig, OptKafkaTransactions)
 
 // CloudStorageValidOptions is options exclusive to cloud storage sink
 var CloudStorageValidOptions = makeStringSet(OptCompression)
@@ -225,7 +266,7 @@ var WebhookValidOptions = makeStringSet(OptWebhookAuthHeader, OptWebhookClientTi
 var PubsubValidOptions = makeStringSet()
 
 // CaseInsensitiveOpts options which supports case Insensitive value
-var CaseInsensitiveOpts = makeStringSet(OptFormat, OptEnvelope, OptCompression, OptSchemaChangeEvents, OptSchemaChangePolicy, OptOnError)
+var CaseInsensitiveOpts = makeStringSet(OptFormat, OptEnvelope, OptCompression, OptSchemaChangeEvents, OptSchemaChangePolicy, OptOnError, OptNewTablesInitialScan)
 
 // NoLongerExperimental aliases options prefixed with experimental that no longer need to be
 var NoLongerExperimental = map[string]string{
@@ -240,7 +281,16 @@ var NoLongerExperimental = map[string]string{
 
 // AlterChangefeedUnsupportedOptions are changefeed options that we do not allow
 // users to alter
-var AlterChangefeedUnsupportedOptions = makeStringSet(OptCursor, OptInitialScan, OptNoInitialScan)
+var AlterChangefeedUnsupportedOptions = makeStringSet(OptCursor, OptInitialScan, OptNoInitialScan,
+	OptIncludeTables, OptExcludeTables)
+
+// AlterChangefeedTargetOptionExpectValues is used to parse the options of
+// targets added by ALTER CHANGEFEED ... ADD using
+// PlanHookState.TypeAsStringOpts().
+var AlterChangefeedTargetOptionExpectValues = map[string]sql.KVStringOptValidate{
+	OptInitialScan:   sql.KVStringOptRequireNoValue,
+	OptNoInitialScan: sql.KVStringOptRequireNoValue,
+}
 
 // AlterChangefeedOptionExpectValues is used to parse alter changefeed options
 // using PlanHookState.TypeAsStringOpts().
@@ -108,6 +108,16 @@ var ScanRequestLimit = settings.RegisterIntSetting(
 	0,
 )
 
+// FrontierSpansTracked is the number of slowest spans of each changefeed
+// frontier which are exposed in crdb_internal.changefeed_frontier_spans.
+var FrontierSpansTracked = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"changefeed.frontier_spans_tracked",
+	"number of the slowest spans of each changefeed frontier listed in crdb_internal.changefeed_frontier_spans; if 0, disabled",
+	10,
+	settings.NonNegativeInt,
+)
+
 // SinkThrottleConfig describes throttling configuration for the sink.
 // 0 values for any of the settings disable that setting.
 type SinkThrottleConfig struct {
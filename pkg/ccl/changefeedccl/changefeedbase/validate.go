@@ -9,8 +9,12 @@
 package changefeedbase
 
 import (
+	"path"
+	"strings"
+
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/errors"
 )
 
@@ -81,3 +85,81 @@ func WarningsForTable(
 	}
 	return warnings
 }
+
+// TableFilter determines which tables are watched by a changefeed on a
+// database.
+type TableFilter struct {
+	DatabaseID descpb.ID
+	Include    []string
+	Exclude    []string
+}
+
+// MakeTableFilter returns the TableFilter of a changefeed on the database with
+// the given ID, configured by the include_tables and exclude_tables options.
+func MakeTableFilter(databaseID descpb.ID, opts map[string]string) (TableFilter, error) {
+	f := TableFilter{DatabaseID: databaseID}
+	var err error
+	if f.Include, err = parseTablePatterns(OptIncludeTables, opts); err != nil {
+		return TableFilter{}, err
+	}
+	if f.Exclude, err = parseTablePatterns(OptExcludeTables, opts); err != nil {
+		return TableFilter{}, err
+	}
+	return f, nil
+}
+
+func parseTablePatterns(opt string, opts map[string]string) ([]string, error) {
+	v, ok := opts[opt]
+	if !ok {
+		return nil, nil
+	}
+	var patterns []string
+	for _, p := range strings.Split(v, ",") {
+		p = strings.TrimSpace(p)
+		if p == `` {
+			continue
+		}
+		// Match only reports malformed patterns while matching, so check the
+		// pattern against the empty string to validate it.
+		if _, err := path.Match(p, ``); err != nil {
+			return nil, errors.Wrapf(err, `invalid pattern %q in %s`, p, opt)
+		}
+		patterns = append(patterns, p)
+	}
+	if len(patterns) == 0 {
+		return nil, errors.Errorf(`%s requires at least one pattern`, opt)
+	}
+	return patterns, nil
+}
+
+// MatchesName returns whether a table with the given name is selected by the
+// include and exclude patterns of the filter.
+func (f TableFilter) MatchesName(name string) bool {
+	matchesAny := func(patterns []string) bool {
+		for _, p := range patterns {
+			// Patterns were validated by MakeTableFilter.
+			if ok, _ := path.Match(p, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(f.Include) > 0 && !matchesAny(f.Include) {
+		return false
+	}
+	return !matchesAny(f.Exclude)
+}
+
+// Watches returns whether the table should be watched by the changefeed. Only
+// public tables of the database are watched; views, sequences and tables which
+// are being added, dropped or are offline are not.
+func (f TableFilter) Watches(tableDesc catalog.TableDescriptor) bool {
+	if tableDesc.GetParentID() != f.DatabaseID || !tableDesc.Public() {
+		return false
+	}
+	if catalog.IsSystemDescriptor(tableDesc) || tableDesc.IsView() ||
+		tableDesc.IsVirtualTable() || tableDesc.IsSequence() || tableDesc.IsTemporary() {
+		return false
+	}
+	return f.MatchesName(tableDesc.GetName())
+}
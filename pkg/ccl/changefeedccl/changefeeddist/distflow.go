@@ -31,6 +31,10 @@ var ChangefeedResultTypes = []*types.T{
 }
 
 // StartDistChangefeed starts distributed changefeed execution.
+//
+// initialScans describe the targets which still need an initial scan even
+// though the changefeed already has a high-water; these are targets added by
+// ALTER CHANGEFEED ... ADD ... WITH initial_scan.
 func StartDistChangefeed(
 	ctx context.Context,
 	execCtx sql.JobExecContext,
@@ -39,6 +43,7 @@ func StartDistChangefeed(
 	trackedSpans []roachpb.Span,
 	initialHighWater hlc.Timestamp,
 	checkpoint jobspb.ChangefeedProgress_Checkpoint,
+	initialScans []execinfrapb.ChangeAggregatorSpec_InitialScan,
 	resultsCh chan<- tree.Datums,
 ) error {
 	// Changefeed flows handle transactional consistency themselves.
@@ -80,11 +85,12 @@ func StartDistChangefeed(
 		}
 
 		spec := &execinfrapb.ChangeAggregatorSpec{
-			Watches:    watches,
-			Checkpoint: aggregatorCheckpoint,
-			Feed:       details,
-			UserProto:  execCtx.User().EncodeProto(),
-			JobID:      jobID,
+			Watches:      watches,
+			Checkpoint:   aggregatorCheckpoint,
+			Feed:         details,
+			UserProto:    execCtx.User().EncodeProto(),
+			JobID:        jobID,
+			InitialScans: partitionInitialScans(sp.Spans, initialScans),
 		}
 		corePlacement[i].SQLInstanceID = sp.SQLInstanceID
 		corePlacement[i].Core.ChangeAggregator = spec
@@ -145,6 +151,42 @@ func StartDistChangefeed(
 	return resultRows.Err()
 }
 
+// partitionInitialScans returns the portions of the initial scans that are
+// covered by the given spans of an aggregator.
+func partitionInitialScans(
+	spans []roachpb.Span, initialScans []execinfrapb.ChangeAggregatorSpec_InitialScan,
+) []execinfrapb.ChangeAggregatorSpec_InitialScan {
+	var res []execinfrapb.ChangeAggregatorSpec_InitialScan
+	for _, scan := range initialScans {
+		scanSpans := intersectSpans(spans, scan.Spans)
+		if len(scanSpans) == 0 {
+			continue
+		}
+		scan.Spans = scanSpans
+		scan.Checkpoint = intersectSpans(scanSpans, scan.Checkpoint)
+		res = append(res, scan)
+	}
+	return res
+}
+
+// intersectSpans returns the portions of spans that are covered by other.
+func intersectSpans(spans []roachpb.Span, other []roachpb.Span) []roachpb.Span {
+	if len(other) == 0 {
+		return nil
+	}
+	var otherGroup roachpb.SpanGroup
+	otherGroup.Add(other...)
+	var res []roachpb.Span
+	for _, sp := range spans {
+		for _, o := range otherGroup.Slice() {
+			if i := sp.Intersect(o); i.Valid() {
+				res = append(res, i)
+			}
+		}
+	}
+	return res
+}
+
 // changefeedResultWriter implements the `sql.rowResultWriter` that sends
 // the received rows back over the given channel.
 type changefeedResultWriter struct {
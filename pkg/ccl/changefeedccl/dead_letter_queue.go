@@ -0,0 +1,306 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// errRowRejected marks errors returned by Sink.EmitRow when the sink refused
+// the row itself, as opposed to failing to emit it (e.g. a message which is
+// larger than the sink allows). Such rows, along with rows which could not be
+// encoded, are sent to the dead letter queue if the changefeed has one.
+var errRowRejected = errors.New("row rejected by sink")
+
+// markRowRejected marks err as a rejection of a single row by the sink.
+func markRowRejected(err error) error {
+	return errors.Mark(err, errRowRejected)
+}
+
+// deadLetterEntry is a row which the changefeed could not emit.
+type deadLetterEntry struct {
+	Topic string `json:"topic"`
+	// Key and Value are the encoded key and value of the row if encoding
+	// succeeded, otherwise the raw KV key and value.
+	Key     []byte `json:"key"`
+	Value   []byte `json:"value"`
+	Error   string `json:"error"`
+	Updated string `json:"updated"`
+}
+
+// deadLetterQueue records the rows a changefeed could not emit so that the
+// changefeed can keep going. Entries are durable once Flush returns; the
+// changeAggregator flushes the queue after the sink, before forwarding
+// resolved timestamps. Implementations are safe for concurrent use, since
+// sinks may add the rows they learn were rejected from their own goroutines.
+type deadLetterQueue interface {
+	// Add queues an entry for the row with the given topic, key and value,
+	// which could not be emitted because of cause.
+	Add(ctx context.Context, topic string, key, value []byte, updated hlc.Timestamp, cause error) error
+	// Flush persists all queued entries and returns how many there were.
+	Flush(ctx context.Context) (int, error)
+	// Close releases the resources held by the queue.
+	Close() error
+}
+
+// deadLetterQueueSink is implemented by sinks which only learn that a row was
+// rejected after EmitRow returned, for example because the downstream system
+// refused it. Such sinks add the rejected rows to the dead letter queue
+// themselves, before the Flush covering them returns.
+type deadLetterQueueSink interface {
+	Sink
+	setDeadLetterQueue(dlq deadLetterQueue)
+}
+
+// isDeadLetterError returns whether err, encountered while encoding or
+// emitting a row, only concerns that row and may be sent to the dead letter
+// queue.
+func isDeadLetterError(err error) bool {
+	return !changefeedbase.IsRetryableError(err) &&
+		!errors.Is(err, errSchemaRegistry) &&
+		!errors.Is(err, context.Canceled)
+}
+
+// isCloudStorageDeadLetterQueue returns whether the dead_letter_queue option
+// value denotes a cloud storage URI rather than a SQL table.
+func isCloudStorageDeadLetterQueue(dest string) bool {
+	u, err := url.Parse(dest)
+	return err == nil && u.Scheme != ``
+}
+
+// validateDeadLetterQueue checks the value of the dead_letter_queue option.
+func validateDeadLetterQueue(dest string) error {
+	if dest == `` {
+		return errors.Newf(`%s requires a cloud storage URI or a table name`,
+			changefeedbase.OptDeadLetterQueue)
+	}
+	if isCloudStorageDeadLetterQueue(dest) {
+		return nil
+	}
+	if _, err := parser.ParseQualifiedTableName(dest); err != nil {
+		return errors.Wrapf(err, `invalid %s`, changefeedbase.OptDeadLetterQueue)
+	}
+	return nil
+}
+
+// makeDeadLetterQueue returns the dead letter queue configured for the
+// changefeed, or nil if the changefeed does not have one.
+func makeDeadLetterQueue(
+	ctx context.Context,
+	cfg *execinfra.ServerConfig,
+	details jobspb.ChangefeedDetails,
+	jobID jobspb.JobID,
+	user security.SQLUsername,
+	m *sliMetrics,
+) (deadLetterQueue, error) {
+	dest, ok := details.Opts[changefeedbase.OptDeadLetterQueue]
+	if !ok {
+		return nil, nil
+	}
+	if err := validateDeadLetterQueue(dest); err != nil {
+		return nil, err
+	}
+
+	if isCloudStorageDeadLetterQueue(dest) {
+		es, err := cfg.ExternalStorageFromURI(ctx, dest, user)
+		if err != nil {
+			return nil, err
+		}
+		return &cloudStorageDeadLetterQueue{
+			es:      es,
+			jobID:   jobID,
+			id:      uuid.MakeV4(),
+			metrics: m,
+		}, nil
+	}
+
+	tableName, err := parser.ParseQualifiedTableName(dest)
+	if err != nil {
+		return nil, err
+	}
+	q := &sqlDeadLetterQueue{
+		ie:        cfg.Executor,
+		user:      user,
+		tableName: tableName,
+		jobID:     jobID,
+		metrics:   m,
+	}
+	if _, err := q.ie.ExecEx(ctx, "changefeed-dlq-create", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: user},
+		fmt.Sprintf(deadLetterQueueCreateTableStmt, tree.AsString(tableName)),
+	); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func makeDeadLetterEntry(
+	topic string, key, value []byte, updated hlc.Timestamp, cause error,
+) deadLetterEntry {
+	return deadLetterEntry{
+		Topic:   topic,
+		Key:     append([]byte(nil), key...),
+		Value:   append([]byte(nil), value...),
+		Error:   cause.Error(),
+		Updated: updated.AsOfSystemTime(),
+	}
+}
+
+// cloudStorageDeadLetterQueue writes entries as newline delimited JSON, one
+// file per flush.
+type cloudStorageDeadLetterQueue struct {
+	es      cloud.ExternalStorage
+	jobID   jobspb.JobID
+	id      uuid.UUID
+	metrics *sliMetrics
+
+	mu struct {
+		syncutil.Mutex
+		seq     int
+		buf     bytes.Buffer
+		pending int
+	}
+}
+
+var _ deadLetterQueue = (*cloudStorageDeadLetterQueue)(nil)
+
+// Add implements the deadLetterQueue interface.
+func (q *cloudStorageDeadLetterQueue) Add(
+	ctx context.Context, topic string, key, value []byte, updated hlc.Timestamp, cause error,
+) error {
+	j, err := json.Marshal(makeDeadLetterEntry(topic, key, value, updated, cause))
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.mu.buf.Write(j)
+	q.mu.buf.WriteByte('\n')
+	q.mu.pending++
+	return nil
+}
+
+// Flush implements the deadLetterQueue interface.
+func (q *cloudStorageDeadLetterQueue) Flush(ctx context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.mu.buf.Len() == 0 {
+		return 0, nil
+	}
+	// Files are named so that they sort by the order in which each processor
+	// wrote them.
+	filename := fmt.Sprintf(`%d-%s-%08d.ndjson`, q.jobID, q.id, q.mu.seq)
+	if err := cloud.WriteFile(ctx, q.es, filename, bytes.NewReader(q.mu.buf.Bytes())); err != nil {
+		return 0, err
+	}
+	n := q.mu.pending
+	q.mu.seq++
+	q.mu.pending = 0
+	q.mu.buf.Reset()
+	q.metrics.recordDeadLetterQueueEntries(n)
+	return n, nil
+}
+
+// Close implements the deadLetterQueue interface.
+func (q *cloudStorageDeadLetterQueue) Close() error {
+	return q.es.Close()
+}
+
+const deadLetterQueueCreateTableStmt = `CREATE TABLE IF NOT EXISTS %s (
+	job_id INT8 NOT NULL,
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	topic STRING NOT NULL,
+	key BYTES,
+	value BYTES,
+	error STRING NOT NULL,
+	updated DECIMAL NOT NULL,
+	PRIMARY KEY (job_id, id)
+)`
+
+// sqlDeadLetterQueue inserts entries into a SQL table, which is created if it
+// does not exist.
+type sqlDeadLetterQueue struct {
+	ie        sqlutil.InternalExecutor
+	user      security.SQLUsername
+	tableName *tree.TableName
+	jobID     jobspb.JobID
+	metrics   *sliMetrics
+
+	mu struct {
+		syncutil.Mutex
+		entries []deadLetterEntry
+	}
+}
+
+var _ deadLetterQueue = (*sqlDeadLetterQueue)(nil)
+
+// Add implements the deadLetterQueue interface.
+func (q *sqlDeadLetterQueue) Add(
+	ctx context.Context, topic string, key, value []byte, updated hlc.Timestamp, cause error,
+) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.mu.entries = append(q.mu.entries, makeDeadLetterEntry(topic, key, value, updated, cause))
+	return nil
+}
+
+// Flush implements the deadLetterQueue interface.
+func (q *sqlDeadLetterQueue) Flush(ctx context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.mu.entries) == 0 {
+		return 0, nil
+	}
+	const cols = 6
+	var stmt strings.Builder
+	fmt.Fprintf(&stmt, `INSERT INTO %s (job_id, topic, key, value, error, updated) VALUES `,
+		tree.AsString(q.tableName))
+	args := make([]interface{}, 0, len(q.mu.entries)*cols)
+	for i, e := range q.mu.entries {
+		if i > 0 {
+			stmt.WriteString(`, `)
+		}
+		n := i * cols
+		fmt.Fprintf(&stmt, `($%d, $%d, $%d, $%d, $%d, $%d::DECIMAL)`, n+1, n+2, n+3, n+4, n+5, n+6)
+		args = append(args, int64(q.jobID), e.Topic, e.Key, e.Value, e.Error, e.Updated)
+	}
+	if _, err := q.ie.ExecEx(ctx, "changefeed-dlq-insert", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: q.user}, stmt.String(), args...,
+	); err != nil {
+		return 0, err
+	}
+	n := len(q.mu.entries)
+	q.mu.entries = q.mu.entries[:0]
+	q.metrics.recordDeadLetterQueueEntries(n)
+	return n, nil
+}
+
+// Close implements the deadLetterQueue interface.
+func (q *sqlDeadLetterQueue) Close() error {
+	return nil
+}
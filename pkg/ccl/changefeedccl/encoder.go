@@ -585,7 +585,11 @@ func (e *confluentAvroEncoder) EncodeResolvedTimestamp(
 func (e *confluentAvroEncoder) register(
 	ctx context.Context, schema *avroRecord, subject string,
 ) (int32, error) {
-	return e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, schema.codec.Schema())
+	id, err := e.schemaRegistry.RegisterSchemaForSubject(ctx, subject, schema.codec.Schema())
+	if err != nil {
+		return 0, errors.Mark(err, errSchemaRegistry)
+	}
+	return id, nil
 }
 
 // nativeEncoder only implements EncodeResolvedTimestamp.
@@ -218,13 +218,20 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 			return err
 		}
 
+		events, err := f.tableFeed.Peek(ctx, highWater.Next())
+		if err != nil {
+			return err
+		}
 		boundaryType := jobspb.ResolvedSpan_BACKFILL
-		if f.schemaChangePolicy == changefeedbase.OptSchemaChangePolicyStop {
+		if isTargetSetChange(events) {
+			// The tables watched by a changefeed on a database changed; the
+			// changefeed restarts to watch the new set of tables regardless of
+			// the schema change policy.
+			boundaryType = jobspb.ResolvedSpan_RESTART
+		} else if f.schemaChangePolicy == changefeedbase.OptSchemaChangePolicyStop {
 			boundaryType = jobspb.ResolvedSpan_EXIT
-		} else if events, err := f.tableFeed.Peek(ctx, highWater.Next()); err == nil && isPrimaryKeyChange(events) {
+		} else if isPrimaryKeyChange(events) {
 			boundaryType = jobspb.ResolvedSpan_RESTART
-		} else if err != nil {
-			return err
 		}
 		// Resolve all of the spans as a boundary if the policy indicates that
 		// we should do so.
@@ -247,8 +254,20 @@ func (f *kvFeed) run(ctx context.Context) (err error) {
 	}
 }
 
+func isTargetSetChange(events []schemafeed.TableEvent) bool {
+	for _, ev := range events {
+		if schemafeed.IsTargetSetChange(ev) {
+			return true
+		}
+	}
+	return false
+}
+
 func isPrimaryKeyChange(events []schemafeed.TableEvent) bool {
 	for _, ev := range events {
+		if schemafeed.IsTargetSetChange(ev) {
+			continue
+		}
 		if schemafeed.IsPrimaryIndexChange(ev) {
 			return true
 		}
@@ -287,6 +306,11 @@ func (f *kvFeed) scanIfShould(
 		// Only backfill for the tables which have events which may not be all
 		// of the targets.
 		for _, ev := range events {
+			// Tables starting or stopping being watched by a changefeed on a
+			// database restart the changefeed rather than trigger a backfill.
+			if schemafeed.IsTargetSetChange(ev) {
+				continue
+			}
 			// If the event corresponds to a primary index change, it does not
 			// indicate a need for a backfill. Furthermore, if the changefeed was
 			// started at this timestamp because of a restart due to a primary index
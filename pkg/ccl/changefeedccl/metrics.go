@@ -52,6 +52,14 @@ type AggMetrics struct {
 	ErrorRetries    *aggmetric.AggCounter
 	AdmitLatency    *aggmetric.AggHistogram
 	RunningCount    *aggmetric.AggGauge
+	DLQEntries      *aggmetric.AggCounter
+
+	// Stage latencies break the time an event spends in the changefeed down by
+	// the stage of the pipeline it is in.
+	RangefeedLatency *aggmetric.AggHistogram
+	BufferLatency    *aggmetric.AggHistogram
+	EncodeLatency    *aggmetric.AggHistogram
+	EmitRowLatency   *aggmetric.AggHistogram
 
 	// There is always at least 1 sliMetrics created for defaultSLI scope.
 	mu struct {
@@ -76,6 +84,12 @@ type sliMetrics struct {
 	AdmitLatency    *aggmetric.Histogram
 	BackfillCount   *aggmetric.Gauge
 	RunningCount    *aggmetric.Gauge
+	DLQEntries      *aggmetric.Counter
+
+	RangefeedLatency *aggmetric.Histogram
+	BufferLatency    *aggmetric.Histogram
+	EncodeLatency    *aggmetric.Histogram
+	EmitRowLatency   *aggmetric.Histogram
 }
 
 // sinkDoesNotCompress is a sentinel value indicating the sink
@@ -140,6 +154,45 @@ func (m *sliMetrics) recordFlushRequestCallback() func() {
 	}
 }
 
+// recordDeadLetterQueueEntries records entries which were durably written to
+// the dead letter queue.
+func (m *sliMetrics) recordDeadLetterQueueEntries(n int) {
+	if m == nil {
+		return
+	}
+	m.DLQEntries.Inc(int64(n))
+}
+
+// recordBufferedEvent records the time the event spent getting to, and then
+// waiting in, the kvfeed buffer. Backfill events are only accounted for in the
+// latter since their MVCC timestamp predates the changefeed.
+func (m *sliMetrics) recordBufferedEvent(ev *kvevent.Event) {
+	if m == nil || ev.BufferAddTimestamp().IsZero() {
+		return
+	}
+	if ev.Type() == kvevent.TypeKV && ev.BackfillTimestamp().IsEmpty() {
+		m.RangefeedLatency.RecordValue(
+			ev.BufferAddTimestamp().Sub(ev.MVCCTimestamp().GoTime()).Nanoseconds())
+	}
+	m.BufferLatency.RecordValue(timeutil.Since(ev.BufferAddTimestamp()).Nanoseconds())
+}
+
+// recordEncode records the time spent encoding the key and value of a row.
+func (m *sliMetrics) recordEncode(start time.Time) {
+	if m == nil {
+		return
+	}
+	m.EncodeLatency.RecordValue(timeutil.Since(start).Nanoseconds())
+}
+
+// recordEmitRow records the time spent handing a row to the sink.
+func (m *sliMetrics) recordEmitRow(start time.Time) {
+	if m == nil {
+		return
+	}
+	m.EmitRowLatency.RecordValue(timeutil.Since(start).Nanoseconds())
+}
+
 func (m *sliMetrics) getBackfillCallback() func() func() {
 	return func() func() {
 		m.BackfillCount.Inc(1)
@@ -155,6 +208,8 @@ const (
 	changefeedFlushHistMaxLatency      = 1 * time.Minute
 	admitLatencyMaxValue               = 1 * time.Minute
 	commitLatencyMaxValue              = 10 * time.Minute
+	rangefeedLatencyMaxValue           = 10 * time.Minute
+	stageLatencyMaxValue               = 1 * time.Minute
 )
 
 var (
@@ -278,6 +333,38 @@ func newAggregateMetrics(histogramWindow time.Duration) *AggMetrics {
 		Measurement: "Changefeeds",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaChangefeedDLQEntries := metric.Metadata{
+		Name:        "changefeed.dead_letter_queue_entries",
+		Help:        "Rows written to the dead letter queue because they could not be encoded or were rejected by the sink",
+		Measurement: "Messages",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaRangefeedLatency := metric.Metadata{
+		Name: "changefeed.stage.rangefeed_latency",
+		Help: "Latency between the MVCC timestamp of an event and the time it was added " +
+			"to the kvfeed buffer; this includes the rangefeed catch-up. Excludes backfills",
+		Measurement: "Nanoseconds",
+		Unit:        metric.Unit_NANOSECONDS,
+	}
+	metaBufferLatency := metric.Metadata{
+		Name:        "changefeed.stage.buffer_latency",
+		Help:        "Time events spent in the kvfeed buffer before being processed",
+		Measurement: "Nanoseconds",
+		Unit:        metric.Unit_NANOSECONDS,
+	}
+	metaEncodeLatency := metric.Metadata{
+		Name:        "changefeed.stage.encode_latency",
+		Help:        "Time spent encoding the key and value of rows",
+		Measurement: "Nanoseconds",
+		Unit:        metric.Unit_NANOSECONDS,
+	}
+	metaEmitRowLatency := metric.Metadata{
+		Name: "changefeed.stage.emit_row_latency",
+		Help: "Time spent handing rows to the sink; this includes the time spent " +
+			"waiting for the sink to accept more rows",
+		Measurement: "Nanoseconds",
+		Unit:        metric.Unit_NANOSECONDS,
+	}
 
 	// NB: When adding new histograms, use sigFigs = 1.  Older histograms
 	// retain significant figures of 2.
@@ -299,6 +386,16 @@ func newAggregateMetrics(histogramWindow time.Duration) *AggMetrics {
 			admitLatencyMaxValue.Nanoseconds(), 1),
 		BackfillCount: b.Gauge(metaChangefeedBackfillCount),
 		RunningCount:  b.Gauge(metaChangefeedRunning),
+		DLQEntries:    b.Counter(metaChangefeedDLQEntries),
+
+		RangefeedLatency: b.Histogram(metaRangefeedLatency, histogramWindow,
+			rangefeedLatencyMaxValue.Nanoseconds(), 1),
+		BufferLatency: b.Histogram(metaBufferLatency, histogramWindow,
+			stageLatencyMaxValue.Nanoseconds(), 1),
+		EncodeLatency: b.Histogram(metaEncodeLatency, histogramWindow,
+			stageLatencyMaxValue.Nanoseconds(), 1),
+		EmitRowLatency: b.Histogram(metaEmitRowLatency, histogramWindow,
+			stageLatencyMaxValue.Nanoseconds(), 1),
 	}
 	a.mu.sliMetrics = make(map[string]*sliMetrics)
 	_, err := a.getOrCreateScope(defaultSLIScope)
@@ -354,6 +451,12 @@ func (a *AggMetrics) getOrCreateScope(scope string) (*sliMetrics, error) {
 		AdmitLatency:    a.AdmitLatency.AddChild(scope),
 		BackfillCount:   a.BackfillCount.AddChild(scope),
 		RunningCount:    a.RunningCount.AddChild(scope),
+		DLQEntries:      a.DLQEntries.AddChild(scope),
+
+		RangefeedLatency: a.RangefeedLatency.AddChild(scope),
+		BufferLatency:    a.BufferLatency.AddChild(scope),
+		EncodeLatency:    a.EncodeLatency.AddChild(scope),
+		EmitRowLatency:   a.EmitRowLatency.AddChild(scope),
 	}
 
 	a.mu.sliMetrics[scope] = sm
@@ -376,6 +479,9 @@ type Metrics struct {
 		syncutil.Mutex
 		id       int
 		resolved map[int]hlc.Timestamp
+		// frontierSpans holds the slowest spans of each change frontier running
+		// on this node, keyed like resolved.
+		frontierSpans map[int][]jobs.ChangefeedFrontierSpan
 	}
 	MaxBehindNanos *metric.Gauge
 }
@@ -383,6 +489,25 @@ type Metrics struct {
 // MetricStruct implements the metric.Struct interface.
 func (*Metrics) MetricStruct() {}
 
+var _ jobs.ChangefeedFrontierSpans = (*Metrics)(nil)
+
+// ForEachFrontierSpan implements the jobs.ChangefeedFrontierSpans interface.
+func (m *Metrics) ForEachFrontierSpan(fn func(jobs.ChangefeedFrontierSpan) error) error {
+	m.mu.Lock()
+	var spans []jobs.ChangefeedFrontierSpan
+	for _, s := range m.mu.frontierSpans {
+		spans = append(spans, s...)
+	}
+	m.mu.Unlock()
+
+	for _, s := range spans {
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getSLIMetrics retursn SLIMeterics associated with the specified scope.
 func (m *Metrics) getSLIMetrics(scope string) (*sliMetrics, error) {
 	return m.AggMetrics.getOrCreateScope(scope)
@@ -404,6 +529,7 @@ func MakeMetrics(histogramWindow time.Duration) metric.Struct {
 	}
 
 	m.mu.resolved = make(map[int]hlc.Timestamp)
+	m.mu.frontierSpans = make(map[int][]jobs.ChangefeedFrontierSpan)
 	m.mu.id = 1 // start the first id at 1 so we can detect initialization
 	m.MaxBehindNanos = metric.NewFunctionalGauge(metaChangefeedMaxBehindNanos, func() int64 {
 		now := timeutil.Now()
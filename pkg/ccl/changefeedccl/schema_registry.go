@@ -28,6 +28,10 @@ import (
 
 const confluentSchemaContentType = `application/vnd.schemaregistry.v1+json`
 
+// errSchemaRegistry marks errors encountered while talking to the schema
+// registry; these do not concern the row being encoded.
+var errSchemaRegistry = errors.New("schema registry error")
+
 type schemaRegistry interface {
 	// Ping tests the connectivity to the schema registry. A nil
 	// error is returned if the schema registry appears to be
@@ -45,8 +45,28 @@ import (
 // earliest and just ingest the relevant descriptors.
 
 // TableEvent represents a change to a table descriptor.
+//
+// For changefeeds on a database, Before is nil if the event corresponds to a
+// table which started being watched by the changefeed (see IsTableAdded).
 type TableEvent struct {
 	Before, After catalog.TableDescriptor
+
+	// targetSetChange is set if the table started or stopped being watched by
+	// a changefeed on a database.
+	targetSetChange bool
+}
+
+// IsTableAdded returns true if the event corresponds to a table which started
+// being watched by a changefeed on a database, e.g. because it was created.
+func IsTableAdded(e TableEvent) bool {
+	return e.targetSetChange && e.Before == nil
+}
+
+// IsTargetSetChange returns true if the event corresponds to a table which
+// started or stopped being watched by a changefeed on a database, e.g. because
+// it was created or dropped. Other changefeeds fail when a target is dropped.
+func IsTargetSetChange(e TableEvent) bool {
+	return e.targetSetChange
 }
 
 // Timestamp refers to the ModificationTime of the After table descriptor.
@@ -69,6 +89,10 @@ type SchemaFeed interface {
 
 // New creates SchemaFeed tracking 'targets' and emitting specified 'events'.
 //
+// If tableFilter is non-nil, the changefeed targets a database and the
+// SchemaFeed also emits events for the tables of the database which start
+// being selected by the filter, and for targets which are dropped.
+//
 // initialHighwater is the timestamp after which events should occur.
 // NB: When clients want to create a changefeed which has a resolved timestamp
 // of ts1, they care about write which occur at ts1.Next() and later but they
@@ -81,6 +105,7 @@ func New(
 	targets []jobspb.ChangefeedTargetSpecification,
 	initialHighwater hlc.Timestamp,
 	metrics *Metrics,
+	tableFilter *changefeedbase.TableFilter,
 ) SchemaFeed {
 	m := &schemaFeed{
 		filter:            schemaChangeEventFilters[events],
@@ -88,12 +113,14 @@ func New(
 		clock:             cfg.DB.Clock(),
 		settings:          cfg.Settings,
 		targets:           targets,
+		tableFilter:       tableFilter,
 		leaseMgr:          cfg.LeaseManager.(*lease.Manager),
 		ie:                cfg.SessionBoundInternalExecutorFactory(ctx, &sessiondata.SessionData{}),
 		collectionFactory: cfg.CollectionFactory,
 		metrics:           metrics,
 	}
 	m.mu.previousTableVersion = make(map[descpb.ID]catalog.TableDescriptor)
+	m.mu.addedTables = make(map[descpb.ID]struct{})
 	m.mu.highWater = initialHighwater
 	m.mu.typeDeps = typeDependencyTracker{deps: make(map[descpb.ID][]descpb.ID)}
 	return m
@@ -118,6 +145,10 @@ type schemaFeed struct {
 	ie       sqlutil.InternalExecutor
 	metrics  *Metrics
 
+	// tableFilter is set for changefeeds on a database; it selects the tables
+	// of the database which the changefeed watches.
+	tableFilter *changefeedbase.TableFilter
+
 	// TODO(ajwerner): Should this live underneath the FilterFunc?
 	// Should there be another function to decide whether to update the
 	// lease manager?
@@ -154,6 +185,11 @@ type schemaFeed struct {
 		// typeDeps tracks dependencies from target tables to user defined types
 		// that they use.
 		typeDeps typeDependencyTracker
+
+		// addedTables are the tables of a changefeed on a database which are
+		// not targets of the changefeed and for which an event was emitted
+		// because they started being watched.
+		addedTables map[descpb.ID]struct{}
 	}
 }
 
@@ -281,10 +317,21 @@ func (tf *schemaFeed) primeInitialTableDescs(ctx context.Context) error {
 		for _, table := range tf.targets {
 			flags := tree.ObjectLookupFlagsWithRequired()
 			flags.AvoidLeased = true
+			if tf.tableFilter != nil {
+				// The targets of a changefeed on a database include the tables
+				// which started being watched right after the initial
+				// high-water, which may not exist yet or not be online.
+				flags.Required = false
+				flags.IncludeOffline = true
+				flags.IncludeDropped = true
+			}
 			tableDesc, err := descriptors.GetImmutableTableByID(ctx, txn, table.TableID, flags)
 			if err != nil {
 				return err
 			}
+			if tableDesc == nil || (tf.tableFilter != nil && !tf.tableFilter.Watches(tableDesc)) {
+				continue
+			}
 			initialDescs = append(initialDescs, tableDesc)
 		}
 		return nil
@@ -508,6 +555,9 @@ func formatDesc(desc catalog.TableDescriptor) string {
 }
 
 func formatEvent(e TableEvent) string {
+	if e.Before == nil {
+		return fmt.Sprintf("added %v", formatDesc(e.After))
+	}
 	return fmt.Sprintf("%v->%v", formatDesc(e.Before), formatDesc(e.After))
 }
 
@@ -525,6 +575,11 @@ func (tf *schemaFeed) validateDescriptor(
 		// manager to acquire the freshest version of the type.
 		return tf.leaseMgr.AcquireFreshestFromStore(ctx, desc.GetID())
 	case catalog.TableDescriptor:
+		if tf.tableFilter != nil {
+			if handled, err := tf.validateDatabaseTableLocked(ctx, earliestTsBeingIngested, desc); handled || err != nil {
+				return err
+			}
+		}
 		if err := changefeedbase.ValidateTable(tf.targets, desc); err != nil {
 			return err
 		}
@@ -561,17 +616,7 @@ func (tf *schemaFeed) validateDescriptor(
 				return err
 			}
 			if !shouldFilter {
-				// Only sort the tail of the events from earliestTsBeingIngested.
-				// The head could already have been handed out and sorting is not
-				// stable.
-				idxToSort := sort.Search(len(tf.mu.events), func(i int) bool {
-					return !tf.mu.events[i].After.GetModificationTime().Less(earliestTsBeingIngested)
-				})
-				tf.mu.events = append(tf.mu.events, e)
-				toSort := tf.mu.events[idxToSort:]
-				sort.Slice(toSort, func(i, j int) bool {
-					return descLess(toSort[i].After, toSort[j].After)
-				})
+				tf.addEventLocked(earliestTsBeingIngested, e)
 			}
 		}
 		// Add the types used by the table into the dependency tracker.
@@ -585,6 +630,83 @@ func (tf *schemaFeed) validateDescriptor(
 	}
 }
 
+// addEventLocked adds an event to the queue of events.
+func (tf *schemaFeed) addEventLocked(earliestTsBeingIngested hlc.Timestamp, e TableEvent) {
+	// Only sort the tail of the events from earliestTsBeingIngested.
+	// The head could already have been handed out and sorting is not
+	// stable.
+	idxToSort := sort.Search(len(tf.mu.events), func(i int) bool {
+		return !tf.mu.events[i].After.GetModificationTime().Less(earliestTsBeingIngested)
+	})
+	tf.mu.events = append(tf.mu.events, e)
+	toSort := tf.mu.events[idxToSort:]
+	sort.Slice(toSort, func(i, j int) bool {
+		return descLess(toSort[i].After, toSort[j].After)
+	})
+}
+
+// validateDatabaseTableLocked handles the descriptors of the tables of a
+// changefeed on a database which start or stop being watched. It emits an
+// event for tables which start being selected by the table filter and for
+// targets which are dropped or stop being selected, so that the changefeed
+// restarts with the new set of tables. It returns whether the descriptor was
+// handled; other descriptors are validated as usual.
+func (tf *schemaFeed) validateDatabaseTableLocked(
+	ctx context.Context, earliestTsBeingIngested hlc.Timestamp, desc catalog.TableDescriptor,
+) (handled bool, _ error) {
+	isTarget := false
+	for _, t := range tf.targets {
+		if t.TableID == desc.GetID() {
+			isTarget = true
+			break
+		}
+	}
+	watched := tf.tableFilter.Watches(desc)
+	lastVersion, seen := tf.mu.previousTableVersion[desc.GetID()]
+	if seen && desc.GetModificationTime().LessEq(lastVersion.GetModificationTime()) {
+		return true, nil
+	}
+
+	switch {
+	case isTarget && !seen:
+		// The first version of a target seen by the feed. Targets which started
+		// being watched right after the initial high-water are first seen once
+		// they become watched.
+		if !watched {
+			return true, nil
+		}
+		return false, nil
+	case isTarget && !watched:
+		if !tf.tableFilter.Watches(lastVersion) {
+			// An event was already emitted for the target.
+			return true, nil
+		}
+		// The target was dropped, renamed so that it is no longer selected or
+		// taken offline.
+		log.VEventf(ctx, 1, "table %v is no longer watched", formatDesc(desc))
+		if err := tf.mu.typeDeps.purgeTable(lastVersion); err != nil {
+			return true, err
+		}
+		tf.mu.previousTableVersion[desc.GetID()] = desc
+		tf.addEventLocked(earliestTsBeingIngested, TableEvent{
+			Before: lastVersion, After: desc, targetSetChange: true,
+		})
+		return true, nil
+	case isTarget:
+		return false, nil
+	case watched:
+		if _, ok := tf.mu.addedTables[desc.GetID()]; ok {
+			return true, nil
+		}
+		log.VEventf(ctx, 1, "table %v started being watched", formatDesc(desc))
+		tf.mu.addedTables[desc.GetID()] = struct{}{}
+		tf.addEventLocked(earliestTsBeingIngested, TableEvent{After: desc, targetSetChange: true})
+		return true, nil
+	default:
+		return true, nil
+	}
+}
+
 func (tf *schemaFeed) fetchDescriptorVersions(
 	ctx context.Context, codec keys.SQLCodec, db *kv.DB, startTS, endTS hlc.Timestamp,
 ) ([]catalog.Descriptor, error) {
@@ -646,13 +768,21 @@ func (tf *schemaFeed) fetchDescriptorVersions(
 					}
 				}
 				isType := tf.mu.typeDeps.containsType(descpb.ID(id))
-				// Check if the descriptor is an interesting table or type.
-				if !(isTable || isType) {
+				// Check if the descriptor is an interesting table or type. For
+				// changefeeds on a database, any table may be interesting; they
+				// are filtered by database below.
+				if !(isTable || isType || tf.tableFilter != nil) {
 					// Uninteresting descriptor.
 					continue
 				}
 
 				unsafeValue := it.UnsafeValue()
+				if unsafeValue == nil && tf.tableFilter != nil {
+					// The descriptor was deleted. For targets of a changefeed on
+					// a database, an event was emitted when the table was
+					// dropped.
+					continue
+				}
 				if unsafeValue == nil {
 					name := origName
 					if name == "" {
@@ -669,8 +799,19 @@ func (tf *schemaFeed) fetchDescriptorVersions(
 				}
 
 				b := descbuilder.NewBuilderWithMVCCTimestamp(&desc, k.Timestamp)
-				if b != nil && (b.DescriptorType() == catalog.Table || b.DescriptorType() == catalog.Type) {
-					descriptors = append(descriptors, b.BuildImmutable())
+				if b == nil {
+					continue
+				}
+				switch b.DescriptorType() {
+				case catalog.Table:
+					d := b.BuildImmutable()
+					if isTable || (tf.tableFilter != nil && d.GetParentID() == tf.tableFilter.DatabaseID) {
+						descriptors = append(descriptors, d)
+					}
+				case catalog.Type:
+					if isType {
+						descriptors = append(descriptors, b.BuildImmutable())
+					}
 				}
 			}
 		}(); err != nil {
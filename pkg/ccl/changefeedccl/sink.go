@@ -66,6 +66,34 @@ type Sink interface {
 	Close() error
 }
 
+// transactionalSink is implemented by sinks which group the messages they emit
+// into transactions, so that consumers only observe messages once the
+// changefeed is about to checkpoint past them.
+//
+// Change aggregators commit right before forwarding the resolved spans
+// covering the committed messages to the change frontier, which persists them
+// in the job progress; they get no notification once that has happened. If
+// the changefeed restarts after a commit but before the matching checkpoint
+// is persisted, the committed messages are emitted again. Transactions thus
+// only prevent consumers from observing messages emitted past the last
+// commit, and delivery remains at-least-once within that window.
+type transactionalSink interface {
+	Sink
+	// CommitTxn flushes the sink and commits every message emitted since the
+	// previous commit. It must be called before the resolved timestamps
+	// covering these messages are checkpointed.
+	CommitTxn(ctx context.Context) error
+}
+
+// commitSinkTxn commits the messages emitted to the given sink if it is
+// transactional, and is a no-op otherwise.
+func commitSinkTxn(ctx context.Context, s Sink) error {
+	if ts, ok := s.(transactionalSink); ok {
+		return ts.CommitTxn(ctx)
+	}
+	return nil
+}
+
 // SinkWithTopics extends the Sink interface to include a method that returns
 // the topics that a changefeed will emit to
 type SinkWithTopics interface {
@@ -80,6 +108,7 @@ func getSink(
 	timestampOracle timestampLowerBoundOracle,
 	user security.SQLUsername,
 	jobID jobspb.JobID,
+	sinkOwner string,
 	m *sliMetrics,
 ) (Sink, error) {
 	u, err := url.Parse(feedCfg.SinkURI)
@@ -109,7 +138,8 @@ func getSink(
 			return makeNullSink(sinkURL{URL: u}, m)
 		case u.Scheme == changefeedbase.SinkSchemeKafka:
 			return validateOptionsAndMakeSink(changefeedbase.KafkaValidOptions, func() (Sink, error) {
-				return makeKafkaSink(ctx, sinkURL{URL: u}, AllTargets(feedCfg), feedCfg.Opts, m)
+				return makeKafkaSink(ctx, sinkURL{URL: u}, AllTargets(feedCfg), feedCfg.Opts,
+					kafkaTransactionalID(jobID, sinkOwner), m)
 			})
 		case isWebhookSink(u):
 			return validateOptionsAndMakeSink(changefeedbase.WebhookValidOptions, func() (Sink, error) {
@@ -274,6 +304,14 @@ func (s errorWrapperSink) Flush(ctx context.Context) error {
 	return nil
 }
 
+// CommitTxn implements transactionalSink interface.
+func (s errorWrapperSink) CommitTxn(ctx context.Context) error {
+	if err := commitSinkTxn(ctx, s.wrapped); err != nil {
+		return changefeedbase.MarkRetryableError(err)
+	}
+	return nil
+}
+
 // Close implements Sink interface.
 func (s errorWrapperSink) Close() error {
 	if err := s.wrapped.Close(); err != nil {
@@ -303,6 +303,16 @@ type cloudStorageSink struct {
 	dataFilePartition string
 	prevFilename      string
 	metrics           *sliMetrics
+
+	// dlq, if set, is the dead letter queue of the changefeed.
+	dlq deadLetterQueue
+}
+
+var _ deadLetterQueueSink = (*cloudStorageSink)(nil)
+
+// setDeadLetterQueue implements the deadLetterQueueSink interface.
+func (s *cloudStorageSink) setDeadLetterQueue(dlq deadLetterQueue) {
+	s.dlq = dlq
 }
 
 const sinkCompressionGzip = "gzip"
@@ -446,6 +456,16 @@ func (s *cloudStorageSink) EmitRow(
 		return errors.New(`cannot EmitRow on a closed sink`)
 	}
 
+	// If the changefeed has a dead letter queue, rows which exceed the maximum
+	// file size by themselves are sent to it rather than each written to an
+	// oversized file.
+	if s.dlq != nil && int64(len(value)) > s.targetMaxFileSize {
+		alloc.Release(ctx)
+		return markRowRejected(errors.Newf(
+			`row of %d bytes exceeds the maximum file size of %d bytes`,
+			len(value), s.targetMaxFileSize))
+	}
+
 	file := s.getOrCreateFile(topic, mvcc)
 	file.alloc.Merge(&alloc)
 
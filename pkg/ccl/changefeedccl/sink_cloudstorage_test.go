@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -704,4 +705,32 @@ func TestCloudStorageSink(t *testing.T) {
 			"w1\n",
 		}, slurpDir(t, dir))
 	})
+
+	t.Run(`dead-letter-queue`, func(t *testing.T) {
+		t1 := makeTopic(`t1`)
+		testSpan := roachpb.Span{Key: []byte("a"), EndKey: []byte("b")}
+		sf, err := span.MakeFrontier(testSpan)
+		require.NoError(t, err)
+		timestampOracle := &changeAggregatorLowerBoundOracle{sf: sf}
+		dir := `dead-letter-queue`
+		s, err := makeCloudStorageSink(
+			ctx, sinkURI(dir, 4), 1,
+			settings, opts, timestampOracle, externalStorageFromURI, user, nil,
+		)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, s.Close()) }()
+
+		// Without a dead letter queue, rows larger than the file size are written
+		// to a file of their own.
+		require.NoError(t, s.EmitRow(ctx, t1, noKey, []byte(`large1`), ts(1), ts(1), zeroAlloc))
+		require.Equal(t, []string{"large1\n"}, slurpDir(t, dir))
+
+		// With one, they are rejected so that they are sent to it.
+		s.(*cloudStorageSink).setDeadLetterQueue(&testDeadLetterQueue{})
+		err = s.EmitRow(ctx, t1, noKey, []byte(`large2`), ts(1), ts(1), zeroAlloc)
+		require.True(t, errors.Is(err, errRowRejected), "expected rejected row, got %v", err)
+		require.NoError(t, s.EmitRow(ctx, t1, noKey, []byte(`ok`), ts(1), ts(1), zeroAlloc))
+		require.NoError(t, s.Flush(ctx))
+		require.Equal(t, []string{"large1\n", "ok\n"}, slurpDir(t, dir))
+	})
 }
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -94,6 +95,18 @@ type kafkaSink struct {
 	scratch      bufalloc.ByteAllocator
 	metrics      *sliMetrics
 
+	// dlq, if set, receives the rows rejected by the brokers. It is set before
+	// the first row is emitted and only read by the worker goroutine afterwards.
+	dlq deadLetterQueue
+
+	// txn is only used when the kafka_transactions option is set, in which
+	// case the producer is transactional. It is only accessed from the client
+	// goroutine.
+	txn struct {
+		enabled bool
+		open    bool
+	}
+
 	// Only synchronized between the client goroutine and the worker goroutine.
 	mu struct {
 		syncutil.Mutex
@@ -118,6 +131,12 @@ type saramaConfig struct {
 	RequiredAcks string `json:",omitempty"`
 
 	Version string `json:",omitempty"`
+
+	// MaxMessageBytes, if set, is the largest message the sink will emit; it
+	// should match the message.max.bytes setting of the brokers. Larger
+	// messages are rejected, and sent to the dead letter queue if one is
+	// configured. See sarama.Config.Producer.MaxMessageBytes
+	MaxMessageBytes int `json:",omitempty"`
 }
 
 func (c saramaConfig) Validate() error {
@@ -193,6 +212,10 @@ func (s *kafkaSink) Dial() error {
 func (s *kafkaSink) Close() error {
 	close(s.stopWorkerCh)
 	s.worker.Wait()
+	// Messages in a transaction which was never committed must not become
+	// visible; they will be re-emitted once the changefeed restarts from its
+	// last checkpoint.
+	s.abortTxn()
 	// If we're shutting down, we don't care what happens to the outstanding
 	// messages, so ignore this error.
 	_ = s.producer.Close()
@@ -207,6 +230,26 @@ type messageMetadata struct {
 	alloc         kvevent.Alloc
 	updateMetrics recordEmittedMessagesCallback
 	mvcc          hlc.Timestamp
+	// topic and updated identify the row in the dead letter queue.
+	topic   string
+	updated hlc.Timestamp
+}
+
+var _ deadLetterQueueSink = (*kafkaSink)(nil)
+
+// setDeadLetterQueue implements the deadLetterQueueSink interface.
+func (s *kafkaSink) setDeadLetterQueue(dlq deadLetterQueue) {
+	s.dlq = dlq
+}
+
+// isKafkaRowRejection returns whether err, returned by the brokers for a
+// message, means that the message itself was refused, in which case sending it
+// again would fail in the same way.
+func isKafkaRowRejection(err error) bool {
+	return errors.Is(err, sarama.ErrMessageSizeTooLarge) ||
+		errors.Is(err, sarama.ErrInvalidMessageSize) ||
+		errors.Is(err, sarama.ErrInvalidMessage) ||
+		errors.Is(err, sarama.ErrInvalidRecord)
 }
 
 // EmitRow implements the Sink interface.
@@ -222,11 +265,27 @@ func (s *kafkaSink) EmitRow(
 		return errors.Errorf(`cannot emit to undeclared topic: %s`, topicDescr.GetName())
 	}
 
+	// Kafka rejects messages larger than the configured maximum. Check this
+	// before handing the message to the producer, where the failure would only
+	// be reported asynchronously and fail the whole changefeed.
+	if size := len(key) + len(value); s.kafkaCfg != nil && size > s.kafkaCfg.Producer.MaxMessageBytes {
+		alloc.Release(ctx)
+		return markRowRejected(errors.Newf(
+			`kafka message of %d bytes exceeds the maximum message size of %d bytes`,
+			size, s.kafkaCfg.Producer.MaxMessageBytes))
+	}
+
 	msg := &sarama.ProducerMessage{
-		Topic:    topic,
-		Key:      sarama.ByteEncoder(key),
-		Value:    sarama.ByteEncoder(value),
-		Metadata: messageMetadata{alloc: alloc, mvcc: mvcc, updateMetrics: s.metrics.recordEmittedMessages()},
+		Topic: topic,
+		Key:   sarama.ByteEncoder(key),
+		Value: sarama.ByteEncoder(value),
+		Metadata: messageMetadata{
+			alloc:         alloc,
+			mvcc:          mvcc,
+			updateMetrics: s.metrics.recordEmittedMessages(),
+			topic:         topicDescr.GetName(),
+			updated:       updated,
+		},
 	}
 	return s.emitMessage(ctx, msg)
 }
@@ -303,7 +362,7 @@ func (s *kafkaSink) Flush(ctx context.Context) error {
 	s.mu.Unlock()
 
 	if immediateFlush {
-		return flushErr
+		return s.maybeAbortTxn(flushErr)
 	}
 
 	if log.V(1) {
@@ -317,7 +376,68 @@ func (s *kafkaSink) Flush(ctx context.Context) error {
 		flushErr := s.mu.flushErr
 		s.mu.flushErr = nil
 		s.mu.Unlock()
-		return flushErr
+		return s.maybeAbortTxn(flushErr)
+	}
+}
+
+// CommitTxn implements the transactionalSink interface. If the sink is
+// transactional, it flushes the sink and commits the open transaction, if any,
+// making every message emitted since the previous commit visible to
+// read_committed consumers.
+func (s *kafkaSink) CommitTxn(ctx context.Context) error {
+	if !s.txn.enabled {
+		return nil
+	}
+	if err := s.Flush(ctx); err != nil {
+		return err
+	}
+	if !s.txn.open {
+		return nil
+	}
+	s.txn.open = false
+	if err := s.producer.CommitTxn(); err != nil {
+		// A failed commit leaves the transaction in an unknown state; abort it so
+		// that the producer can be reused once the changefeed retries.
+		s.txn.open = true
+		s.abortTxn()
+		return errors.Wrap(err, `committing kafka transaction`)
+	}
+	return nil
+}
+
+// maybeBeginTxn opens a kafka transaction if the sink is transactional and no
+// transaction is currently open.
+func (s *kafkaSink) maybeBeginTxn() error {
+	if !s.txn.enabled || s.txn.open {
+		return nil
+	}
+	if err := s.producer.BeginTxn(); err != nil {
+		return errors.Wrap(err, `beginning kafka transaction`)
+	}
+	s.txn.open = true
+	return nil
+}
+
+// maybeAbortTxn is called once every inflight message has been acknowledged
+// (or failed) during a Flush. A transaction missing some of its messages must
+// never be committed, so it is aborted if any of them failed; the changefeed
+// then restarts from its last checkpoint and emits them again.
+func (s *kafkaSink) maybeAbortTxn(flushErr error) error {
+	if flushErr != nil {
+		s.abortTxn()
+	}
+	return flushErr
+}
+
+// abortTxn aborts the open transaction, if any. Errors are only logged since
+// the brokers abort transactions which time out anyway.
+func (s *kafkaSink) abortTxn() {
+	if !s.txn.open {
+		return
+	}
+	s.txn.open = false
+	if err := s.producer.AbortTxn(); err != nil {
+		log.Warningf(s.ctx, `aborting kafka transaction: %v`, err)
 	}
 }
 
@@ -333,6 +453,9 @@ func (s *kafkaSink) startInflightMessage(ctx context.Context) error {
 }
 
 func (s *kafkaSink) emitMessage(ctx context.Context, msg *sarama.ProducerMessage) error {
+	if err := s.maybeBeginTxn(); err != nil {
+		return err
+	}
 	if err := s.startInflightMessage(ctx); err != nil {
 		return err
 	}
@@ -365,6 +488,12 @@ func (s *kafkaSink) workerLoop() {
 		if m, ok := ackMsg.Metadata.(messageMetadata); ok {
 			if ackError == nil {
 				m.updateMetrics(1, m.mvcc, ackMsg.Key.Length()+ackMsg.Value.Length(), sinkDoesNotCompress)
+			} else if s.dlq != nil && !s.txn.enabled && isKafkaRowRejection(ackError) {
+				// Rows rejected by the brokers are sent to the dead letter queue
+				// rather than failing the changefeed. This isn't possible when
+				// the sink is transactional: the brokers then abort the whole
+				// transaction, which must be emitted again.
+				ackError = s.sendToDeadLetterQueue(ackMsg, m, ackError)
 			}
 			m.alloc.Release(s.ctx)
 		}
@@ -383,6 +512,23 @@ func (s *kafkaSink) workerLoop() {
 	}
 }
 
+// sendToDeadLetterQueue adds a message rejected by the brokers to the dead
+// letter queue. It returns the error the sink should fail with, if any.
+func (s *kafkaSink) sendToDeadLetterQueue(
+	msg *sarama.ProducerMessage, m messageMetadata, cause error,
+) error {
+	key, err := msg.Key.Encode()
+	if err != nil {
+		return err
+	}
+	value, err := msg.Value.Encode()
+	if err != nil {
+		return err
+	}
+	log.VEventf(s.ctx, 2, "sending row of %s to the dead letter queue: %v", m.topic, cause)
+	return s.dlq.Add(s.ctx, m.topic, key, value, m.updated, markRowRejected(cause))
+}
+
 func (s *kafkaSink) Topics() []string {
 	var topics []string
 	for _, topic := range s.topics {
@@ -454,6 +600,9 @@ func (c *saramaConfig) Apply(kafka *sarama.Config) error {
 	// had enough resources to ingest and process this message, then sarama shouldn't
 	// get in a way.  Set this limit to be just a bit under maximum request size.
 	kafka.Producer.MaxMessageBytes = int(sarama.MaxRequestSize - 1)
+	if c.MaxMessageBytes > 0 {
+		kafka.Producer.MaxMessageBytes = c.MaxMessageBytes
+	}
 
 	kafka.Producer.Flush.Bytes = c.Flush.Bytes
 	kafka.Producer.Flush.Messages = c.Flush.Messages
@@ -580,6 +729,19 @@ func buildKafkaConfig(u sinkURL, opts map[string]string) (*sarama.Config, error)
 	config.ClientID = `CockroachDB`
 	config.Producer.Return.Successes = true
 	config.Producer.Partitioner = newChangefeedPartitioner
+	if _, transactional := opts[changefeedbase.OptKafkaTransactions]; transactional {
+		// Kafka transactions are built on top of the idempotent producer, which
+		// imposes these settings.
+		config.Producer.Idempotent = true
+		config.Producer.RequiredAcks = sarama.WaitForAll
+		config.Net.MaxOpenRequests = 1
+		// Transactions stay open until the changefeed checkpoints, which happens
+		// every min_checkpoint_frequency; they must not time out in between.
+		if freq, err := time.ParseDuration(opts[changefeedbase.OptMinCheckpointFrequency]); err == nil &&
+			2*freq > config.Producer.Transaction.Timeout {
+			config.Producer.Transaction.Timeout = 2 * freq
+		}
+	}
 
 	if dialConfig.tlsEnabled {
 		config.Net.TLS.Enable = true
@@ -643,14 +805,55 @@ func buildKafkaConfig(u sinkURL, opts map[string]string) (*sarama.Config, error)
 	if err := saramaCfg.Apply(config); err != nil {
 		return nil, errors.Wrap(err, "failed to apply kafka client configuration")
 	}
+
+	if config.Producer.Idempotent {
+		if config.Producer.RequiredAcks != sarama.WaitForAll {
+			return nil, errors.Errorf(
+				`%s requires RequiredAcks to be "ALL"`, changefeedbase.OptKafkaTransactions)
+		}
+		if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+			return nil, errors.Errorf(
+				`%s requires kafka version 0.11.0.0 or later`, changefeedbase.OptKafkaTransactions)
+		}
+	}
 	return config, nil
 }
 
+// The owners of the sinks of a changefeed, used to derive the transactional
+// IDs of their kafka producers; see kafkaTransactionalID.
+const (
+	frontierSinkOwner = `frontier`
+	canarySinkOwner   = `canary`
+)
+
+// aggregatorSinkOwner returns the owner of the sink of the change aggregator
+// running on the given SQL instance. Changefeeds are planned with at most one
+// change aggregator per SQL instance.
+func aggregatorSinkOwner(instanceID base.SQLInstanceID) string {
+	return fmt.Sprintf(`aggregator-%d`, instanceID)
+}
+
+// kafkaTransactionalID returns the transactional ID of the producer used by the
+// kafka sink of the given owner in a changefeed. Brokers allow a single
+// producer per transactional ID: when a changefeed restarts, the producer of
+// each of its sinks fences off the one of its previous incarnation, which may
+// still be running on a partitioned node, and aborts the transaction it left
+// open. The ID must therefore be stable across replanning, and is derived from
+// the job and from the SQL instance running the change aggregator, rather than
+// from the DistSQL processor ID, which changes whenever the flow is planned
+// again. If a replanned changefeed no longer runs an aggregator on some
+// instance, the transaction left open by that instance's previous aggregator
+// is aborted by the brokers once the transaction timeout expires.
+func kafkaTransactionalID(jobID jobspb.JobID, sinkOwner string) string {
+	return fmt.Sprintf(`crdb-changefeed-%d-%s`, jobID, sinkOwner)
+}
+
 func makeKafkaSink(
 	ctx context.Context,
 	u sinkURL,
 	targets []jobspb.ChangefeedTargetSpecification,
 	opts map[string]string,
+	transactionalID string,
 	m *sliMetrics,
 ) (Sink, error) {
 	kafkaTopicPrefix := u.consumeParam(changefeedbase.SinkParamTopicPrefix)
@@ -663,6 +866,10 @@ func makeKafkaSink(
 	if err != nil {
 		return nil, err
 	}
+	_, transactional := opts[changefeedbase.OptKafkaTransactions]
+	if transactional {
+		config.Producer.Transaction.ID = transactionalID
+	}
 
 	sink := &kafkaSink{
 		ctx:            ctx,
@@ -671,6 +878,7 @@ func makeKafkaSink(
 		topics:         makeTopicsMap(kafkaTopicPrefix, kafkaTopicName, targets),
 		metrics:        m,
 	}
+	sink.txn.enabled = transactional
 
 	if unknownParams := u.remainingQueryParams(); len(unknownParams) > 0 {
 		return nil, errors.Errorf(
@@ -9,6 +9,7 @@
 package changefeedccl
 
 import (
+	"bytes"
 	"context"
 	"net/url"
 	"strconv"
@@ -68,6 +69,21 @@ func (p *asyncProducerMock) Close() error {
 	close(p.errorsCh)
 	return nil
 }
+func (p *asyncProducerMock) IsTransactional() bool { return false }
+func (p *asyncProducerMock) TxnStatus() sarama.ProducerTxnStatusFlag {
+	return sarama.ProducerTxnFlagReady
+}
+func (p *asyncProducerMock) BeginTxn() error  { panic(`unimplemented`) }
+func (p *asyncProducerMock) CommitTxn() error { panic(`unimplemented`) }
+func (p *asyncProducerMock) AbortTxn() error  { panic(`unimplemented`) }
+func (p *asyncProducerMock) AddOffsetsToTxn(
+	map[string][]*sarama.PartitionOffsetMetadata, string,
+) error {
+	panic(`unimplemented`)
+}
+func (p *asyncProducerMock) AddMessageToTxn(*sarama.ConsumerMessage, string, *string) error {
+	panic(`unimplemented`)
+}
 
 // consumeAndSucceed consumes input messages and sends them to successes channel.
 // Returns function that must be called to stop this consumer
@@ -145,6 +161,52 @@ func (p *asyncProducerMock) outstanding() int {
 	return len(p.mu.outstanding)
 }
 
+// txnProducerMock is an asyncProducerMock which also records the kafka
+// transaction operations performed on it.
+type txnProducerMock struct {
+	*asyncProducerMock
+	commitErr error
+	txn       struct {
+		syncutil.Mutex
+		began, committed, aborted int
+	}
+}
+
+var _ sarama.AsyncProducer = (*txnProducerMock)(nil)
+
+func (p *txnProducerMock) IsTransactional() bool { return true }
+
+func (p *txnProducerMock) BeginTxn() error {
+	p.txn.Lock()
+	defer p.txn.Unlock()
+	p.txn.began++
+	return nil
+}
+
+func (p *txnProducerMock) CommitTxn() error {
+	p.txn.Lock()
+	defer p.txn.Unlock()
+	if p.commitErr != nil {
+		return p.commitErr
+	}
+	p.txn.committed++
+	return nil
+}
+
+func (p *txnProducerMock) AbortTxn() error {
+	p.txn.Lock()
+	defer p.txn.Unlock()
+	p.txn.aborted++
+	return nil
+}
+
+// txnCounts returns the number of begun, committed and aborted transactions.
+func (p *txnProducerMock) txnCounts() [3]int {
+	p.txn.Lock()
+	defer p.txn.Unlock()
+	return [3]int{p.txn.began, p.txn.committed, p.txn.aborted}
+}
+
 func topic(name string) tableDescriptorTopic {
 	return tableDescriptorTopic{tabledesc.NewBuilder(&descpb.TableDescriptor{Name: name}).BuildImmutableTable()}
 }
@@ -683,3 +745,306 @@ func TestKafkaSinkTracksMemory(t *testing.T) {
 	require.EqualValues(t, 0, p.outstanding())
 	require.EqualValues(t, 0, pool.used())
 }
+
+func TestKafkaSinkRejectsLargeMessages(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	p := newAsyncProducerMock(unbuffered)
+	stopConsume := p.consume()
+
+	sink, cleanup := makeTestKafkaSink(
+		t, noTopicPrefix, defaultTopicName, p, "t")
+	defer func() {
+		stopConsume()
+		cleanup()
+	}()
+
+	sink.kafkaCfg = sarama.NewConfig()
+	cfg := defaultSaramaConfig()
+	cfg.MaxMessageBytes = 16
+	require.NoError(t, cfg.Apply(sink.kafkaCfg))
+
+	testTopic := topic(`t`)
+	var pool testAllocPool
+
+	// A message larger than MaxMessageBytes is rejected synchronously, and its
+	// memory is released.
+	err := sink.EmitRow(ctx, testTopic, []byte(`key`), bytes.Repeat([]byte(`v`), 16),
+		zeroTS, zeroTS, pool.alloc())
+	require.True(t, errors.Is(err, errRowRejected), "expected rejected row, got %v", err)
+	require.EqualValues(t, 0, pool.used())
+
+	// Smaller messages are still emitted.
+	require.NoError(t, sink.EmitRow(ctx, testTopic, []byte(`key`), []byte(`value`),
+		zeroTS, zeroTS, pool.alloc()))
+	p.acknowledge(1, p.successesCh)
+	require.NoError(t, sink.Flush(ctx))
+	require.EqualValues(t, 0, pool.used())
+}
+
+// testDeadLetterQueue is an in-memory deadLetterQueue.
+type testDeadLetterQueue struct {
+	syncutil.Mutex
+	entries []deadLetterEntry
+}
+
+var _ deadLetterQueue = (*testDeadLetterQueue)(nil)
+
+func (q *testDeadLetterQueue) Add(
+	_ context.Context, topic string, key, value []byte, updated hlc.Timestamp, cause error,
+) error {
+	q.Lock()
+	defer q.Unlock()
+	q.entries = append(q.entries, makeDeadLetterEntry(topic, key, value, updated, cause))
+	return nil
+}
+
+func (q *testDeadLetterQueue) Flush(context.Context) (int, error) { return 0, nil }
+func (q *testDeadLetterQueue) Close() error                       { return nil }
+
+// keys returns the keys of the entries added to the queue.
+func (q *testDeadLetterQueue) keys() []string {
+	q.Lock()
+	defer q.Unlock()
+	keys := make([]string, len(q.entries))
+	for i, e := range q.entries {
+		keys[i] = string(e.Key)
+	}
+	return keys
+}
+
+func TestKafkaSinkDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	p := newAsyncProducerMock(unbuffered)
+	stopConsume := p.consume()
+
+	sink, cleanup := makeTestKafkaSink(
+		t, noTopicPrefix, defaultTopicName, p, "t")
+	defer func() {
+		stopConsume()
+		cleanup()
+	}()
+	dlq := &testDeadLetterQueue{}
+	sink.setDeadLetterQueue(dlq)
+
+	var pool testAllocPool
+	emit := func(keys ...string) {
+		t.Helper()
+		for _, k := range keys {
+			require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(k), []byte(`v`),
+				zeroTS, zeroTS, pool.alloc()))
+		}
+		testutils.SucceedsSoon(t, func() error {
+			if n := p.outstanding(); n != len(keys) {
+				return errors.Newf("expected %d outstanding messages, found %d", len(keys), n)
+			}
+			return nil
+		})
+	}
+	fail := func(err error) {
+		p.mu.Lock()
+		failed := p.mu.outstanding[0]
+		p.mu.outstanding = p.mu.outstanding[1:]
+		p.mu.Unlock()
+		p.errorsCh <- &sarama.ProducerError{Msg: failed, Err: err}
+	}
+
+	// Messages rejected by the brokers are sent to the dead letter queue.
+	emit(`a`, `b`)
+	fail(sarama.ErrMessageSizeTooLarge)
+	p.acknowledge(1, p.successesCh)
+	require.NoError(t, sink.Flush(ctx))
+	require.Equal(t, []string{`a`}, dlq.keys())
+	require.EqualValues(t, 0, pool.used())
+
+	// Other errors still fail the sink.
+	emit(`c`)
+	fail(errors.New("boom"))
+	require.Regexp(t, "boom", sink.Flush(ctx))
+	require.Equal(t, []string{`a`}, dlq.keys())
+}
+
+func TestKafkaSinkTransactions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	p := &txnProducerMock{asyncProducerMock: newAsyncProducerMock(unbuffered)}
+	stopConsume := p.consume()
+
+	sink, _ := makeTestKafkaSink(
+		t, noTopicPrefix, defaultTopicName, p, "t")
+	sink.txn.enabled = true
+	emit := func() {
+		t.Helper()
+		require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`k`), []byte(`v`), zeroTS, zeroTS, zeroAlloc))
+	}
+
+	// Committing without emitting anything does not open a transaction.
+	require.NoError(t, sink.CommitTxn(ctx))
+	require.Equal(t, [3]int{0, 0, 0}, p.txnCounts())
+
+	// All messages emitted between two commits are part of one transaction,
+	// regardless of the flushes in between.
+	for i := 0; i < 3; i++ {
+		emit()
+	}
+	require.Equal(t, [3]int{1, 0, 0}, p.txnCounts())
+	p.acknowledge(3, p.successesCh)
+	require.NoError(t, sink.Flush(ctx))
+	require.Equal(t, [3]int{1, 0, 0}, p.txnCounts())
+	emit()
+	p.acknowledge(1, p.successesCh)
+	require.NoError(t, sink.CommitTxn(ctx))
+	require.Equal(t, [3]int{1, 1, 0}, p.txnCounts())
+
+	// A failed message aborts the transaction.
+	emit()
+	emit()
+	testutils.SucceedsSoon(t, func() error {
+		if n := p.outstanding(); n != 2 {
+			return errors.Newf("expected 2 outstanding messages, found %d", n)
+		}
+		return nil
+	})
+	p.mu.Lock()
+	failed := p.mu.outstanding[0]
+	p.mu.outstanding = p.mu.outstanding[1:]
+	p.mu.Unlock()
+	p.errorsCh <- &sarama.ProducerError{Msg: failed, Err: errors.New("boom")}
+	p.acknowledge(1, p.successesCh)
+	require.Regexp(t, "boom", sink.Flush(ctx))
+	require.Equal(t, [3]int{2, 1, 1}, p.txnCounts())
+
+	// A failed commit aborts the transaction as well.
+	p.commitErr = errors.New("fenced")
+	emit()
+	p.acknowledge(1, p.successesCh)
+	require.Regexp(t, "fenced", sink.CommitTxn(ctx))
+	require.Equal(t, [3]int{3, 1, 2}, p.txnCounts())
+
+	// Closing the sink aborts any transaction which was never committed.
+	p.commitErr = nil
+	emit()
+	stopConsume()
+	require.NoError(t, sink.Close())
+	require.Equal(t, [3]int{4, 1, 3}, p.txnCounts())
+}
+
+// TestKafkaSinkTransactionsBroker runs a transactional kafka sink against a
+// mock broker speaking the kafka protocol, and checks the transactional
+// requests received by the broker.
+func TestKafkaSinkTransactionsBroker(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const topicName = `t`
+	txnID := kafkaTransactionalID(jobspb.JobID(42), aggregatorSinkOwner(3))
+	require.Equal(t, `crdb-changefeed-42-aggregator-3`, txnID)
+
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"ApiVersionsRequest": sarama.NewMockApiVersionsResponse(t),
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetController(broker.BrokerID()).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topicName, 0, broker.BrokerID()),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(t).
+			SetCoordinator(sarama.CoordinatorTransaction, txnID, broker),
+		"InitProducerIDRequest": sarama.NewMockWrapper(&sarama.InitProducerIDResponse{
+			ProducerID: 1,
+		}),
+		"AddPartitionsToTxnRequest": sarama.NewMockWrapper(&sarama.AddPartitionsToTxnResponse{
+			Errors: map[string][]*sarama.PartitionError{topicName: {{Partition: 0}}},
+		}),
+		"ProduceRequest": sarama.NewMockProduceResponse(t).
+			SetVersion(3).
+			SetError(topicName, 0, sarama.ErrNoError),
+		"EndTxnRequest": sarama.NewMockWrapper(&sarama.EndTxnResponse{}),
+	})
+
+	u, err := url.Parse(`kafka://` + broker.Addr())
+	require.NoError(t, err)
+	opts := map[string]string{
+		changefeedbase.OptKafkaTransactions: ``,
+		changefeedbase.OptKafkaSinkConfig:   `{"Version": "0.11.0.0"}`,
+	}
+	s, err := makeKafkaSink(ctx, sinkURL{URL: u}, makeChangefeedTargets(topicName), opts,
+		txnID, nil /* m */)
+	require.NoError(t, err)
+	sink := s.(*kafkaSink)
+	require.NoError(t, sink.Dial())
+
+	// endTxns returns the outcome of the EndTxn requests received by the broker.
+	endTxns := func() (committed []bool) {
+		for _, rr := range broker.History() {
+			if req, ok := rr.Request.(*sarama.EndTxnRequest); ok {
+				require.Equal(t, txnID, req.TransactionalID)
+				committed = append(committed, req.TransactionResult)
+			}
+		}
+		return committed
+	}
+
+	// The producer registers its transactional ID with the broker on startup,
+	// which fences off previous producers with the same ID.
+	var initialized bool
+	for _, rr := range broker.History() {
+		if req, ok := rr.Request.(*sarama.InitProducerIDRequest); ok {
+			require.NotNil(t, req.TransactionalID)
+			require.Equal(t, txnID, *req.TransactionalID)
+			initialized = true
+		}
+	}
+	require.True(t, initialized)
+
+	emit := func(key string) {
+		t.Helper()
+		require.NoError(t, sink.EmitRow(ctx, topic(topicName), []byte(key), []byte(`v`),
+			zeroTS, zeroTS, zeroAlloc))
+	}
+
+	// Flushing does not commit the transaction; committing does.
+	emit(`a`)
+	emit(`b`)
+	require.NoError(t, sink.Flush(ctx))
+	require.Empty(t, endTxns())
+	require.NoError(t, sink.CommitTxn(ctx))
+	require.Equal(t, []bool{true}, endTxns())
+
+	// Closing the sink aborts the open transaction.
+	emit(`c`)
+	require.NoError(t, sink.Flush(ctx))
+	require.NoError(t, sink.Close())
+	require.Equal(t, []bool{true, false}, endTxns())
+}
+
+func TestKafkaTransactionsConfig(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	u, err := url.Parse(`kafka://localhost:9092`)
+	require.NoError(t, err)
+
+	opts := map[string]string{changefeedbase.OptKafkaTransactions: ``}
+	cfg, err := buildKafkaConfig(sinkURL{URL: u}, opts)
+	require.NoError(t, err)
+	require.True(t, cfg.Producer.Idempotent)
+	require.Equal(t, sarama.WaitForAll, cfg.Producer.RequiredAcks)
+	require.Equal(t, 1, cfg.Net.MaxOpenRequests)
+
+	opts[changefeedbase.OptKafkaSinkConfig] = `{"RequiredAcks": "ONE"}`
+	_, err = buildKafkaConfig(sinkURL{URL: u}, opts)
+	require.Regexp(t, `kafka_transactions requires RequiredAcks to be "ALL"`, err)
+
+	opts[changefeedbase.OptKafkaSinkConfig] = `{"Version": "0.10.2.0"}`
+	_, err = buildKafkaConfig(sinkURL{URL: u}, opts)
+	require.Regexp(t, `kafka_transactions requires kafka version 0.11.0.0 or later`, err)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/cockroach/pkg/util/system"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
@@ -81,6 +82,17 @@ type webhookSink struct {
 	exitWorkers func() // Signaled to shut down all workers.
 	eventsChans []chan []messagePayload
 	metrics     *sliMetrics
+
+	// dlq, if set, receives the rows rejected by the endpoint. It is set before
+	// the first row is emitted.
+	dlq deadLetterQueue
+}
+
+var _ deadLetterQueueSink = (*webhookSink)(nil)
+
+// setDeadLetterQueue implements the deadLetterQueueSink interface.
+func (s *webhookSink) setDeadLetterQueue(dlq deadLetterQueue) {
+	s.dlq = dlq
 }
 
 type webhookSinkPayload struct {
@@ -131,6 +143,9 @@ type messagePayload struct {
 	alloc    kvevent.Alloc
 	emitTime time.Time
 	mvcc     hlc.Timestamp
+	// topic and updated identify the row in the dead letter queue.
+	topic   TopicDescriptor
+	updated hlc.Timestamp
 }
 
 // webhookMessage contains either messagePayload or a flush request.
@@ -571,27 +586,73 @@ func (s *webhookSink) workerLoop(workerIndex int) {
 				continue
 			}
 
-			encoded, err := encodePayloadWebhook(msgs)
-			if err != nil {
+			if err := s.sendBatch(msgs); err != nil {
 				s.exitWorkersWithError(err)
 				return
 			}
-			if err := s.sendMessageWithRetries(s.workerCtx, encoded.data); err != nil {
-				s.exitWorkersWithError(err)
-				return
+		}
+	}
+}
+
+// sendBatch sends a batch of messages to the endpoint. If the endpoint rejects
+// the batch and the changefeed has a dead letter queue, the messages are sent
+// one by one and those which are rejected are added to the dead letter queue.
+func (s *webhookSink) sendBatch(msgs []messagePayload) error {
+	encoded, err := encodePayloadWebhook(msgs)
+	if err != nil {
+		return err
+	}
+	defer encoded.alloc.Release(s.workerCtx)
+	err = s.sendMessageWithRetries(s.workerCtx, encoded.data)
+	if err == nil {
+		s.metrics.recordEmittedBatch(
+			encoded.emitTime, len(msgs), encoded.mvcc, len(encoded.data), sinkDoesNotCompress)
+		return nil
+	}
+	if s.dlq == nil || !errors.Is(err, errRowRejected) {
+		return err
+	}
+	if len(msgs) > 1 {
+		for i := range msgs {
+			if err := s.sendBatch(msgs[i : i+1]); err != nil {
+				return err
 			}
-			encoded.alloc.Release(s.workerCtx)
-			s.metrics.recordEmittedBatch(
-				encoded.emitTime, len(msgs), encoded.mvcc, len(encoded.data), sinkDoesNotCompress)
 		}
+		return nil
 	}
+	m := msgs[0]
+	log.VEventf(s.workerCtx, 2, "sending row of %s to the dead letter queue: %v", m.topic.GetName(), err)
+	return s.dlq.Add(s.workerCtx, m.topic.GetName(), m.key, m.val, m.updated, err)
 }
 
 func (s *webhookSink) sendMessageWithRetries(ctx context.Context, reqBody []byte) error {
+	// Rejections are not retried since the endpoint would refuse the payload
+	// again.
+	var rejected error
 	requestFunc := func() error {
-		return s.sendMessage(ctx, reqBody)
+		err := s.sendMessage(ctx, reqBody)
+		if errors.Is(err, errRowRejected) {
+			rejected = err
+			return nil
+		}
+		return err
+	}
+	if err := retry.WithMaxAttempts(ctx, s.retryCfg, s.retryCfg.MaxRetries+1, requestFunc); err != nil {
+		return err
+	}
+	return rejected
+}
+
+// isWebhookRejection returns whether an HTTP status returned by the endpoint
+// means that it refused the payload itself, in which case sending it again
+// would fail in the same way.
+func isWebhookRejection(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return true
+	default:
+		return false
 	}
-	return retry.WithMaxAttempts(ctx, s.retryCfg, s.retryCfg.MaxRetries+1, requestFunc)
 }
 
 func (s *webhookSink) sendMessage(ctx context.Context, reqBody []byte) error {
@@ -616,7 +677,11 @@ func (s *webhookSink) sendMessage(ctx context.Context, reqBody []byte) error {
 		if err != nil {
 			return errors.Wrapf(err, "failed to read body for HTTP response with status: %d", res.StatusCode)
 		}
-		return fmt.Errorf("%s: %s", res.Status, string(resBody))
+		err = fmt.Errorf("%s: %s", res.Status, string(resBody))
+		if isWebhookRejection(res.StatusCode) {
+			return markRowRejected(err)
+		}
+		return err
 	}
 	return nil
 }
@@ -676,6 +741,8 @@ func (s *webhookSink) EmitRow(
 			alloc:    alloc,
 			emitTime: timeutil.Now(),
 			mvcc:     mvcc,
+			topic:    topic,
+			updated:  updated,
 		}}:
 	}
 	return nil
@@ -634,3 +634,51 @@ func TestWebhookSinkShutsDownOnError(t *testing.T) {
 		webhookSinkTestfn(i)
 	}
 }
+
+func TestWebhookSinkDeadLetterQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	cert, certEncoded, err := cdctest.NewCACertBase64Encoded()
+	require.NoError(t, err)
+	sinkDest, err := cdctest.StartMockWebhookSink(cert)
+	require.NoError(t, err)
+	defer sinkDest.Close()
+
+	sinkDestHost, err := url.Parse(sinkDest.URL())
+	require.NoError(t, err)
+	params := sinkDestHost.Query()
+	params.Set(changefeedbase.SinkParamCACert, certEncoded)
+	sinkDestHost.RawQuery = params.Encode()
+
+	opts := getGenericWebhookSinkOptions()
+	opts[changefeedbase.OptWebhookSinkConfig] =
+		`{"Flush": {"Messages": 2, "Frequency": "1h"}, "Retry": {"Backoff": "5ms"}}`
+	details := jobspb.ChangefeedDetails{
+		SinkURI: fmt.Sprintf("webhook-%s", sinkDestHost.String()),
+		Opts:    opts,
+	}
+	sinkSrc, err := setupWebhookSinkWithDetails(ctx, details, 1 /* parallelism */, timeutil.DefaultTimeSource{})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sinkSrc.Close()) }()
+	dlq := &testDeadLetterQueue{}
+	sinkSrc.(*webhookSink).setDeadLetterQueue(dlq)
+
+	// The endpoint rejects the batch, then accepts the first row and rejects
+	// the second one when they are sent on their own.
+	sinkDest.SetStatusCodes([]int{
+		http.StatusRequestEntityTooLarge, http.StatusOK, http.StatusRequestEntityTooLarge})
+
+	var pool testAllocPool
+	require.NoError(t, sinkSrc.EmitRow(ctx, topic(`foo`), []byte(`[1]`),
+		[]byte(`{"after":{"a":1},"key":[1]}`), zeroTS, zeroTS, pool.alloc()))
+	require.NoError(t, sinkSrc.EmitRow(ctx, topic(`foo`), []byte(`[2]`),
+		[]byte(`{"after":{"a":2},"key":[2]}`), zeroTS, zeroTS, pool.alloc()))
+	require.NoError(t, sinkSrc.Flush(ctx))
+	require.EqualValues(t, 0, pool.used())
+
+	// Rejections are not retried.
+	require.Equal(t, 3, sinkDest.GetNumCalls())
+	require.Equal(t, `{"payload":[{"after":{"a":1},"key":[1]}],"length":1}`, sinkDest.Latest())
+	require.Equal(t, []string{`[2]`}, dlq.keys())
+}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -36,7 +37,7 @@ func (s *instance) ReconfigureTokenBucket(
 	if err := s.checkTenantID(ctx, txn, tenantID); err != nil {
 		return err
 	}
-	h := makeSysTableHelper(ctx, s.executor, txn, tenantID)
+	h := makeSysTableHelper(ctx, s.settings, s.executor, txn, tenantID)
 	state, err := h.readTenantState()
 	if err != nil {
 		return err
@@ -53,6 +54,32 @@ func (s *instance) ReconfigureTokenBucket(
 	return nil
 }
 
+// ReconfigureStorageQuota updates a tenant's storage quota. It is part of the
+// TenantUsageServer interface; see that for more details.
+func (s *instance) ReconfigureStorageQuota(
+	ctx context.Context, txn *kv.Txn, tenantID roachpb.TenantID, quotaBytes int64,
+) error {
+	if !s.settings.Version.IsActive(ctx, clusterversion.TenantStorageQuotas) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use storage quotas",
+			clusterversion.ByKey(clusterversion.TenantStorageQuotas))
+	}
+	if quotaBytes < 0 {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "storage quota cannot be negative")
+	}
+	if err := s.checkTenantID(ctx, txn, tenantID); err != nil {
+		return err
+	}
+	h := makeSysTableHelper(ctx, s.settings, s.executor, txn, tenantID)
+	state, err := h.readTenantState()
+	if err != nil {
+		return err
+	}
+	state.update(s.timeSource.Now())
+	state.StorageQuota = quotaBytes
+	return h.updateTenantState(state)
+}
+
 // checkTenantID verifies that the tenant exists and is active.
 func (s *instance) checkTenantID(
 	ctx context.Context, txn *kv.Txn, tenantID roachpb.TenantID,
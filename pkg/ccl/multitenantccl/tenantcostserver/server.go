@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstorage"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -27,6 +28,9 @@ type instance struct {
 	metrics    Metrics
 	timeSource timeutil.TimeSource
 	settings   *cluster.Settings
+	// tenantStorage, if set, is used to report the storage usage and quota of
+	// the tenant in token bucket responses.
+	tenantStorage *tenantstorage.Tracker
 }
 
 // Note: the "four" in the description comes from
@@ -44,12 +48,14 @@ func newInstance(
 	db *kv.DB,
 	executor *sql.InternalExecutor,
 	timeSource timeutil.TimeSource,
+	tenantStorage *tenantstorage.Tracker,
 ) *instance {
 	res := &instance{
-		db:         db,
-		executor:   executor,
-		timeSource: timeSource,
-		settings:   settings,
+		db:            db,
+		executor:      executor,
+		timeSource:    timeSource,
+		settings:      settings,
+		tenantStorage: tenantStorage,
 	}
 	res.metrics.init()
 	return res
@@ -67,7 +73,8 @@ func init() {
 		settings *cluster.Settings,
 		db *kv.DB,
 		executor *sql.InternalExecutor,
+		tenantStorage *tenantstorage.Tracker,
 	) multitenant.TenantUsageServer {
-		return newInstance(settings, db, executor, timeutil.DefaultTimeSource{})
+		return newInstance(settings, db, executor, timeutil.DefaultTimeSource{}, tenantStorage)
 	}
 }
@@ -250,6 +250,7 @@ func (ts *testState) inspect(t *testing.T, d *datadriven.TestData) string {
 	tenantID := ts.tenantID(t, d)
 	res, err := tenantcostserver.InspectTenantMetadata(
 		context.Background(),
+		ts.s.ClusterSettings(),
 		ts.s.InternalExecutor().(*sql.InternalExecutor),
 		nil, /* txn */
 		roachpb.MakeTenantID(tenantID),
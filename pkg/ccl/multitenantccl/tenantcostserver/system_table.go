@@ -18,8 +18,10 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/ccl/multitenantccl/tenantcostserver/tenanttokenbucket"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -45,6 +47,11 @@ type tenantState struct {
 
 	// Current consumption information.
 	Consumption roachpb.TenantConsumption
+
+	// StorageQuota is the maximum number of logical bytes the tenant can store
+	// (set via the crdb_internal.update_tenant_storage_quota SQL built-in), or 0
+	// if there is no quota.
+	StorageQuota int64
 }
 
 // defaultRefillRate is the default refill rate if it is never configured (via
@@ -114,17 +121,43 @@ type sysTableHelper struct {
 	ex       *sql.InternalExecutor
 	txn      *kv.Txn
 	tenantID roachpb.TenantID
+	// storageQuotas is set if the storage_quota_bytes column exists (i.e. the
+	// TenantStorageQuotas version is active).
+	storageQuotas bool
 }
 
 func makeSysTableHelper(
-	ctx context.Context, ex *sql.InternalExecutor, txn *kv.Txn, tenantID roachpb.TenantID,
+	ctx context.Context,
+	st *cluster.Settings,
+	ex *sql.InternalExecutor,
+	txn *kv.Txn,
+	tenantID roachpb.TenantID,
 ) sysTableHelper {
 	return sysTableHelper{
-		ctx:      ctx,
-		ex:       ex,
-		txn:      txn,
-		tenantID: tenantID,
+		ctx:           ctx,
+		ex:            ex,
+		txn:           txn,
+		tenantID:      tenantID,
+		storageQuotas: st.Version.IsActive(ctx, clusterversion.TenantStorageQuotas),
+	}
+}
+
+// storageQuotaColumn returns the expression used to read the
+// storage_quota_bytes column, which is NULL if the column doesn't exist yet.
+func (h *sysTableHelper) storageQuotaColumn() string {
+	if h.storageQuotas {
+		return "storage_quota_bytes"
 	}
+	return "NULL"
+}
+
+// storageQuotaDatum returns the value stored in the storage_quota_bytes column
+// for the given quota (NULL if there is no quota).
+func storageQuotaDatum(quota int64) tree.Datum {
+	if quota == 0 {
+		return tree.DNull
+	}
+	return tree.NewDInt(tree.DInt(quota))
 }
 
 // readTenantState reads the tenant state from the system table.
@@ -155,7 +188,7 @@ func (h *sysTableHelper) readTenantAndInstanceState(
 	rows, err := h.ex.QueryBufferedEx(
 		h.ctx, "tenant-usage-select", h.txn,
 		sessiondata.NodeUserSessionDataOverride,
-		`SELECT
+		fmt.Sprintf(`SELECT
 		  instance_id,               /* 0 */
 			next_instance_id,          /* 1 */
 			last_update,               /* 2 */
@@ -166,9 +199,10 @@ func (h *sysTableHelper) readTenantAndInstanceState(
 			total_consumption,         /* 7 */
 			instance_lease,            /* 8 */
 			instance_seq,              /* 9 */
-			instance_shares            /* 10 */
+			instance_shares,           /* 10 */
+			%s                         /* 11 */
 		 FROM system.tenant_usage
-		 WHERE tenant_id = $1 AND instance_id IN (0, $2)`,
+		 WHERE tenant_id = $1 AND instance_id IN (0, $2)`, h.storageQuotaColumn()),
 		h.tenantID.ToUint64(),
 		int64(instanceID),
 	)
@@ -197,6 +231,9 @@ func (h *sysTableHelper) readTenantAndInstanceState(
 					return tenantState{}, instanceState{}, err
 				}
 			}
+			if quota := r[11]; quota != tree.DNull {
+				tenant.StorageQuota = int64(tree.MustBeDInt(quota))
+			}
 		} else {
 			// Instance state.
 			instance.Present = true
@@ -217,12 +254,27 @@ func (h *sysTableHelper) updateTenantState(tenant tenantState) error {
 	if err != nil {
 		return err
 	}
+	args := []interface{}{
+		h.tenantID.ToUint64(),                    // $1
+		int64(tenant.FirstInstance),              // $2
+		&tenant.LastUpdate,                       // $3
+		tenant.Bucket.RUBurstLimit,               // $4
+		tenant.Bucket.RURefillRate,               // $5
+		tenant.Bucket.RUCurrent,                  // $6
+		tenant.Bucket.CurrentShareSum,            // $7
+		tree.NewDBytes(tree.DBytes(consumption)), // $8
+	}
+	var quotaCol, quotaVal string
+	if h.storageQuotas {
+		quotaCol, quotaVal = ", storage_quota_bytes", ", $9"
+		args = append(args, storageQuotaDatum(tenant.StorageQuota)) // $9
+	}
 	// Note: it is important that this UPSERT specifies all columns of the
 	// table, to allow it to perform "blind" writes.
 	_, err = h.ex.ExecEx(
 		h.ctx, "tenant-usage-upsert", h.txn,
 		sessiondata.NodeUserSessionDataOverride,
-		`UPSERT INTO system.tenant_usage(
+		fmt.Sprintf(`UPSERT INTO system.tenant_usage(
 		  tenant_id,
 		  instance_id,
 			next_instance_id,
@@ -234,17 +286,10 @@ func (h *sysTableHelper) updateTenantState(tenant tenantState) error {
 			total_consumption,
 			instance_lease,
 			instance_seq,
-			instance_shares
-		 ) VALUES ($1, 0, $2, $3, $4, $5, $6, $7, $8, NULL, NULL, NULL)
-		 `,
-		h.tenantID.ToUint64(),                    // $1
-		int64(tenant.FirstInstance),              // $2
-		&tenant.LastUpdate,                       // $3
-		tenant.Bucket.RUBurstLimit,               // $4
-		tenant.Bucket.RURefillRate,               // $5
-		tenant.Bucket.RUCurrent,                  // $6
-		tenant.Bucket.CurrentShareSum,            // $7
-		tree.NewDBytes(tree.DBytes(consumption)), // $8
+			instance_shares%s
+		 ) VALUES ($1, 0, $2, $3, $4, $5, $6, $7, $8, NULL, NULL, NULL%s)
+		 `, quotaCol, quotaVal),
+		args...,
 	)
 	return err
 }
@@ -257,12 +302,33 @@ func (h *sysTableHelper) updateTenantAndInstanceState(
 	if err != nil {
 		return err
 	}
+	args := []interface{}{
+		h.tenantID.ToUint64(),                    // $1
+		int64(tenant.FirstInstance),              // $2
+		&tenant.LastUpdate,                       // $3
+		tenant.Bucket.RUBurstLimit,               // $4
+		tenant.Bucket.RURefillRate,               // $5
+		tenant.Bucket.RUCurrent,                  // $6
+		tenant.Bucket.CurrentShareSum,            // $7
+		tree.NewDBytes(tree.DBytes(consumption)), // $8
+		int64(instance.ID),                       // $9
+		int64(instance.NextInstance),             // $10
+		&instance.LastUpdate,                     // $11
+		&instance.Lease,                          // $12
+		instance.Seq,                             // $13
+		instance.Shares,                          // $14
+	}
+	var quotaCol, tenantQuotaVal, instanceQuotaVal string
+	if h.storageQuotas {
+		quotaCol, tenantQuotaVal, instanceQuotaVal = ", storage_quota_bytes", ", $15", ", NULL"
+		args = append(args, storageQuotaDatum(tenant.StorageQuota)) // $15
+	}
 	// Note: it is important that this UPSERT specifies all columns of the
 	// table, to allow it to perform "blind" writes.
 	_, err = h.ex.ExecEx(
 		h.ctx, "tenant-usage-insert", h.txn,
 		sessiondata.NodeUserSessionDataOverride,
-		`UPSERT INTO system.tenant_usage(
+		fmt.Sprintf(`UPSERT INTO system.tenant_usage(
 		  tenant_id,
 		  instance_id,
 			next_instance_id,
@@ -274,25 +340,12 @@ func (h *sysTableHelper) updateTenantAndInstanceState(
 			total_consumption,
 			instance_lease,
 			instance_seq,
-			instance_shares
+			instance_shares%s
 		 ) VALUES
-		   ($1, 0,  $2,  $3,  $4,   $5,   $6,   $7,   $8,   NULL, NULL, NULL),
-			 ($1, $9, $10, $11, NULL, NULL, NULL, NULL, NULL, $12,  $13,  $14)
-		 `,
-		h.tenantID.ToUint64(),                    // $1
-		int64(tenant.FirstInstance),              // $2
-		&tenant.LastUpdate,                       // $3
-		tenant.Bucket.RUBurstLimit,               // $4
-		tenant.Bucket.RURefillRate,               // $5
-		tenant.Bucket.RUCurrent,                  // $6
-		tenant.Bucket.CurrentShareSum,            // $7
-		tree.NewDBytes(tree.DBytes(consumption)), // $8
-		int64(instance.ID),                       // $9
-		int64(instance.NextInstance),             // $10
-		&instance.LastUpdate,                     // $11
-		&instance.Lease,                          // $12
-		instance.Seq,                             // $13
-		instance.Shares,                          // $14
+		   ($1, 0,  $2,  $3,  $4,   $5,   $6,   $7,   $8,   NULL, NULL, NULL%s),
+			 ($1, $9, $10, $11, NULL, NULL, NULL, NULL, NULL, $12,  $13,  $14%s)
+		 `, quotaCol, tenantQuotaVal, instanceQuotaVal),
+		args...,
 	)
 	return err
 }
@@ -342,6 +395,10 @@ func (h *sysTableHelper) accomodateNewInstance(tenant *tenantState, instance *in
 	prevInstanceSeq := row[4]
 	prevInstanceShares := row[5]
 
+	var quotaCol, quotaVal string
+	if h.storageQuotas {
+		quotaCol, quotaVal = ", storage_quota_bytes", ", NULL"
+	}
 	// Update the previous instance: its next_instance_id is the new instance.
 	// TODO(radu): consider coalescing this with updateTenantAndInstanceState to
 	// perform a single UPSERT.
@@ -349,7 +406,7 @@ func (h *sysTableHelper) accomodateNewInstance(tenant *tenantState, instance *in
 		h.ctx, "update-next-id", h.txn,
 		sessiondata.NodeUserSessionDataOverride,
 		// Update the previous instance's next_instance_id.
-		`UPSERT INTO system.tenant_usage(
+		fmt.Sprintf(`UPSERT INTO system.tenant_usage(
 		  tenant_id,
 		  instance_id,
 			next_instance_id,
@@ -361,9 +418,9 @@ func (h *sysTableHelper) accomodateNewInstance(tenant *tenantState, instance *in
 			total_consumption,
 			instance_lease,
 			instance_seq,
-			instance_shares
-		 ) VALUES ($1, $2, $3, $4, NULL, NULL, NULL, NULL, NULL, $5, $6, $7)
-		`,
+			instance_shares%s
+		 ) VALUES ($1, $2, $3, $4, NULL, NULL, NULL, NULL, NULL, $5, $6, $7%s)
+		`, quotaCol, quotaVal),
 		h.tenantID.ToUint64(),  // $1
 		int64(prevInstanceID),  // $2
 		int64(instance.ID),     // $3
@@ -560,12 +617,13 @@ func (h *sysTableHelper) checkInvariants() error {
 // and debugging.
 func InspectTenantMetadata(
 	ctx context.Context,
+	st *cluster.Settings,
 	ex *sql.InternalExecutor,
 	txn *kv.Txn,
 	tenantID roachpb.TenantID,
 	timeFormat string,
 ) (string, error) {
-	h := makeSysTableHelper(ctx, ex, txn, tenantID)
+	h := makeSysTableHelper(ctx, st, ex, txn, tenantID)
 	tenant, err := h.readTenantState()
 	if err != nil {
 		return "", err
@@ -592,6 +650,9 @@ func InspectTenantMetadata(
 	)
 	fmt.Fprintf(&buf, "Last update: %s\n", tenant.LastUpdate.Time.Format(timeFormat))
 	fmt.Fprintf(&buf, "First active instance: %d\n", tenant.FirstInstance)
+	if tenant.StorageQuota != 0 {
+		fmt.Fprintf(&buf, "Storage quota: %d bytes\n", tenant.StorageQuota)
+	}
 
 	rows, err := ex.QueryBufferedEx(
 		ctx, "inspect-tenant-state", txn,
@@ -55,7 +55,7 @@ func (s *instance) TokenBucketRequest(
 	if err := s.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
 		*result = roachpb.TokenBucketResponse{}
 
-		h := makeSysTableHelper(ctx, s.executor, txn, tenantID)
+		h := makeSysTableHelper(ctx, s.settings, s.executor, txn, tenantID)
 		tenant, instance, err := h.readTenantAndInstanceState(instanceID)
 		if err != nil {
 			return err
@@ -129,6 +129,11 @@ func (s *instance) TokenBucketRequest(
 	metrics.totalWriteBytes.Update(int64(consumption.WriteBytes))
 	metrics.totalSQLPodsCPUSeconds.Update(consumption.SQLPodsCPUSeconds)
 	metrics.totalPGWireEgressBytes.Update(int64(consumption.PGWireEgressBytes))
+
+	if s.tenantStorage != nil {
+		result.StorageUsageBytes = s.tenantStorage.Usage(tenantID)
+		result.StorageQuotaBytes = s.tenantStorage.Quota(tenantID)
+	}
 	return result
 }
 
@@ -24,6 +24,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/systemconfigwatcher/systemconfigwatchertest"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/distsql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
@@ -91,6 +93,47 @@ func TestTenantCannotSetClusterSetting(t *testing.T) {
 	}
 }
 
+// TestTenantStorageQuota verifies that once a tenant reaches its storage
+// quota, its writes are rejected while deletions are still permitted.
+func TestTenantStorageQuota(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	tc := serverutils.StartNewTestCluster(t, 1, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	tenantID := serverutils.TestTenantID()
+	_, db := serverutils.StartTenant(t, tc.Server(0), base.TestTenantArgs{TenantID: tenantID})
+	defer db.Close()
+	r := sqlutils.MakeSQLRunner(db)
+	r.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	r.Exec(t, `INSERT INTO t VALUES (1, 'a'), (2, 'b')`)
+
+	// Set a quota which the tenant already exceeds.
+	sqlutils.MakeSQLRunner(tc.ServerConn(0)).Exec(t,
+		`SELECT crdb_internal.update_tenant_storage_quota($1, 1)`, tenantID.ToUint64(),
+	)
+
+	// Writes are rejected once the quota and the tenant's usage have been
+	// picked up by the node.
+	testutils.SucceedsSoon(t, func() error {
+		_, err := db.Exec(`UPSERT INTO t VALUES (3, 'c')`)
+		if err == nil {
+			return errors.New("expected write to be rejected")
+		}
+		var pqErr *pq.Error
+		if !errors.As(err, &pqErr) || pgcode.MakeCode(string(pqErr.Code)) != pgcode.DiskFull {
+			return fmt.Errorf("unexpected error: %v", err)
+		}
+		return nil
+	})
+
+	// Deletions are permitted so that the tenant can free up space.
+	r.Exec(t, `DELETE FROM t WHERE k = 1`)
+	r.CheckQueryResults(t, `SELECT k, v FROM t`, [][]string{{"2", "b"}})
+}
+
 func TestTenantCanUseEnterpriseFeatures(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -40,6 +40,10 @@ var encryptionPreamble = []byte("encrypt")
 
 const encryptionSaltSize = 16
 
+// errDecryptionFailed marks the errors returned when a ciphertext fails to
+// authenticate, i.e. when it was encrypted with another key or was altered.
+var errDecryptionFailed = errors.New("decryption failed")
+
 // v1 is just the IV and then one sealed GCM message.
 const encryptionVersionIVPrefix = 1
 
@@ -77,6 +81,12 @@ func AppearsEncrypted(text []byte) bool {
 	return bytes.HasPrefix(text, encryptionPreamble)
 }
 
+// IsDecryptionError returns true if err was returned because a ciphertext could
+// not be decrypted with the given key.
+func IsDecryptionError(err error) bool {
+	return errors.Is(err, errDecryptionFailed)
+}
+
 type encWriter struct {
 	gcm        cipher.AEAD
 	iv         []byte
@@ -242,7 +252,10 @@ func decryptingReader(ciphertext readerAndReaderAt, key []byte) (sstable.Readabl
 			return nil, err
 		}
 		buf, err = gcm.Open(buf[:0], iv, buf, nil)
-		return vfs.NewMemFile(buf), errors.Wrap(err, "failed to decrypt — maybe incorrect key")
+		if err != nil {
+			return vfs.NewMemFile(buf), errors.Mark(errors.Wrap(err, "failed to decrypt — maybe incorrect key"), errDecryptionFailed)
+		}
+		return vfs.NewMemFile(buf), nil
 	}
 	buf := make([]byte, nonceSize, encryptionChunkSizeV2+tagSize+nonceSize)
 	ivScratch := buf[:nonceSize]
@@ -269,7 +282,7 @@ func (r *decryptReader) fill(chunk int64) error {
 	// Decrypt the ciphertext chunk into buf.
 	buf, err := r.g.Open(r.buf[:0], r.chunkIV(chunk), r.buf, nil)
 	if err != nil {
-		return errors.Wrap(err, "failed to decrypt — maybe incorrect key")
+		return errors.Mark(errors.Wrap(err, "failed to decrypt — maybe incorrect key"), errDecryptionFailed)
 	}
 	r.buf = buf
 	r.chunk = chunk
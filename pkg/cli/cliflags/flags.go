@@ -892,6 +892,16 @@ memory that the store may consume, for example:
   --store=type=mem,size=20GiB
   --store=type=mem,size=90%
 
+</PRE>
+The "raft-log-path" field can be used to keep the Raft log of the store's
+replicas in a separate storage engine, preferably on a different device, so
+that writes to the log do not compete with the store's compactions. Once a
+store has been started with a separate Raft log, the field must be specified
+with the same path on every subsequent start, for example:
+<PRE>
+
+  --store=path=/mnt/hda1,raft-log-path=/mnt/ssd01/raft
+
 </PRE>
 Commas are forbidden in all values, since they are used to separate fields.
 Also, if you use equal signs in the file path to a store, you must use the
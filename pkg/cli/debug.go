@@ -1145,7 +1145,7 @@ func removeDeadReplicas(
 			// is likely the right choice. See:
 			//
 			// https://github.com/cockroachdb/cockroach/issues/33007
-			if rep.IsVoterNewConfig() && rep.StoreID > maxLiveVoter {
+			if rep.IsVoterNewConfig() && rep.GetType() != roachpb.WITNESS && rep.StoreID > maxLiveVoter {
 				maxLiveVoter = rep.StoreID
 			}
 		}
@@ -1517,6 +1517,7 @@ var DebugCommandsRequiringEncryption = []*cobra.Command{
 	debugUnsafeRemoveDeadReplicasCmd,
 	debugRecoverCollectInfoCmd,
 	debugRecoverExecuteCmd,
+	debugRepairRangeDiffCmd,
 }
 
 // Debug commands. All commands in this list to be added to root debug command.
@@ -1546,6 +1547,7 @@ var debugCmds = []*cobra.Command{
 	debugResetQuorumCmd,
 	debugSendKVBatchCmd,
 	debugRecoverCmd,
+	debugRepairRangeCmd,
 }
 
 // DebugCmd is the root of all debug commands. Exported to allow modification by CCL code.
@@ -1663,6 +1665,10 @@ func init() {
 	f.VarP(&debugRecoverExecuteOpts.confirmAction, cliflags.ConfirmActions.Name, cliflags.ConfirmActions.Shorthand,
 		cliflags.ConfirmActions.Usage())
 
+	f = debugRepairRangeApplyCmd.Flags()
+	f.IntVar(&debugRepairRangeApplyOpts.authoritativeStoreID, "authoritative-store", 0,
+		"ID of the store holding the replica whose data is kept")
+
 	f = debugMergeLogsCmd.Flags()
 	f.Var(flagutil.Time(&debugMergeLogsOpts.from), "from",
 		"time before which messages should be filtered")
@@ -0,0 +1,194 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+)
+
+// debugRepairRangeCmd is the root of the range repair commands.
+var debugRepairRangeCmd = &cobra.Command{
+	Use:   "repair-range [command]",
+	Short: "commands to repair a range whose replicas have diverged",
+	Long: `Set of commands to repair a range whose replicas have diverged.
+
+When the consistency checker finds that the replicas of a range disagree, it
+terminates the nodes holding the replicas in the minority, and leaves a
+checkpoint of every replica's store in the auxiliary/checkpoints directory of
+the store, named r<range ID>_at_<applied index>. A file preventing the
+terminated nodes from restarting is placed in the auxiliary directory as well.
+
+To repair the range, one should perform this sequence of actions:
+
+1. Collect the checkpoints of the range from all the nodes holding a replica
+of it into a location where they can be read together.
+
+2. Run 'cockroach debug repair-range diff' on the checkpoints to see how
+the replicas differ, and pick the replica whose data is to be kept.
+
+3. Remove the files preventing the terminated nodes from restarting, and
+restart them. All nodes holding a replica of the range must be live.
+
+4. Run 'cockroach debug repair-range apply' with the store ID of the picked
+replica. It transfers the lease to that replica, and removes and re-adds every
+other replica of the range on the same store, which rebuilds it from a
+snapshot sent by the picked replica, even if snapshot delegation
+(kv.snapshot_delegation.enabled) is enabled. Every step is recorded in the range log, and
+can be inspected on the range report page of the DB Console.
+
+The data of the replicas that were not picked is irrevocably discarded. The
+checkpoints should be kept until the repaired data has been verified.
+
+Example run, repairing r42 with replicas on stores 1, 2 and 3, where store 2
+turned out to hold the correct data:
+
+[cockroach@base ~]$ cockroach debug repair-range diff 42 s1/r42_at_100 s2/r42_at_100 s3/r42_at_100
+[cockroach@base ~]$ cockroach debug repair-range apply 42 --authoritative-store=2 --host=node1
+`,
+	RunE: UsageAndErr,
+}
+
+func init() {
+	debugRepairRangeCmd.AddCommand(
+		debugRepairRangeDiffCmd,
+		debugRepairRangeApplyCmd)
+}
+
+var debugRepairRangeDiffCmd = &cobra.Command{
+	Use:   "diff <range id> <dir> <dir>...",
+	Short: "compare the replicas of a range across checkpoints or stores",
+	Long: `
+Compare the data of a range across the given directories, which can be
+consistency checkpoints or stopped stores. The first directory is used as the
+baseline: the data of every other directory is diffed against it, where '-'
+lines are only present in the baseline and '+' lines are only present in the
+other directory. The header of each diff names the two directories.
+
+See debug repair-range command help for more details on how to use this
+command.
+`,
+	Args: cobra.MinimumNArgs(3),
+	RunE: clierrorplus.MaybeDecorateError(runDebugRepairRangeDiff),
+}
+
+func runDebugRepairRangeDiff(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	rangeID, err := parseRangeID(args[0])
+	if err != nil {
+		return err
+	}
+
+	type replicaData struct {
+		dir  string
+		data *roachpb.RaftSnapshotData
+	}
+	var replicas []replicaData
+	for _, dir := range args[1:] {
+		db, err := OpenExistingStore(dir, stopper, true /* readOnly */)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open store at path %q", dir)
+		}
+		ident, err := kvserver.ReadStoreIdent(ctx, db)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read store ident at path %q", dir)
+		}
+		desc, data, err := kvserver.LoadReplicaData(ctx, db, rangeID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load r%d at path %q", rangeID, dir)
+		}
+		replica, ok := desc.GetReplicaDescriptor(ident.StoreID)
+		if !ok {
+			// The store still holds data of a replica that was removed from
+			// the range.
+			replica = roachpb.ReplicaDescriptor{NodeID: ident.NodeID, StoreID: ident.StoreID}
+		}
+		fmt.Printf("%s: replica %s, %d keys, descriptor %s\n", dir, replica, len(data.KV), desc)
+		replicas = append(replicas, replicaData{dir: dir, data: data})
+	}
+
+	baseline := replicas[0]
+	for _, other := range replicas[1:] {
+		diff := kvserver.DiffReplicaData(baseline.data, other.data)
+		if len(diff) == 0 {
+			fmt.Printf("\n%s is identical to %s\n", other.dir, baseline.dir)
+			continue
+		}
+		fmt.Printf("\n%s differs from %s in %d keys:\n%s", other.dir, baseline.dir, len(diff),
+			diff.FormatWithLabels(baseline.dir, other.dir))
+	}
+	return nil
+}
+
+var debugRepairRangeApplyOpts struct {
+	authoritativeStoreID int
+}
+
+var debugRepairRangeApplyCmd = &cobra.Command{
+	Use:   "apply <range id> --authoritative-store=<store id>",
+	Short: "rebuild the replicas of a range from the replica on the given store",
+	Long: `
+Rebuild all replicas of the range from the replica on the authoritative store,
+by transferring the lease to it and then removing and re-adding each other
+replica. The data of the other replicas is discarded.
+
+The command connects to a running node, and can be re-run if it is
+interrupted.
+
+See debug repair-range command help for more details on how to use this
+command.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: clierrorplus.MaybeDecorateError(runDebugRepairRangeApply),
+}
+
+func runDebugRepairRangeApply(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rangeID, err := parseRangeID(args[0])
+	if err != nil {
+		return err
+	}
+	if debugRepairRangeApplyOpts.authoritativeStoreID <= 0 {
+		return errors.New("--authoritative-store must be set to the store ID of the replica to keep")
+	}
+
+	conn, _, finish, err := getClientGRPCConn(ctx, serverCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to the node")
+	}
+	defer finish()
+
+	resp, err := serverpb.NewAdminClient(conn).RepairRange(ctx, &serverpb.RepairRangeRequest{
+		RangeID:              rangeID,
+		AuthoritativeStoreID: roachpb.StoreID(debugRepairRangeApplyOpts.authoritativeStoreID),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("repaired r%d: %s\n", rangeID, &resp.RangeDescriptor)
+	fmt.Printf("ok; the repair steps are recorded in the range log "+
+		"(see the report page of r%d in the DB Console)\n", rangeID)
+	return nil
+}
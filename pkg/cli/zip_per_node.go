@@ -80,6 +80,7 @@ var debugZipTablesPerNode = []string{
 	"crdb_internal.node_transactions",
 	"crdb_internal.node_txn_stats",
 	"crdb_internal.active_range_feeds",
+	"crdb_internal.changefeed_frontier_spans",
 }
 
 // collectCPUProfiles collects CPU profiles in parallel over all nodes
@@ -349,3 +349,37 @@ func WriteFile(ctx context.Context, dest ExternalStorage, basename string, src i
 	}
 	return errors.Wrap(w.Close(), "closing object")
 }
+
+// CopyFile copies the file at the given path of one ExternalStorage to the
+// same path of another, returning the number of bytes copied.
+func CopyFile(
+	ctx context.Context, src ExternalStorage, dest ExternalStorage, basename string,
+) (int64, error) {
+	var span *tracing.Span
+	ctx, span = tracing.ChildSpan(ctx, fmt.Sprintf("%s.CopyFile", dest.Conf().Provider.String()))
+	defer span.Finish()
+
+	r, err := src.ReadFile(ctx, basename)
+	if err != nil {
+		return 0, errors.Wrapf(err, "opening %s for reading", basename)
+	}
+	defer r.Close(ctx)
+
+	counter := &countingReader{r: ioctx.ReaderCtxAdapter(ctx, r)}
+	if err := WriteFile(ctx, dest, basename, counter); err != nil {
+		return 0, errors.Wrapf(err, "writing %s", basename)
+	}
+	return counter.n, nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements the io.Reader interface.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
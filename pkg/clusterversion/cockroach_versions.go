@@ -305,6 +305,40 @@ const (
 	// stop overwriting the LATEST and checkpoint files during backup execution.
 	// Instead, it writes new files alongside the old in reserved subdirectories.
 	BackupDoesNotOverwriteLatestAndCheckpoint
+	// ImportIntoUpsert is the version where IMPORT INTO can upsert into a table
+	// which stays online for reads, with writes to it rejected meanwhile.
+	ImportIntoUpsert
+	// ImportInferSchema is the version where IMPORT can infer the schema of the
+	// table it creates from a sample of a CSV file.
+	ImportInferSchema
+	// EnsurePebbleFormatVersionRangeKeys is the first step of a two-part
+	// migration that bumps Pebble's format major version to a version that
+	// supports range keys.
+	EnsurePebbleFormatVersionRangeKeys
+	// EnablePebbleFormatVersionRangeKeys is the second of a two-part migration
+	// and is used as the feature gate for use of range keys, e.g. MVCC range
+	// tombstones. Any node at this version is guaranteed to reside in a cluster
+	// where all nodes support range keys at the Pebble layer.
+	EnablePebbleFormatVersionRangeKeys
+	// CPUBasedRebalancing is the version where all stores gossip the CPU time
+	// spent on their replicas, which allows the allocator and the store
+	// rebalancer to balance load based on CPU usage.
+	CPUBasedRebalancing
+	// DelegatedSnapshots is the version where the leaseholder can delegate
+	// the sending of a snapshot to a follower that is closer to the recipient.
+	DelegatedSnapshots
+	// RangeRepair is the version where the admin RepairRange RPC is available
+	// and records repair_range events in the range log.
+	RangeRepair
+	// WitnessReplicas is the version where ranges can contain WITNESS replicas,
+	// which vote and persist the Raft log but don't hold any user data.
+	WitnessReplicas
+	// TenantStorageQuotas adds the storage_quota_bytes column to the
+	// system.tenant_usage table, which holds the storage quota of each tenant.
+	TenantStorageQuotas
+	// ReplicatedLocks is the version where transactions can acquire replicated
+	// locks, which are written to the lock table keyspace next to intents.
+	ReplicatedLocks
 
 	// *************************************************
 	// Step (1): Add new versions here.
@@ -500,6 +534,46 @@ var versionsSingleton = keyedVersions{
 		Key:     BackupDoesNotOverwriteLatestAndCheckpoint,
 		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 84},
 	},
+	{
+		Key:     ImportIntoUpsert,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 86},
+	},
+	{
+		Key:     ImportInferSchema,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 88},
+	},
+	{
+		Key:     EnsurePebbleFormatVersionRangeKeys,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 90},
+	},
+	{
+		Key:     EnablePebbleFormatVersionRangeKeys,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 92},
+	},
+	{
+		Key:     CPUBasedRebalancing,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 94},
+	},
+	{
+		Key:     DelegatedSnapshots,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 96},
+	},
+	{
+		Key:     RangeRepair,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 98},
+	},
+	{
+		Key:     WitnessReplicas,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 100},
+	},
+	{
+		Key:     TenantStorageQuotas,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 102},
+	},
+	{
+		Key:     ReplicatedLocks,
+		Version: roachpb.Version{Major: 21, Minor: 2, Internal: 104},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
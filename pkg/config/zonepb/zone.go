@@ -320,6 +320,10 @@ func (z *ZoneConfig) ValidateTandemFields() error {
 		return fmt.Errorf("when voter_constraints are set, num_voters must be set as well")
 	}
 
+	if len(z.WitnessConstraints) > 0 && z.NumWitnesses == nil {
+		return fmt.Errorf("when witness_constraints are set, num_witnesses must be set as well")
+	}
+
 	if (z.RangeMinBytes != nil || z.RangeMaxBytes != nil) &&
 		(z.RangeMinBytes == nil || z.RangeMaxBytes == nil) {
 		return fmt.Errorf("range_min_bytes and range_max_bytes must be set together")
@@ -355,7 +359,7 @@ func (z *ZoneConfig) Validate() error {
 			}
 			return fmt.Errorf("at least one replica is required")
 		case *z.NumReplicas == 2:
-			if !(z.NumVoters != nil && *z.NumVoters > 0) {
+			if !(z.NumVoters != nil && *z.NumVoters > 0) && !z.hasWitnesses() {
 				return fmt.Errorf("at least 3 replicas are required for multi-replica configurations")
 			}
 		}
@@ -368,13 +372,32 @@ func (z *ZoneConfig) Validate() error {
 		case *z.NumVoters <= 0:
 			return fmt.Errorf("at least one voting replica is required")
 		case *z.NumVoters == 2:
-			return fmt.Errorf("at least 3 voting replicas are required for multi-replica configurations")
+			if !z.hasWitnesses() {
+				return fmt.Errorf("at least 3 voting replicas are required for multi-replica configurations")
+			}
 		}
 		if z.NumReplicas != nil && *z.NumVoters > *z.NumReplicas {
 			return fmt.Errorf("num_voters cannot be greater than num_replicas")
 		}
 	}
 
+	if z.NumWitnesses != nil {
+		if *z.NumWitnesses < 0 {
+			return fmt.Errorf("num_witnesses cannot be negative")
+		}
+		// Every quorum must contain at least one replica that holds the data,
+		// which is the case as long as there are fewer witnesses than (data)
+		// voters.
+		numDataVoters := z.NumVoters
+		if numDataVoters == nil || *numDataVoters == 0 {
+			numDataVoters = z.NumReplicas
+		}
+		if numDataVoters != nil && *numDataVoters > 0 && *z.NumWitnesses >= *numDataVoters {
+			return fmt.Errorf("num_witnesses (%d) must be less than the number of voting replicas (%d)",
+				*z.NumWitnesses, *numDataVoters)
+		}
+	}
+
 	if z.RangeMaxBytes != nil && *z.RangeMaxBytes < base.MinRangeMaxBytes {
 		return fmt.Errorf("RangeMaxBytes %d less than minimum allowed %d",
 			*z.RangeMaxBytes, base.MinRangeMaxBytes)
@@ -416,6 +439,21 @@ func (z *ZoneConfig) Validate() error {
 		}
 	}
 
+	var numConstrainedWitnesses int64
+	for _, constraints := range z.WitnessConstraints {
+		for _, constraint := range constraints.Constraints {
+			if constraint.Type != Constraint_REQUIRED {
+				return fmt.Errorf("witness_constraints must be of type 'required' (prefixed with a '+')")
+			}
+		}
+		numConstrainedWitnesses += int64(constraints.NumReplicas)
+	}
+	if z.NumWitnesses != nil && numConstrainedWitnesses > int64(*z.NumWitnesses) {
+		return fmt.Errorf("the number of replicas specified in witness_constraints (%d) cannot be greater "+
+			"than the number of witnesses configured for the zone (%d)",
+			numConstrainedWitnesses, *z.NumWitnesses)
+	}
+
 	// We only need to further validate constraints if per-replica constraints
 	// are in use. The old style of constraints that apply to all replicas don't
 	// require validation.
@@ -499,6 +537,12 @@ func (z *ZoneConfig) Validate() error {
 	return nil
 }
 
+// hasWitnesses returns whether the zone explicitly asks for at least one
+// witness replica.
+func (z *ZoneConfig) hasWitnesses() bool {
+	return z.NumWitnesses != nil && *z.NumWitnesses > 0
+}
+
 // validateVoterConstraintsCompatibility cross-validates `voter_constraints`
 // against `constraints` and ensures that nothing that is prohibited at the
 // overall `constraints` level is required at the `voter_constraints` level,
@@ -541,6 +585,13 @@ func (z *ZoneConfig) InheritFromParent(parent *ZoneConfig) {
 			z.NumVoters = proto.Int32(*parent.NumVoters)
 		}
 	}
+	if z.NumWitnesses == nil {
+		// WitnessConstraints are inherited along with NumWitnesses.
+		if parent.NumWitnesses != nil {
+			z.NumWitnesses = proto.Int32(*parent.NumWitnesses)
+			z.WitnessConstraints = parent.WitnessConstraints
+		}
+	}
 	if z.GlobalReads == nil {
 		if parent.GlobalReads != nil {
 			z.GlobalReads = proto.Bool(*parent.GlobalReads)
@@ -596,6 +647,11 @@ func (z *ZoneConfig) CopyFromZone(other ZoneConfig, fieldList []tree.Name) {
 			if other.NumVoters != nil {
 				z.NumVoters = proto.Int32(*other.NumVoters)
 			}
+		case "num_witnesses":
+			z.NumWitnesses = nil
+			if other.NumWitnesses != nil {
+				z.NumWitnesses = proto.Int32(*other.NumWitnesses)
+			}
 		case "range_min_bytes":
 			z.RangeMinBytes = nil
 			if other.RangeMinBytes != nil {
@@ -623,6 +679,8 @@ func (z *ZoneConfig) CopyFromZone(other ZoneConfig, fieldList []tree.Name) {
 		case "voter_constraints":
 			z.VoterConstraints = other.VoterConstraints
 			z.NullVoterConstraintsIsEmpty = other.NullVoterConstraintsIsEmpty
+		case "witness_constraints":
+			z.WitnessConstraints = other.WitnessConstraints
 		case "lease_preferences":
 			z.LeasePreferences = other.LeasePreferences
 			z.InheritedLeasePreferences = other.InheritedLeasePreferences
@@ -685,6 +743,16 @@ func (z *ZoneConfig) DiffWithZone(
 					Field: "num_voters",
 				}, nil
 			}
+		case "num_witnesses":
+			if other.NumWitnesses == nil && z.NumWitnesses == nil {
+				continue
+			}
+			if z.NumWitnesses == nil || other.NumWitnesses == nil ||
+				*z.NumWitnesses != *other.NumWitnesses {
+				return false, DiffWithZoneMismatch{
+					Field: "num_witnesses",
+				}, nil
+			}
 		case "range_min_bytes":
 			if other.RangeMinBytes == nil && z.RangeMinBytes == nil {
 				continue
@@ -764,6 +832,26 @@ func (z *ZoneConfig) DiffWithZone(
 					}
 				}
 			}
+		case "witness_constraints":
+			if other.WitnessConstraints == nil && z.WitnessConstraints == nil {
+				continue
+			}
+			if z.WitnessConstraints == nil || other.WitnessConstraints == nil {
+				return false, DiffWithZoneMismatch{
+					Field: "witness_constraints",
+				}, nil
+			}
+			for i, c := range z.WitnessConstraints {
+				for j, constraint := range c.Constraints {
+					if len(other.WitnessConstraints) <= i ||
+						len(other.WitnessConstraints[i].Constraints) <= j ||
+						constraint != other.WitnessConstraints[i].Constraints[j] {
+						return false, DiffWithZoneMismatch{
+							Field: "witness_constraints",
+						}, nil
+					}
+				}
+			}
 		case "lease_preferences":
 			if other.LeasePreferences == nil && z.LeasePreferences == nil {
 				continue
@@ -1196,6 +1284,9 @@ func (z *ZoneConfig) toSpanConfig() (roachpb.SpanConfig, error) {
 	if z.NumVoters != nil {
 		sc.NumVoters = *z.NumVoters
 	}
+	if z.NumWitnesses != nil {
+		sc.NumWitnesses = *z.NumWitnesses
+	}
 
 	toSpanConfigConstraints := func(src []Constraint) ([]roachpb.Constraint, error) {
 		spanConfigConstraints := make([]roachpb.Constraint, len(src))
@@ -1239,6 +1330,12 @@ func (z *ZoneConfig) toSpanConfig() (roachpb.SpanConfig, error) {
 			return roachpb.SpanConfig{}, err
 		}
 	}
+	if len(z.WitnessConstraints) != 0 {
+		sc.WitnessConstraints, err = toSpanConfigConstraintsConjunction(z.WitnessConstraints)
+		if err != nil {
+			return roachpb.SpanConfig{}, err
+		}
+	}
 
 	if len(z.LeasePreferences) != 0 {
 		sc.LeasePreferences = make([]roachpb.LeasePreference, len(z.LeasePreferences))
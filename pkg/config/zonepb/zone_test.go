@@ -277,6 +277,98 @@ func TestZoneConfigValidateNonVoterSpecific(t *testing.T) {
 	}
 }
 
+func TestZoneConfigValidateWitnesses(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		cfg      ZoneConfig
+		expected string
+	}{
+		{
+			cfg: ZoneConfig{
+				NumReplicas:  proto.Int32(2),
+				NumWitnesses: proto.Int32(1),
+			},
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas:  proto.Int32(4),
+				NumVoters:    proto.Int32(2),
+				NumWitnesses: proto.Int32(1),
+				WitnessConstraints: []ConstraintsConjunction{
+					{Constraints: []Constraint{{Key: "region", Value: "c", Type: Constraint_REQUIRED}}},
+				},
+			},
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas:  proto.Int32(2),
+				NumWitnesses: proto.Int32(0),
+			},
+			expected: "at least 3 replicas are required for multi-replica configurations",
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas:  proto.Int32(3),
+				NumWitnesses: proto.Int32(-1),
+			},
+			expected: "num_witnesses cannot be negative",
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas:  proto.Int32(2),
+				NumWitnesses: proto.Int32(2),
+			},
+			expected: `num_witnesses \(2\) must be less than the number of voting replicas \(2\)`,
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas:  proto.Int32(4),
+				NumVoters:    proto.Int32(1),
+				NumWitnesses: proto.Int32(1),
+			},
+			expected: `num_witnesses \(1\) must be less than the number of voting replicas \(1\)`,
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas:  proto.Int32(2),
+				NumWitnesses: proto.Int32(1),
+				WitnessConstraints: []ConstraintsConjunction{
+					{Constraints: []Constraint{{Key: "region", Value: "c", Type: Constraint_PROHIBITED}}},
+				},
+			},
+			expected: "witness_constraints must be of type 'required'",
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas:  proto.Int32(2),
+				NumWitnesses: proto.Int32(1),
+				WitnessConstraints: []ConstraintsConjunction{
+					{
+						NumReplicas: 2,
+						Constraints: []Constraint{{Key: "region", Value: "c", Type: Constraint_REQUIRED}},
+					},
+				},
+			},
+			expected: `the number of replicas specified in witness_constraints \(2\) cannot be greater ` +
+				`than the number of witnesses configured for the zone \(1\)`,
+		},
+	}
+
+	for i, c := range testCases {
+		err := c.cfg.Validate()
+		if c.expected == "" {
+			if err != nil {
+				t.Errorf("%d: expected success, got %v", i, err)
+			}
+			continue
+		}
+		if !testutils.IsError(err, c.expected) {
+			t.Errorf("%d: expected %q, got %v", i, c.expected, err)
+		}
+	}
+}
+
 func TestZoneConfigValidateTandemFields(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
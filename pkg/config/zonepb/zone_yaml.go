@@ -207,6 +207,8 @@ type marshalableZoneConfig struct {
 	NumVoters                    *int32            `json:"num_voters" yaml:"num_voters"`
 	Constraints                  ConstraintsList   `json:"constraints" yaml:"constraints,flow"`
 	VoterConstraints             ConstraintsList   `json:"voter_constraints" yaml:"voter_constraints,flow"`
+	NumWitnesses                 *int32            `json:"num_witnesses,omitempty" yaml:"num_witnesses,omitempty"`
+	WitnessConstraints           *ConstraintsList  `json:"witness_constraints,omitempty" yaml:"witness_constraints,flow,omitempty"`
 	LeasePreferences             []LeasePreference `json:"lease_preferences" yaml:"lease_preferences,flow"`
 	ExperimentalLeasePreferences []LeasePreference `json:"experimental_lease_preferences" yaml:"experimental_lease_preferences,flow,omitempty"`
 	Subzones                     []Subzone         `json:"subzones" yaml:"-"`
@@ -240,6 +242,12 @@ func zoneConfigToMarshalable(c ZoneConfig) marshalableZoneConfig {
 	// `c.InheritedVoterConstraints()`. This is copacetic as long as the value is
 	// unmarshalled correctly in zoneConfigFromMarshalable().
 	m.VoterConstraints = ConstraintsList{c.VoterConstraints, !c.NullVoterConstraintsIsEmpty}
+	// NB: The witness fields are only marshaled when witnesses are configured,
+	// so that the output for zones without witnesses is unchanged.
+	if c.NumWitnesses != nil {
+		m.NumWitnesses = proto.Int32(*c.NumWitnesses)
+		m.WitnessConstraints = &ConstraintsList{c.WitnessConstraints, false /* inherited */}
+	}
 	if !c.InheritedLeasePreferences {
 		m.LeasePreferences = c.LeasePreferences
 	}
@@ -277,6 +285,12 @@ func zoneConfigFromMarshalable(m marshalableZoneConfig, c ZoneConfig) ZoneConfig
 	}
 	c.VoterConstraints = m.VoterConstraints.Constraints
 	c.NullVoterConstraintsIsEmpty = !m.VoterConstraints.Inherited
+	if m.NumWitnesses != nil {
+		c.NumWitnesses = proto.Int32(*m.NumWitnesses)
+	}
+	if m.WitnessConstraints != nil {
+		c.WitnessConstraints = m.WitnessConstraints.Constraints
+	}
 	if m.LeasePreferences != nil {
 		c.LeasePreferences = m.LeasePreferences
 	}
@@ -102,6 +102,11 @@ const (
 	// stmtDiagnosticsRequestRegistry listens for notifications and responds by
 	// polling for new requests.
 	KeyGossipStatementDiagnosticsRequestCancellation = "stmt-diag-cancel-req"
+
+	// KeyTenantStorageUsagePrefix is the key prefix for gossiping the logical
+	// storage usage of secondary tenants. The suffix is a store ID and the
+	// value is a kvserverpb.TenantStorageUsage.
+	KeyTenantStorageUsagePrefix = "tenant-storage-usage"
 )
 
 // MakeKey creates a canonical key under which to gossip a piece of
@@ -180,6 +185,12 @@ func StoreIDFromKey(storeKey string) (roachpb.StoreID, error) {
 	return roachpb.StoreID(storeID), nil
 }
 
+// MakeTenantStorageUsageKey returns the gossip key under which the given store
+// gossips the logical storage usage of secondary tenants.
+func MakeTenantStorageUsageKey(storeID roachpb.StoreID) string {
+	return MakeKey(KeyTenantStorageUsagePrefix, storeID.String())
+}
+
 // MakeDistSQLNodeVersionKey returns the gossip key for the given store.
 func MakeDistSQLNodeVersionKey(instanceID base.SQLInstanceID) string {
 	return MakeKey(KeyDistSQLNodeVersionKeyPrefix, instanceID.String())
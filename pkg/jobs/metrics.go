@@ -16,7 +16,9 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	io_prometheus_client "github.com/prometheus/client_model/go"
 )
@@ -28,6 +30,7 @@ type Metrics struct {
 	RowLevelTTL  metric.Struct
 	Changefeed   metric.Struct
 	StreamIngest metric.Struct
+	Backup       metric.Struct
 
 	// AdoptIterations counts the number of adopt loops executed by Registry.
 	AdoptIterations *metric.Counter
@@ -189,6 +192,9 @@ func (m *Metrics) init(histogramWindowInterval time.Duration) {
 	if MakeStreamIngestMetricsHook != nil {
 		m.StreamIngest = MakeStreamIngestMetricsHook(histogramWindowInterval)
 	}
+	if MakeBackupMetricsHook != nil {
+		m.Backup = MakeBackupMetricsHook(histogramWindowInterval)
+	}
 	m.AdoptIterations = metric.NewCounter(metaAdoptIterations)
 	m.ClaimedJobs = metric.NewCounter(metaClaimedJobs)
 	m.ResumedJobs = metric.NewCounter(metaResumedClaimedJobs)
@@ -215,10 +221,29 @@ func (m *Metrics) init(histogramWindowInterval time.Duration) {
 // ccl code.
 var MakeChangefeedMetricsHook func(time.Duration) metric.Struct
 
+// ChangefeedFrontierSpan is one of the spans tracked by the frontier of a
+// changefeed, along with the timestamp it is resolved up to.
+type ChangefeedFrontierSpan struct {
+	// JobID is zero for sinkless changefeeds.
+	JobID    jobspb.JobID
+	Span     roachpb.Span
+	Resolved hlc.Timestamp
+}
+
+// ChangefeedFrontierSpans is implemented by the changefeed metrics to expose
+// the slowest spans of the changefeed frontiers running on this node.
+type ChangefeedFrontierSpans interface {
+	ForEachFrontierSpan(fn func(ChangefeedFrontierSpan) error) error
+}
+
 // MakeStreamIngestMetricsHook allows for registration of streaming metrics from
 // ccl code.
 var MakeStreamIngestMetricsHook func(duration time.Duration) metric.Struct
 
+// MakeBackupMetricsHook allows for registration of the metrics of BACKUP and
+// RESTORE jobs from ccl code.
+var MakeBackupMetricsHook func(time.Duration) metric.Struct
+
 // MakeRowLevelTTLMetricsHook allows for registration of row-level TTL metrics.
 var MakeRowLevelTTLMetricsHook func(time.Duration) metric.Struct
 
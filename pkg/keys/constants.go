@@ -190,6 +190,11 @@ var (
 	// localStoreNodeTombstoneSuffix stores key value pairs that map
 	// nodeIDs to time of removal from cluster.
 	localStoreNodeTombstoneSuffix = []byte("ntmb")
+	// localStoreRaftEngineIdentSuffix stores the identifier of the store in
+	// its state machine engine once the Raft state of its replicas has been
+	// moved to a separate engine, which prevents the store from being started
+	// without that engine.
+	localStoreRaftEngineIdentSuffix = []byte("rfte")
 	// localStoreCachedSettingsSuffix stores the cached settings for node.
 	localStoreCachedSettingsSuffix = []byte("stng")
 	// LocalStoreCachedSettingsKeyMin is the start of span of possible cached settings keys.
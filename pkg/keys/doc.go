@@ -221,6 +221,7 @@ var _ = [...]interface{}{
 	StoreIdentKey,                 // "iden"
 	StoreUnsafeReplicaRecoveryKey, // "loqr"
 	StoreNodeTombstoneKey,         // "ntmb"
+	StoreRaftEngineIdentKey,       // "rfte"
 	StoreCachedSettingsKey,        // "stng"
 	StoreLastUpKey,                // "uptm"
 
@@ -56,6 +56,12 @@ func StoreIdentKey() roachpb.Key {
 	return MakeStoreKey(localStoreIdentSuffix, nil)
 }
 
+// StoreRaftEngineIdentKey returns a store-local key marking a store whose
+// Raft state is kept in a separate engine.
+func StoreRaftEngineIdentKey() roachpb.Key {
+	return MakeStoreKey(localStoreRaftEngineIdentSuffix, nil)
+}
+
 // StoreGossipKey returns a store-local key for the gossip bootstrap metadata.
 func StoreGossipKey() roachpb.Key {
 	return MakeStoreKey(localStoreGossipSuffix, nil)
@@ -50,6 +50,10 @@ type BufferingAdder struct {
 
 	sorted bool
 
+	// replaceDuplicates keeps only the value added last for each key, see
+	// kvserverbase.BulkAdderOptions.
+	replaceDuplicates bool
+
 	initialSplits int
 
 	flushCounts struct {
@@ -115,6 +119,7 @@ func MakeBulkAdder(
 			splitAfter:             opts.SplitAndScatterAfter,
 			batchTS:                opts.BatchTimestamp,
 			writeAtBatchTS:         opts.WriteAtBatchTimestamp,
+			elasticAdmission:       opts.ElasticAdmission,
 		},
 		timestamp:           timestamp,
 		curBufferSize:       opts.MinBufferSize,
@@ -122,6 +127,7 @@ func MakeBulkAdder(
 		incrementBufferSize: opts.StepBufferSize,
 		bulkMon:             bulkMon,
 		sorted:              true,
+		replaceDuplicates:   opts.ReplaceDuplicates,
 		initialSplits:       opts.InitialSplitsIfUnordered,
 	}
 
@@ -242,7 +248,12 @@ func (b *BufferingAdder) doFlush(ctx context.Context, forSize bool) error {
 	beforeSort := timeutil.Now()
 
 	if !b.sorted {
-		sort.Sort(&b.curBuf)
+		if b.replaceDuplicates {
+			// Keep the values of each key in the order they were added in.
+			sort.Stable(&b.curBuf)
+		} else {
+			sort.Sort(&b.curBuf)
+		}
 	}
 	mvccKey := storage.MVCCKey{Timestamp: b.timestamp}
 
@@ -259,6 +270,9 @@ func (b *BufferingAdder) doFlush(ctx context.Context, forSize bool) error {
 
 	for i := range b.curBuf.entries {
 		mvccKey.Key = b.curBuf.Key(i)
+		if b.replaceDuplicates && i+1 < len(b.curBuf.entries) && mvccKey.Key.Equal(b.curBuf.Key(i+1)) {
+			continue
+		}
 		if err := b.sink.AddMVCCKey(ctx, mvccKey, b.curBuf.Value(i)); err != nil {
 			return err
 		}
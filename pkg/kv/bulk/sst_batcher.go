@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -99,6 +100,10 @@ type SSTBatcher struct {
 	// writeAtBatchTS is passed to the writeAtBatchTs argument to db.AddSStable.
 	writeAtBatchTS bool
 
+	// elasticAdmission makes the AddSSTable requests elastic work for admission
+	// control. See kvserverbase.BulkAdderOptions.ElasticAdmission.
+	elasticAdmission bool
+
 	// The rest of the fields accumulated state as opposed to configuration. Some,
 	// like totalRows, are accumulated _across_ batches and are not reset between
 	// batches when Reset() is called.
@@ -373,8 +378,18 @@ func (b *SSTBatcher) doFlush(ctx context.Context, reason int, nextKey roachpb.Ke
 		b.ms.LastUpdateNanos = timeutil.Now().UnixNano()
 	}
 
+	var admissionHeader roachpb.AdmissionHeader
+	if b.elasticAdmission {
+		admissionHeader = roachpb.AdmissionHeader{
+			Priority:                 int32(admission.BulkNormalPri),
+			CreateTime:               timeutil.Now().UnixNano(),
+			Source:                   roachpb.AdmissionHeader_ROOT_KV,
+			NoMemoryReservedAtSource: true,
+		}
+	}
+
 	beforeSend := timeutil.Now()
-	writeTS, files, err := AddSSTable(ctx, b.db, start, end, b.sstFile.Data(), b.disallowShadowingBelow, b.ms, b.settings, b.batchTS, b.writeAtBatchTS)
+	writeTS, files, err := AddSSTable(ctx, b.db, start, end, b.sstFile.Data(), b.disallowShadowingBelow, b.ms, b.settings, b.batchTS, b.writeAtBatchTS, admissionHeader)
 	if err != nil {
 		return err
 	}
@@ -446,6 +461,7 @@ type SSTSender interface {
 		stats *enginepb.MVCCStats,
 		ingestAsWrites bool,
 		batchTs hlc.Timestamp,
+		admissionHeader roachpb.AdmissionHeader,
 	) error
 
 	AddSSTableAtBatchTimestamp(
@@ -458,6 +474,7 @@ type SSTSender interface {
 		stats *enginepb.MVCCStats,
 		ingestAsWrites bool,
 		batchTs hlc.Timestamp,
+		admissionHeader roachpb.AdmissionHeader,
 	) (hlc.Timestamp, error)
 
 	SplitAndScatter(
@@ -476,7 +493,8 @@ type sstSpan struct {
 
 // AddSSTable retries db.AddSSTable if retryable errors occur, including if the
 // SST spans a split, in which case it is iterated and split into two SSTs, one
-// for each side of the split in the error, and each are retried.
+// for each side of the split in the error, and each are retried. All requests
+// are sent with the given admission header.
 func AddSSTable(
 	ctx context.Context,
 	db SSTSender,
@@ -487,6 +505,7 @@ func AddSSTable(
 	settings *cluster.Settings,
 	batchTs hlc.Timestamp,
 	writeAtBatchTs bool,
+	admissionHeader roachpb.AdmissionHeader,
 ) (hlc.Timestamp, int, error) {
 	var files int
 	var maxTs hlc.Timestamp
@@ -539,7 +558,7 @@ func AddSSTable(
 					// This will fail if the range has split but we'll check for that below.
 					writeTs, err = db.AddSSTableAtBatchTimestamp(ctx, item.start, item.end, item.sstBytes,
 						false /* disallowConflicts */, !item.disallowShadowingBelow.IsEmpty(),
-						item.disallowShadowingBelow, &item.stats, ingestAsWriteBatch, batchTs)
+						item.disallowShadowingBelow, &item.stats, ingestAsWriteBatch, batchTs, admissionHeader)
 					if err == nil {
 						maxTs.Forward(writeTs)
 					}
@@ -547,7 +566,7 @@ func AddSSTable(
 					// This will fail if the range has split but we'll check for that below.
 					err = db.AddSSTable(ctx, item.start, item.end, item.sstBytes, false, /* disallowConflicts */
 						!item.disallowShadowingBelow.IsEmpty(), item.disallowShadowingBelow, &item.stats,
-						ingestAsWriteBatch, batchTs)
+						ingestAsWriteBatch, batchTs, admissionHeader)
 				}
 				if err == nil {
 					log.VEventf(ctx, 3, "adding %s AddSSTable [%s,%s) took %v", sz(len(item.sstBytes)), item.start, item.end, timeutil.Since(before))
@@ -205,3 +205,65 @@ func runTestImport(t *testing.T, batchSizeValue int64) {
 		})
 	}
 }
+
+// TestReplaceDuplicates tests that a BulkAdder with ReplaceDuplicates keeps the
+// value added last for each key, within a buffer and across flushes.
+func TestReplaceDuplicates(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	s, _, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	prefix := keys.SystemSQLCodec.IndexPrefix(100, 1)
+	key := func(i int) roachpb.Key {
+		return encoding.EncodeStringAscending(append([]byte{}, prefix...), fmt.Sprintf("k%d", i))
+	}
+	b, err := bulk.MakeBulkAdder(ctx, kvDB, nil /* rangeCache */, s.ClusterSettings(),
+		s.Clock().Now(), kvserverbase.BulkAdderOptions{
+			ReplaceDuplicates:     true,
+			WriteAtBatchTimestamp: true,
+		}, nil /* bulkMon */)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close(ctx)
+
+	add := func(x int, value string) {
+		v := roachpb.MakeValueFromString(value)
+		v.InitChecksum(key(x))
+		if err := b.Add(ctx, key(x), v.RawBytes); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The keys are added out of order, so the buffer is sorted.
+	add(2, "a")
+	add(1, "a")
+	add(2, "b")
+	add(1, "b")
+	add(2, "c")
+	add(3, "a")
+	if err := b.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	add(3, "b")
+	if err := b.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := kvDB.Scan(ctx, key(0), key(9), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []string
+	for _, kv := range got {
+		v, err := kv.Value.GetBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, string(v))
+	}
+	if expected := []string{"b", "c", "b"}; !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+}
@@ -547,6 +547,16 @@ func (db *DB) DelRange(
 	return r.Keys, err
 }
 
+// DelRangeUsingTombstone deletes the rows between begin (inclusive) and end
+// (exclusive) using an MVCC range tombstone. Callers must check
+// storage.CanUseMVCCRangeTombstones before using it.
+func (db *DB) DelRangeUsingTombstone(ctx context.Context, begin, end interface{}) error {
+	b := &Batch{}
+	b.DelRangeUsingTombstone(begin, end)
+	_, err := getOneResult(db.Run(ctx, b), b)
+	return err
+}
+
 // AdminMerge merges the range containing key and the subsequent range. After
 // the merge operation is complete, the range containing key will contain all of
 // the key/value pairs of the subsequent range and the subsequent range will no
@@ -676,6 +686,9 @@ func (db *DB) AdminRelocateRange(
 //
 // The disallowConflicts, disallowShadowingBelow parameters
 // require the MVCCAddSSTable version gate, as they are new in 22.1.
+//
+// The admission header is used for the admission control of the request. An
+// empty header makes the request bypass admission control.
 func (db *DB) AddSSTable(
 	ctx context.Context,
 	begin, end interface{},
@@ -686,8 +699,9 @@ func (db *DB) AddSSTable(
 	stats *enginepb.MVCCStats,
 	ingestAsWrites bool,
 	batchTs hlc.Timestamp,
+	admissionHeader roachpb.AdmissionHeader,
 ) error {
-	b := &Batch{Header: roachpb.Header{Timestamp: batchTs}}
+	b := &Batch{Header: roachpb.Header{Timestamp: batchTs}, AdmissionHeader: admissionHeader}
 	b.addSSTable(begin, end, data, disallowConflicts, disallowShadowing, disallowShadowingBelow,
 		stats, ingestAsWrites, hlc.Timestamp{} /* sstTimestampToRequestTimestamp */)
 	return getOneErr(db.Run(ctx, b), b)
@@ -698,7 +712,8 @@ func (db *DB) AddSSTable(
 // batch timestamp at which the sst is actually ingested -- and that those keys
 // end up with after it is ingested -- may be updated if the request is pushed.
 //
-// Should only be called after checking the MVCCAddSSTable version gate.
+// Should only be called after checking the MVCCAddSSTable version gate. The
+// admission header is used like in AddSSTable.
 func (db *DB) AddSSTableAtBatchTimestamp(
 	ctx context.Context,
 	begin, end interface{},
@@ -709,8 +724,9 @@ func (db *DB) AddSSTableAtBatchTimestamp(
 	stats *enginepb.MVCCStats,
 	ingestAsWrites bool,
 	batchTs hlc.Timestamp,
+	admissionHeader roachpb.AdmissionHeader,
 ) (hlc.Timestamp, error) {
-	b := &Batch{Header: roachpb.Header{Timestamp: batchTs}}
+	b := &Batch{Header: roachpb.Header{Timestamp: batchTs}, AdmissionHeader: admissionHeader}
 	b.addSSTable(begin, end, data, disallowConflicts, disallowShadowing, disallowShadowingBelow,
 		stats, ingestAsWrites, batchTs)
 	err := getOneErr(db.Run(ctx, b), b)
@@ -175,7 +175,7 @@ func (a *activeRangeFeed) onRangeEvent(
 ) {
 	a.Lock()
 	defer a.Unlock()
-	if event.Val != nil || event.SST != nil {
+	if event.Val != nil || event.SST != nil || event.DeleteRange != nil {
 		a.LastValueReceived = timeutil.Now()
 	} else if event.Checkpoint != nil {
 		a.Resolved = event.Checkpoint.ResolvedTS
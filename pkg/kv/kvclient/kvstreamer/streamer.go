@@ -1418,6 +1418,7 @@ func (w *workerCoordinator) processSingleRangeResults(
 				gets = gets[1:]
 				newGet.req.SetSpan(*get.ResumeSpan)
 				newGet.req.KeyLocking = origRequest.KeyLocking
+				newGet.req.KeyLockingReplicated = origRequest.KeyLockingReplicated
 				newGet.union.Get = &newGet.req
 				resumeReq.reqs[resumeReqIdx].Value = &newGet.union
 				resumeReq.positions[resumeReqIdx] = req.positions[i]
@@ -1490,6 +1491,7 @@ func (w *workerCoordinator) processSingleRangeResults(
 				newScan.req.SetSpan(*scan.ResumeSpan)
 				newScan.req.ScanFormat = roachpb.BATCH_RESPONSE
 				newScan.req.KeyLocking = origRequest.KeyLocking
+				newScan.req.KeyLockingReplicated = origRequest.KeyLockingReplicated
 				newScan.union.Scan = &newScan.req
 				resumeReq.reqs[resumeReqIdx].Value = &newScan.union
 				resumeReq.positions[resumeReqIdx] = req.positions[i]
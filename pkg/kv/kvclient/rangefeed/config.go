@@ -41,6 +41,7 @@ type config struct {
 	onCheckpoint         OnCheckpoint
 	onFrontierAdvance    OnFrontierAdvance
 	onSSTable            OnSSTable
+	onDeleteRange        OnDeleteRange
 	extraPProfLabels     []string
 }
 
@@ -178,6 +179,21 @@ func WithOnSSTable(f OnSSTable) Option {
 	})
 }
 
+// OnDeleteRange is called when an MVCC range tombstone is written, deleting
+// all keys in its span at its timestamp. The span is truncated to the
+// rangefeed span. Range tombstones can only be written when the
+// storage.mvcc.range_tombstones.enabled setting is enabled, and the rangefeed
+// fails with an assertion error if one is received without this callback.
+type OnDeleteRange func(ctx context.Context, value *roachpb.RangeFeedDeleteRange)
+
+// WithOnDeleteRange sets up a callback that's invoked whenever an MVCC range
+// tombstone is written.
+func WithOnDeleteRange(f OnDeleteRange) Option {
+	return optionFunc(func(c *config) {
+		c.onDeleteRange = f
+	})
+}
+
 // OnFrontierAdvance is called when the rangefeed frontier is advanced with the
 // new frontier timestamp.
 type OnFrontierAdvance func(ctx context.Context, timestamp hlc.Timestamp)
@@ -514,7 +514,7 @@ func TestWithOnSSTable(t *testing.T) {
 	sst, sstStart, sstEnd := sstutil.MakeSST(t, srv.ClusterSettings(), sstKVs)
 	_, pErr := db.AddSSTableAtBatchTimestamp(ctx, sstStart, sstEnd, sst,
 		false /* disallowConflicts */, false /* disallowShadowing */, hlc.Timestamp{}, nil, /* stats */
-		false /* ingestAsWrites */, now)
+		false /* ingestAsWrites */, now, roachpb.AdmissionHeader{})
 	require.Nil(t, pErr)
 
 	// Wait for the SST event and check its contents.
@@ -590,7 +590,7 @@ func TestWithOnSSTableCatchesUpIfNotSet(t *testing.T) {
 	sst, sstStart, sstEnd := sstutil.MakeSST(t, srv.ClusterSettings(), sstKVs)
 	_, pErr := db.AddSSTableAtBatchTimestamp(ctx, sstStart, sstEnd, sst,
 		false /* disallowConflicts */, false /* disallowShadowing */, hlc.Timestamp{}, nil, /* stats */
-		false /* ingestAsWrites */, now)
+		false /* ingestAsWrites */, now, roachpb.AdmissionHeader{})
 	require.Nil(t, pErr)
 
 	// Assert that we receive the KV pairs within the rangefeed span.
@@ -106,6 +106,9 @@ const (
 	AllocatorConsiderRebalance
 	AllocatorRangeUnavailable
 	AllocatorFinalizeAtomicReplicationChange
+	AllocatorAddWitness
+	AllocatorReplaceDeadWitness
+	AllocatorRemoveWitness
 )
 
 var allocatorActionNames = map[AllocatorAction]string{
@@ -126,6 +129,9 @@ var allocatorActionNames = map[AllocatorAction]string{
 	AllocatorConsiderRebalance:               "consider rebalance",
 	AllocatorRangeUnavailable:                "range unavailable",
 	AllocatorFinalizeAtomicReplicationChange: "finalize conf change",
+	AllocatorAddWitness:                      "add witness",
+	AllocatorReplaceDeadWitness:              "replace dead witness",
+	AllocatorRemoveWitness:                   "remove witness",
 }
 
 func (a AllocatorAction) String() string {
@@ -158,6 +164,12 @@ func (a AllocatorAction) Priority() float64 {
 		return 900
 	case AllocatorRemoveVoter:
 		return 800
+	case AllocatorReplaceDeadWitness:
+		return 780
+	case AllocatorAddWitness:
+		return 770
+	case AllocatorRemoveWitness:
+		return 750
 	case AllocatorReplaceDeadNonVoter:
 		return 700
 	case AllocatorAddNonVoter:
@@ -183,6 +195,7 @@ const (
 	_ targetReplicaType = iota
 	voterTarget
 	nonVoterTarget
+	witnessTarget
 )
 
 // AddChangeType returns the roachpb.ReplicaChangeType corresponding to the
@@ -196,6 +209,8 @@ func (t targetReplicaType) AddChangeType() roachpb.ReplicaChangeType {
 		return roachpb.ADD_VOTER
 	case nonVoterTarget:
 		return roachpb.ADD_NON_VOTER
+	case witnessTarget:
+		return roachpb.ADD_WITNESS
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -209,6 +224,8 @@ func (t targetReplicaType) RemoveChangeType() roachpb.ReplicaChangeType {
 		return roachpb.REMOVE_VOTER
 	case nonVoterTarget:
 		return roachpb.REMOVE_NON_VOTER
+	case witnessTarget:
+		return roachpb.REMOVE_WITNESS
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -220,6 +237,8 @@ func (t targetReplicaType) String() string {
 		return "voter"
 	case nonVoterTarget:
 		return "non-voter"
+	case witnessTarget:
+		return "witness"
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -241,6 +260,7 @@ const (
 type allocatorError struct {
 	constraints           []roachpb.ConstraintsConjunction
 	voterConstraints      []roachpb.ConstraintsConjunction
+	witnessConstraints    []roachpb.ConstraintsConjunction
 	existingVoterCount    int
 	existingNonVoterCount int
 	aliveStores           int
@@ -273,7 +293,7 @@ func (ae *allocatorError) Error() string {
 			ae.aliveStores, existingVoterStr, existingNonVoterStr)
 	}
 
-	if len(ae.constraints) == 0 && len(ae.voterConstraints) == 0 {
+	if len(ae.constraints) == 0 && len(ae.voterConstraints) == 0 && len(ae.witnessConstraints) == 0 {
 		if ae.throttledStores > 0 {
 			return baseMsg
 		}
@@ -304,6 +324,19 @@ func (ae *allocatorError) Error() string {
 	}
 	b.WriteString("]")
 
+	if len(ae.witnessConstraints) > 0 {
+		b.WriteString("; witnesses must match witness_constraints [")
+		for i := range ae.witnessConstraints {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteByte('{')
+			b.WriteString(ae.witnessConstraints[i].String())
+			b.WriteByte('}')
+		}
+		b.WriteString("]")
+	}
+
 	return b.String()
 }
 
@@ -335,6 +368,12 @@ type RangeUsageInfo struct {
 	LogicalBytes     int64
 	QueriesPerSecond float64
 	WritesPerSecond  float64
+	// RequestCPUNanosPerSecond is the CPU time spent evaluating requests on the
+	// range's leaseholder, which moves along with the lease.
+	RequestCPUNanosPerSecond float64
+	// RaftCPUNanosPerSecond is the CPU time spent applying Raft commands on each
+	// of the range's replicas.
+	RaftCPUNanosPerSecond float64
 }
 
 func rangeUsageInfoForRepl(repl *Replica) RangeUsageInfo {
@@ -347,6 +386,12 @@ func rangeUsageInfoForRepl(repl *Replica) RangeUsageInfo {
 	if writesPerSecond, dur := repl.writeStats.avgQPS(); dur >= MinStatsDuration {
 		info.WritesPerSecond = writesPerSecond
 	}
+	if requestCPU, dur := repl.requestCPUStats.avgQPS(); dur >= MinStatsDuration {
+		info.RequestCPUNanosPerSecond = requestCPU
+	}
+	if raftCPU, dur := repl.raftCPUStats.avgQPS(); dur >= MinStatsDuration {
+		info.RaftCPUNanosPerSecond = raftCPU
+	}
 	return info
 }
 
@@ -441,6 +486,22 @@ func GetNeededNonVoters(numVoters, zoneConfigNonVoterCount, clusterNodes int) in
 	return need
 }
 
+// GetNeededWitnesses calculates the number of witnesses a range should have
+// given the number of voting replicas the range has and the number of nodes
+// available for up-replication. Like GetNeededNonVoters, it assumes that we
+// have exactly as many voters as we need.
+func GetNeededWitnesses(numVoters, zoneConfigWitnessCount, clusterNodes int) int {
+	need := zoneConfigWitnessCount
+	if clusterNodes-numVoters < need {
+		// Witnesses can only be placed on nodes that do not have a voting replica.
+		need = clusterNodes - numVoters
+	}
+	if need < 0 {
+		need = 0 // Must be non-negative.
+	}
+	return need
+}
+
 // ComputeAction determines the exact operation needed to repair the
 // supplied range, as governed by the supplied zone configuration. It
 // returns the required action that should be taken and a priority.
@@ -506,7 +567,7 @@ func (a *Allocator) ComputeAction(
 	}
 
 	return a.computeAction(ctx, conf, desc.Replicas().VoterDescriptors(),
-		desc.Replicas().NonVoterDescriptors())
+		desc.Replicas().NonVoterDescriptors(), desc.Replicas().WitnessDescriptors())
 
 }
 
@@ -515,6 +576,7 @@ func (a *Allocator) computeAction(
 	conf roachpb.SpanConfig,
 	voterReplicas []roachpb.ReplicaDescriptor,
 	nonVoterReplicas []roachpb.ReplicaDescriptor,
+	witnessReplicas []roachpb.ReplicaDescriptor,
 ) (action AllocatorAction, adjustedPriority float64) {
 	// NB: The ordering of the checks in this method is intentional. The order in
 	// which these actions are returned by this method determines the relative
@@ -523,8 +585,9 @@ func (a *Allocator) computeAction(
 	// (which influence the replicateQueue's decision of which range it'll pick to
 	// repair/rebalance before the others).
 	//
-	// In broad strokes, we first handle all voting replica-based actions and then
-	// the actions pertaining to non-voting replicas. Within each replica set, we
+	// In broad strokes, we first handle all voting replica-based actions, then
+	// the actions pertaining to witnesses and finally the ones pertaining to
+	// non-voting replicas. Within each replica set, we
 	// first handle operations that correspond to repairing/recovering the range.
 	// After that we handle rebalancing related actions, followed by removal
 	// actions.
@@ -535,7 +598,10 @@ func (a *Allocator) computeAction(
 	clusterNodes := a.storePool.ClusterNodeCount()
 	neededVoters := GetNeededVoters(conf.GetNumVoters(), clusterNodes)
 	desiredQuorum := computeQuorum(neededVoters)
-	quorum := computeQuorum(haveVoters)
+	// NB: witnesses vote in the range's raft group, so they count towards its
+	// quorum even though they aren't considered voters by the allocator.
+	haveWitnesses := len(witnessReplicas)
+	quorum := computeQuorum(haveVoters + haveWitnesses)
 
 	// TODO(aayush): When haveVoters < neededVoters but we don't have quorum to
 	// actually execute the addition of a new replica, we should be returning a
@@ -559,15 +625,18 @@ func (a *Allocator) computeAction(
 	// elsewhere (for a regular rebalance or for decommissioning).
 	const includeSuspectAndDrainingStores = true
 	liveVoters, deadVoters := a.storePool.liveAndDeadReplicas(voterReplicas, includeSuspectAndDrainingStores)
+	liveWitnesses, deadWitnesses := a.storePool.liveAndDeadReplicas(
+		witnessReplicas, includeSuspectAndDrainingStores,
+	)
 
-	if len(liveVoters) < quorum {
+	if len(liveVoters)+len(liveWitnesses) < quorum {
 		// Do not take any replacement/removal action if we do not have a quorum of
 		// live voters. If we're correctly assessing the unavailable state of the
 		// range, we also won't be able to add replicas as we try above, but hope
 		// springs eternal.
 		action = AllocatorRangeUnavailable
-		log.VEventf(ctx, 1, "unable to take action - live voters %v don't meet quorum of %d",
-			liveVoters, quorum)
+		log.VEventf(ctx, 1, "unable to take action - live voters %v and witnesses %v don't meet quorum of %d",
+			liveVoters, liveWitnesses, quorum)
 		return action, action.Priority()
 	}
 
@@ -598,7 +667,7 @@ func (a *Allocator) computeAction(
 	if len(deadVoters) > 0 {
 		// The range has dead replicas, which should be removed immediately.
 		action = AllocatorRemoveDeadVoter
-		adjustedPriority = action.Priority() + float64(quorum-len(liveVoters))
+		adjustedPriority = action.Priority() + float64(quorum-len(liveVoters)-len(liveWitnesses))
 		log.VEventf(ctx, 3, "%s - dead=%d, live=%d, quorum=%d, priority=%.2f",
 			action, len(deadVoters), len(liveVoters), quorum, adjustedPriority)
 		return action, adjustedPriority
@@ -625,6 +694,33 @@ func (a *Allocator) computeAction(
 		return action, adjustedPriority
 	}
 
+	// Witness actions follow. Witnesses are added and removed one at a time, so a
+	// dead (or decommissioning) witness is replaced by first adding a new one and
+	// then removing the old one, which is preferred for removal by the
+	// replicateQueue.
+	neededWitnesses := GetNeededWitnesses(haveVoters, int(conf.NumWitnesses), clusterNodes)
+	if haveWitnesses < neededWitnesses {
+		action = AllocatorAddWitness
+		log.VEventf(ctx, 3, "%s - missing witness need=%d, have=%d, priority=%.2f",
+			action, neededWitnesses, haveWitnesses, action.Priority())
+		return action, action.Priority()
+	}
+
+	decommissioningWitnesses := a.storePool.decommissioningReplicas(witnessReplicas)
+	if haveWitnesses == neededWitnesses && len(deadWitnesses)+len(decommissioningWitnesses) > 0 {
+		action = AllocatorReplaceDeadWitness
+		log.VEventf(ctx, 3, "%s - replacement for %d dead and %d decommissioning witnesses priority=%.2f",
+			action, len(deadWitnesses), len(decommissioningWitnesses), action.Priority())
+		return action, action.Priority()
+	}
+
+	if haveWitnesses > neededWitnesses {
+		action = AllocatorRemoveWitness
+		log.VEventf(ctx, 3, "%s - need=%d, have=%d, priority=%.2f", action,
+			neededWitnesses, haveWitnesses, action.Priority())
+		return action, action.Priority()
+	}
+
 	// Non-voting replica actions follow.
 	//
 	// Non-voting replica addition / replacement.
@@ -798,6 +894,109 @@ func (a *Allocator) AllocateNonVoter(
 	return a.allocateTarget(ctx, conf, existingVoters, existingNonVoters, nonVoterTarget)
 }
 
+// AllocateWitness returns a suitable store for a new allocation of a witness
+// replica, as per the range's witness_constraints. Nodes already accommodating
+// _any_ existing replicas are ruled out as targets.
+func (a *Allocator) AllocateWitness(
+	ctx context.Context,
+	conf roachpb.SpanConfig,
+	existingReplicas, existingWitnesses []roachpb.ReplicaDescriptor,
+) (roachpb.ReplicationTarget, string, error) {
+	candidateStoreList, aliveStoreCount, throttled := a.storePool.getStoreList(storeFilterThrottled)
+
+	analyzedWitnessConstraints := constraint.AnalyzeConstraints(ctx, a.storePool.getStoreDescriptor,
+		existingWitnesses, conf.NumWitnesses, conf.WitnessConstraints)
+	// Witnesses are placed so as to maximize the diversity of the raft group
+	// they vote in, so all other replicas are taken into account.
+	allReplicas := make([]roachpb.ReplicaDescriptor, 0, len(existingReplicas)+len(existingWitnesses))
+	allReplicas = append(allReplicas, existingReplicas...)
+	allReplicas = append(allReplicas, existingWitnesses...)
+	candidates := rankedCandidateListForAllocation(
+		ctx,
+		candidateStoreList,
+		witnessConstraintsCheckerForAllocation(analyzedWitnessConstraints),
+		allReplicas,
+		a.storePool.getLocalitiesByStore(allReplicas),
+		a.storePool.isStoreReadyForRoutineReplicaTransfer,
+		false, /* allowMultipleReplsPerNode */
+		a.scorerOptions(),
+	)
+
+	log.VEventf(ctx, 3, "allocate %s: %s", witnessTarget, candidates)
+	if target := candidates.selectGood(a.randGen); target != nil {
+		log.VEventf(ctx, 3, "add target: %s", target)
+		details := decisionDetails{Target: target.compactString()}
+		detailsBytes, err := json.Marshal(details)
+		if err != nil {
+			log.Warningf(ctx, "failed to marshal details for choosing allocate target: %+v", err)
+		}
+		return roachpb.ReplicationTarget{
+			NodeID: target.store.Node.NodeID, StoreID: target.store.StoreID,
+		}, string(detailsBytes), nil
+	}
+
+	// When there are throttled stores that do match, we shouldn't send
+	// the replica to purgatory.
+	if len(throttled) > 0 {
+		return roachpb.ReplicationTarget{}, "", errors.Errorf(
+			"%d matching stores are currently throttled: %v", len(throttled), throttled,
+		)
+	}
+	return roachpb.ReplicationTarget{}, "", &allocatorError{
+		witnessConstraints: conf.WitnessConstraints,
+		existingVoterCount: len(existingReplicas),
+		aliveStores:        aliveStoreCount,
+		throttledStores:    len(throttled),
+	}
+}
+
+// RemoveWitness returns a suitable witness to remove from the provided set of
+// candidates, as per the range's witness_constraints.
+func (a Allocator) RemoveWitness(
+	ctx context.Context,
+	conf roachpb.SpanConfig,
+	witnessCandidates []roachpb.ReplicaDescriptor,
+	existingReplicas, existingWitnesses []roachpb.ReplicaDescriptor,
+	options scorerOptions,
+) (roachpb.ReplicationTarget, string, error) {
+	candidateStoreIDs := make(roachpb.StoreIDSlice, len(witnessCandidates))
+	for i, exist := range witnessCandidates {
+		candidateStoreIDs[i] = exist.StoreID
+	}
+	candidateStoreList, _, _ := a.storePool.getStoreListFromIDs(candidateStoreIDs, storeFilterNone)
+	if len(candidateStoreList.stores) == 0 {
+		return roachpb.ReplicationTarget{}, "", errors.Errorf(
+			"must supply at least one candidate witness to allocator.RemoveWitness()",
+		)
+	}
+
+	analyzedWitnessConstraints := constraint.AnalyzeConstraints(ctx, a.storePool.getStoreDescriptor,
+		existingWitnesses, conf.NumWitnesses, conf.WitnessConstraints)
+	allReplicas := make([]roachpb.ReplicaDescriptor, 0, len(existingReplicas)+len(existingWitnesses))
+	allReplicas = append(allReplicas, existingReplicas...)
+	allReplicas = append(allReplicas, existingWitnesses...)
+	rankedCandidates := candidateListForRemoval(
+		candidateStoreList,
+		witnessConstraintsCheckerForRemoval(analyzedWitnessConstraints),
+		a.storePool.getLocalitiesByStore(allReplicas),
+		options,
+	)
+
+	log.VEventf(ctx, 3, "remove %s: %s", witnessTarget, rankedCandidates)
+	if bad := rankedCandidates.selectBad(a.randGen); bad != nil {
+		log.VEventf(ctx, 3, "remove target: %s", bad)
+		details := decisionDetails{Target: bad.compactString()}
+		detailsBytes, err := json.Marshal(details)
+		if err != nil {
+			log.Warningf(ctx, "failed to marshal details for choosing remove target: %+v", err)
+		}
+		return roachpb.ReplicationTarget{
+			NodeID: bad.store.Node.NodeID, StoreID: bad.store.StoreID,
+		}, string(detailsBytes), nil
+	}
+	return roachpb.ReplicationTarget{}, "", errors.New("could not select an appropriate witness to be removed")
+}
+
 func (a *Allocator) allocateTargetFromList(
 	ctx context.Context,
 	candidateStores StoreList,
@@ -1492,8 +1691,10 @@ func (a *Allocator) TransferLeaseTarget(
 		defer a.randGen.Unlock()
 		return candidates[a.randGen.Intn(len(candidates))]
 
-	case qpsConvergence:
-		leaseReplQPS, _ := stats.avgQPS()
+	case loadConvergence:
+		// NB: For this goal, stats measure the load along the dimension given by
+		// the objective, i.e. the lease's QPS or its request evaluation CPU.
+		leaseReplLoad, _ := stats.avgQPS()
 		candidates := make([]roachpb.StoreID, 0, len(existing)-1)
 		for _, repl := range existing {
 			if repl.StoreID != leaseRepl.StoreID() {
@@ -1501,20 +1702,28 @@ func (a *Allocator) TransferLeaseTarget(
 			}
 		}
 
-		// When the goal is to further QPS convergence across stores, we ensure that
-		// any lease transfer decision we make *reduces the delta between the store
-		// serving the highest QPS and the store serving the lowest QPS* among our
-		// list of candidates.
+		// When the goal is to further load convergence across stores, we ensure
+		// that any lease transfer decision we make *reduces the delta between the
+		// store serving the highest load and the store serving the lowest load*
+		// among our list of candidates.
 		//
 		// NB: We're assuming that the lease transfer will move all of the
 		// leaseholder's load to the replica that receives the lease. This will not
 		// be true in all cases (some percentage of the leaseholder's traffic could
 		// be follower read traffic). See
 		// https://github.com/cockroachdb/cockroach/issues/75630.
-		bestStore, noRebalanceReason := bestStoreToMinimizeQPSDelta(
-			leaseReplQPS,
-			qpsRebalanceThreshold.Get(&a.storePool.st.SV),
-			minQPSDifferenceForTransfers.Get(&a.storePool.st.SV),
+		//
+		// Unlike replica rebalances, lease transfers are not allowed to make the
+		// receiving store any hotter than the sending store (see
+		// maxQPSTransferOvershoot), which lets the store rebalancer move on to
+		// a cooler lease instead.
+		rebalanceThreshold, minRequiredDiff := a.loadRebalanceThresholds(opts.objective)
+		bestStore, noRebalanceReason := bestStoreToMinimizeLoadDelta(
+			opts.objective,
+			leaseReplLoad,
+			rebalanceThreshold,
+			minRequiredDiff,
+			0, /* maxOvershoot */
 			leaseRepl.StoreID(),
 			candidates,
 			storeDescMap,
@@ -1539,7 +1748,7 @@ func (a *Allocator) TransferLeaseTarget(
 			return roachpb.ReplicaDescriptor{}
 		case significantlySwitchesRelativeDisposition:
 			log.VEventf(ctx, 5,
-				"r%d: lease transfer away from s%d would make it hotter than the coldest follower",
+				"r%d: lease transfer away from s%d would make the coldest follower hotter than it",
 				leaseRepl.GetRangeID(), leaseRepl.StoreID())
 			return roachpb.ReplicaDescriptor{}
 		case missingStatsForExistingStore:
@@ -1552,13 +1761,13 @@ func (a *Allocator) TransferLeaseTarget(
 			log.VEventf(
 				ctx,
 				5,
-				"r%d: should transfer lease (qps=%0.2f) from s%d (qps=%0.2f) to s%d (qps=%0.2f)",
+				"r%d: should transfer lease (%s) from s%d (%s) to s%d (%s)",
 				leaseRepl.GetRangeID(),
-				leaseReplQPS,
+				opts.objective.format(leaseReplLoad),
 				leaseRepl.StoreID(),
-				storeDescMap[leaseRepl.StoreID()].Capacity.QueriesPerSecond,
+				opts.objective.format(opts.objective.storeLoad(storeDescMap[leaseRepl.StoreID()].Capacity)),
 				bestStore,
-				storeDescMap[bestStore].Capacity.QueriesPerSecond,
+				opts.objective.format(opts.objective.storeLoad(storeDescMap[bestStore].Capacity)),
 			)
 		default:
 			log.Fatalf(ctx, "unknown declineReason: %v", noRebalanceReason)
@@ -1576,44 +1785,57 @@ func (a *Allocator) TransferLeaseTarget(
 	panic("unreachable")
 }
 
-// getCandidateWithMinQPS returns the StoreID that belongs to the store serving
-// the lowest QPS among all the `candidates` stores.
-func getCandidateWithMinQPS(
-	storeQPSMap map[roachpb.StoreID]float64, candidates []roachpb.StoreID,
+// loadRebalanceThresholds returns the rebalance threshold (as a fraction of
+// the mean) and the minimum load difference between two stores required for a
+// lease or replica transfer, for the given load-based rebalancing objective.
+func (a *Allocator) loadRebalanceThresholds(
+	objective LBRebalancingObjective,
+) (rebalanceThreshold, minRequiredDiff float64) {
+	sv := &a.storePool.st.SV
+	if objective == LBRebalancingCPU {
+		return cpuRebalanceThreshold.Get(sv), float64(minCPUDifferenceForTransfers.Get(sv))
+	}
+	return qpsRebalanceThreshold.Get(sv), minQPSDifferenceForTransfers.Get(sv)
+}
+
+// getCandidateWithMinLoad returns the StoreID that belongs to the store serving
+// the lowest load among all the `candidates` stores.
+func getCandidateWithMinLoad(
+	storeLoadMap map[roachpb.StoreID]float64, candidates []roachpb.StoreID,
 ) (bestCandidate roachpb.StoreID) {
-	minCandidateQPS := math.MaxFloat64
+	minCandidateLoad := math.MaxFloat64
 	for _, store := range candidates {
-		candidateQPS, ok := storeQPSMap[store]
+		candidateLoad, ok := storeLoadMap[store]
 		if !ok {
 			continue
 		}
-		if minCandidateQPS > candidateQPS {
-			minCandidateQPS = candidateQPS
+		if minCandidateLoad > candidateLoad {
+			minCandidateLoad = candidateLoad
 			bestCandidate = store
 		}
 	}
 	return bestCandidate
 }
 
-// getQPSDelta returns the difference between the store serving the highest QPS
-// and the store serving the lowest QPS, among the set of stores in the
+// getLoadDelta returns the difference between the store serving the highest
+// load and the store serving the lowest load, among the set of stores in the
 // `domain`.
-func getQPSDelta(storeQPSMap map[roachpb.StoreID]float64, domain []roachpb.StoreID) float64 {
-	maxCandidateQPS := float64(0)
-	minCandidateQPS := math.MaxFloat64
+func getLoadDelta(storeLoadMap map[roachpb.StoreID]float64, domain []roachpb.StoreID) float64 {
+	maxCandidateLoad := float64(0)
+	minCandidateLoad := math.MaxFloat64
 	for _, cand := range domain {
-		candidateQPS, ok := storeQPSMap[cand]
+		candidateLoad, ok := storeLoadMap[cand]
 		if !ok {
 			continue
 		}
-		if maxCandidateQPS < candidateQPS {
-			maxCandidateQPS = candidateQPS
+		if maxCandidateLoad < candidateLoad {
+			maxCandidateLoad = candidateLoad
 		}
-		if minCandidateQPS > candidateQPS {
-			minCandidateQPS = candidateQPS
+		if minCandidateLoad > candidateLoad {
+			minCandidateLoad = candidateLoad
 		}
 	}
-	return maxCandidateQPS - minCandidateQPS
+	return maxCandidateLoad - minCandidateLoad
 }
 
 // ShouldTransferLease returns true if the specified store is overfull in terms
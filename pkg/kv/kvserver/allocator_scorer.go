@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/constraint"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -288,15 +289,18 @@ func (o *rangeCountScorerOptions) removalMaximallyConvergesScore(
 	return 0
 }
 
-// qpsScorerOptions is used by the StoreRebalancer to tell the Allocator's
-// rebalancing machinery to base its balance/convergence scores on
-// queries-per-second. This means that the resulting rebalancing decisions will
-// further the goal of converging QPS across stores in the cluster.
-type qpsScorerOptions struct {
-	deterministic                             bool
-	qpsRebalanceThreshold, minRequiredQPSDiff float64
-
-	// QPS-based rebalancing assumes that:
+// loadScorerOptions is used by the StoreRebalancer to tell the Allocator's
+// rebalancing machinery to base its balance/convergence scores on the load
+// dimension given by the rebalancing objective (queries-per-second or CPU).
+// This means that the resulting rebalancing decisions will further the goal of
+// converging that load across stores in the cluster.
+type loadScorerOptions struct {
+	deterministic bool
+	// objective is the load dimension being balanced.
+	objective                           LBRebalancingObjective
+	rebalanceThreshold, minRequiredDiff float64
+
+	// Load-based rebalancing assumes that:
 	// 1. Every replica of a range currently receives the same level of traffic.
 	// 2. Transferring this replica to another store would also transfer all of
 	// this replica's load onto that receiving store.
@@ -308,24 +312,24 @@ type qpsScorerOptions struct {
 	// track it separately yet. See
 	// https://github.com/cockroachdb/cockroach/issues/75630.
 
-	// qpsPerReplica states the level of traffic being served by each replica in a
+	// loadPerReplica states the level of load being served by each replica in a
 	// range.
-	qpsPerReplica float64
+	loadPerReplica float64
 }
 
-func (o *qpsScorerOptions) maybeJitterStoreStats(sl StoreList, _ allocatorRand) StoreList {
+func (o *loadScorerOptions) maybeJitterStoreStats(sl StoreList, _ allocatorRand) StoreList {
 	return sl
 }
 
-func (o *qpsScorerOptions) deterministicForTesting() bool {
+func (o *loadScorerOptions) deterministicForTesting() bool {
 	return o.deterministic
 }
 
 // shouldRebalanceBasedOnThresholds tries to determine if, within the given
 // equivalenceClass `eqClass`, rebalancing a replica from one of the existing
-// stores to one of the candidate stores will lead to QPS convergence among the
-// stores in the equivalence class.
-func (o *qpsScorerOptions) shouldRebalanceBasedOnThresholds(
+// stores to one of the candidate stores will lead to load convergence among
+// the stores in the equivalence class.
+func (o *loadScorerOptions) shouldRebalanceBasedOnThresholds(
 	ctx context.Context, eqClass equivalenceClass,
 ) bool {
 	if len(eqClass.candidateSL.stores) == 0 {
@@ -353,20 +357,20 @@ func (o *qpsScorerOptions) shouldRebalanceBasedOnThresholds(
 			eqClass.existing.StoreID,
 		)
 	case missingStatsForExistingStore:
-		log.VEventf(ctx, 4, "missing QPS stats for s%d", eqClass.existing.StoreID)
+		log.VEventf(ctx, 4, "missing %s stats for s%d", o.objective, eqClass.existing.StoreID)
 	case shouldRebalance:
-		var bestStoreQPS float64
+		var bestStoreLoad float64
 		for _, store := range eqClass.candidateSL.stores {
 			if bestStore == store.StoreID {
-				bestStoreQPS = store.Capacity.QueriesPerSecond
+				bestStoreLoad = o.objective.storeLoad(store.Capacity)
 			}
 		}
 		log.VEventf(
 			ctx, 4,
-			"should rebalance replica with %0.2f qps from s%d (qps=%0.2f) to s%d (qps=%0.2f)",
-			o.qpsPerReplica, eqClass.existing.StoreID,
-			eqClass.existing.Capacity.QueriesPerSecond,
-			bestStore, bestStoreQPS,
+			"should rebalance replica with %s from s%d (%s) to s%d (%s)",
+			o.objective.format(o.loadPerReplica), eqClass.existing.StoreID,
+			o.objective.format(o.objective.storeLoad(eqClass.existing.Capacity)),
+			bestStore, o.objective.format(bestStoreLoad),
 		)
 	default:
 		log.Fatalf(ctx, "unknown reason to decline rebalance: %v", declineReason)
@@ -375,24 +379,25 @@ func (o *qpsScorerOptions) shouldRebalanceBasedOnThresholds(
 	return declineReason == shouldRebalance
 }
 
-func (o *qpsScorerOptions) balanceScore(sl StoreList, sc roachpb.StoreCapacity) balanceStatus {
-	maxQPS := overfullQPSThreshold(o, sl.candidateQueriesPerSecond.mean)
-	minQPS := underfullQPSThreshold(o, sl.candidateQueriesPerSecond.mean)
-	curQPS := sc.QueriesPerSecond
-	if curQPS < minQPS {
+func (o *loadScorerOptions) balanceScore(sl StoreList, sc roachpb.StoreCapacity) balanceStatus {
+	mean := o.objective.meanLoad(sl)
+	maxLoad := overfullLoadThreshold(o, mean)
+	minLoad := underfullLoadThreshold(o, mean)
+	curLoad := o.objective.storeLoad(sc)
+	if curLoad < minLoad {
 		return underfull
-	} else if curQPS >= maxQPS {
+	} else if curLoad >= maxLoad {
 		return overfull
 	}
 	return aroundTheMean
 }
 
 // rebalanceFromConvergesScore returns a score of -1 if the existing store in
-// eqClass needs to be rebalanced away in order to minimize the QPS delta
+// eqClass needs to be rebalanced away in order to minimize the load delta
 // between the stores in the equivalence class `eqClass`.
-func (o *qpsScorerOptions) rebalanceFromConvergesScore(eqClass equivalenceClass) int {
+func (o *loadScorerOptions) rebalanceFromConvergesScore(eqClass equivalenceClass) int {
 	_, declineReason := o.getRebalanceTargetToMinimizeDelta(eqClass)
-	// If there are any rebalance opportunities that minimize the QPS delta in
+	// If there are any rebalance opportunities that minimize the load delta in
 	// this equivalence class, we return a score of -1 to make the existing store
 	// more likely to be picked for removal.
 	if declineReason == shouldRebalance {
@@ -402,9 +407,9 @@ func (o *qpsScorerOptions) rebalanceFromConvergesScore(eqClass equivalenceClass)
 }
 
 // rebalanceToConvergesScore returns a score of 1 if `candidate` needs to be
-// rebalanced to in order to minimize the QPS delta between the stores in the
+// rebalanced to in order to minimize the load delta between the stores in the
 // equivalence class `eqClass`
-func (o *qpsScorerOptions) rebalanceToConvergesScore(
+func (o *loadScorerOptions) rebalanceToConvergesScore(
 	eqClass equivalenceClass, candidate roachpb.StoreDescriptor,
 ) int {
 	bestTarget, declineReason := o.getRebalanceTargetToMinimizeDelta(eqClass)
@@ -415,21 +420,21 @@ func (o *qpsScorerOptions) rebalanceToConvergesScore(
 }
 
 // removalMaximallyConvergesScore returns a score of -1 `existing` is the
-// hottest store (based on QPS) among the stores inside
+// hottest store (based on the objective's load) among the stores inside
 // `removalCandidateStores`.
-func (o *qpsScorerOptions) removalMaximallyConvergesScore(
+func (o *loadScorerOptions) removalMaximallyConvergesScore(
 	removalCandStoreList StoreList, existing roachpb.StoreDescriptor,
 ) int {
-	maxQPS := float64(-1)
+	maxLoad := float64(-1)
 	for _, store := range removalCandStoreList.stores {
-		if store.Capacity.QueriesPerSecond > maxQPS {
-			maxQPS = store.Capacity.QueriesPerSecond
+		if load := o.objective.storeLoad(store.Capacity); load > maxLoad {
+			maxLoad = load
 		}
 	}
 	// NB: Note that if there are multiple stores inside `removalCandStoreList`
-	// with the same (or similar) maxQPS, we will return a
+	// with the same (or similar) maxLoad, we will return a
 	// removalMaximallyConvergesScore of -1 for all of them.
-	if scoresAlmostEqual(maxQPS, existing.Capacity.QueriesPerSecond) {
+	if scoresAlmostEqual(maxLoad, o.objective.storeLoad(existing.Capacity)) {
 		return -1
 	}
 	return 0
@@ -930,12 +935,20 @@ const (
 	// other words, we generally disallow rebalances were qps(s1) < qps(s2) before
 	// the rebalance but qps(s1) > qps(s2) after the rebalance. However, an
 	// exception to this is that if the inversion is insignificant (less than
-	// minQPSTransferOvershoot qps).
+	// maxQPSTransferOvershoot qps).
+	//
+	// The exception only applies to replica rebalancing. Lease transfers are
+	// cheap and fine-grained enough that the store rebalancer can always choose
+	// a different lease instead, so they never invert the relative dispositions
+	// of the stores.
 	maxQPSTransferOvershoot = 500
+	// maxCPUTransferOvershoot is the analog of maxQPSTransferOvershoot for
+	// CPU-based rebalancing, in nanoseconds of CPU time per second.
+	maxCPUTransferOvershoot = float64(500 * time.Millisecond)
 )
 
 // declineReason enumerates the various results of a call into
-// `bestStoreToMinimizeQPSDelta`. The result may be that we have a good
+// `bestStoreToMinimizeLoadDelta`. The result may be that we have a good
 // candidate to rebalance to (indicated by `shouldRebalance`) or it might be
 // rejected due to a number of reasons (see below).
 type declineReason int
@@ -953,7 +966,7 @@ const (
 	existingNotOverfull
 	// deltaNotSignificant indicates that the delta between the existing store and
 	// the best candidate store is not high enough to justify a lease transfer or
-	// replica rebalance. This delta is computed _ignoring_ the QPS of the
+	// replica rebalance. This delta is computed _ignoring_ the load of the
 	// lease/replica in question.
 	deltaNotSignificant
 	// significantlySwitchesRelativeDisposition indicates that the lease / replica
@@ -962,35 +975,38 @@ const (
 	significantlySwitchesRelativeDisposition
 	// missingStatsForExistingStore indicates that we're missing the store
 	// descriptor of the existing store, which means we don't have access to the
-	// QPS levels of the existing store. Nothing we can do in this case except
+	// load levels of the existing store. Nothing we can do in this case except
 	// bail early.
 	missingStatsForExistingStore
 )
 
-// bestStoreToMinimizeQPSDelta computes a rebalance (or lease transfer) target
+// bestStoreToMinimizeLoadDelta computes a rebalance (or lease transfer) target
 // for the existing store such that executing the rebalance (or lease transfer)
-// decision would minimize the QPS range between the existing store and the
-// coldest store in the equivalence class.
-func bestStoreToMinimizeQPSDelta(
-	replQPS, rebalanceThreshold, minRequiredQPSDiff float64,
+// decision would minimize the load range, along the dimension given by the
+// objective, between the existing store and the coldest store in the
+// equivalence class. The target is not allowed to end up hotter than the
+// existing store by more than maxOvershoot.
+func bestStoreToMinimizeLoadDelta(
+	objective LBRebalancingObjective,
+	replLoad, rebalanceThreshold, minRequiredDiff, maxOvershoot float64,
 	existing roachpb.StoreID,
 	candidates []roachpb.StoreID,
 	storeDescMap map[roachpb.StoreID]*roachpb.StoreDescriptor,
 ) (bestCandidate roachpb.StoreID, reason declineReason) {
-	storeQPSMap := make(map[roachpb.StoreID]float64, len(candidates)+1)
+	storeLoadMap := make(map[roachpb.StoreID]float64, len(candidates)+1)
 	for _, store := range candidates {
 		if desc, ok := storeDescMap[store]; ok {
-			storeQPSMap[store] = desc.Capacity.QueriesPerSecond
+			storeLoadMap[store] = objective.storeLoad(desc.Capacity)
 		}
 	}
 	desc, ok := storeDescMap[existing]
 	if !ok {
 		return 0, missingStatsForExistingStore
 	}
-	storeQPSMap[existing] = desc.Capacity.QueriesPerSecond
+	storeLoadMap[existing] = objective.storeLoad(desc.Capacity)
 
 	// domain defines the domain over which this function tries to minimize the
-	// QPS delta.
+	// load delta.
 	domain := append(candidates, existing)
 	storeDescs := make([]roachpb.StoreDescriptor, 0, len(domain))
 	for _, desc := range storeDescMap {
@@ -998,57 +1014,58 @@ func bestStoreToMinimizeQPSDelta(
 	}
 	domainStoreList := makeStoreList(storeDescs)
 
-	bestCandidate = getCandidateWithMinQPS(storeQPSMap, candidates)
+	bestCandidate = getCandidateWithMinLoad(storeLoadMap, candidates)
 	if bestCandidate == 0 {
 		return 0, noBetterCandidate
 	}
 
-	bestCandQPS := storeQPSMap[bestCandidate]
-	existingQPS := storeQPSMap[existing]
-	if bestCandQPS > existingQPS {
+	bestCandLoad := storeLoadMap[bestCandidate]
+	existingLoad := storeLoadMap[existing]
+	if bestCandLoad > existingLoad {
 		return 0, noBetterCandidate
 	}
 
-	// NB: The store's QPS and the replica's QPS aren't captured at the same
+	// NB: The store's load and the replica's load aren't captured at the same
 	// time, so they may be mutually inconsistent. Thus, it is possible for
-	// the store's QPS captured here to be lower than the replica's QPS. So we
+	// the store's load captured here to be lower than the replica's load. So we
 	// defensively use the `math.Max` here.
-	existingQPSIgnoringRepl := math.Max(existingQPS-replQPS, 0)
+	existingLoadIgnoringRepl := math.Max(existingLoad-replLoad, 0)
 
-	// Only proceed if the QPS difference between `existing` and
+	// Only proceed if the load difference between `existing` and
 	// `bestCandidate` (not accounting for the replica under consideration) is
-	// higher than `minQPSDifferenceForTransfers`.
-	diffIgnoringRepl := existingQPSIgnoringRepl - bestCandQPS
-	if diffIgnoringRepl < minRequiredQPSDiff {
+	// higher than `minRequiredDiff`.
+	diffIgnoringRepl := existingLoadIgnoringRepl - bestCandLoad
+	if diffIgnoringRepl < minRequiredDiff {
 		return 0, deltaNotSignificant
 	}
 
 	// Only proceed with rebalancing iff `existingStore` is overfull relative to
 	// the equivalence class.
-	mean := domainStoreList.candidateQueriesPerSecond.mean
-	overfullThreshold := overfullQPSThreshold(
-		&qpsScorerOptions{qpsRebalanceThreshold: rebalanceThreshold},
+	mean := objective.meanLoad(domainStoreList)
+	overfullThreshold := overfullLoadThreshold(
+		&loadScorerOptions{objective: objective, rebalanceThreshold: rebalanceThreshold},
 		mean,
 	)
-	if existingQPS < overfullThreshold {
+	if existingLoad < overfullThreshold {
 		return 0, existingNotOverfull
 	}
 
-	currentQPSDelta := getQPSDelta(storeQPSMap, domain)
-	// Simulate the coldest candidate's QPS after it receives a lease/replica for
-	// the range.
-	storeQPSMap[bestCandidate] += replQPS
-	// Simulate the hottest existing store's QPS after it sheds the lease/replica
-	// away.
-	storeQPSMap[existing] = existingQPSIgnoringRepl
-	bestCandQPSWithRepl := storeQPSMap[bestCandidate]
+	currentLoadDelta := getLoadDelta(storeLoadMap, domain)
+	// Simulate the coldest candidate's load after it receives a lease/replica
+	// for the range.
+	storeLoadMap[bestCandidate] += replLoad
+	// Simulate the hottest existing store's load after it sheds the
+	// lease/replica away.
+	storeLoadMap[existing] = existingLoadIgnoringRepl
+	bestCandLoadWithRepl := storeLoadMap[bestCandidate]
 
-	if existingQPSIgnoringRepl+maxQPSTransferOvershoot < bestCandQPSWithRepl {
+	if existingLoadIgnoringRepl+maxOvershoot < bestCandLoadWithRepl {
 		return 0, significantlySwitchesRelativeDisposition
 	}
 
-	// NB: We proceed with a lease transfer / rebalance even if `currentQPSDelta`
-	// is exactly equal to `newQPSDelta`. Consider the following example:
+	// NB: We proceed with a lease transfer / rebalance even if
+	// `currentLoadDelta` is exactly equal to `newLoadDelta`. Consider the
+	// following example:
 	// perReplicaQPS: 10qps
 	// existingQPS: 100qps
 	// candidates: [100qps, 0qps, 0qps]
@@ -1057,13 +1074,14 @@ func bestStoreToMinimizeQPSDelta(
 	// store to the coldest store is not going to reduce the delta between all
 	// these stores, but it is still a desirable action to take.
 
-	newQPSDelta := getQPSDelta(storeQPSMap, domain)
-	if currentQPSDelta < newQPSDelta {
+	newLoadDelta := getLoadDelta(storeLoadMap, domain)
+	if currentLoadDelta < newLoadDelta {
 		panic(
 			fmt.Sprintf(
-				"programming error: projected QPS delta higher than current delta;"+
-					" existing: %0.2f qps, coldest candidate: %0.2f qps, replica/lease: %0.2f qps",
-				existingQPS, bestCandQPS, replQPS,
+				"programming error: projected %s delta higher than current delta;"+
+					" existing: %s, coldest candidate: %s, replica/lease: %s",
+				objective, objective.format(existingLoad), objective.format(bestCandLoad),
+				objective.format(replLoad),
 			),
 		)
 	}
@@ -1075,7 +1093,7 @@ func bestStoreToMinimizeQPSDelta(
 // candidates in the equivalence class) such that rebalancing to this store
 // would minimize the delta between the existing store and the coldest store in
 // the equivalence class.
-func (o *qpsScorerOptions) getRebalanceTargetToMinimizeDelta(
+func (o *loadScorerOptions) getRebalanceTargetToMinimizeDelta(
 	eqClass equivalenceClass,
 ) (bestStore roachpb.StoreID, declineReason declineReason) {
 	domainStoreList := makeStoreList(append(eqClass.candidateSL.stores, eqClass.existing))
@@ -1083,10 +1101,12 @@ func (o *qpsScorerOptions) getRebalanceTargetToMinimizeDelta(
 	for _, store := range eqClass.candidateSL.stores {
 		candidates = append(candidates, store.StoreID)
 	}
-	return bestStoreToMinimizeQPSDelta(
-		o.qpsPerReplica,
-		o.qpsRebalanceThreshold,
-		o.minRequiredQPSDiff,
+	return bestStoreToMinimizeLoadDelta(
+		o.objective,
+		o.loadPerReplica,
+		o.rebalanceThreshold,
+		o.minRequiredDiff,
+		o.objective.maxTransferOvershoot(),
 		eqClass.existing.StoreID,
 		candidates,
 		storeListToMap(domainStoreList),
@@ -1503,6 +1523,31 @@ func nonVoterConstraintsCheckerForRemoval(
 	}
 }
 
+// witnessConstraintsCheckerForAllocation returns a constraintsCheckFn that
+// determines whether a candidate for a new witness is valid and/or necessary as
+// per the `witness_constraints` on the range.
+//
+// NB: Witnesses don't hold any data, so the overall `constraints` don't apply
+// to them.
+func witnessConstraintsCheckerForAllocation(
+	witnessConstraints constraint.AnalyzedConstraints,
+) constraintsCheckFn {
+	return func(s roachpb.StoreDescriptor) (valid, necessary bool) {
+		return allocateConstraintsCheck(s, witnessConstraints)
+	}
+}
+
+// witnessConstraintsCheckerForRemoval returns a constraintsCheckFn that
+// determines whether an existing witness is valid and/or necessary with respect
+// to the `witness_constraints` on the range.
+func witnessConstraintsCheckerForRemoval(
+	witnessConstraints constraint.AnalyzedConstraints,
+) constraintsCheckFn {
+	return func(s roachpb.StoreDescriptor) (valid, necessary bool) {
+		return removeConstraintsCheck(s, witnessConstraints)
+	}
+}
+
 // voterConstraintsCheckerForRebalance returns a rebalanceConstraintsCheckFn
 // that determines whether a given store is a valid and/or necessary rebalance
 // candidate from a given store of an existing voting replica.
@@ -1825,12 +1870,12 @@ func underfullRangeThreshold(options *rangeCountScorerOptions, mean float64) flo
 	return mean - math.Max(mean*options.rangeRebalanceThreshold, minRangeRebalanceThreshold)
 }
 
-func overfullQPSThreshold(options *qpsScorerOptions, mean float64) float64 {
-	return mean + math.Max(mean*options.qpsRebalanceThreshold, minQPSThresholdDifference)
+func overfullLoadThreshold(options *loadScorerOptions, mean float64) float64 {
+	return mean + math.Max(mean*options.rebalanceThreshold, options.objective.minThresholdDifference())
 }
 
-func underfullQPSThreshold(options *qpsScorerOptions, mean float64) float64 {
-	return mean - math.Max(mean*options.qpsRebalanceThreshold, minQPSThresholdDifference)
+func underfullLoadThreshold(options *loadScorerOptions, mean float64) float64 {
+	return mean - math.Max(mean*options.rebalanceThreshold, options.objective.minThresholdDifference())
 }
 
 func rebalanceConvergesRangeCountOnMean(
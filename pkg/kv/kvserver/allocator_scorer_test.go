@@ -1517,8 +1517,8 @@ func TestRebalanceBalanceScoreOnQPS(t *testing.T) {
 	storeList := StoreList{
 		candidateQueriesPerSecond: stat{mean: 1000},
 	}
-	options := qpsScorerOptions{
-		qpsRebalanceThreshold: 0.1,
+	options := loadScorerOptions{
+		rebalanceThreshold: 0.1,
 	}
 
 	testCases := []struct {
@@ -1465,9 +1465,9 @@ func TestAllocatorRebalanceByQPS(t *testing.T) {
 		defer stopper.Stop(ctx)
 		gossiputil.NewStoreGossiper(g).GossipStores(subtest.testStores, t)
 		var rangeUsageInfo RangeUsageInfo
-		options := &qpsScorerOptions{
-			qpsPerReplica:         100,
-			qpsRebalanceThreshold: 0.2,
+		options := &loadScorerOptions{
+			loadPerReplica:     100,
+			rebalanceThreshold: 0.2,
 		}
 		add, remove, _, ok := a.RebalanceVoter(
 			ctx,
@@ -1574,8 +1574,8 @@ func TestAllocatorRemoveBasedOnQPS(t *testing.T) {
 		stopper, g, _, a, _ := createTestAllocator(ctx, 10, false /* deterministic */)
 		defer stopper.Stop(ctx)
 		gossiputil.NewStoreGossiper(g).GossipStores(subtest.testStores, t)
-		options := &qpsScorerOptions{
-			qpsRebalanceThreshold: 0.1,
+		options := &loadScorerOptions{
+			rebalanceThreshold: 0.1,
 		}
 		remove, _, err := a.RemoveVoter(
 			ctx,
@@ -6221,6 +6221,88 @@ func TestAllocatorComputeActionRemoveDead(t *testing.T) {
 	}
 }
 
+func TestAllocatorComputeActionWitnesses(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	conf := roachpb.SpanConfig{NumReplicas: 2, NumWitnesses: 1}
+	twoReplDesc := roachpb.RangeDescriptor{
+		InternalReplicas: []roachpb.ReplicaDescriptor{
+			{StoreID: 1, NodeID: 1, ReplicaID: 1},
+			{StoreID: 2, NodeID: 2, ReplicaID: 2},
+		},
+	}
+	withWitnessDesc := twoReplDesc
+	withWitnessDesc.InternalReplicas = append(withWitnessDesc.InternalReplicas[:2:2], roachpb.ReplicaDescriptor{
+		StoreID: 3, NodeID: 3, ReplicaID: 3, Type: roachpb.ReplicaTypeWitness(),
+	})
+	withTwoWitnessesDesc := withWitnessDesc
+	withTwoWitnessesDesc.InternalReplicas = append(withTwoWitnessesDesc.InternalReplicas[:3:3], roachpb.ReplicaDescriptor{
+		StoreID: 4, NodeID: 4, ReplicaID: 4, Type: roachpb.ReplicaTypeWitness(),
+	})
+
+	testCases := []struct {
+		desc           roachpb.RangeDescriptor
+		live           []roachpb.StoreID
+		dead           []roachpb.StoreID
+		expectedAction AllocatorAction
+	}{
+		// Missing a witness.
+		{
+			desc:           twoReplDesc,
+			live:           []roachpb.StoreID{1, 2, 3},
+			expectedAction: AllocatorAddWitness,
+		},
+		// Nothing to do.
+		{
+			desc:           withWitnessDesc,
+			live:           []roachpb.StoreID{1, 2, 3},
+			expectedAction: AllocatorConsiderRebalance,
+		},
+		// One of the voters is dead, but the witness maintains quorum so the voter
+		// can be replaced.
+		{
+			desc:           withWitnessDesc,
+			live:           []roachpb.StoreID{1, 3, 4},
+			dead:           []roachpb.StoreID{2},
+			expectedAction: AllocatorReplaceDeadVoter,
+		},
+		// The witness is dead.
+		{
+			desc:           withWitnessDesc,
+			live:           []roachpb.StoreID{1, 2, 4},
+			dead:           []roachpb.StoreID{3},
+			expectedAction: AllocatorReplaceDeadWitness,
+		},
+		// The dead witness has been replaced and now needs to be removed.
+		{
+			desc:           withTwoWitnessesDesc,
+			live:           []roachpb.StoreID{1, 2, 4},
+			dead:           []roachpb.StoreID{3},
+			expectedAction: AllocatorRemoveWitness,
+		},
+		// A voter and the witness are dead (i.e. the range lacks a quorum).
+		{
+			desc:           withWitnessDesc,
+			live:           []roachpb.StoreID{1, 4},
+			dead:           []roachpb.StoreID{2, 3},
+			expectedAction: AllocatorRangeUnavailable,
+		},
+	}
+
+	ctx := context.Background()
+	stopper, _, sp, a, _ := createTestAllocator(ctx, 10, false /* deterministic */)
+	defer stopper.Stop(ctx)
+
+	for i, tcase := range testCases {
+		mockStorePool(sp, tcase.live, nil, tcase.dead, nil, nil, nil)
+		action, _ := a.ComputeAction(ctx, conf, &tcase.desc)
+		if tcase.expectedAction != action {
+			t.Errorf("Test case %d expected action %s, got action %s", i, tcase.expectedAction, action)
+		}
+	}
+}
+
 func TestAllocatorComputeActionSuspect(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -7707,9 +7789,9 @@ func qpsBasedRebalanceFn(
 ) {
 	avgQPS := candidate.Capacity.QueriesPerSecond / float64(candidate.Capacity.RangeCount)
 	jitteredQPS := avgQPS * (1 + alloc.randGen.Float64())
-	opts := &qpsScorerOptions{
-		qpsPerReplica:         jitteredQPS,
-		qpsRebalanceThreshold: 0.2,
+	opts := &loadScorerOptions{
+		loadPerReplica:     jitteredQPS,
+		rebalanceThreshold: 0.2,
 	}
 	var rangeUsageInfo RangeUsageInfo
 	add, remove, details, ok := alloc.RebalanceVoter(
@@ -924,6 +924,7 @@ func runTestDBAddSSTable(
 	var allowShadowingBelow hlc.Timestamp
 	var nilStats *enginepb.MVCCStats
 	var noTS hlc.Timestamp
+	var noAdmissionHeader roachpb.AdmissionHeader
 	cs := cluster.MakeTestingClusterSettings()
 
 	{
@@ -931,13 +932,13 @@ func runTestDBAddSSTable(
 
 		// Key is before the range in the request span.
 		err := db.AddSSTable(
-			ctx, "d", "e", sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS)
+			ctx, "d", "e", sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS, noAdmissionHeader)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "not in request range")
 
 		// Key is after the range in the request span.
 		err = db.AddSSTable(
-			ctx, "a", "b", sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS)
+			ctx, "a", "b", sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS, noAdmissionHeader)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "not in request range")
 
@@ -945,7 +946,7 @@ func runTestDBAddSSTable(
 		ingestCtx, getRecAndFinish := tracing.ContextWithRecordingSpan(ctx, tr, "test-recording")
 		defer getRecAndFinish()
 		require.NoError(t, db.AddSSTable(
-			ingestCtx, start, end, sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS))
+			ingestCtx, start, end, sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS, noAdmissionHeader))
 		trace := getRecAndFinish().String()
 		require.Contains(t, trace, "evaluating AddSSTable")
 		require.Contains(t, trace, "sideloadable proposal detected")
@@ -971,7 +972,7 @@ func runTestDBAddSSTable(
 	{
 		sst, start, end := sstutil.MakeSST(t, cs, []sstutil.KV{{"bb", 1, "2"}})
 		require.NoError(t, db.AddSSTable(
-			ctx, start, end, sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS))
+			ctx, start, end, sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS, noAdmissionHeader))
 		r, err := db.Get(ctx, "bb")
 		require.NoError(t, err)
 		require.Equal(t, []byte("1"), r.ValueBytes())
@@ -994,7 +995,7 @@ func runTestDBAddSSTable(
 			defer getRecAndFinish()
 
 			require.NoError(t, db.AddSSTable(
-				ingestCtx, start, end, sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS))
+				ingestCtx, start, end, sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS, noAdmissionHeader))
 			trace := getRecAndFinish().String()
 			require.Contains(t, trace, "evaluating AddSSTable")
 			require.Contains(t, trace, "sideloadable proposal detected")
@@ -1030,7 +1031,7 @@ func runTestDBAddSSTable(
 			defer getRecAndFinish()
 
 			require.NoError(t, db.AddSSTable(
-				ingestCtx, start, end, sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsWrites, noTS))
+				ingestCtx, start, end, sst, allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsWrites, noTS, noAdmissionHeader))
 			trace := getRecAndFinish().String()
 			require.Contains(t, trace, "evaluating AddSSTable")
 			require.Contains(t, trace, "via regular write batch")
@@ -1061,7 +1062,7 @@ func runTestDBAddSSTable(
 		require.NoError(t, w.Finish())
 
 		err := db.AddSSTable(
-			ctx, "b", "c", sstFile.Data(), allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS)
+			ctx, "b", "c", sstFile.Data(), allowConflicts, allowShadowing, allowShadowingBelow, nilStats, ingestAsSST, noTS, noAdmissionHeader)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "invalid checksum")
 	}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
@@ -104,6 +105,16 @@ func ClearRange(
 	}
 	cArgs.Stats.Subtract(statsDelta)
 
+	// Clear any MVCC range tombstones in the span, along with the point keys
+	// below. Range keys can only exist once the Pebble format version supports
+	// them, and clearing them before then is an error.
+	if cArgs.EvalCtx.ClusterSettings().Version.IsActive(
+		ctx, clusterversion.EnablePebbleFormatVersionRangeKeys) {
+		if err := readWriter.ExperimentalClearAllMVCCRangeKeys(from, to); err != nil {
+			return result.Result{}, err
+		}
+	}
+
 	// If the total size of data to be cleared is less than
 	// clearRangeBytesThreshold, clear the individual values with an iterator,
 	// instead of using a range tombstone (inefficient for small ranges).
@@ -163,8 +174,13 @@ func computeStatsDelta(
 	// If we can't use the fast stats path, or race test is enabled,
 	// compute stats across the key span to be cleared.
 	if !fast || util.RaceEnabled {
+		rangeTombstones, err := storage.ReadMVCCRangeTombstones(readWriter, from, to)
+		if err != nil {
+			return enginepb.MVCCStats{}, err
+		}
 		iter := readWriter.NewMVCCIterator(storage.MVCCKeyAndIntentsIterKind, storage.IterOptions{UpperBound: to})
-		computed, err := iter.ComputeStats(from, to, delta.LastUpdateNanos)
+		computed, err := storage.ComputeStatsForRangeWithRangeTombstones(
+			iter, rangeTombstones, from, to, delta.LastUpdateNanos)
 		iter.Close()
 		if err != nil {
 			return enginepb.MVCCStats{}, err
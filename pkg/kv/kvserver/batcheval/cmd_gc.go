@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/spanset"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -44,6 +45,10 @@ func declareKeysGC(
 			latchSpans.AddMVCC(spanset.SpanReadWrite, roachpb.Span{Key: key.Key}, header.Timestamp)
 		}
 	}
+	for _, rk := range gcr.RangeKeys {
+		latchSpans.AddMVCC(spanset.SpanReadWrite,
+			roachpb.Span{Key: rk.StartKey, EndKey: rk.EndKey}, header.Timestamp)
+	}
 	// Be smart here about blocking on the threshold keys. The MVCC GC queue can
 	// send an empty request first to bump the thresholds, and then another one
 	// that actually does work but can avoid declaring these keys below.
@@ -85,7 +90,7 @@ func GC(
 	// 2. the read could be served off a follower, which could be applying the
 	//    GC request's effect from the raft log. Latches held on the leaseholder
 	//    would have no impact on a follower read.
-	if !args.Threshold.IsEmpty() && len(args.Keys) != 0 &&
+	if !args.Threshold.IsEmpty() && (len(args.Keys) != 0 || len(args.RangeKeys) != 0) &&
 		!cArgs.EvalCtx.EvalKnobs().AllowGCWithNewThresholdAndKeys {
 		return result.Result{}, errors.AssertionFailedf(
 			"GC request can set threshold or it can GC keys, but it is unsafe for it to do both")
@@ -119,6 +124,22 @@ func GC(
 		}
 	}
 
+	// Garbage collect the specified MVCC range tombstones, after the point keys
+	// they cover. As above, range keys outside of this range are dropped.
+	var rangeKeys []storage.MVCCRangeKey
+	for _, rk := range args.RangeKeys {
+		if kvserverbase.ContainsKeyRange(cArgs.EvalCtx.Desc(), rk.StartKey, rk.EndKey) {
+			rangeKeys = append(rangeKeys, storage.MVCCRangeKey{
+				StartKey:  rk.StartKey,
+				EndKey:    rk.EndKey,
+				Timestamp: rk.Timestamp,
+			})
+		}
+	}
+	if err := storage.MVCCGarbageCollectRangeKeys(ctx, readWriter, rangeKeys); err != nil {
+		return result.Result{}, err
+	}
+
 	// Optionally bump the GC threshold timestamp.
 	var res result.Result
 	if !args.Threshold.IsEmpty() {
@@ -101,7 +101,11 @@ func Get(
 
 	var res result.Result
 	if args.KeyLocking != lock.None && h.Txn != nil && val != nil {
-		acq := roachpb.MakeLockAcquisition(h.Txn, args.Key, lock.Unreplicated)
+		acq, err := acquireLockOnKey(ctx, cArgs.EvalCtx.ClusterSettings(), reader, cArgs.Stats,
+			h.Txn, args.KeyLocking, roachpb.LockingDurability(args), args.Key)
+		if err != nil {
+			return result.Result{}, err
+		}
 		res.Local.AcquiredLocks = []roachpb.LockAcquisition{acq}
 	}
 	res.Local.EncounteredIntents = intents
@@ -224,7 +224,7 @@ func TestQueryResolvedTimestampErrors(t *testing.T) {
 
 	lockTableKey := storage.LockTableKey{
 		Key:      roachpb.Key("a"),
-		Strength: lock.Exclusive,
+		Strength: lock.Intent,
 		TxnUUID:  txnUUID.GetBytes(),
 	}
 	engineKey, buf := lockTableKey.ToEngineKey(nil)
@@ -96,7 +96,8 @@ func ReverseScan(
 	}
 
 	if args.KeyLocking != lock.None && h.Txn != nil {
-		err = acquireUnreplicatedLocksOnKeys(&res, h.Txn, args.ScanFormat, &scanRes)
+		err = acquireLocksOnKeys(ctx, cArgs.EvalCtx.ClusterSettings(), reader, cArgs.Stats, &res,
+			h.Txn, args.KeyLocking, roachpb.LockingDurability(args), args.ScanFormat, &scanRes)
 		if err != nil {
 			return result.Result{}, err
 		}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/spanset"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/uncertainty"
@@ -78,7 +79,23 @@ func DefaultDeclareIsolatedKeys(
 		timestamp.Forward(in.GlobalLimit)
 	}
 	latchSpans.AddMVCC(access, req.Header().Span(), timestamp)
-	lockSpans.AddNonMVCC(access, req.Header().Span())
+	lockSpans.AddNonMVCC(lockAccess(header, req, access), req.Header().Span())
+}
+
+// lockAccess returns the access with which a request that declares latches
+// with the provided access should declare its lock spans. Requests that acquire
+// Shared locks declare write latches, which serialize them with other writers
+// to the same keys, but they declare shared-read lock spans so that they are
+// not sequenced behind other Shared lock holders in the lock table.
+// Non-transactional requests cannot hold locks, so they keep their latch access.
+func lockAccess(
+	header *roachpb.Header, req roachpb.Request, latchAccess spanset.SpanAccess,
+) spanset.SpanAccess {
+	if latchAccess == spanset.SpanReadWrite && header.Txn != nil &&
+		roachpb.IsReadOnly(req) && roachpb.LockingStrength(req) == lock.Shared {
+		return spanset.SpanReadShared
+	}
+	return latchAccess
 }
 
 // DeclareKeysForBatch adds all keys that the batch with the provided header
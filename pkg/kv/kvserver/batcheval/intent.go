@@ -13,10 +13,13 @@ package batcheval
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
 )
@@ -103,11 +106,21 @@ func readProvisionalVal(
 
 }
 
-// acquireUnreplicatedLocksOnKeys adds an unreplicated lock acquisition by the
-// transaction to the provided result.Result for each key in the scan result.
-func acquireUnreplicatedLocksOnKeys(
+// acquireLocksOnKeys acquires a lock of the provided strength and durability
+// by the transaction on each key in the scan result and adds the lock
+// acquisitions to the provided result.Result. Unreplicated locks are only
+// acquired in the leaseholder's in-memory lock table once the request has
+// evaluated, while Replicated locks are also written to the replicated lock
+// table by the request itself.
+func acquireLocksOnKeys(
+	ctx context.Context,
+	st *cluster.Settings,
+	reader storage.Reader,
+	ms *enginepb.MVCCStats,
 	res *result.Result,
 	txn *roachpb.Transaction,
+	str lock.Strength,
+	dur lock.Durability,
 	scanFmt roachpb.ScanFormat,
 	scanRes *storage.MVCCScanResult,
 ) error {
@@ -116,13 +129,21 @@ func acquireUnreplicatedLocksOnKeys(
 	case roachpb.BATCH_RESPONSE:
 		var i int
 		return storage.MVCCScanDecodeKeyValues(scanRes.KVData, func(key storage.MVCCKey, _ []byte) error {
-			res.Local.AcquiredLocks[i] = roachpb.MakeLockAcquisition(txn, copyKey(key.Key), lock.Unreplicated)
+			acq, err := acquireLockOnKey(ctx, st, reader, ms, txn, str, dur, copyKey(key.Key))
+			if err != nil {
+				return err
+			}
+			res.Local.AcquiredLocks[i] = acq
 			i++
 			return nil
 		})
 	case roachpb.KEY_VALUES:
 		for i, row := range scanRes.KVs {
-			res.Local.AcquiredLocks[i] = roachpb.MakeLockAcquisition(txn, copyKey(row.Key), lock.Unreplicated)
+			acq, err := acquireLockOnKey(ctx, st, reader, ms, txn, str, dur, copyKey(row.Key))
+			if err != nil {
+				return err
+			}
+			res.Local.AcquiredLocks[i] = acq
 		}
 		return nil
 	default:
@@ -130,8 +151,51 @@ func acquireUnreplicatedLocksOnKeys(
 	}
 }
 
+// acquireLockOnKey acquires a lock of the provided strength and durability by
+// the transaction on the key and returns the corresponding lock acquisition.
+// Replicated locks are written to the replicated lock table, which requires
+// the request to be evaluated as a write. Unreplicated locks are checked for
+// conflicts with the replicated locks of other transactions, which may not be
+// tracked by the in-memory lock table, e.g. after a lease transfer.
+func acquireLockOnKey(
+	ctx context.Context,
+	st *cluster.Settings,
+	reader storage.Reader,
+	ms *enginepb.MVCCStats,
+	txn *roachpb.Transaction,
+	str lock.Strength,
+	dur lock.Durability,
+	key roachpb.Key,
+) (roachpb.LockAcquisition, error) {
+	switch dur {
+	case lock.Replicated:
+		// Nodes running older binaries cannot decode replicated locks other than
+		// intents in the lock table keyspace.
+		if !st.Version.IsActive(ctx, clusterversion.ReplicatedLocks) {
+			return roachpb.LockAcquisition{}, errors.Newf(
+				"version %v must be finalized to acquire replicated locks",
+				clusterversion.ByKey(clusterversion.ReplicatedLocks))
+		}
+		rw, ok := reader.(storage.ReadWriter)
+		if !ok {
+			return roachpb.LockAcquisition{}, errors.AssertionFailedf(
+				"replicated lock acquired by read-only evaluation")
+		}
+		if err := storage.MVCCAcquireLock(ctx, rw, txn, str, key, ms); err != nil {
+			return roachpb.LockAcquisition{}, err
+		}
+	case lock.Unreplicated:
+		if err := storage.MVCCCheckForReplicatedLockConflicts(reader, key, txn); err != nil {
+			return roachpb.LockAcquisition{}, err
+		}
+	default:
+		return roachpb.LockAcquisition{}, errors.AssertionFailedf("unexpected durability %s", dur)
+	}
+	return roachpb.MakeLockAcquisition(txn, key, dur, str), nil
+}
+
 // copyKey copies the provided roachpb.Key into a new byte slice, returning the
-// copy. It is used in acquireUnreplicatedLocksOnKeys for two reasons:
+// copy. It is used in acquireLocksOnKeys for two reasons:
 // 1. the keys in an MVCCScanResult, regardless of the scan format used, point
 //    to a small number of large, contiguous byte slices. These "MVCCScan
 //    batches" contain keys and their associated values in the same backing
@@ -3452,6 +3452,14 @@ func (errorChannelTestHandler) HandleSnapshot(
 	panic("unimplemented")
 }
 
+func (errorChannelTestHandler) HandleDelegatedSnapshot(
+	_ context.Context,
+	_ *kvserverpb.DelegateSnapshotRequest,
+	_ kvserver.DelegateSnapshotResponseStream,
+) error {
+	panic("unimplemented")
+}
+
 // This test simulates a scenario where one replica has been removed from the
 // range's Raft group but it is unaware of the fact. We check that this replica
 // coming back from the dead cannot cause elections.
@@ -4002,6 +4010,77 @@ func TestTransferRaftLeadership(t *testing.T) {
 	})
 }
 
+// TestTransferRaftLeadershipOffWitness verifies that a WITNESS replica that
+// becomes the Raft leader transfers the leadership to a full voter, even when
+// the leadership doesn't follow the lease.
+func TestTransferRaftLeadershipOffWitness(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 3,
+		base.TestClusterArgs{
+			ReplicationMode: base.ReplicationManual,
+			ServerArgs: base.TestServerArgs{
+				RaftConfig: base.RaftConfig{
+					// Suppress timeout-based elections, which could move the leadership
+					// in ways this test doesn't expect.
+					RaftElectionTimeoutTicks: 100000,
+				},
+				Knobs: base.TestingKnobs{
+					Store: &kvserver.StoreTestingKnobs{
+						// Make sure that the leadership is moved off the witness because
+						// it is a witness, not because the lease is elsewhere.
+						DisableLeaderFollowsLeaseholder: true,
+					},
+				},
+			},
+		})
+	defer tc.Stopper().Stop(ctx)
+
+	// Set up a scratch range with voters on n1 and n2 and a witness on n3.
+	key := tc.ScratchRange(t)
+	desc := tc.AddVotersOrFatal(t, key, tc.Target(1))
+	desc, err := tc.Server(0).DB().AdminChangeReplicas(
+		ctx, key, desc, roachpb.MakeReplicationChanges(roachpb.ADD_WITNESS, tc.Target(2)),
+	)
+	require.NoError(t, err)
+	witness, ok := desc.GetReplicaDescriptor(tc.Target(2).StoreID)
+	require.True(t, ok)
+	require.Equal(t, roachpb.WITNESS, witness.GetType())
+	require.NoError(t, tc.Server(0).DB().Put(ctx, key, "a"))
+
+	store0 := tc.GetFirstStoreFromServer(t, 0)
+	store2 := tc.GetFirstStoreFromServer(t, 2)
+	repl0 := store0.LookupReplica(keys.MustAddr(key))
+	require.NotNil(t, repl0)
+	origCount := store2.Metrics().RangeRaftLeaderTransfers.Count()
+
+	// Hand the leadership to the witness, which transfers it away once it
+	// notices that it is the leader. Since the leadership doesn't follow the
+	// lease, any leadership transfer counted by s3 is the witness's.
+	repl0.TransferRaftLeadership(witness.ReplicaID)
+	testutils.SucceedsSoon(t, func() error {
+		if a := store2.Metrics().RangeRaftLeaderTransfers.Count() - origCount; a < 1 {
+			return errors.Errorf("expected the witness to transfer the raft leadership; got %d transfers", a)
+		}
+		lead := roachpb.ReplicaID(repl0.RaftStatus().Lead)
+		leader, ok := desc.GetReplicaDescriptorByID(lead)
+		if !ok || leader.GetType() != roachpb.VOTER_FULL {
+			return errors.Errorf("expected the leader to be a full voter; got replica ID %d", lead)
+		}
+		return nil
+	})
+	require.NoError(t, tc.Server(0).DB().Put(ctx, key, "b"))
+
+	// Relocating the range would drop the witness, so it is refused.
+	voterTargets := []roachpb.ReplicationTarget{tc.Target(0), tc.Target(1)}
+	err = tc.Server(0).DB().AdminRelocateRange(
+		ctx, key, voterTargets, nil /* nonVoterTargets */, false, /* transferLeaseToFirstVoter */
+	)
+	require.True(t, testutils.IsError(err, "witness replicas .* can't be relocated"), "%v", err)
+}
+
 // Test that a single blocked replica does not block other replicas.
 func TestRaftBlockedReplica(t *testing.T) {
 	defer leaktest.AfterTest(t)()
@@ -0,0 +1,74 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// BenchmarkReplicaSendLoadObjective measures the overhead of measuring the CPU
+// time spent evaluating requests, which replicas only do while load-based
+// rebalancing uses the cpu objective, by sending point reads and writes to a
+// replica under each objective.
+func BenchmarkReplicaSendLoadObjective(b *testing.B) {
+	defer leaktest.AfterTest(b)()
+	defer log.Scope(b).Close(b)
+	ctx := context.Background()
+
+	for _, objective := range []kvserver.LBRebalancingObjective{
+		kvserver.LBRebalancingQueries, kvserver.LBRebalancingCPU,
+	} {
+		for _, write := range []bool{false, true} {
+			op := "get"
+			if write {
+				op = "put"
+			}
+			b.Run("objective="+objective.String()+"/"+op, func(b *testing.B) {
+				b.ReportAllocs()
+				tc := testcluster.StartTestCluster(b, 1, base.TestClusterArgs{})
+				defer tc.Stopper().Stop(ctx)
+
+				_, err := tc.ServerConn(0).Exec(
+					`SET CLUSTER SETTING kv.allocator.load_based_rebalancing.objective = $1`,
+					objective.String())
+				require.NoError(b, err)
+
+				key := tc.ScratchRange(b)
+				repl := tc.GetFirstStoreFromServer(b, 0).LookupReplica(keys.MustAddr(key))
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					var ba roachpb.BatchRequest
+					ba.RangeID = repl.RangeID
+					ba.Timestamp = repl.Clock().Now()
+					if write {
+						ba.Add(roachpb.NewPut(key, roachpb.MakeValueFromString("foo")))
+					} else {
+						ba.Add(roachpb.NewGet(key, false /* forUpdate */))
+					}
+					if _, pErr := repl.Send(ctx, ba); pErr != nil {
+						b.Fatal(pErr)
+					}
+				}
+			})
+		}
+	}
+}
@@ -64,7 +64,7 @@ import (
 //
 // check-opt-no-conflicts req=<req-name>
 //
-// on-lock-acquired  req=<req-name> key=<key> [seq=<seq>] [dur=r|u]
+// on-lock-acquired  req=<req-name> key=<key> [seq=<seq>] [dur=r|u] [str=shared|exclusive]
 // on-lock-updated   req=<req-name> txn=<txn-name> key=<key> status=[committed|aborted|pending] [ts=<int>[,<int>]]
 // on-txn-updated    txn=<txn-name> status=[committed|aborted|pending] [ts=<int>[,<int>]]
 //
@@ -347,6 +347,11 @@ func TestConcurrencyManagerBasic(t *testing.T) {
 					dur = scanLockDurability(t, d)
 				}
 
+				str := lock.Exclusive
+				if d.HasArg("str") {
+					str = scanLockStrength(t, d)
+				}
+
 				// Confirm that the request has a corresponding write request.
 				found := false
 				for _, ru := range guard.Req.Requests {
@@ -368,7 +373,7 @@ func TestConcurrencyManagerBasic(t *testing.T) {
 
 				mon.runSync("acquire lock", func(ctx context.Context) {
 					log.Eventf(ctx, "txn %s @ %s", txn.ID.Short(), key)
-					acq := roachpb.MakeLockAcquisition(txnAcquire, roachpb.Key(key), dur)
+					acq := roachpb.MakeLockAcquisition(txnAcquire, roachpb.Key(key), dur, str)
 					m.OnLockAcquired(ctx, &acq)
 				})
 				return c.waitAndCollect(t, mon)
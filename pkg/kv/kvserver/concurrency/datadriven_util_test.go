@@ -57,6 +57,24 @@ func scanLockDurability(t *testing.T, d *datadriven.TestData) lock.Durability {
 	}
 }
 
+func scanLockStrength(t *testing.T, d *datadriven.TestData) lock.Strength {
+	var strS string
+	d.ScanArgs(t, "str", &strS)
+	return parseLockStrength(t, d, strS)
+}
+
+func parseLockStrength(t *testing.T, d *datadriven.TestData, strS string) lock.Strength {
+	switch strS {
+	case "shared":
+		return lock.Shared
+	case "exclusive":
+		return lock.Exclusive
+	default:
+		d.Fatalf(t, "unknown lock strength: %s", strS)
+		return 0
+	}
+}
+
 func scanWaitPolicy(t *testing.T, d *datadriven.TestData, required bool) lock.WaitPolicy {
 	const key = "wait-policy"
 	if !required && !d.HasArg(key) {
@@ -110,18 +128,27 @@ func scanSingleRequest(
 		}
 		return enginepb.TxnSeq(n)
 	}
+	maybeGetStr := func() lock.Strength {
+		s, ok := fields["str"]
+		if !ok {
+			return lock.None
+		}
+		return parseLockStrength(t, d, s)
+	}
 
 	switch cmd {
 	case "get":
 		var r roachpb.GetRequest
 		r.Sequence = maybeGetSeq()
 		r.Key = roachpb.Key(mustGetField("key"))
+		r.KeyLocking = maybeGetStr()
 		return &r
 
 	case "scan":
 		var r roachpb.ScanRequest
 		r.Sequence = maybeGetSeq()
 		r.Key = roachpb.Key(mustGetField("key"))
+		r.KeyLocking = maybeGetStr()
 		if v, ok := fields["endkey"]; ok {
 			r.EndKey = roachpb.Key(v)
 		}
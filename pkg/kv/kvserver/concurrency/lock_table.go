@@ -687,7 +687,8 @@ func (g *lockTableGuardImpl) findNextLockAfter(notify bool) {
 // non-transactional requests.
 type queuedGuard struct {
 	guard  *lockTableGuardImpl
-	active bool // protected by lockState.mu
+	access spanset.SpanAccess // SpanReadShared or SpanReadWrite
+	active bool               // protected by lockState.mu
 }
 
 // Information about a lock holder.
@@ -735,10 +736,15 @@ type lockState struct {
 
 	// Invariant summary (see detailed comments below):
 	// - both holder.locked and waitQ.reservation != nil cannot be true.
+	// - both holder.locked and len(holder.shared) > 0 cannot be true.
+	// - both len(holder.shared) > 0 and waitQ.reservation != nil cannot be
+	//   true.
 	// - if holder.locked and multiple holderInfos have txn != nil: all the
 	//   txns must have the same txn.ID.
+	// - each txn appears at most once in holder.shared.
 	// - !holder.locked => waitingReaders.Len() == 0. That is, readers wait
-	//   only if the lock is held. They do not wait for a reservation.
+	//   only if the lock is held as Exclusive. They do not wait for Shared
+	//   lock holders or for a reservation.
 	// - If reservation != nil, that request is not in queuedWriters.
 
 	// Information about whether the lock is held and the holder. We track
@@ -746,10 +752,17 @@ type lockState struct {
 	// go through multiple epochs and TxnSeq and may acquire the same lock in
 	// replicated and unreplicated mode at different stages.
 	holder struct {
+		// locked is true iff the lock is held with Exclusive strength, in which
+		// case the holder is described by holder.
 		locked bool
-		// LockStrength is always Exclusive
 		holder [lock.MaxDurability + 1]lockHolderInfo
 
+		// Information about the transactions holding the lock with Shared
+		// strength, if any. Shared locks are always Unreplicated, and a
+		// transaction that holds the lock as Exclusive is never also tracked
+		// here (see acquireLock).
+		shared []lockHolderInfo
+
 		// The start time of the lockholder being marked as held in the lock table.
 		// NB: In the case of a replicated lock that is held by a transaction, if
 		// there is no wait-queue, the lock is not tracked by the in-memory lock
@@ -777,10 +790,12 @@ type lockWaitQueue struct {
 	// A not-held lock can be "reserved". A reservation is just a claim that
 	// prevents multiple requests from racing when the lock is released. A
 	// reservation by req2 can be broken by req1 is req1 has a smaller seqNum
-	// than req2. Only requests that specify SpanReadWrite for a key can make
-	// reservations. This means a reservation can only be made when the lock is
-	// not held, since the reservation (which can acquire an Exclusive lock) and
-	// the lock holder (which is an Exclusive lock) conflict.
+	// than req2. Only requests that specify SpanReadWrite or SpanReadShared for
+	// a key can make reservations. A reservation can only be made when the lock
+	// is not held. For SpanReadWrite this is because the reservation (which can
+	// acquire an Exclusive lock) conflicts with any lock holder. A SpanReadShared
+	// request does not need a reservation to acquire a lock that is only held
+	// as Shared, so it does not make one (see "Shared locks" below).
 	//
 	// Read reservations are not permitted due to the complexities discussed in
 	// the review for #43740. Additionally, reads do not queue for their turn at
@@ -864,46 +879,51 @@ type lockWaitQueue struct {
 	//   This is a deadlock caused by the lock table unless req2 partially
 	//   breaks the reservation at A.
 	//
-	// Extension for Shared and Upgrade locks:
+	// Shared locks:
 	// There are 3 aspects to consider: holders; reservers; the dependencies
 	// that need to be captured when waiting.
 	//
 	// - Holders: only shared locks are compatible with themselves, so there can
 	//   be one of (a) no holder (b) multiple shared lock holders, (c) one
-	//   exclusive holder, (d) one upgrade holder. Non-locking reads will
-	//   wait in waitingReaders for only an incompatible exclusive holder.
+	//   exclusive holder. Non-locking reads will wait in waitingReaders for only
+	//   an incompatible exclusive holder. A transaction that is the only shared
+	//   lock holder can upgrade its lock to an exclusive lock without waiting.
 	//
-	// - Reservers: This follows the same pattern as holders. Non-locking reads
-	//   do not wait on reservers.
+	// - Reservers: a reservation is made by at most one request, which can
+	//   want to acquire either a shared or an exclusive lock. Requests that
+	//   want to acquire a shared lock do not conflict with a shared
+	//   reservation. Non-locking reads do not wait on reservers.
 	//
 	// - Queueing and dependencies: All potential lockers and non-transactional
-	//   writers will wait in the same queue. A sequence of consecutive requests
-	//   that have the potential to acquire a shared lock will jointly reserve
-	//   that shared lock. Such requests cannot jump ahead of requests with a
-	//   lower seqnum just because there is currently a shared lock reservation
-	//   (this can cause lockTable induced deadlocks). Such joint reservations
-	//   can be partially broken by a waiter desiring an exclusive or upgrade
-	//   lock. Like the current code, non-transactional writes will wait for
-	//   reservations that have a lower sequence num, but not make their own
-	//   reservation. Additionally, they can partially break joint reservations.
+	//   writers wait in the same queue (queuedWriters). A waiter desiring an
+	//   exclusive lock conflicts with all holder(s) or the reserver, so that
+	//   is the dependency that is captured (one holder at a time, if there are
+	//   multiple shared lock holders). A waiter desiring a shared lock may
+	//   encounter a situation where it does not conflict with the holder(s)
+	//   or reserver since those are also shared lockers. Such requests cannot
+	//   jump ahead of transactional requests with a lower seqnum that desire an
+	//   exclusive lock, since that could starve the exclusive lockers. In that
+	//   case the shared locker depends on the first such request in the queue.
+	//   When the lock becomes free, the first transactional request in the
+	//   queue gets the reservation, and if it is a shared locker, all shared
+	//   lockers that are not behind an exclusive locker are released from the
+	//   queue and race to acquire the lock alongside it.
 	//
-	//   Reservations that are (partially or fully) broken cause requests to
-	//   reenter the queue as inactive waiters. This is no different than the
-	//   current behavior. Each request can specify the same key in spans for
-	//   ReadOnly, ReadShared, ReadUpgrade, ReadWrite. The spans will be
-	//   iterated over in decreasing order of strength, to only wait at a lock
-	//   at the highest strength (this is similar to the current behavior using
-	//   accessDecreasingStrength).
+	//   Reservations that are broken cause requests to reenter the queue as
+	//   inactive waiters. Each request can specify the same key in spans for
+	//   ReadOnly, ReadShared, ReadWrite. The spans will be iterated over in
+	//   decreasing order of strength, to only wait at a lock at the highest
+	//   strength.
 	//
-	//   For dependencies, a waiter desiring an exclusive or upgrade lock always
-	//   conflicts with the holder(s) or reserver(s) so that is the dependency
-	//   that will be captured. A waiter desiring a shared lock may encounter a
-	//   situation where it does not conflict with the holder(s) or reserver(s)
-	//   since those are also shared lockers. In that case it will depend on the
-	//   first waiter since that waiter must be desiring a lock that is
-	//   incompatible with a shared lock.
+	// Upgrade locks are not currently supported. If they are added, they will
+	// be incompatible with shared and upgrade lockers, so they will behave like
+	// exclusive lockers with respect to holders, reservers and queueing.
 
 	reservation *lockTableGuardImpl
+	// The access with which the reservation was made. A reservation made with
+	// SpanReadShared does not conflict with other requests that want to
+	// acquire a Shared lock.
+	reservationAccess spanset.SpanAccess
 
 	// TODO(sbhola): There are a number of places where we iterate over these
 	// lists looking for something, as described below. If some of these turn
@@ -926,12 +946,15 @@ type lockWaitQueue struct {
 	// waiting for.
 
 	// List of *queuedGuard. A subset of these are actively waiting. If
-	// non-empty, either the lock is held or there is a reservation.
+	// non-empty, either the lock is held or there is a reservation. Despite
+	// the name, this also includes requests that want to acquire a Shared
+	// lock.
 	queuedWriters list.List
 
 	// List of *lockTableGuardImpl. All of these are actively waiting. If
-	// non-empty, the lock must be held. By definition these cannot be in
-	// waitSelf state since that state is only used when there is a reservation.
+	// non-empty, the lock must be held as Exclusive. By definition these
+	// cannot be in waitSelf state since that state is only used when there is
+	// a reservation.
 	waitingReaders list.List
 
 	// If there is a non-empty set of active waiters that are not waitSelf, then
@@ -979,6 +1002,26 @@ func (l *lockState) safeFormat(sb *redact.StringBuilder, finalizedTxnCache *txnC
 		sb.Printf("txn: %v, ts: %v, seq: %v\n",
 			redact.Safe(txn.ID), redact.Safe(ts), redact.Safe(txn.Sequence))
 	}
+	writeHolderDetails := func(sb *redact.StringBuilder, h *lockHolderInfo) {
+		if finalizedTxnCache != nil {
+			finalizedTxn, ok := finalizedTxnCache.get(h.txn.ID)
+			if ok {
+				var statusStr string
+				switch finalizedTxn.Status {
+				case roachpb.COMMITTED:
+					statusStr = "committed"
+				case roachpb.ABORTED:
+					statusStr = "aborted"
+				}
+				sb.Printf("[holder finalized: %s] ", redact.Safe(statusStr))
+			}
+		}
+		sb.Printf("epoch: %d, seqs: [%d", redact.Safe(h.txn.Epoch), redact.Safe(h.seqs[0]))
+		for j := 1; j < len(h.seqs); j++ {
+			sb.Printf(", %d", redact.Safe(h.seqs[j]))
+		}
+		sb.SafeString("]")
+	}
 	writeHolderInfo := func(sb *redact.StringBuilder, txn *enginepb.TxnMeta, ts hlc.Timestamp) {
 		sb.Printf("  holder: txn: %v, ts: %v, info: ", redact.Safe(txn.ID), redact.Safe(ts))
 		first := true
@@ -996,33 +1039,26 @@ func (l *lockState) safeFormat(sb *redact.StringBuilder, finalizedTxnCache *txnC
 			} else {
 				sb.SafeString("unrepl ")
 			}
-			if finalizedTxnCache != nil {
-				finalizedTxn, ok := finalizedTxnCache.get(h.txn.ID)
-				if ok {
-					var statusStr string
-					switch finalizedTxn.Status {
-					case roachpb.COMMITTED:
-						statusStr = "committed"
-					case roachpb.ABORTED:
-						statusStr = "aborted"
-					}
-					sb.Printf("[holder finalized: %s] ", redact.Safe(statusStr))
-				}
-			}
-			sb.Printf("epoch: %d, seqs: [%d", redact.Safe(h.txn.Epoch), redact.Safe(h.seqs[0]))
-			for j := 1; j < len(h.seqs); j++ {
-				sb.Printf(", %d", redact.Safe(h.seqs[j]))
-			}
-			sb.SafeString("]")
+			writeHolderDetails(sb, h)
 		}
 		sb.SafeString("\n")
 	}
 	txn, ts := l.getLockHolder()
-	if txn == nil {
+	if txn != nil {
+		writeHolderInfo(sb, txn, ts)
+	} else if len(l.holder.shared) > 0 {
+		for i := range l.holder.shared {
+			h := &l.holder.shared[i]
+			sb.Printf("  holder: txn: %v, ts: %v, info: shared unrepl ", redact.Safe(h.txn.ID), redact.Safe(h.ts))
+			writeHolderDetails(sb, h)
+			sb.SafeString("\n")
+		}
+	} else {
 		sb.Printf("  res: req: %d, ", l.reservation.seqNum)
+		if l.reservationAccess == spanset.SpanReadShared {
+			sb.SafeString("shared, ")
+		}
 		writeResInfo(sb, l.reservation.txn, l.reservation.ts)
-	} else {
-		writeHolderInfo(sb, txn, ts)
 	}
 	// TODO(sumeer): Add an optional `description string` field to Request and
 	// lockTableGuardImpl that tests can set to avoid relying on the seqNum to
@@ -1044,7 +1080,11 @@ func (l *lockState) safeFormat(sb *redact.StringBuilder, finalizedTxnCache *txnC
 		for e := l.queuedWriters.Front(); e != nil; e = e.Next() {
 			qg := e.Value.(*queuedGuard)
 			g := qg.guard
-			sb.Printf("    active: %t req: %d, txn: ", redact.Safe(qg.active), redact.Safe(qg.guard.seqNum))
+			sb.Printf("    active: %t req: %d, ", redact.Safe(qg.active), redact.Safe(qg.guard.seqNum))
+			if qg.access == spanset.SpanReadShared {
+				sb.SafeString("shared, ")
+			}
+			sb.SafeString("txn: ")
 			if g.txn == nil {
 				sb.SafeString("none\n")
 			} else {
@@ -1067,7 +1107,7 @@ func (l *lockState) addToMetrics(m *LockTableMetrics, now time.Time) {
 	totalWaitDuration, maxWaitDuration := l.totalAndMaxWaitDuration(now)
 	lm := LockMetrics{
 		Key:                  l.key,
-		Held:                 l.holder.locked,
+		Held:                 l.isHeld(),
 		HoldDurationNanos:    l.lockHeldDuration(now).Nanoseconds(),
 		WaitingReaders:       int64(l.waitingReaders.Len()),
 		WaitingWriters:       int64(l.queuedWriters.Len()),
@@ -1085,6 +1125,7 @@ func (l *lockState) tryBreakReservation(seqNum uint64) bool {
 	if l.reservation.seqNum > seqNum {
 		qg := &queuedGuard{
 			guard:  l.reservation,
+			access: l.reservationAccess,
 			active: false,
 		}
 		l.queuedWriters.PushFront(qg)
@@ -1096,7 +1137,10 @@ func (l *lockState) tryBreakReservation(seqNum uint64) bool {
 
 // Informs active waiters about reservation or lock holder. The reservation
 // may have changed so this needs to fix any inconsistencies wrt waitSelf and
-// waitForDistinguished states.
+// waitForDistinguished states. Active waiters in queuedWriters that no longer
+// conflict with the lock, which is possible for requests that want to acquire
+// a Shared lock or to upgrade their own Shared lock, are told that they are
+// done waiting at this lock.
 // REQUIRES: l.mu is locked.
 func (l *lockState) informActiveWaiters() {
 	waitForState := waitingState{
@@ -1105,16 +1149,9 @@ func (l *lockState) informActiveWaiters() {
 		queuedWriters: l.queuedWriters.Len(),
 		queuedReaders: l.waitingReaders.Len(),
 	}
-	findDistinguished := l.distinguishedWaiter == nil
 	if lockHolderTxn, _ := l.getLockHolder(); lockHolderTxn != nil {
 		waitForState.txn = lockHolderTxn
 		waitForState.held = true
-	} else {
-		waitForState.txn = l.reservation.txn
-		if !findDistinguished && l.distinguishedWaiter.isSameTxnAsReservation(waitForState) {
-			findDistinguished = true
-			l.distinguishedWaiter = nil
-		}
 	}
 
 	for e := l.waitingReaders.Front(); e != nil; e = e.Next() {
@@ -1124,9 +1161,8 @@ func (l *lockState) informActiveWaiters() {
 		// or into a state with a reservation, since readers do not wait for
 		// reservations.
 		g := e.Value.(*lockTableGuardImpl)
-		if findDistinguished {
+		if l.distinguishedWaiter == nil {
 			l.distinguishedWaiter = g
-			findDistinguished = false
 		}
 		g.mu.Lock()
 		g.mu.state = state
@@ -1136,20 +1172,36 @@ func (l *lockState) informActiveWaiters() {
 		g.notify()
 		g.mu.Unlock()
 	}
-	for e := l.queuedWriters.Front(); e != nil; e = e.Next() {
+	for e := l.queuedWriters.Front(); e != nil; {
 		qg := e.Value.(*queuedGuard)
+		curr := e
+		e = e.Next()
 		if !qg.active {
 			continue
 		}
 		g := qg.guard
+		txn, held := l.lockerWaitsFor(g, qg.access)
+		if txn == nil {
+			// No longer conflicts with the lock.
+			l.queuedWriters.Remove(curr)
+			if g == l.distinguishedWaiter {
+				l.distinguishedWaiter = nil
+			}
+			g.doneWaitingAtLock(false, l)
+			continue
+		}
 		state := waitForState
+		state.txn = txn
+		state.held = held
+		state.guardAccess = qg.access
 		if g.isSameTxnAsReservation(state) {
 			state.kind = waitSelf
+			if g == l.distinguishedWaiter {
+				l.distinguishedWaiter = nil
+			}
 		} else {
-			state.guardAccess = spanset.SpanReadWrite
-			if findDistinguished {
+			if l.distinguishedWaiter == nil {
 				l.distinguishedWaiter = g
-				findDistinguished = false
 			}
 			if l.distinguishedWaiter == g {
 				state.kind = waitForDistinguished
@@ -1160,6 +1212,11 @@ func (l *lockState) informActiveWaiters() {
 		g.notify()
 		g.mu.Unlock()
 	}
+	if l.distinguishedWaiter == nil {
+		// The distinguished waiter may have been removed or moved to the
+		// waitSelf state after earlier waiters were visited.
+		l.tryMakeNewDistinguished()
+	}
 }
 
 // releaseWritersFromTxn removes all waiting writers for the lockState that are
@@ -1218,7 +1275,7 @@ func (l *lockState) tryMakeNewDistinguished() {
 // reservation.
 // REQUIRES: l.mu is locked.
 func (l *lockState) isEmptyLock() bool {
-	if !l.holder.locked && l.reservation == nil {
+	if !l.isHeld() && l.reservation == nil {
 		for i := range l.holder.holder {
 			if !l.holder.holder[i].isEmpty() {
 				panic("lockState with !locked but non-zero lockHolderInfo")
@@ -1235,7 +1292,7 @@ func (l *lockState) isEmptyLock() bool {
 // Returns the duration of time the lock has been tracked as held in the lock table.
 // REQUIRES: l.mu is locked.
 func (l *lockState) lockHeldDuration(now time.Time) time.Duration {
-	if !l.holder.locked {
+	if !l.isHeld() {
 		return time.Duration(0)
 	}
 
@@ -1272,10 +1329,19 @@ func (l *lockState) totalAndMaxWaitDuration(now time.Time) (time.Duration, time.
 	return totalWaitDuration, maxWaitDuration
 }
 
+// Returns true iff the lock is currently held, with any strength.
+// REQUIRES: l.mu is locked.
+func (l *lockState) isHeld() bool {
+	return l.holder.locked || len(l.holder.shared) > 0
+}
+
 // Returns true iff the lock is currently held by the transaction with the
-// given id.
+// given id, with any strength.
 // REQUIRES: l.mu is locked.
 func (l *lockState) isLockedBy(id uuid.UUID) bool {
+	if l.findSharedHolder(id) >= 0 {
+		return true
+	}
 	if l.holder.locked {
 		var holderID uuid.UUID
 		if l.holder.holder[lock.Unreplicated].txn != nil {
@@ -1288,8 +1354,131 @@ func (l *lockState) isLockedBy(id uuid.UUID) bool {
 	return false
 }
 
-// Returns information about the current lock holder if the lock is held, else
-// returns nil.
+// Returns the index in holder.shared of the transaction with the given id, or
+// -1 if the transaction does not hold the lock as Shared.
+// REQUIRES: l.mu is locked.
+func (l *lockState) findSharedHolder(id uuid.UUID) int {
+	for i := range l.holder.shared {
+		if l.holder.shared[i].txn.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the queuedGuard for g if it is in queuedWriters, else returns nil.
+// REQUIRES: l.mu is locked.
+func (l *lockState) findQueuedGuard(g *lockTableGuardImpl) *queuedGuard {
+	for e := l.queuedWriters.Front(); e != nil; e = e.Next() {
+		qg := e.Value.(*queuedGuard)
+		if qg.guard == g {
+			return qg
+		}
+	}
+	return nil
+}
+
+// Returns the first transactional request in queuedWriters that wants to
+// acquire an Exclusive lock, is not from the same transaction as g, and has a
+// lower seqNum than g. A request that wants to acquire a Shared lock must not
+// jump ahead of such a request, else a stream of Shared lockers could starve
+// it. Returns nil if there is no such request.
+// REQUIRES: l.mu is locked.
+func (l *lockState) queuedExclusiveLockerBefore(g *lockTableGuardImpl) *lockTableGuardImpl {
+	for e := l.queuedWriters.Front(); e != nil; e = e.Next() {
+		qg := e.Value.(*queuedGuard)
+		qqg := qg.guard
+		if qg.access == spanset.SpanReadWrite && qqg.txn != nil && !g.isSameTxn(qqg.txn) &&
+			qqg.seqNum < g.seqNum {
+			return qqg
+		}
+	}
+	return nil
+}
+
+// Returns the transaction that the request g, which wants to lock the key
+// with access sa (SpanReadShared or SpanReadWrite), needs to wait for given
+// the current holder(s), reservation and queue of the lock. held is true iff
+// that transaction holds the lock. Returns a nil txn if g does not conflict
+// with the lock.
+//
+// This does not consider the special treatment of reservations for
+// non-transactional requests, or the breaking of reservations, which are
+// handled by tryActiveWait.
+// REQUIRES: l.mu is locked.
+func (l *lockState) lockerWaitsFor(
+	g *lockTableGuardImpl, sa spanset.SpanAccess,
+) (txn *enginepb.TxnMeta, held bool) {
+	if lockHolderTxn, _ := l.getLockHolder(); lockHolderTxn != nil {
+		if g.isSameTxn(lockHolderTxn) {
+			return nil, false
+		}
+		return lockHolderTxn, true
+	}
+	if len(l.holder.shared) > 0 {
+		if sa == spanset.SpanReadWrite {
+			// Conflicts with all Shared lock holders from other transactions. If
+			// g's transaction is the only holder, it can upgrade its lock.
+			for i := range l.holder.shared {
+				if !g.isSameTxn(l.holder.shared[i].txn) {
+					return l.holder.shared[i].txn, true
+				}
+			}
+			return nil, false
+		}
+		// Compatible with the Shared lock holders.
+		if g.txn != nil && l.findSharedHolder(g.txn.ID) >= 0 {
+			return nil, false
+		}
+		if w := l.queuedExclusiveLockerBefore(g); w != nil {
+			return w.txn, false
+		}
+		return nil, false
+	}
+	if l.reservation == nil || l.reservation == g {
+		return nil, false
+	}
+	if sa == spanset.SpanReadShared && l.reservationAccess == spanset.SpanReadShared {
+		// Compatible with the reservation.
+		if g.isSameTxn(l.reservation.txn) {
+			return nil, false
+		}
+		if w := l.queuedExclusiveLockerBefore(g); w != nil {
+			return w.txn, false
+		}
+		return nil, false
+	}
+	return l.reservation.txn, false
+}
+
+// Called after one or more Shared lock holders have been removed from
+// holder.shared. Returns whether the lockState can be garbage collected.
+// REQUIRES: l.mu is locked.
+func (l *lockState) releasedSharedLocks() (gc bool) {
+	if len(l.holder.shared) == 0 {
+		l.holder.startTime = time.Time{}
+		return l.lockIsFree()
+	}
+	// The remaining holders may no longer conflict with some of the waiters,
+	// e.g. if the only remaining holder is upgrading its lock.
+	l.informActiveWaiters()
+	return false
+}
+
+// Removes the Shared lock holder at index i from holder.shared.
+// REQUIRES: l.mu is locked.
+func (l *lockState) removeSharedHolder(i int) {
+	n := len(l.holder.shared)
+	copy(l.holder.shared[i:], l.holder.shared[i+1:])
+	l.holder.shared[n-1] = lockHolderInfo{}
+	l.holder.shared = l.holder.shared[:n-1]
+	if len(l.holder.shared) == 0 {
+		l.holder.shared = nil
+	}
+}
+
+// Returns information about the current Exclusive lock holder if the lock is
+// held as Exclusive, else returns nil.
 // REQUIRES: l.mu is locked.
 func (l *lockState) getLockHolder() (*enginepb.TxnMeta, hlc.Timestamp) {
 	if !l.holder.locked {
@@ -1321,6 +1510,7 @@ func (l *lockState) clearLockHolder() {
 	for i := range l.holder.holder {
 		l.holder.holder[i] = lockHolderInfo{}
 	}
+	l.holder.shared = nil
 }
 
 // Decides whether the request g with access sa should actively wait at this
@@ -1417,6 +1607,22 @@ func (l *lockState) tryActiveWait(
 				replicatedLockFinalizedTxn = finalizedTxn
 			}
 		}
+	} else if len(l.holder.shared) > 0 {
+		// Shared locks are always unreplicated, so Shared locks held by
+		// finalized transactions can be released immediately.
+		released := false
+		for i := 0; i < len(l.holder.shared); {
+			if _, ok := g.lt.finalizedTxnCache.get(l.holder.shared[i].txn.ID); ok {
+				l.removeSharedHolder(i)
+				released = true
+				continue
+			}
+			i++
+		}
+		if released && l.releasedSharedLocks() {
+			// Empty lock.
+			return false, true
+		}
 	}
 
 	if sa == spanset.SpanReadOnly {
@@ -1447,6 +1653,12 @@ func (l *lockState) tryActiveWait(
 		if alsoHasStrongerAccess {
 			return false, false
 		}
+	} else if sa == spanset.SpanReadShared {
+		// Similarly, if the request is already in the queue because it is also
+		// writing to this key, defer to the stronger access.
+		if qg := l.findQueuedGuard(g); qg != nil && qg.access == spanset.SpanReadWrite {
+			return false, false
+		}
 	}
 
 	waitForState := waitingState{
@@ -1468,18 +1680,30 @@ func (l *lockState) tryActiveWait(
 		// and only waits for a reservation if the reservation has a lower
 		// seqNum. Note that `sa == spanset.SpanRead && lockHolderTxn == nil`
 		// was already checked above.
-		if g.txn == nil && l.reservation.seqNum > g.seqNum {
+		if g.txn == nil && l.reservation != nil && l.reservation.seqNum > g.seqNum {
 			// Reservation is held by a request with a higher seqNum and g is a
 			// non-transactional request. Ignore the reservation.
 			return false, false
 		}
-		waitForState.txn = l.reservation.txn
+		// The lock is held as Shared or reserved.
+		txn, held := l.lockerWaitsFor(g, sa)
+		if txn == nil {
+			// Compatible with the Shared lock holders or reservation, and not
+			// behind a request that wants to acquire an Exclusive lock.
+			return false, false
+		}
+		waitForState.txn = txn
+		waitForState.held = held
 	}
 
-	// Incompatible with whoever is holding lock or reservation.
+	// Incompatible with whoever is holding lock or reservation, or queued
+	// ahead of this request.
 
-	if l.reservation != nil && sa == spanset.SpanReadWrite && l.tryBreakReservation(g.seqNum) {
+	conflictsWithReservation := l.reservation != nil &&
+		!(sa == spanset.SpanReadShared && l.reservationAccess == spanset.SpanReadShared)
+	if conflictsWithReservation && l.tryBreakReservation(g.seqNum) {
 		l.reservation = g
+		l.reservationAccess = sa
 		g.mu.Lock()
 		g.mu.locks[l] = struct{}{}
 		g.mu.Unlock()
@@ -1494,18 +1718,12 @@ func (l *lockState) tryActiveWait(
 	wait = true
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	if sa == spanset.SpanReadWrite {
+	if sa != spanset.SpanReadOnly {
 		var qg *queuedGuard
 		if _, inQueue := g.mu.locks[l]; inQueue {
 			// Already in queue and must be in the right position, so mark as active
 			// waiter there. We expect this to be rare.
-			for e := l.queuedWriters.Front(); e != nil; e = e.Next() {
-				qqg := e.Value.(*queuedGuard)
-				if qqg.guard == g {
-					qg = qqg
-					break
-				}
-			}
+			qg = l.findQueuedGuard(g)
 			if qg == nil {
 				panic("lockTable bug")
 			}
@@ -1516,6 +1734,7 @@ func (l *lockState) tryActiveWait(
 			// designation is tentative (see below).
 			qg = &queuedGuard{
 				guard:  g,
+				access: sa,
 				active: true,
 			}
 			if curLen := l.queuedWriters.Len(); curLen == 0 {
@@ -1621,6 +1840,16 @@ func (l *lockState) isNonConflictingLock(g *lockTableGuardImpl, sa spanset.SpanA
 		// conflicting latches before declaring success and a reservation holder
 		// that holds latches will be discovered, and the optimistic evaluation
 		// will retry as pessimistic.
+		//
+		// Shared lock holders only conflict with writes from other
+		// transactions.
+		if sa == spanset.SpanReadWrite {
+			for i := range l.holder.shared {
+				if !g.isSameTxn(l.holder.shared[i].txn) {
+					return false
+				}
+			}
+		}
 		return true
 	}
 	if g.isSameTxn(lockHolderTxn) {
@@ -1643,7 +1872,7 @@ func (l *lockState) isNonConflictingLock(g *lockTableGuardImpl, sa spanset.SpanA
 // that is acquiring the lock.
 // Acquires l.mu.
 func (l *lockState) acquireLock(
-	_ lock.Strength,
+	strength lock.Strength,
 	durability lock.Durability,
 	txn *enginepb.TxnMeta,
 	ts hlc.Timestamp,
@@ -1651,6 +1880,27 @@ func (l *lockState) acquireLock(
 ) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	if strength == lock.Shared {
+		if !l.holder.locked {
+			return l.acquireSharedLock(txn, ts, timeProvider)
+		}
+		// A transaction that holds the lock as Exclusive does not need a
+		// Shared lock. We record the acquisition as an unreplicated Exclusive
+		// acquisition, which keeps the lock held (conservatively, as Exclusive)
+		// if the earlier acquisitions are rolled back.
+	} else if len(l.holder.shared) > 0 {
+		// Upgrade the transaction's Shared lock. The Shared lock's sequence
+		// numbers are carried over to the unreplicated Exclusive lock, for
+		// the same reason as above.
+		if !l.tryUpgradeSharedLock(txn) {
+			return errors.AssertionFailedf(
+				"lock held as Shared by other transactions cannot be acquired as Exclusive")
+		}
+		// If there are waiting requests from the same txn, they no longer need
+		// to wait. The other active waiters need to be told about the upgrade.
+		l.releaseWritersFromTxn(txn)
+		l.informActiveWaiters()
+	}
 	if l.holder.locked {
 		// Already held.
 		beforeTxn, beforeTs := l.getLockHolder()
@@ -1737,6 +1987,7 @@ func (l *lockState) acquireLock(
 			// Reservation is broken.
 			qg := &queuedGuard{
 				guard:  l.reservation,
+				access: l.reservationAccess,
 				active: false,
 			}
 			l.queuedWriters.PushFront(qg)
@@ -1774,6 +2025,102 @@ func (l *lockState) acquireLock(
 	return nil
 }
 
+// Acquires this lock as Shared. Shared locks are always unreplicated.
+// REQUIRES: l.mu is locked and the lock is not held as Exclusive.
+func (l *lockState) acquireSharedLock(
+	txn *enginepb.TxnMeta, ts hlc.Timestamp, timeProvider timeutil.TimeSource,
+) error {
+	if i := l.findSharedHolder(txn.ID); i >= 0 {
+		// Already held as Shared by this transaction. See the handling of
+		// sequence numbers in acquireLock.
+		holder := &l.holder.shared[i]
+		if holder.txn.Epoch < txn.Epoch {
+			// Clear the sequences for the older epoch.
+			holder.seqs = holder.seqs[:0]
+		}
+		seqs := holder.seqs
+		if len(seqs) > 0 && seqs[len(seqs)-1] >= txn.Sequence {
+			// Idempotent lock acquisition.
+			if j := sort.Search(len(seqs), func(j int) bool {
+				return seqs[j] >= txn.Sequence
+			}); seqs[j] != txn.Sequence {
+				seqs = append(seqs, 0)
+				copy(seqs[j+1:], seqs[j:])
+				seqs[j] = txn.Sequence
+				holder.seqs = seqs
+			}
+			return nil
+		}
+		holder.txn = txn
+		// The timestamp of a Shared lock does not determine which requests
+		// conflict with it, but we still forward it for consistency with
+		// Exclusive locks.
+		holder.ts.Forward(ts)
+		holder.seqs = append(seqs, txn.Sequence)
+		return nil
+	}
+	// Not already held by this transaction, so may have been reserved by this
+	// request. As in acquireLock, the reservation may also have been broken or
+	// be held by a different request.
+	if l.reservation != nil {
+		if l.reservation.txn.ID != txn.ID && l.reservationAccess == spanset.SpanReadWrite {
+			// Reservation is broken.
+			qg := &queuedGuard{
+				guard:  l.reservation,
+				access: l.reservationAccess,
+				active: false,
+			}
+			l.queuedWriters.PushFront(qg)
+		} else {
+			// Else, the reservation is held by this transaction, or by a request
+			// from another transaction that also wants to acquire a Shared lock,
+			// which is compatible with this one. In either case, the reservation
+			// holder is not actively waiting at this lock and no longer needs a
+			// reservation to acquire it.
+			l.reservation.mu.Lock()
+			delete(l.reservation.mu.locks, l)
+			l.reservation.mu.Unlock()
+		}
+	} else if len(l.holder.shared) == 0 && l.queuedWriters.Len() > 0 {
+		panic("lockTable bug")
+	}
+	if l.waitingReaders.Len() > 0 {
+		panic("lockTable bug")
+	}
+	l.reservation = nil
+	if len(l.holder.shared) == 0 {
+		l.holder.startTime = timeProvider.Now()
+	}
+	l.holder.shared = append(l.holder.shared, lockHolderInfo{
+		txn:  txn,
+		ts:   ts,
+		seqs: []enginepb.TxnSeq{txn.Sequence},
+	})
+
+	// If there are waiting requests from the same txn, they no longer need to
+	// wait. Requests that want to acquire an Exclusive lock will still
+	// conflict with this lock once they re-scan.
+	l.releaseWritersFromTxn(txn)
+
+	// Inform active waiters since lock has transitioned to held.
+	l.informActiveWaiters()
+	return nil
+}
+
+// Converts the Shared lock held by txn into an unreplicated Exclusive lock.
+// Returns false if the lock is held as Shared by any other transaction, in
+// which case the lock is not modified.
+// REQUIRES: l.mu is locked and the lock is held as Shared.
+func (l *lockState) tryUpgradeSharedLock(txn *enginepb.TxnMeta) bool {
+	if len(l.holder.shared) != 1 || l.holder.shared[0].txn.ID != txn.ID {
+		return false
+	}
+	l.holder.locked = true
+	l.holder.holder[lock.Unreplicated] = l.holder.shared[0]
+	l.holder.shared = nil
+	return true
+}
+
 // A replicated lock held by txn with timestamp ts was discovered by guard g
 // where g is trying to access this key with access sa.
 // Acquires l.mu.
@@ -1797,6 +2144,15 @@ func (l *lockState) discoveredLock(
 				"discovered lock by different transaction (%s) than existing lock (see issue #63592): %s",
 				txn, l)
 		}
+	} else if len(l.holder.shared) > 0 {
+		// The transaction that wrote the intent must be the only Shared lock
+		// holder, since Shared locks cannot be acquired on a key with an intent
+		// from another transaction.
+		if !l.tryUpgradeSharedLock(txn) {
+			return errors.AssertionFailedf(
+				"discovered lock by different transaction (%s) than existing Shared locks: %s",
+				txn, l)
+		}
 	} else {
 		l.holder.locked = true
 		l.holder.startTime = timeProvider.Now()
@@ -1816,6 +2172,7 @@ func (l *lockState) discoveredLock(
 	if l.reservation != nil {
 		qg := &queuedGuard{
 			guard:  l.reservation,
+			access: l.reservationAccess,
 			active: false,
 		}
 		l.queuedWriters.PushFront(qg)
@@ -1835,7 +2192,7 @@ func (l *lockState) discoveredLock(
 			return errors.AssertionFailedf("discovered non-conflicting lock")
 		}
 
-	case spanset.SpanReadWrite:
+	case spanset.SpanReadShared, spanset.SpanReadWrite:
 		// Immediately enter the lock's queuedWriters list.
 		// NB: this inactive waiter can be non-transactional.
 		g.mu.Lock()
@@ -1850,6 +2207,7 @@ func (l *lockState) discoveredLock(
 			// Put self in queue as inactive waiter.
 			qg := &queuedGuard{
 				guard:  g,
+				access: sa,
 				active: false,
 			}
 			// g is not necessarily first in the queue in the (rare) case (a) above.
@@ -1894,8 +2252,9 @@ func (l *lockState) tryClearLock(force bool) bool {
 	}
 	replicatedHeld := l.holder.locked && l.holder.holder[lock.Replicated].txn != nil
 
-	// Remove unreplicated holder.
+	// Remove unreplicated holders. Shared locks are always unreplicated.
 	l.holder.holder[lock.Unreplicated] = lockHolderInfo{}
+	l.holder.shared = nil
 	var waitState waitingState
 	if replicatedHeld && !force {
 		lockHolderTxn, _ := l.getLockHolder()
@@ -1936,7 +2295,6 @@ func (l *lockState) tryClearLock(force bool) bool {
 		g.mu.Unlock()
 	}
 
-	waitState.guardAccess = spanset.SpanReadWrite
 	for e := l.queuedWriters.Front(); e != nil; {
 		qg := e.Value.(*queuedGuard)
 		curr := e
@@ -1947,6 +2305,7 @@ func (l *lockState) tryClearLock(force bool) bool {
 		g.mu.Lock()
 		if qg.active {
 			g.mu.state = waitState
+			g.mu.state.guardAccess = qg.access
 			g.notify()
 		}
 		delete(g.mu.locks, l)
@@ -1986,6 +2345,9 @@ func (l *lockState) tryUpdateLock(up *roachpb.LockUpdate) (heldByTxn, gc bool) {
 		// tryActiveWait due to the txn being in the finalizedTxnCache.
 		return false, true
 	}
+	if len(l.holder.shared) > 0 {
+		return l.tryUpdateSharedLock(up)
+	}
 	if !l.isLockedBy(up.Txn.ID) {
 		return false, false
 	}
@@ -2062,6 +2424,40 @@ func (l *lockState) tryUpdateLock(up *roachpb.LockUpdate) (heldByTxn, gc bool) {
 	return true, false
 }
 
+// Like tryUpdateLock, but for a lock that is held as Shared.
+// REQUIRES: l.mu is locked.
+func (l *lockState) tryUpdateSharedLock(up *roachpb.LockUpdate) (heldByTxn, gc bool) {
+	i := l.findSharedHolder(up.Txn.ID)
+	if i < 0 {
+		return false, false
+	}
+	holder := &l.holder.shared[i]
+	txn := &up.Txn
+	// Shared locks are unreplicated, so the lock table is the source of truth
+	// for them. Like unreplicated Exclusive locks in tryUpdateLock, the lock
+	// is released if the transaction is finalized or has moved to a higher
+	// epoch, or if all of the sequence numbers at which it was acquired have
+	// been rolled back.
+	release := up.Status.IsFinalized() || txn.Epoch > holder.txn.Epoch
+	if !release && txn.Epoch == holder.txn.Epoch {
+		holder.seqs = removeIgnored(holder.seqs, up.IgnoredSeqNums)
+		release = len(holder.seqs) == 0
+	}
+	if release {
+		l.removeSharedHolder(i)
+		return true, l.releasedSharedLocks()
+	}
+	// The timestamp of a Shared lock does not affect which requests conflict
+	// with it, so there is no need to inform the waiters.
+	if holder.ts.Less(txn.WriteTimestamp) {
+		holder.ts = txn.WriteTimestamp
+		if txn.Epoch == holder.txn.Epoch {
+			holder.txn = txn
+		}
+	}
+	return true, false
+}
+
 // The lock holder timestamp has increased. Some of the waiters may no longer
 // need to wait.
 // REQUIRES: l.mu is locked.
@@ -2192,7 +2588,7 @@ func (l *lockState) tryFreeLockOnReplicatedAcquire() bool {
 // waiters, but there cannot be a reservation.
 // REQUIRES: l.mu is locked.
 func (l *lockState) lockIsFree() (gc bool) {
-	if l.holder.locked {
+	if l.isHeld() {
 		panic("called lockIsFree on lock with holder")
 	}
 	if l.reservation != nil {
@@ -2240,11 +2636,15 @@ func (l *lockState) lockIsFree() (gc bool) {
 		return true
 	}
 
-	// First waiting writer (it must be transactional) gets the reservation.
+	// First waiting writer (it must be transactional) gets the reservation. If
+	// it wants to acquire a Shared lock, the Shared lockers behind it that are
+	// not queued behind an Exclusive locker are released by
+	// informActiveWaiters below.
 	e := l.queuedWriters.Front()
 	qg := e.Value.(*queuedGuard)
 	g := qg.guard
 	l.reservation = g
+	l.reservationAccess = qg.access
 	l.queuedWriters.Remove(e)
 	if qg.active {
 		if g == l.distinguishedWaiter {
@@ -2473,8 +2873,14 @@ func (t *lockTableImpl) AcquireLock(
 		// If not enabled, don't track any locks.
 		return nil
 	}
-	if strength != lock.Exclusive {
-		return errors.AssertionFailedf("lock strength not Exclusive")
+	switch strength {
+	case lock.Exclusive:
+	case lock.Shared:
+		if durability != lock.Unreplicated {
+			return errors.AssertionFailedf("Shared locks must be Unreplicated")
+		}
+	default:
+		return errors.AssertionFailedf("lock strength %s not supported", strength)
 	}
 	ss := spanset.SpanGlobal
 	if keys.IsLocal(key) {
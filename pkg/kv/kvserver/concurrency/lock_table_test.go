@@ -61,7 +61,7 @@ new-txn txn=<name> ts=<int>[,<int>] epoch=<int> [seq=<int>]
 
  Creates a TxnMeta.
 
-new-request r=<name> txn=<name>|none ts=<int>[,<int>] spans=r|w@<start>[,<end>]+... [max-lock-wait-queue-length=<int>]
+new-request r=<name> txn=<name>|none ts=<int>[,<int>] spans=r|s|w@<start>[,<end>]+... [max-lock-wait-queue-length=<int>]
 ----
 
  Creates a Request.
@@ -80,11 +80,12 @@ start-waiting: <bool>
  Calls lockTable.ScanOptimistic. The request must not have an existing guard.
  If a guard is returned, stores it for later use.
 
-acquire r=<name> k=<key> durability=r|u
+acquire r=<name> k=<key> durability=r|u [strength=shared|exclusive]
 ----
 <error string>
 
- Acquires lock for the request, using the existing guard for that request.
+ Acquires lock for the request, using the existing guard for that request. The
+ lock is acquired with Exclusive strength, unless specified otherwise.
 
 release txn=<name> span=<start>[,<end>]
 ----
@@ -109,7 +110,7 @@ add-discovered r=<name> k=<key> txn=<name> [lease-seq=<seq>] [consult-finalized-
 
  Adds a discovered lock that is discovered by the named request.
 
-check-opt-no-conflicts r=<name> spans=r|w@<start>[,<end>]+...
+check-opt-no-conflicts r=<name> spans=r|s|w@<start>[,<end>]+...
 ----
 no-conflicts: <bool>
 
@@ -347,7 +348,13 @@ func TestLockTableBasic(t *testing.T) {
 				if s[0] == 'r' {
 					durability = lock.Replicated
 				}
-				if err := lt.AcquireLock(&req.Txn.TxnMeta, roachpb.Key(key), lock.Exclusive, durability); err != nil {
+				strength := lock.Exclusive
+				if d.HasArg("strength") {
+					var str string
+					d.ScanArgs(t, "strength", &str)
+					strength = parseLockStrength(t, d, str)
+				}
+				if err := lt.AcquireLock(&req.Txn.TxnMeta, roachpb.Key(key), strength, durability); err != nil {
 					return err.Error()
 				}
 				return lt.String()
@@ -618,6 +625,8 @@ func scanSpans(t *testing.T, d *datadriven.TestData, ts hlc.Timestamp) *spanset.
 		switch c {
 		case 'r':
 			sa = spanset.SpanReadOnly
+		case 's':
+			sa = spanset.SpanReadShared
 		case 'w':
 			sa = spanset.SpanReadWrite
 		default:
@@ -843,8 +852,9 @@ type workItem struct {
 	// Contains one of request or intents.
 
 	// Request.
-	request        *Request
-	locksToAcquire []roachpb.Key
+	request              *Request
+	locksToAcquire       []roachpb.Key
+	sharedLocksToAcquire []roachpb.Key
 
 	// Update locks.
 	intents []roachpb.LockUpdate
@@ -923,11 +933,19 @@ func doWork(ctx context.Context, item *workItem, e *workloadExecutor) error {
 
 		// acquire locks.
 		for _, k := range item.locksToAcquire {
-			err = e.acquireLock(&item.request.Txn.TxnMeta, k)
+			err = e.acquireLock(&item.request.Txn.TxnMeta, k, lock.Exclusive)
 			if err != nil {
 				break
 			}
 		}
+		if err == nil {
+			for _, k := range item.sharedLocksToAcquire {
+				err = e.acquireLock(&item.request.Txn.TxnMeta, k, lock.Shared)
+				if err != nil {
+					break
+				}
+			}
+		}
 		e.lt.Dequeue(g)
 		e.lm.Release(lg)
 		return err
@@ -946,7 +964,8 @@ type workloadItem struct {
 	// Request to be executed, iff request != nil
 	request *Request
 	// locks to be acquired by the request.
-	locksToAcquire []roachpb.Key
+	locksToAcquire       []roachpb.Key
+	sharedLocksToAcquire []roachpb.Key
 
 	// Non-empty when transaction should release locks.
 	finish uuid.UUID
@@ -985,8 +1004,9 @@ func makeWorkItemFinishTxn(tstate *transactionState) workItem {
 
 func makeWorkItemForRequest(wi workloadItem) workItem {
 	wItem := workItem{
-		request:        wi.request,
-		locksToAcquire: wi.locksToAcquire,
+		request:              wi.request,
+		locksToAcquire:       wi.locksToAcquire,
+		sharedLocksToAcquire: wi.sharedLocksToAcquire,
 	}
 	return wItem
 }
@@ -1020,8 +1040,10 @@ func newWorkLoadExecutor(items []workloadItem, concurrency int) *workloadExecuto
 	}
 }
 
-func (e *workloadExecutor) acquireLock(txn *enginepb.TxnMeta, k roachpb.Key) error {
-	err := e.lt.AcquireLock(txn, k, lock.Exclusive, lock.Unreplicated)
+func (e *workloadExecutor) acquireLock(
+	txn *enginepb.TxnMeta, k roachpb.Key, strength lock.Strength,
+) error {
+	err := e.lt.AcquireLock(txn, k, strength, lock.Unreplicated)
 	if err != nil {
 		return err
 	}
@@ -1241,13 +1263,6 @@ func TestLockTableConcurrentSingleRequests(t *testing.T) {
 	const numRequests = 10000
 	for i := 0; i < numRequests; i++ {
 		ts := timestamps[rng.Intn(len(timestamps))]
-		keysPerm := rng.Perm(len(keys))
-		spans := &spanset.SpanSet{}
-		for i := 0; i < numKeys; i++ {
-			span := roachpb.Span{Key: keys[keysPerm[i]]}
-			acc := spanset.SpanAccess(rng.Intn(int(spanset.NumSpanAccess)))
-			spans.AddMVCC(acc, span, ts)
-		}
 		var txn *roachpb.Transaction
 		if rng.Intn(2) == 0 {
 			txn = &roachpb.Transaction{
@@ -1258,11 +1273,19 @@ func TestLockTableConcurrentSingleRequests(t *testing.T) {
 				ReadTimestamp: ts,
 			}
 		}
+		keysPerm := rng.Perm(len(keys))
+		latchSpans, lockSpans := &spanset.SpanSet{}, &spanset.SpanSet{}
+		for i := 0; i < numKeys; i++ {
+			span := roachpb.Span{Key: keys[keysPerm[i]]}
+			acc := randomLockAccess(rng, txn != nil)
+			latchSpans.AddMVCC(latchAccess(acc), span, ts)
+			lockSpans.AddMVCC(acc, span, ts)
+		}
 		request := &Request{
 			Txn:        txn,
 			Timestamp:  ts,
-			LatchSpans: spans,
-			LockSpans:  spans,
+			LatchSpans: latchSpans,
+			LockSpans:  lockSpans,
 		}
 		items = append(items, workloadItem{request: request})
 		if txn != nil {
@@ -1335,13 +1358,13 @@ func TestLockTableConcurrentRequests(t *testing.T) {
 			ts = timestamps[rng.Intn(len(timestamps))]
 		}
 		keysPerm := rng.Perm(len(keys))
-		spans := &spanset.SpanSet{}
+		latchSpans, lockSpans := &spanset.SpanSet{}, &spanset.SpanSet{}
 		onlyReads := txnMeta == nil && rng.Intn(2) != 0
 		numKeys := rng.Intn(len(keys)-1) + 1
 		request := &Request{
 			Timestamp:  ts,
-			LatchSpans: spans,
-			LockSpans:  spans,
+			LatchSpans: latchSpans,
+			LockSpans:  lockSpans,
 		}
 		if txnMeta != nil {
 			request.Txn = &roachpb.Transaction{
@@ -1355,19 +1378,25 @@ func TestLockTableConcurrentRequests(t *testing.T) {
 			acc := spanset.SpanReadOnly
 			dupRead := false
 			if !onlyReads {
-				acc = spanset.SpanAccess(rng.Intn(int(spanset.NumSpanAccess)))
+				acc = randomLockAccess(rng, txnMeta != nil)
 				if acc == spanset.SpanReadWrite && txnMeta != nil && rng.Intn(2) == 0 {
 					// Acquire lock.
 					wi.locksToAcquire = append(wi.locksToAcquire, span.Key)
 				}
-				if acc == spanset.SpanReadWrite && rng.Intn(2) == 0 {
+				if acc == spanset.SpanReadShared && rng.Intn(2) == 0 {
+					// Acquire Shared lock.
+					wi.sharedLocksToAcquire = append(wi.sharedLocksToAcquire, span.Key)
+				}
+				if acc != spanset.SpanReadOnly && rng.Intn(2) == 0 {
 					// Also include the key as read.
 					dupRead = true
 				}
 			}
-			spans.AddMVCC(acc, span, ts)
+			latchSpans.AddMVCC(latchAccess(acc), span, ts)
+			lockSpans.AddMVCC(acc, span, ts)
 			if dupRead {
-				spans.AddMVCC(spanset.SpanReadOnly, span, ts)
+				latchSpans.AddMVCC(spanset.SpanReadOnly, span, ts)
+				lockSpans.AddMVCC(spanset.SpanReadOnly, span, ts)
 			}
 		}
 		items = append(items, wi)
@@ -1388,6 +1417,27 @@ func TestLockTableConcurrentRequests(t *testing.T) {
 	}
 }
 
+// randomLockAccess returns a random access for a key in the lock spans of a
+// request. Only transactional requests acquire Shared locks.
+func randomLockAccess(rng *rand.Rand, transactional bool) spanset.SpanAccess {
+	acc := spanset.SpanAccess(rng.Intn(int(spanset.NumSpanAccess)))
+	if acc == spanset.SpanReadShared && !transactional {
+		acc = spanset.SpanReadWrite
+	}
+	return acc
+}
+
+// latchAccess returns the access of the latch span declared by a request for
+// a key in its lock spans with the provided access. Like
+// batcheval.DefaultDeclareIsolatedKeys, requests that acquire Shared locks
+// declare write latches.
+func latchAccess(acc spanset.SpanAccess) spanset.SpanAccess {
+	if acc == spanset.SpanReadShared {
+		return spanset.SpanReadWrite
+	}
+	return acc
+}
+
 type benchWorkItem struct {
 	Request
 	locksToAcquire []roachpb.Key
@@ -469,13 +469,17 @@ func (w *lockTableWaiterImpl) pushLockTxn(
 		// the lock holder's timestamp forward so the read request can read
 		// under the lock. For write-write conflicts, try to abort the lock
 		// holder entirely so the write request can revoke and replace the lock
-		// with its own lock.
+		// with its own lock. Shared locking reads are treated like writes, as
+		// pushing the timestamp of a conflicting lock holder does not allow
+		// them to acquire a lock. If the lock is held by multiple Shared lock
+		// holders, the waiter pushes one of them at a time, as directed by the
+		// lockTable.
 		switch ws.guardAccess {
 		case spanset.SpanReadOnly:
 			pushType = roachpb.PUSH_TIMESTAMP
 			log.VEventf(ctx, 2, "pushing timestamp of txn %s above %s", ws.txn.ID.Short(), h.Timestamp)
 
-		case spanset.SpanReadWrite:
+		case spanset.SpanReadShared, spanset.SpanReadWrite:
 			pushType = roachpb.PUSH_ABORT
 			log.VEventf(ctx, 2, "pushing txn %s to abort", ws.txn.ID.Short())
 		}
@@ -137,8 +137,8 @@ func consistencyQueueShouldQueueImpl(
 			return false, 0
 		}
 	}
-	// Check if all replicas are available.
-	for _, rep := range data.desc.Replicas().Descriptors() {
+	// Check if all replicas holding data are available.
+	for _, rep := range data.desc.Replicas().DataReplicaDescriptors() {
 		if !data.isNodeAvailable(rep.NodeID) {
 			return false, 0
 		}
@@ -123,10 +123,16 @@ type PureGCer interface {
 	GC(context.Context, []roachpb.GCRequest_GCKey) error
 }
 
+// RangeKeyGCer is part of the GCer interface.
+type RangeKeyGCer interface {
+	GCRangeKeys(context.Context, []roachpb.GCRequest_GCRangeKey) error
+}
+
 // A GCer is an abstraction used by the MVCC GC queue to carry out chunked deletions.
 type GCer interface {
 	Thresholder
 	PureGCer
+	RangeKeyGCer
 }
 
 // NoopGCer implements GCer by doing nothing.
@@ -140,6 +146,9 @@ func (NoopGCer) SetGCThreshold(context.Context, Threshold) error { return nil }
 // GC implements storage.GCer.
 func (NoopGCer) GC(context.Context, []roachpb.GCRequest_GCKey) error { return nil }
 
+// GCRangeKeys implements storage.GCer.
+func (NoopGCer) GCRangeKeys(context.Context, []roachpb.GCRequest_GCRangeKey) error { return nil }
+
 // Threshold holds the key and txn span GC thresholds, respectively.
 type Threshold struct {
 	Key hlc.Timestamp
@@ -293,6 +302,10 @@ func Run(
 //
 // The logic iterates all versions of all keys in the range from oldest to
 // newest. Expired intents are written into the txnMap and intentKeyMap.
+//
+// Versions deleted by MVCC range tombstones at or below the threshold are
+// garbage, like versions deleted by point tombstones. Once they have all been
+// removed, the range tombstones at or below the threshold are removed too.
 func processReplicatedKeyRange(
 	ctx context.Context,
 	desc *roachpb.RangeDescriptor,
@@ -309,6 +322,12 @@ func processReplicatedKeyRange(
 	// Compute intent expiration (intent age at which we attempt to resolve).
 	intentExp := now.Add(-intentAgeThreshold.Nanoseconds(), 0)
 
+	rangeTombstones, err := storage.ReadMVCCRangeTombstones(
+		snap, desc.StartKey.AsRawKey(), desc.EndKey.AsRawKey())
+	if err != nil {
+		return err
+	}
+
 	batcher := newIntentBatcher(cleanupIntentsFn, options, info)
 
 	handleIntent := func(keyValue *storage.MVCCKeyValue) error {
@@ -369,11 +388,19 @@ func processReplicatedKeyRange(
 			continue
 		}
 		isNewest := s.curIsNewest()
-		if isGarbage(threshold, s.cur, s.next, isNewest) {
+		if isGarbage(threshold, rangeTombstones, s.cur, s.next, isNewest) {
 			keyBytes := int64(s.cur.Key.EncodedSize())
 			batchGCKeysBytes += keyBytes
 			haveGarbageForThisKey = true
 			gcTimestampForThisKey = s.cur.Key.Timestamp
+			if isNewest {
+				// If the newest version was deleted by a range tombstone, the GC
+				// request must cover the range tombstone's timestamp for it to be
+				// removed (see storage.MVCCGarbageCollect).
+				if deletedAt, ok := deletedByRangeTombstone(threshold, rangeTombstones, s.cur); ok {
+					gcTimestampForThisKey = deletedAt
+				}
+			}
 			info.AffectedVersionsKeyBytes += keyBytes
 			info.AffectedVersionsValBytes += int64(len(s.cur.Value))
 		}
@@ -424,6 +451,25 @@ func processReplicatedKeyRange(
 			return err
 		}
 	}
+
+	// Finally, remove the range tombstones at or below the threshold. This must
+	// happen after the point keys they cover have been removed, since they
+	// would otherwise become visible again.
+	var gcRangeKeys []roachpb.GCRequest_GCRangeKey
+	for _, rk := range rangeTombstones.RangeKeys() {
+		if rk.Timestamp.LessEq(threshold) {
+			gcRangeKeys = append(gcRangeKeys, roachpb.GCRequest_GCRangeKey{
+				StartKey:  rk.StartKey,
+				EndKey:    rk.EndKey,
+				Timestamp: rk.Timestamp,
+			})
+		}
+	}
+	if len(gcRangeKeys) > 0 {
+		if err := gcer.GCRangeKeys(ctx, gcRangeKeys); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -561,12 +607,23 @@ func (b *intentBatcher) maybeFlushPendingIntents(ctx context.Context) error {
 // guaranteed as described above. However if this were the only rule, then if
 // the most recent write was a delete, it would never be removed. Thus, when a
 // deleted value is the most recent before expiration, it can be deleted.
-func isGarbage(threshold hlc.Timestamp, cur, next *storage.MVCCKeyValue, isNewest bool) bool {
+//
+// A value deleted by a range tombstone at or below the expiration time is
+// treated like a deleted value.
+func isGarbage(
+	threshold hlc.Timestamp,
+	rangeTombstones *storage.MVCCRangeTombstones,
+	cur, next *storage.MVCCKeyValue,
+	isNewest bool,
+) bool {
 	// If the value is not at or below the threshold then it's not garbage.
 	if belowThreshold := cur.Key.Timestamp.LessEq(threshold); !belowThreshold {
 		return false
 	}
 	isDelete := len(cur.Value) == 0
+	if !isDelete {
+		_, isDelete = deletedByRangeTombstone(threshold, rangeTombstones, cur)
+	}
 	if isNewest && !isDelete {
 		return false
 	}
@@ -580,6 +637,15 @@ func isGarbage(threshold hlc.Timestamp, cur, next *storage.MVCCKeyValue, isNewes
 	return isDelete || next.Key.Timestamp.LessEq(threshold)
 }
 
+// deletedByRangeTombstone returns the timestamp of the range tombstone that
+// deleted the given version, if it is at or below the threshold.
+func deletedByRangeTombstone(
+	threshold hlc.Timestamp, rangeTombstones *storage.MVCCRangeTombstones, cur *storage.MVCCKeyValue,
+) (hlc.Timestamp, bool) {
+	deletedAt, ok := rangeTombstones.DeletedAt(cur.Key.Key, cur.Key.Timestamp)
+	return deletedAt, ok && deletedAt.LessEq(threshold)
+}
+
 // processLocalKeyRange scans the local range key entries, consisting of
 // transaction records, queue last processed timestamps, and range descriptors.
 //
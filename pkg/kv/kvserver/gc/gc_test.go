@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -149,6 +150,59 @@ func TestIntentAgeThresholdSetting(t *testing.T) {
 		"Expected 1 intents considered by GC with short threshold")
 }
 
+// TestGCRangeTombstones verifies that versions deleted by MVCC range
+// tombstones at or below the GC threshold are garbage collected at the range
+// tombstone's timestamp, and that those range tombstones are then removed,
+// while versions deleted by newer range tombstones are kept.
+func TestGCRangeTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	eng := storage.NewDefaultInMemForTesting()
+	defer eng.Close()
+	require.NoError(t, eng.SetMinVersion(
+		clusterversion.ByKey(clusterversion.EnsurePebbleFormatVersionRangeKeys)))
+
+	ts := func(sec int64) hlc.Timestamp {
+		return hlc.Timestamp{WallTime: sec * time.Second.Nanoseconds()}
+	}
+	value := roachpb.MakeValueFromString("value")
+	require.NoError(t, storage.MVCCPut(ctx, eng, nil, roachpb.Key("a"), ts(1), value, nil))
+	require.NoError(t, storage.MVCCPut(ctx, eng, nil, roachpb.Key("b"), ts(2), value, nil))
+	require.NoError(t, storage.MVCCPut(ctx, eng, nil, roachpb.Key("c"), ts(6), value, nil))
+	require.NoError(t, storage.MVCCPut(ctx, eng, nil, roachpb.Key("d"), ts(1), value, nil))
+	// The first range tombstone is below the GC threshold, the second above it.
+	require.NoError(t, storage.MVCCDeleteRangeUsingTombstone(
+		ctx, eng, nil, roachpb.Key("a"), roachpb.Key("c"), ts(3), 0))
+	require.NoError(t, storage.MVCCDeleteRangeUsingTombstone(
+		ctx, eng, nil, roachpb.Key("c"), roachpb.Key("e"), ts(8), 0))
+
+	desc := roachpb.RangeDescriptor{
+		StartKey: roachpb.RKey("a"),
+		EndKey:   roachpb.RKey("z"),
+	}
+	gcTTL := 5 * time.Second
+	nowTs := ts(10)
+	snap := eng.NewSnapshot()
+	defer snap.Close()
+	fakeGCer := makeFakeGCer()
+	_, err := Run(ctx, &desc, snap, nowTs, CalculateThreshold(nowTs, gcTTL),
+		RunOptions{IntentAgeThreshold: time.Hour}, gcTTL, &fakeGCer,
+		fakeGCer.resolveIntents, fakeGCer.resolveIntentsAsync)
+	require.NoError(t, err)
+
+	// a and b were deleted by the range tombstone at 3, so they are removed at
+	// its timestamp. c and d were deleted by the range tombstone at 8, above
+	// the threshold, so they are kept.
+	require.Equal(t, map[string]roachpb.GCRequest_GCKey{
+		roachpb.Key("a").String(): {Key: roachpb.Key("a"), Timestamp: ts(3)},
+		roachpb.Key("b").String(): {Key: roachpb.Key("b"), Timestamp: ts(3)},
+	}, fakeGCer.gcKeys)
+	require.Equal(t, []roachpb.GCRequest_GCRangeKey{
+		{StartKey: roachpb.Key("a"), EndKey: roachpb.Key("c"), Timestamp: ts(3)},
+	}, fakeGCer.gcRangeKeys)
+}
+
 func TestIntentCleanupBatching(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
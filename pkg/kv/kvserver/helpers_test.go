@@ -477,6 +477,14 @@ func (r *Replica) GetQueueLastProcessed(ctx context.Context, queue string) (hlc.
 	return r.getQueueLastProcessed(ctx, queue)
 }
 
+// TransferRaftLeadership asks Raft to transfer the leadership of the range to
+// the given replica. It's a no-op unless this replica is the leader.
+func (r *Replica) TransferRaftLeadership(target roachpb.ReplicaID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.internalRaftGroup.TransferLeader(uint64(target))
+}
+
 func (r *Replica) MaybeUnquiesceAndWakeLeader() bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -55,6 +55,14 @@ type BulkAdderOptions struct {
 	// detection does not apply.
 	SkipDuplicates bool
 
+	// ReplaceDuplicates configures handling of duplicate keys within a local
+	// sorted batch like SkipDuplicates, except that the value added last for a
+	// key is kept and the values added before it are dropped, whether or not
+	// they are equal. Combined with WriteAtBatchTimestamp, a value added after
+	// a flush is written at a later timestamp than those added before it, so
+	// the value added last for a key is always its newest version.
+	ReplaceDuplicates bool
+
 	// DisallowShadowingBelow controls whether shadowing of existing keys is
 	// permitted when the SSTables produced by this adder are ingested. See the
 	// comment on roachpb.AddSSTableRequest for more details. Note that if this is
@@ -74,6 +82,12 @@ type BulkAdderOptions struct {
 	// roachpb.AddSSTableRequest.SSTTimestampToRequestTimestamp.
 	WriteAtBatchTimestamp bool
 
+	// ElasticAdmission makes the AddSSTable requests sent by this adder elastic
+	// work for admission control (see admission.BulkNormalPri), which is
+	// throttled when the disk bandwidth utilization of a store is high. When
+	// false, the requests bypass admission control.
+	ElasticAdmission bool
+
 	// InitialSplitsIfUnordered specifies a number of splits to make before the
 	// first flush of the buffer if the contents of that buffer were unsorted.
 	// Being unsorted suggests the remaining input is likely unsorted as well and
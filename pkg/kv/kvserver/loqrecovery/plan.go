@@ -281,7 +281,8 @@ func rankReplicasBySurvivability(replicas []loqrecoverypb.ReplicaInfo) rankedRep
 	isVoter := func(desc loqrecoverypb.ReplicaInfo) int {
 		for _, replica := range desc.Desc.InternalReplicas {
 			if replica.StoreID == desc.StoreID {
-				if replica.IsVoterNewConfig() {
+				// NB: Witnesses hold no data, so they can't be survivors.
+				if replica.IsVoterNewConfig() && replica.GetType() != roachpb.WITNESS {
 					return 1
 				}
 				return 0
@@ -364,7 +365,7 @@ func checkKeyspaceCovering(replicas []rankedReplicas) ([]Problem, error) {
 		if err != nil {
 			return nil, err
 		}
-		if !r.IsVoterNewConfig() {
+		if !r.IsVoterNewConfig() || r.GetType() == roachpb.WITNESS {
 			continue
 		}
 		switch {
@@ -269,7 +269,13 @@ func (mq *mergeQueue) process(
 	// Use a lower threshold for load based splitting so we don't find ourselves
 	// in a situation where we keep merging ranges that would be split soon after
 	// by a small increase in load.
-	conservativeLoadBasedSplitThreshold := 0.5 * lhsRepl.SplitByLoadQPSThreshold()
+	//
+	// NB: The "QPS" measurements above are in the unit of the load based
+	// splitting objective, which is CPU time per second when splitting on CPU.
+	// The objective is cluster-wide, so both sides normally agree on the unit.
+	// They may transiently disagree right after the objective changes, or if
+	// only some of the nodes can measure CPU time.
+	conservativeLoadBasedSplitThreshold := 0.5 * lhsRepl.SplitByLoadThreshold(lhsRepl.loadSplitObjective(ctx))
 	shouldSplit, _ := shouldSplitRange(ctx, mergedDesc, mergedStats,
 		lhsRepl.GetMaxBytes(), lhsRepl.shouldBackpressureWrites(), confReader)
 	if shouldSplit || mergedQPS >= conservativeLoadBasedSplitThreshold {
@@ -296,26 +302,37 @@ func (mq *mergeQueue) process(
 	}
 	leftRepls, rightRepls := lhsDesc.Replicas().Descriptors(), rhsDesc.Replicas().Descriptors()
 
-	// Defensive sanity check that the ranges involved only have either VOTER_FULL
-	// and NON_VOTER replicas.
-	for i := range leftRepls {
-		if typ := leftRepls[i].GetType(); !(typ == roachpb.VOTER_FULL || typ == roachpb.NON_VOTER) {
-			return false,
-				errors.AssertionFailedf(
-					`cannot merge because lhs is either in a joint state or has learner replicas: %v`,
-					leftRepls,
-				)
-		}
+	// Defensive sanity check that the ranges involved only have VOTER_FULL,
+	// NON_VOTER and WITNESS replicas.
+	if !mergeableReplicaTypes(lhsDesc.Replicas()) {
+		return false,
+			errors.AssertionFailedf(
+				`cannot merge because lhs is either in a joint state or has learner replicas: %v`,
+				leftRepls,
+			)
 	}
 
 	// Range merges require that the set of stores that contain a replica for the
 	// RHS range be equal to the set of stores that contain a replica for the LHS
-	// range. The LHS and RHS ranges' leaseholders do not need to be co-located
-	// and types of the replicas (voting or non-voting) do not matter. Even if
+	// range, and that the replicas on each store be of the same type. The LHS
+	// and RHS ranges' leaseholders do not need to be co-located. Even if
 	// replicas are collocated, the RHS might still be in a joint config, and
 	// calling AdminRelocateRange will fix this.
 	if !replicasCollocated(leftRepls, rightRepls) ||
 		rhsDesc.Replicas().InAtomicReplicationChange() {
+		// AdminRelocateRange doesn't know how to move witnesses, so remove the
+		// RHS's witnesses before collocating its other replicas with the LHS's
+		// and add the LHS's witnesses to the RHS afterwards.
+		for _, w := range rhsDesc.Replicas().WitnessDescriptors() {
+			rhsDesc, err = mq.store.DB().AdminChangeReplicas(
+				ctx, rhsDesc.StartKey, *rhsDesc, roachpb.MakeReplicationChanges(
+					roachpb.REMOVE_WITNESS, roachpb.ReplicationTarget{NodeID: w.NodeID, StoreID: w.StoreID}),
+			)
+			if err != nil {
+				return false, err
+			}
+		}
+
 		// TODO(aayush): We enable merges to proceed even when LHS and/or RHS are in
 		// violation of their constraints (by adding or removing replicas on the RHS
 		// as needed). We could instead choose to check constraints conformance of
@@ -357,17 +374,22 @@ func (mq *mergeQueue) process(
 		if err != nil {
 			return false, err
 		}
+		for _, target := range lhsDesc.Replicas().Witnesses().ReplicationTargets() {
+			rhsDesc, err = mq.store.DB().AdminChangeReplicas(
+				ctx, rhsDesc.StartKey, *rhsDesc, roachpb.MakeReplicationChanges(roachpb.ADD_WITNESS, target),
+			)
+			if err != nil {
+				return false, err
+			}
+		}
 		rightRepls = rhsDesc.Replicas().Descriptors()
 	}
-	for i := range rightRepls {
-		if typ := rightRepls[i].GetType(); !(typ == roachpb.VOTER_FULL || typ == roachpb.NON_VOTER) {
-			log.Infof(ctx, "RHS Type: %s", typ)
-			return false,
-				errors.AssertionFailedf(
-					`cannot merge because rhs is either in a joint state or has learner replicas: %v`,
-					rightRepls,
-				)
-		}
+	if !mergeableReplicaTypes(rhsDesc.Replicas()) {
+		return false,
+			errors.AssertionFailedf(
+				`cannot merge because rhs is either in a joint state or has learner replicas: %v`,
+				rightRepls,
+			)
 	}
 
 	log.VEventf(ctx, 2, "merging to produce range: %s-%s", mergedDesc.StartKey, mergedDesc.EndKey)
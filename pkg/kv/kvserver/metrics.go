@@ -293,6 +293,12 @@ var (
 		Measurement: "Keys/Sec",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaAverageCPUNanosPerSecond = metric.Metadata{
+		Name:        "rebalancing.cpunanospersecond",
+		Help:        "CPU time spent per second by the store evaluating requests and applying raft commands, averaged over a large time period as used in rebalancing decisions",
+		Measurement: "Nanoseconds/Sec",
+		Unit:        metric.Unit_NANOSECONDS,
+	}
 
 	// Metric for tracking follower reads.
 	metaFollowerReadsCount = metric.Metadata{
@@ -496,6 +502,18 @@ var (
 		Measurement: "Snapshots",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaRangeSnapshotsDelegateSuccesses = metric.Metadata{
+		Name:        "range.snapshots.delegate.successes",
+		Help:        "Number of snapshots that were successfully sent by a delegated sender on behalf of this store",
+		Measurement: "Snapshots",
+		Unit:        metric.Unit_COUNT,
+	}
+	metaRangeSnapshotsDelegateFailures = metric.Metadata{
+		Name:        "range.snapshots.delegate.failures",
+		Help:        "Number of snapshots that could not be sent by a delegated sender, and were sent by this store instead",
+		Measurement: "Snapshots",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaRangeRaftLeaderTransfers = metric.Metadata{
 		Name:        "range.raftleadertransfers",
 		Help:        "Number of raft leader transfers",
@@ -1343,8 +1361,9 @@ type StoreMetrics struct {
 	Reserved           *metric.Gauge
 
 	// Rebalancing metrics.
-	AverageQueriesPerSecond *metric.GaugeFloat64
-	AverageWritesPerSecond  *metric.GaugeFloat64
+	AverageQueriesPerSecond  *metric.GaugeFloat64
+	AverageWritesPerSecond   *metric.GaugeFloat64
+	AverageCPUNanosPerSecond *metric.GaugeFloat64
 
 	// Follower read metrics.
 	FollowerReadsCount *metric.Counter
@@ -1392,6 +1411,8 @@ type StoreMetrics struct {
 	RangeSnapshotsAppliedByVoters                *metric.Counter
 	RangeSnapshotsAppliedForInitialUpreplication *metric.Counter
 	RangeSnapshotsAppliedByNonVoters             *metric.Counter
+	RangeSnapshotsDelegateSuccesses              *metric.Counter
+	RangeSnapshotsDelegateFailures               *metric.Counter
 	RangeRaftLeaderTransfers                     *metric.Counter
 	RangeLossOfQuorumRecoveries                  *metric.Counter
 
@@ -1784,8 +1805,9 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		Reserved:  metric.NewGauge(metaReserved),
 
 		// Rebalancing metrics.
-		AverageQueriesPerSecond: metric.NewGaugeFloat64(metaAverageQueriesPerSecond),
-		AverageWritesPerSecond:  metric.NewGaugeFloat64(metaAverageWritesPerSecond),
+		AverageQueriesPerSecond:  metric.NewGaugeFloat64(metaAverageQueriesPerSecond),
+		AverageWritesPerSecond:   metric.NewGaugeFloat64(metaAverageWritesPerSecond),
+		AverageCPUNanosPerSecond: metric.NewGaugeFloat64(metaAverageCPUNanosPerSecond),
 
 		// Follower reads metrics.
 		FollowerReadsCount: metric.NewCounter(metaFollowerReadsCount),
@@ -1828,6 +1850,8 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		RangeSnapshotsAppliedByVoters: metric.NewCounter(metaRangeSnapshotsAppliedByVoters),
 		RangeSnapshotsAppliedForInitialUpreplication: metric.NewCounter(metaRangeSnapshotsAppliedForInitialUpreplication),
 		RangeSnapshotsAppliedByNonVoters:             metric.NewCounter(metaRangeSnapshotsAppliedByNonVoter),
+		RangeSnapshotsDelegateSuccesses:              metric.NewCounter(metaRangeSnapshotsDelegateSuccesses),
+		RangeSnapshotsDelegateFailures:               metric.NewCounter(metaRangeSnapshotsDelegateFailures),
 		RangeRaftLeaderTransfers:                     metric.NewCounter(metaRangeRaftLeaderTransfers),
 		RangeLossOfQuorumRecoveries:                  metric.NewCounter(metaRangeLossOfQuorumRecoveries),
 
@@ -440,16 +440,15 @@ func (r *replicaGCer) send(ctx context.Context, req roachpb.GCRequest) error {
 	var admissionHandle interface{}
 	if r.admissionController != nil {
 		ba.AdmissionHeader = roachpb.AdmissionHeader{
-			// GC is currently assigned NormalPri.
+			// GC is assigned BulkNormalPri, which makes it elastic work that is
+			// throttled when the store's disk bandwidth utilization is high, so
+			// that it does not impact user-facing traffic.
 			//
-			// TODO(kv): GC could be expected to be LowPri, so that it does not
-			// impact user-facing traffic when resources (e.g. CPU, write capacity
-			// of the store) are scarce. However long delays in GC can slow down
-			// user-facing traffic due to more versions in the store, and can
-			// increase write amplification of the store since there is more live
-			// data. Ideally, we should adjust this priority based on how far behind
-			// we are wrt GCing in this range.
-			Priority:                 int32(admission.NormalPri),
+			// TODO(kv): long delays in GC can slow down user-facing traffic due to
+			// more versions in the store, and can increase write amplification of
+			// the store since there is more live data. Ideally, we should adjust
+			// this priority based on how far behind we are wrt GCing in this range.
+			Priority:                 int32(admission.BulkNormalPri),
 			CreateTime:               timeutil.Now().UnixNano(),
 			Source:                   roachpb.AdmissionHeader_ROOT_KV,
 			NoMemoryReservedAtSource: true,
@@ -487,6 +486,17 @@ func (r *replicaGCer) GC(ctx context.Context, keys []roachpb.GCRequest_GCKey) er
 	return r.send(ctx, req)
 }
 
+func (r *replicaGCer) GCRangeKeys(
+	ctx context.Context, rangeKeys []roachpb.GCRequest_GCRangeKey,
+) error {
+	if len(rangeKeys) == 0 {
+		return nil
+	}
+	req := r.template()
+	req.RangeKeys = rangeKeys
+	return r.send(ctx, req)
+}
+
 // process first determines whether the replica can run MVCC GC given its view
 // of the protected timestamp subsystem and its current state. This check also
 // determines the most recent time which can be used for the purposes of
@@ -226,6 +226,9 @@ type storeForTruncator interface {
 	releaseReplicaForTruncator(r replicaForTruncator)
 	// Engine accessor.
 	getEngine() storage.Engine
+	// getRaftEngine returns the engine holding the raft log, which is the same
+	// as getEngine() unless the store has a separate raft engine.
+	getRaftEngine() storage.Engine
 }
 
 // replicaForTruncator abstracts the interface of Replica needed by the
@@ -551,7 +554,7 @@ func (t *raftLogTruncator) tryEnactTruncations(
 	}
 	// Do the truncation of persistent raft entries, specified by enactIndex
 	// (this subsumes all the preceding queued truncations).
-	batch := t.store.getEngine().NewUnindexedBatch(false /* writeOnly */)
+	batch := t.store.getRaftEngine().NewUnindexedBatch(false /* writeOnly */)
 	defer batch.Close()
 	apply, err := handleTruncatedStateBelowRaftPreApply(ctx, &truncState,
 		&pendingTruncs.mu.truncs[enactIndex].RaftTruncatedState, stateLoader, batch)
@@ -254,6 +254,10 @@ func (s *storeTruncatorTest) getEngine() storage.Engine {
 	return s.eng
 }
 
+func (s *storeTruncatorTest) getRaftEngine() storage.Engine {
+	return s.eng
+}
+
 func (s *storeTruncatorTest) acquireReplicaForTruncator(
 	rangeID roachpb.RangeID,
 ) replicaForTruncator {
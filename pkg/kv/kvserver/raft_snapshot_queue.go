@@ -111,7 +111,10 @@ func (rq *raftSnapshotQueue) processRaftSnapshot(
 	snapType := kvserverpb.SnapshotRequest_VIA_SNAPSHOT_QUEUE
 	skipSnapLogLimiter := log.Every(10 * time.Second)
 
-	if typ := repDesc.GetType(); typ == roachpb.LEARNER || typ == roachpb.NON_VOTER {
+	// NB: Witnesses are voters, but like learners and non-voters they are
+	// sent an INITIAL snapshot by the replica adding them.
+	if typ := repDesc.GetType(); typ == roachpb.LEARNER || typ == roachpb.NON_VOTER ||
+		typ == roachpb.WITNESS {
 		if fn := repl.store.cfg.TestingKnobs.RaftSnapshotQueueSkipReplica; fn != nil && fn() {
 			return nil
 		}
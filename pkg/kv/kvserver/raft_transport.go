@@ -104,6 +104,14 @@ type SnapshotResponseStream interface {
 	Recv() (*kvserverpb.SnapshotRequest, error)
 }
 
+// DelegateSnapshotResponseStream is the subset of the
+// MultiRaft_DelegateRaftSnapshotServer interface that is needed for sending
+// responses.
+type DelegateSnapshotResponseStream interface {
+	Send(*kvserverpb.DelegateSnapshotResponse) error
+	Recv() (*kvserverpb.DelegateSnapshotRequest, error)
+}
+
 // RaftMessageHandler is the interface that must be implemented by
 // arguments to RaftTransport.Listen.
 type RaftMessageHandler interface {
@@ -122,6 +130,11 @@ type RaftMessageHandler interface {
 	// HandleSnapshot is called for each new incoming snapshot stream, after
 	// parsing the initial SnapshotRequest_Header on the stream.
 	HandleSnapshot(ctx context.Context, header *kvserverpb.SnapshotRequest_Header, respStream SnapshotResponseStream) error
+
+	// HandleDelegatedSnapshot is called for each incoming request to send a
+	// snapshot on behalf of another replica. The outcome is sent back over
+	// respStream once the snapshot has been sent.
+	HandleDelegatedSnapshot(ctx context.Context, req *kvserverpb.DelegateSnapshotRequest, respStream DelegateSnapshotResponseStream) error
 }
 
 type raftTransportStats struct {
@@ -447,6 +460,42 @@ func (t *RaftTransport) RaftSnapshot(stream MultiRaft_RaftSnapshotServer) error
 	}
 }
 
+// DelegateRaftSnapshot handles incoming requests to send a snapshot on behalf
+// of another replica.
+func (t *RaftTransport) DelegateRaftSnapshot(stream MultiRaft_DelegateRaftSnapshotServer) error {
+	errCh := make(chan error, 1)
+	taskCtx, cancel := t.stopper.WithCancelOnQuiesce(stream.Context())
+	defer cancel()
+	if err := t.stopper.RunAsyncTaskEx(
+		taskCtx,
+		stop.TaskOpts{
+			TaskName: "storage.RaftTransport: processing delegated snapshot",
+			SpanOpt:  stop.ChildSpan,
+		}, func(ctx context.Context) {
+			errCh <- func() error {
+				req, err := stream.Recv()
+				if err != nil {
+					return err
+				}
+				handler, ok := t.getHandler(req.DelegatedSender.StoreID)
+				if !ok {
+					log.Warningf(ctx, "unable to accept delegated snapshot request from %+v: no handler registered for %+v",
+						req.CoordinatorReplica, req.DelegatedSender)
+					return roachpb.NewStoreNotFoundError(req.DelegatedSender.StoreID)
+				}
+				return handler.HandleDelegatedSnapshot(ctx, req, stream)
+			}()
+		}); err != nil {
+		return err
+	}
+	select {
+	case <-t.stopper.ShouldQuiesce():
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
 // Listen registers a raftMessageHandler to receive proxied messages.
 func (t *RaftTransport) Listen(storeID roachpb.StoreID, handler RaftMessageHandler) {
 	t.handlers.Store(int64(storeID), unsafe.Pointer(&handler))
@@ -709,3 +758,44 @@ func (t *RaftTransport) SendSnapshot(
 		ctx, t.st, stream, storePool, header, snap, newBatch, sent,
 	)
 }
+
+// DelegateSnapshot asks the delegated sender named in the request to send a
+// snapshot to the recipient, and waits for it to do so. An error is returned
+// if the delegated sender could not be reached or failed to send the
+// snapshot.
+func (t *RaftTransport) DelegateSnapshot(
+	ctx context.Context, req *kvserverpb.DelegateSnapshotRequest,
+) error {
+	nodeID := req.DelegatedSender.NodeID
+	conn, err := t.dialer.Dial(ctx, nodeID, rpc.DefaultClass)
+	if err != nil {
+		return err
+	}
+	client := NewMultiRaftClient(conn)
+	stream, err := client.DelegateRaftSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stream.CloseSend(); err != nil {
+			log.Warningf(ctx, "failed to close delegated snapshot stream: %+v", err)
+		}
+	}()
+
+	if err := stream.Send(req); err != nil {
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	switch resp.Status {
+	case kvserverpb.SnapshotResponse_APPLIED:
+		return nil
+	case kvserverpb.SnapshotResponse_ERROR:
+		return errors.Errorf("%s: delegated snapshot failed: %s", req.DelegatedSender, resp.Message)
+	default:
+		return errors.Errorf("%s: unexpected response to delegated snapshot request: %s",
+			req.DelegatedSender, resp)
+	}
+}
@@ -25,7 +25,10 @@ import (
 // A CatchUpIterator is an iterator for catchUp-scans.
 type CatchUpIterator struct {
 	storage.SimpleMVCCIterator
-	close func()
+	// reader is used to read MVCC range tombstones, which are not surfaced by
+	// the iterator. If nil, no range tombstones are read.
+	reader storage.Reader
+	close  func()
 }
 
 // NewCatchUpIterator returns a CatchUpIterator for the given Reader.
@@ -35,7 +38,8 @@ func NewCatchUpIterator(
 	reader storage.Reader, args *roachpb.RangeFeedRequest, useTBI bool, closer func(),
 ) *CatchUpIterator {
 	ret := &CatchUpIterator{
-		close: closer,
+		reader: reader,
+		close:  closer,
 	}
 	// TODO(ssd): The withDiff option requires us to iterate over
 	// values arbitrarily in the past so that we can populate the
@@ -88,12 +92,39 @@ type outputEventFn func(e *roachpb.RangeFeedEvent) error
 // CatchUpScan iterates over all changes for the given span of keys,
 // starting at catchUpTimestamp. Keys and Values are emitted as
 // RangeFeedEvents passed to the given outputFn.
+//
+// MVCC range tombstones are emitted as RangeFeedDeleteRange events before any
+// point key events. Events are only ordered by timestamp for each individual
+// point key, so consumers must use the event timestamps to determine whether a
+// range deletion applies to a point key value emitted afterwards.
 func (i *CatchUpIterator) CatchUpScan(
 	startKey, endKey storage.MVCCKey,
 	catchUpTimestamp hlc.Timestamp,
 	withDiff bool,
 	outputFn outputEventFn,
 ) error {
+	var rangeTombstones *storage.MVCCRangeTombstones
+	if i.reader != nil {
+		var err error
+		rangeTombstones, err = storage.ReadMVCCRangeTombstones(i.reader, startKey.Key, endKey.Key)
+		if err != nil {
+			return err
+		}
+	}
+	for _, rk := range rangeTombstones.RangeKeys() {
+		if rk.Timestamp.LessEq(catchUpTimestamp) {
+			continue
+		}
+		var event roachpb.RangeFeedEvent
+		event.MustSetValue(&roachpb.RangeFeedDeleteRange{
+			Span:      rk.Span(),
+			Timestamp: rk.Timestamp,
+		})
+		if err := outputFn(&event); err != nil {
+			return err
+		}
+	}
+
 	var a bufalloc.ByteAllocator
 	// MVCCIterator will encounter historical values for each key in
 	// reverse-chronological order. To output in chronological order, store
@@ -212,8 +243,18 @@ func (i *CatchUpIterator) CatchUpScan(
 			a, val = a.Copy(unsafeVal, 0)
 			if withDiff {
 				// Update the last version with its
-				// previous value (this version).
-				addPrevToLastEvent(val)
+				// previous value (this version). If this
+				// version was deleted by a range tombstone
+				// before the last version was written, the
+				// previous value is a deletion.
+				prevVal := val
+				if l := len(reorderBuf); l > 0 {
+					deletedAt, ok := rangeTombstones.DeletedAt(key, ts)
+					if ok && deletedAt.Less(reorderBuf[l-1].Val.Value.Timestamp) {
+						prevVal = nil
+					}
+				}
+				addPrevToLastEvent(prevVal)
 			}
 
 			if !ignore {
@@ -0,0 +1,94 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rangefeed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCatchUpScanRangeTombstones verifies that catch-up scans emit MVCC range
+// tombstones above the catch-up timestamp as RangeFeedDeleteRange events, and
+// that previous values deleted by range tombstones are emitted as deletions.
+func TestCatchUpScanRangeTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	eng := storage.NewDefaultInMemForTesting()
+	defer eng.Close()
+	require.NoError(t, eng.SetMinVersion(
+		clusterversion.ByKey(clusterversion.EnsurePebbleFormatVersionRangeKeys)))
+
+	keyA, keyB, keyC := roachpb.Key("a"), roachpb.Key("b"), roachpb.Key("c")
+	ts1, ts2, ts3 := hlc.Timestamp{WallTime: 1}, hlc.Timestamp{WallTime: 2}, hlc.Timestamp{WallTime: 3}
+	put := func(key roachpb.Key, ts hlc.Timestamp, val string) {
+		require.NoError(t, storage.MVCCPut(ctx, eng, nil, key, ts, roachpb.MakeValueFromString(val), nil))
+	}
+	put(keyA, ts1, "a1")
+	put(keyB, ts1, "b1")
+	require.NoError(t, storage.MVCCDeleteRangeUsingTombstone(ctx, eng, nil, keyA, keyC, ts2, 0))
+	put(keyA, ts3, "a3")
+
+	catchUpScan := func(t *testing.T, catchUpTS hlc.Timestamp) []*roachpb.RangeFeedEvent {
+		span := roachpb.Span{Key: keyA, EndKey: roachpb.Key("z")}
+		iter := NewCatchUpIterator(eng, &roachpb.RangeFeedRequest{
+			Header:   roachpb.Header{Timestamp: catchUpTS},
+			Span:     span,
+			WithDiff: true,
+		}, false /* useTBI */, nil /* closer */)
+		defer iter.Close()
+		var events []*roachpb.RangeFeedEvent
+		require.NoError(t, iter.CatchUpScan(
+			storage.MakeMVCCMetadataKey(span.Key), storage.MakeMVCCMetadataKey(span.EndKey),
+			catchUpTS, true /* withDiff */, func(e *roachpb.RangeFeedEvent) error {
+				events = append(events, e)
+				return nil
+			}))
+		return events
+	}
+
+	t.Run("below", func(t *testing.T) {
+		events := catchUpScan(t, ts1)
+		require.Len(t, events, 2)
+
+		delRange := events[0].DeleteRange
+		require.NotNil(t, delRange)
+		require.Equal(t, roachpb.Span{Key: keyA, EndKey: keyC}, delRange.Span)
+		require.Equal(t, ts2, delRange.Timestamp)
+
+		// The previous value of a@3 is a@1, but it was deleted by the range
+		// tombstone at ts2.
+		val := events[1].Val
+		require.NotNil(t, val)
+		require.Equal(t, keyA, val.Key)
+		require.Equal(t, ts3, val.Value.Timestamp)
+		valBytes, err := val.Value.GetBytes()
+		require.NoError(t, err)
+		require.Equal(t, "a3", string(valBytes))
+		require.False(t, val.PrevValue.IsPresent())
+	})
+
+	t.Run("above", func(t *testing.T) {
+		events := catchUpScan(t, ts2)
+		require.Len(t, events, 1)
+		require.Nil(t, events[0].DeleteRange)
+		require.Equal(t, keyA, events[0].Val.Key)
+	})
+}
@@ -715,6 +715,10 @@ func (p *Processor) consumeLogicalOps(
 		case *enginepb.MVCCAbortTxnOp:
 			// No updates to publish.
 
+		case *enginepb.MVCCDeleteRangeOp:
+			// Publish the range deletion directly.
+			p.publishDeleteRange(ctx, t.StartKey, t.EndKey, t.Timestamp, allocation)
+
 		default:
 			panic(errors.AssertionFailedf("unknown logical op %T", t))
 		}
@@ -798,6 +802,26 @@ func (p *Processor) publishSSTable(
 	}, allocation)
 }
 
+func (p *Processor) publishDeleteRange(
+	ctx context.Context,
+	startKey, endKey roachpb.Key,
+	timestamp hlc.Timestamp,
+	allocation *SharedBudgetAllocation,
+) {
+	if !p.Span.ContainsKeyRange(roachpb.RKey(startKey), roachpb.RKey(endKey)) {
+		log.Fatalf(ctx, "span [%s,%s) not in Processor's key range %v", startKey, endKey, p.Span)
+	}
+
+	span := roachpb.Span{Key: startKey, EndKey: endKey}
+
+	var event roachpb.RangeFeedEvent
+	event.MustSetValue(&roachpb.RangeFeedDeleteRange{
+		Span:      span,
+		Timestamp: timestamp,
+	})
+	p.reg.PublishToOverlapping(ctx, span, &event, allocation)
+}
+
 func (p *Processor) publishCheckpoint(ctx context.Context) {
 	// TODO(nvanbenschoten): persist resolvedTimestamp. Give Processor a client.DB.
 	// TODO(nvanbenschoten): rate limit these? send them periodically?
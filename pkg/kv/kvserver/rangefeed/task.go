@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -121,10 +122,16 @@ func (s *SeparatedIntentScanner) ConsumeIntents(
 		if err != nil {
 			return err
 		}
-		lockedKey, err := keys.DecodeLockTableSingleKey(engineKey.Key)
+		ltKey, err := engineKey.ToLockTableKey()
 		if err != nil {
-			return errors.Wrapf(err, "decoding LockTable key: %s", lockedKey)
+			return errors.Wrapf(err, "decoding LockTable key: %s", engineKey)
 		}
+		if ltKey.Strength != lock.Intent {
+			// Replicated locks that are not intents have no provisional value,
+			// so they do not hold back the resolved timestamp.
+			continue
+		}
+		lockedKey := ltKey.Key
 
 		if err := protoutil.Unmarshal(s.iter.UnsafeValue(), &meta); err != nil {
 			return errors.Wrapf(err, "unmarshaling mvcc meta for locked key %s", lockedKey)
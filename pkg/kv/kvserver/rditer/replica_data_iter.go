@@ -129,6 +129,24 @@ func MakeReplicatedKeyRangesExceptRangeID(d *roachpb.RangeDescriptor) []KeyRange
 	return ranges
 }
 
+// MakeWitnessKeyRanges returns the key ranges that are replicated to a
+// WITNESS replica of the given Range, i.e. the range's metadata but none of
+// its data. These are returned in the following sorted order, which is
+// compatible with the order of MakeReplicatedKeyRanges:
+//
+// 1. Replicated range-id local key range
+// 2. Range descriptor key
+// 3. Lock-table key range of the range descriptor key
+func MakeWitnessKeyRanges(d *roachpb.RangeDescriptor) []KeyRange {
+	descKey := keys.RangeDescriptorKey(d.StartKey)
+	descLockKey, _ := keys.LockTableSingleKey(descKey, nil)
+	return []KeyRange{
+		MakeRangeIDLocalKeyRange(d.RangeID, true /* replicatedOnly */),
+		{Start: descKey, End: descKey.PrefixEnd()},
+		{Start: descLockKey, End: descLockKey.PrefixEnd()},
+	}
+}
+
 // MakeRangeIDLocalKeyRange returns the range-id local key range. If
 // replicatedOnly is true, then it returns only the replicated keys, otherwise,
 // it only returns both the replicated and unreplicated keys.
@@ -337,14 +355,28 @@ func (ri *ReplicaMVCCDataIterator) UnsafeValue() []byte {
 func NewReplicaEngineDataIterator(
 	d *roachpb.RangeDescriptor, reader storage.Reader, replicatedOnly bool,
 ) *ReplicaEngineDataIterator {
-	it := reader.NewEngineIterator(storage.IterOptions{UpperBound: d.EndKey.AsRawKey()})
-
 	rangeFunc := MakeAllKeyRanges
 	if replicatedOnly {
 		rangeFunc = MakeReplicatedKeyRanges
 	}
+	return newReplicaEngineDataIterator(d, reader, rangeFunc(d))
+}
+
+// NewWitnessEngineDataIterator creates a ReplicaEngineDataIterator over the
+// key ranges that a WITNESS replica of the given range holds. See
+// MakeWitnessKeyRanges.
+func NewWitnessEngineDataIterator(
+	d *roachpb.RangeDescriptor, reader storage.Reader,
+) *ReplicaEngineDataIterator {
+	return newReplicaEngineDataIterator(d, reader, MakeWitnessKeyRanges(d))
+}
+
+func newReplicaEngineDataIterator(
+	d *roachpb.RangeDescriptor, reader storage.Reader, ranges []KeyRange,
+) *ReplicaEngineDataIterator {
+	it := reader.NewEngineIterator(storage.IterOptions{UpperBound: d.EndKey.AsRawKey()})
 	ri := &ReplicaEngineDataIterator{
-		ranges: rangeFunc(d),
+		ranges: ranges,
 		it:     it,
 	}
 	ri.seekStart()
@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -334,3 +335,69 @@ func TestReplicaKeyRanges(t *testing.T) {
 	checkOrdering(t, MakeReplicatedKeyRangesExceptLockTable(&desc))
 	checkOrdering(t, MakeReplicatedKeyRangesExceptRangeID(&desc))
 }
+
+func TestWitnessEngineDataIterator(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	eng := storage.NewDefaultInMemForTesting()
+	defer eng.Close()
+
+	descs := []roachpb.RangeDescriptor{
+		{RangeID: 1, StartKey: roachpb.RKeyMin, EndKey: roachpb.RKey("b")},
+		{RangeID: 2, StartKey: roachpb.RKey("b"), EndKey: roachpb.RKey("c")},
+		{RangeID: 3, StartKey: roachpb.RKey("c"), EndKey: roachpb.RKeyMax},
+	}
+	replicatedKeys := make([][]storage.MVCCKey, len(descs))
+	for i := range descs {
+		_, replicatedKeys[i] = createRangeData(t, eng, descs[i])
+	}
+
+	for i := range descs {
+		desc := &descs[i]
+		t.Run(fmt.Sprintf("r%d", desc.RangeID), func(t *testing.T) {
+			ranges := MakeWitnessKeyRanges(desc)
+			checkOrdering(t, ranges)
+
+			// Only the range-ID local keys and the range descriptor are expected.
+			rangeIDPrefix := keys.MakeRangeIDReplicatedPrefix(desc.RangeID)
+			descKey := keys.RangeDescriptorKey(desc.StartKey)
+			var expected []storage.MVCCKey
+			for _, key := range replicatedKeys[i] {
+				if bytes.HasPrefix(key.Key, rangeIDPrefix) || key.Key.Equal(descKey) {
+					expected = append(expected, key)
+				}
+			}
+			sort.Slice(expected, func(i, j int) bool { return expected[i].Less(expected[j]) })
+
+			it := NewWitnessEngineDataIterator(desc, eng)
+			defer it.Close()
+			var actual []storage.MVCCKey
+			for {
+				ok, err := it.Valid()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !ok {
+					break
+				}
+				key, err := it.UnsafeKey().ToMVCCKey()
+				if err != nil {
+					t.Fatal(err)
+				}
+				actual = append(actual, storage.MVCCKey{
+					Key:       append(roachpb.Key(nil), key.Key...),
+					Timestamp: key.Timestamp,
+				})
+				it.Next()
+			}
+			if len(actual) != len(expected) {
+				t.Fatalf("expected %d keys, found %d:\n%v\n%v", len(expected), len(actual), expected, actual)
+			}
+			for j := range expected {
+				if !expected[j].Equal(actual[j]) {
+					t.Fatalf("key %d: expected %s, found %s", j, expected[j], actual[j])
+				}
+			}
+		})
+	}
+}
@@ -26,12 +26,26 @@ func ComputeStatsForRange(
 	var err error
 	for _, keyRange := range MakeReplicatedKeyRangesExceptLockTable(d) {
 		func() {
+			var rangeTombstones *storage.MVCCRangeTombstones
+			rangeTombstones, err = storage.ReadMVCCRangeTombstones(reader, keyRange.Start, keyRange.End)
+			if err != nil {
+				return
+			}
 			iter := reader.NewMVCCIterator(storage.MVCCKeyAndIntentsIterKind,
 				storage.IterOptions{UpperBound: keyRange.End})
 			defer iter.Close()
 
 			var msDelta enginepb.MVCCStats
-			if msDelta, err = iter.ComputeStats(keyRange.Start, keyRange.End, nowNanos); err != nil {
+			if msDelta, err = storage.ComputeStatsForRangeWithRangeTombstones(
+				iter, rangeTombstones, keyRange.Start, keyRange.End, nowNanos,
+			); err != nil {
+				return
+			}
+			ms.Add(msDelta)
+
+			if msDelta, err = storage.ComputeReplicatedLockStatsForRange(
+				reader, keyRange.Start, keyRange.End,
+			); err != nil {
 				return
 			}
 			ms.Add(msDelta)
@@ -244,6 +244,15 @@ type Replica struct {
 	//
 	// [1]: https://github.com/cockroachdb/cockroach/pull/16664
 	writeStats *replicaStats
+	// requestCPUStats tracks the CPU time, in nanoseconds, spent evaluating
+	// requests on this replica. Since requests are (for the most part) evaluated
+	// on the leaseholder, this is the portion of the replica's CPU usage that
+	// follows the lease.
+	requestCPUStats *replicaStats
+	// raftCPUStats tracks the CPU time, in nanoseconds, spent applying
+	// committed Raft entries to this replica's state machine. Every replica of
+	// the range incurs this cost, regardless of where the lease is.
+	raftCPUStats *replicaStats
 
 	// creatingReplica is set when a replica is created as uninitialized
 	// via a raft message.
@@ -683,6 +692,14 @@ type Replica struct {
 
 	// loadBasedSplitter keeps information about load-based splitting.
 	loadBasedSplitter split.Decider
+	// loadBasedSplitterObjective is the LBRebalancingObjective that
+	// loadBasedSplitter is currently recording measurements for. Accessed
+	// atomically.
+	loadBasedSplitterObjective int64
+	// measuringCPU is 1 if the CPU time spent on this replica was being
+	// measured as of the last call to measureCPU, and 0 otherwise. Accessed
+	// atomically.
+	measuringCPU int32
 
 	unreachablesMu struct {
 		syncutil.Mutex
@@ -1286,7 +1303,13 @@ func (r *Replica) State(ctx context.Context) kvserverpb.RangeInfo {
 func (r *Replica) assertStateRaftMuLockedReplicaMuRLocked(
 	ctx context.Context, reader storage.Reader,
 ) {
-	diskState, err := r.mu.stateLoader.Load(ctx, reader, r.mu.state.Desc)
+	raftReader := reader
+	if r.store.separateRaftEngine() {
+		// The TruncatedState is only updated under raftMu, so reading it from
+		// the Raft engine is consistent with reader.
+		raftReader = r.store.raftEngine
+	}
+	diskState, err := loadReplicaState(ctx, r.mu.stateLoader, reader, raftReader, r.mu.state.Desc)
 	if err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
@@ -427,6 +427,10 @@ type replicaAppBatch struct {
 	// changeRemovesReplica tracks whether the command in the batch (there must
 	// be only one) removes this replica from the range.
 	changeRemovesReplica bool
+	// syncForRaftEngine tracks whether the command in the batch is a split or
+	// merge, which with a separate Raft engine requires the batch to be durable
+	// before the Raft state of the RHS is written or removed.
+	syncForRaftEngine bool
 
 	// Statistics.
 	entries      int
@@ -578,12 +582,27 @@ func (b *replicaAppBatch) stageWriteBatch(ctx context.Context, cmd *replicatedCm
 	} else {
 		b.mutations += mutations
 	}
+	if b.isWitness() {
+		// Witnesses only retain the range's metadata, so we filter out the rest
+		// of the mutations instead of applying the batch wholesale.
+		if err := applyWitnessWriteBatch(b.batch, wb.Data); err != nil {
+			return wrapWithNonDeterministicFailure(err, "unable to apply WriteBatch on witness")
+		}
+		return nil
+	}
 	if err := b.batch.ApplyBatchRepr(wb.Data, false); err != nil {
 		return wrapWithNonDeterministicFailure(err, "unable to apply WriteBatch")
 	}
 	return nil
 }
 
+// isWitness returns whether the replica the batch is being applied to is a
+// WITNESS, according to the range descriptor in the batch's state.
+func (b *replicaAppBatch) isWitness() bool {
+	repDesc, ok := b.state.Desc.GetReplicaDescriptor(b.r.StoreID())
+	return ok && repDesc.GetType() == roachpb.WITNESS
+}
+
 // changeRemovesStore returns true if any of the removals in this change have storeID.
 func changeRemovesStore(
 	desc *roachpb.RangeDescriptor, change *kvserverpb.ChangeReplicas, storeID roachpb.StoreID,
@@ -639,6 +658,11 @@ func (b *replicaAppBatch) runPreApplyTriggersAfterStagingWriteBatch(
 	// NB: any command which has an AddSSTable is non-trivial and will be
 	// applied in its own batch so it's not possible that any other commands
 	// which precede this command can shadow writes from this SSTable.
+	//
+	// Witnesses don't hold the range's data, so they skip the ingestion.
+	if res.AddSSTable != nil && b.isWitness() {
+		res.AddSSTable = nil
+	}
 	if res.AddSSTable != nil {
 		copied := addSSTablePreApply(
 			ctx,
@@ -675,6 +699,7 @@ func (b *replicaAppBatch) runPreApplyTriggersAfterStagingWriteBatch(
 		// Alternatively if we discover that the RHS has already been removed
 		// from this store, clean up its data.
 		splitPreApply(ctx, b.r, b.batch, res.Split.SplitTrigger, cmd.raftCmd.ClosedTimestamp)
+		b.syncForRaftEngine = b.r.store.separateRaftEngine()
 
 		// The rangefeed processor will no longer be provided logical ops for
 		// its entire range, so it needs to be shut down and all registrations
@@ -723,6 +748,7 @@ func (b *replicaAppBatch) runPreApplyTriggersAfterStagingWriteBatch(
 		); err != nil {
 			return wrapWithNonDeterministicFailure(err, "unable to destroy replica before merge")
 		}
+		b.syncForRaftEngine = b.r.store.separateRaftEngine()
 
 		// Shut down rangefeed processors on either side of the merge.
 		//
@@ -755,7 +781,12 @@ func (b *replicaAppBatch) runPreApplyTriggersAfterStagingWriteBatch(
 		// only for deciding how to truncate the raft log, which is not part of
 		// the state machine. Also, we will eventually eliminate this check by
 		// only supporting loosely coupled truncation.
-		looselyCoupledTruncation := isLooselyCoupledRaftLogTruncationEnabled(ctx, b.r.ClusterSettings())
+		//
+		// A separate Raft engine cannot be truncated atomically with this batch,
+		// so truncations are always loosely coupled in that case.
+		separateRaftEngine := b.r.store.separateRaftEngine()
+		looselyCoupledTruncation := separateRaftEngine ||
+			isLooselyCoupledRaftLogTruncationEnabled(ctx, b.r.ClusterSettings())
 		// In addition to cluster version and cluster settings, we also apply
 		// immediately if RaftExpectedFirstIndex is not populated (see comment in
 		// that proto).
@@ -767,7 +798,8 @@ func (b *replicaAppBatch) runPreApplyTriggersAfterStagingWriteBatch(
 		// it, the loosely coupled code will mark the log size as untrusted and
 		// will recompute the size. This has no correctness impact, so we are not
 		// going to bother with a long-running migration.
-		apply := !looselyCoupledTruncation || res.RaftExpectedFirstIndex == 0
+		apply := !looselyCoupledTruncation ||
+			(res.RaftExpectedFirstIndex == 0 && !separateRaftEngine)
 		if apply {
 			if apply, err = handleTruncatedStateBelowRaftPreApply(
 				ctx, b.state.TruncatedState, res.State.TruncatedState, b.r.raftMu.stateLoader, b.batch,
@@ -929,8 +961,9 @@ func (b *replicaAppBatch) ApplyToStateMachine(ctx context.Context) error {
 	// applied again upon startup. However, if we're removing the replica's data
 	// then we sync this batch as it is not safe to call postDestroyRaftMuLocked
 	// before ensuring that the replica's data has been synchronously removed.
-	// See handleChangeReplicasResult().
-	sync := b.changeRemovesReplica
+	// See handleChangeReplicasResult(). The same holds for splits and merges
+	// with a separate Raft engine, see syncForRaftEngine.
+	sync := b.changeRemovesReplica || b.syncForRaftEngine
 	if err := b.batch.Commit(sync); err != nil {
 		return wrapWithNonDeterministicFailure(err, "unable to commit Raft entry batch")
 	}
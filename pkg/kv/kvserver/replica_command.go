@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"time"
@@ -639,7 +640,8 @@ func (r *Replica) AdminMerge(
 		}
 		// For simplicity, don't handle learner replicas or joint states, expect
 		// the caller to resolve them first. (Defensively, we check that there
-		// are no non-voter replicas, in case some third type is later added).
+		// are only full voters, non-voters and witnesses, in case some other type
+		// is later added).
 		// This behavior can be changed later if the complexity becomes worth
 		// it, but it's not right now.
 		//
@@ -650,11 +652,11 @@ func (r *Replica) AdminMerge(
 		// queues should fix things up quickly).
 		lReplicas, rReplicas := origLeftDesc.Replicas(), rightDesc.Replicas()
 
-		if len(lReplicas.VoterFullAndNonVoterDescriptors()) != len(lReplicas.Descriptors()) {
+		if !mergeableReplicaTypes(lReplicas) {
 			return errors.Errorf("cannot merge ranges when lhs is in a joint state or has learners: %s",
 				lReplicas)
 		}
-		if len(rReplicas.VoterFullAndNonVoterDescriptors()) != len(rReplicas.Descriptors()) {
+		if !mergeableReplicaTypes(rReplicas) {
 			return errors.Errorf("cannot merge ranges when rhs is in a joint state or has learners: %s",
 				rReplicas)
 		}
@@ -1029,12 +1031,16 @@ func (r *Replica) changeReplicasImpl(
 	// 3. Voter removals
 	// 4. Non-voter additions
 	// 5. Non-voter removals
+	// 6. Witness additions
+	// 7. Witness removals
 	//
 	// This order is meant to be symmetric with how the allocator prioritizes
 	// these actions. Broadly speaking, we first want to add a missing voter (and
 	// promoting an existing non-voter, or swapping with one, is the fastest way
 	// to do that). Then, we consider rebalancing/removing voters. Finally, we
-	// handle non-voter additions & removals.
+	// handle non-voter additions & removals. Witness changes are never combined
+	// with any other change (see validateWitnessChanges), so their position in
+	// this order is immaterial.
 
 	// We perform promotions of non-voting replicas to voting replicas, and
 	// likewise, demotions of voting replicas to non-voting replicas. If both
@@ -1155,6 +1161,43 @@ func (r *Replica) changeReplicasImpl(
 		}
 	}
 
+	if len(targets.witnessAdditions)+len(targets.witnessRemovals) > 0 &&
+		!r.store.ClusterSettings().Version.IsActive(ctx, clusterversion.WitnessReplicas) {
+		return nil, errors.Mark(
+			errors.Newf("witness replicas require cluster version %s", clusterversion.WitnessReplicas),
+			errMarkInvalidReplicationChange)
+	}
+
+	if adds := targets.witnessAdditions; len(adds) > 0 {
+		// Witnesses are added directly as voters through a simple configuration
+		// change and then sent a snapshot containing only the range's metadata. As
+		// with learners, they are rolled back if they can't be initialized.
+		desc, err = r.initializeRaftLearners(
+			ctx, desc, priority, reason, details, adds, roachpb.WITNESS,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if removals := targets.witnessRemovals; len(removals) > 0 {
+		for _, rem := range removals {
+			iChgs := []internalReplicationChange{{target: rem, typ: internalChangeTypeRemove}}
+			var err error
+			desc, err = execChangeReplicasTxn(ctx, desc, reason, details, iChgs,
+				changeReplicasTxnArgs{
+					db:                                   r.store.DB(),
+					liveAndDeadReplicas:                  r.store.allocator.storePool.liveAndDeadReplicas,
+					logChange:                            r.store.logChange,
+					testForceJointConfig:                 r.store.TestingKnobs().ReplicationAlwaysUseJointConfig,
+					testAllowDangerousReplicationChanges: r.store.TestingKnobs().AllowDangerousReplicationChanges,
+				})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if len(targets.voterDemotions) > 0 {
 		// If we demoted or swapped any voters with non-voters, we likely are in a
 		// joint config or have learners on the range. Let's exit the joint config
@@ -1168,6 +1211,7 @@ type targetsForReplicationChanges struct {
 	voterDemotions, nonVoterPromotions  []roachpb.ReplicationTarget
 	voterAdditions, voterRemovals       []roachpb.ReplicationTarget
 	nonVoterAdditions, nonVoterRemovals []roachpb.ReplicationTarget
+	witnessAdditions, witnessRemovals   []roachpb.ReplicationTarget
 }
 
 // synthesizeTargetsByChangeType groups replication changes in the
@@ -1194,6 +1238,10 @@ func synthesizeTargetsByChangeType(
 	result.nonVoterAdditions = subtractTargets(chgs.NonVoterAdditions(), chgs.VoterRemovals())
 	result.nonVoterRemovals = subtractTargets(chgs.NonVoterRemovals(), chgs.VoterAdditions())
 
+	// Witnesses are never promoted or demoted.
+	result.witnessAdditions = chgs.WitnessAdditions()
+	result.witnessRemovals = chgs.WitnessRemovals()
+
 	return result
 }
 
@@ -1401,6 +1449,11 @@ func validateAdditionsPerStore(
 					return errors.AssertionFailedf(
 						"trying to add a non-voter to a store that already has a %s", t)
 				}
+			case roachpb.WITNESS:
+				// Witnesses can't be promoted or demoted, so nothing can be added to a
+				// store that has one.
+				return errors.AssertionFailedf(
+					"trying to add(%+v) to a store that already has a %s", chg, t)
 			default:
 				return errors.AssertionFailedf("store(%d) being added to already contains a"+
 					" replica of an unexpected type: %s", storeID, t)
@@ -1435,6 +1488,11 @@ func validateRemovals(desc *roachpb.RangeDescriptor, chgsByStoreID changesByStor
 					return errors.AssertionFailedf("type of replica being removed (%s) does not match"+
 						" expectation for change: %+v", t, chg)
 				}
+			case roachpb.WITNESS:
+				if chg.ChangeType != roachpb.REMOVE_WITNESS {
+					return errors.AssertionFailedf("type of replica being removed (%s) does not match"+
+						" expectation for change: %+v", t, chg)
+				}
 			default:
 				return errors.AssertionFailedf("unexpected replica type for removal %+v: %s", chg, t)
 			}
@@ -1564,6 +1622,17 @@ func validateOneReplicaPerNode(desc *roachpb.RangeDescriptor, chgsByNodeID chang
 	return nil
 }
 
+// validateWitnessChanges ensures that the addition or removal of a witness is
+// the only change requested. Witnesses are added and removed through simple
+// configuration changes, which can't be combined with other changes.
+func validateWitnessChanges(chgs roachpb.ReplicationChanges) error {
+	numWitnessChgs := len(chgs.WitnessAdditions()) + len(chgs.WitnessRemovals())
+	if numWitnessChgs > 0 && len(chgs) > 1 {
+		return errors.AssertionFailedf("witness changes cannot be combined with other changes: %+v", chgs)
+	}
+	return nil
+}
+
 // validateReplicationChanges runs a series of validation checks against the
 // given range descriptor and the proposed set of replication changes on the
 // range.
@@ -1580,12 +1649,17 @@ func validateOneReplicaPerNode(desc *roachpb.RangeDescriptor, chgsByNodeID chang
 // 5. We're not removing a replica that doesn't exist.
 // 6. Additions to stores that already contain a replica are strictly the ones
 // that correspond to a voter demotion and/or a non-voter promotion
+// 7. The addition or removal of a witness is the only change.
 func validateReplicationChanges(
 	desc *roachpb.RangeDescriptor, chgs roachpb.ReplicationChanges,
 ) error {
 	chgsByStoreID := getChangesByStoreID(chgs)
 	chgsByNodeID := getChangesByNodeID(chgs)
 
+	if err := validateWitnessChanges(chgs); err != nil {
+		return err
+	}
+
 	if err := validateAdditionsPerStore(desc, chgsByStoreID); err != nil {
 		return err
 	}
@@ -1634,7 +1708,9 @@ func getChangesByNodeID(chgs roachpb.ReplicationChanges) changesByNodeID {
 
 // initializeRaftLearners adds etcd LearnerNodes (LEARNERs or NON_VOTERs in
 // Cockroach-land) to the given replication targets and synchronously sends them
-// an initial snapshot to upreplicate. Once this successfully returns, the
+// an initial snapshot to upreplicate. It is also used to add WITNESS replicas,
+// which are voters as far as etcd is concerned but are similarly rolled back
+// if they can't be initialized. Once this successfully returns, the
 // callers can assume that the learners were added and have been initialized via
 // that snapshot. Otherwise, if we get any errors trying to add or upreplicate
 // any of these learners, this function will clean up after itself by rolling all
@@ -1654,6 +1730,8 @@ func (r *Replica) initializeRaftLearners(
 		iChangeType = internalChangeTypeAddLearner
 	case roachpb.NON_VOTER:
 		iChangeType = internalChangeTypeAddNonVoter
+	case roachpb.WITNESS:
+		iChangeType = internalChangeTypeAddWitness
 	default:
 		log.Fatalf(ctx, "unexpected replicaType %s", replicaType)
 	}
@@ -1899,7 +1977,10 @@ func (r *Replica) tryRollbackRaftLearner(
 ) {
 	repDesc, ok := rangeDesc.GetReplicaDescriptor(target.StoreID)
 	isLearnerOrNonVoter := repDesc.GetType() == roachpb.LEARNER || repDesc.GetType() == roachpb.NON_VOTER
-	if !ok || !isLearnerOrNonVoter {
+	// NB: A witness is rolled back if its initial snapshot fails, much like a
+	// non-voter.
+	isWitness := repDesc.GetType() == roachpb.WITNESS
+	if !ok || !(isLearnerOrNonVoter || isWitness) {
 		// There's no learner to roll back.
 		log.Event(ctx, "learner to roll back not found; skipping")
 		return
@@ -1950,6 +2031,10 @@ const (
 	_ internalChangeType = iota + 1
 	internalChangeTypeAddLearner
 	internalChangeTypeAddNonVoter
+	// internalChangeTypeAddWitness adds a WITNESS replica. Witnesses are voters,
+	// but unlike the addition of a VOTER_FULL, this doesn't go through a LEARNER
+	// first, so it must not use joint consensus.
+	internalChangeTypeAddWitness
 	// NB: internalChangeTypePromote{Learner,Voter} are quite similar to each
 	// other. We only chose to differentiate them in order to be able to assert on
 	// the type of replica being promoted. See `prepareChangeReplicasTrigger`.
@@ -2019,6 +2104,14 @@ func prepareChangeReplicasTrigger(
 			case internalChangeTypeAddNonVoter:
 				added = append(added,
 					updatedDesc.AddReplica(chg.target.NodeID, chg.target.StoreID, roachpb.NON_VOTER))
+			case internalChangeTypeAddWitness:
+				// NB: witnesses are always added through a simple configuration
+				// change, even when testingForceJointConfig is set.
+				if len(chgs) > 1 {
+					return nil, errors.Errorf("cannot add witness %v through joint consensus", chg.target)
+				}
+				added = append(added,
+					updatedDesc.AddReplica(chg.target.NodeID, chg.target.StoreID, roachpb.WITNESS))
 			case internalChangeTypePromoteLearner:
 				typ := roachpb.VOTER_FULL
 				if useJoint {
@@ -2048,7 +2141,9 @@ func prepareChangeReplicasTrigger(
 				}
 				prevTyp := rDesc.GetType()
 				isRaftLearner := prevTyp == roachpb.LEARNER || prevTyp == roachpb.NON_VOTER
-				if !useJoint || isRaftLearner {
+				// NB: witnesses are always removed through a simple configuration
+				// change. validateWitnessChanges ensures that this is the only change.
+				if !useJoint || isRaftLearner || prevTyp == roachpb.WITNESS {
 					rDesc, _ = updatedDesc.RemoveReplica(chg.target.NodeID, chg.target.StoreID)
 				} else if prevTyp != roachpb.VOTER_FULL {
 					// NB: prevTyp is already known to be VOTER_FULL because of
@@ -2394,16 +2489,21 @@ func recordRangeEventsInLog(
 ) error {
 	for _, repDesc := range repDescs {
 		isNonVoter := repDesc.GetType() == roachpb.NON_VOTER
+		isWitness := repDesc.GetType() == roachpb.WITNESS
 		var typ roachpb.ReplicaChangeType
 		if added {
 			typ = roachpb.ADD_VOTER
 			if isNonVoter {
 				typ = roachpb.ADD_NON_VOTER
+			} else if isWitness {
+				typ = roachpb.ADD_WITNESS
 			}
 		} else {
 			typ = roachpb.REMOVE_VOTER
 			if isNonVoter {
 				typ = roachpb.REMOVE_NON_VOTER
+			} else if isWitness {
+				typ = roachpb.REMOVE_WITNESS
 			}
 		}
 		if err := logChange(
@@ -2459,6 +2559,17 @@ func recordRangeEventsInLog(
 // different thing but a similar idea). Notably, GetSnapshot does not do the
 // data iteration.
 //
+// Delegating the snapshot: The replica on which sendSnapshot is called (the
+// raft leader, which is usually also the leaseholder) coordinates the
+// snapshot, but it does not have to be the one that generates and transmits
+// it. If enabled via `kv.snapshot_delegation.enabled`, the coordinator asks an
+// up-to-date follower in a locality closer to the recipient to send the
+// snapshot in its stead, over the grpc `DelegateRaftSnapshot` method. This
+// keeps snapshots for replicas added in a remote region from crossing the WAN
+// when another replica already lives in that region. The coordinator falls
+// back to sending the snapshot itself if the delegate fails, and either way it
+// is the coordinator that reports the outcome to raft.
+//
 // Transmitting the snapshot: The transfer itself happens over the grpc
 // `RaftSnapshot` method, which is a bi-directional stream of `SnapshotRequest`s
 // and `SnapshotResponse`s. The two sides are orchestrated by the
@@ -2538,6 +2649,247 @@ func (r *Replica) sendSnapshot(
 		r.reportSnapshotStatus(ctx, recipient.ReplicaID, retErr)
 	}()
 
+	sender, err := r.GetReplicaDescriptor()
+	if err != nil {
+		return errors.Wrapf(err, "%s: change replicas failed", r)
+	}
+
+	status := r.RaftStatus()
+	if status == nil {
+		// This code path is sometimes hit during scatter for replicas that
+		// haven't woken up yet.
+		return &benignError{errors.Wrap(errMarkSnapshotError, "raft status not initialized")}
+	}
+
+	firstIndex, err := r.GetFirstIndex()
+	if err != nil {
+		err = errors.Wrapf(err, "%s: failed to determine first index", r)
+		return errors.Mark(err, errMarkSnapshotError)
+	}
+
+	req := &kvserverpb.DelegateSnapshotRequest{
+		RangeID:            r.RangeID,
+		CoordinatorReplica: sender,
+		RecipientReplica:   recipient,
+		DelegatedSender:    sender,
+		Priority:           priority,
+		Type:               snapType,
+		Term:               status.Term,
+		FirstIndex:         firstIndex,
+	}
+	// A witness doesn't hold the range's data, so it can only send snapshots
+	// to other witnesses. Snapshots to any other replica must be delegated to a
+	// replica that has the data.
+	mustDelegate := senderMustDelegateSnapshot(sender, recipient)
+	delegate := r.getSnapshotDelegate(ctx, sender, recipient, status, firstIndex)
+	if delegate.ReplicaID != sender.ReplicaID {
+		err := r.delegateSnapshot(ctx, req, delegate)
+		if err == nil {
+			return nil
+		}
+		if mustDelegate {
+			err = errors.Wrapf(err, "%s: failed to delegate snapshot from witness to %s", r, delegate)
+			return errors.Mark(err, errMarkSnapshotError)
+		}
+		log.Infof(ctx, "failed to delegate snapshot to %s, sending it from %s instead: %v",
+			delegate, sender, err)
+	} else if mustDelegate {
+		return errors.Mark(errors.Errorf(
+			"%s: no replica is able to send a snapshot to %s on behalf of witness %s",
+			r, recipient, sender), errMarkSnapshotError)
+	}
+	return r.generateAndSendSnapshot(ctx, req)
+}
+
+// senderMustDelegateSnapshot returns whether the sender can't send a snapshot
+// to the recipient itself, which is the case when the sender is a witness that
+// doesn't have the data the recipient needs.
+func senderMustDelegateSnapshot(sender, recipient roachpb.ReplicaDescriptor) bool {
+	return sender.GetType() == roachpb.WITNESS && recipient.GetType() != roachpb.WITNESS
+}
+
+type snapshotDelegationDisabledKey struct{}
+
+// withSnapshotDelegationDisabled returns a context in which snapshots are sent
+// by the replica sending them rather than delegated to another replica, see
+// AdminChangeReplicasRequest.DisableSnapshotDelegation.
+func withSnapshotDelegationDisabled(ctx context.Context) context.Context {
+	return context.WithValue(ctx, snapshotDelegationDisabledKey{}, snapshotDelegationDisabledKey{})
+}
+
+func hasSnapshotDelegationDisabled(ctx context.Context) bool {
+	return ctx.Value(snapshotDelegationDisabledKey{}) != nil
+}
+
+// getSnapshotDelegate returns the replica that should send a snapshot to the
+// recipient on behalf of the sender, which is the sender itself unless
+// snapshot delegation is enabled (and not disabled through the context) and
+// another replica is a better fit. If the sender is a witness that can't send
+// the snapshot itself, a delegate is picked regardless of whether delegation
+// is enabled, and the sender is only returned if no other replica is able to
+// send the snapshot.
+func (r *Replica) getSnapshotDelegate(
+	ctx context.Context,
+	sender, recipient roachpb.ReplicaDescriptor,
+	status *raft.Status,
+	firstIndex uint64,
+) roachpb.ReplicaDescriptor {
+	st := r.store.ClusterSettings()
+	mustDelegate := senderMustDelegateSnapshot(sender, recipient)
+	if !mustDelegate && (!SnapshotDelegationEnabled.Get(&st.SV) ||
+		!st.Version.IsActive(ctx, clusterversion.DelegatedSnapshots) ||
+		hasSnapshotDelegationDisabled(ctx)) {
+		return sender
+	}
+	storePool := r.store.allocator.storePool
+	if storePool == nil {
+		return sender
+	}
+	// NB: VoterAndNonVoterDescriptors doesn't include witnesses, so the
+	// delegate always has the range's data.
+	delegate := selectSnapshotDelegate(
+		sender, recipient, r.Desc().Replicas().VoterAndNonVoterDescriptors(), status, firstIndex,
+		!mustDelegate, /* senderEligible */
+		func(storeID roachpb.StoreID) (roachpb.Locality, bool) {
+			desc, ok := storePool.getStoreDescriptor(storeID)
+			return desc.Node.Locality, ok
+		},
+	)
+	if delegate.ReplicaID != sender.ReplicaID {
+		log.VEventf(ctx, 2, "delegating snapshot for %s to %s", recipient, delegate)
+	}
+	return delegate
+}
+
+// selectSnapshotDelegate picks the replica, among the given replicas, that is
+// closest to the recipient in terms of locality and is able to send it a
+// snapshot on behalf of the sender. The sender is returned unless another
+// replica is strictly closer to the recipient.
+//
+// A replica is only considered if the raft status of the sender (which must
+// be the raft leader) shows that it is replicating and that it has caught up
+// to at least firstIndex, so that the recipient will be able to catch up on
+// the rest of the log from the sender once it has applied the snapshot.
+//
+// If the sender isn't eligible to send the snapshot itself (i.e. it is a
+// witness), the closest eligible replica is returned even if it isn't closer to
+// the recipient than the sender, and replicas with unknown localities are
+// considered as a last resort. The sender is then only returned if there is no
+// eligible replica at all.
+func selectSnapshotDelegate(
+	sender, recipient roachpb.ReplicaDescriptor,
+	replicas []roachpb.ReplicaDescriptor,
+	status *raft.Status,
+	firstIndex uint64,
+	senderEligible bool,
+	getLocality func(roachpb.StoreID) (roachpb.Locality, bool),
+) roachpb.ReplicaDescriptor {
+	if !isRaftLeader(status) {
+		return sender
+	}
+	recipientLocality, ok := getLocality(recipient.StoreID)
+	if !ok && senderEligible {
+		return sender
+	}
+	best, bestScore := sender, math.Inf(1)
+	if senderEligible {
+		senderLocality, ok := getLocality(sender.StoreID)
+		if !ok {
+			return sender
+		}
+		bestScore = recipientLocality.DiversityScore(senderLocality)
+	}
+
+	for _, repl := range replicas {
+		if repl.ReplicaID == sender.ReplicaID || repl.ReplicaID == recipient.ReplicaID {
+			continue
+		}
+		pr, ok := status.Progress[uint64(repl.ReplicaID)]
+		if !ok || pr.State != tracker.StateReplicate || pr.Match < firstIndex {
+			continue
+		}
+		locality, ok := getLocality(repl.StoreID)
+		if !ok && senderEligible {
+			continue
+		}
+		score := roachpb.MaxDiversityScore
+		if ok {
+			score = recipientLocality.DiversityScore(locality)
+		}
+		if score < bestScore {
+			best, bestScore = repl, score
+		}
+	}
+	return best
+}
+
+// delegateSnapshot asks the given delegate to send the snapshot described by
+// the request to its recipient, and waits for it to do so.
+func (r *Replica) delegateSnapshot(
+	ctx context.Context, req *kvserverpb.DelegateSnapshotRequest, delegate roachpb.ReplicaDescriptor,
+) error {
+	// Prevent the log from being truncated past the first index while the
+	// delegate is sending the snapshot, so that the recipient can catch up
+	// from here once it has applied it. This also lets the raft snapshot queue
+	// know that a snapshot is in flight to the recipient.
+	snapUUID := uuid.MakeV4()
+	r.addSnapshotLogTruncationConstraint(ctx, snapUUID, req.FirstIndex, req.RecipientReplica.StoreID)
+	defer func() {
+		r.completeSnapshotLogTruncationConstraint(ctx, snapUUID, timeutil.Now())
+	}()
+
+	delegatedReq := *req
+	delegatedReq.DelegatedSender = delegate
+	if err := contextutil.RunWithTimeout(
+		ctx, "delegate-snapshot", sendSnapshotTimeout, func(ctx context.Context) error {
+			return r.store.cfg.Transport.DelegateSnapshot(ctx, &delegatedReq)
+		}); err != nil {
+		r.store.metrics.RangeSnapshotsDelegateFailures.Inc(1)
+		return err
+	}
+	r.store.metrics.RangeSnapshotsDelegateSuccesses.Inc(1)
+	return nil
+}
+
+// validateSnapshotDelegationRequest checks that this replica is able to send
+// the snapshot described by the request on behalf of its coordinator.
+func (r *Replica) validateSnapshotDelegationRequest(req *kvserverpb.DelegateSnapshotRequest) error {
+	if req.DelegatedSender.ReplicaID == req.CoordinatorReplica.ReplicaID {
+		// The coordinator is sending the snapshot itself.
+		return nil
+	}
+	if r.replicaID != req.DelegatedSender.ReplicaID {
+		return errors.Errorf("%s: delegated snapshot request for replica %s, but this is replica %d",
+			r, req.DelegatedSender, r.replicaID)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.mu.internalRaftGroup == nil {
+		return errors.Errorf("%s: raft group not initialized", r)
+	}
+	if term := r.raftBasicStatusRLocked().Term; term != req.Term {
+		return errors.Errorf("%s: raft term %d does not match coordinator's term %d", r, term, req.Term)
+	}
+	if applied := r.mu.state.RaftAppliedIndex; applied < req.FirstIndex {
+		return errors.Errorf("%s: applied index %d is behind coordinator's first index %d",
+			r, applied, req.FirstIndex)
+	}
+	return nil
+}
+
+// generateAndSendSnapshot generates a snapshot of the replica and sends it to
+// the recipient named in the request. The snapshot is addressed as if it came
+// from the coordinator, which is either this replica or the replica that
+// delegated the sending of the snapshot to this one.
+func (r *Replica) generateAndSendSnapshot(
+	ctx context.Context, req *kvserverpb.DelegateSnapshotRequest,
+) error {
+	if err := r.validateSnapshotDelegationRequest(req); err != nil {
+		return errors.Mark(err, errMarkSnapshotError)
+	}
+
+	recipient := req.RecipientReplica
+	snapType := req.Type
 	snap, err := r.GetSnapshot(ctx, snapType, recipient.StoreID)
 	if err != nil {
 		err = errors.Wrapf(err, "%s: failed to generate %s snapshot", r, snapType)
@@ -2557,18 +2909,6 @@ func (r *Replica) sendSnapshot(
 				"snapshot type: %s, recipient: s%d, desc: %s", snapType, recipient, snap.State.Desc)
 	}
 
-	sender, err := r.GetReplicaDescriptor()
-	if err != nil {
-		return errors.Wrapf(err, "%s: change replicas failed", r)
-	}
-
-	status := r.RaftStatus()
-	if status == nil {
-		// This code path is sometimes hit during scatter for replicas that
-		// haven't woken up yet.
-		return &benignError{errors.Wrap(errMarkSnapshotError, "raft status not initialized")}
-	}
-
 	// We avoid shipping over the past Raft log in the snapshot by changing
 	// the truncated state (we're allowed to -- it's an unreplicated key and not
 	// subject to mapping across replicas). The actual sending happens here:
@@ -2584,23 +2924,26 @@ func (r *Replica) sendSnapshot(
 	// explicitly for snapshots going out to followers.
 	snap.State.DeprecatedUsingAppliedStateKey = true
 
-	req := kvserverpb.SnapshotRequest_Header{
+	// NB: the snapshot is addressed from the coordinator, at the coordinator's
+	// term, since it is the coordinator's raft group that is waiting for it.
+	coordinator := req.CoordinatorReplica
+	header := kvserverpb.SnapshotRequest_Header{
 		State:                                snap.State,
 		DeprecatedUnreplicatedTruncatedState: true,
 		RaftMessageRequest: kvserverpb.RaftMessageRequest{
 			RangeID:     r.RangeID,
-			FromReplica: sender,
+			FromReplica: coordinator,
 			ToReplica:   recipient,
 			Message: raftpb.Message{
 				Type:     raftpb.MsgSnap,
 				To:       uint64(recipient.ReplicaID),
-				From:     uint64(sender.ReplicaID),
-				Term:     status.Term,
+				From:     uint64(coordinator.ReplicaID),
+				Term:     req.Term,
 				Snapshot: snap.RaftSnap,
 			},
 		},
 		RangeSize: r.GetMVCCStats().Total(),
-		Priority:  priority,
+		Priority:  req.Priority,
 		Strategy:  kvserverpb.SnapshotRequest_KV_BATCH,
 		Type:      snapType,
 	}
@@ -2615,7 +2958,7 @@ func (r *Replica) sendSnapshot(
 			return r.store.cfg.Transport.SendSnapshot(
 				ctx,
 				r.store.allocator.storePool,
-				req,
+				header,
 				snap,
 				newBatchFn,
 				sent,
@@ -2638,19 +2981,27 @@ func (r *Replica) sendSnapshot(
 }
 
 // replicasCollocated is used in AdminMerge to ensure that the ranges are
-// all collocate on the same set of replicas.
+// all collocate on the same set of replicas. The replicas on each store must
+// also be of the same type: a WITNESS doesn't hold the range's data, so merging
+// a range with a WITNESS on a store into a range with a data-bearing replica
+// on that store (or vice versa) would leave the merged replica with only part
+// of the data it claims to have.
 func replicasCollocated(a, b []roachpb.ReplicaDescriptor) bool {
 	if len(a) != len(b) {
 		return false
 	}
 
-	set := make(map[roachpb.StoreID]int)
+	type storeAndType struct {
+		storeID roachpb.StoreID
+		typ     roachpb.ReplicaType
+	}
+	set := make(map[storeAndType]int)
 	for _, replica := range a {
-		set[replica.StoreID]++
+		set[storeAndType{replica.StoreID, replica.GetType()}]++
 	}
 
 	for _, replica := range b {
-		set[replica.StoreID]--
+		set[storeAndType{replica.StoreID, replica.GetType()}]--
 	}
 
 	for _, value := range set {
@@ -2662,6 +3013,20 @@ func replicasCollocated(a, b []roachpb.ReplicaDescriptor) bool {
 	return true
 }
 
+// mergeableReplicaTypes returns whether all of the replicas in the set are of
+// a type that a merge can handle: VOTER_FULL, NON_VOTER or WITNESS. Learners
+// and replicas in a joint configuration have to be dealt with before merging.
+func mergeableReplicaTypes(replicas roachpb.ReplicaSet) bool {
+	for _, rDesc := range replicas.Descriptors() {
+		switch rDesc.GetType() {
+		case roachpb.VOTER_FULL, roachpb.NON_VOTER, roachpb.WITNESS:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 func checkDescsEqual(desc *roachpb.RangeDescriptor) func(*roachpb.RangeDescriptor) bool {
 	return func(desc2 *roachpb.RangeDescriptor) bool {
 		return desc.Equal(desc2)
@@ -2971,6 +3336,12 @@ func (r *Replica) relocateOne(
 	voterTargets, nonVoterTargets []roachpb.ReplicationTarget,
 	transferLeaseToFirstVoter bool,
 ) ([]roachpb.ReplicationChange, *roachpb.ReplicationTarget, error) {
+	if witnesses := desc.Replicas().WitnessDescriptors(); len(witnesses) > 0 {
+		// Witnesses aren't among the voter targets, which are the replicas that
+		// hold the range's data, so relocating the range would remove them.
+		return nil, nil, errors.Errorf(
+			"range %s has witness replicas %v, which can't be relocated", desc, witnesses)
+	}
 	if repls := desc.Replicas(); len(repls.VoterFullAndNonVoterDescriptors()) != len(repls.Descriptors()) {
 		// The caller removed all the learners and left the joint config, so there
 		// shouldn't be anything but voters and non_voters.
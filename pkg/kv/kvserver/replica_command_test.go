@@ -23,6 +23,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/tracker"
 )
 
 // Regression test for #38308. Summary: a non-nullable field was added to
@@ -600,3 +602,161 @@ func TestSynthesizeTargetsByChangeType(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectSnapshotDelegate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	mkLocality := func(region, zone string) roachpb.Locality {
+		return roachpb.Locality{Tiers: []roachpb.Tier{
+			{Key: "region", Value: region},
+			{Key: "zone", Value: zone},
+		}}
+	}
+	localities := map[roachpb.StoreID]roachpb.Locality{
+		1: mkLocality("us-east", "a"),
+		2: mkLocality("us-east", "b"),
+		3: mkLocality("eu-west", "a"),
+		4: mkLocality("eu-west", "b"),
+		5: mkLocality("eu-west", "a"),
+	}
+	getLocality := func(storeID roachpb.StoreID) (roachpb.Locality, bool) {
+		l, ok := localities[storeID]
+		return l, ok
+	}
+	mkReplica := func(id int) roachpb.ReplicaDescriptor {
+		return roachpb.ReplicaDescriptor{
+			NodeID:    roachpb.NodeID(id),
+			StoreID:   roachpb.StoreID(id),
+			ReplicaID: roachpb.ReplicaID(id),
+		}
+	}
+	sender, recipient := mkReplica(1), mkReplica(5)
+	replicas := []roachpb.ReplicaDescriptor{
+		mkReplica(1), mkReplica(2), mkReplica(3), mkReplica(4),
+	}
+	const firstIndex = 50
+	upToDate := tracker.Progress{State: tracker.StateReplicate, Match: 100}
+
+	testCases := []struct {
+		name       string
+		leader     bool
+		progress   map[uint64]tracker.Progress
+		noLocality roachpb.StoreID
+		// witness is set if the sender is a witness, which can't send the
+		// snapshot itself.
+		witness bool
+		exp     roachpb.ReplicaID
+	}{
+		{
+			name:     "follower in the recipient's zone",
+			leader:   true,
+			progress: map[uint64]tracker.Progress{2: upToDate, 3: upToDate, 4: upToDate},
+			exp:      3,
+		},
+		{
+			name:   "follower in the recipient's zone is behind",
+			leader: true,
+			progress: map[uint64]tracker.Progress{
+				2: upToDate,
+				3: {State: tracker.StateReplicate, Match: firstIndex - 1},
+				4: upToDate,
+			},
+			exp: 4,
+		},
+		{
+			name:     "follower in the recipient's zone is unknown to raft",
+			leader:   true,
+			progress: map[uint64]tracker.Progress{2: upToDate, 4: upToDate},
+			exp:      4,
+		},
+		{
+			name:   "no follower in the recipient's region is replicating",
+			leader: true,
+			progress: map[uint64]tracker.Progress{
+				2: upToDate,
+				3: {State: tracker.StateProbe, Match: 100},
+				4: {State: tracker.StateSnapshot},
+			},
+			exp: 1,
+		},
+		{
+			name:     "sender is not the raft leader",
+			leader:   false,
+			progress: map[uint64]tracker.Progress{2: upToDate, 3: upToDate, 4: upToDate},
+			exp:      1,
+		},
+		{
+			name:       "recipient's locality is unknown",
+			leader:     true,
+			progress:   map[uint64]tracker.Progress{2: upToDate, 3: upToDate, 4: upToDate},
+			noLocality: 5,
+			exp:        1,
+		},
+		{
+			name:       "follower's locality is unknown",
+			leader:     true,
+			progress:   map[uint64]tracker.Progress{2: upToDate, 3: upToDate, 4: upToDate},
+			noLocality: 3,
+			exp:        4,
+		},
+		{
+			name:   "witness delegates to a follower that isn't closer",
+			leader: true,
+			progress: map[uint64]tracker.Progress{
+				2: upToDate,
+				3: {State: tracker.StateProbe, Match: 100},
+				4: {State: tracker.StateSnapshot},
+			},
+			witness: true,
+			exp:     2,
+		},
+		{
+			name:     "witness delegates to the closest follower",
+			leader:   true,
+			progress: map[uint64]tracker.Progress{2: upToDate, 3: upToDate, 4: upToDate},
+			witness:  true,
+			exp:      3,
+		},
+		{
+			name:       "witness delegates even if the recipient's locality is unknown",
+			leader:     true,
+			progress:   map[uint64]tracker.Progress{2: upToDate, 3: upToDate, 4: upToDate},
+			noLocality: 5,
+			witness:    true,
+			exp:        2,
+		},
+		{
+			name:   "witness has no follower to delegate to",
+			leader: true,
+			progress: map[uint64]tracker.Progress{
+				2: {State: tracker.StateProbe, Match: 100},
+				3: {State: tracker.StateProbe, Match: 100},
+				4: {State: tracker.StateSnapshot},
+			},
+			witness: true,
+			exp:     1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := &raft.Status{Progress: tc.progress}
+			if tc.leader {
+				status.RaftState = raft.StateLeader
+			}
+			getLocality := getLocality
+			if tc.noLocality != 0 {
+				getLocality = func(storeID roachpb.StoreID) (roachpb.Locality, bool) {
+					if storeID == tc.noLocality {
+						return roachpb.Locality{}, false
+					}
+					return localities[storeID], true
+				}
+			}
+			delegate := selectSnapshotDelegate(
+				sender, recipient, replicas, status, firstIndex, !tc.witness /* senderEligible */, getLocality,
+			)
+			require.Equal(t, tc.exp, delegate.ReplicaID)
+		})
+	}
+}
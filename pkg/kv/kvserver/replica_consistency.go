@@ -370,8 +370,9 @@ func (r *Replica) RunConsistencyCheck(
 		}
 
 		// Move the local replica to the front (which makes it the "master"
-		// we're comparing against).
-		orderedReplicas = append(orderedReplicas, desc.Replicas().Descriptors()...)
+		// we're comparing against). Witnesses don't hold the range's data, so
+		// there's nothing to compare them against.
+		orderedReplicas = append(orderedReplicas, desc.Replicas().DataReplicaDescriptors()...)
 
 		sort.Slice(orderedReplicas, func(i, j int) bool {
 			return orderedReplicas[i] == localReplica
@@ -640,6 +641,39 @@ func (*Replica) sha512(
 		return err
 	}
 
+	// NB: range keys are not included in the debug snapshot.
+	rangeKeyVisitor := func(rangeKey storage.MVCCRangeKey) error {
+		if err := limiter.WaitN(ctx, int64(len(rangeKey.StartKey)+len(rangeKey.EndKey))); err != nil {
+			return err
+		}
+		// Encode the length of the start and end keys.
+		binary.LittleEndian.PutUint64(intBuf[:], uint64(len(rangeKey.StartKey)))
+		if _, err := hasher.Write(intBuf[:]); err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint64(intBuf[:], uint64(len(rangeKey.EndKey)))
+		if _, err := hasher.Write(intBuf[:]); err != nil {
+			return err
+		}
+		if _, err := hasher.Write(rangeKey.StartKey); err != nil {
+			return err
+		}
+		if _, err := hasher.Write(rangeKey.EndKey); err != nil {
+			return err
+		}
+		legacyTimestamp = rangeKey.Timestamp.ToLegacyTimestamp()
+		if size := legacyTimestamp.Size(); size > cap(timestampBuf) {
+			timestampBuf = make([]byte, size)
+		} else {
+			timestampBuf = timestampBuf[:size]
+		}
+		if _, err := protoutil.MarshalTo(&legacyTimestamp, timestampBuf); err != nil {
+			return err
+		}
+		_, err := hasher.Write(timestampBuf)
+		return err
+	}
+
 	var ms enginepb.MVCCStats
 	// In statsOnly mode, we hash only the RangeAppliedState. In regular mode, hash
 	// all of the replicated key space.
@@ -651,10 +685,22 @@ func (*Replica) sha512(
 		// we will probably not have any interleaved intents so we could stop
 		// using MVCCKeyAndIntentsIterKind and consider all locks here.
 		for _, span := range rditer.MakeReplicatedKeyRangesExceptLockTable(&desc) {
+			rangeTombstones, err := storage.ReadMVCCRangeTombstones(snap, span.Start, span.End)
+			if err != nil {
+				return nil, err
+			}
+			// MVCC range tombstones are hashed separately from point keys. Spans
+			// without range tombstones hash the same as before they were
+			// introduced.
+			for _, rk := range rangeTombstones.RangeKeys() {
+				if err := rangeKeyVisitor(rk); err != nil {
+					return nil, err
+				}
+			}
 			iter := snap.NewMVCCIterator(storage.MVCCKeyAndIntentsIterKind,
 				storage.IterOptions{UpperBound: span.End})
-			spanMS, err := storage.ComputeStatsForRange(
-				iter, span.Start, span.End, 0 /* nowNanos */, visitor,
+			spanMS, err := storage.ComputeStatsForRangeWithRangeTombstones(
+				iter, rangeTombstones, span.Start, span.End, 0 /* nowNanos */, visitor,
 			)
 			iter.Close()
 			if err != nil {
@@ -800,6 +846,9 @@ to replace this node; cluster data may still be at risk of corruption.
 A checkpoints directory to aid (expert) debugging should be present in:
 %s
 
+The checkpoints can be compared with 'cockroach debug repair-range diff', and
+the range rebuilt from a chosen replica with 'cockroach debug repair-range apply'.
+
 A file preventing this node from restarting was placed at:
 %s
 `
@@ -12,10 +12,15 @@ package kvserver
 
 import (
 	"bytes"
+	"context"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
 
@@ -36,7 +41,20 @@ type ReplicaSnapshotDiffSlice []ReplicaSnapshotDiff
 
 // SafeFormat implements redact.SafeFormatter.
 func (rsds ReplicaSnapshotDiffSlice) SafeFormat(buf redact.SafePrinter, _ rune) {
-	buf.Printf("--- leaseholder\n+++ follower\n")
+	rsds.format(buf, redact.SafeString("leaseholder"), redact.SafeString("follower"))
+}
+
+// FormatWithLabels is like String, but labels the two sides of the diff (the
+// entries with and without LeaseHolder set, respectively) with the given names
+// instead of as the leaseholder and the follower.
+func (rsds ReplicaSnapshotDiffSlice) FormatWithLabels(lhs, rhs string) string {
+	return redact.Sprintfn(func(w redact.SafePrinter) {
+		rsds.format(w, lhs, rhs)
+	}).StripMarkers()
+}
+
+func (rsds ReplicaSnapshotDiffSlice) format(buf redact.SafePrinter, lhs, rhs interface{}) {
+	buf.Printf("--- %s\n+++ %s\n", lhs, rhs)
 	for _, d := range rsds {
 		prefix := redact.SafeString("+")
 		if d.LeaseHolder {
@@ -138,3 +156,44 @@ func diffRange(l, r *roachpb.RaftSnapshotData) ReplicaSnapshotDiffSlice {
 	}
 	return diff
 }
+
+// DiffReplicaData diffs the kv dumps of two copies of the same range, for
+// example two consistency checkpoints. The authoritative copy takes the place
+// of the lease holder in the returned diff, which is best printed through
+// FormatWithLabels.
+func DiffReplicaData(authoritative, other *roachpb.RaftSnapshotData) ReplicaSnapshotDiffSlice {
+	return diffRange(authoritative, other)
+}
+
+// LoadReplicaData returns the descriptor of the given range as found in the
+// reader (typically a consistency checkpoint opened offline) along with a dump
+// of the replicated data the consistency checker would hash for it. It returns
+// an error if the reader has no replica of the range.
+func LoadReplicaData(
+	ctx context.Context, reader storage.Reader, rangeID roachpb.RangeID,
+) (roachpb.RangeDescriptor, *roachpb.RaftSnapshotData, error) {
+	var desc roachpb.RangeDescriptor
+	var found bool
+	if err := IterateRangeDescriptorsFromDisk(ctx, reader, func(d roachpb.RangeDescriptor) error {
+		if d.RangeID != rangeID {
+			return nil
+		}
+		desc, found = d, true
+		return iterutil.StopIteration()
+	}); err != nil {
+		return roachpb.RangeDescriptor{}, nil, err
+	}
+	if !found {
+		return roachpb.RangeDescriptor{}, nil, errors.Errorf("r%d not found", rangeID)
+	}
+
+	var snapshot roachpb.RaftSnapshotData
+	limiter := quotapool.NewRateLimiter(
+		"RepairRange", quotapool.Limit(math.MaxFloat64), math.MaxInt64)
+	if _, err := (*Replica)(nil).sha512(
+		ctx, desc, reader, &snapshot, roachpb.ChecksumMode_CHECK_FULL, limiter,
+	); err != nil {
+		return roachpb.RangeDescriptor{}, nil, err
+	}
+	return desc, &snapshot, nil
+}
@@ -12,15 +12,22 @@ package kvserver
 
 import (
 	"context"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/stretchr/testify/require"
@@ -118,3 +125,103 @@ func TestGetChecksumNotSuccessfulExitConditions(t *testing.T) {
 	}
 	require.Nil(t, rc.Checksum)
 }
+
+func TestLoadAndDiffReplicaData(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	desc := roachpb.RangeDescriptor{
+		RangeID:  7,
+		StartKey: roachpb.RKey("a"),
+		EndKey:   roachpb.RKey("z"),
+		InternalReplicas: []roachpb.ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+			{NodeID: 2, StoreID: 2, ReplicaID: 2},
+		},
+	}
+	ts := hlc.Timestamp{WallTime: 1}
+
+	// Populate two engines with the same range, diverging on a single key.
+	load := func(divergentValue string) *roachpb.RaftSnapshotData {
+		eng := storage.NewDefaultInMemForTesting()
+		defer eng.Close()
+		require.NoError(t, storage.MVCCPutProto(
+			ctx, eng, nil, keys.RangeDescriptorKey(desc.StartKey), ts, nil, &desc))
+		for k, v := range map[string]string{"b": "same", "c": divergentValue} {
+			require.NoError(t, storage.MVCCPut(
+				ctx, eng, nil, roachpb.Key(k), ts, roachpb.MakeValueFromString(v), nil))
+		}
+
+		_, _, err := LoadReplicaData(ctx, eng, desc.RangeID+1)
+		require.True(t, testutils.IsError(err, "r8 not found"), "%v", err)
+
+		loadedDesc, data, err := LoadReplicaData(ctx, eng, desc.RangeID)
+		require.NoError(t, err)
+		require.Equal(t, desc, loadedDesc)
+		return data
+	}
+	authoritative, other := load("good"), load("bad")
+
+	require.Empty(t, DiffReplicaData(authoritative, authoritative))
+	diff := DiffReplicaData(authoritative, other)
+	require.Len(t, diff, 2)
+	for i, onAuthoritative := range []bool{true, false} {
+		require.Equal(t, onAuthoritative, diff[i].LeaseHolder)
+		require.Equal(t, roachpb.Key("c"), diff[i].Key)
+		require.Equal(t, ts, diff[i].Timestamp)
+	}
+	require.True(t, strings.HasPrefix(diff.String(), "--- leaseholder\n+++ follower\n"), diff.String())
+	formatted := diff.FormatWithLabels("/mnt/n1", "/mnt/n2")
+	require.True(t, strings.HasPrefix(formatted, "--- /mnt/n1\n+++ /mnt/n2\n-"), formatted)
+}
+
+// TestReplicaChecksumRangeTombstones verifies that MVCC range tombstones are
+// included in the replica checksum, and that the point keys they delete are not
+// counted as live in the recomputed stats.
+func TestReplicaChecksumRangeTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	desc := roachpb.RangeDescriptor{
+		RangeID:  7,
+		StartKey: roachpb.RKey("a"),
+		EndKey:   roachpb.RKey("z"),
+	}
+	limiter := quotapool.NewRateLimiter(
+		"ConsistencyQueue", quotapool.Limit(math.MaxFloat64), math.MaxInt64)
+
+	// checksum computes the checksum of a replica with the point keys b@1 and
+	// c@1 and, if non-empty, a range tombstone [b,d) at the given timestamp.
+	checksum := func(rangeTombstoneTS hlc.Timestamp) *replicaHash {
+		eng := storage.NewDefaultInMemForTesting()
+		defer eng.Close()
+		require.NoError(t, eng.SetMinVersion(
+			clusterversion.ByKey(clusterversion.EnsurePebbleFormatVersionRangeKeys)))
+		for _, k := range []string{"b", "c"} {
+			require.NoError(t, storage.MVCCPut(ctx, eng, nil, roachpb.Key(k),
+				hlc.Timestamp{WallTime: 1}, roachpb.MakeValueFromString(k), nil))
+		}
+		if !rangeTombstoneTS.IsEmpty() {
+			require.NoError(t, storage.MVCCDeleteRangeUsingTombstone(
+				ctx, eng, nil, roachpb.Key("b"), roachpb.Key("d"), rangeTombstoneTS, 0))
+		}
+		res, err := (*Replica)(nil).sha512(
+			ctx, desc, eng, nil /* snapshot */, roachpb.ChecksumMode_CHECK_FULL, limiter)
+		require.NoError(t, err)
+		return res
+	}
+
+	none := checksum(hlc.Timestamp{})
+	ts2 := checksum(hlc.Timestamp{WallTime: 2})
+	ts3 := checksum(hlc.Timestamp{WallTime: 3})
+
+	require.Equal(t, ts2.SHA512, checksum(hlc.Timestamp{WallTime: 2}).SHA512)
+	require.NotEqual(t, none.SHA512, ts2.SHA512)
+	require.NotEqual(t, ts2.SHA512, ts3.SHA512)
+
+	require.EqualValues(t, 2, none.RecomputedMS.LiveCount)
+	require.EqualValues(t, 0, ts2.RecomputedMS.LiveCount)
+	require.Equal(t, none.RecomputedMS.KeyCount, ts2.RecomputedMS.KeyCount)
+}
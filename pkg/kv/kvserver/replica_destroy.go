@@ -123,6 +123,20 @@ func (r *Replica) postDestroyRaftMuLocked(ctx context.Context, ms enginepb.MVCCS
 		}
 	}
 
+	// With a separate Raft engine, the Raft state is removed only now that the
+	// removal of the state machine is durable. If this write is lost, Store.Start
+	// removes the Raft state again.
+	if r.store.separateRaftEngine() {
+		batch := r.store.raftEngine.NewUnindexedBatch(true /* writeOnly */)
+		defer batch.Close()
+		if err := clearRaftEngineState(r.RangeID, batch); err != nil {
+			return err
+		}
+		if err := batch.Commit(false /* sync */); err != nil {
+			return err
+		}
+	}
+
 	// Release the reference to this tenant in metrics, we know the tenant ID is
 	// valid if the replica is initialized.
 	if r.tenantMetricsRef != nil {
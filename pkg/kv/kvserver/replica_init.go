@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"math/rand"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -95,7 +96,7 @@ func newUnloadedReplica(
 	r.mu.quiescent = true
 	r.mu.conf = store.cfg.DefaultSpanConfig
 	split.Init(&r.loadBasedSplitter, rand.Intn, func() float64 {
-		return float64(SplitByLoadQPSThreshold.Get(&store.cfg.Settings.SV))
+		return r.SplitByLoadThreshold(LBRebalancingObjective(atomic.LoadInt64(&r.loadBasedSplitterObjective)))
 	}, func() time.Duration {
 		return kvserverbase.SplitByLoadMergeDelay.Get(&store.cfg.Settings.SV)
 	})
@@ -115,6 +116,9 @@ func newUnloadedReplica(
 	// Pass nil for the localityOracle because we intentionally don't track the
 	// origin locality of write load.
 	r.writeStats = newReplicaStats(store.Clock(), nil)
+	// Likewise for CPU usage.
+	r.requestCPUStats = newReplicaStats(store.Clock(), nil)
+	r.raftCPUStats = newReplicaStats(store.Clock(), nil)
 
 	// Init rangeStr with the range ID.
 	r.rangeStr.store(replicaID, &roachpb.RangeDescriptor{RangeID: desc.RangeID})
@@ -193,10 +197,12 @@ func (r *Replica) loadRaftMuLockedReplicaMuLocked(desc *roachpb.RangeDescriptor)
 	r.mu.internalRaftGroup = nil
 
 	var err error
-	if r.mu.state, err = r.mu.stateLoader.Load(ctx, r.Engine(), desc); err != nil {
+	if r.mu.state, err = loadReplicaState(
+		ctx, r.mu.stateLoader, r.Engine(), r.store.raftEngine, desc,
+	); err != nil {
 		return err
 	}
-	r.mu.lastIndex, err = r.mu.stateLoader.LoadLastIndex(ctx, r.Engine())
+	r.mu.lastIndex, err = r.mu.stateLoader.LoadLastIndex(ctx, r.store.raftEngine)
 	if err != nil {
 		return err
 	}
@@ -12,6 +12,7 @@ package kvserver
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
@@ -266,6 +267,55 @@ func (r *Replica) WritesPerSecond() float64 {
 	return wps
 }
 
+// RequestCPUNanosPerSecond returns the range's average CPU time, in
+// nanoseconds per second, spent evaluating requests. See
+// Replica.recordRequestCPU.
+func (r *Replica) RequestCPUNanosPerSecond() float64 {
+	cpu, _ := r.requestCPUStats.avgQPS()
+	return cpu
+}
+
+// RaftCPUNanosPerSecond returns the range's average CPU time, in nanoseconds
+// per second, spent applying committed Raft entries on this replica.
+func (r *Replica) RaftCPUNanosPerSecond() float64 {
+	cpu, _ := r.raftCPUStats.avgQPS()
+	return cpu
+}
+
+// CPUNanosPerSecond returns the range's average CPU time, in nanoseconds per
+// second, spent on this replica. It is the sum of RequestCPUNanosPerSecond and
+// RaftCPUNanosPerSecond.
+func (r *Replica) CPUNanosPerSecond() float64 {
+	return r.RequestCPUNanosPerSecond() + r.RaftCPUNanosPerSecond()
+}
+
+// measureCPU returns whether the CPU time spent evaluating requests and
+// applying Raft commands on this replica should be measured. Measuring CPU
+// time isn't free, so it is only done while load-based rebalancing and
+// splitting are using the CPU objective. Whenever measurements start or stop,
+// the CPU stats are reset, so that they neither report the time during which
+// nothing was measured as idle nor keep reporting stale measurements.
+func (r *Replica) measureCPU(ctx context.Context) bool {
+	var measuring int32
+	if ResolveLBRebalancingObjective(ctx, r.store.cfg.Settings) == LBRebalancingCPU {
+		measuring = 1
+	}
+	if prev := atomic.SwapInt32(&r.measuringCPU, measuring); prev != measuring {
+		r.requestCPUStats.resetRequestCounts()
+		r.raftCPUStats.resetRequestCounts()
+	}
+	return measuring == 1
+}
+
+// leaseLoadStats returns the stats tracking the portion of the replica's load,
+// along the dimension given by the objective, that follows the lease.
+func (r *Replica) leaseLoadStats(objective LBRebalancingObjective) *replicaStats {
+	if objective == LBRebalancingCPU {
+		return r.requestCPUStats
+	}
+	return r.leaseholderStats
+}
+
 func (r *Replica) needsSplitBySizeRLocked() bool {
 	exceeded, _ := r.exceedsMultipleOfSplitSizeRLocked(1)
 	return exceeded
@@ -11,13 +11,16 @@
 package kvserver
 
 import (
+	"context"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/grunning"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/stretchr/testify/require"
 )
 
@@ -248,3 +251,55 @@ func TestCalcRangeCounterLeaseHolder(t *testing.T) {
 		})
 	}
 }
+
+// TestReplicaMeasureCPU verifies that a replica only measures CPU time while
+// load-based rebalancing is using the CPU objective, and that its CPU stats are
+// reset whenever it starts or stops measuring.
+func TestReplicaMeasureCPU(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	tc := testContext{}
+	tc.Start(ctx, t, stopper)
+	repl := tc.repl
+	sv := &tc.store.cfg.Settings.SV
+
+	sum := func(rs *replicaStats) float64 {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+		sum, _ := rs.sumQueriesLocked()
+		return sum
+	}
+	record := func() {
+		repl.requestCPUStats.recordCount(100, 0 /* nodeID */)
+		repl.raftCPUStats.recordCount(100, 0 /* nodeID */)
+	}
+	requireCPUStats := func(expected float64) {
+		t.Helper()
+		require.Equal(t, expected, sum(repl.requestCPUStats))
+		require.Equal(t, expected, sum(repl.raftCPUStats))
+	}
+
+	require.False(t, repl.measureCPU(ctx))
+	record()
+
+	LoadBasedRebalancingObjective.Override(ctx, sv, int64(LBRebalancingCPU))
+	if !grunning.Supported() {
+		require.False(t, repl.measureCPU(ctx))
+		requireCPUStats(100)
+		return
+	}
+	require.True(t, repl.measureCPU(ctx))
+	requireCPUStats(0)
+
+	record()
+	require.True(t, repl.measureCPU(ctx))
+	requireCPUStats(100)
+
+	LoadBasedRebalancingObjective.Override(ctx, sv, int64(LBRebalancingQueries))
+	require.False(t, repl.measureCPU(ctx))
+	requireCPUStats(0)
+}
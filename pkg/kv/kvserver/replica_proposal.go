@@ -305,6 +305,7 @@ func (r *Replica) leasePostApplyLocked(
 		if r.leaseholderStats != nil {
 			r.leaseholderStats.resetRequestCounts()
 		}
+		r.requestCPUStats.resetRequestCounts()
 		r.loadBasedSplitter.Reset(r.Clock().PhysicalTime())
 	}
 
@@ -364,6 +365,7 @@ func (r *Replica) leasePostApplyLocked(
 		if r.leaseholderStats != nil {
 			r.leaseholderStats.resetRequestCounts()
 		}
+		r.requestCPUStats.resetRequestCounts()
 	}
 
 	// Potentially re-gossip if the range contains system data (e.g. system
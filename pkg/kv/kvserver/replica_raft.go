@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/grunning"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -801,7 +802,7 @@ func (r *Replica) handleRaftReadyRaftMuLocked(
 
 	// Use a more efficient write-only batch because we don't need to do any
 	// reads from the batch. Any reads are performed on the underlying DB.
-	batch := r.store.Engine().NewUnindexedBatch(false /* writeOnly */)
+	batch := r.store.raftEngine.NewUnindexedBatch(false /* writeOnly */)
 	defer batch.Close()
 
 	prevLastIndex := lastIndex
@@ -912,7 +913,21 @@ func (r *Replica) handleRaftReadyRaftMuLocked(
 
 	applicationStart := timeutil.Now()
 	if len(rd.CommittedEntries) > 0 {
+		var m grunning.Measurement
+		measureCPU := r.measureCPU(ctx)
+		if measureCPU {
+			// Applying commands writes to the engine and may block on it, so don't
+			// tie up the OS thread by locking the goroutine to it.
+			m = grunning.StartUnlocked()
+		}
 		err := appTask.ApplyCommittedEntries(ctx)
+		if measureCPU {
+			if cpu, ok := m.Elapsed(); ok {
+				// Account the CPU time spent applying commands to the replica, for
+				// use in CPU-based rebalancing.
+				r.raftCPUStats.recordCount(float64(cpu), 0 /* nodeID */)
+			}
+		}
 		stats.applyCommittedEntriesStats = sm.moveStats()
 		if errors.Is(err, apply.ErrRemoved) {
 			// We know that our replica has been removed. All future calls to
@@ -1060,6 +1075,7 @@ func (r *Replica) tick(ctx context.Context, livenessMap liveness.IsLiveMap) (boo
 	}
 
 	r.maybeTransferRaftLeadershipToLeaseholderLocked(ctx, now)
+	r.maybeTransferRaftLeadershipOffWitnessLocked(ctx)
 
 	// For followers, we update lastUpdateTimes when we step a message from them
 	// into the local Raft group. The leader won't hit that path, so we update
@@ -2092,7 +2108,7 @@ func (r *Replica) printRaftTail(
 	end := keys.RaftLogPrefix(r.RangeID).PrefixEnd()
 
 	// NB: raft log does not have intents.
-	it := r.Engine().NewEngineIterator(storage.IterOptions{LowerBound: start, UpperBound: end})
+	it := r.store.raftEngine.NewEngineIterator(storage.IterOptions{LowerBound: start, UpperBound: end})
 	valid, err := it.SeekEngineKeyLT(storage.EngineKey{Key: end})
 	if err != nil {
 		return "", err
@@ -61,7 +61,7 @@ var _ raft.Storage = (*replicaRaftStorage)(nil)
 // InitialState requires that r.mu is held.
 func (r *replicaRaftStorage) InitialState() (raftpb.HardState, raftpb.ConfState, error) {
 	ctx := r.AnnotateCtx(context.TODO())
-	hs, err := r.mu.stateLoader.LoadHardState(ctx, r.store.Engine())
+	hs, err := r.mu.stateLoader.LoadHardState(ctx, r.store.raftEngine)
 	// For uninitialized ranges, membership is unknown at this point.
 	if raft.IsEmptyHardState(hs) || err != nil {
 		return raftpb.HardState{}, raftpb.ConfState{}, err
@@ -74,7 +74,7 @@ func (r *replicaRaftStorage) InitialState() (raftpb.HardState, raftpb.ConfState,
 // and this method will always return at least one entry even if it exceeds
 // maxBytes. Sideloaded proposals count towards maxBytes with their payloads inlined.
 func (r *replicaRaftStorage) Entries(lo, hi, maxBytes uint64) ([]raftpb.Entry, error) {
-	readonly := r.store.Engine().NewReadOnly(storage.StandardDurability)
+	readonly := r.store.raftEngine.NewReadOnly(storage.StandardDurability)
 	defer readonly.Close()
 	ctx := r.AnnotateCtx(context.TODO())
 	if r.raftMu.sideloaded == nil {
@@ -280,7 +280,7 @@ func (r *replicaRaftStorage) Term(i uint64) (uint64, error) {
 	if e, ok := r.store.raftEntryCache.Get(r.RangeID, i); ok {
 		return e.Term, nil
 	}
-	readonly := r.store.Engine().NewReadOnly(storage.StandardDurability)
+	readonly := r.store.raftEngine.NewReadOnly(storage.StandardDurability)
 	defer readonly.Close()
 	ctx := r.AnnotateCtx(context.TODO())
 	return term(ctx, r.mu.stateLoader, readonly, r.RangeID, r.store.raftEntryCache, i)
@@ -340,7 +340,7 @@ func (r *Replica) raftTruncatedStateLocked(
 	if r.mu.state.TruncatedState != nil {
 		return *r.mu.state.TruncatedState, nil
 	}
-	ts, err := r.mu.stateLoader.LoadRaftTruncatedState(ctx, r.store.Engine())
+	ts, err := r.mu.stateLoader.LoadRaftTruncatedState(ctx, r.store.raftEngine)
 	if err != nil {
 		return ts, err
 	}
@@ -425,6 +425,13 @@ func (r *Replica) GetSnapshot(
 	// the corresponding Raft command not applied yet).
 	r.raftMu.Lock()
 	snap := r.store.engine.NewSnapshot()
+	// The Raft state is only needed to generate the snapshot metadata, so a
+	// snapshot of a separate Raft engine is released when we return.
+	raftSnap := snap
+	if r.store.separateRaftEngine() {
+		raftSnap = r.store.raftEngine.NewSnapshot()
+		defer raftSnap.Close()
+	}
 	{
 		r.mu.Lock()
 		// We will fetch the applied index later again, from snap. The
@@ -483,7 +490,7 @@ func (r *Replica) GetSnapshot(
 	// create a new state loader.
 	snapData, err := snapshot(
 		ctx, snapUUID, stateloader.Make(rangeID), snapType,
-		snap, rangeID, r.store.raftEntryCache, withSideloaded, startKey,
+		snap, raftSnap, rangeID, r.store.raftEntryCache, withSideloaded, startKey, recipientStore,
 	)
 	if err != nil {
 		log.Errorf(ctx, "error generating snapshot: %+v", err)
@@ -560,17 +567,20 @@ func (s IncomingSnapshot) SafeFormat(w redact.SafePrinter, _ rune) {
 }
 
 // snapshot creates an OutgoingSnapshot containing a pebble snapshot for the
-// given range. Note that snapshot() is called without Replica.raftMu held.
+// given range. The Raft state is read from raftSnap, which is the same as snap
+// unless the store keeps it in a separate engine. Note that snapshot() is
+// called without Replica.raftMu held.
 func snapshot(
 	ctx context.Context,
 	snapUUID uuid.UUID,
 	rsl stateloader.StateLoader,
 	snapType kvserverpb.SnapshotRequest_Type,
-	snap storage.Reader,
+	snap, raftSnap storage.Reader,
 	rangeID roachpb.RangeID,
 	eCache *raftentry.Cache,
 	withSideloaded func(func(SideloadStorage) error) error,
 	startKey roachpb.RKey,
+	recipientStore roachpb.StoreID,
 ) (OutgoingSnapshot, error) {
 	var desc roachpb.RangeDescriptor
 	// We ignore intents on the range descriptor (consistent=false) because we
@@ -585,12 +595,12 @@ func snapshot(
 		return OutgoingSnapshot{}, errors.Mark(errors.Errorf("couldn't find range descriptor"), errMarkSnapshotError)
 	}
 
-	state, err := rsl.Load(ctx, snap, &desc)
+	state, err := loadReplicaState(ctx, rsl, snap, raftSnap, &desc)
 	if err != nil {
 		return OutgoingSnapshot{}, err
 	}
 
-	term, err := term(ctx, rsl, snap, rangeID, eCache, state.RaftAppliedIndex)
+	term, err := term(ctx, rsl, raftSnap, rangeID, eCache, state.RaftAppliedIndex)
 	// If we've migrated to populating RaftAppliedIndexTerm, check that the term
 	// from the two sources are equal.
 	if state.RaftAppliedIndexTerm != 0 && term != state.RaftAppliedIndexTerm {
@@ -602,8 +612,14 @@ func snapshot(
 	}
 
 	// Intentionally let this iterator and the snapshot escape so that the
-	// streamer can send chunks from it bit by bit.
-	iter := rditer.NewReplicaEngineDataIterator(&desc, snap, true /* replicatedOnly */)
+	// streamer can send chunks from it bit by bit. Witnesses only receive the
+	// range's metadata.
+	var iter *rditer.ReplicaEngineDataIterator
+	if recipient, ok := desc.GetReplicaDescriptor(recipientStore); ok && recipient.GetType() == roachpb.WITNESS {
+		iter = rditer.NewWitnessEngineDataIterator(&desc, snap)
+	} else {
+		iter = rditer.NewReplicaEngineDataIterator(&desc, snap, true /* replicatedOnly */)
+	}
 
 	return OutgoingSnapshot{
 		RaftEntryCache: eCache,
@@ -838,7 +854,8 @@ func (r *Replica) applySnapshot(
 				// "applied by voters" here, since the LEARNER will soon be promoted to
 				// a voting replica.
 				case roachpb.VOTER_FULL, roachpb.VOTER_INCOMING, roachpb.VOTER_DEMOTING_LEARNER,
-					roachpb.VOTER_OUTGOING, roachpb.LEARNER, roachpb.VOTER_DEMOTING_NON_VOTER:
+					roachpb.VOTER_OUTGOING, roachpb.LEARNER, roachpb.VOTER_DEMOTING_NON_VOTER,
+					roachpb.WITNESS:
 					r.store.metrics.RangeSnapshotsAppliedByVoters.Inc(1)
 				case roachpb.NON_VOTER:
 					r.store.metrics.RangeSnapshotsAppliedByNonVoters.Inc(1)
@@ -890,8 +907,22 @@ func (r *Replica) applySnapshot(
 		return errors.Wrapf(err, "error clearing range of unreplicated SST writer")
 	}
 
+	// With a separate Raft engine, the Raft state is written to it only once
+	// the state machine has been durably ingested below. See
+	// store_raft_engine.go.
+	var raftWriter storage.Writer = &unreplicatedSST
+	var raftBatch storage.Batch
+	if r.store.separateRaftEngine() {
+		raftBatch = r.store.raftEngine.NewUnindexedBatch(true /* writeOnly */)
+		defer raftBatch.Close()
+		if err := clearRaftEngineState(r.RangeID, raftBatch); err != nil {
+			return errors.Wrapf(err, "unable to clear Raft state")
+		}
+		raftWriter = raftBatch
+	}
+
 	// Update HardState.
-	if err := r.raftMu.stateLoader.SetHardState(ctx, &unreplicatedSST, hs); err != nil {
+	if err := r.raftMu.stateLoader.SetHardState(ctx, raftWriter, hs); err != nil {
 		return errors.Wrapf(err, "unable to write HardState to unreplicated SST writer")
 	}
 	// We've cleared all the raft state above, so we are forced to write the
@@ -905,7 +936,7 @@ func (r *Replica) applySnapshot(
 	r.store.raftEntryCache.Drop(r.RangeID)
 
 	if err := r.raftMu.stateLoader.SetRaftTruncatedState(
-		ctx, &unreplicatedSST,
+		ctx, raftWriter,
 		&roachpb.RaftTruncatedState{
 			Index: nonemptySnap.Metadata.Index,
 			Term:  nonemptySnap.Metadata.Term,
@@ -948,7 +979,17 @@ func (r *Replica) applySnapshot(
 	}
 	stats.ingestion = timeutil.Now()
 
-	state, err := stateloader.Make(desc.RangeID).Load(ctx, r.store.engine, desc)
+	// NB: the batch must be synced, as Raft assumes the snapshot to be durably
+	// applied once we return.
+	if raftBatch != nil {
+		if err := raftBatch.Commit(true /* sync */); err != nil {
+			log.Fatalf(ctx, "unable to write Raft state after ingesting snapshot: %v", err)
+		}
+	}
+
+	state, err := loadReplicaState(
+		ctx, stateloader.Make(desc.RangeID), r.store.engine, r.store.raftEngine, desc,
+	)
 	if err != nil {
 		log.Fatalf(ctx, "unable to load replica state: %s", err)
 	}
@@ -352,7 +352,7 @@ func TestReplicaRangefeed(t *testing.T) {
 
 	_, pErr = store1.DB().AddSSTableAtBatchTimestamp(ctx, roachpb.Key("b"), roachpb.Key("r"), sstFile.Data(),
 		false /* disallowConflicts */, false /* disallowShadowing */, hlc.Timestamp{}, nil, /* stats */
-		false /* ingestAsWrites */, ts6)
+		false /* ingestAsWrites */, ts6, roachpb.AdmissionHeader{})
 	require.Nil(t, pErr)
 
 	// Ingest an SSTable as writes.
@@ -379,7 +379,7 @@ func TestReplicaRangefeed(t *testing.T) {
 
 	_, pErr = store1.DB().AddSSTableAtBatchTimestamp(ctx, roachpb.Key("b"), roachpb.Key("r"), sstFile.Data(),
 		false /* disallowConflicts */, false /* disallowShadowing */, hlc.Timestamp{}, nil, /* stats */
-		true /* ingestAsWrites */, ts7)
+		true /* ingestAsWrites */, ts7, roachpb.AdmissionHeader{})
 	require.Nil(t, pErr)
 
 	// Wait for all streams to observe the expected events.
@@ -24,15 +24,41 @@ const (
 type replicaWithStats struct {
 	repl *Replica
 	qps  float64
+	// requestCPU and raftCPU are the CPU time, in nanoseconds per second, spent
+	// evaluating requests and applying Raft commands on the replica.
+	requestCPU float64
+	raftCPU    float64
 	// TODO(aayush): Include writes-per-second and logicalBytes of storage?
 }
 
-// replicaRankings maintains top-k orderings of the replicas in a store by QPS.
+// load returns the replica's load along the dimension given by the objective.
+// This is the load that moves to another store when the replica is rebalanced
+// along with its lease.
+func (r replicaWithStats) load(objective LBRebalancingObjective) float64 {
+	if objective == LBRebalancingCPU {
+		return r.requestCPU + r.raftCPU
+	}
+	return r.qps
+}
+
+// leaseLoad returns the portion of the replica's load, along the dimension
+// given by the objective, that moves to another store when only the lease is
+// transferred.
+func (r replicaWithStats) leaseLoad(objective LBRebalancingObjective) float64 {
+	if objective == LBRebalancingCPU {
+		return r.requestCPU
+	}
+	return r.qps
+}
+
+// replicaRankings maintains top-k orderings of the replicas in a store by QPS
+// and by CPU.
 type replicaRankings struct {
 	mu struct {
 		syncutil.Mutex
-		qpsAccumulator *rrAccumulator
-		byQPS          []replicaWithStats
+		accumulator *rrAccumulator
+		byQPS       []replicaWithStats
+		byCPU       []replicaWithStats
 	}
 }
 
@@ -42,23 +68,36 @@ func newReplicaRankings() *replicaRankings {
 
 func (rr *replicaRankings) newAccumulator() *rrAccumulator {
 	res := &rrAccumulator{}
-	res.qps.val = func(r replicaWithStats) float64 { return r.qps }
+	res.qps.val = func(r replicaWithStats) float64 { return r.load(LBRebalancingQueries) }
+	res.cpu.val = func(r replicaWithStats) float64 { return r.load(LBRebalancingCPU) }
 	return res
 }
 
 func (rr *replicaRankings) update(acc *rrAccumulator) {
 	rr.mu.Lock()
-	rr.mu.qpsAccumulator = acc
+	rr.mu.accumulator = acc
 	rr.mu.Unlock()
 }
 
 func (rr *replicaRankings) topQPS() []replicaWithStats {
+	return rr.topLoad(LBRebalancingQueries)
+}
+
+// topLoad returns the hottest replicas on the store along the dimension given
+// by the objective, in descending order of load.
+func (rr *replicaRankings) topLoad(objective LBRebalancingObjective) []replicaWithStats {
 	rr.mu.Lock()
 	defer rr.mu.Unlock()
 	// If we have a new set of data, consume it. Otherwise, just return the most
 	// recently consumed data.
-	if rr.mu.qpsAccumulator != nil && rr.mu.qpsAccumulator.qps.Len() > 0 {
-		rr.mu.byQPS = consumeAccumulator(&rr.mu.qpsAccumulator.qps)
+	if objective == LBRebalancingCPU {
+		if rr.mu.accumulator != nil && rr.mu.accumulator.cpu.Len() > 0 {
+			rr.mu.byCPU = consumeAccumulator(&rr.mu.accumulator.cpu)
+		}
+		return rr.mu.byCPU
+	}
+	if rr.mu.accumulator != nil && rr.mu.accumulator.qps.Len() > 0 {
+		rr.mu.byQPS = consumeAccumulator(&rr.mu.accumulator.qps)
 	}
 	return rr.mu.byQPS
 }
@@ -73,20 +112,26 @@ func (rr *replicaRankings) topQPS() []replicaWithStats {
 // `update`d accumulator will win.
 type rrAccumulator struct {
 	qps rrPriorityQueue
+	cpu rrPriorityQueue
 }
 
 func (a *rrAccumulator) addReplica(repl replicaWithStats) {
+	a.qps.maybeAdd(repl)
+	a.cpu.maybeAdd(repl)
+}
+
+func (pq *rrPriorityQueue) maybeAdd(repl replicaWithStats) {
 	// If the heap isn't full, just push the new replica and return.
-	if a.qps.Len() < numTopReplicasToTrack {
-		heap.Push(&a.qps, repl)
+	if pq.Len() < numTopReplicasToTrack {
+		heap.Push(pq, repl)
 		return
 	}
 
 	// Otherwise, conditionally push if the new replica is more deserving than
 	// the current tip of the heap.
-	if repl.qps > a.qps.entries[0].qps {
-		heap.Pop(&a.qps)
-		heap.Push(&a.qps, repl)
+	if pq.val(repl) > pq.val(pq.entries[0]) {
+		heap.Pop(pq)
+		heap.Push(pq, repl)
 	}
 }
 
@@ -31,6 +31,22 @@ func (r *Replica) maybeRateLimitBatch(ctx context.Context, ba *roachpb.BatchRequ
 	return r.tenantLimiter.Wait(ctx, tenantcostmodel.MakeRequestInfo(ba))
 }
 
+// maybeRejectBatchOverTenantStorageQuota returns an error if the batch, issued
+// by a secondary tenant which has reached its storage quota, would add data to
+// the range. Deletions are always let through.
+func (r *Replica) maybeRejectBatchOverTenantStorageQuota(
+	ctx context.Context, ba *roachpb.BatchRequest,
+) error {
+	if r.store.cfg.TenantStorage == nil {
+		return nil
+	}
+	tenantID, ok := roachpb.TenantFromContext(ctx)
+	if !ok || tenantID == roachpb.SystemTenantID {
+		return nil
+	}
+	return r.store.cfg.TenantStorage.CheckBatch(tenantID, ba)
+}
+
 // recordImpactOnRateLimiter is used to record a read against the tenant rate limiter.
 func (r *Replica) recordImpactOnRateLimiter(ctx context.Context, br *roachpb.BatchResponse) {
 	if r.tenantLimiter == nil || br == nil {
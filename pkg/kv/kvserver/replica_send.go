@@ -65,6 +65,9 @@ var optimisticEvalLimitedScans = settings.RegisterBoolSetting(
 //               Replica.maybeRateLimitBatch (tenant rate limits)
 //                                      │
 //                                      ▼
+//     Replica.maybeRejectBatchOverTenantStorageQuota (tenant storage quotas)
+//                                      │
+//                                      ▼
 //                 Replica.maybeCommitWaitBeforeCommitTrigger (if committing with commit-trigger)
 //                                      │
 // read-write ◄─────────────────────────┴────────────────────────► read-only
@@ -158,6 +161,9 @@ func (r *Replica) sendWithoutRangeID(
 	if err := r.maybeRateLimitBatch(ctx, ba); err != nil {
 		return nil, roachpb.NewError(err)
 	}
+	if err := r.maybeRejectBatchOverTenantStorageQuota(ctx, ba); err != nil {
+		return nil, roachpb.NewError(err)
+	}
 	if err := r.maybeCommitWaitBeforeCommitTrigger(ctx, ba); err != nil {
 		return nil, roachpb.NewError(err)
 	}
@@ -917,6 +923,9 @@ func (r *Replica) executeAdminBatch(
 
 	case *roachpb.AdminChangeReplicasRequest:
 		chgs := tArgs.Changes()
+		if tArgs.DisableSnapshotDelegation {
+			ctx = withSnapshotDelegationDisabled(ctx)
+		}
 		desc, err := r.ChangeReplicas(ctx, &tArgs.ExpDesc, kvserverpb.SnapshotRequest_REBALANCE, kvserverpb.ReasonAdminRequest, "", chgs)
 		pErr = roachpb.NewError(err)
 		if pErr != nil {
@@ -12,7 +12,10 @@ package kvserver
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/spanset"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -35,11 +38,43 @@ var SplitByLoadQPSThreshold = settings.RegisterIntSetting(
 	2500, // 2500 req/s
 ).WithPublic()
 
+// SplitByLoadCPUThreshold wraps "kv.range_split.load_cpu_threshold".
+var SplitByLoadCPUThreshold = settings.RegisterDurationSetting(
+	settings.SystemOnly,
+	"kv.range_split.load_cpu_threshold",
+	"the CPU time per second over which, the range becomes a candidate for load "+
+		"based splitting when kv.allocator.load_based_rebalancing.objective is cpu",
+	250*time.Millisecond,
+	settings.NonNegativeDuration,
+).WithPublic()
+
 // SplitByLoadQPSThreshold returns the QPS request rate for a given replica.
 func (r *Replica) SplitByLoadQPSThreshold() float64 {
 	return float64(SplitByLoadQPSThreshold.Get(&r.store.cfg.Settings.SV))
 }
 
+// SplitByLoadThreshold returns the load over which the replica becomes a
+// candidate for load based splitting, in the unit of the given objective:
+// requests per second for QPS, and nanoseconds of CPU time per second for CPU.
+func (r *Replica) SplitByLoadThreshold(objective LBRebalancingObjective) float64 {
+	if objective == LBRebalancingCPU {
+		return float64(SplitByLoadCPUThreshold.Get(&r.store.cfg.Settings.SV))
+	}
+	return r.SplitByLoadQPSThreshold()
+}
+
+// loadSplitObjective returns the objective that load based splitting should
+// use. If it differs from the objective the load based splitter was last
+// recording for, the splitter is reset, since its measurements would
+// otherwise mix up units.
+func (r *Replica) loadSplitObjective(ctx context.Context) LBRebalancingObjective {
+	objective := ResolveLBRebalancingObjective(ctx, r.store.cfg.Settings)
+	if prev := atomic.SwapInt64(&r.loadBasedSplitterObjective, int64(objective)); prev != int64(objective) {
+		r.loadBasedSplitter.Reset(r.Clock().PhysicalTime())
+	}
+	return objective
+}
+
 // SplitByLoadEnabled returns whether load based splitting is enabled.
 // Although this is a method of *Replica, the configuration is really global,
 // shared across all stores.
@@ -49,11 +84,13 @@ func (r *Replica) SplitByLoadEnabled() bool {
 }
 
 // recordBatchForLoadBasedSplitting records the batch's spans to be considered
-// for load based splitting.
+// for load based splitting. It is a no-op when load based splitting is using
+// the CPU objective, in which case the batch is recorded by recordRequestCPU
+// after it has been evaluated.
 func (r *Replica) recordBatchForLoadBasedSplitting(
 	ctx context.Context, ba *roachpb.BatchRequest, spans *spanset.SpanSet,
 ) {
-	if !r.SplitByLoadEnabled() {
+	if !r.SplitByLoadEnabled() || r.loadSplitObjective(ctx) != LBRebalancingQueries {
 		return
 	}
 	shouldInitSplit := r.loadBasedSplitter.Record(timeutil.Now(), len(ba.Requests), func() roachpb.Span {
@@ -63,3 +100,21 @@ func (r *Replica) recordBatchForLoadBasedSplitting(
 		r.store.splitQueue.MaybeAddAsync(ctx, r, r.store.Clock().NowAsClockTimestamp())
 	}
 }
+
+// recordRequestCPU records the CPU time spent evaluating a batch, for use in
+// load based rebalancing and, when load based splitting is using the CPU
+// objective, load based splitting. The guard provides the spans of the batch.
+func (r *Replica) recordRequestCPU(
+	ctx context.Context, g *concurrency.Guard, cpu time.Duration,
+) {
+	r.requestCPUStats.recordCount(float64(cpu), 0 /* nodeID */)
+	if g == nil || !r.SplitByLoadEnabled() || r.loadSplitObjective(ctx) != LBRebalancingCPU {
+		return
+	}
+	shouldInitSplit := r.loadBasedSplitter.Record(timeutil.Now(), int(cpu), func() roachpb.Span {
+		return g.LatchSpans().BoundarySpan(spanset.SpanGlobal)
+	})
+	if shouldInitSplit {
+		r.store.splitQueue.MaybeAddAsync(ctx, r, r.store.Clock().NowAsClockTimestamp())
+	}
+}
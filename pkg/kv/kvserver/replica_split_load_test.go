@@ -0,0 +1,157 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/split"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/cockroachdb/cockroach/pkg/util/grunning"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/datadriven"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadBasedSplitSimulation is a datadriven test that simulates load based
+// splitting of a replica, for each of the load-based rebalancing objectives.
+//
+// The input files support the following commands:
+//
+//   - init objective=(qps|cpu): switches load based splitting to the given
+//     objective, which resets the replica's split decider if the objective
+//     changed.
+//   - workload keys=(<keys>) rate=<float> cpu=<duration> duration=<duration>:
+//     sends rate requests per second for the given duration, cycling through
+//     the given keys, each of which costs cpu to evaluate. The requests are
+//     recorded the way recordBatchForLoadBasedSplitting and recordRequestCPU
+//     record them, and the load last measured by the split decider is printed
+//     along with the time into the workload at which a split was first
+//     suggested, if any, and the suggested split key.
+func TestLoadBasedSplitSimulation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	if !grunning.Supported() {
+		skip.IgnoreLint(t, "the cpu objective requires CPU time measurements")
+	}
+
+	ctx := context.Background()
+	datadriven.Walk(t, testutils.TestDataPath(t, "load_split"), func(t *testing.T, path string) {
+		stopper := stop.NewStopper()
+		defer stopper.Stop(ctx)
+		// Keep the replica's own requests from being recorded by its split
+		// decider.
+		cfg := TestStoreConfig(nil)
+		cfg.TestingKnobs.DisableLoadBasedSplitting = true
+		tc := testContext{}
+		tc.StartWithStoreConfig(ctx, t, stopper, cfg)
+		repl := tc.repl
+		sv := &tc.store.cfg.Settings.SV
+		// The simulation keeps its own time, so that advancing it doesn't affect
+		// the replica's lease.
+		now := repl.Clock().PhysicalTime()
+
+		// Mirror the initialization of the split decider in newUnloadedReplica,
+		// but with a deterministic source of randomness.
+		rng := rand.New(rand.NewSource(0))
+		split.Init(&repl.loadBasedSplitter, rng.Intn, func() float64 {
+			return repl.SplitByLoadThreshold(LBRebalancingObjective(atomic.LoadInt64(&repl.loadBasedSplitterObjective)))
+		}, func() time.Duration {
+			return kvserverbase.SplitByLoadMergeDelay.Get(sv)
+		})
+
+		datadriven.RunTest(t, path, func(t *testing.T, d *datadriven.TestData) string {
+			switch d.Cmd {
+			case "init":
+				var objectiveStr string
+				d.ScanArgs(t, "objective", &objectiveStr)
+				var objective LBRebalancingObjective
+				switch objectiveStr {
+				case "qps":
+					objective = LBRebalancingQueries
+				case "cpu":
+					objective = LBRebalancingCPU
+				default:
+					d.Fatalf(t, "unknown objective %q", objectiveStr)
+				}
+				LoadBasedRebalancingObjective.Override(ctx, sv, int64(objective))
+				require.Equal(t, objective, repl.loadSplitObjective(ctx))
+				return ""
+
+			case "workload":
+				var keys []roachpb.Key
+				var rateStr, cpuStr, durationStr string
+				for _, arg := range d.CmdArgs {
+					if arg.Key == "keys" {
+						for _, key := range arg.Vals {
+							keys = append(keys, roachpb.Key(key))
+						}
+					}
+				}
+				d.ScanArgs(t, "rate", &rateStr)
+				d.ScanArgs(t, "cpu", &cpuStr)
+				d.ScanArgs(t, "duration", &durationStr)
+				rate, err := strconv.ParseFloat(rateStr, 64)
+				require.NoError(t, err)
+				cpu, err := time.ParseDuration(cpuStr)
+				require.NoError(t, err)
+				duration, err := time.ParseDuration(durationStr)
+				require.NoError(t, err)
+
+				objective := LBRebalancingObjective(atomic.LoadInt64(&repl.loadBasedSplitterObjective))
+				interval := time.Duration(float64(time.Second) / rate)
+				var suggested time.Duration
+				var last time.Time
+				for elapsed := time.Duration(0); elapsed < duration; elapsed += interval {
+					last = now
+					key := keys[int(elapsed/interval)%len(keys)]
+					n := 1
+					if objective == LBRebalancingCPU {
+						n = int(cpu)
+					}
+					if repl.loadBasedSplitter.Record(now, n, func() roachpb.Span {
+						return roachpb.Span{Key: key}
+					}) && suggested == 0 {
+						suggested = elapsed
+					}
+					now = now.Add(interval)
+				}
+
+				splitKey := "none"
+				if key := repl.loadBasedSplitter.MaybeSplitKey(last); key != nil {
+					splitKey = string(key)
+				}
+				suggestedStr := "never"
+				if suggested != 0 {
+					suggestedStr = suggested.String()
+				}
+				return fmt.Sprintf("load=%s suggested=%s split-key=%s\n",
+					objective.format(repl.loadBasedSplitter.LastQPS(last)), suggestedStr, splitKey)
+
+			default:
+				d.Fatalf(t, "unknown command %q", d.Cmd)
+				return ""
+			}
+		})
+	})
+}
@@ -6428,6 +6428,12 @@ func TestConditionFailedError(t *testing.T) {
 func TestReplicaSetsEqual(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
+	withType := func(
+		descs []roachpb.ReplicaDescriptor, i int, typ *roachpb.ReplicaType,
+	) []roachpb.ReplicaDescriptor {
+		descs[i].Type = typ
+		return descs
+	}
 	testData := []struct {
 		expected bool
 		a        []roachpb.ReplicaDescriptor
@@ -6445,6 +6451,15 @@ func TestReplicaSetsEqual(t *testing.T) {
 		{true, createReplicaSets([]roachpb.StoreID{1, 1}), createReplicaSets([]roachpb.StoreID{1, 1})},
 		{false, createReplicaSets([]roachpb.StoreID{1, 1}), createReplicaSets([]roachpb.StoreID{1, 1, 1})},
 		{true, createReplicaSets([]roachpb.StoreID{1, 2, 3, 1, 2, 3}), createReplicaSets([]roachpb.StoreID{1, 1, 2, 2, 3, 3})},
+		// The replicas on each store must be of the same type.
+		{true, withType(createReplicaSets([]roachpb.StoreID{1, 2, 3}), 2, roachpb.ReplicaTypeWitness()),
+			withType(createReplicaSets([]roachpb.StoreID{3, 1, 2}), 0, roachpb.ReplicaTypeWitness())},
+		{false, withType(createReplicaSets([]roachpb.StoreID{1, 2, 3}), 2, roachpb.ReplicaTypeWitness()),
+			withType(createReplicaSets([]roachpb.StoreID{1, 2, 3}), 1, roachpb.ReplicaTypeWitness())},
+		{false, withType(createReplicaSets([]roachpb.StoreID{1, 2, 3}), 2, roachpb.ReplicaTypeWitness()),
+			createReplicaSets([]roachpb.StoreID{1, 2, 3})},
+		{false, withType(createReplicaSets([]roachpb.StoreID{1, 2, 3}), 2, roachpb.ReplicaTypeNonVoter()),
+			createReplicaSets([]roachpb.StoreID{1, 2, 3})},
 	}
 	for _, test := range testData {
 		if replicasCollocated(test.a, test.b) != test.expected {
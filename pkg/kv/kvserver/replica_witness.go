@@ -0,0 +1,130 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"go.etcd.io/etcd/raft/v3"
+)
+
+// isWitnessKey returns whether the given key is retained by WITNESS replicas.
+// Witnesses participate in the range's raft group and need to maintain its
+// replicated state (the range-ID local keys) as well as the range descriptors
+// (and any locks on them) to follow configuration changes, splits, etc. All
+// other keys, notably the user data, are dropped by witnesses.
+func isWitnessKey(key roachpb.Key) bool {
+	if bytes.HasPrefix(key, keys.LocalRangeIDPrefix) {
+		return true
+	}
+	if lockedKey, err := keys.DecodeLockTableSingleKey(key); err == nil {
+		key = lockedKey
+	}
+	if !bytes.HasPrefix(key, keys.LocalRangePrefix) {
+		return false
+	}
+	_, suffix, _, err := keys.DecodeRangeKey(key)
+	return err == nil && bytes.Equal(suffix, keys.LocalRangeDescriptorSuffix)
+}
+
+// applyWitnessWriteBatch applies the mutations in the given WriteBatch repr to
+// the supplied writer, skipping those to keys that aren't retained by WITNESS
+// replicas (see isWitnessKey). Range deletions are always applied, as they are
+// harmless on a witness which doesn't have the data in the first place.
+func applyWitnessWriteBatch(w storage.Writer, repr []byte) error {
+	r, err := storage.NewRocksDBBatchReader(repr)
+	if err != nil {
+		return err
+	}
+	for r.Next() {
+		switch r.BatchType() {
+		case storage.BatchTypeRangeDeletion:
+			start, err := r.EngineKey()
+			if err != nil {
+				return err
+			}
+			end, err := r.EngineEndKey()
+			if err != nil {
+				return err
+			}
+			if err := w.ClearRawRange(start.Key, end.Key); err != nil {
+				return err
+			}
+			continue
+		case storage.BatchTypeLogData:
+			continue
+		}
+
+		key, err := r.EngineKey()
+		if err != nil {
+			return err
+		}
+		if !isWitnessKey(key.Key) {
+			continue
+		}
+		switch r.BatchType() {
+		case storage.BatchTypeValue:
+			err = w.PutEngineKey(key, r.Value())
+		case storage.BatchTypeDeletion:
+			err = w.ClearEngineKey(key)
+		case storage.BatchTypeSingleDeletion:
+			err = w.SingleClearEngineKey(key)
+		case storage.BatchTypeMerge:
+			var mvccKey storage.MVCCKey
+			if mvccKey, err = key.ToMVCCKey(); err == nil {
+				err = w.Merge(mvccKey, r.Value())
+			}
+		default:
+			err = errors.AssertionFailedf("unexpected batch entry type %d", r.BatchType())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return r.Error()
+}
+
+// maybeTransferRaftLeadershipOffWitnessLocked transfers the Raft leadership to
+// an up-to-date VOTER_FULL replica if this replica is a WITNESS and the leader.
+// Witnesses vote, so they can win elections, but they can neither hold the
+// lease nor apply commands, so a witness leader forces every proposal through
+// a replica that can't serve it. maybeTransferRaftLeadershipToLeaseholderLocked
+// only moves the leadership to a valid leaseholder, which the range may not
+// have.
+func (r *Replica) maybeTransferRaftLeadershipOffWitnessLocked(ctx context.Context) {
+	desc := r.descRLocked()
+	repDesc, ok := desc.GetReplicaDescriptorByID(r.replicaID)
+	if !ok || repDesc.GetType() != roachpb.WITNESS {
+		return
+	}
+	raftStatus := r.raftStatusRLocked()
+	if raftStatus == nil || raftStatus.RaftState != raft.StateLeader ||
+		raftStatus.LeadTransferee != raft.None {
+		return
+	}
+	for _, rd := range desc.Replicas().VoterDescriptors() {
+		if rd.GetType() != roachpb.VOTER_FULL {
+			continue
+		}
+		if pr, ok := raftStatus.Progress[uint64(rd.ReplicaID)]; ok && pr.Match >= raftStatus.Commit {
+			log.VEventf(ctx, 1, "transferring raft leadership off witness to replica ID %v", rd.ReplicaID)
+			r.store.metrics.RangeRaftLeaderTransfers.Inc(1)
+			r.mu.internalRaftGroup.TransferLeader(uint64(rd.ReplicaID))
+			return
+		}
+	}
+}
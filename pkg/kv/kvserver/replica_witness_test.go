@@ -0,0 +1,93 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWitnessKey(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	descKey := keys.RangeDescriptorKey(roachpb.RKey("a"))
+	descLockKey, _ := keys.LockTableSingleKey(descKey, nil)
+	userLockKey, _ := keys.LockTableSingleKey(roachpb.Key("a"), nil)
+
+	for _, tc := range []struct {
+		key roachpb.Key
+		exp bool
+	}{
+		{keys.RangeAppliedStateKey(1), true},
+		{keys.RangeLeaseKey(5), true},
+		{keys.RaftTruncatedStateKey(5), true},
+		{descKey, true},
+		{descLockKey, true},
+		{keys.TransactionKey(roachpb.Key("a"), [16]byte{}), false},
+		{keys.QueueLastProcessedKey(roachpb.RKey("a"), "consistency"), false},
+		{userLockKey, false},
+		{roachpb.Key("a"), false},
+		{keys.SystemSQLCodec.TablePrefix(50), false},
+	} {
+		t.Run(tc.key.String(), func(t *testing.T) {
+			require.Equal(t, tc.exp, isWitnessKey(tc.key))
+		})
+	}
+}
+
+func TestApplyWitnessWriteBatch(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	eng := storage.NewDefaultInMemForTesting()
+	defer eng.Close()
+
+	descKey := keys.RangeDescriptorKey(roachpb.RKey("a"))
+	appliedStateKey := keys.RangeAppliedStateKey(1)
+	leaseKey := keys.RangeLeaseKey(1)
+	userKey := roachpb.Key("a")
+
+	// Seed the engine with a key which is deleted by the batch below.
+	require.NoError(t, eng.PutUnversioned(leaseKey, []byte("lease")))
+
+	src := eng.NewBatch()
+	defer src.Close()
+	require.NoError(t, src.PutUnversioned(descKey, []byte("desc")))
+	require.NoError(t, src.PutUnversioned(appliedStateKey, []byte("state")))
+	require.NoError(t, src.PutUnversioned(userKey, []byte("value")))
+	require.NoError(t, src.ClearUnversioned(leaseKey))
+
+	dst := eng.NewBatch()
+	defer dst.Close()
+	require.NoError(t, applyWitnessWriteBatch(dst, src.Repr()))
+	require.NoError(t, dst.Commit(true /* sync */))
+
+	for _, tc := range []struct {
+		key roachpb.Key
+		exp []byte
+	}{
+		{descKey, []byte("desc")},
+		{appliedStateKey, []byte("state")},
+		{userKey, nil},
+		{leaseKey, nil},
+	} {
+		val, err := eng.MVCCGet(storage.MakeMVCCMetadataKey(tc.key))
+		require.NoError(t, err)
+		require.Equal(t, tc.exp, val, "key %s", tc.key)
+	}
+}
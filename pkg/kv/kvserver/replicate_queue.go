@@ -375,6 +375,10 @@ func (rq *replicateQueue) processOneChange(
 	liveNonVoterReplicas, deadNonVoterReplicas := rq.allocator.storePool.liveAndDeadReplicas(
 		nonVoterReplicas, true, /* includeSuspectAndDrainingStores */
 	)
+	witnessReplicas := desc.Replicas().WitnessDescriptors()
+	_, deadWitnessReplicas := rq.allocator.storePool.liveAndDeadReplicas(
+		witnessReplicas, true, /* includeSuspectAndDrainingStores */
+	)
 
 	// NB: the replication layer ensures that the below operations don't cause
 	// unavailability; see:
@@ -402,12 +406,25 @@ func (rq *replicateQueue) processOneChange(
 		return rq.addOrReplaceVoters(ctx, repl, liveVoterReplicas, liveNonVoterReplicas, -1 /* removeIdx */, dryRun)
 	case AllocatorAddNonVoter:
 		return rq.addOrReplaceNonVoters(ctx, repl, liveVoterReplicas, liveNonVoterReplicas, -1 /* removeIdx */, dryRun)
+	case AllocatorAddWitness:
+		return rq.addWitness(ctx, repl, voterReplicas, nonVoterReplicas, witnessReplicas, dryRun)
 
 	// Remove replicas.
 	case AllocatorRemoveVoter:
 		return rq.removeVoter(ctx, repl, voterReplicas, nonVoterReplicas, dryRun)
 	case AllocatorRemoveNonVoter:
 		return rq.removeNonVoter(ctx, repl, voterReplicas, nonVoterReplicas, dryRun)
+	case AllocatorRemoveWitness:
+		// Dead and decommissioning witnesses are removed first; this is how the
+		// replacement of such a witness (see AllocatorReplaceDeadWitness) is
+		// completed.
+		if len(deadWitnessReplicas) > 0 {
+			return rq.removeDead(ctx, repl, deadWitnessReplicas, witnessTarget, dryRun)
+		}
+		if len(rq.allocator.storePool.decommissioningReplicas(witnessReplicas)) > 0 {
+			return rq.removeDecommissioning(ctx, repl, witnessTarget, dryRun)
+		}
+		return rq.removeWitness(ctx, repl, voterReplicas, nonVoterReplicas, witnessReplicas, dryRun)
 
 	// Replace dead replicas.
 	case AllocatorReplaceDeadVoter:
@@ -434,6 +451,10 @@ func (rq *replicateQueue) processOneChange(
 				deadNonVoterReplicas[0], nonVoterReplicas)
 		}
 		return rq.addOrReplaceNonVoters(ctx, repl, liveVoterReplicas, liveNonVoterReplicas, removeIdx, dryRun)
+	case AllocatorReplaceDeadWitness:
+		// Witnesses can only be added or removed one at a time, so we add a new
+		// witness here and remove the dead one once the range is over-replicated.
+		return rq.addWitness(ctx, repl, voterReplicas, nonVoterReplicas, witnessReplicas, dryRun)
 
 	// Replace decommissioning replicas.
 	case AllocatorReplaceDecommissioningVoter:
@@ -715,6 +736,40 @@ func (rq *replicateQueue) addOrReplaceNonVoters(
 	return true, nil
 }
 
+// addWitness adds a witness replica to `repl`s range.
+func (rq *replicateQueue) addWitness(
+	ctx context.Context,
+	repl *Replica,
+	existingVoters, existingNonVoters, existingWitnesses []roachpb.ReplicaDescriptor,
+	dryRun bool,
+) (requeue bool, _ error) {
+	desc, conf := repl.DescAndSpanConfig()
+
+	existingReplicas := append(existingVoters[:len(existingVoters):len(existingVoters)], existingNonVoters...)
+	newWitness, details, err := rq.allocator.AllocateWitness(ctx, conf, existingReplicas, existingWitnesses)
+	if err != nil {
+		return false, err
+	}
+	rq.metrics.AddReplicaCount.Inc(1)
+	log.VEventf(ctx, 1, "adding witness %+v: %s",
+		newWitness, rangeRaftProgress(repl.RaftStatus(), existingWitnesses))
+
+	if err := rq.changeReplicas(
+		ctx,
+		repl,
+		roachpb.MakeReplicationChanges(roachpb.ADD_WITNESS, newWitness),
+		desc,
+		kvserverpb.SnapshotRequest_RECOVERY,
+		kvserverpb.ReasonRangeUnderReplicated,
+		details,
+		dryRun,
+	); err != nil {
+		return false, err
+	}
+	// Always requeue to see if more work needs to be done.
+	return true, nil
+}
+
 // findRemoveVoter takes a list of voting replicas and picks one to remove,
 // making sure to not remove a newly added voter or to violate the zone configs
 // in the process.
@@ -938,6 +993,46 @@ func (rq *replicateQueue) removeNonVoter(
 	return true, nil
 }
 
+func (rq *replicateQueue) removeWitness(
+	ctx context.Context,
+	repl *Replica,
+	existingVoters, existingNonVoters, existingWitnesses []roachpb.ReplicaDescriptor,
+	dryRun bool,
+) (requeue bool, _ error) {
+	rq.metrics.RemoveReplicaCount.Inc(1)
+
+	desc, conf := repl.DescAndSpanConfig()
+	existingReplicas := append(existingVoters[:len(existingVoters):len(existingVoters)], existingNonVoters...)
+	removeWitness, details, err := rq.allocator.RemoveWitness(
+		ctx,
+		conf,
+		existingWitnesses,
+		existingReplicas,
+		existingWitnesses,
+		rq.allocator.scorerOptions(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	log.VEventf(ctx, 1, "removing witness %+v due to over-replication: %s",
+		removeWitness, rangeRaftProgress(repl.RaftStatus(), existingWitnesses))
+
+	if err := rq.changeReplicas(
+		ctx,
+		repl,
+		roachpb.MakeReplicationChanges(roachpb.REMOVE_WITNESS, removeWitness),
+		desc,
+		kvserverpb.SnapshotRequest_UNKNOWN,
+		kvserverpb.ReasonRangeOverReplicated,
+		details,
+		dryRun,
+	); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (rq *replicateQueue) removeDecommissioning(
 	ctx context.Context, repl *Replica, targetType targetReplicaType, dryRun bool,
 ) (requeue bool, _ error) {
@@ -952,6 +1047,10 @@ func (rq *replicateQueue) removeDecommissioning(
 		decommissioningReplicas = rq.allocator.storePool.decommissioningReplicas(
 			desc.Replicas().NonVoterDescriptors(),
 		)
+	case witnessTarget:
+		decommissioningReplicas = rq.allocator.storePool.decommissioningReplicas(
+			desc.Replicas().WitnessDescriptors(),
+		)
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType: %s", targetType))
 	}
@@ -1292,17 +1391,20 @@ func replicationChangesForRebalance(
 
 // transferLeaseGoal dictates whether a call to TransferLeaseTarget should
 // improve locality of access, convergence of lease counts or convergence of
-// QPS.
+// load (QPS or CPU, see transferLeaseOptions.objective).
 type transferLeaseGoal int
 
 const (
 	followTheWorkload transferLeaseGoal = iota
 	leaseCountConvergence
-	qpsConvergence
+	loadConvergence
 )
 
 type transferLeaseOptions struct {
 	goal transferLeaseGoal
+	// objective is the load dimension to converge when the goal is
+	// loadConvergence.
+	objective LBRebalancingObjective
 	// checkTransferLeaseSource, when false, tells `TransferLeaseTarget` to
 	// exclude the current leaseholder from consideration as a potential target
 	// (i.e. when the caller explicitly wants to shed its lease away).
@@ -1368,18 +1470,17 @@ func (rq *replicateQueue) shedLease(
 		return noTransferDryRun, nil
 	}
 
-	avgQPS, qpsMeasurementDur := repl.leaseholderStats.avgQPS()
-	if qpsMeasurementDur < MinStatsDuration {
-		avgQPS = 0
-	}
-	if err := rq.transferLease(ctx, repl, target, avgQPS); err != nil {
+	if err := rq.transferLease(ctx, repl, target, rangeUsageInfoForRepl(repl)); err != nil {
 		return transferErr, err
 	}
 	return transferOK, nil
 }
 
 func (rq *replicateQueue) transferLease(
-	ctx context.Context, repl *Replica, target roachpb.ReplicaDescriptor, rangeQPS float64,
+	ctx context.Context,
+	repl *Replica,
+	target roachpb.ReplicaDescriptor,
+	rangeUsageInfo RangeUsageInfo,
 ) error {
 	rq.metrics.TransferLeaseCount.Inc(1)
 	log.VEventf(ctx, 1, "transferring lease to s%d", target.StoreID)
@@ -1388,7 +1489,7 @@ func (rq *replicateQueue) transferLease(
 	}
 	rq.lastLeaseTransfer.Store(timeutil.Now())
 	rq.allocator.storePool.updateLocalStoresAfterLeaseTransfer(
-		repl.store.StoreID(), target.StoreID, rangeQPS)
+		repl.store.StoreID(), target.StoreID, rangeUsageInfo)
 	return nil
 }
 
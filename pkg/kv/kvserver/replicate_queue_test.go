@@ -675,6 +675,92 @@ func getNonVoterNodeIDs(rangeDesc roachpb.RangeDescriptor) (result []roachpb.Nod
 	return result
 }
 
+// TestReplicateQueueDeadVoterWithWitness checks that a range with two voters and
+// a witness remains available when it loses one of its voters, and that the
+// replicateQueue replaces the lost voter.
+func TestReplicateQueueDeadVoterWithWitness(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	skip.UnderRace(t, "takes a long time or times out under race")
+
+	ctx := context.Background()
+
+	var deadNodeID int32
+	tc := testcluster.StartTestCluster(t, 4,
+		base.TestClusterArgs{
+			ReplicationMode: base.ReplicationManual,
+			ServerArgs: base.TestServerArgs{
+				Knobs: base.TestingKnobs{
+					SpanConfig: &spanconfig.TestingKnobs{
+						ConfigureScratchRange: true,
+					},
+					NodeLiveness: kvserver.NodeLivenessTestingKnobs{
+						StorePoolNodeLivenessFn: func(
+							id roachpb.NodeID, now time.Time, duration time.Duration,
+						) livenesspb.NodeLivenessStatus {
+							if id == roachpb.NodeID(atomic.LoadInt32(&deadNodeID)) {
+								return livenesspb.NodeLivenessStatus_DEAD
+							}
+							return livenesspb.NodeLivenessStatus_LIVE
+						},
+					},
+				},
+			},
+		},
+	)
+	defer tc.Stopper().Stop(ctx)
+
+	_, err := tc.ServerConn(0).Exec(
+		`ALTER RANGE DEFAULT CONFIGURE ZONE USING num_replicas = 2, num_witnesses = 1`,
+	)
+	require.NoError(t, err)
+
+	// Set up a scratch range with voters on n1 and n2 and a witness on n3.
+	scratchKey := tc.ScratchRange(t)
+	desc := tc.AddVotersOrFatal(t, scratchKey, tc.Target(1))
+	newDesc, err := tc.Server(0).DB().AdminChangeReplicas(
+		ctx, scratchKey, desc, roachpb.MakeReplicationChanges(roachpb.ADD_WITNESS, tc.Target(2)),
+	)
+	require.NoError(t, err)
+	require.Len(t, newDesc.Replicas().WitnessDescriptors(), 1)
+	require.NoError(t, tc.Server(0).DB().Put(ctx, scratchKey, "a"))
+
+	// Lose the voter on n2. The voter on n1 and the witness on n3 still make up
+	// a quorum, so the range remains available.
+	atomic.StoreInt32(&deadNodeID, int32(tc.Target(1).NodeID))
+	tc.StopServer(1)
+	require.NoError(t, tc.Server(0).DB().Put(ctx, scratchKey, "b"))
+
+	// The replicateQueue replaces the dead voter with one on n4, the only live
+	// node without a replica.
+	store := tc.GetFirstStoreFromServer(t, 0)
+	repl := store.LookupReplica(roachpb.RKey(scratchKey))
+	testutils.SucceedsSoon(t, func() error {
+		_, processErr, enqueueErr := store.ManuallyEnqueue(ctx, "replicate", repl, true /* skipShouldQueue */)
+		if enqueueErr != nil {
+			return enqueueErr
+		}
+		if processErr != nil {
+			return processErr
+		}
+		desc := repl.Desc()
+		voters := desc.Replicas().VoterDescriptors()
+		if len(voters) != 2 {
+			return errors.Newf("expected 2 voters; got %v", desc.Replicas())
+		}
+		if rDesc, ok := desc.GetReplicaDescriptor(tc.Target(3).StoreID); !ok ||
+			rDesc.GetType() != roachpb.VOTER_FULL {
+			return errors.Newf("expected a voter on n4; got %v", desc.Replicas())
+		}
+		if witnesses := desc.Replicas().WitnessDescriptors(); len(witnesses) != 1 ||
+			witnesses[0].StoreID != tc.Target(2).StoreID {
+			return errors.Newf("expected a witness on n3; got %v", desc.Replicas())
+		}
+		return nil
+	})
+	require.NoError(t, tc.Server(0).DB().Put(ctx, scratchKey, "c"))
+}
+
 // TestReplicateQueueSwapVoterWithNonVoters tests that voting replicas can
 // rebalance to stores that already have a non-voter by "swapping" with them.
 // "Swapping" in this context means simply changing the `ReplicaType` on the
@@ -223,6 +223,21 @@ func (i *MVCCIterator) SupportsPrev() bool {
 	return i.i.SupportsPrev()
 }
 
+// HasPointAndRange is part of the storage.MVCCIterator interface.
+func (i *MVCCIterator) HasPointAndRange() (bool, bool) {
+	return i.i.HasPointAndRange()
+}
+
+// RangeBounds is part of the storage.MVCCIterator interface.
+func (i *MVCCIterator) RangeBounds() roachpb.Span {
+	return i.i.RangeBounds()
+}
+
+// RangeKeys is part of the storage.MVCCIterator interface.
+func (i *MVCCIterator) RangeKeys() []storage.MVCCRangeKeyValue {
+	return i.i.RangeKeys()
+}
+
 // EngineIterator wraps a storage.EngineIterator and ensures that it can
 // only be used to access spans in a SpanSet.
 type EngineIterator struct {
@@ -599,6 +614,29 @@ func (s spanSetWriter) ClearIterRange(iter storage.MVCCIterator, start, end roac
 	return s.w.ClearIterRange(iter, start, end)
 }
 
+func (s spanSetWriter) ExperimentalClearMVCCRangeKey(rangeKey storage.MVCCRangeKey) error {
+	if err := s.checkAllowedRange(rangeKey.StartKey, rangeKey.EndKey); err != nil {
+		return err
+	}
+	return s.w.ExperimentalClearMVCCRangeKey(rangeKey)
+}
+
+func (s spanSetWriter) ExperimentalClearAllMVCCRangeKeys(start, end roachpb.Key) error {
+	if err := s.checkAllowedRange(start, end); err != nil {
+		return err
+	}
+	return s.w.ExperimentalClearAllMVCCRangeKeys(start, end)
+}
+
+func (s spanSetWriter) ExperimentalPutMVCCRangeKey(
+	rangeKey storage.MVCCRangeKey, value []byte,
+) error {
+	if err := s.checkAllowedRange(rangeKey.StartKey, rangeKey.EndKey); err != nil {
+		return err
+	}
+	return s.w.ExperimentalPutMVCCRangeKey(rangeKey, value)
+}
+
 func (s spanSetWriter) Merge(key storage.MVCCKey, value []byte) error {
 	if s.spansOnly {
 		if err := s.spans.CheckAllowed(SpanReadWrite, roachpb.Span{Key: key.Key}); err != nil {
@@ -30,6 +30,11 @@ type SpanAccess int
 // Constants for SpanAccess. Higher-valued accesses imply lower-level ones.
 const (
 	SpanReadOnly SpanAccess = iota
+	// SpanReadShared is a read that acquires Shared locks on the keys it reads.
+	// It is only meaningful in the lock spans of a request, where it is used to
+	// sequence the request in the lockTable. It must not be used for latches;
+	// requests that acquire Shared locks declare write latches.
+	SpanReadShared
 	SpanReadWrite
 	NumSpanAccess
 )
@@ -39,6 +44,8 @@ func (a SpanAccess) String() string {
 	switch a {
 	case SpanReadOnly:
 		return "read"
+	case SpanReadShared:
+		return "read-shared"
 	case SpanReadWrite:
 		return "write"
 	default:
@@ -41,6 +41,15 @@ const (
 	RaftLogTermSignalForAddRaftAppliedIndexTermMigration = 3
 )
 
+// InitialRaftTruncatedState returns the RaftTruncatedState which
+// WriteInitialReplicaState writes for a new Range.
+func InitialRaftTruncatedState() roachpb.RaftTruncatedState {
+	return roachpb.RaftTruncatedState{
+		Term:  raftInitialLogTerm,
+		Index: raftInitialLogIndex,
+	}
+}
+
 // WriteInitialReplicaState sets up a new Range, but without writing an
 // associated Raft state (which must be written separately via
 // SynthesizeRaftState before instantiating a Replica). The main task is to
@@ -60,10 +69,8 @@ func WriteInitialReplicaState(
 ) (enginepb.MVCCStats, error) {
 	rsl := Make(desc.RangeID)
 	var s kvserverpb.ReplicaState
-	s.TruncatedState = &roachpb.RaftTruncatedState{
-		Term:  raftInitialLogTerm,
-		Index: raftInitialLogIndex,
-	}
+	truncState := InitialRaftTruncatedState()
+	s.TruncatedState = &truncState
 	s.RaftAppliedIndex = s.TruncatedState.Index
 	if writeRaftAppliedIndexTerm {
 		s.RaftAppliedIndexTerm = s.TruncatedState.Term
@@ -43,6 +43,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/raftentry"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangefeed"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantrate"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstorage"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tscache"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnrecovery"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/txnwait"
@@ -705,6 +706,7 @@ type Store struct {
 	cfg             StoreConfig
 	db              *kv.DB
 	engine          storage.Engine // The underlying key-value store
+	raftEngine      storage.Engine // Holds the Raft state of replicas; see RaftEngine()
 	tsCache         tscache.Cache  // Most recent timestamps for keys / key ranges
 	allocator       Allocator      // Makes allocation decisions
 	replRankings    *replicaRankings
@@ -740,12 +742,13 @@ type Store struct {
 	// gossip interval. Updated atomically.
 	gossipRangeCountdown int32
 	gossipLeaseCountdown int32
-	// gossipQueriesPerSecondVal and gossipWritesPerSecond serve similar
-	// purposes, but simply record the most recently gossiped value so that we
-	// can tell if a newly measured value differs by enough to justify
-	// re-gossiping the store.
+	// gossipQueriesPerSecondVal, gossipWritesPerSecond and gossipCPUPerSecondVal
+	// serve similar purposes, but simply record the most recently gossiped
+	// value so that we can tell if a newly measured value differs by enough to
+	// justify re-gossiping the store.
 	gossipQueriesPerSecondVal syncutil.AtomicFloat64
 	gossipWritesPerSecondVal  syncutil.AtomicFloat64
+	gossipCPUPerSecondVal     syncutil.AtomicFloat64
 
 	coalescedMu struct {
 		syncutil.Mutex
@@ -1063,6 +1066,16 @@ type StoreConfig struct {
 	// KVAdmissionController is an optional field used for admission control.
 	KVAdmissionController KVAdmissionController
 
+	// TenantStorage is an optional field used to enforce the storage quotas of
+	// secondary tenants.
+	TenantStorage *tenantstorage.Tracker
+
+	// RaftEngines maps the engine of a store to a dedicated engine holding the
+	// Raft log and the other unreplicated Raft state of its replicas. Stores
+	// whose engine is not in the map keep their Raft state in their engine.
+	// See store_raft_engine.go for details.
+	RaftEngines map[storage.Engine]storage.Engine
+
 	// SystemConfigProvider is used to drive replication decision-making in the
 	// mixed-version state, before the span configuration infrastructure has been
 	// bootstrapped.
@@ -1166,6 +1179,11 @@ func NewStore(
 	}
 	s.replRankings = newReplicaRankings()
 
+	s.raftEngine = eng
+	if raftEng, ok := cfg.RaftEngines[eng]; ok {
+		s.raftEngine = raftEng
+	}
+
 	s.draining.Store(false)
 	s.scheduler = newRaftScheduler(cfg.AmbientCtx, s.metrics, s, storeSchedulerConcurrency)
 
@@ -1801,6 +1819,12 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 		s.cfg.Gossip.NodeID.Set(ctx, s.Ident.NodeID)
 	}
 
+	// Bring the Raft engine in line with the state machine engine before any
+	// replica is loaded. See reconcileRaftEngine.
+	if err := s.reconcileRaftEngine(ctx); err != nil {
+		return err
+	}
+
 	// Create ID allocators.
 	idAlloc, err := idalloc.NewAllocator(idalloc.Options{
 		AmbientCtx:  s.cfg.AmbientCtx,
@@ -2491,6 +2515,7 @@ func (s *Store) GossipStore(ctx context.Context, useCached bool) error {
 	// recursively triggering a gossip of the store capacity.
 	syncutil.StoreFloat64(&s.gossipQueriesPerSecondVal, -1)
 	syncutil.StoreFloat64(&s.gossipWritesPerSecondVal, -1)
+	syncutil.StoreFloat64(&s.gossipCPUPerSecondVal, -1)
 
 	storeDesc, err := s.Descriptor(ctx, useCached)
 	if err != nil {
@@ -2507,6 +2532,7 @@ func (s *Store) GossipStore(ctx context.Context, useCached bool) error {
 	atomic.StoreInt32(&s.gossipLeaseCountdown, int32(math.Ceil(math.Max(leaseCountdown, 1))))
 	syncutil.StoreFloat64(&s.gossipQueriesPerSecondVal, storeDesc.Capacity.QueriesPerSecond)
 	syncutil.StoreFloat64(&s.gossipWritesPerSecondVal, storeDesc.Capacity.WritesPerSecond)
+	syncutil.StoreFloat64(&s.gossipCPUPerSecondVal, storeDesc.Capacity.CPUPerSecond)
 
 	// Unique gossip key per store.
 	gossipStoreKey := gossip.MakeStoreKey(storeDesc.StoreID)
@@ -2557,32 +2583,40 @@ func (s *Store) maybeGossipOnCapacityChange(ctx context.Context, cce capacityCha
 }
 
 // recordNewPerSecondStats takes recently calculated values for the number of
-// queries and key writes the store is handling and decides whether either has
-// changed enough to justify re-gossiping the store's capacity.
-func (s *Store) recordNewPerSecondStats(newQPS, newWPS float64) {
+// queries and key writes the store is handling, as well as the CPU time it is
+// spending on them, and decides whether any has changed enough to justify
+// re-gossiping the store's capacity.
+func (s *Store) recordNewPerSecondStats(newQPS, newWPS, newCPU float64) {
 	oldQPS := syncutil.LoadFloat64(&s.gossipQueriesPerSecondVal)
 	oldWPS := syncutil.LoadFloat64(&s.gossipWritesPerSecondVal)
-	if oldQPS == -1 || oldWPS == -1 {
+	oldCPU := syncutil.LoadFloat64(&s.gossipCPUPerSecondVal)
+	if oldQPS == -1 || oldWPS == -1 || oldCPU == -1 {
 		// Gossiping of store capacity is already ongoing.
 		return
 	}
 
 	const minAbsoluteChange = 100
+	// CPU is measured in nanoseconds per second, so it needs its own absolute
+	// threshold: 5% of a single core.
+	const minAbsoluteCPUChange = float64(50 * time.Millisecond)
 	updateForQPS := (newQPS < oldQPS*.5 || newQPS > oldQPS*1.5) && math.Abs(newQPS-oldQPS) > minAbsoluteChange
 	updateForWPS := (newWPS < oldWPS*.5 || newWPS > oldWPS*1.5) && math.Abs(newWPS-oldWPS) > minAbsoluteChange
+	updateForCPU := (newCPU < oldCPU*.5 || newCPU > oldCPU*1.5) && math.Abs(newCPU-oldCPU) > minAbsoluteCPUChange
 
-	if !updateForQPS && !updateForWPS {
-		return
+	var changed []string
+	if updateForQPS {
+		changed = append(changed, "queries-per-second")
 	}
-
-	var message string
-	if updateForQPS && updateForWPS {
-		message = "queries-per-second and writes-per-second change"
-	} else if updateForQPS {
-		message = "queries-per-second change"
-	} else {
-		message = "writes-per-second change"
+	if updateForWPS {
+		changed = append(changed, "writes-per-second")
 	}
+	if updateForCPU {
+		changed = append(changed, "cpu-per-second")
+	}
+	if len(changed) == 0 {
+		return
+	}
+	message := strings.Join(changed, " and ") + " change"
 	// TODO(a-robinson): Use the provided values to avoid having to recalculate
 	// them in GossipStore.
 	s.asyncGossipStore(context.TODO(), message, false /* useCached */)
@@ -2864,6 +2898,11 @@ func (s *Store) Clock() *hlc.Clock { return s.cfg.Clock }
 // Engine accessor.
 func (s *Store) Engine() storage.Engine { return s.engine }
 
+// RaftEngine accessor. The returned engine holds the Raft log, HardState and
+// TruncatedState of the store's replicas, and is the same as Engine() unless
+// the store has a separate Raft engine.
+func (s *Store) RaftEngine() storage.Engine { return s.raftEngine }
+
 // DB accessor.
 func (s *Store) DB() *kv.DB { return s.cfg.DB }
 
@@ -2925,6 +2964,7 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 	var logicalBytes int64
 	var totalQueriesPerSecond float64
 	var totalWritesPerSecond float64
+	var totalCPUPerSecond float64
 	replicaCount := s.metrics.ReplicaCount.Value()
 	bytesPerReplica := make([]float64, 0, replicaCount)
 	writesPerReplica := make([]float64, 0, replicaCount)
@@ -2951,9 +2991,19 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 			totalWritesPerSecond += wps
 			writesPerReplica = append(writesPerReplica, wps)
 		}
+		var requestCPU, raftCPU float64
+		if cpu, dur := r.requestCPUStats.avgQPS(); dur >= MinStatsDuration {
+			requestCPU = cpu
+		}
+		if cpu, dur := r.raftCPUStats.avgQPS(); dur >= MinStatsDuration {
+			raftCPU = cpu
+		}
+		totalCPUPerSecond += requestCPU + raftCPU
 		rankingsAccumulator.addReplica(replicaWithStats{
-			repl: r,
-			qps:  qps,
+			repl:       r,
+			qps:        qps,
+			requestCPU: requestCPU,
+			raftCPU:    raftCPU,
 		})
 		return true
 	})
@@ -2962,10 +3012,11 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 	capacity.LogicalBytes = logicalBytes
 	capacity.QueriesPerSecond = totalQueriesPerSecond
 	capacity.WritesPerSecond = totalWritesPerSecond
+	capacity.CPUPerSecond = totalCPUPerSecond
 	capacity.ReadAmplification = s.metrics.RdbReadAmplification.Value()
 	capacity.BytesPerReplica = roachpb.PercentilesFromData(bytesPerReplica)
 	capacity.WritesPerReplica = roachpb.PercentilesFromData(writesPerReplica)
-	s.recordNewPerSecondStats(totalQueriesPerSecond, totalWritesPerSecond)
+	s.recordNewPerSecondStats(totalQueriesPerSecond, totalWritesPerSecond, totalCPUPerSecond)
 	s.replRankings.update(rankingsAccumulator)
 
 	s.cachedCapacity.Lock()
@@ -3063,6 +3114,7 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 		uninitializedCount            int64
 		averageQueriesPerSecond       float64
 		averageWritesPerSecond        float64
+		averageCPUNanosPerSecond      float64
 
 		rangeCount                int64
 		unavailableRangeCount     int64
@@ -3080,6 +3132,8 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 		maxLockWaitQueueWaitersForLock int64
 
 		minMaxClosedTS hlc.Timestamp
+
+		tenantLogicalBytes = map[uint64]int64{}
 	)
 
 	now := s.cfg.Clock.NowAsClockTimestamp()
@@ -3110,6 +3164,9 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 			case roachpb.LeaseEpoch:
 				leaseEpochCount++
 			}
+			if tenantID, ok := rep.TenantID(); ok && tenantID != roachpb.SystemTenantID {
+				tenantLogicalBytes[tenantID.ToUint64()] += rep.GetMVCCStats().Total()
+			}
 		}
 		if metrics.Quiescent {
 			quiescentCount++
@@ -3133,6 +3190,12 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 		if wps, dur := rep.writeStats.avgQPS(); dur >= MinStatsDuration {
 			averageWritesPerSecond += wps
 		}
+		if cpu, dur := rep.requestCPUStats.avgQPS(); dur >= MinStatsDuration {
+			averageCPUNanosPerSecond += cpu
+		}
+		if cpu, dur := rep.raftCPUStats.avgQPS(); dur >= MinStatsDuration {
+			averageCPUNanosPerSecond += cpu
+		}
 		locks += metrics.LockTableMetrics.Locks
 		totalLockHoldDurationNanos += metrics.LockTableMetrics.TotalLockHoldDurationNanos
 		locksWithWaitQueues += metrics.LockTableMetrics.LocksWithWaitQueues
@@ -3163,7 +3226,8 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 	s.metrics.UninitializedCount.Update(uninitializedCount)
 	s.metrics.AverageQueriesPerSecond.Update(averageQueriesPerSecond)
 	s.metrics.AverageWritesPerSecond.Update(averageWritesPerSecond)
-	s.recordNewPerSecondStats(averageQueriesPerSecond, averageWritesPerSecond)
+	s.metrics.AverageCPUNanosPerSecond.Update(averageCPUNanosPerSecond)
+	s.recordNewPerSecondStats(averageQueriesPerSecond, averageWritesPerSecond, averageCPUNanosPerSecond)
 
 	s.metrics.RangeCount.Update(rangeCount)
 	s.metrics.UnavailableRangeCount.Update(unavailableRangeCount)
@@ -3196,9 +3260,30 @@ func (s *Store) updateReplicationGauges(ctx context.Context) error {
 
 	s.metrics.RaftEnqueuedPending.Update(s.cfg.Transport.queuedMessageCount())
 
+	s.gossipTenantStorageUsage(ctx, tenantLogicalBytes)
+
 	return nil
 }
 
+// gossipTenantStorageUsage gossips the logical bytes of the secondary tenants'
+// ranges for which this store holds the lease. The usage is aggregated across
+// all stores by the tenantstorage.Tracker to enforce the tenants' storage
+// quotas. The usage is gossiped even if the store holds no such leases, so
+// that the store's previous report is replaced as soon as it loses them.
+func (s *Store) gossipTenantStorageUsage(ctx context.Context, logicalBytes map[uint64]int64) {
+	if s.cfg.Gossip == nil {
+		return
+	}
+	usage := kvserverpb.TenantStorageUsage{
+		StoreID:      s.StoreID(),
+		LogicalBytes: logicalBytes,
+	}
+	key := gossip.MakeTenantStorageUsageKey(s.StoreID())
+	if err := s.cfg.Gossip.AddInfoProto(key, &usage, tenantstorage.UsageGossipTTL); err != nil {
+		log.Warningf(ctx, "unable to gossip tenant storage usage: %+v", err)
+	}
+}
+
 // checkpoint creates a RocksDB checkpoint in the auxiliary directory with the
 // provided tag used in the filepath. The filepath for the checkpoint directory
 // is returned.
@@ -3531,6 +3616,10 @@ func (s *storeForTruncatorImpl) getEngine() storage.Engine {
 	return (*Store)(s).engine
 }
 
+func (s *storeForTruncatorImpl) getRaftEngine() storage.Engine {
+	return (*Store)(s).raftEngine
+}
+
 // WriteClusterVersion writes the given cluster version to the store-local
 // cluster version key. We only accept a raw engine to ensure we're persisting
 // the write durably.
@@ -3668,8 +3757,14 @@ func (n KVAdmissionControllerImpl) AdmitKVWork(
 		// all the slots, causing no useful work to happen. We do want useful work
 		// to continue even when throttling since there are often significant
 		// number of tokens available.
-		if ba.IsWrite() && !isSingleHeartbeatTxnRequest(ba) {
-			ah.storeAdmissionQ = n.storeGrantCoords.TryGetQueueForStore(int32(ba.Replica.StoreID))
+		//
+		// Elastic reads, like the exports done by backups, are subject to the
+		// storeAdmissionQ too, since they consume disk bandwidth (see
+		// admission.KVElasticWork).
+		if (ba.IsWrite() || admission.IsElasticWork(admissionInfo.Priority)) &&
+			!isSingleHeartbeatTxnRequest(ba) {
+			ah.storeAdmissionQ = n.storeGrantCoords.TryGetQueueForStore(
+				int32(ba.Replica.StoreID), admissionInfo.Priority)
 		}
 		admissionEnabled := true
 		if ah.storeAdmissionQ != nil {
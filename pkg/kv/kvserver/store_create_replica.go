@@ -215,7 +215,7 @@ func (s *Store) tryGetOrCreateReplica(
 		// An uninitialized replica should have an empty HardState.Commit at
 		// all times. Failure to maintain this invariant indicates corruption.
 		// And yet, we have observed this in the wild. See #40213.
-		if hs, err := repl.mu.stateLoader.LoadHardState(ctx, s.Engine()); err != nil {
+		if hs, err := repl.mu.stateLoader.LoadHardState(ctx, s.raftEngine); err != nil {
 			return err
 		} else if hs.Commit != 0 {
 			log.Fatalf(ctx, "found non-zero HardState.Commit on uninitialized replica %s. HS=%+v", repl, hs)
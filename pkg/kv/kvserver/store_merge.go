@@ -154,6 +154,12 @@ func (s *Store) MergeRange(
 		// logic that depends on them.
 		leftRepl.writeStats.resetRequestCounts()
 	}
+	if leftRepl.requestCPUStats != nil {
+		leftRepl.requestCPUStats.resetRequestCounts()
+	}
+	if leftRepl.raftCPUStats != nil {
+		leftRepl.raftCPUStats.resetRequestCounts()
+	}
 
 	// Clear the concurrency manager's lock and txn wait-queues to redirect the
 	// queued transactions to the left-hand replica, if necessary.
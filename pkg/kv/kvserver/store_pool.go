@@ -478,6 +478,7 @@ func (sp *StorePool) updateLocalStoreAfterRebalance(
 		detail.desc.Capacity.RangeCount++
 		detail.desc.Capacity.LogicalBytes += rangeUsageInfo.LogicalBytes
 		detail.desc.Capacity.WritesPerSecond += rangeUsageInfo.WritesPerSecond
+		detail.desc.Capacity.CPUPerSecond += rangeUsageInfo.RaftCPUNanosPerSecond
 	case roachpb.REMOVE_VOTER, roachpb.REMOVE_NON_VOTER:
 		detail.desc.Capacity.RangeCount--
 		if detail.desc.Capacity.LogicalBytes <= rangeUsageInfo.LogicalBytes {
@@ -490,6 +491,17 @@ func (sp *StorePool) updateLocalStoreAfterRebalance(
 		} else {
 			detail.desc.Capacity.WritesPerSecond -= rangeUsageInfo.WritesPerSecond
 		}
+		if detail.desc.Capacity.CPUPerSecond <= rangeUsageInfo.RaftCPUNanosPerSecond {
+			detail.desc.Capacity.CPUPerSecond = 0
+		} else {
+			detail.desc.Capacity.CPUPerSecond -= rangeUsageInfo.RaftCPUNanosPerSecond
+		}
+	case roachpb.ADD_WITNESS:
+		// Witnesses don't hold the range's data, nor do they apply its writes,
+		// so only the range count changes.
+		detail.desc.Capacity.RangeCount++
+	case roachpb.REMOVE_WITNESS:
+		detail.desc.Capacity.RangeCount--
 	default:
 		return
 	}
@@ -497,13 +509,16 @@ func (sp *StorePool) updateLocalStoreAfterRebalance(
 }
 
 // updateLocalStoresAfterLeaseTransfer is used to update the local copies of the
-// involved store descriptors immediately after a lease transfer.
+// involved store descriptors immediately after a lease transfer. Only the
+// range's QPS and request evaluation CPU are assumed to move with the lease.
 func (sp *StorePool) updateLocalStoresAfterLeaseTransfer(
-	from roachpb.StoreID, to roachpb.StoreID, rangeQPS float64,
+	from roachpb.StoreID, to roachpb.StoreID, rangeUsageInfo RangeUsageInfo,
 ) {
 	sp.detailsMu.Lock()
 	defer sp.detailsMu.Unlock()
 
+	rangeQPS := rangeUsageInfo.QueriesPerSecond
+	rangeCPU := rangeUsageInfo.RequestCPUNanosPerSecond
 	fromDetail := *sp.getStoreDetailLocked(from)
 	if fromDetail.desc != nil {
 		fromDetail.desc.Capacity.LeaseCount--
@@ -512,6 +527,11 @@ func (sp *StorePool) updateLocalStoresAfterLeaseTransfer(
 		} else {
 			fromDetail.desc.Capacity.QueriesPerSecond -= rangeQPS
 		}
+		if fromDetail.desc.Capacity.CPUPerSecond < rangeCPU {
+			fromDetail.desc.Capacity.CPUPerSecond = 0
+		} else {
+			fromDetail.desc.Capacity.CPUPerSecond -= rangeCPU
+		}
 		sp.detailsMu.storeDetails[from] = &fromDetail
 	}
 
@@ -519,6 +539,7 @@ func (sp *StorePool) updateLocalStoresAfterLeaseTransfer(
 	if toDetail.desc != nil {
 		toDetail.desc.Capacity.LeaseCount++
 		toDetail.desc.Capacity.QueriesPerSecond += rangeQPS
+		toDetail.desc.Capacity.CPUPerSecond += rangeCPU
 		sp.detailsMu.storeDetails[to] = &toDetail
 	}
 }
@@ -756,6 +777,10 @@ type StoreList struct {
 	// eligible to be rebalance targets.
 	candidateWritesPerSecond stat
 
+	// candidateCPU tracks CPU-time-per-second stats for stores that are
+	// eligible to be rebalance targets.
+	candidateCPU stat
+
 	// candidateReadAmplification tracks the read amplification stats for stores that are
 	// eligible to be rebalance targets.
 	candidateReadAmplification stat
@@ -773,6 +798,7 @@ func makeStoreList(descriptors []roachpb.StoreDescriptor) StoreList {
 		sl.candidateLogicalBytes.update(float64(desc.Capacity.LogicalBytes))
 		sl.candidateQueriesPerSecond.update(desc.Capacity.QueriesPerSecond)
 		sl.candidateWritesPerSecond.update(desc.Capacity.WritesPerSecond)
+		sl.candidateCPU.update(desc.Capacity.CPUPerSecond)
 		sl.candidateReadAmplification.update(float64(desc.Capacity.ReadAmplification))
 	}
 	return sl
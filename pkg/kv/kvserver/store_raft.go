@@ -84,6 +84,39 @@ func (s *Store) HandleSnapshot(
 	})
 }
 
+// HandleDelegatedSnapshot sends a snapshot of the requested range to the
+// recipient on behalf of the coordinator that delegated it, and reports the
+// outcome back on the stream.
+func (s *Store) HandleDelegatedSnapshot(
+	ctx context.Context,
+	req *kvserverpb.DelegateSnapshotRequest,
+	stream DelegateSnapshotResponseStream,
+) error {
+	ctx = s.AnnotateCtx(ctx)
+	const name = "storage.Store: handle delegated snapshot"
+	return s.stopper.RunTaskWithErr(ctx, name, func(ctx context.Context) error {
+		sendErr := func() error {
+			if s.IsDraining() {
+				return errors.New(storeDrainingMsg)
+			}
+			sender, err := s.GetReplica(req.RangeID)
+			if err != nil {
+				return err
+			}
+			return sender.generateAndSendSnapshot(ctx, req)
+		}()
+		if sendErr != nil {
+			return stream.Send(&kvserverpb.DelegateSnapshotResponse{
+				Status:  kvserverpb.SnapshotResponse_ERROR,
+				Message: sendErr.Error(),
+			})
+		}
+		return stream.Send(&kvserverpb.DelegateSnapshotResponse{
+			Status: kvserverpb.SnapshotResponse_APPLIED,
+		})
+	})
+}
+
 func (s *Store) uncoalesceBeats(
 	ctx context.Context,
 	beats []kvserverpb.RaftHeartbeat,
@@ -0,0 +1,394 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/stateloader"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+// A store can be configured to keep the Raft state of its replicas, i.e. their
+// HardState, TruncatedState and log entries, in an engine separate from the
+// one holding the state machines (see StoreConfig.RaftEngines). The rest of
+// the unreplicated range ID local state (RaftReplicaID, RangeTombstone and
+// the replica GC timestamp) stays in the state machine engine, and so do the
+// sideloaded entries, which are files in the auxiliary directory of the state
+// machine engine.
+//
+// A write can no longer update both the state machine and the Raft state
+// atomically, so such writes are split into steps that are ordered such that
+// a crash between them leaves a state which Store.Start can reconcile (this
+// follows the design notes in storage/replicas_storage.go):
+//
+// - Log truncations are always loosely coupled (see raftLogTruncator), which
+//   only truncates the log up to the durable applied index of the state
+//   machine.
+//
+// - A replica is initialized (through a snapshot or a split) by first durably
+//   writing its state machine, and then resetting its Raft state to an empty
+//   log whose TruncatedState is at the applied index of the state machine. If
+//   the second step is lost, the Raft state lags behind the state machine,
+//   which is rolled forward by rollForwardRaftState.
+//
+// - A replica is removed (or subsumed by a merge) by first durably removing its
+//   state machine, and then its Raft state. If the second step is lost, the
+//   Raft state of an initialized replica is left without a state machine, and
+//   is removed by reconcileRaftEngine.
+//
+// Once a store has been started with a separate Raft engine, its state machine
+// engine contains a StoreRaftEngineIdentKey, which prevents it from being
+// started without that engine again. The Raft engine in turn holds the
+// StoreIdent of the store it belongs to.
+
+// raftEngineMigrationBatchSize is the size of the batches in which the Raft
+// state of a store is copied to a separate Raft engine when the store is first
+// started with it.
+const raftEngineMigrationBatchSize = 32 << 20 // 32 MiB
+
+// separateRaftEngine returns whether the Raft state of the store's replicas is
+// kept in an engine separate from the state machine engine.
+func (s *Store) separateRaftEngine() bool {
+	return s.raftEngine != s.engine
+}
+
+// isRaftStateKey returns whether the given range ID local key is part of the
+// Raft state which is kept in a separate Raft engine.
+func isRaftStateKey(key roachpb.Key) (roachpb.RangeID, bool, error) {
+	rangeID, _, suffix, _, err := keys.DecodeRangeIDKey(key)
+	if err != nil {
+		return 0, false, err
+	}
+	if !bytes.HasPrefix(key, keys.MakeRangeIDUnreplicatedPrefix(rangeID)) {
+		return rangeID, false, nil
+	}
+	return rangeID, bytes.Equal(suffix, keys.LocalRaftHardStateSuffix) ||
+		bytes.Equal(suffix, keys.LocalRaftTruncatedStateSuffix) ||
+		bytes.Equal(suffix, keys.LocalRaftLogSuffix), nil
+}
+
+// clearRaftEngineState clears the Raft state of the given range from a
+// separate Raft engine. Since the Raft engine holds nothing but Raft state,
+// this clears the entire unreplicated range ID local span.
+func clearRaftEngineState(rangeID roachpb.RangeID, writer storage.Writer) error {
+	prefix := keys.MakeRangeIDUnreplicatedPrefix(rangeID)
+	return writer.ClearRawRange(prefix, prefix.PrefixEnd())
+}
+
+// clearRaftStateFromStateEngine clears the Raft state of the given range from
+// the state machine engine, leaving the rest of its unreplicated state intact.
+func clearRaftStateFromStateEngine(rangeID roachpb.RangeID, writer storage.Writer) error {
+	prefixBuf := keys.MakeRangeIDPrefixBuf(rangeID)
+	if err := writer.ClearUnversioned(prefixBuf.RaftHardStateKey()); err != nil {
+		return err
+	}
+	if err := writer.ClearUnversioned(prefixBuf.RaftTruncatedStateKey()); err != nil {
+		return err
+	}
+	logPrefix := prefixBuf.RaftLogPrefix()
+	return writer.ClearRawRange(logPrefix, logPrefix.PrefixEnd())
+}
+
+// loadReplicaState is like StateLoader.Load, but reads the TruncatedState,
+// which is part of the Raft state, from raftReader.
+func loadReplicaState(
+	ctx context.Context,
+	rsl stateloader.StateLoader,
+	reader, raftReader storage.Reader,
+	desc *roachpb.RangeDescriptor,
+) (kvserverpb.ReplicaState, error) {
+	state, err := rsl.Load(ctx, reader, desc)
+	if err != nil || raftReader == reader {
+		return state, err
+	}
+	truncState, err := rsl.LoadRaftTruncatedState(ctx, raftReader)
+	if err != nil {
+		return kvserverpb.ReplicaState{}, err
+	}
+	state.TruncatedState = &truncState
+	return state, nil
+}
+
+// rollForwardRaftState brings the Raft state of an initialized replica in a
+// separate Raft engine up to date with its state machine. If the log contains
+// the applied index, only the commit index of the HardState may lag behind,
+// since it is not always synced. Otherwise, the state machine was durably
+// written by a snapshot or split but the Raft state was not, so the log is
+// discarded, and the Raft state is reset to an empty log starting after the
+// applied index, retaining the term and vote of the HardState. Returns whether
+// the Raft state was rewritten.
+func rollForwardRaftState(
+	ctx context.Context, rangeID roachpb.RangeID, reader storage.Reader, raftRW storage.ReadWriter,
+) (bool, error) {
+	rsl := stateloader.Make(rangeID)
+	as, err := rsl.LoadRangeAppliedState(ctx, reader)
+	if err != nil {
+		return false, err
+	}
+	hs, err := rsl.LoadHardState(ctx, raftRW)
+	if err != nil {
+		return false, err
+	}
+	truncState, err := rsl.LoadRaftTruncatedState(ctx, raftRW)
+	if err != nil {
+		return false, err
+	}
+	if truncState.Index != 0 && truncState.Index <= as.RaftAppliedIndex {
+		// Find the term of the applied index in the log. NB: a stale log left
+		// behind by a lost snapshot may contain the applied index too, but not
+		// with the term it was applied at.
+		term := truncState.Term
+		if truncState.Index < as.RaftAppliedIndex {
+			var ent raftpb.Entry
+			if _, err := storage.MVCCGetProto(ctx, raftRW, rsl.RaftLogKey(as.RaftAppliedIndex),
+				hlc.Timestamp{}, &ent, storage.MVCCGetOptions{}); err != nil {
+				return false, err
+			}
+			term = ent.Term
+		}
+		if term != 0 && (term == as.RaftAppliedIndexTerm || as.RaftAppliedIndexTerm == 0) {
+			if hs.Commit >= as.RaftAppliedIndex {
+				return false, nil
+			}
+			hs.Commit = as.RaftAppliedIndex
+			return true, rsl.SetHardState(ctx, raftRW, hs)
+		}
+	}
+
+	truncState = roachpb.RaftTruncatedState{
+		Index: as.RaftAppliedIndex,
+		Term:  as.RaftAppliedIndexTerm,
+	}
+	if truncState.Term == 0 {
+		// Ranges created before RaftAppliedIndexTerm was populated only know the
+		// term of their applied index if it is the initial one.
+		initState := stateloader.InitialRaftTruncatedState()
+		if truncState.Index != initState.Index {
+			return false, errors.AssertionFailedf(
+				"r%d: cannot determine the term of applied index %d", rangeID, truncState.Index)
+		}
+		truncState.Term = initState.Term
+	}
+	logPrefix := rsl.RaftLogPrefix()
+	if err := raftRW.ClearRawRange(logPrefix, logPrefix.PrefixEnd()); err != nil {
+		return false, err
+	}
+	if err := rsl.SetRaftTruncatedState(ctx, raftRW, &truncState); err != nil {
+		return false, err
+	}
+	return true, rsl.SynthesizeHardState(ctx, raftRW, hs, truncState, truncState.Index)
+}
+
+// reconcileRaftEngine is called when the store starts, and makes sure that the
+// Raft state of its replicas in a separate Raft engine is consistent with
+// their state machines after a crash. When the store is first started with a
+// separate Raft engine, it also moves the Raft state into it.
+func (s *Store) reconcileRaftEngine(ctx context.Context) error {
+	var markerIdent roachpb.StoreIdent
+	hasMarker, err := storage.MVCCGetProto(ctx, s.engine, keys.StoreRaftEngineIdentKey(),
+		hlc.Timestamp{}, &markerIdent, storage.MVCCGetOptions{})
+	if err != nil {
+		return err
+	}
+	if !s.separateRaftEngine() {
+		if hasMarker {
+			return errors.Errorf("%s keeps its Raft log in a separate engine, "+
+				"whose path must be specified with the raft-log-path field of --store", s.Ident)
+		}
+		return nil
+	}
+
+	var raftIdent roachpb.StoreIdent
+	hasRaftIdent, err := storage.MVCCGetProto(ctx, s.raftEngine, keys.StoreIdentKey(),
+		hlc.Timestamp{}, &raftIdent, storage.MVCCGetOptions{})
+	if err != nil {
+		return err
+	}
+	if hasRaftIdent && raftIdent != *s.Ident {
+		return errors.Errorf("the Raft engine of %s belongs to %s", s.Ident, raftIdent)
+	}
+	if !hasMarker {
+		return s.moveRaftStateToRaftEngine(ctx)
+	}
+	if !hasRaftIdent {
+		return errors.Errorf("the Raft engine of %s is empty, "+
+			"but the store keeps its Raft log in a separate engine", s.Ident)
+	}
+	if markerIdent != *s.Ident {
+		return errors.AssertionFailedf("%s is marked as %s", s.Ident, markerIdent)
+	}
+
+	// Collect the ranges which have Raft state, and the initialized ones.
+	raftRangeIDs, err := raftEngineRangeIDs(s.raftEngine)
+	if err != nil {
+		return err
+	}
+	initialized := map[roachpb.RangeID]struct{}{}
+	if err := IterateRangeDescriptorsFromDisk(ctx, s.engine, func(desc roachpb.RangeDescriptor) error {
+		initialized[desc.RangeID] = struct{}{}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	batch := s.raftEngine.NewBatch()
+	defer batch.Close()
+	var rolledForward, removed int
+	for rangeID := range initialized {
+		ok, err := rollForwardRaftState(ctx, rangeID, s.engine, batch)
+		if err != nil {
+			return err
+		}
+		if ok {
+			rolledForward++
+		}
+	}
+	for _, rangeID := range raftRangeIDs {
+		if _, ok := initialized[rangeID]; ok {
+			continue
+		}
+		// The replica has no state machine. If it has a TruncatedState, it was
+		// initialized and has since been removed, but the removal of its Raft
+		// state was lost. Otherwise, it is uninitialized, and its HardState must
+		// be retained.
+		truncState, err := stateloader.Make(rangeID).LoadRaftTruncatedState(ctx, batch)
+		if err != nil {
+			return err
+		}
+		if truncState.Index == 0 {
+			continue
+		}
+		if err := clearRaftEngineState(rangeID, batch); err != nil {
+			return err
+		}
+		removed++
+	}
+	if batch.Empty() {
+		return nil
+	}
+	log.Infof(ctx, "reconciled Raft engine: rolled forward %d replicas, removed %d replicas",
+		rolledForward, removed)
+	return batch.Commit(true /* sync */)
+}
+
+// raftEngineRangeIDs returns the IDs of the ranges which have Raft state in the
+// given Raft engine, in ascending order.
+func raftEngineRangeIDs(raftEngine storage.Reader) ([]roachpb.RangeID, error) {
+	start, end := keys.LocalRangeIDPrefix.AsRawKey(), keys.LocalRangeIDPrefix.AsRawKey().PrefixEnd()
+	iter := raftEngine.NewEngineIterator(storage.IterOptions{LowerBound: start, UpperBound: end})
+	defer iter.Close()
+
+	var rangeIDs []roachpb.RangeID
+	valid, err := iter.SeekEngineKeyGE(storage.EngineKey{Key: start})
+	for ; valid; valid, err = iter.SeekEngineKeyGE(storage.EngineKey{Key: keys.MakeRangeIDPrefix(
+		rangeIDs[len(rangeIDs)-1] + 1)}) {
+		key, err := iter.UnsafeEngineKey()
+		if err != nil {
+			return nil, err
+		}
+		rangeID, _, _, _, err := keys.DecodeRangeIDKey(key.Key)
+		if err != nil {
+			return nil, err
+		}
+		rangeIDs = append(rangeIDs, rangeID)
+	}
+	return rangeIDs, err
+}
+
+// moveRaftStateToRaftEngine moves the Raft state of all replicas from the state
+// machine engine to the separate Raft engine, the first time the store is
+// started with one. The Raft state is first durably copied to the Raft engine,
+// and then removed from the state machine engine in the same batch that marks
+// the store as having a separate Raft engine. A crash before the second step
+// leaves the store as it was, and the move is redone on the next start.
+func (s *Store) moveRaftStateToRaftEngine(ctx context.Context) error {
+	log.Infof(ctx, "moving Raft state to separate Raft engine")
+
+	raftBatch := s.raftEngine.NewUnindexedBatch(true /* writeOnly */)
+	defer func() { raftBatch.Close() }()
+	if err := storage.MVCCBlindPutProto(
+		ctx, raftBatch, nil /* ms */, keys.StoreIdentKey(), hlc.Timestamp{}, s.Ident, nil, /* txn */
+	); err != nil {
+		return err
+	}
+
+	var rangeIDs []roachpb.RangeID
+	var size int64
+	if err := func() error {
+		start, end := keys.LocalRangeIDPrefix.AsRawKey(), keys.LocalRangeIDPrefix.AsRawKey().PrefixEnd()
+		iter := s.engine.NewEngineIterator(storage.IterOptions{LowerBound: start, UpperBound: end})
+		defer iter.Close()
+		valid, err := iter.SeekEngineKeyGE(storage.EngineKey{Key: start})
+		for ; valid; valid, err = iter.NextEngineKey() {
+			key, err := iter.UnsafeEngineKey()
+			if err != nil {
+				return err
+			}
+			rangeID, ok, err := isRaftStateKey(key.Key)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if n := len(rangeIDs); n == 0 || rangeIDs[n-1] != rangeID {
+				rangeIDs = append(rangeIDs, rangeID)
+			}
+			if err := raftBatch.PutEngineKey(key, iter.UnsafeValue()); err != nil {
+				return err
+			}
+			size += int64(len(key.Key) + len(iter.UnsafeValue()))
+			if raftBatch.Len() >= raftEngineMigrationBatchSize {
+				if err := raftBatch.Commit(false /* sync */); err != nil {
+					return err
+				}
+				raftBatch.Close()
+				raftBatch = s.raftEngine.NewUnindexedBatch(true /* writeOnly */)
+			}
+		}
+		return err
+	}(); err != nil {
+		return err
+	}
+	// Syncing the last batch also makes the preceding ones durable.
+	if err := raftBatch.Commit(true /* sync */); err != nil {
+		return err
+	}
+
+	stateBatch := s.engine.NewUnindexedBatch(true /* writeOnly */)
+	defer stateBatch.Close()
+	for _, rangeID := range rangeIDs {
+		if err := clearRaftStateFromStateEngine(rangeID, stateBatch); err != nil {
+			return err
+		}
+	}
+	if err := storage.MVCCBlindPutProto(
+		ctx, stateBatch, nil /* ms */, keys.StoreRaftEngineIdentKey(), hlc.Timestamp{}, s.Ident, nil, /* txn */
+	); err != nil {
+		return err
+	}
+	if err := stateBatch.Commit(true /* sync */); err != nil {
+		return err
+	}
+	log.Infof(ctx, "moved Raft state of %d ranges (%s) to separate Raft engine",
+		len(rangeIDs), humanizeutil.IBytes(size))
+	return nil
+}
@@ -0,0 +1,274 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/stateloader"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestIsRaftStateKey(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const rangeID = roachpb.RangeID(7)
+	for _, tc := range []struct {
+		key roachpb.Key
+		exp bool
+	}{
+		{keys.RaftHardStateKey(rangeID), true},
+		{keys.RaftTruncatedStateKey(rangeID), true},
+		{keys.RaftLogKey(rangeID, 12), true},
+		{keys.RaftReplicaIDKey(rangeID), false},
+		{keys.RangeTombstoneKey(rangeID), false},
+		{keys.RangeLastReplicaGCTimestampKey(rangeID), false},
+		{keys.RangeAppliedStateKey(rangeID), false},
+		{keys.RangeLeaseKey(rangeID), false},
+	} {
+		t.Run(tc.key.String(), func(t *testing.T) {
+			gotRangeID, ok, err := isRaftStateKey(tc.key)
+			require.NoError(t, err)
+			require.Equal(t, rangeID, gotRangeID)
+			require.Equal(t, tc.exp, ok)
+		})
+	}
+}
+
+// writeRaftLogForTesting writes log entries with the given term at the given
+// indexes, along with the TruncatedState preceding them and a HardState.
+func writeRaftLogForTesting(
+	t *testing.T,
+	rw storage.ReadWriter,
+	rangeID roachpb.RangeID,
+	truncState roachpb.RaftTruncatedState,
+	hs raftpb.HardState,
+	lastIndex, term uint64,
+) {
+	ctx := context.Background()
+	rsl := stateloader.Make(rangeID)
+	require.NoError(t, rsl.SetRaftTruncatedState(ctx, rw, &truncState))
+	require.NoError(t, rsl.SetHardState(ctx, rw, hs))
+	for i := truncState.Index + 1; i <= lastIndex; i++ {
+		ent := raftpb.Entry{Index: i, Term: term}
+		require.NoError(t, storage.MVCCPutProto(
+			ctx, rw, nil /* ms */, keys.RaftLogKey(rangeID, i), hlc.Timestamp{}, nil /* txn */, &ent))
+	}
+}
+
+func TestRollForwardRaftState(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	const rangeID = roachpb.RangeID(3)
+	rsl := stateloader.Make(rangeID)
+
+	for _, tc := range []struct {
+		name string
+		// setup writes the Raft state, if any, to the Raft engine. The state
+		// machine is always at applied index 20, term 6.
+		setup          func(t *testing.T, raftEng storage.Engine)
+		expRolled      bool
+		expTruncState  roachpb.RaftTruncatedState
+		expHardState   raftpb.HardState
+		expLastIndex   uint64
+		expEntryAtTerm uint64
+	}{
+		{
+			name: "missing",
+			setup: func(t *testing.T, raftEng storage.Engine) {
+				// An uninitialized replica which has voted, e.g. the RHS of a split.
+				require.NoError(t, rsl.SetHardState(ctx, raftEng, raftpb.HardState{Term: 4, Vote: 2}))
+			},
+			expRolled:     true,
+			expTruncState: roachpb.RaftTruncatedState{Index: 20, Term: 6},
+			expHardState:  raftpb.HardState{Term: 6, Commit: 20},
+			expLastIndex:  20,
+		},
+		{
+			name: "up-to-date",
+			setup: func(t *testing.T, raftEng storage.Engine) {
+				writeRaftLogForTesting(t, raftEng, rangeID, roachpb.RaftTruncatedState{Index: 14, Term: 6},
+					raftpb.HardState{Term: 6, Vote: 1, Commit: 22}, 25, 6)
+			},
+			expRolled:      false,
+			expTruncState:  roachpb.RaftTruncatedState{Index: 14, Term: 6},
+			expHardState:   raftpb.HardState{Term: 6, Vote: 1, Commit: 22},
+			expLastIndex:   25,
+			expEntryAtTerm: 6,
+		},
+		{
+			name: "commit-lagging",
+			setup: func(t *testing.T, raftEng storage.Engine) {
+				writeRaftLogForTesting(t, raftEng, rangeID, roachpb.RaftTruncatedState{Index: 14, Term: 6},
+					raftpb.HardState{Term: 6, Vote: 1, Commit: 18}, 25, 6)
+			},
+			expRolled:      true,
+			expTruncState:  roachpb.RaftTruncatedState{Index: 14, Term: 6},
+			expHardState:   raftpb.HardState{Term: 6, Vote: 1, Commit: 20},
+			expLastIndex:   25,
+			expEntryAtTerm: 6,
+		},
+		{
+			name: "truncated-at-applied",
+			setup: func(t *testing.T, raftEng storage.Engine) {
+				writeRaftLogForTesting(t, raftEng, rangeID, roachpb.RaftTruncatedState{Index: 20, Term: 6},
+					raftpb.HardState{Term: 6, Vote: 1, Commit: 20}, 20, 6)
+			},
+			expRolled:     false,
+			expTruncState: roachpb.RaftTruncatedState{Index: 20, Term: 6},
+			expHardState:  raftpb.HardState{Term: 6, Vote: 1, Commit: 20},
+			expLastIndex:  20,
+		},
+		{
+			name: "stale-log",
+			setup: func(t *testing.T, raftEng storage.Engine) {
+				// The log which preceded a snapshot whose Raft state was lost. It
+				// contains the applied index, but at a different term.
+				writeRaftLogForTesting(t, raftEng, rangeID, roachpb.RaftTruncatedState{Index: 10, Term: 5},
+					raftpb.HardState{Term: 5, Vote: 3, Commit: 12}, 24, 5)
+			},
+			expRolled:     true,
+			expTruncState: roachpb.RaftTruncatedState{Index: 20, Term: 6},
+			expHardState:  raftpb.HardState{Term: 6, Commit: 20},
+			expLastIndex:  20,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			eng := storage.NewDefaultInMemForTesting()
+			defer eng.Close()
+			raftEng := storage.NewDefaultInMemForTesting()
+			defer raftEng.Close()
+
+			require.NoError(t, rsl.SetRangeAppliedState(
+				ctx, eng, 20 /* appliedIndex */, 5 /* leaseAppliedIndex */, 6, /* appliedIndexTerm */
+				&enginepb.MVCCStats{}, nil /* raftClosedTimestamp */))
+			tc.setup(t, raftEng)
+
+			batch := raftEng.NewBatch()
+			defer batch.Close()
+			rolled, err := rollForwardRaftState(ctx, rangeID, eng, batch)
+			require.NoError(t, err)
+			require.Equal(t, tc.expRolled, rolled)
+			require.NoError(t, batch.Commit(false /* sync */))
+
+			truncState, err := rsl.LoadRaftTruncatedState(ctx, raftEng)
+			require.NoError(t, err)
+			require.Equal(t, tc.expTruncState, truncState)
+			hs, err := rsl.LoadHardState(ctx, raftEng)
+			require.NoError(t, err)
+			require.Equal(t, tc.expHardState, hs)
+			lastIndex, err := rsl.LoadLastIndex(ctx, raftEng)
+			require.NoError(t, err)
+			require.Equal(t, tc.expLastIndex, lastIndex)
+			if tc.expEntryAtTerm != 0 {
+				var ent raftpb.Entry
+				ok, err := storage.MVCCGetProto(ctx, raftEng, keys.RaftLogKey(rangeID, 20),
+					hlc.Timestamp{}, &ent, storage.MVCCGetOptions{})
+				require.NoError(t, err)
+				require.True(t, ok)
+				require.Equal(t, tc.expEntryAtTerm, ent.Term)
+			}
+		})
+	}
+}
+
+func TestStoreReconcileRaftEngine(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	eng := storage.NewDefaultInMemForTesting()
+	defer eng.Close()
+	raftEng := storage.NewDefaultInMemForTesting()
+	defer raftEng.Close()
+
+	ident := roachpb.StoreIdent{NodeID: 1, StoreID: 1}
+	require.NoError(t, storage.MVCCPutProto(
+		ctx, eng, nil /* ms */, keys.StoreIdentKey(), hlc.Timestamp{}, nil /* txn */, &ident))
+
+	// r1 is initialized and has a Raft log in the state machine engine.
+	desc := roachpb.RangeDescriptor{
+		RangeID:  1,
+		StartKey: roachpb.RKeyMin,
+		EndKey:   roachpb.RKeyMax,
+		InternalReplicas: []roachpb.ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+		},
+		NextReplicaID: 2,
+	}
+	require.NoError(t, storage.MVCCPutProto(ctx, eng, nil /* ms */, keys.RangeDescriptorKey(desc.StartKey),
+		hlc.Timestamp{WallTime: 1}, nil /* txn */, &desc))
+	rsl := stateloader.Make(desc.RangeID)
+	require.NoError(t, rsl.SetRangeAppliedState(
+		ctx, eng, 20 /* appliedIndex */, 5 /* leaseAppliedIndex */, 6, /* appliedIndexTerm */
+		&enginepb.MVCCStats{}, nil /* raftClosedTimestamp */))
+	require.NoError(t, rsl.SetRaftReplicaID(ctx, eng, 1))
+	writeRaftLogForTesting(t, eng, desc.RangeID, roachpb.RaftTruncatedState{Index: 14, Term: 6},
+		raftpb.HardState{Term: 6, Vote: 1, Commit: 20}, 25, 6)
+
+	s := &Store{engine: eng, raftEngine: raftEng, Ident: &ident}
+	require.True(t, s.separateRaftEngine())
+
+	// The first start moves the Raft state into the Raft engine, and leaves the
+	// rest of the unreplicated state behind.
+	require.NoError(t, s.reconcileRaftEngine(ctx))
+	for _, reader := range []storage.Reader{eng, raftEng} {
+		lastIndex, err := rsl.LoadLastIndex(ctx, reader)
+		require.NoError(t, err)
+		hs, err := rsl.LoadHardState(ctx, reader)
+		require.NoError(t, err)
+		if reader == eng {
+			// NB: LoadLastIndex falls back to the TruncatedState, which is gone.
+			require.Zero(t, lastIndex)
+			require.Equal(t, raftpb.HardState{}, hs)
+		} else {
+			require.EqualValues(t, 25, lastIndex)
+			require.Equal(t, raftpb.HardState{Term: 6, Vote: 1, Commit: 20}, hs)
+		}
+	}
+	replicaID, found, err := rsl.LoadRaftReplicaID(ctx, eng)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 1, replicaID.ReplicaID)
+	var marker roachpb.StoreIdent
+	ok, err := storage.MVCCGetProto(ctx, eng, keys.StoreRaftEngineIdentKey(),
+		hlc.Timestamp{}, &marker, storage.MVCCGetOptions{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, ident, marker)
+
+	// r2 was removed, but the removal of its Raft state was lost. r3 is an
+	// uninitialized replica which has voted.
+	writeRaftLogForTesting(t, raftEng, 2, roachpb.RaftTruncatedState{Index: 10, Term: 5},
+		raftpb.HardState{Term: 5, Commit: 12}, 12, 5)
+	require.NoError(t, stateloader.Make(3).SetHardState(ctx, raftEng, raftpb.HardState{Term: 2, Vote: 1}))
+
+	require.NoError(t, s.reconcileRaftEngine(ctx))
+	rangeIDs, err := raftEngineRangeIDs(raftEng)
+	require.NoError(t, err)
+	require.Equal(t, []roachpb.RangeID{1, 3}, rangeIDs)
+
+	// Restarting without the Raft engine is refused.
+	s.raftEngine = eng
+	require.Error(t, s.reconcileRaftEngine(ctx))
+}
@@ -12,18 +12,22 @@ package kvserver
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"math/rand"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
+	"github.com/cockroachdb/cockroach/pkg/util/grunning"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/redact"
 	"go.etcd.io/etcd/raft/v3"
 )
 
@@ -39,6 +43,12 @@ const (
 	// threshold. This avoids too many lease transfers / range rebalances in
 	// lightly loaded clusters.
 	minQPSThresholdDifference = 100
+
+	// minCPUThresholdDifference is the analog of minQPSThresholdDifference for
+	// CPU-based rebalancing, in nanoseconds of CPU time per second. We won't
+	// worry about rebalancing for CPU reasons if a store's CPU usage differs
+	// from the mean by less than a tenth of a core.
+	minCPUThresholdDifference = float64(100 * time.Millisecond)
 )
 
 var (
@@ -75,7 +85,7 @@ func makeStoreRebalancerMetrics() StoreRebalancerMetrics {
 var LoadBasedRebalancingMode = settings.RegisterEnumSetting(
 	settings.SystemOnly,
 	"kv.allocator.load_based_rebalancing",
-	"whether to rebalance based on the distribution of QPS across stores",
+	"whether to rebalance based on the distribution of load across stores",
 	"leases and replicas",
 	map[int64]string{
 		int64(LBRebalancingOff):               "off",
@@ -124,6 +134,135 @@ var minQPSDifferenceForTransfers = func() *settings.FloatSetting {
 	return s
 }()
 
+// minCPUDifferenceForTransfers is the analog of minQPSDifferenceForTransfers
+// for CPU-based rebalancing.
+var minCPUDifferenceForTransfers = func() *settings.DurationSetting {
+	s := settings.RegisterDurationSetting(
+		settings.SystemOnly,
+		"kv.allocator.min_cpu_difference_for_transfers",
+		"the minimum difference in CPU time per second that must exist between any"+
+			" two stores for the allocator to allow a lease or replica transfer between them",
+		2*time.Duration(minCPUThresholdDifference),
+		settings.NonNegativeDuration,
+	)
+	s.SetVisibility(settings.Reserved)
+	return s
+}()
+
+// cpuRebalanceThreshold is the analog of qpsRebalanceThreshold for CPU-based
+// rebalancing.
+var cpuRebalanceThreshold = func() *settings.FloatSetting {
+	s := settings.RegisterFloatSetting(
+		settings.SystemOnly,
+		"kv.allocator.cpu_rebalance_threshold",
+		"minimum fraction away from the mean a store's CPU usage can be before it is considered overfull or underfull",
+		0.1,
+		settings.NonNegativeFloat,
+	)
+	s.SetVisibility(settings.Public)
+	return s
+}()
+
+// LoadBasedRebalancingObjective controls which load dimension the allocator
+// and the store rebalancer balance across stores, and which load dimension
+// load-based splitting uses to decide when to split a range.
+var LoadBasedRebalancingObjective = settings.RegisterEnumSetting(
+	settings.SystemOnly,
+	"kv.allocator.load_based_rebalancing.objective",
+	"what to balance across stores when rebalancing based on load, and what to "+
+		"measure when splitting ranges based on load; cpu is only used if it can "+
+		"be measured on this platform and otherwise falls back to qps",
+	"qps",
+	map[int64]string{
+		int64(LBRebalancingQueries): "qps",
+		int64(LBRebalancingCPU):     "cpu",
+	},
+).WithPublic()
+
+// LBRebalancingObjective is the load dimension that load-based rebalancing
+// and splitting act on.
+type LBRebalancingObjective int64
+
+const (
+	// LBRebalancingQueries balances the number of batch requests received per
+	// second by each store.
+	LBRebalancingQueries LBRebalancingObjective = iota
+	// LBRebalancingCPU balances the CPU time spent per second by each store
+	// evaluating requests and applying Raft commands.
+	LBRebalancingCPU
+)
+
+// ResolveLBRebalancingObjective returns the load-based rebalancing objective
+// in effect. The CPU objective is only honored once all nodes in the cluster
+// gossip their CPU usage, and only if CPU time can be measured on this
+// platform; QPS is used otherwise.
+func ResolveLBRebalancingObjective(
+	ctx context.Context, st *cluster.Settings,
+) LBRebalancingObjective {
+	objective := LBRebalancingObjective(LoadBasedRebalancingObjective.Get(&st.SV))
+	if objective == LBRebalancingCPU &&
+		(!grunning.Supported() || !st.Version.IsActive(ctx, clusterversion.CPUBasedRebalancing)) {
+		return LBRebalancingQueries
+	}
+	return objective
+}
+
+// String implements the fmt.Stringer interface.
+func (o LBRebalancingObjective) String() string {
+	switch o {
+	case LBRebalancingQueries:
+		return "qps"
+	case LBRebalancingCPU:
+		return "cpu"
+	default:
+		return fmt.Sprintf("LBRebalancingObjective(%d)", int64(o))
+	}
+}
+
+// storeLoad returns the load of the store described by the given capacity.
+func (o LBRebalancingObjective) storeLoad(sc roachpb.StoreCapacity) float64 {
+	if o == LBRebalancingCPU {
+		return sc.CPUPerSecond
+	}
+	return sc.QueriesPerSecond
+}
+
+// meanLoad returns the mean load of the candidate stores in the given list.
+func (o LBRebalancingObjective) meanLoad(sl StoreList) float64 {
+	if o == LBRebalancingCPU {
+		return sl.candidateCPU.mean
+	}
+	return sl.candidateQueriesPerSecond.mean
+}
+
+// minThresholdDifference returns the minimum difference from the mean that a
+// store's load needs to exhibit for it to be considered overfull or
+// underfull.
+func (o LBRebalancingObjective) minThresholdDifference() float64 {
+	if o == LBRebalancingCPU {
+		return minCPUThresholdDifference
+	}
+	return minQPSThresholdDifference
+}
+
+// maxTransferOvershoot returns the amount of load by which a replica rebalance
+// is allowed to make the receiving store hotter than the sending store. See
+// maxQPSTransferOvershoot.
+func (o LBRebalancingObjective) maxTransferOvershoot() float64 {
+	if o == LBRebalancingCPU {
+		return maxCPUTransferOvershoot
+	}
+	return maxQPSTransferOvershoot
+}
+
+// format formats the given load for logging.
+func (o LBRebalancingObjective) format(load float64) redact.SafeString {
+	if o == LBRebalancingCPU {
+		return redact.SafeString(fmt.Sprintf("%s/s cpu", time.Duration(load)))
+	}
+	return redact.SafeString(fmt.Sprintf("%.2f qps", load))
+}
+
 // LBRebalancingMode controls if and when we do store-level rebalancing
 // based on load.
 type LBRebalancingMode int64
@@ -133,10 +272,10 @@ const (
 	// based on load statistics.
 	LBRebalancingOff LBRebalancingMode = iota
 	// LBRebalancingLeasesOnly means that we rebalance leases based on
-	// store-level QPS imbalances.
+	// store-level load imbalances.
 	LBRebalancingLeasesOnly
 	// LBRebalancingLeasesAndReplicas means that we rebalance both leases and
-	// replicas based on store-level QPS imbalances.
+	// replicas based on store-level load imbalances.
 	LBRebalancingLeasesAndReplicas
 )
 
@@ -221,39 +360,45 @@ func (sr *StoreRebalancer) Start(ctx context.Context, stopper *stop.Stopper) {
 				continue
 			}
 
+			objective := ResolveLBRebalancingObjective(ctx, sr.st)
 			storeList, _, _ := sr.rq.allocator.storePool.getStoreList(storeFilterSuspect)
-			sr.rebalanceStore(ctx, mode, storeList)
+			sr.rebalanceStore(ctx, mode, objective, storeList)
 		}
 	})
 }
 
-// NB: The StoreRebalancer only cares about the convergence of QPS across
-// stores, not the convergence of range count. So, we don't use the allocator's
-// `scorerOptions` here, which sets the range count rebalance threshold.
-// Instead, we use our own implementation of `scorerOptions` that promotes QPS
-// balance.
-func (sr *StoreRebalancer) scorerOptions() *qpsScorerOptions {
-	return &qpsScorerOptions{
-		deterministic:         sr.rq.allocator.storePool.deterministic,
-		qpsRebalanceThreshold: qpsRebalanceThreshold.Get(&sr.st.SV),
-		minRequiredQPSDiff:    minQPSDifferenceForTransfers.Get(&sr.st.SV),
+// NB: The StoreRebalancer only cares about the convergence of load (QPS or
+// CPU, depending on the objective) across stores, not the convergence of range
+// count. So, we don't use the allocator's `scorerOptions` here, which sets the
+// range count rebalance threshold. Instead, we use our own implementation of
+// `scorerOptions` that promotes load balance.
+func (sr *StoreRebalancer) scorerOptions(objective LBRebalancingObjective) *loadScorerOptions {
+	rebalanceThreshold, minRequiredDiff := sr.rq.allocator.loadRebalanceThresholds(objective)
+	return &loadScorerOptions{
+		deterministic:      sr.rq.allocator.storePool.deterministic,
+		objective:          objective,
+		rebalanceThreshold: rebalanceThreshold,
+		minRequiredDiff:    minRequiredDiff,
 	}
 }
 
 // rebalanceStore iterates through the top K hottest ranges on this store and
 // for each such range, performs a lease transfer if it determines that that
-// will improve QPS balance across the stores in the cluster. After it runs out
+// will improve load balance across the stores in the cluster. After it runs out
 // of leases to transfer away (i.e. because it couldn't find better
 // replacements), it considers these ranges for replica rebalancing.
 //
 // TODO(aayush): We don't try to move replicas or leases away from the local
-// store unless it is fielding more than the overfull threshold of QPS based off
-// of all the stores in the cluster. Is this desirable? Should we be more
+// store unless it is fielding more than the overfull threshold of load based
+// off of all the stores in the cluster. Is this desirable? Should we be more
 // aggressive?
 func (sr *StoreRebalancer) rebalanceStore(
-	ctx context.Context, mode LBRebalancingMode, allStoresList StoreList,
+	ctx context.Context,
+	mode LBRebalancingMode,
+	objective LBRebalancingObjective,
+	allStoresList StoreList,
 ) {
-	options := sr.scorerOptions()
+	options := sr.scorerOptions(objective)
 	var localDesc *roachpb.StoreDescriptor
 	for i := range allStoresList.stores {
 		if allStoresList.stores[i].StoreID == sr.rq.store.StoreID() {
@@ -267,29 +412,33 @@ func (sr *StoreRebalancer) rebalanceStore(
 	}
 
 	// We only bother rebalancing stores that are fielding more than the
-	// cluster-level overfull threshold of QPS.
-	qpsMaxThreshold := overfullQPSThreshold(options, allStoresList.candidateQueriesPerSecond.mean)
-	if !(localDesc.Capacity.QueriesPerSecond > qpsMaxThreshold) {
-		log.Infof(ctx, "local QPS %.2f is below max threshold %.2f (mean=%.2f); no rebalancing needed",
-			localDesc.Capacity.QueriesPerSecond, qpsMaxThreshold, allStoresList.candidateQueriesPerSecond.mean)
+	// cluster-level overfull threshold of load.
+	meanLoad := objective.meanLoad(allStoresList)
+	maxThreshold := overfullLoadThreshold(options, meanLoad)
+	localLoad := func() float64 { return objective.storeLoad(localDesc.Capacity) }
+	if !(localLoad() > maxThreshold) {
+		log.Infof(ctx, "local load %s is below max threshold %s (mean=%s); no rebalancing needed",
+			objective.format(localLoad()), objective.format(maxThreshold), objective.format(meanLoad))
 		return
 	}
 
 	var replicasToMaybeRebalance []replicaWithStats
 	storeMap := storeListToMap(allStoresList)
 
-	// First check if we should transfer leases away to better balance QPS.
+	// First check if we should transfer leases away to better balance load.
 	log.Infof(ctx,
-		"considering load-based lease transfers for s%d with %.2f qps (mean=%.2f, upperThreshold=%.2f)",
-		localDesc.StoreID, localDesc.Capacity.QueriesPerSecond, allStoresList.candidateQueriesPerSecond.mean, qpsMaxThreshold)
-	hottestRanges := sr.replRankings.topQPS()
-	for localDesc.Capacity.QueriesPerSecond > qpsMaxThreshold {
+		"considering load-based lease transfers for s%d with %s (mean=%s, upperThreshold=%s)",
+		localDesc.StoreID, objective.format(localLoad()), objective.format(meanLoad),
+		objective.format(maxThreshold))
+	hottestRanges := sr.replRankings.topLoad(objective)
+	for localLoad() > maxThreshold {
 		replWithStats, target, considerForRebalance := sr.chooseLeaseToTransfer(
 			ctx,
 			&hottestRanges,
 			localDesc,
 			allStoresList,
 			storeMap,
+			objective,
 		)
 		replicasToMaybeRebalance = append(replicasToMaybeRebalance, considerForRebalance...)
 		if replWithStats.repl == nil {
@@ -298,7 +447,10 @@ func (sr *StoreRebalancer) rebalanceStore(
 
 		timeout := sr.rq.processTimeoutFunc(sr.st, replWithStats.repl)
 		if err := contextutil.RunWithTimeout(ctx, "transfer lease", timeout, func(ctx context.Context) error {
-			return sr.rq.transferLease(ctx, replWithStats.repl, target, replWithStats.qps)
+			return sr.rq.transferLease(ctx, replWithStats.repl, target, RangeUsageInfo{
+				QueriesPerSecond:         replWithStats.qps,
+				RequestCPUNanosPerSecond: replWithStats.requestCPU,
+			})
 		}); err != nil {
 			log.Errorf(ctx, "unable to transfer lease to s%d: %+v", target.StoreID, err)
 			continue
@@ -310,45 +462,48 @@ func (sr *StoreRebalancer) rebalanceStore(
 		// up-to-date info. The StorePool copies are updated by transferLease.
 		localDesc.Capacity.LeaseCount--
 		localDesc.Capacity.QueriesPerSecond -= replWithStats.qps
+		localDesc.Capacity.CPUPerSecond -= replWithStats.requestCPU
 		if otherDesc := storeMap[target.StoreID]; otherDesc != nil {
 			otherDesc.Capacity.LeaseCount++
 			otherDesc.Capacity.QueriesPerSecond += replWithStats.qps
+			otherDesc.Capacity.CPUPerSecond += replWithStats.requestCPU
 		}
 	}
 
-	if !(localDesc.Capacity.QueriesPerSecond > qpsMaxThreshold) {
+	if !(localLoad() > maxThreshold) {
 		log.Infof(ctx,
-			"load-based lease transfers successfully brought s%d down to %.2f qps (mean=%.2f, upperThreshold=%.2f)",
-			localDesc.StoreID, localDesc.Capacity.QueriesPerSecond, allStoresList.candidateQueriesPerSecond.mean, qpsMaxThreshold)
+			"load-based lease transfers successfully brought s%d down to %s (mean=%s, upperThreshold=%s)",
+			localDesc.StoreID, objective.format(localLoad()), objective.format(meanLoad),
+			objective.format(maxThreshold))
 		return
 	}
 
 	if mode != LBRebalancingLeasesAndReplicas {
 		log.Infof(ctx,
-			"ran out of leases worth transferring and qps (%.2f) is still above desired threshold (%.2f)",
-			localDesc.Capacity.QueriesPerSecond, qpsMaxThreshold)
+			"ran out of leases worth transferring and load (%s) is still above desired threshold (%s)",
+			objective.format(localLoad()), objective.format(maxThreshold))
 		return
 	}
 	log.Infof(ctx,
-		"ran out of leases worth transferring and qps (%.2f) is still above desired threshold (%.2f); considering load-based replica rebalances",
-		localDesc.Capacity.QueriesPerSecond, qpsMaxThreshold)
+		"ran out of leases worth transferring and load (%s) is still above desired threshold (%s); considering load-based replica rebalances",
+		objective.format(localLoad()), objective.format(maxThreshold))
 
 	// Re-combine replicasToMaybeRebalance with what remains of hottestRanges so
 	// that we'll reconsider them for replica rebalancing.
 	replicasToMaybeRebalance = append(replicasToMaybeRebalance, hottestRanges...)
 
-	for localDesc.Capacity.QueriesPerSecond > qpsMaxThreshold {
+	for localLoad() > maxThreshold {
 		replWithStats, voterTargets, nonVoterTargets := sr.chooseRangeToRebalance(
 			ctx,
 			&replicasToMaybeRebalance,
 			localDesc,
 			allStoresList,
-			sr.scorerOptions(),
+			sr.scorerOptions(objective),
 		)
 		if replWithStats.repl == nil {
 			log.Infof(ctx,
-				"ran out of replicas worth transferring and qps (%.2f) is still above desired threshold (%.2f); will check again soon",
-				localDesc.Capacity.QueriesPerSecond, qpsMaxThreshold)
+				"ran out of replicas worth transferring and load (%s) is still above desired threshold (%s); will check again soon",
+				objective.format(localLoad()), objective.format(maxThreshold))
 			return
 		}
 
@@ -356,9 +511,9 @@ func (sr *StoreRebalancer) rebalanceStore(
 		log.VEventf(
 			ctx,
 			1,
-			"rebalancing r%d (%.2f qps) to better balance load: voters from %v to %v; non-voters from %v to %v",
+			"rebalancing r%d (%s) to better balance load: voters from %v to %v; non-voters from %v to %v",
 			replWithStats.repl.RangeID,
-			replWithStats.qps,
+			objective.format(replWithStats.load(objective)),
 			descBeforeRebalance.Replicas().Voters(),
 			voterTargets,
 			descBeforeRebalance.Replicas().NonVoters(),
@@ -391,24 +546,29 @@ func (sr *StoreRebalancer) rebalanceStore(
 		for i := range replicasBeforeRebalance {
 			if storeDesc := storeMap[replicasBeforeRebalance[i].StoreID]; storeDesc != nil {
 				storeDesc.Capacity.RangeCount--
+				storeDesc.Capacity.CPUPerSecond -= replWithStats.raftCPU
 			}
 		}
 		localDesc.Capacity.LeaseCount--
 		localDesc.Capacity.QueriesPerSecond -= replWithStats.qps
+		localDesc.Capacity.CPUPerSecond -= replWithStats.requestCPU
 		for i := range voterTargets {
 			if storeDesc := storeMap[voterTargets[i].StoreID]; storeDesc != nil {
 				storeDesc.Capacity.RangeCount++
+				storeDesc.Capacity.CPUPerSecond += replWithStats.raftCPU
 				if i == 0 {
 					storeDesc.Capacity.LeaseCount++
 					storeDesc.Capacity.QueriesPerSecond += replWithStats.qps
+					storeDesc.Capacity.CPUPerSecond += replWithStats.requestCPU
 				}
 			}
 		}
 	}
 
 	log.Infof(ctx,
-		"load-based replica transfers successfully brought s%d down to %.2f qps (mean=%.2f, upperThreshold=%.2f)",
-		localDesc.StoreID, localDesc.Capacity.QueriesPerSecond, allStoresList.candidateQueriesPerSecond.mean, qpsMaxThreshold)
+		"load-based replica transfers successfully brought s%d down to %s (mean=%s, upperThreshold=%s)",
+		localDesc.StoreID, objective.format(localLoad()), objective.format(meanLoad),
+		objective.format(maxThreshold))
 }
 
 func (sr *StoreRebalancer) chooseLeaseToTransfer(
@@ -417,6 +577,7 @@ func (sr *StoreRebalancer) chooseLeaseToTransfer(
 	localDesc *roachpb.StoreDescriptor,
 	storeList StoreList,
 	storeMap map[roachpb.StoreID]*roachpb.StoreDescriptor,
+	objective LBRebalancingObjective,
 ) (replicaWithStats, roachpb.ReplicaDescriptor, []replicaWithStats) {
 	var considerForRebalance []replicaWithStats
 	now := sr.rq.store.Clock().NowAsClockTimestamp()
@@ -437,27 +598,30 @@ func (sr *StoreRebalancer) chooseLeaseToTransfer(
 			continue
 		}
 
-		// Don't bother moving leases whose QPS is below some small fraction of the
-		// store's QPS. It's just unnecessary churn with no benefit to move leases
-		// responsible for, for example, 1 qps on a store with 5000 qps.
-		const minQPSFraction = .001
-		if replWithStats.qps < localDesc.Capacity.QueriesPerSecond*minQPSFraction {
-			log.VEventf(ctx, 3, "r%d's %.2f qps is too little to matter relative to s%d's %.2f total qps",
-				replWithStats.repl.RangeID, replWithStats.qps, localDesc.StoreID, localDesc.Capacity.QueriesPerSecond)
+		// Don't bother moving leases whose load is below some small fraction of
+		// the store's load. It's just unnecessary churn with no benefit to move
+		// leases responsible for, for example, 1 qps on a store with 5000 qps.
+		const minLoadFraction = .001
+		leaseLoad := replWithStats.leaseLoad(objective)
+		storeLoad := objective.storeLoad(localDesc.Capacity)
+		if leaseLoad < storeLoad*minLoadFraction {
+			log.VEventf(ctx, 3, "r%d's %s is too little to matter relative to s%d's %s total",
+				replWithStats.repl.RangeID, objective.format(leaseLoad), localDesc.StoreID,
+				objective.format(storeLoad))
 			continue
 		}
 
 		desc, conf := replWithStats.repl.DescAndSpanConfig()
-		log.VEventf(ctx, 3, "considering lease transfer for r%d with %.2f qps",
-			desc.RangeID, replWithStats.qps)
+		log.VEventf(ctx, 3, "considering lease transfer for r%d with %s",
+			desc.RangeID, objective.format(leaseLoad))
 
-		// Check all the other voting replicas in order of increasing qps.
+		// Check all the other voting replicas in order of increasing load.
 		// Learners or non-voters aren't allowed to become leaseholders or raft
 		// leaders, so only consider the `Voter` replicas.
 		candidates := desc.Replicas().DeepCopy().VoterDescriptors()
 
 		// Only consider replicas that are not lagging behind the leader in order to
-		// avoid hurting QPS in the short term. This is a stronger check than what
+		// avoid hurting throughput in the short term. This is a stronger check than what
 		// `TransferLeaseTarget` performs (it only excludes replicas that are
 		// waiting for a snapshot).
 		candidates = filterBehindReplicas(ctx, sr.getRaftStatusFn(replWithStats.repl), candidates)
@@ -467,10 +631,11 @@ func (sr *StoreRebalancer) chooseLeaseToTransfer(
 			conf,
 			candidates,
 			replWithStats.repl,
-			replWithStats.repl.leaseholderStats,
+			replWithStats.repl.leaseLoadStats(objective),
 			true, /* forceDecisionWithoutStats */
 			transferLeaseOptions{
-				goal:                     qpsConvergence,
+				goal:                     loadConvergence,
+				objective:                objective,
 				checkTransferLeaseSource: true,
 			},
 		)
@@ -507,13 +672,13 @@ func (sr *StoreRebalancer) chooseLeaseToTransfer(
 			log.VEventf(
 				ctx,
 				1,
-				"transferring lease for r%d (qps=%.2f) to store s%d (qps=%.2f) from local store s%d (qps=%.2f)",
+				"transferring lease for r%d (%s) to store s%d (%s) from local store s%d (%s)",
 				desc.RangeID,
-				replWithStats.qps,
+				objective.format(leaseLoad),
 				targetStore.StoreID,
-				targetStore.Capacity.QueriesPerSecond,
+				objective.format(objective.storeLoad(targetStore.Capacity)),
 				localDesc.StoreID,
-				localDesc.Capacity.QueriesPerSecond,
+				objective.format(storeLoad),
 			)
 		}
 		return replWithStats, candidate, considerForRebalance
@@ -522,7 +687,7 @@ func (sr *StoreRebalancer) chooseLeaseToTransfer(
 
 // rangeRebalanceContext represents a snapshot of a replicas's state along with
 // the state of the cluster during the StoreRebalancer's attempt to rebalance it
-// based on QPS.
+// based on load.
 type rangeRebalanceContext struct {
 	replWithStats replicaWithStats
 	rangeDesc     *roachpb.RangeDescriptor
@@ -534,8 +699,9 @@ func (sr *StoreRebalancer) chooseRangeToRebalance(
 	hottestRanges *[]replicaWithStats,
 	localDesc *roachpb.StoreDescriptor,
 	allStoresList StoreList,
-	options *qpsScorerOptions,
+	options *loadScorerOptions,
 ) (replWithStats replicaWithStats, voterTargets, nonVoterTargets []roachpb.ReplicationTarget) {
+	objective := options.objective
 	now := sr.rq.store.Clock().NowAsClockTimestamp()
 	for {
 		if len(*hottestRanges) == 0 {
@@ -548,19 +714,20 @@ func (sr *StoreRebalancer) chooseRangeToRebalance(
 			return replicaWithStats{}, nil, nil
 		}
 
-		// Don't bother moving ranges whose QPS is below some small fraction of the
-		// store's QPS. It's just unnecessary churn with no benefit to move ranges
-		// responsible for, for example, 1 qps on a store with 5000 qps.
-		const minQPSFraction = .001
-		if replWithStats.qps < localDesc.Capacity.QueriesPerSecond*minQPSFraction {
+		// Don't bother moving ranges whose load is below some small fraction of
+		// the store's load. It's just unnecessary churn with no benefit to move
+		// ranges responsible for, for example, 1 qps on a store with 5000 qps.
+		const minLoadFraction = .001
+		replLoad := replWithStats.load(objective)
+		if storeLoad := objective.storeLoad(localDesc.Capacity); replLoad < storeLoad*minLoadFraction {
 			log.VEventf(
 				ctx,
 				5,
-				"r%d's %.2f qps is too little to matter relative to s%d's %.2f total qps",
+				"r%d's %s is too little to matter relative to s%d's %s total",
 				replWithStats.repl.RangeID,
-				replWithStats.qps,
+				objective.format(replLoad),
 				localDesc.StoreID,
-				localDesc.Capacity.QueriesPerSecond,
+				objective.format(storeLoad),
 			)
 			continue
 		}
@@ -591,16 +758,23 @@ func (sr *StoreRebalancer) chooseRangeToRebalance(
 			)
 			continue
 		}
+		// AdminRelocateRange only relocates voters and non-voters, and it refuses
+		// to operate on ranges with witnesses, which the replicateQueue manages.
+		if witnesses := rangeDesc.Replicas().WitnessDescriptors(); len(witnesses) > 0 {
+			log.VEventf(ctx, 3, "r%d has witnesses %v, which can't be relocated; ignoring",
+				rangeDesc.RangeID, witnesses)
+			continue
+		}
 		rebalanceCtx := rangeRebalanceContext{
 			replWithStats: replWithStats,
 			rangeDesc:     rangeDesc,
 			conf:          conf,
 		}
 
-		// We ascribe the leaseholder's QPS to every follower replica. The store
+		// We ascribe the leaseholder's load to every follower replica. The store
 		// rebalancer first attempts to transfer the leases of its hot ranges away
 		// in `chooseLeaseToTransfer`. If it cannot move enough leases away to bring
-		// down the store's QPS below the cluster-level overfullness threshold, it
+		// down the store's load below the cluster-level overfullness threshold, it
 		// moves on to rebalancing replicas. In other words, for every hot range on
 		// the store, the StoreRebalancer first tries moving the load away to one of
 		// its existing replicas but then tries to reconfigure the range (i.e. move
@@ -610,7 +784,7 @@ func (sr *StoreRebalancer) chooseRangeToRebalance(
 		// Thus, we ideally want to base our replica rebalancing on the assumption
 		// that all of the load from the leaseholder's replica is going to shift to
 		// the new store that we end up rebalancing to.
-		options.qpsPerReplica = replWithStats.qps
+		options.loadPerReplica = replLoad
 
 		if !replWithStats.repl.OwnsValidLease(ctx, now) {
 			log.VEventf(ctx, 3, "store doesn't own the lease for r%d", replWithStats.repl.RangeID)
@@ -620,12 +794,12 @@ func (sr *StoreRebalancer) chooseRangeToRebalance(
 		log.VEventf(
 			ctx,
 			3,
-			"considering replica rebalance for r%d with %.2f qps",
+			"considering replica rebalance for r%d with %s",
 			replWithStats.repl.GetRangeID(),
-			replWithStats.qps,
+			objective.format(replLoad),
 		)
 
-		targetVoterRepls, targetNonVoterRepls, foundRebalance := sr.getRebalanceTargetsBasedOnQPS(
+		targetVoterRepls, targetNonVoterRepls, foundRebalance := sr.getRebalanceTargetsBasedOnLoad(
 			ctx,
 			rebalanceCtx,
 			options,
@@ -641,13 +815,13 @@ func (sr *StoreRebalancer) chooseRangeToRebalance(
 
 		storeDescMap := storeListToMap(allStoresList)
 
-		// Pick the voter with the least QPS to be leaseholder;
+		// Pick the voter with the least load to be leaseholder;
 		// RelocateRange transfers the lease to the first provided target.
 		//
 		// TODO(aayush): Does this logic need to exist? This logic does not take
 		// lease preferences into account. So it is already broken in a way.
 		newLeaseIdx := 0
-		newLeaseQPS := math.MaxFloat64
+		newLeaseLoad := math.MaxFloat64
 		var raftStatus *raft.Status
 		for i := 0; i < len(targetVoterRepls); i++ {
 			// Ensure we don't transfer the lease to an existing replica that is behind
@@ -662,9 +836,9 @@ func (sr *StoreRebalancer) chooseRangeToRebalance(
 			}
 
 			storeDesc, ok := storeDescMap[targetVoterRepls[i].StoreID]
-			if ok && storeDesc.Capacity.QueriesPerSecond < newLeaseQPS {
+			if ok && objective.storeLoad(storeDesc.Capacity) < newLeaseLoad {
 				newLeaseIdx = i
-				newLeaseQPS = storeDesc.Capacity.QueriesPerSecond
+				newLeaseLoad = objective.storeLoad(storeDesc.Capacity)
 			}
 		}
 		targetVoterRepls[0], targetVoterRepls[newLeaseIdx] = targetVoterRepls[newLeaseIdx], targetVoterRepls[0]
@@ -674,12 +848,12 @@ func (sr *StoreRebalancer) chooseRangeToRebalance(
 	}
 }
 
-// getRebalanceTargetsBasedOnQPS returns a list of rebalance targets for
+// getRebalanceTargetsBasedOnLoad returns a list of rebalance targets for
 // voting and non-voting replicas on the range that match the relevant
-// constraints on the range and would further the goal of balancing the QPS on
+// constraints on the range and would further the goal of balancing the load on
 // the stores in this cluster.
-func (sr *StoreRebalancer) getRebalanceTargetsBasedOnQPS(
-	ctx context.Context, rbCtx rangeRebalanceContext, options scorerOptions,
+func (sr *StoreRebalancer) getRebalanceTargetsBasedOnLoad(
+	ctx context.Context, rbCtx rangeRebalanceContext, options *loadScorerOptions,
 ) (finalVoterTargets, finalNonVoterTargets []roachpb.ReplicaDescriptor, foundRebalance bool) {
 	finalVoterTargets = rbCtx.rangeDesc.Replicas().VoterDescriptors()
 	finalNonVoterTargets = rbCtx.rangeDesc.Replicas().NonVoterDescriptors()
@@ -704,7 +878,7 @@ func (sr *StoreRebalancer) getRebalanceTargetsBasedOnQPS(
 			log.VEventf(
 				ctx,
 				3,
-				"no more rebalancing opportunities for r%d voters that improve QPS balance",
+				"no more rebalancing opportunities for r%d voters that improve load balance",
 				rbCtx.rangeDesc.RangeID,
 			)
 			break
@@ -715,8 +889,8 @@ func (sr *StoreRebalancer) getRebalanceTargetsBasedOnQPS(
 		log.VEventf(
 			ctx,
 			3,
-			"rebalancing voter (qps=%.2f) for r%d on %v to %v in order to improve QPS balance",
-			rbCtx.replWithStats.qps,
+			"rebalancing voter (%s) for r%d on %v to %v in order to improve load balance",
+			options.objective.format(options.loadPerReplica),
 			rbCtx.rangeDesc.RangeID,
 			remove,
 			add,
@@ -768,7 +942,7 @@ func (sr *StoreRebalancer) getRebalanceTargetsBasedOnQPS(
 			log.VEventf(
 				ctx,
 				3,
-				"no more rebalancing opportunities for r%d non-voters that improve QPS balance",
+				"no more rebalancing opportunities for r%d non-voters that improve load balance",
 				rbCtx.rangeDesc.RangeID,
 			)
 			break
@@ -779,8 +953,8 @@ func (sr *StoreRebalancer) getRebalanceTargetsBasedOnQPS(
 		log.VEventf(
 			ctx,
 			3,
-			"rebalancing non-voter (qps=%.2f) for r%d on %v to %v in order to improve QPS balance",
-			rbCtx.replWithStats.qps,
+			"rebalancing non-voter (%s) for r%d on %v to %v in order to improve load balance",
+			options.objective.format(options.loadPerReplica),
 			rbCtx.rangeDesc.RangeID,
 			remove,
 			add,
@@ -15,17 +15,23 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/gossiputil"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/datadriven"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft/v3"
 	"go.etcd.io/etcd/raft/v3/tracker"
@@ -239,6 +245,9 @@ type testRange struct {
 	// The first storeID in the list will be the leaseholder.
 	voters, nonVoters []roachpb.StoreID
 	qps               float64
+	// requestCPU is the CPU time (in nanoseconds per second) spent evaluating
+	// requests on the range's leaseholder.
+	requestCPU float64
 }
 
 func loadRanges(rr *replicaRankings, s *Store, ranges []testRange) {
@@ -275,10 +284,15 @@ func loadRanges(rr *replicaRankings, s *Store, ranges []testRange) {
 		repl.leaseholderStats = newReplicaStats(s.Clock(), nil)
 		repl.leaseholderStats.setAvgQPSForTesting(r.qps)
 
+		repl.requestCPUStats = newReplicaStats(s.Clock(), nil)
+		repl.requestCPUStats.setAvgQPSForTesting(r.requestCPU)
+		repl.raftCPUStats = newReplicaStats(s.Clock(), nil)
+
 		repl.writeStats = newReplicaStats(s.Clock(), nil)
 		acc.addReplica(replicaWithStats{
-			repl: repl,
-			qps:  r.qps,
+			repl:       repl,
+			qps:        r.qps,
+			requestCPU: r.requestCPU,
 		})
 	}
 	rr.update(acc)
@@ -411,10 +425,13 @@ func TestChooseLeaseToTransfer(t *testing.T) {
 			qps:          500,
 			expectTarget: 5,
 		},
+		// NB: s1 would be projected to have 900 qps and s5 1100 qps. Lease
+		// transfers aren't allowed to make the receiving store hotter than the
+		// sending store.
 		{
 			storeIDs:     []roachpb.StoreID{1, 5},
 			qps:          600,
-			expectTarget: 5,
+			expectTarget: 0,
 		},
 
 		// NB: s1 serves 1500 qps and s5 serves 500. Without the lease, s1 would
@@ -436,18 +453,26 @@ func TestChooseLeaseToTransfer(t *testing.T) {
 			expectTarget: 0,
 		},
 		// If s1 is projected to have 701qps and s5 is projected to have 1299qps, we
-		// would not transfer the lease because doing so would significantly switch
-		// the relative dispositions of s1 and s5.
+		// would not transfer the lease because doing so would switch the relative
+		// dispositions of s1 and s5.
 		{
 			storeIDs:     []roachpb.StoreID{1, 3, 4, 5},
 			qps:          799,
 			expectTarget: 0,
 		},
-		// NB: However, if s1 is projected to have 750 qps, we would expect a lease
-		// transfer to s5.
+		// NB: The same holds if s1 is projected to have 750 qps and s5 1250 qps,
+		// even though replica rebalancing would tolerate such an overshoot (see
+		// maxQPSTransferOvershoot).
 		{
 			storeIDs:     []roachpb.StoreID{1, 3, 4, 5},
 			qps:          750,
+			expectTarget: 0,
+		},
+		// If s1 and s5 would be projected to have the same qps, we expect a lease
+		// transfer to s5.
+		{
+			storeIDs:     []roachpb.StoreID{1, 3, 4, 5},
+			qps:          500,
 			expectTarget: 5,
 		},
 
@@ -487,7 +512,7 @@ func TestChooseLeaseToTransfer(t *testing.T) {
 		t.Run("", func(t *testing.T) {
 			loadRanges(rr, s, []testRange{{voters: tc.storeIDs, qps: tc.qps}})
 			hottestRanges := rr.topQPS()
-			_, target, _ := sr.chooseLeaseToTransfer(ctx, &hottestRanges, &localDesc, storeList, storeMap)
+			_, target, _ := sr.chooseLeaseToTransfer(ctx, &hottestRanges, &localDesc, storeList, storeMap, LBRebalancingQueries)
 			if target.StoreID != tc.expectTarget {
 				t.Errorf("got target store %d for range with replicas %v and %f qps; want %d",
 					target.StoreID, tc.storeIDs, tc.qps, tc.expectTarget)
@@ -631,9 +656,9 @@ func TestChooseRangeToRebalanceRandom(t *testing.T) {
 				&hottestRanges,
 				&localDesc,
 				storeList,
-				&qpsScorerOptions{
-					deterministic:         false,
-					qpsRebalanceThreshold: qpsRebalanceThreshold,
+				&loadScorerOptions{
+					deterministic:      false,
+					rebalanceThreshold: qpsRebalanceThreshold,
 				},
 			)
 			var rebalancedVoterStores, rebalancedNonVoterStores []roachpb.StoreID
@@ -883,7 +908,7 @@ func TestChooseRangeToRebalanceAcrossHeterogeneousZones(t *testing.T) {
 				&hottestRanges,
 				&localDesc,
 				storeList,
-				&qpsScorerOptions{deterministic: true, qpsRebalanceThreshold: 0.05},
+				&loadScorerOptions{deterministic: true, rebalanceThreshold: 0.05},
 			)
 
 			require.Len(t, voterTargets, len(tc.expRebalancedVoters))
@@ -955,7 +980,7 @@ func TestChooseRangeToRebalanceIgnoresRangeOnBestStores(t *testing.T) {
 	loadRanges(rr, s, []testRange{{voters: []roachpb.StoreID{localDesc.StoreID}, qps: 100}})
 	hottestRanges := rr.topQPS()
 	sr.chooseRangeToRebalance(
-		ctx, &hottestRanges, &localDesc, storeList, &qpsScorerOptions{qpsRebalanceThreshold: 0.05},
+		ctx, &hottestRanges, &localDesc, storeList, &loadScorerOptions{rebalanceThreshold: 0.05},
 	)
 	trace := finishAndGetRecording()
 	require.Regexpf(
@@ -1117,7 +1142,7 @@ func TestChooseRangeToRebalanceOffHotNodes(t *testing.T) {
 				&hottestRanges,
 				&localDesc,
 				storeList,
-				&qpsScorerOptions{deterministic: true, qpsRebalanceThreshold: tc.rebalanceThreshold},
+				&loadScorerOptions{deterministic: true, rebalanceThreshold: tc.rebalanceThreshold},
 			)
 			require.Len(t, voterTargets, len(tc.expRebalancedVoters))
 
@@ -1191,7 +1216,7 @@ func TestNoLeaseTransferToBehindReplicas(t *testing.T) {
 		return status
 	}
 
-	_, target, _ := sr.chooseLeaseToTransfer(ctx, &hottestRanges, &localDesc, storeList, storeMap)
+	_, target, _ := sr.chooseLeaseToTransfer(ctx, &hottestRanges, &localDesc, storeList, storeMap, LBRebalancingQueries)
 	expectTarget := roachpb.StoreID(4)
 	if target.StoreID != expectTarget {
 		t.Errorf("got target store s%d for range with RaftStatus %v; want s%d",
@@ -1210,7 +1235,7 @@ func TestNoLeaseTransferToBehindReplicas(t *testing.T) {
 		&hottestRanges,
 		&localDesc,
 		storeList,
-		&qpsScorerOptions{deterministic: true, qpsRebalanceThreshold: 0.05},
+		&loadScorerOptions{deterministic: true, rebalanceThreshold: 0.05},
 	)
 	expectTargets := []roachpb.ReplicationTarget{
 		{NodeID: 4, StoreID: 4}, {NodeID: 3, StoreID: 3}, {NodeID: 5, StoreID: 5},
@@ -1220,3 +1245,304 @@ func TestNoLeaseTransferToBehindReplicas(t *testing.T) {
 			targets, sr.getRaftStatusFn(repl), expectTargets)
 	}
 }
+
+// TestStoreRebalancerSimulation is a datadriven test that simulates the
+// load-based lease transfers and replica rebalances made by the
+// StoreRebalancer on s1, for each of the load-based rebalancing objectives.
+//
+// The input files support the following commands:
+//
+//   - init: takes one range per line of input, in the form
+//     `range voters=(<store IDs>) qps=<float> cpu=<duration>`, where the first
+//     voter is the leaseholder and cpu is the request CPU time spent per second
+//     on the leaseholder. The stores' load is the sum of the load of the leases
+//     they hold.
+//   - rebalance objective=(qps|cpu): transfers leases away from s1 until it is
+//     no longer overfull, or until it runs out of leases worth transferring,
+//     and prints the transfers along with the resulting load on each store.
+//   - rebalance-replicas objective=(qps|cpu): relocates ranges whose lease is
+//     held by s1 until it is no longer overfull, or until it runs out of ranges
+//     worth relocating, and prints the relocations along with the resulting
+//     load on each store. This is the phase of rebalanceStore that follows the
+//     lease transfers when they didn't suffice.
+func TestStoreRebalancerSimulation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	datadriven.Walk(t, testutils.TestDataPath(t, "store_rebalancer"), func(t *testing.T, path string) {
+		var stopper *stop.Stopper
+		defer func() {
+			if stopper != nil {
+				stopper.Stop(ctx)
+			}
+		}()
+		var a Allocator
+		var rr *replicaRankings
+		var sr *StoreRebalancer
+
+		scanObjective := func(d *datadriven.TestData) LBRebalancingObjective {
+			var objectiveStr string
+			d.ScanArgs(t, "objective", &objectiveStr)
+			switch objectiveStr {
+			case "qps":
+				return LBRebalancingQueries
+			case "cpu":
+				return LBRebalancingCPU
+			default:
+				d.Fatalf(t, "unknown objective %q", objectiveStr)
+				return 0
+			}
+		}
+		printStores := func(buf *strings.Builder) {
+			allStores, _, _ := a.storePool.getStoreList(storeFilterNone)
+			sort.Slice(allStores.stores, func(i, j int) bool {
+				return allStores.stores[i].StoreID < allStores.stores[j].StoreID
+			})
+			for _, desc := range allStores.stores {
+				fmt.Fprintf(buf, "s%d: ranges=%d leases=%d qps=%.0f cpu=%s\n",
+					desc.StoreID, desc.Capacity.RangeCount, desc.Capacity.LeaseCount,
+					desc.Capacity.QueriesPerSecond, time.Duration(desc.Capacity.CPUPerSecond))
+			}
+		}
+
+		datadriven.RunTest(t, path, func(t *testing.T, d *datadriven.TestData) string {
+			switch d.Cmd {
+			case "init":
+				if stopper != nil {
+					stopper.Stop(ctx)
+				}
+				var g *gossip.Gossip
+				stopper, g, _, a, _ = createTestAllocatorWithKnobs(ctx,
+					10, false /* deterministic */, &AllocatorTestingKnobs{
+						AllowLeaseTransfersToReplicasNeedingSnapshots: true,
+					},
+				)
+
+				var ranges []testRange
+				stores := make(map[roachpb.StoreID]*roachpb.StoreDescriptor)
+				for _, line := range strings.Split(strings.TrimSpace(d.Input), "\n") {
+					cmd, args, err := datadriven.ParseLine(line)
+					require.NoError(t, err)
+					if cmd != "range" {
+						d.Fatalf(t, "unknown input %q", cmd)
+					}
+					var r testRange
+					for _, arg := range args {
+						switch arg.Key {
+						case "voters":
+							for i := range arg.Vals {
+								var storeID int
+								arg.Scan(t, i, &storeID)
+								r.voters = append(r.voters, roachpb.StoreID(storeID))
+							}
+						case "qps":
+							var qps string
+							arg.Scan(t, 0, &qps)
+							r.qps, err = strconv.ParseFloat(qps, 64)
+							require.NoError(t, err)
+						case "cpu":
+							var cpu string
+							arg.Scan(t, 0, &cpu)
+							dur, err := time.ParseDuration(cpu)
+							require.NoError(t, err)
+							r.requestCPU = float64(dur)
+						default:
+							d.Fatalf(t, "unknown argument %q", arg.Key)
+						}
+					}
+					for i, storeID := range r.voters {
+						desc, ok := stores[storeID]
+						if !ok {
+							desc = &roachpb.StoreDescriptor{
+								StoreID: storeID,
+								Node:    roachpb.NodeDescriptor{NodeID: roachpb.NodeID(storeID)},
+							}
+							stores[storeID] = desc
+						}
+						desc.Capacity.RangeCount++
+						if i == 0 {
+							desc.Capacity.LeaseCount++
+							desc.Capacity.QueriesPerSecond += r.qps
+							desc.Capacity.CPUPerSecond += r.requestCPU
+						}
+					}
+					ranges = append(ranges, r)
+				}
+				storeDescs := make([]*roachpb.StoreDescriptor, 0, len(stores))
+				for _, desc := range stores {
+					storeDescs = append(storeDescs, desc)
+				}
+				sort.Slice(storeDescs, func(i, j int) bool {
+					return storeDescs[i].StoreID < storeDescs[j].StoreID
+				})
+				gossiputil.NewStoreGossiper(g).GossipStores(storeDescs, t)
+
+				cfg := TestStoreConfig(nil)
+				cfg.Gossip = g
+				s := createTestStoreWithoutStart(ctx, t, stopper, testStoreOpts{createSystemRanges: true}, &cfg)
+				s.Ident = &roachpb.StoreIdent{StoreID: 1}
+				rq := newReplicateQueue(s, a)
+				rr = newReplicaRankings()
+				sr = NewStoreRebalancer(cfg.AmbientCtx, cfg.Settings, rq, rr)
+				// Fake out the raft status so that all replicas appear up to date.
+				sr.getRaftStatusFn = func(r *Replica) *raft.Status {
+					status := &raft.Status{
+						Progress: make(map[uint64]tracker.Progress),
+					}
+					status.Lead = uint64(r.ReplicaID())
+					status.Commit = 1
+					for _, replica := range r.Desc().InternalReplicas {
+						status.Progress[uint64(replica.ReplicaID)] = tracker.Progress{
+							Match: 1,
+							State: tracker.StateReplicate,
+						}
+					}
+					return status
+				}
+				loadRanges(rr, s, ranges)
+				return ""
+
+			case "rebalance":
+				objective := scanObjective(d)
+
+				// Mirror the lease transfer phase of rebalanceStore, with the
+				// transfers themselves applied directly to the StorePool.
+				storeList, _, _ := a.storePool.getStoreList(storeFilterThrottled)
+				storeMap := storeListToMap(storeList)
+				localDesc := storeMap[1]
+				maxThreshold := overfullLoadThreshold(sr.scorerOptions(objective), objective.meanLoad(storeList))
+				hottestRanges := rr.topLoad(objective)
+
+				var buf strings.Builder
+				for objective.storeLoad(localDesc.Capacity) > maxThreshold {
+					replWithStats, target, _ := sr.chooseLeaseToTransfer(
+						ctx, &hottestRanges, localDesc, storeList, storeMap, objective,
+					)
+					if replWithStats.repl == nil {
+						break
+					}
+					fmt.Fprintf(&buf, "transfer r%d (%s) from s%d to s%d\n",
+						replWithStats.repl.RangeID, objective.format(replWithStats.leaseLoad(objective)),
+						localDesc.StoreID, target.StoreID)
+
+					replWithStats.repl.mu.Lock()
+					replWithStats.repl.mu.state.Lease.Replica = target
+					replWithStats.repl.mu.Unlock()
+					a.storePool.updateLocalStoresAfterLeaseTransfer(localDesc.StoreID, target.StoreID, RangeUsageInfo{
+						QueriesPerSecond:         replWithStats.qps,
+						RequestCPUNanosPerSecond: replWithStats.requestCPU,
+					})
+					localDesc.Capacity.LeaseCount--
+					localDesc.Capacity.QueriesPerSecond -= replWithStats.qps
+					localDesc.Capacity.CPUPerSecond -= replWithStats.requestCPU
+					if otherDesc := storeMap[target.StoreID]; otherDesc != nil {
+						otherDesc.Capacity.LeaseCount++
+						otherDesc.Capacity.QueriesPerSecond += replWithStats.qps
+						otherDesc.Capacity.CPUPerSecond += replWithStats.requestCPU
+					}
+				}
+
+				printStores(&buf)
+				return buf.String()
+
+			case "rebalance-replicas":
+				objective := scanObjective(d)
+
+				// Mirror the replica rebalancing phase of rebalanceStore, with the
+				// relocations themselves applied directly to the replicas and the
+				// StorePool. Unlike rebalanceStore, which leaves it to gossip to
+				// update the StorePool, this lets each decision see the load moved
+				// by the previous ones.
+				storeList, _, _ := a.storePool.getStoreList(storeFilterThrottled)
+				storeMap := storeListToMap(storeList)
+				localDesc := storeMap[1]
+				options := sr.scorerOptions(objective)
+				maxThreshold := overfullLoadThreshold(options, objective.meanLoad(storeList))
+				hottestRanges := rr.topLoad(objective)
+
+				var buf strings.Builder
+				for objective.storeLoad(localDesc.Capacity) > maxThreshold {
+					replWithStats, voterTargets, _ := sr.chooseRangeToRebalance(
+						ctx, &hottestRanges, localDesc, storeList, options,
+					)
+					if replWithStats.repl == nil {
+						break
+					}
+					repl := replWithStats.repl
+					descBefore := repl.Desc()
+					formatVoters := func(voters []roachpb.ReplicaDescriptor) string {
+						storeIDs := make([]string, len(voters))
+						for i := range voters {
+							storeIDs[i] = strconv.Itoa(int(voters[i].StoreID))
+						}
+						return "(" + strings.Join(storeIDs, ",") + ")"
+					}
+					voters := make([]roachpb.ReplicaDescriptor, len(voterTargets))
+					for i, target := range voterTargets {
+						voters[i] = roachpb.ReplicaDescriptor{
+							NodeID:    target.NodeID,
+							StoreID:   target.StoreID,
+							ReplicaID: roachpb.ReplicaID(target.StoreID),
+							Type:      roachpb.ReplicaTypeVoterFull(),
+						}
+					}
+					fmt.Fprintf(&buf, "relocate r%d (%s) from voters=%s to voters=%s\n",
+						repl.RangeID, objective.format(replWithStats.load(objective)),
+						formatVoters(descBefore.Replicas().VoterDescriptors()), formatVoters(voters))
+
+					usage := RangeUsageInfo{
+						QueriesPerSecond:         replWithStats.qps,
+						RequestCPUNanosPerSecond: replWithStats.requestCPU,
+						RaftCPUNanosPerSecond:    replWithStats.raftCPU,
+					}
+					for _, voter := range descBefore.Replicas().VoterDescriptors() {
+						if _, ok := roachpb.MakeReplicaSet(voters).GetReplicaDescriptor(voter.StoreID); !ok {
+							a.storePool.updateLocalStoreAfterRebalance(voter.StoreID, usage, roachpb.REMOVE_VOTER)
+						}
+					}
+					for _, voter := range voters {
+						if _, ok := descBefore.GetReplicaDescriptor(voter.StoreID); !ok {
+							a.storePool.updateLocalStoreAfterRebalance(voter.StoreID, usage, roachpb.ADD_VOTER)
+						}
+					}
+					a.storePool.updateLocalStoresAfterLeaseTransfer(localDesc.StoreID, voters[0].StoreID, usage)
+					repl.mu.Lock()
+					desc := *repl.mu.state.Desc
+					desc.InternalReplicas = voters
+					repl.mu.state.Desc = &desc
+					repl.mu.state.Lease.Replica = voters[0]
+					repl.mu.Unlock()
+
+					for _, voter := range descBefore.Replicas().VoterDescriptors() {
+						if storeDesc := storeMap[voter.StoreID]; storeDesc != nil {
+							storeDesc.Capacity.RangeCount--
+							storeDesc.Capacity.CPUPerSecond -= replWithStats.raftCPU
+						}
+					}
+					localDesc.Capacity.LeaseCount--
+					localDesc.Capacity.QueriesPerSecond -= replWithStats.qps
+					localDesc.Capacity.CPUPerSecond -= replWithStats.requestCPU
+					for i, voter := range voters {
+						if storeDesc := storeMap[voter.StoreID]; storeDesc != nil {
+							storeDesc.Capacity.RangeCount++
+							storeDesc.Capacity.CPUPerSecond += replWithStats.raftCPU
+							if i == 0 {
+								storeDesc.Capacity.LeaseCount++
+								storeDesc.Capacity.QueriesPerSecond += replWithStats.qps
+								storeDesc.Capacity.CPUPerSecond += replWithStats.requestCPU
+							}
+						}
+					}
+				}
+
+				printStores(&buf)
+				return buf.String()
+
+			default:
+				d.Fatalf(t, "unknown command %q", d.Cmd)
+				return ""
+			}
+		})
+	})
+}
@@ -766,6 +766,18 @@ var recoverySnapshotRate = settings.RegisterByteSizeSetting(
 	},
 ).WithPublic()
 
+// SnapshotDelegationEnabled controls whether the raft leader may delegate the
+// sending of a snapshot to a follower that is closer to the recipient, which
+// avoids streaming snapshots across regions when a replica is added in a
+// region that already contains another replica of the range.
+var SnapshotDelegationEnabled = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"kv.snapshot_delegation.enabled",
+	"if enabled, snapshots are sent by an up-to-date follower in the locality of the recipient "+
+		"when one is available, rather than by the leaseholder",
+	false,
+).WithPublic()
+
 // snapshotSenderBatchSize is the size that key-value batches are allowed to
 // grow to during Range snapshots before being sent to the receiver. This limit
 // places an upper-bound on the memory footprint of the sender of a Range
@@ -994,10 +1006,12 @@ func SendEmptySnapshot(
 		// quickly.
 		kvserverpb.SnapshotRequest_VIA_SNAPSHOT_QUEUE,
 		eng,
+		eng, /* raftSnap */
 		desc.RangeID,
 		raftentry.NewCache(1), // cache is not used
 		func(func(SideloadStorage) error) error { return nil }, // this is used for sstables, not needed here as there are no logs
 		desc.StartKey,
+		to.StoreID,
 	)
 	if err != nil {
 		return err
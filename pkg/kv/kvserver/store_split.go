@@ -80,6 +80,10 @@ func splitPreApply(
 		// the HardState and tombstone. Note that we only do this if rightRepl
 		// exists; if it doesn't, there's no Raft state to massage (when rightRepl
 		// was removed, a tombstone was written instead).
+		//
+		// With a separate Raft engine, the HardState is not in readWriter and
+		// is left untouched.
+		separateRaftEngine := r.store.separateRaftEngine()
 		var hs raftpb.HardState
 		if rightRepl != nil {
 			// Assert that the rightRepl is not initialized. We're about to clear out
@@ -88,10 +92,12 @@ func splitPreApply(
 			if rightRepl.IsInitialized() {
 				log.Fatalf(ctx, "unexpectedly found initialized newer RHS of split: %v", rightRepl.Desc())
 			}
-			var err error
-			hs, err = rightRepl.raftMu.stateLoader.LoadHardState(ctx, readWriter)
-			if err != nil {
-				log.Fatalf(ctx, "failed to load hard state for removed rhs: %v", err)
+			if !separateRaftEngine {
+				var err error
+				hs, err = rightRepl.raftMu.stateLoader.LoadHardState(ctx, readWriter)
+				if err != nil {
+					log.Fatalf(ctx, "failed to load hard state for removed rhs: %v", err)
+				}
 			}
 		}
 		const rangeIDLocalOnly = false
@@ -107,8 +113,10 @@ func splitPreApply(
 			// to HardState.{Term,Vote} that we would accidentally undo here,
 			// because we are not actually holding the appropriate mutex. See
 			// https://github.com/cockroachdb/cockroach/issues/75918.
-			if err := rightRepl.raftMu.stateLoader.SetHardState(ctx, readWriter, hs); err != nil {
-				log.Fatalf(ctx, "failed to set hard state with 0 commit index for removed rhs: %v", err)
+			if !separateRaftEngine {
+				if err := rightRepl.raftMu.stateLoader.SetHardState(ctx, readWriter, hs); err != nil {
+					log.Fatalf(ctx, "failed to set hard state with 0 commit index for removed rhs: %v", err)
+				}
 			}
 			if err := rightRepl.raftMu.stateLoader.SetRaftReplicaID(
 				ctx, readWriter, rightRepl.ReplicaID()); err != nil {
@@ -121,8 +129,17 @@ func splitPreApply(
 	// Update the raft HardState with the new Commit value now that the
 	// replica is initialized (combining it with existing or default
 	// Term and Vote). This is the common case.
+	//
+	// With a separate Raft engine, the Raft state of the RHS can only be written
+	// once this batch is durable, which prepareRightReplicaForSplit takes care
+	// of. The TruncatedState written by the split trigger is removed from the
+	// state machine engine instead.
 	rsl := stateloader.Make(split.RightDesc.RangeID)
-	if err := rsl.SynthesizeRaftState(ctx, readWriter); err != nil {
+	if r.store.separateRaftEngine() {
+		if err := readWriter.ClearUnversioned(rsl.RaftTruncatedStateKey()); err != nil {
+			log.Fatalf(ctx, "%v", err)
+		}
+	} else if err := rsl.SynthesizeRaftState(ctx, readWriter); err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
 	// Write the RaftReplicaID for the RHS to maintain the invariant that any
@@ -240,6 +257,24 @@ func prepareRightReplicaForSplit(
 		return nil
 	}
 
+	// With a separate Raft engine, write the Raft state of the RHS now that the
+	// split has been durably applied. If this write is lost, Store.Start rolls
+	// the Raft state forward again.
+	if r.store.separateRaftEngine() {
+		if err := func() error {
+			batch := r.store.raftEngine.NewBatch()
+			defer batch.Close()
+			if _, err := rollForwardRaftState(
+				ctx, split.RightDesc.RangeID, r.store.engine, batch,
+			); err != nil {
+				return err
+			}
+			return batch.Commit(false /* sync */)
+		}(); err != nil {
+			log.Fatalf(ctx, "failed to write Raft state of RHS: %v", err)
+		}
+	}
+
 	// Finish initialization of the RHS.
 	err := rightRepl.loadRaftMuLockedReplicaMuLocked(&split.RightDesc)
 	if err != nil {
@@ -324,9 +359,13 @@ func (s *Store) SplitRange(
 	if rightReplOrNil == nil {
 		throwawayRightWriteStats := new(replicaStats)
 		leftRepl.writeStats.splitRequestCounts(throwawayRightWriteStats)
+		leftRepl.requestCPUStats.splitRequestCounts(new(replicaStats))
+		leftRepl.raftCPUStats.splitRequestCounts(new(replicaStats))
 	} else {
 		rightRepl := rightReplOrNil
 		leftRepl.writeStats.splitRequestCounts(rightRepl.writeStats)
+		leftRepl.requestCPUStats.splitRequestCounts(rightRepl.requestCPUStats)
+		leftRepl.raftCPUStats.splitRequestCounts(rightRepl.raftCPUStats)
 		if err := s.addReplicaInternalLocked(rightRepl); err != nil {
 			return errors.Wrapf(err, "unable to add replica %v", rightRepl)
 		}
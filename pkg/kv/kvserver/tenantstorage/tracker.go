@@ -0,0 +1,273 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package tenantstorage tracks the logical storage usage of secondary tenants
+// and enforces per-tenant storage quotas.
+//
+// Each store periodically gossips the logical bytes (as per MVCCStats.Total())
+// of the secondary tenants' ranges for which it holds the lease. The Tracker
+// sums up these reports across all stores in the cluster and rejects writes
+// which would add data on behalf of tenants that have reached their quota.
+// The quotas are configured per tenant (next to the tenant's resource limits
+// in system.tenant_usage) and are periodically read by each node. Since both
+// the usage and the quotas are only refreshed periodically, tenants can
+// overshoot their quota by the amount of data written in between two
+// refreshes.
+package tenantstorage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/gossip"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// UsageGossipTTL is the TTL of the usage gossiped by each store. Stores gossip
+// their usage every time their metrics are computed, which is much more
+// frequent than this. The usage reported by stores which haven't gossiped in
+// this long (for example because they were removed from the cluster) is
+// disregarded.
+const UsageGossipTTL = time.Minute
+
+// quotaRefreshInterval is the interval at which the Tracker reads the tenants'
+// storage quotas.
+const quotaRefreshInterval = 10 * time.Second
+
+// QuotaReader returns the storage quotas of all the tenants that have one.
+type QuotaReader func(ctx context.Context) (map[roachpb.TenantID]int64, error)
+
+// TenantUsage is the logical storage usage of a tenant.
+type TenantUsage struct {
+	TenantID     roachpb.TenantID
+	LogicalBytes int64
+}
+
+// storeUsage is the usage most recently reported by a store.
+type storeUsage struct {
+	updated      time.Time
+	logicalBytes map[roachpb.TenantID]int64
+}
+
+// Tracker aggregates the logical storage usage of secondary tenants across
+// the stores of the cluster and enforces the tenants' storage quotas. A
+// Tracker is shared by all stores of a node.
+type Tracker struct {
+	timeSource timeutil.TimeSource
+	mu         struct {
+		syncutil.RWMutex
+		stores map[roachpb.StoreID]storeUsage
+		totals map[roachpb.TenantID]int64
+		quotas map[roachpb.TenantID]int64
+	}
+}
+
+// NewTracker constructs a Tracker which receives the usage of the stores in
+// the cluster through the provided gossip instance.
+func NewTracker(g *gossip.Gossip) *Tracker {
+	t := newTracker(timeutil.DefaultTimeSource{})
+	if g != nil {
+		// Stores gossip their usage periodically even if it hasn't changed, so
+		// we register a redundant callback to keep track of stores which stop
+		// reporting.
+		g.RegisterCallback(
+			gossip.MakePrefixPattern(gossip.KeyTenantStorageUsagePrefix),
+			t.gossipUpdate,
+			gossip.Redundant,
+		)
+	}
+	return t
+}
+
+func newTracker(timeSource timeutil.TimeSource) *Tracker {
+	t := &Tracker{timeSource: timeSource}
+	t.mu.stores = make(map[roachpb.StoreID]storeUsage)
+	t.mu.totals = make(map[roachpb.TenantID]int64)
+	t.mu.quotas = make(map[roachpb.TenantID]int64)
+	return t
+}
+
+// Start starts a background task which periodically refreshes the tenants'
+// storage quotas using the provided reader. If reading the quotas fails, the
+// previously read quotas remain in effect.
+func (t *Tracker) Start(ctx context.Context, stopper *stop.Stopper, readQuotas QuotaReader) {
+	_ = stopper.RunAsyncTask(ctx, "tenant-storage-quotas", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			if quotas, err := readQuotas(ctx); err != nil {
+				if ctx.Err() == nil {
+					log.Warningf(ctx, "unable to read tenant storage quotas: %v", err)
+				}
+			} else {
+				t.SetQuotas(quotas)
+			}
+			timer.Reset(quotaRefreshInterval)
+			select {
+			case <-timer.C:
+				timer.Read = true
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+		}
+	})
+}
+
+// SetQuotas replaces the storage quotas of all tenants. Tenants not present in
+// the map are not subject to a quota.
+func (t *Tracker) SetQuotas(quotas map[roachpb.TenantID]int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mu.quotas = make(map[roachpb.TenantID]int64, len(quotas))
+	for tenantID, quota := range quotas {
+		if quota > 0 {
+			t.mu.quotas[tenantID] = quota
+		}
+	}
+}
+
+// gossipUpdate is the gossip callback used to receive the usage of stores.
+func (t *Tracker) gossipUpdate(key string, content roachpb.Value) {
+	var usage kvserverpb.TenantStorageUsage
+	if err := content.GetProto(&usage); err != nil {
+		log.Errorf(context.TODO(), "unable to unmarshal tenant storage usage for key %q: %v", key, err)
+		return
+	}
+	t.updateStore(&usage)
+}
+
+// updateStore replaces the usage of the store which reported the given usage.
+func (t *Tracker) updateStore(usage *kvserverpb.TenantStorageUsage) {
+	now := t.timeSource.Now()
+	logicalBytes := make(map[roachpb.TenantID]int64, len(usage.LogicalBytes))
+	for id, bytes := range usage.LogicalBytes {
+		logicalBytes[roachpb.MakeTenantID(id)] = bytes
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.removeStoreLocked(usage.StoreID)
+	for storeID, s := range t.mu.stores {
+		if now.Sub(s.updated) > UsageGossipTTL {
+			t.removeStoreLocked(storeID)
+		}
+	}
+	t.mu.stores[usage.StoreID] = storeUsage{updated: now, logicalBytes: logicalBytes}
+	for tenantID, bytes := range logicalBytes {
+		t.mu.totals[tenantID] += bytes
+	}
+}
+
+// removeStoreLocked discards the usage reported by the given store.
+func (t *Tracker) removeStoreLocked(storeID roachpb.StoreID) {
+	s, ok := t.mu.stores[storeID]
+	if !ok {
+		return
+	}
+	delete(t.mu.stores, storeID)
+	for tenantID, bytes := range s.logicalBytes {
+		total := t.mu.totals[tenantID] - bytes
+		if total == 0 {
+			delete(t.mu.totals, tenantID)
+		} else {
+			t.mu.totals[tenantID] = total
+		}
+	}
+}
+
+// Usage returns the logical storage usage of the given tenant.
+func (t *Tracker) Usage(tenantID roachpb.TenantID) int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.mu.totals[tenantID]
+}
+
+// Usages returns the logical storage usage of all tenants known to the
+// Tracker, ordered by tenant ID.
+func (t *Tracker) Usages() []TenantUsage {
+	t.mu.RLock()
+	usages := make([]TenantUsage, 0, len(t.mu.totals))
+	for tenantID, bytes := range t.mu.totals {
+		usages = append(usages, TenantUsage{TenantID: tenantID, LogicalBytes: bytes})
+	}
+	t.mu.RUnlock()
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].TenantID.ToUint64() < usages[j].TenantID.ToUint64()
+	})
+	return usages
+}
+
+// Quota returns the storage quota of the given tenant, or zero if the tenant
+// is not subject to a quota.
+func (t *Tracker) Quota(tenantID roachpb.TenantID) int64 {
+	if tenantID == roachpb.SystemTenantID {
+		return 0
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.mu.quotas[tenantID]
+}
+
+// CheckBatch returns an error if the given batch, issued by the given tenant,
+// adds data while the tenant has reached its storage quota. Batches which
+// don't add data (reads, deletions, transaction bookkeeping, etc.) are always
+// let through so that tenants can free up space.
+func (t *Tracker) CheckBatch(tenantID roachpb.TenantID, ba *roachpb.BatchRequest) error {
+	if !ba.IsWrite() {
+		return nil
+	}
+	quota := t.Quota(tenantID)
+	if quota == 0 {
+		return nil
+	}
+	usage := t.Usage(tenantID)
+	if usage < quota || !addsData(ba) {
+		return nil
+	}
+	return newQuotaExceededError(tenantID, usage, quota)
+}
+
+// addsData returns whether the given batch contains a request which may grow
+// the amount of data stored in the range, i.e. a request subject to
+// backpressure other than a deletion.
+func addsData(ba *roachpb.BatchRequest) bool {
+	for _, ru := range ba.Requests {
+		switch req := ru.GetInner(); req.(type) {
+		case *roachpb.DeleteRequest, *roachpb.DeleteRangeRequest:
+		default:
+			if roachpb.CanBackpressure(req) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func newQuotaExceededError(tenantID roachpb.TenantID, usage, quota int64) error {
+	err := errors.Newf(
+		"tenant %s has exceeded its storage quota: using %s of %s",
+		tenantID, humanizeutil.IBytes(usage), humanizeutil.IBytes(quota),
+	)
+	err = errors.WithHint(err, "Only deletions are permitted until the storage usage "+
+		"falls below the quota.")
+	return pgerror.WithCandidateCode(err, pgcode.DiskFull)
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tenantstorage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerUsage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ts := timeutil.NewManualTime(timeutil.Unix(0, 0))
+	tr := newTracker(ts)
+	t10, t11 := roachpb.MakeTenantID(10), roachpb.MakeTenantID(11)
+
+	tr.updateStore(&kvserverpb.TenantStorageUsage{
+		StoreID:      1,
+		LogicalBytes: map[uint64]int64{10: 100, 11: 50},
+	})
+	tr.updateStore(&kvserverpb.TenantStorageUsage{
+		StoreID:      2,
+		LogicalBytes: map[uint64]int64{10: 20},
+	})
+	require.Equal(t, int64(120), tr.Usage(t10))
+	require.Equal(t, int64(50), tr.Usage(t11))
+
+	// A new report from a store replaces its previous one.
+	tr.updateStore(&kvserverpb.TenantStorageUsage{
+		StoreID:      1,
+		LogicalBytes: map[uint64]int64{10: 200},
+	})
+	require.Equal(t, []TenantUsage{{TenantID: t10, LogicalBytes: 220}}, tr.Usages())
+
+	// Stores which stop reporting are eventually disregarded.
+	ts.Advance(UsageGossipTTL + time.Second)
+	tr.updateStore(&kvserverpb.TenantStorageUsage{
+		StoreID:      1,
+		LogicalBytes: map[uint64]int64{10: 200, 11: 10},
+	})
+	require.Equal(t, []TenantUsage{
+		{TenantID: t10, LogicalBytes: 200},
+		{TenantID: t11, LogicalBytes: 10},
+	}, tr.Usages())
+}
+
+func TestTrackerCheckBatch(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tr := newTracker(timeutil.NewManualTime(timeutil.Unix(0, 0)))
+	tenantID, otherTenantID := roachpb.MakeTenantID(10), roachpb.MakeTenantID(11)
+	tr.updateStore(&kvserverpb.TenantStorageUsage{
+		StoreID:      1,
+		LogicalBytes: map[uint64]int64{10: 1000},
+	})
+
+	key := roachpb.Key("a")
+	span := roachpb.RequestHeader{Key: key, EndKey: key.Next()}
+	makeBatch := func(reqs ...roachpb.Request) *roachpb.BatchRequest {
+		ba := &roachpb.BatchRequest{}
+		ba.Add(reqs...)
+		return ba
+	}
+	put := makeBatch(&roachpb.PutRequest{RequestHeader: span})
+	incWithDel := makeBatch(&roachpb.DeleteRequest{RequestHeader: span}, &roachpb.IncrementRequest{RequestHeader: span})
+	get := makeBatch(&roachpb.GetRequest{RequestHeader: span})
+	del := makeBatch(&roachpb.DeleteRequest{RequestHeader: span}, &roachpb.EndTxnRequest{RequestHeader: span})
+	delRange := makeBatch(&roachpb.DeleteRangeRequest{RequestHeader: span})
+
+	check := func(tenantID roachpb.TenantID, exp bool, batches ...*roachpb.BatchRequest) {
+		t.Helper()
+		for _, ba := range batches {
+			err := tr.CheckBatch(tenantID, ba)
+			if !exp {
+				require.NoError(t, err, "%s", ba)
+				continue
+			}
+			require.Error(t, err, "%s", ba)
+			require.Equal(t, pgcode.DiskFull, pgerror.GetPGCode(err))
+		}
+	}
+
+	// No quota.
+	check(tenantID, false, put, incWithDel, get, del, delRange)
+
+	// Below the quota.
+	tr.SetQuotas(map[roachpb.TenantID]int64{tenantID: 1001})
+	check(tenantID, false, put, incWithDel, get, del, delRange)
+
+	// At the quota, only writes which add data are rejected.
+	tr.SetQuotas(map[roachpb.TenantID]int64{tenantID: 1000})
+	check(tenantID, true, put, incWithDel)
+	check(tenantID, false, get, del, delRange)
+
+	// Quotas are per tenant.
+	tr.updateStore(&kvserverpb.TenantStorageUsage{
+		StoreID:      1,
+		LogicalBytes: map[uint64]int64{10: 1000, 11: 1000},
+	})
+	check(otherTenantID, false, put, incWithDel, get, del, delRange)
+	tr.SetQuotas(map[roachpb.TenantID]int64{otherTenantID: 1000})
+	check(tenantID, false, put, incWithDel, get, del, delRange)
+	check(otherTenantID, true, put, incWithDel)
+
+	// The system tenant is not subject to the quota.
+	check(roachpb.SystemTenantID, false, put, incWithDel, get, del, delRange)
+}
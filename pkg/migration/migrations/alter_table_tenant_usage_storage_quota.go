@@ -0,0 +1,44 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/migration"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+)
+
+const addStorageQuotaCol = `
+ALTER TABLE system.tenant_usage
+ADD COLUMN IF NOT EXISTS storage_quota_bytes INT8 FAMILY "primary"
+`
+
+// alterTableTenantUsageAddStorageQuota adds the storage_quota_bytes column to
+// the system.tenant_usage table (for the system tenant).
+func alterTableTenantUsageAddStorageQuota(
+	ctx context.Context, cs clusterversion.ClusterVersion, d migration.TenantDeps, _ *jobs.Job,
+) error {
+	// The table only exists on the system tenant.
+	if !d.Codec.ForSystemTenant() {
+		return nil
+	}
+	op := operation{
+		name:           "add-table-tenant-usage-storage-quota-col",
+		schemaList:     []string{"storage_quota_bytes"},
+		query:          addStorageQuotaCol,
+		schemaExistsFn: hasColumn,
+	}
+	return migrateTable(ctx, cs, d, op, keys.TenantUsageTableID, systemschema.TenantUsageTable)
+}
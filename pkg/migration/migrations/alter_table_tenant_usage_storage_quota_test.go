@@ -0,0 +1,154 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package migrations_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/migration/migrations"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestAlterSystemTenantUsageTable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	clusterArgs := base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{
+				Server: &server.TestingKnobs{
+					DisableAutomaticVersionUpgrade: make(chan struct{}),
+					BinaryVersionOverride: clusterversion.ByKey(
+						clusterversion.TenantStorageQuotas - 1),
+				},
+			},
+		},
+	}
+
+	var (
+		ctx = context.Background()
+
+		tc    = testcluster.StartTestCluster(t, 1, clusterArgs)
+		s     = tc.Server(0)
+		sqlDB = tc.ServerConn(0)
+	)
+	defer tc.Stopper().Stop(ctx)
+
+	var (
+		validationSchemas = []migrations.Schema{
+			{Name: "storage_quota_bytes", ValidationFn: migrations.HasColumn},
+		}
+	)
+
+	// Inject the old copy of the descriptor.
+	migrations.InjectLegacyTable(ctx, t, s, systemschema.TenantUsageTable, getDeprecatedTenantUsageDescriptor)
+	// Validate that the tenant usage table has the old schema.
+	migrations.ValidateSchemaExists(
+		ctx,
+		t,
+		s,
+		sqlDB,
+		keys.TenantUsageTableID,
+		systemschema.TenantUsageTable,
+		[]string{},
+		validationSchemas,
+		false, /* expectExists */
+	)
+	// Run the migration.
+	migrations.Migrate(
+		t,
+		sqlDB,
+		clusterversion.TenantStorageQuotas,
+		nil,   /* done */
+		false, /* expectError */
+	)
+	// Validate that the table has new schema.
+	migrations.ValidateSchemaExists(
+		ctx,
+		t,
+		s,
+		sqlDB,
+		keys.TenantUsageTableID,
+		systemschema.TenantUsageTable,
+		[]string{},
+		validationSchemas,
+		true, /* expectExists */
+	)
+}
+
+// getDeprecatedTenantUsageDescriptor returns the system.tenant_usage table
+// descriptor that was being used before adding a new column in the current
+// version.
+func getDeprecatedTenantUsageDescriptor() *descpb.TableDescriptor {
+	return &descpb.TableDescriptor{
+		Name:                    "tenant_usage",
+		ID:                      keys.TenantUsageTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []descpb.ColumnDescriptor{
+			{Name: "tenant_id", ID: 1, Type: types.Int},
+			{Name: "instance_id", ID: 2, Type: types.Int},
+			{Name: "next_instance_id", ID: 3, Type: types.Int},
+			{Name: "last_update", ID: 4, Type: types.Timestamp},
+			{Name: "ru_burst_limit", ID: 5, Type: types.Float, Nullable: true},
+			{Name: "ru_refill_rate", ID: 6, Type: types.Float, Nullable: true},
+			{Name: "ru_current", ID: 7, Type: types.Float, Nullable: true},
+			{Name: "current_share_sum", ID: 8, Type: types.Float, Nullable: true},
+			{Name: "total_consumption", ID: 9, Type: types.Bytes, Nullable: true},
+			{Name: "instance_lease", ID: 10, Type: types.Bytes, Nullable: true},
+			{Name: "instance_seq", ID: 11, Type: types.Int, Nullable: true},
+			{Name: "instance_shares", ID: 12, Type: types.Float, Nullable: true},
+		},
+		NextColumnID: 13,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{
+				Name: "primary",
+				ColumnNames: []string{
+					"tenant_id", "instance_id", "next_instance_id", "last_update",
+					"ru_burst_limit", "ru_refill_rate", "ru_current", "current_share_sum",
+					"total_consumption",
+					"instance_lease", "instance_seq", "instance_shares",
+				},
+				ColumnIDs: []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: descpb.IndexDescriptor{
+			Name:           "primary",
+			ID:             1,
+			Unique:         true,
+			KeyColumnNames: []string{"tenant_id", "instance_id"},
+			KeyColumnIDs:   []descpb.ColumnID{1, 2},
+			KeyColumnDirections: []descpb.IndexDescriptor_Direction{
+				descpb.IndexDescriptor_ASC,
+				descpb.IndexDescriptor_ASC,
+			},
+		},
+		NextIndexID:    2,
+		Privileges:     catpb.NewCustomSuperuserPrivilegeDescriptor(privilege.ReadWriteData, security.NodeUserName()),
+		NextMutationID: 1,
+		FormatVersion:  3,
+	}
+}
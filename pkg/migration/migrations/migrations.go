@@ -125,6 +125,12 @@ var migrations = []migration.Migration{
 		NoPrecondition,
 		tenantSettingsTableMigration,
 	),
+	migration.NewTenantMigration(
+		"add column storage_quota_bytes to system.tenant_usage",
+		toCV(clusterversion.TenantStorageQuotas),
+		NoPrecondition,
+		alterTableTenantUsageAddStorageQuota,
+	),
 }
 
 func init() {
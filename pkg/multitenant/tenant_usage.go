@@ -63,6 +63,13 @@ type TenantUsageServer interface {
 		asOfConsumedRequestUnits float64,
 	) error
 
+	// ReconfigureStorageQuota updates the maximum number of logical bytes that
+	// a tenant can store; a quota of 0 removes the limit. The quota is enforced
+	// by the KV layer once the stores pick up the new value.
+	ReconfigureStorageQuota(
+		ctx context.Context, txn *kv.Txn, tenantID roachpb.TenantID, quotaBytes int64,
+	) error
+
 	// Metrics returns the top-level metrics.
 	Metrics() metric.Struct
 }
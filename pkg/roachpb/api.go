@@ -148,12 +148,44 @@ func IsLocking(args Request) bool {
 }
 
 // LockingDurability returns the durability of the locks acquired by the
-// request. The function assumes that IsLocking(args).
+// request. Locking reads acquire Unreplicated locks unless their
+// KeyLockingReplicated field is set, while all other locking requests acquire
+// Replicated locks. The function assumes that IsLocking(args).
 func LockingDurability(args Request) lock.Durability {
-	if IsReadOnly(args) {
-		return lock.Unreplicated
+	switch t := args.(type) {
+	case *GetRequest:
+		return readLockDurability(t.KeyLockingReplicated)
+	case *ScanRequest:
+		return readLockDurability(t.KeyLockingReplicated)
+	case *ReverseScanRequest:
+		return readLockDurability(t.KeyLockingReplicated)
+	default:
+		return lock.Replicated
+	}
+}
+
+func readLockDurability(replicated bool) lock.Durability {
+	if replicated {
+		return lock.Replicated
+	}
+	return lock.Unreplicated
+}
+
+// LockingStrength returns the strength of the locks acquired by the request.
+// Locking reads acquire locks of the strength requested in their KeyLocking
+// field, while all other locking requests acquire Exclusive locks. The
+// function assumes that IsLocking(args).
+func LockingStrength(args Request) lock.Strength {
+	switch t := args.(type) {
+	case *GetRequest:
+		return t.KeyLocking
+	case *ScanRequest:
+		return t.KeyLocking
+	case *ReverseScanRequest:
+		return t.KeyLocking
+	default:
+		return lock.Exclusive
 	}
-	return lock.Replicated
 }
 
 // IsIntentWrite returns true if the request produces write intents at
@@ -1183,15 +1215,21 @@ func scanLockStrength(forUpdate bool) lock.Strength {
 	return lock.None
 }
 
-func flagForLockStrength(l lock.Strength) flag {
-	if l != lock.None {
-		return isLocking
+// flagForLockStrength returns the flags of a read that acquires locks of the
+// provided strength. Replicated locks are written to the replicated lock
+// table, so reads that acquire them are also writes.
+func flagForLockStrength(l lock.Strength, replicated bool) flag {
+	if l == lock.None {
+		return 0
 	}
-	return 0
+	if replicated {
+		return isLocking | isWrite
+	}
+	return isLocking
 }
 
 func (gr *GetRequest) flags() flag {
-	maybeLocking := flagForLockStrength(gr.KeyLocking)
+	maybeLocking := flagForLockStrength(gr.KeyLocking, gr.KeyLockingReplicated)
 	return isRead | isTxn | maybeLocking | updatesTSCache | needsRefresh
 }
 
@@ -1250,6 +1288,11 @@ func (drr *DeleteRangeRequest) flags() flag {
 	if drr.Inline {
 		return isRead | isWrite | isRange | isAlone
 	}
+	// Similarly, deletions using MVCC range tombstones can't be transactional,
+	// but they must update the timestamp cache to prevent writes below them.
+	if drr.UseRangeTombstone {
+		return isRead | isWrite | isRange | isAlone | appliesTSCache | updatesTSCache
+	}
 	// DeleteRange updates the timestamp cache as it doesn't leave intents or
 	// tombstones for keys which don't yet exist or keys that already have
 	// tombstones on them, but still wants to prevent anybody from writing under
@@ -1273,12 +1316,12 @@ func (*RevertRangeRequest) flags() flag {
 }
 
 func (sr *ScanRequest) flags() flag {
-	maybeLocking := flagForLockStrength(sr.KeyLocking)
+	maybeLocking := flagForLockStrength(sr.KeyLocking, sr.KeyLockingReplicated)
 	return isRead | isRange | isTxn | maybeLocking | updatesTSCache | needsRefresh
 }
 
 func (rsr *ReverseScanRequest) flags() flag {
-	maybeLocking := flagForLockStrength(rsr.KeyLocking)
+	maybeLocking := flagForLockStrength(rsr.KeyLocking, rsr.KeyLockingReplicated)
 	return isRead | isRange | isReverse | isTxn | maybeLocking | updatesTSCache | needsRefresh
 }
 
@@ -1471,6 +1514,9 @@ func (e *RangeFeedEvent) ShallowCopy() *RangeFeedEvent {
 	case *RangeFeedSSTable:
 		cpySST := *t
 		cpy.MustSetValue(&cpySST)
+	case *RangeFeedDeleteRange:
+		cpyDelRange := *t
+		cpy.MustSetValue(&cpyDelRange)
 	case *RangeFeedError:
 		cpyErr := *t
 		cpy.MustSetValue(&cpyErr)
@@ -1562,6 +1608,18 @@ func (rc ReplicationChanges) NonVoterRemovals() []ReplicationTarget {
 	return rc.byType(REMOVE_NON_VOTER)
 }
 
+// WitnessAdditions returns a slice of all contained replication changes that
+// add witnesses.
+func (rc ReplicationChanges) WitnessAdditions() []ReplicationTarget {
+	return rc.byType(ADD_WITNESS)
+}
+
+// WitnessRemovals returns a slice of all contained replication changes that
+// remove witnesses.
+func (rc ReplicationChanges) WitnessRemovals() []ReplicationTarget {
+	return rc.byType(REMOVE_WITNESS)
+}
+
 // Changes returns the changes requested by this AdminChangeReplicasRequest, taking
 // the deprecated method of doing so into account.
 func (acrr *AdminChangeReplicasRequest) Changes() []ReplicationChange {
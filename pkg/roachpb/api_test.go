@@ -321,6 +321,9 @@ func TestFlagCombinations(t *testing.T) {
 		&GetRequest{KeyLocking: lock.Exclusive},
 		&ReverseScanRequest{KeyLocking: lock.Exclusive},
 		&ScanRequest{KeyLocking: lock.Exclusive},
+		&GetRequest{KeyLocking: lock.Exclusive, KeyLockingReplicated: true},
+		&ReverseScanRequest{KeyLocking: lock.Exclusive, KeyLockingReplicated: true},
+		&ScanRequest{KeyLocking: lock.Exclusive, KeyLockingReplicated: true},
 	}
 
 	reqTypes := []Request{}
@@ -236,6 +236,8 @@ func TestLockSpanIterate(t *testing.T) {
 		{&GetRequest{KeyLocking: lock.Exclusive}, &GetResponse{}, sp("l", ""), sp("", "")},
 		{&ScanRequest{KeyLocking: lock.Exclusive}, &ScanResponse{}, sp("m", "o"), sp("n", "o")},
 		{&ReverseScanRequest{KeyLocking: lock.Exclusive}, &ReverseScanResponse{}, sp("p", "r"), sp("q", "r")},
+		{&GetRequest{KeyLocking: lock.Exclusive, KeyLockingReplicated: true}, &GetResponse{}, sp("s", ""), sp("", "")},
+		{&ScanRequest{KeyLocking: lock.Exclusive, KeyLockingReplicated: true}, &ScanResponse{}, sp("t", "v"), sp("u", "v")},
 	}
 
 	// NB: can't import testutils for RunTrueAndFalse.
@@ -271,8 +273,9 @@ func TestLockSpanIterate(t *testing.T) {
 				return exp
 			}
 
-			// The intent writes are replicated locking request.
-			require.Equal(t, toExpSpans(testReqs[3], testReqs[4]), spans[lock.Replicated])
+			// The intent writes and the scans with KeyLockingReplicated are
+			// replicated locking requests.
+			require.Equal(t, toExpSpans(testReqs[3], testReqs[4], testReqs[8], testReqs[9]), spans[lock.Replicated])
 
 			// The scans with KeyLocking are unreplicated locking requests.
 			require.Equal(t, toExpSpans(testReqs[5], testReqs[6], testReqs[7]), spans[lock.Unreplicated])
@@ -1649,6 +1649,11 @@ func confChangeImpl(
 			if err := checkNotExists(rDesc); err != nil {
 				return nil, err
 			}
+		case WITNESS:
+			// Witnesses are always removed directly, without joint consensus.
+			if err := checkNotExists(rDesc); err != nil {
+				return nil, err
+			}
 		default:
 			return nil, errors.Errorf("can't remove replica in state %v", rDesc.GetType())
 		}
@@ -1671,6 +1676,10 @@ func confChangeImpl(
 			// We're adding a voter, but will transition into a joint config
 			// first.
 			changeType = raftpb.ConfChangeAddNode
+		case WITNESS:
+			// We're adding a witness, which is a voter as far as raft is
+			// concerned. Witnesses are never added through joint consensus.
+			changeType = raftpb.ConfChangeAddNode
 		case LEARNER, NON_VOTER:
 			// We're adding a learner or non-voter.
 			// Note that we're guaranteed by virtue of the upstream ChangeReplicas txn
@@ -2052,9 +2061,11 @@ func AsIntents(txn *enginepb.TxnMeta, keys []Key) []Intent {
 }
 
 // MakeLockAcquisition makes a lock acquisition message from the given
-// txn, key, and durability level.
-func MakeLockAcquisition(txn *Transaction, key Key, dur lock.Durability) LockAcquisition {
-	return LockAcquisition{Span: Span{Key: key}, Txn: txn.TxnMeta, Durability: dur}
+// txn, key, durability level, and lock strength.
+func MakeLockAcquisition(
+	txn *Transaction, key Key, dur lock.Durability, str lock.Strength,
+) LockAcquisition {
+	return LockAcquisition{Span: Span{Key: key}, Txn: txn.TxnMeta, Durability: dur, Strength: str}
 }
 
 // MakeLockUpdate makes a lock update from the given txn and span.
@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -463,9 +464,11 @@ func (r ReplicaType) SafeValue() {}
 // config (or, simply is a voter if the range is not in a joint-config state).
 // Can be used as a filter for
 // ReplicaDescriptors.Filter(ReplicaDescriptor.IsVoterOldConfig).
+//
+// Note that WITNESS replicas are voters as far as raft is concerned.
 func (r ReplicaDescriptor) IsVoterOldConfig() bool {
 	switch r.GetType() {
-	case VOTER_FULL, VOTER_OUTGOING, VOTER_DEMOTING_NON_VOTER, VOTER_DEMOTING_LEARNER:
+	case VOTER_FULL, WITNESS, VOTER_OUTGOING, VOTER_DEMOTING_NON_VOTER, VOTER_DEMOTING_LEARNER:
 		return true
 	default:
 		return false
@@ -476,9 +479,11 @@ func (r ReplicaDescriptor) IsVoterOldConfig() bool {
 // config (or, simply is a voter if the range is not in a joint-config state).
 // Can be used as a filter for
 // ReplicaDescriptors.Filter(ReplicaDescriptor.IsVoterOldConfig).
+//
+// Note that WITNESS replicas are voters as far as raft is concerned.
 func (r ReplicaDescriptor) IsVoterNewConfig() bool {
 	switch r.GetType() {
-	case VOTER_FULL, VOTER_INCOMING:
+	case VOTER_FULL, WITNESS, VOTER_INCOMING:
 		return true
 	default:
 		return false
@@ -490,7 +495,7 @@ func (r ReplicaDescriptor) IsVoterNewConfig() bool {
 // for ReplicaDescriptors.Filter(ReplicaDescriptor.IsVoterOldConfig).
 func (r ReplicaDescriptor) IsAnyVoter() bool {
 	switch r.GetType() {
-	case VOTER_FULL, VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING_NON_VOTER, VOTER_DEMOTING_LEARNER:
+	case VOTER_FULL, WITNESS, VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING_NON_VOTER, VOTER_DEMOTING_LEARNER:
 		return true
 	default:
 		return false
@@ -560,12 +565,12 @@ func (sc StoreCapacity) String() string {
 // SafeFormat implements the redact.SafeFormatter interface.
 func (sc StoreCapacity) SafeFormat(w redact.SafePrinter, _ rune) {
 	w.Printf("disk (capacity=%s, available=%s, used=%s, logicalBytes=%s), "+
-		"ranges=%d, leases=%d, queries=%.2f, writes=%.2f, readAmplification=%d"+
+		"ranges=%d, leases=%d, queries=%.2f, writes=%.2f, cpu=%s, readAmplification=%d"+
 		"bytesPerReplica={%s}, writesPerReplica={%s}",
 		humanizeutil.IBytes(sc.Capacity), humanizeutil.IBytes(sc.Available),
 		humanizeutil.IBytes(sc.Used), humanizeutil.IBytes(sc.LogicalBytes),
 		sc.RangeCount, sc.LeaseCount, sc.QueriesPerSecond, sc.WritesPerSecond,
-		sc.ReadAmplification, sc.BytesPerReplica, sc.WritesPerReplica)
+		time.Duration(sc.CPUPerSecond), sc.ReadAmplification, sc.BytesPerReplica, sc.WritesPerReplica)
 }
 
 // FractionUsed computes the fraction of storage capacity that is in use.
@@ -60,6 +60,13 @@ func ReplicaTypeNonVoter() *ReplicaType {
 	return &t
 }
 
+// ReplicaTypeWitness returns a WITNESS pointer suitable for use in a nullable
+// proto field.
+func ReplicaTypeWitness() *ReplicaType {
+	t := WITNESS
+	return &t
+}
+
 // ReplicaSet is a set of replicas, usually the nodes/stores on which
 // replicas of a range are stored.
 type ReplicaSet struct {
@@ -122,6 +129,14 @@ func predNonVoter(rDesc ReplicaDescriptor) bool {
 	return rDesc.GetType() == NON_VOTER
 }
 
+func predWitness(rDesc ReplicaDescriptor) bool {
+	return rDesc.GetType() == WITNESS
+}
+
+func predNotWitness(rDesc ReplicaDescriptor) bool {
+	return !predWitness(rDesc)
+}
+
 func predVoterOrNonVoter(rDesc ReplicaDescriptor) bool {
 	return predVoterFullOrIncoming(rDesc) || predNonVoter(rDesc)
 }
@@ -136,6 +151,14 @@ func predVoterFullOrNonVoter(rDesc ReplicaDescriptor) bool {
 // not be returned even though they do in the current state retain their voting
 // rights.
 //
+// WITNESS replicas are not returned either, even though they are voters as far
+// as Raft is concerned (see ReplicaDescriptor.IsAnyVoter). The callers of
+// Voters are interested in the voters that hold the range's data: the ones
+// that can hold the lease, that count towards the range's num_voters, or that
+// can be relocated. Callers interested in quorum, such as ReplicationStatus,
+// must use IsVoterOldConfig and IsVoterNewConfig instead. Store range counts
+// include witnesses, since they are replicas on the store all the same.
+//
 // This may allocate, but it also may return the underlying slice as a
 // performance optimization, so it's not safe to modify the returned value.
 //
@@ -147,7 +170,7 @@ func (d ReplicaSet) Voters() ReplicaSet {
 }
 
 // VoterDescriptors returns the descriptors of current and future voter replicas
-// in the set.
+// in the set. Like Voters, it excludes witnesses.
 func (d ReplicaSet) VoterDescriptors() []ReplicaDescriptor {
 	return d.FilterToDescriptors(predVoterFullOrIncoming)
 }
@@ -255,6 +278,26 @@ func (d ReplicaSet) NonVoterDescriptors() []ReplicaDescriptor {
 	return d.FilterToDescriptors(predNonVoter)
 }
 
+// Witnesses returns a ReplicaSet containing only the witnesses in `d`.
+// Witnesses vote in the range's raft group like full voters, but they don't
+// apply commands and hold no user data. Notably, they are not returned by
+// Voters() or VoterDescriptors(), which are used to determine the replicas that
+// hold the data of a range; use IsAnyVoter to account for their votes.
+func (d ReplicaSet) Witnesses() ReplicaSet {
+	return d.Filter(predWitness)
+}
+
+// WitnessDescriptors returns the witness replica descriptors in the set.
+func (d ReplicaSet) WitnessDescriptors() []ReplicaDescriptor {
+	return d.FilterToDescriptors(predWitness)
+}
+
+// DataReplicaDescriptors returns the descriptors of all replicas in the set
+// that (will) hold the range's data, i.e. every replica except witnesses.
+func (d ReplicaSet) DataReplicaDescriptors() []ReplicaDescriptor {
+	return d.FilterToDescriptors(predNotWitness)
+}
+
 // VoterFullAndNonVoterDescriptors returns the descriptors of
 // VOTER_FULL/NON_VOTER replicas in the set. This set will not contain learners
 // or, during an atomic replication change, incoming or outgoing voters.
@@ -351,7 +394,7 @@ func (d ReplicaSet) InAtomicReplicationChange() bool {
 		case VOTER_INCOMING, VOTER_OUTGOING, VOTER_DEMOTING_LEARNER,
 			VOTER_DEMOTING_NON_VOTER:
 			return true
-		case VOTER_FULL, LEARNER, NON_VOTER:
+		case VOTER_FULL, LEARNER, NON_VOTER, WITNESS:
 		default:
 			panic(fmt.Sprintf("unknown replica type %d", rDesc.GetType()))
 		}
@@ -370,7 +413,9 @@ func (d ReplicaSet) ConfState() raftpb.ConfState {
 		id := uint64(rep.ReplicaID)
 		typ := rep.GetType()
 		switch typ {
-		case VOTER_FULL:
+		case VOTER_FULL, WITNESS:
+			// NB: Witnesses are only added and removed through simple config
+			// changes, so they are in both the incoming and the outgoing config.
 			cs.Voters = append(cs.Voters, id)
 			if joint {
 				cs.VotersOutgoing = append(cs.VotersOutgoing, id)
@@ -459,11 +504,22 @@ func (d ReplicaSet) ReplicationStatus(
 
 	res.Available = availableIncomingGroup && availableOutgoingGroup
 
-	// Determine over/under-replication. Note that learners don't matter.
-	underReplicatedOldGroup := len(liveVotersOldGroup) < neededVoters
-	underReplicatedNewGroup := len(liveVotersNewGroup) < neededVoters
-	overReplicatedOldGroup := len(votersOldGroup) > neededVoters
-	overReplicatedNewGroup := len(votersNewGroup) > neededVoters
+	// Determine over/under-replication. Note that learners don't matter, and
+	// neither do witnesses: they count towards availability above, but
+	// neededVoters refers to the voters holding the range's data.
+	numData := func(descs []ReplicaDescriptor) int {
+		var n int
+		for _, desc := range descs {
+			if !predWitness(desc) {
+				n++
+			}
+		}
+		return n
+	}
+	underReplicatedOldGroup := numData(liveVotersOldGroup) < neededVoters
+	underReplicatedNewGroup := numData(liveVotersNewGroup) < neededVoters
+	overReplicatedOldGroup := numData(votersOldGroup) > neededVoters
+	overReplicatedNewGroup := numData(votersNewGroup) > neededVoters
 	res.UnderReplicated = underReplicatedOldGroup || underReplicatedNewGroup
 	res.OverReplicated = overReplicatedOldGroup || overReplicatedNewGroup
 	return res
@@ -489,9 +545,9 @@ func (d ReplicaSet) ReplicationTargets() (out []ReplicationTarget) {
 // IsAddition returns true if `c` refers to a replica addition operation.
 func (c ReplicaChangeType) IsAddition() bool {
 	switch c {
-	case ADD_NON_VOTER, ADD_VOTER:
+	case ADD_NON_VOTER, ADD_VOTER, ADD_WITNESS:
 		return true
-	case REMOVE_NON_VOTER, REMOVE_VOTER:
+	case REMOVE_NON_VOTER, REMOVE_VOTER, REMOVE_WITNESS:
 		return false
 	default:
 		panic(fmt.Sprintf("unexpected ReplicaChangeType %s", c))
@@ -501,9 +557,9 @@ func (c ReplicaChangeType) IsAddition() bool {
 // IsRemoval returns true if `c` refers a replica removal operation.
 func (c ReplicaChangeType) IsRemoval() bool {
 	switch c {
-	case ADD_NON_VOTER, ADD_VOTER:
+	case ADD_NON_VOTER, ADD_VOTER, ADD_WITNESS:
 		return false
-	case REMOVE_NON_VOTER, REMOVE_VOTER:
+	case REMOVE_NON_VOTER, REMOVE_VOTER, REMOVE_WITNESS:
 		return true
 	default:
 		panic(fmt.Sprintf("unexpected ReplicaChangeType %s", c))
@@ -525,11 +581,14 @@ var errReplicaCannotHoldLease = errors.Errorf("replica cannot hold lease")
 // latencies. Additionally, as of the time of writing, learner replicas are
 // only used for a short time in replica addition, so it's not worth working
 // out the edge cases.
+//
+// WITNESS replicas can never hold the lease since they don't have the data to
+// serve requests.
 func CheckCanReceiveLease(wouldbeLeaseholder ReplicaDescriptor, rngDesc *RangeDescriptor) error {
 	repDesc, ok := rngDesc.GetReplicaDescriptorByID(wouldbeLeaseholder.ReplicaID)
 	if !ok {
 		return errReplicaNotFound
-	} else if !repDesc.IsVoterNewConfig() {
+	} else if !repDesc.IsVoterNewConfig() || repDesc.GetType() == WITNESS {
 		return errReplicaCannotHoldLease
 	}
 	return nil
@@ -43,6 +43,7 @@ var vi = ReplicaTypeVoterIncoming()
 var vo = ReplicaTypeVoterOutgoing()
 var vd = ReplicaTypeVoterDemotingLearner()
 var l = ReplicaTypeLearner()
+var w = ReplicaTypeWitness()
 
 func TestVotersLearnersAll(t *testing.T) {
 
@@ -198,6 +199,18 @@ func TestReplicaDescriptorsConfState(t *testing.T) {
 			[]ReplicaDescriptor{rd(vo, 1), rd(vd, 2), rd(vi, 3), rd(vi, 4), rd(l, 5)},
 			"Voters:[3 4] VotersOutgoing:[1 2] Learners:[5] LearnersNext:[2] AutoLeave:false",
 		},
+		// Witnesses are voters as far as raft is concerned.
+		{
+			[]ReplicaDescriptor{rd(v, 1), rd(v, 2), rd(w, 3)},
+			"Voters:[1 2 3] VotersOutgoing:[] Learners:[] LearnersNext:[] AutoLeave:false",
+		},
+		// Witnesses are only ever added or removed through simple config changes,
+		// so during an atomic replication change involving other replicas, they
+		// are in both the incoming and the outgoing config.
+		{
+			[]ReplicaDescriptor{rd(v, 1), rd(vo, 2), rd(vi, 3), rd(w, 4)},
+			"Voters:[1 3 4] VotersOutgoing:[1 2 4] Learners:[] LearnersNext:[] AutoLeave:false",
+		},
 	}
 
 	for _, test := range tests {
@@ -293,6 +306,18 @@ func TestReplicaDescriptorsCanMakeProgress(t *testing.T) {
 			{false, rd(l, 6)},
 			{false, rd(l, 7)},
 		}, true},
+		// One out of two voters dead, but the witness provides a quorum.
+		{[]descWithLiveness{
+			{true, rd(v, 1)},
+			{false, rd(v, 2)},
+			{true, rd(w, 3)},
+		}, true},
+		// One voter and the witness dead.
+		{[]descWithLiveness{
+			{true, rd(v, 1)},
+			{false, rd(v, 2)},
+			{false, rd(w, 3)},
+		}, false},
 		// Non-joint case that should be live unless the learner is somehow taken
 		// into account.
 		{[]descWithLiveness{
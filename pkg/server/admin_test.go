@@ -2511,3 +2511,148 @@ func TestAdminDecommissionedOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestRepairRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	// The authoritative replica lives in a region of its own, so that with
+	// snapshot delegation, the snapshots to the rebuilt replicas would be sent
+	// by one of the other replicas, which are closer to the recipient.
+	serverArgs := make(map[int]base.TestServerArgs)
+	for i := 0; i < 4; i++ {
+		region := "b"
+		if i == 1 {
+			region = "a"
+		}
+		serverArgs[i] = base.TestServerArgs{
+			Locality: roachpb.Locality{Tiers: []roachpb.Tier{{Key: "region", Value: region}}},
+		}
+	}
+	tc := serverutils.StartNewTestCluster(t, 4, base.TestClusterArgs{
+		ReplicationMode:   base.ReplicationManual,
+		ServerArgsPerNode: serverArgs,
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	scratchKey := tc.ScratchRange(t)
+	tc.AddVotersOrFatal(t, scratchKey, tc.Target(1), tc.Target(2))
+	before := tc.AddNonVotersOrFatal(t, scratchKey, tc.Target(3))
+	authoritative := tc.Target(1)
+	authoritativeStore, err := tc.Server(1).GetStores().(*kvserver.Stores).GetStore(authoritative.StoreID)
+	require.NoError(t, err)
+
+	adminSrv := tc.Server(0)
+	conn, err := adminSrv.RPCContext().GRPCDialNode(
+		adminSrv.RPCAddr(), adminSrv.NodeID(), rpc.DefaultClass).Connect(ctx)
+	require.NoError(t, err)
+	adminClient := serverpb.NewAdminClient(conn)
+
+	// The authoritative store must hold a replica of the range.
+	_, err = adminClient.RepairRange(ctx, &serverpb.RepairRangeRequest{
+		RangeID: before.RangeID, AuthoritativeStoreID: 42,
+	})
+	require.True(t, testutils.IsError(err, "s42 has no replica"), "%v", err)
+
+	for i := 0; i < tc.NumServers(); i++ {
+		kvserver.SnapshotDelegationEnabled.Override(ctx, &tc.Server(i).ClusterSettings().SV, true)
+	}
+	resp, err := adminClient.RepairRange(ctx, &serverpb.RepairRangeRequest{
+		RangeID: before.RangeID, AuthoritativeStoreID: authoritative.StoreID,
+	})
+	require.NoError(t, err)
+	after := resp.RangeDescriptor
+	require.Equal(t, after, tc.LookupRangeOrFatal(t, scratchKey))
+
+	// Every replica lives on the same store with the same type as before, but
+	// all except the authoritative one were replaced.
+	require.Len(t, after.Replicas().Descriptors(), len(before.Replicas().Descriptors()))
+	for _, old := range before.Replicas().Descriptors() {
+		repl, ok := after.GetReplicaDescriptor(old.StoreID)
+		require.True(t, ok, "replica %s missing from %s", old, after)
+		require.Equal(t, old.GetType(), repl.GetType())
+		if old.StoreID == authoritative.StoreID {
+			require.Equal(t, old.ReplicaID, repl.ReplicaID)
+		} else {
+			require.Greater(t, repl.ReplicaID, old.ReplicaID)
+		}
+	}
+	leaseholder, err := tc.FindRangeLeaseHolder(after, nil /* hint */)
+	require.NoError(t, err)
+	require.Equal(t, authoritative, leaseholder)
+	// The snapshots were sent by the authoritative replica despite snapshot
+	// delegation being enabled.
+	metrics := authoritativeStore.Metrics()
+	require.Zero(t, metrics.RangeSnapshotsDelegateSuccesses.Count())
+	require.Zero(t, metrics.RangeSnapshotsDelegateFailures.Count())
+
+	// Start, lease transfer, a removal and an addition per rebuilt replica,
+	// and finish.
+	var count int
+	db := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	db.QueryRow(t, `SELECT count(*) FROM system.rangelog WHERE "rangeID" = $1 AND "eventType" = $2`,
+		after.RangeID, kvserverpb.RangeLogEventType_repair_range.String()).Scan(&count)
+	require.Equal(t, 3+2*(len(before.Replicas().Descriptors())-1), count)
+}
+
+// TestRepairRangeLeaseMoved verifies that a range repair moves the lease back
+// to the authoritative replica when it is moved away in the middle of the
+// repair, before rebuilding any more replicas.
+func TestRepairRangeLeaseMoved(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := serverutils.StartNewTestCluster(t, 3, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+	})
+	defer tc.Stopper().Stop(ctx)
+
+	scratchKey := tc.ScratchRange(t)
+	before := tc.AddVotersOrFatal(t, scratchKey, tc.Target(1), tc.Target(2))
+	authoritative := tc.Target(1)
+
+	// Move the lease to s3 once the replica on s1 has been removed, as the
+	// replicate queue could.
+	sqlDB := tc.ServerConn(0)
+	var movedLease bool
+	sqlExec := func(ctx context.Context, stmt string, args ...interface{}) (int, error) {
+		res, err := sqlDB.ExecContext(ctx, stmt, args...)
+		if err != nil {
+			return 0, err
+		}
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if !movedLease && strings.Contains(args[5].(string), "removed diverged replica") {
+			movedLease = true
+			if err := tc.TransferRangeLease(before, tc.Target(2)); err != nil {
+				return 0, err
+			}
+		}
+		return int(rows), nil
+	}
+	after, err := repairRange(ctx, tc.Server(0).DB(), sqlExec, before.RangeID, authoritative.StoreID)
+	require.NoError(t, err)
+	require.True(t, movedLease)
+	require.Equal(t, after, tc.LookupRangeOrFatal(t, scratchKey))
+	for _, old := range before.Replicas().Descriptors() {
+		repl, ok := after.GetReplicaDescriptor(old.StoreID)
+		require.True(t, ok, "replica %s missing from %s", old, after)
+		if old.StoreID != authoritative.StoreID {
+			require.Greater(t, repl.ReplicaID, old.ReplicaID)
+		}
+	}
+	leaseholder, err := tc.FindRangeLeaseHolder(after, nil /* hint */)
+	require.NoError(t, err)
+	require.Equal(t, authoritative, leaseholder)
+
+	// The lease was moved back once, before re-adding the replica on s1.
+	var count int
+	db := sqlutils.MakeSQLRunner(sqlDB)
+	db.QueryRow(t, `SELECT count(*) FROM system.rangelog WHERE "rangeID" = $1 AND "eventType" = $2 AND info::JSONB->>'Details' LIKE 'transferred lease back%'`,
+		after.RangeID, kvserverpb.RangeLogEventType_repair_range.String()).Scan(&count)
+	require.Equal(t, 1, count)
+}
@@ -332,6 +332,11 @@ type KVConfig struct {
 	DelayedBootstrapFn func()
 
 	enginesCreated bool
+	// raftEngines maps the engine of each store which keeps its Raft log in a
+	// separate engine (see base.StoreSpec.RaftLogPath) to that engine. It is
+	// populated by CreateEngines, and the engines in it are closed along with
+	// the store engines.
+	raftEngines map[storage.Engine]storage.Engine
 }
 
 // MakeKVConfig returns a KVConfig with default values.
@@ -511,6 +516,8 @@ func (e *Engines) Close() {
 func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 	engines := Engines(nil)
 	defer engines.Close()
+	raftEngines := Engines(nil)
+	defer raftEngines.Close()
 
 	if cfg.enginesCreated {
 		return Engines{}, errors.Errorf("engines already created")
@@ -525,6 +532,9 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 		if !spec.InMemory {
 			physicalStores++
 		}
+		if spec.RaftLogPath != "" {
+			physicalStores++
+		}
 	}
 	openFileLimitPerStore, err := setOpenFileLimit(physicalStores)
 	if err != nil {
@@ -540,6 +550,7 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 		tableCache = pebble.NewTableCache(pebbleCache, runtime.GOMAXPROCS(0), totalFileLimit)
 	}
 
+	raftEngineMap := map[storage.Engine]storage.Engine{}
 	skipSizeCheck := cfg.TestingKnobs.Store != nil &&
 		cfg.TestingKnobs.Store.(*kvserver.StoreTestingKnobs).SkipMinSizeCheck
 	for i, spec := range cfg.Stores.Specs {
@@ -641,6 +652,34 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			}
 			details = append(details, redact.Sprintf("store %d: %+v", i, eng.Properties()))
 			engines = append(engines, eng)
+
+			if spec.RaftLogPath != "" {
+				if err := vfs.Default.MkdirAll(spec.RaftLogPath, 0755); err != nil {
+					return Engines{}, errors.Wrap(err, "creating raft log directory")
+				}
+				// The Raft engine shares the caches and encryption settings of the
+				// store, but is not subject to its size limits.
+				raftConfig := storage.PebbleConfig{
+					StorageConfig: base.StorageConfig{
+						Attrs:             spec.Attributes,
+						Dir:               spec.RaftLogPath,
+						Settings:          cfg.Settings,
+						UseFileRegistry:   spec.UseFileRegistry,
+						EncryptionOptions: spec.EncryptionOptions,
+					},
+					Opts: storage.DefaultPebbleOptions(),
+				}
+				raftConfig.Opts.Cache = pebbleCache
+				raftConfig.Opts.TableCache = tableCache
+				raftConfig.Opts.MaxOpenFiles = int(openFileLimitPerStore)
+				raftEng, err := storage.NewPebble(ctx, raftConfig)
+				if err != nil {
+					return Engines{}, errors.Wrapf(err, "store %d: opening raft log engine", i)
+				}
+				details = append(details, redact.Sprintf("store %d: raft log at %s", i, spec.RaftLogPath))
+				raftEngines = append(raftEngines, raftEng)
+				raftEngineMap[eng] = raftEng
+			}
 		}
 	}
 
@@ -656,6 +695,8 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 	for _, s := range details {
 		log.Infof(ctx, "%v", s)
 	}
+	cfg.raftEngines = raftEngineMap
+	raftEngines = nil
 	enginesCopy := engines
 	engines = nil
 	return enginesCopy, nil
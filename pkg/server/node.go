@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvtenant"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstorage"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
@@ -762,16 +763,47 @@ func (n *Node) computePeriodicMetrics(ctx context.Context, tick int) error {
 
 // GetPebbleMetrics implements admission.PebbleMetricsProvider.
 func (n *Node) GetPebbleMetrics() []admission.StoreMetrics {
+	ctx := n.AnnotateCtx(context.Background())
+	// If the disk stats can't be read, they are unavailable for every store,
+	// which makes admission control skip the disk bandwidth adjustment for
+	// this interval rather than see the disks as idle.
+	diskBytes, err := status.GetDiskReadWriteBytes(ctx)
+	if err != nil {
+		log.Warningf(ctx, "unable to get disk stats for admission control: %v", err)
+	}
 	var metrics []admission.StoreMetrics
 	_ = n.stores.VisitStores(func(store *kvserver.Store) error {
 		m := store.Engine().GetMetrics()
-		metrics = append(
-			metrics, admission.StoreMetrics{StoreID: int32(store.StoreID()), Metrics: m.Metrics})
+		metrics = append(metrics, admission.StoreMetrics{
+			StoreID:   int32(store.StoreID()),
+			Metrics:   m.Metrics,
+			DiskStats: storeDiskStats(store.Engine().Properties(), diskBytes),
+		})
 		return nil
 	})
 	return metrics
 }
 
+// storeDiskStats returns the stats of the disk backing the store with the
+// given properties, which are unavailable if the store is in-memory, or if
+// its block device isn't found among the disks of this machine (which is the
+// case on macOS, for example).
+func storeDiskStats(
+	props roachpb.StoreProperties, diskBytes map[string]status.DiskReadWriteBytes,
+) admission.DiskStats {
+	if props.FileStoreProperties == nil || props.FileStoreProperties.BlockDevice == "" {
+		return admission.DiskStats{Unavailable: true}
+	}
+	b, ok := diskBytes[status.DiskName(props.FileStoreProperties.BlockDevice)]
+	if !ok {
+		return admission.DiskStats{Unavailable: true}
+	}
+	return admission.DiskStats{
+		BytesRead:    uint64(b.ReadBytes),
+		BytesWritten: uint64(b.WriteBytes),
+	}
+}
+
 func (n *Node) startGraphiteStatsExporter(st *cluster.Settings) {
 	ctx := logtags.AddTag(n.AnnotateCtx(context.Background()), "graphite stats exporter", nil)
 	pm := metric.MakePrometheusExporter()
@@ -1547,6 +1579,7 @@ var NewTenantUsageServer = func(
 	settings *cluster.Settings,
 	db *kv.DB,
 	executor *sql.InternalExecutor,
+	tenantStorage *tenantstorage.Tracker,
 ) multitenant.TenantUsageServer {
 	return dummyTenantUsageServer{}
 }
@@ -1578,6 +1611,13 @@ func (dummyTenantUsageServer) ReconfigureTokenBucket(
 	return errors.Errorf("tenant resource limits require a CCL binary")
 }
 
+// ReconfigureStorageQuota is defined in the TenantUsageServer interface.
+func (dummyTenantUsageServer) ReconfigureStorageQuota(
+	ctx context.Context, txn *kv.Txn, tenantID roachpb.TenantID, quotaBytes int64,
+) error {
+	return errors.Errorf("tenant storage quotas require a CCL binary")
+}
+
 // Metrics is defined in the TenantUsageServer interface.
 func (dummyTenantUsageServer) Metrics() metric.Struct {
 	return emptyMetricStruct{}
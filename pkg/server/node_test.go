@@ -26,10 +26,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/status"
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -680,3 +682,28 @@ func TestNodeBatchRequestMetricsInc(t *testing.T) {
 	require.GreaterOrEqual(t, n.metrics.MethodCounts[roachpb.Get].Count(), getCurr)
 	require.GreaterOrEqual(t, n.metrics.MethodCounts[roachpb.Put].Count(), putCurr)
 }
+
+func TestStoreDiskStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	diskBytes := map[string]status.DiskReadWriteBytes{
+		"sda1": {ReadBytes: 1, WriteBytes: 2},
+		"sdb1": {ReadBytes: 3, WriteBytes: 4},
+	}
+	onDevice := func(device string) roachpb.StoreProperties {
+		return roachpb.StoreProperties{
+			FileStoreProperties: &roachpb.FileStoreProperties{BlockDevice: device},
+		}
+	}
+
+	// Each store gets the stats of the disk backing it.
+	require.Equal(t, admission.DiskStats{BytesRead: 3, BytesWritten: 4},
+		storeDiskStats(onDevice("/dev/sdb1"), diskBytes))
+	// The stats are unavailable for in-memory stores, stores whose disk is
+	// unknown, and when the disk stats couldn't be read at all.
+	unavailable := admission.DiskStats{Unavailable: true}
+	require.Equal(t, unavailable, storeDiskStats(roachpb.StoreProperties{}, diskBytes))
+	require.Equal(t, unavailable, storeDiskStats(onDevice(""), diskBytes))
+	require.Equal(t, unavailable, storeDiskStats(onDevice("/dev/sdc1"), diskBytes))
+	require.Equal(t, unavailable, storeDiskStats(onDevice("/dev/sda1"), nil))
+}
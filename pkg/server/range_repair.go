@@ -0,0 +1,317 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RepairRange rebuilds the replicas of a range that the consistency checker
+// found to have diverged. The replica on the authoritative store (picked by
+// the operator, typically after inspecting the consistency checkpoints with
+// `cockroach debug repair-range diff`) acquires the lease, and every other
+// replica is removed and re-added on the same store, which makes it catch up
+// through a snapshot of the authoritative replica. If the lease moves away
+// from the authoritative replica during the repair, it is moved back before
+// the next replica is removed or re-added. Each step is recorded in the range
+// log.
+//
+// Replicas are rebuilt one at a time so that the range stays available
+// throughout, provided it could make progress to begin with. The operation
+// can be re-run if it is interrupted; replicas that were already rebuilt
+// are rebuilt again, which is wasteful but harmless.
+func (s *adminServer) RepairRange(
+	ctx context.Context, req *serverpb.RepairRangeRequest,
+) (*serverpb.RepairRangeResponse, error) {
+	ctx = s.server.AnnotateCtx(ctx)
+
+	if _, err := s.requireAdminUser(ctx); err != nil {
+		// NB: not using serverError() here since the priv checker
+		// already returns a proper gRPC error status.
+		return nil, err
+	}
+
+	if req.RangeID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "range_id must be positive; got %d", req.RangeID)
+	}
+	if req.AuthoritativeStoreID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"authoritative_store_id must be positive; got %d", req.AuthoritativeStoreID)
+	}
+	if !s.server.st.Version.IsActive(ctx, clusterversion.RangeRepair) {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"range repair requires the cluster to be fully upgraded to version %s",
+			clusterversion.ByKey(clusterversion.RangeRepair))
+	}
+
+	sqlExec := func(ctx context.Context, stmt string, args ...interface{}) (int, error) {
+		return s.server.sqlServer.internalExecutor.ExecEx(ctx, "repair-range-log", nil, /* txn */
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()}, stmt, args...)
+	}
+	desc, err := repairRange(ctx, s.server.db, sqlExec, req.RangeID, req.AuthoritativeStoreID)
+	if err != nil {
+		log.Errorf(ctx, "repair of r%d failed: %v", req.RangeID, err)
+		return nil, err
+	}
+	return &serverpb.RepairRangeResponse{RangeDescriptor: desc}, nil
+}
+
+// repairRange implements the bulk of RepairRange. See there for details.
+func repairRange(
+	ctx context.Context,
+	db *kv.DB,
+	sqlExec func(ctx context.Context, stmt string, args ...interface{}) (int, error),
+	rangeID roachpb.RangeID,
+	authoritativeStoreID roachpb.StoreID,
+) (roachpb.RangeDescriptor, error) {
+	var desc *roachpb.RangeDescriptor
+	if err := db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) (err error) {
+		desc, err = kvclient.GetRangeWithID(ctx, txn, rangeID)
+		return err
+	}); err != nil {
+		return roachpb.RangeDescriptor{}, errors.Wrapf(err, "looking up descriptor of r%d", rangeID)
+	}
+	if desc == nil {
+		return roachpb.RangeDescriptor{}, errors.Errorf("r%d not found", rangeID)
+	}
+
+	authoritative, ok := desc.GetReplicaDescriptor(authoritativeStoreID)
+	if !ok {
+		return roachpb.RangeDescriptor{}, errors.Errorf(
+			"s%d has no replica of r%d: %s", authoritativeStoreID, rangeID, desc)
+	}
+	if typ := authoritative.GetType(); typ != roachpb.VOTER_FULL {
+		return roachpb.RangeDescriptor{}, errors.Errorf(
+			"authoritative replica %s must be a voter, not %s", authoritative, typ)
+	}
+	// Rebuilding a replica changes the configuration of the range, which must
+	// not be in the middle of another change.
+	for _, rd := range desc.Replicas().Descriptors() {
+		if typ := rd.GetType(); typ != roachpb.VOTER_FULL && typ != roachpb.NON_VOTER {
+			return roachpb.RangeDescriptor{}, errors.Errorf(
+				"replica %s of r%d is a %s; retry once pending replication changes are done",
+				rd, rangeID, typ)
+		}
+	}
+
+	logEvent := func(storeID roachpb.StoreID, added, removed *roachpb.ReplicaDescriptor, details string) error {
+		return logRangeRepairEvent(ctx, sqlExec, *desc, storeID, added, removed, details)
+	}
+	log.Infof(ctx, "repairing r%d from authoritative replica %s", rangeID, authoritative)
+	if err := logEvent(authoritativeStoreID, nil, nil, fmt.Sprintf(
+		"repair started with authoritative replica %s", authoritative)); err != nil {
+		return roachpb.RangeDescriptor{}, err
+	}
+
+	// Move the lease to the authoritative replica, which makes it the one to
+	// send the snapshots to the replicas being re-added (see changeReplicas).
+	if err := db.AdminTransferLease(ctx, desc.StartKey.AsRawKey(), authoritativeStoreID); err != nil {
+		return roachpb.RangeDescriptor{}, errors.Wrapf(err,
+			"transferring lease to authoritative replica %s", authoritative)
+	}
+	if err := logEvent(authoritativeStoreID, nil, nil, fmt.Sprintf(
+		"transferred lease to authoritative replica %s", authoritative)); err != nil {
+		return roachpb.RangeDescriptor{}, err
+	}
+
+	// ensureLease makes sure that the authoritative replica still holds the
+	// lease before the next configuration change. The lease may have been moved
+	// since it was transferred above, for instance by the replicate queue, and
+	// the Raft leadership typically follows it. If that happened, a replica
+	// being re-added could catch up from a snapshot sent by a diverged leader
+	// instead, so the lease is moved back first.
+	ensureLease := func() error {
+		leaseholder, err := leaseholderStoreID(ctx, db, *desc)
+		if err != nil {
+			return errors.Wrap(err, "looking up leaseholder")
+		}
+		if leaseholder == authoritativeStoreID {
+			return nil
+		}
+		log.Infof(ctx, "lease of r%d moved to s%d during repair; moving it back to %s",
+			rangeID, leaseholder, authoritative)
+		if err := db.AdminTransferLease(ctx, desc.StartKey.AsRawKey(), authoritativeStoreID); err != nil {
+			return errors.Wrapf(err,
+				"transferring lease back from s%d to authoritative replica %s", leaseholder, authoritative)
+		}
+		if leaseholder, err = leaseholderStoreID(ctx, db, *desc); err != nil {
+			return errors.Wrap(err, "looking up leaseholder")
+		}
+		if leaseholder != authoritativeStoreID {
+			return errors.Errorf(
+				"lease moved to s%d after transferring it back to authoritative replica %s",
+				leaseholder, authoritative)
+		}
+		return logEvent(authoritativeStoreID, nil, nil, fmt.Sprintf(
+			"transferred lease back to authoritative replica %s", authoritative))
+	}
+
+	toRebuild := append([]roachpb.ReplicaDescriptor(nil), desc.Replicas().Descriptors()...)
+	for _, rd := range toRebuild {
+		if rd.StoreID == authoritativeStoreID {
+			continue
+		}
+		target := roachpb.ReplicationTarget{NodeID: rd.NodeID, StoreID: rd.StoreID}
+		addType, removeType := roachpb.ADD_VOTER, roachpb.REMOVE_VOTER
+		if rd.GetType() == roachpb.NON_VOTER {
+			addType, removeType = roachpb.ADD_NON_VOTER, roachpb.REMOVE_NON_VOTER
+		}
+
+		if err := ensureLease(); err != nil {
+			return roachpb.RangeDescriptor{}, err
+		}
+		var err error
+		if desc, err = changeReplicas(
+			ctx, db, *desc, roachpb.MakeReplicationChanges(removeType, target),
+		); err != nil {
+			return roachpb.RangeDescriptor{}, errors.Wrapf(err, "removing replica %s", rd)
+		}
+		removed := rd
+		if err := logEvent(rd.StoreID, nil, &removed, "removed diverged replica"); err != nil {
+			return roachpb.RangeDescriptor{}, err
+		}
+
+		if err := ensureLease(); err != nil {
+			return roachpb.RangeDescriptor{}, err
+		}
+		if desc, err = changeReplicas(
+			ctx, db, *desc, roachpb.MakeReplicationChanges(addType, target),
+		); err != nil {
+			return roachpb.RangeDescriptor{}, errors.Wrapf(err, "re-adding replica on %s", target)
+		}
+		added, ok := desc.GetReplicaDescriptor(rd.StoreID)
+		if !ok {
+			return roachpb.RangeDescriptor{}, errors.AssertionFailedf(
+				"re-added replica on %s missing from %s", target, desc)
+		}
+		if err := logEvent(rd.StoreID, &added, nil, fmt.Sprintf(
+			"re-added replica from snapshot of authoritative replica %s", authoritative)); err != nil {
+			return roachpb.RangeDescriptor{}, err
+		}
+	}
+
+	if err := logEvent(authoritativeStoreID, nil, nil, "repair finished"); err != nil {
+		return roachpb.RangeDescriptor{}, err
+	}
+	log.Infof(ctx, "repaired r%d: %s", rangeID, desc)
+	return *desc, nil
+}
+
+// changeReplicas applies the given changes to the range through an
+// AdminChangeReplicas request. Unlike DB.AdminChangeReplicas, it makes the
+// leaseholder send the snapshots to any replica being added itself, since the
+// snapshot could otherwise be delegated to a replica that hasn't been rebuilt
+// yet and would copy its diverged data.
+func changeReplicas(
+	ctx context.Context,
+	db *kv.DB,
+	expDesc roachpb.RangeDescriptor,
+	chgs []roachpb.ReplicationChange,
+) (*roachpb.RangeDescriptor, error) {
+	req := &roachpb.AdminChangeReplicasRequest{
+		RequestHeader: roachpb.RequestHeader{
+			Key: expDesc.StartKey.AsRawKey(),
+		},
+		ExpDesc:                   expDesc,
+		DisableSnapshotDelegation: true,
+	}
+	req.AddChanges(chgs...)
+	resp, pErr := kv.SendWrapped(ctx, db.NonTransactionalSender(), req)
+	if pErr != nil {
+		return nil, pErr.GoError()
+	}
+	desc := resp.(*roachpb.AdminChangeReplicasResponse).Desc
+	return &desc, nil
+}
+
+// leaseholderStoreID returns the store holding the lease of the given range,
+// as seen by the leaseholder.
+func leaseholderStoreID(
+	ctx context.Context, db *kv.DB, desc roachpb.RangeDescriptor,
+) (roachpb.StoreID, error) {
+	req := &roachpb.LeaseInfoRequest{
+		RequestHeader: roachpb.RequestHeader{
+			Key: desc.StartKey.AsRawKey(),
+		},
+	}
+	resp, pErr := kv.SendWrapped(ctx, db.NonTransactionalSender(), req)
+	if pErr != nil {
+		return 0, pErr.GoError()
+	}
+	leaseResp := resp.(*roachpb.LeaseInfoResponse)
+	if leaseResp.CurrentLease != nil {
+		return leaseResp.CurrentLease.Replica.StoreID, nil
+	}
+	return leaseResp.Lease.Replica.StoreID, nil
+}
+
+// logRangeRepairEvent records a step of a range repair in the range log.
+func logRangeRepairEvent(
+	ctx context.Context,
+	sqlExec func(ctx context.Context, stmt string, args ...interface{}) (int, error),
+	desc roachpb.RangeDescriptor,
+	storeID roachpb.StoreID,
+	added, removed *roachpb.ReplicaDescriptor,
+	details string,
+) error {
+	const insertEventTableStmt = `
+	INSERT INTO system.rangelog (
+		timestamp, "rangeID", "storeID", "eventType", "otherRangeID", info
+	)
+	VALUES(
+		$1, $2, $3, $4, $5, $6
+	)
+	`
+	info := kvserverpb.RangeLogEvent_Info{
+		UpdatedDesc:    &desc,
+		AddedReplica:   added,
+		RemovedReplica: removed,
+		Reason:         kvserverpb.ReasonRangeRepair,
+		Details:        details,
+	}
+	infoBytes, err := json.Marshal(info)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize a RangeLog info entry")
+	}
+	args := []interface{}{
+		timeutil.Now(),
+		desc.RangeID,
+		storeID,
+		kvserverpb.RangeLogEventType_repair_range.String(),
+		nil, // otherRangeID
+		string(infoBytes),
+	}
+
+	rows, err := sqlExec(ctx, insertEventTableStmt, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert a RangeLog entry")
+	}
+	if rows != 1 {
+		return errors.Errorf("%d row(s) affected by RangeLog insert while expected 1", rows)
+	}
+	return nil
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptreconcile"
 	serverrangefeed "github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangefeed"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/reports"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstorage"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/rpc/nodedialer"
@@ -66,8 +67,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	_ "github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scjob" // register jobs declared outside of pkg/sql
-	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttljob"          // register jobs declared outside of pkg/sql
-	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttlschedule"     // register schedules declared outside of pkg/sql
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttljob"      // register jobs declared outside of pkg/sql
+	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttlschedule" // register schedules declared outside of pkg/sql
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/ts"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -134,6 +137,7 @@ type Server struct {
 
 	replicationReporter *reports.Reporter
 	protectedtsProvider protectedts.Provider
+	tenantStorage       *tenantstorage.Tracker
 
 	spanConfigSubscriber *spanconfigkvsubscriber.KVSubscriber
 
@@ -206,6 +210,11 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		return nil, errors.Wrap(err, "failed to create engines")
 	}
 	stopper.AddCloser(&engines)
+	stopper.AddCloser(stop.CloserFn(func() {
+		for _, raftEng := range cfg.raftEngines {
+			raftEng.Close()
+		}
+	}))
 
 	nodeTombStorage, checkPingFor := getPingCheckDecommissionFn(engines)
 
@@ -437,6 +446,8 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		/* deterministic */ false,
 	)
 
+	tenantStorage := tenantstorage.NewTracker(g)
+
 	raftTransport := kvserver.NewRaftTransport(
 		cfg.AmbientCtx, st, nodeDialer, grpcServer.Server, stopper,
 	)
@@ -544,6 +555,8 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		KVMemoryMonitor:          kvMemoryMonitor,
 		RangefeedBudgetFactory:   rangeReedBudgetFactory,
 		SystemConfigProvider:     systemConfigWatcher,
+		RaftEngines:              cfg.raftEngines,
+		TenantStorage:            tenantStorage,
 	}
 
 	var spanConfig struct {
@@ -632,7 +645,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		updates.TestingKnobs = &cfg.TestingKnobs.Server.(*TestingKnobs).DiagnosticsTestingKnobs
 	}
 
-	tenantUsage := NewTenantUsageServer(st, db, internalExecutor)
+	tenantUsage := NewTenantUsageServer(st, db, internalExecutor, tenantStorage)
 	registry.AddMetricStruct(tenantUsage.Metrics())
 
 	tenantSettingsWatcher := tenantsettingswatcher.New(
@@ -744,6 +757,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		sqlStatusServer:          sStatus,
 		regionsServer:            sStatus,
 		tenantUsageServer:        tenantUsage,
+		tenantStorage:            tenantStorage,
 		monitorAndMetrics:        sqlMonitorAndMetrics,
 		settingsStorage:          settingsWriter,
 	})
@@ -804,6 +818,7 @@ func NewServer(cfg Config, stopper *stop.Stopper) (*Server, error) {
 		kvProber:               kvProber,
 		replicationReporter:    replicationReporter,
 		protectedtsProvider:    protectedtsProvider,
+		tenantStorage:          tenantStorage,
 		spanConfigSubscriber:   spanConfig.subscriber,
 		sqlServer:              sqlServer,
 		externalStorageBuilder: externalStorageBuilder,
@@ -1498,6 +1513,8 @@ func (s *Server) PreStart(ctx context.Context) error {
 		return errors.Wrapf(err, "failed to start KV prober")
 	}
 
+	s.tenantStorage.Start(ctx, s.stopper, s.readTenantStorageQuotas)
+
 	// As final stage of loss of quorum recovery, write events into corresponding
 	// range logs. We do it as a separate stage to log events early just in case
 	// startup fails, and write to range log once the server is running as we need
@@ -1516,6 +1533,31 @@ func (s *Server) PreStart(ctx context.Context) error {
 	return maybeImportTS(ctx, s)
 }
 
+// readTenantStorageQuotas reads the storage quotas of the secondary tenants
+// from the system.tenant_usage table. It implements tenantstorage.QuotaReader.
+func (s *Server) readTenantStorageQuotas(
+	ctx context.Context,
+) (map[roachpb.TenantID]int64, error) {
+	if !s.st.Version.IsActive(ctx, clusterversion.TenantStorageQuotas) {
+		return nil, nil
+	}
+	rows, err := s.sqlServer.internalExecutor.QueryBufferedEx(
+		ctx, "read-tenant-storage-quotas", nil, /* txn */
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT tenant_id, storage_quota_bytes FROM system.tenant_usage
+		 WHERE instance_id = 0 AND storage_quota_bytes IS NOT NULL`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	quotas := make(map[roachpb.TenantID]int64, len(rows))
+	for _, row := range rows {
+		tenantID := roachpb.MakeTenantID(uint64(tree.MustBeDInt(row[0])))
+		quotas[tenantID] = int64(tree.MustBeDInt(row[1]))
+	}
+	return quotas, nil
+}
+
 // AcceptClients starts listening for incoming SQL clients over the network.
 func (s *Server) AcceptClients(ctx context.Context) error {
 	workersCtx := s.AnnotateCtx(context.Background())
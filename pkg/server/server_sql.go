@@ -34,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/tenantstorage"
 	"github.com/cockroachdb/cockroach/pkg/migration"
 	"github.com/cockroachdb/cockroach/pkg/migration/migrationcluster"
 	"github.com/cockroachdb/cockroach/pkg/migration/migrationmanager"
@@ -304,6 +305,9 @@ type sqlServerArgs struct {
 	// Used for multi-tenant cost control (on the host cluster side).
 	tenantUsageServer multitenant.TenantUsageServer
 
+	// Used to report the storage usage of tenants (on the host cluster side).
+	tenantStorage *tenantstorage.Tracker
+
 	// Used for multi-tenant cost control (on the tenant side).
 	costController multitenant.TenantSideCostController
 
@@ -697,6 +701,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		CompactEngineSpanFunc:   compactEngineSpanFunc,
 		TraceCollector:          traceCollector,
 		TenantUsageServer:       cfg.tenantUsageServer,
+		TenantStorage:           cfg.tenantStorage,
 
 		DistSQLPlanner: sql.NewDistSQLPlanner(
 			ctx,
@@ -28,8 +28,9 @@ func getDiskCounters(ctx context.Context) ([]diskStats, error) {
 
 	output := make([]diskStats, len(driveStats))
 	i := 0
-	for _, counters := range driveStats {
+	for name, counters := range driveStats {
 		output[i] = diskStats{
+			name:           name,
 			readBytes:      int64(counters.ReadBytes),
 			readCount:      int64(counters.ReadCount),
 			readTime:       time.Duration(counters.ReadTime) * time.Millisecond,
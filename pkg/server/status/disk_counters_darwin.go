@@ -28,6 +28,7 @@ func getDiskCounters(context.Context) ([]diskStats, error) {
 	output := make([]diskStats, len(driveStats))
 	for i, counters := range driveStats {
 		output[i] = diskStats{
+			name:           counters.Name,
 			readBytes:      counters.BytesRead,
 			readCount:      counters.NumRead,
 			readTime:       counters.TotalReadTime,
@@ -13,6 +13,7 @@ package status
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"time"
@@ -598,6 +599,10 @@ func (rsr *RuntimeStatSampler) GetCPUCombinedPercentNorm() float64 {
 // Except for iopsInProgress, these metrics act like counters (always
 // increasing, and best interpreted as a rate).
 type diskStats struct {
+	// name is the name of the disk, e.g. sda1 on linux. It is not set when
+	// the stats are summed across disks.
+	name string
+
 	readBytes int64
 	readCount int64
 
@@ -632,6 +637,43 @@ func getSummedDiskCounters(ctx context.Context) (diskStats, error) {
 	return sumDiskCounters(diskCounters), nil
 }
 
+// DiskReadWriteBytes are the cumulative bytes read from and written to a
+// disk.
+type DiskReadWriteBytes struct {
+	ReadBytes  int64
+	WriteBytes int64
+}
+
+// GetDiskReadWriteBytes returns the cumulative bytes read from and written to
+// each disk of this machine, keyed by the name of the disk. See DiskName for
+// the name of the disk that backs a block device.
+func GetDiskReadWriteBytes(ctx context.Context) (map[string]DiskReadWriteBytes, error) {
+	diskCounters, err := getDiskCounters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	output := make(map[string]DiskReadWriteBytes, len(diskCounters))
+	for _, stats := range diskCounters {
+		output[stats.name] = DiskReadWriteBytes{
+			ReadBytes:  stats.readBytes,
+			WriteBytes: stats.writeBytes,
+		}
+	}
+	return output, nil
+}
+
+// DiskName returns the name under which GetDiskReadWriteBytes reports the stats
+// of the given block device (e.g. /dev/sda1, or /dev/mapper/vg-lv, which is a
+// symlink to /dev/dm-0). The name is not meaningful on all platforms, e.g. on
+// macOS, stats are reported for whole disks rather than for the partitions
+// that back filesystems.
+func DiskName(blockDevice string) string {
+	if resolved, err := filepath.EvalSymlinks(blockDevice); err == nil {
+		blockDevice = resolved
+	}
+	return filepath.Base(blockDevice)
+}
+
 func getSummedNetStats(ctx context.Context) (net.IOCountersStat, error) {
 	netCounters, err := net.IOCountersWithContext(ctx, true /* per NIC */)
 	if err != nil {
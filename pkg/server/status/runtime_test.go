@@ -11,6 +11,8 @@
 package status
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -50,6 +52,28 @@ func TestSumDiskCounters(t *testing.T) {
 	}
 }
 
+func TestDiskName(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	if name := DiskName("/dev/sda1"); name != "sda1" {
+		t.Fatalf("expected sda1; got %s", name)
+	}
+
+	// Block devices can be symlinks, like those of LVM volumes in /dev/mapper.
+	dir := t.TempDir()
+	device := filepath.Join(dir, "dm-0")
+	if err := os.WriteFile(device, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "vg-lv")
+	if err := os.Symlink(device, link); err != nil {
+		t.Fatal(err)
+	}
+	if name := DiskName(link); name != "dm-0" {
+		t.Fatalf("expected dm-0; got %s", name)
+	}
+}
+
 func TestSumNetCounters(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
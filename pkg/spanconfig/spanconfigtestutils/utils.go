@@ -381,6 +381,9 @@ func PrintSpanConfigDiffedAgainstDefaults(conf roachpb.SpanConfig) string {
 	if conf.NumVoters != defaultConf.NumVoters {
 		diffs = append(diffs, fmt.Sprintf("num_voters=%d", conf.NumVoters))
 	}
+	if conf.NumWitnesses != defaultConf.NumWitnesses {
+		diffs = append(diffs, fmt.Sprintf("num_witnesses=%d", conf.NumWitnesses))
+	}
 	if conf.RangefeedEnabled != defaultConf.RangefeedEnabled {
 		diffs = append(diffs, fmt.Sprintf("rangefeed_enabled=%t", conf.RangefeedEnabled))
 	}
@@ -390,6 +393,9 @@ func PrintSpanConfigDiffedAgainstDefaults(conf roachpb.SpanConfig) string {
 	if !reflect.DeepEqual(conf.VoterConstraints, defaultConf.VoterConstraints) {
 		diffs = append(diffs, fmt.Sprintf("voter_constraints=%v", conf.VoterConstraints))
 	}
+	if !reflect.DeepEqual(conf.WitnessConstraints, defaultConf.WitnessConstraints) {
+		diffs = append(diffs, fmt.Sprintf("witness_constraints=%v", conf.WitnessConstraints))
+	}
 	if !reflect.DeepEqual(conf.LeasePreferences, defaultConf.LeasePreferences) {
 		diffs = append(diffs, fmt.Sprintf("lease_preferences=%v", conf.VoterConstraints))
 	}
@@ -155,6 +155,7 @@ func (cb *ColumnBackfiller) init(
 		false, /* reverse */
 		descpb.ScanLockingStrength_FOR_NONE,
 		descpb.ScanLockingWaitPolicy_BLOCK,
+		descpb.ScanLockingDurability_BEST_EFFORT,
 		0, /* lockTimeout */
 		&cb.alloc,
 		cb.mon,
@@ -851,6 +852,7 @@ func (ib *IndexBackfiller) BuildIndexEntriesChunk(
 		false, /* reverse */
 		descpb.ScanLockingStrength_FOR_NONE,
 		descpb.ScanLockingWaitPolicy_BLOCK,
+		descpb.ScanLockingDurability_BEST_EFFORT,
 		0, /* lockTimeout */
 		&ib.alloc,
 		ib.mon,
@@ -377,7 +377,9 @@ const (
 	PgExtensionGeographyColumnsTableID
 	PgExtensionGeometryColumnsTableID
 	PgExtensionSpatialRefSysTableID
-	MinVirtualID = PgExtensionSpatialRefSysTableID
+	CrdbInternalChangefeedFrontierSpansTableID
+	CrdbInternalTenantStorageUsageTableID
+	MinVirtualID = CrdbInternalTenantStorageUsageTableID
 )
 
 // DefaultHashShardedIndexBucketCount is the cluster setting of default bucket
@@ -670,6 +670,9 @@ type TableDescriptor interface {
 	// GetExcludeDataFromBackup returns true if the table's row data is configured
 	// to be excluded during backup.
 	GetExcludeDataFromBackup() bool
+	// GetReadOnlyReason returns a user-visible reason for why the table rejects
+	// writes and schema changes, or the empty string if it accepts them.
+	GetReadOnlyReason() string
 	// GetStorageParams returns a list of storage parameters for the table.
 	GetStorageParams(spaceBetweenEqual bool) []string
 }
@@ -621,11 +621,17 @@ CREATE TABLE system.tenant_usage (
   -- Current shares value for this instance.
   instance_shares FLOAT,
 
+  -- Storage quota of the tenant, in logical bytes, or NULL if the tenant is
+  -- not subject to a quota. Used only for the per-tenant state, when
+  -- instance_id = 0.
+  storage_quota_bytes INT,
+
   FAMILY "primary" (
     tenant_id, instance_id, next_instance_id, last_update,
     ru_burst_limit, ru_refill_rate, ru_current, current_share_sum,
     total_consumption,
-    instance_lease, instance_seq, instance_shares
+    instance_lease, instance_seq, instance_shares,
+    storage_quota_bytes
   ),
 
 	CONSTRAINT "primary" PRIMARY KEY (tenant_id, instance_id)
@@ -2224,6 +2230,7 @@ var (
 				{Name: "instance_lease", ID: 10, Type: types.Bytes, Nullable: true},
 				{Name: "instance_seq", ID: 11, Type: types.Int, Nullable: true},
 				{Name: "instance_shares", ID: 12, Type: types.Float, Nullable: true},
+				{Name: "storage_quota_bytes", ID: 13, Type: types.Int, Nullable: true},
 			},
 			[]descpb.ColumnFamilyDescriptor{
 				{
@@ -2234,8 +2241,9 @@ var (
 						"ru_burst_limit", "ru_refill_rate", "ru_current", "current_share_sum",
 						"total_consumption",
 						"instance_lease", "instance_seq", "instance_shares",
+						"storage_quota_bytes",
 					},
-					ColumnIDs:       []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+					ColumnIDs:       []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
 					DefaultColumnID: 0,
 				},
 			},
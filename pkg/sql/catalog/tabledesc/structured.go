@@ -2468,6 +2468,12 @@ func (desc *Mutable) SetOffline(reason string) {
 	desc.OfflineReason = reason
 }
 
+// SetReadOnly marks the public table as rejecting writes and schema changes,
+// with the provided reason. An empty reason makes the table writable again.
+func (desc *Mutable) SetReadOnly(reason string) {
+	desc.ReadOnlyReason = reason
+}
+
 // IsLocalityRegionalByRow implements the TableDescriptor interface.
 func (desc *wrapper) IsLocalityRegionalByRow() bool {
 	return desc.LocalityConfig.GetRegionalByRow() != nil
@@ -169,6 +169,9 @@ type cFetcherArgs struct {
 	// lockWaitPolicy represents the policy to be used for handling conflicting
 	// locks held by other active transactions.
 	lockWaitPolicy descpb.ScanLockingWaitPolicy
+	// lockDurability represents the durability of the locks acquired when
+	// fetching rows.
+	lockDurability descpb.ScanLockingDurability
 	// lockTimeout specifies the maximum amount of time that the fetcher will
 	// wait while attempting to acquire a lock on a key or while blocking on an
 	// existing lock in order to perform a non-locking read on a key.
@@ -552,6 +555,7 @@ func (cf *cFetcher) StartScan(
 		firstBatchLimit,
 		cf.lockStrength,
 		cf.lockWaitPolicy,
+		cf.lockDurability,
 		cf.lockTimeout,
 		cf.kvFetcherMemAcc,
 		forceProductionKVBatchSize,
@@ -577,7 +581,7 @@ func (cf *cFetcher) StartScanStreaming(
 	spans roachpb.Spans,
 	limitHint rowinfra.RowLimit,
 ) error {
-	kvBatchFetcher, err := row.NewTxnKVStreamer(ctx, streamer, spans, cf.lockStrength)
+	kvBatchFetcher, err := row.NewTxnKVStreamer(ctx, streamer, spans, cf.lockStrength, cf.lockDurability)
 	if err != nil {
 		return err
 	}
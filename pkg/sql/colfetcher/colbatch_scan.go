@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -197,6 +198,7 @@ func NewColBatchScan(
 	fetcher.cFetcherArgs = cFetcherArgs{
 		spec.LockingStrength,
 		spec.LockingWaitPolicy,
+		row.GetLockingDurability(flowCtx.EvalCtx.SessionData().DurableLockingEnabled),
 		flowCtx.EvalCtx.SessionData().LockTimeout,
 		execinfra.GetWorkMemLimit(flowCtx),
 		estimatedRowCount,
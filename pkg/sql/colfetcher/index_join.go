@@ -471,6 +471,7 @@ func NewColIndexJoin(
 	fetcher.cFetcherArgs = cFetcherArgs{
 		spec.LockingStrength,
 		spec.LockingWaitPolicy,
+		row.GetLockingDurability(flowCtx.EvalCtx.SessionData().DurableLockingEnabled),
 		flowCtx.EvalCtx.SessionData().LockTimeout,
 		memoryLimit,
 		// Note that the correct estimated row count will be set by the index
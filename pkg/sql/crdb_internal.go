@@ -161,6 +161,8 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalActiveRangeFeedsTable:              crdbInternalActiveRangeFeedsTable,
 		catconstants.CrdbInternalTenantUsageDetailsViewID:           crdbInternalTenantUsageDetailsView,
 		catconstants.CrdbInternalPgCatalogTableIsImplementedTableID: crdbInternalPgCatalogTableIsImplementedTable,
+		catconstants.CrdbInternalChangefeedFrontierSpansTableID:     crdbInternalChangefeedFrontierSpansTable,
+		catconstants.CrdbInternalTenantStorageUsageTableID:          crdbInternalTenantStorageUsageTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -5280,6 +5282,59 @@ CREATE TABLE crdb_internal.active_range_feeds (
 	},
 }
 
+var crdbInternalChangefeedFrontierSpansTable = virtualSchemaTable{
+	comment: `node-level table listing the slowest spans of the changefeed frontiers running on this node`,
+	schema: `
+CREATE TABLE crdb_internal.changefeed_frontier_spans (
+  job_id INT,
+  span_start STRING,
+  span_end STRING,
+  resolved STRING,
+  lag INTERVAL,
+  range_id INT
+);`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.changefeed_frontier_spans"); err != nil {
+			return err
+		}
+		frontierSpans, ok := p.execCfg.JobRegistry.MetricsStruct().Changefeed.(jobs.ChangefeedFrontierSpans)
+		if !ok {
+			return nil
+		}
+		now := timeutil.Now()
+		return frontierSpans.ForEachFrontierSpan(func(s jobs.ChangefeedFrontierSpan) error {
+			jobID, resolved, lag := tree.DNull, tree.DNull, tree.DNull
+			if s.JobID != 0 {
+				jobID = tree.NewDInt(tree.DInt(s.JobID))
+			}
+			if !s.Resolved.IsEmpty() {
+				resolved = tree.NewDString(s.Resolved.AsOfSystemTime())
+				lag = tree.NewDInterval(
+					duration.MakeDuration(now.Sub(s.Resolved.GoTime()).Nanoseconds(), 0, 0),
+					types.DefaultIntervalTypeMetadata,
+				)
+			}
+			// The range is looked up in the range cache, which usually knows about
+			// the ranges the changefeed is watching.
+			rangeID := tree.DNull
+			if key, err := keys.Addr(s.Span.Key); err == nil {
+				rc := p.execCfg.DistSender.RangeDescriptorCache()
+				if entry := rc.GetCached(ctx, key, false /* inverted */); entry != nil {
+					rangeID = tree.NewDInt(tree.DInt(entry.Desc().RangeID))
+				}
+			}
+			return addRow(
+				jobID,
+				tree.NewDString(keys.PrettyPrint(nil /* valDirs */, s.Span.Key)),
+				tree.NewDString(keys.PrettyPrint(nil /* valDirs */, s.Span.EndKey)),
+				resolved,
+				lag,
+				rangeID,
+			)
+		})
+	},
+}
+
 // crdb_internal.cluster_transaction_statistics contains cluster-wide transaction statistics
 // that have not yet been flushed to disk.
 var crdbInternalClusterTxnStatsTable = virtualSchemaTable{
@@ -5461,6 +5516,42 @@ CREATE VIEW crdb_internal.tenant_usage_details AS
 	},
 }
 
+// crdbInternalTenantStorageUsageTable exposes the logical storage usage of
+// secondary tenants, as tracked to enforce their storage quotas.
+var crdbInternalTenantStorageUsageTable = virtualSchemaTable{
+	comment: "logical storage usage of secondary tenants (RAM; aggregated from gossip)",
+	schema: `
+CREATE TABLE crdb_internal.tenant_storage_usage (
+  tenant_id      INT NOT NULL,
+  logical_bytes  INT NOT NULL,
+  quota_bytes    INT          -- null if the tenant is not subject to a quota
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.tenant_storage_usage"); err != nil {
+			return err
+		}
+		tracker := p.ExecCfg().TenantStorage
+		if tracker == nil {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"table crdb_internal.tenant_storage_usage is not implemented on tenants")
+		}
+		for _, usage := range tracker.Usages() {
+			quota := tree.DNull
+			if q := tracker.Quota(usage.TenantID); q != 0 {
+				quota = tree.NewDInt(tree.DInt(q))
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(usage.TenantID.ToUint64())),
+				tree.NewDInt(tree.DInt(usage.LogicalBytes)),
+				quota,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
 var crdbInternalTransactionContentionEventsTable = virtualSchemaTable{
 	comment: `cluster-wide transaction contention events. Querying this table is an
 		expensive operation since it creates a cluster-wide RPC-fanout.`,
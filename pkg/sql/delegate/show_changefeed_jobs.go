@@ -31,7 +31,11 @@ WITH payload AS (
     crdb_internal.pb_to_json(
       'cockroach.sql.jobs.jobspb.Payload', 
       payload, false, true
-    )->'changefeed' AS changefeed_details 
+    )->'changefeed' AS changefeed_details, 
+    crdb_internal.pb_to_json(
+      'cockroach.sql.jobs.jobspb.Progress', 
+      progress, false, true
+    )->'changefeed' AS changefeed_progress 
   FROM 
     system.jobs
 ) 
@@ -63,7 +67,11 @@ SELECT
       table_id = ANY (descriptor_ids)
   ) AS full_table_names, 
   changefeed_details->'opts'->>'topics' AS topics,
-  changefeed_details->'opts'->>'format' AS format 
+  changefeed_details->'opts'->>'format' AS format, 
+  COALESCE(
+    (changefeed_progress->>'dead_letter_queue_entries')::INT8, 
+    0
+  ) AS dead_letter_queue_entries 
 FROM 
   crdb_internal.jobs 
   INNER JOIN payload ON id = job_id`
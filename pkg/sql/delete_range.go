@@ -101,6 +101,7 @@ func (d *deleteRangeNode) startExec(params runParams) error {
 		false, /* reverse */
 		descpb.ScanLockingStrength_FOR_NONE,
 		descpb.ScanLockingWaitPolicy_BLOCK,
+		descpb.ScanLockingDurability_BEST_EFFORT,
 		0, /* lockTimeout */
 		params.p.alloc,
 		nil, /* memMonitor */
@@ -505,6 +505,13 @@ func (c *DummyTenantOperator) UpdateTenantResourceLimits(
 	return errors.WithStack(errEvalTenant)
 }
 
+// UpdateTenantStorageQuota is part of the tree.TenantOperator interface.
+func (c *DummyTenantOperator) UpdateTenantStorageQuota(
+	_ context.Context, tenantID uint64, quotaBytes int64,
+) error {
+	return errors.WithStack(errEvalTenant)
+}
+
 // DummyPreparedStatementState implements the tree.PreparedStatementState
 // interface.
 type DummyPreparedStatementState struct{}
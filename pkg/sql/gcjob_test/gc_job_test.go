@@ -31,8 +31,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/desctestutils"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/gcjob"
+	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
@@ -475,3 +477,39 @@ SELECT job_id
 	).Scan(&status)
 	require.Equal(t, jobs.StatusSucceeded, status)
 }
+
+// TestClearTableDataRangeTombstones verifies that ClearTableData removes the
+// table data with ClearRange even when MVCC range tombstones are enabled. The
+// data is only cleared once the GC TTL of the table expired, so there is no
+// history left to keep readable below the deletion timestamp.
+func TestClearTableDataRangeTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	execCfg := s.ExecutorConfig().(sql.ExecutorConfig)
+	tdb := sqlutils.MakeSQLRunner(db)
+
+	testutils.RunTrueAndFalse(t, "rangeTombstones", func(t *testing.T, rangeTombstones bool) {
+		storage.MVCCRangeTombstonesEnabled.Override(ctx, &execCfg.Settings.SV, rangeTombstones)
+
+		table := fmt.Sprintf("t_%t", rangeTombstones)
+		tdb.Exec(t, fmt.Sprintf("CREATE TABLE %s (k INT PRIMARY KEY)", table))
+		tdb.Exec(t, fmt.Sprintf("INSERT INTO %s SELECT generate_series(1, 10)", table))
+		var beforeClear string
+		tdb.QueryRow(t, "SELECT cluster_logical_timestamp()").Scan(&beforeClear)
+
+		tableDesc := desctestutils.TestingGetMutableExistingTableDescriptor(
+			kvDB, keys.SystemSQLCodec, "defaultdb", table)
+		tableDesc.DropTime = timeutil.Now().UnixNano()
+		require.NoError(t, gcjob.ClearTableData(
+			ctx, kvDB, execCfg.DistSender, keys.SystemSQLCodec, &execCfg.Settings.SV, tableDesc))
+
+		tdb.CheckQueryResults(t, fmt.Sprintf("SELECT count(*) FROM %s", table), [][]string{{"0"}})
+		tdb.CheckQueryResults(t,
+			fmt.Sprintf("SELECT count(*) FROM %s AS OF SYSTEM TIME %s", table, beforeClear),
+			[][]string{{"0"}})
+	})
+}
@@ -0,0 +1,401 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descidgen"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/errors"
+)
+
+// inferSchemaDefaultSampleRows is the number of rows of a CSV file that are
+// read to infer its schema when the sample_rows option is not specified.
+const inferSchemaDefaultSampleRows = 1000
+
+// inferCandidateTypes are the types that a column of a CSV file can be
+// inferred to have, in order of preference. A column is given the first of
+// these types which all of its sampled values parse as, or STRING if there is
+// none.
+var inferCandidateTypes = []*types.T{
+	types.Int,
+	types.Decimal,
+	types.Bool,
+	types.Date,
+	types.TimestampTZ,
+	types.Timestamp,
+	types.Uuid,
+	types.Jsonb,
+}
+
+// inferredColumn accumulates what is known about a column of a CSV file while
+// its sampled values are read.
+type inferredColumn struct {
+	name string
+	// candidates are the types of inferCandidateTypes that every non-NULL value
+	// sampled so far parses as.
+	candidates []*types.T
+	hasValues  bool
+	hasNulls   bool
+	// distinct holds the values sampled so far as long as they are all
+	// distinct and non-NULL, and is nil otherwise.
+	distinct map[string]struct{}
+}
+
+func makeInferredColumn(name string) inferredColumn {
+	return inferredColumn{
+		name:       name,
+		candidates: append([]*types.T(nil), inferCandidateTypes...),
+		distinct:   make(map[string]struct{}),
+	}
+}
+
+// add records a sampled value of the column.
+func (c *inferredColumn) add(evalCtx *tree.EvalContext, field string, null bool) {
+	if null {
+		c.hasNulls = true
+		c.distinct = nil
+		return
+	}
+	if c.distinct != nil {
+		if _, ok := c.distinct[field]; ok {
+			c.distinct = nil
+		} else {
+			c.distinct[field] = struct{}{}
+		}
+	}
+	c.hasValues = true
+	remaining := c.candidates[:0]
+	for _, typ := range c.candidates {
+		if inferParses(evalCtx, typ, field) {
+			remaining = append(remaining, typ)
+		}
+	}
+	c.candidates = remaining
+}
+
+// typ returns the type inferred for the column.
+func (c *inferredColumn) typ() *types.T {
+	if !c.hasValues || len(c.candidates) == 0 {
+		// A column without any non-NULL values is given the most general type.
+		return types.String
+	}
+	return c.candidates[0]
+}
+
+// isKeyCandidate returns whether the column can be the primary key of the
+// table: its type must be one that keys are usually made of, and its sampled
+// values must all be distinct and non-NULL.
+func (c *inferredColumn) isKeyCandidate(evalCtx *tree.EvalContext) bool {
+	if c.distinct == nil || len(c.distinct) == 0 {
+		return false
+	}
+	typ := c.typ()
+	switch typ.Family() {
+	case types.IntFamily, types.UuidFamily:
+		// Distinct strings can parse as equal values, for instance "1" and "01".
+		seen := make(map[string]struct{}, len(c.distinct))
+		for field := range c.distinct {
+			d, _, err := tree.ParseAndRequireString(typ, field, evalCtx)
+			if err != nil {
+				return false
+			}
+			key := tree.AsStringWithFlags(d, tree.FmtBareStrings)
+			if _, ok := seen[key]; ok {
+				return false
+			}
+			seen[key] = struct{}{}
+		}
+		return true
+	case types.StringFamily:
+		return true
+	}
+	return false
+}
+
+// inferParses returns whether field can be imported into a column of type
+// typ. A value whose interpretation depends on the session, such as a
+// TIMESTAMPTZ without a time zone offset, does not count as parsing as the
+// type, and neither does a value that would lose information, such as a DATE
+// with a time of day, nor a scalar value for JSONB.
+func inferParses(evalCtx *tree.EvalContext, typ *types.T, field string) bool {
+	switch typ.Family() {
+	case types.DateFamily:
+		ts, _, err := tree.ParseDTimestamp(evalCtx, field, time.Microsecond)
+		if err == nil && !ts.Time.Equal(ts.Time.Truncate(24*time.Hour)) {
+			return false
+		}
+	case types.JsonFamily:
+		trimmed := strings.TrimSpace(field)
+		if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+			return false
+		}
+	}
+	_, dependsOnContext, err := tree.ParseAndRequireString(typ, field, evalCtx)
+	return err == nil && !dependsOnContext
+}
+
+// inferredCSVSchema is the schema inferred from a sample of a CSV file.
+type inferredCSVSchema struct {
+	columns []inferredColumn
+	// primaryKey is the index of the column inferred to be the primary key of
+	// the table, or -1 if there is none.
+	primaryKey int
+	// header is set if the first record of the file, after the skipped ones,
+	// holds the names of the columns rather than data.
+	header bool
+}
+
+// inferCSVSchema reads up to sampleRows records of CSV data from r and infers
+// the names, types and nullability of its columns, as well as a column which
+// may serve as the primary key.
+//
+// Columns are named after the fields of the first record if it looks like a
+// header: its fields are distinct, non-empty and not numbers, and at least one
+// of them does not parse as the type inferred for its column from the other
+// records. The columns are named column1, column2, etc. otherwise. A field is NULL if it
+// matches the nullif option, as when importing the file.
+func inferCSVSchema(
+	evalCtx *tree.EvalContext, r io.Reader, opts roachpb.CSVOptions, sampleRows int64,
+) (*inferredCSVSchema, error) {
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = !opts.StrictQuotes
+	cr.Comment = opts.Comment
+
+	// Read one more record than requested in case the first one is a header.
+	var records [][]string
+	for rowNum := int64(1); int64(len(records)) <= sampleRows; rowNum++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, wrapRowErr(err, rowNum, pgcode.Uncategorized, "")
+		}
+		if rowNum <= int64(opts.Skip) {
+			continue
+		}
+		if len(records) > 0 {
+			expected := len(records[0])
+			if len(record) == expected+1 && record[expected] == "" {
+				// Record has the optional trailing comma, ignore the empty field.
+				record = record[:expected]
+			} else if len(record) != expected {
+				return nil, makeRowErr(rowNum, pgcode.Uncategorized,
+					"expected %d fields, got %d", expected, len(record))
+			}
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("cannot infer a schema from a file without any records")
+	}
+
+	isNull := func(field string) bool {
+		return opts.NullEncoding != nil && field == *opts.NullEncoding
+	}
+	infer := func(names []string, records [][]string) []inferredColumn {
+		columns := make([]inferredColumn, len(names))
+		for i, name := range names {
+			columns[i] = makeInferredColumn(name)
+		}
+		for _, record := range records {
+			for i, field := range record {
+				columns[i].add(evalCtx, field, isNull(field))
+			}
+		}
+		return columns
+	}
+
+	schema := &inferredCSVSchema{primaryKey: -1}
+	first := records[0]
+	if rest := infer(first, records[1:]); len(records) > 1 && looksLikeHeader(evalCtx, first, rest) {
+		schema.header = true
+		schema.columns = rest
+	} else {
+		names := make([]string, len(first))
+		for i := range names {
+			names[i] = fmt.Sprintf("column%d", i+1)
+		}
+		if int64(len(records)) > sampleRows {
+			records = records[:sampleRows]
+		}
+		schema.columns = infer(names, records)
+	}
+
+	for i := range schema.columns {
+		if schema.columns[i].isKeyCandidate(evalCtx) {
+			schema.primaryKey = i
+			break
+		}
+	}
+	return schema, nil
+}
+
+// looksLikeHeader returns whether record holds the names of the columns
+// rather than data, given the columns inferred from the records after it.
+func looksLikeHeader(evalCtx *tree.EvalContext, record []string, columns []inferredColumn) bool {
+	names := make(map[string]struct{}, len(record))
+	for _, field := range record {
+		if strings.TrimSpace(field) == "" || inferParses(evalCtx, types.Decimal, field) {
+			return false
+		}
+		if _, ok := names[field]; ok {
+			return false
+		}
+		names[field] = struct{}{}
+	}
+	for i := range columns {
+		if typ := columns[i].typ(); typ.Family() != types.StringFamily &&
+			!inferParses(evalCtx, typ, record[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// createTable returns a CREATE TABLE statement for a table with the inferred
+// schema.
+func (s *inferredCSVSchema) createTable(name tree.TableName) *tree.CreateTable {
+	create := &tree.CreateTable{Table: name}
+	for i := range s.columns {
+		col := &s.columns[i]
+		def := &tree.ColumnTableDef{
+			Name: tree.Name(col.name),
+			Type: col.typ(),
+		}
+		def.Nullable.Nullability = tree.SilentNull
+		if !col.hasNulls {
+			def.Nullable.Nullability = tree.NotNull
+		}
+		def.PrimaryKey.IsPrimaryKey = i == s.primaryKey
+		create.Defs = append(create.Defs, def)
+	}
+	return create
+}
+
+// inferredTableName returns the name given to a table whose schema is
+// inferred from the file at uri when no name is specified, which is the name
+// of the file without its extensions.
+func inferredTableName(uri string) string {
+	name := uri
+	if parsed, err := url.Parse(uri); err == nil {
+		name = parsed.Path
+	}
+	name = path.Base(name)
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" || name == "/" {
+		return "imported"
+	}
+	return name
+}
+
+// readCSVSchema infers the schema of the CSV file at uri.
+func readCSVSchema(
+	ctx context.Context,
+	evalCtx *tree.EvalContext,
+	uri string,
+	format roachpb.IOFileFormat,
+	sampleRows int64,
+	makeExternalStorage cloud.ExternalStorageFromURIFactory,
+	user security.SQLUsername,
+) (*inferredCSVSchema, error) {
+	store, err := makeExternalStorage(ctx, uri, user)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	raw, err := store.ReadFile(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	defer raw.Close(ctx)
+	reader, err := decompressingReader(ioctx.ReaderCtxAdapter(ctx, raw), uri, format.Compression)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return inferCSVSchema(evalCtx, reader, format.Csv, sampleRows)
+}
+
+// inferCSVTableDesc creates the descriptor of a table with the schema
+// inferred from a sample of the CSV data read from r, which is named after
+// the file at uri if tableName is empty. If the data starts with a header, the
+// format is updated to skip it when the data is imported.
+func inferCSVTableDesc(
+	ctx context.Context,
+	r io.Reader,
+	evalCtx *tree.EvalContext,
+	p sql.JobExecContext,
+	parentDB catalog.DatabaseDescriptor,
+	tableName string,
+	uri string,
+	sampleRows int64,
+	format *roachpb.IOFileFormat,
+	fks fkHandler,
+	walltime int64,
+) ([]*tabledesc.Mutable, error) {
+	schema, err := inferCSVSchema(evalCtx, r, format.Csv, sampleRows)
+	if err != nil {
+		return nil, err
+	}
+	if schema.header {
+		format.Csv.Skip++
+	}
+	if tableName == "" {
+		tableName = inferredTableName(uri)
+	}
+
+	publicSchema, err := getPublicSchemaDescForDatabase(ctx, p.ExecCfg(), parentDB)
+	if err != nil {
+		return nil, err
+	}
+	id, err := descidgen.PeekNextUniqueDescID(ctx, p.ExecCfg().DB, p.ExecCfg().Codec)
+	if err != nil {
+		return nil, err
+	}
+	// Inferred schemas never reference user defined types, and so we nil out
+	// the type resolver as for bundle imports.
+	semaCtx := makeSemaCtxWithoutTypeResolver(p.SemaCtx())
+	create := schema.createTable(tree.MakeUnqualifiedTableName(tree.Name(tableName)))
+	desc, err := MakeSimpleTableDescriptor(
+		ctx, semaCtx, p.ExecCfg().Settings, create, parentDB, publicSchema, id, fks, walltime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return []*tabledesc.Mutable{desc}, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferCSVSchema(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	evalCtx := tree.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	null := ""
+
+	for _, tc := range []struct {
+		name       string
+		data       string
+		opts       roachpb.CSVOptions
+		sampleRows int64
+		expected   string
+		header     bool
+		err        string
+	}{
+		{
+			name: "header",
+			data: `id,name,price,active,created
+1,apple,1.5,true,2022-01-02
+2,pear,2,false,2022-01-03
+3,plum,,,2022-01-04
+`,
+			opts:     roachpb.CSVOptions{NullEncoding: &null},
+			expected: `CREATE TABLE t (id INT8 NOT NULL PRIMARY KEY, name STRING NOT NULL, price DECIMAL, active BOOL, created DATE NOT NULL)`,
+			header:   true,
+		},
+		{
+			name: "no-header",
+			data: `a,1,{"k": 1}
+b,1,[]
+`,
+			expected: `CREATE TABLE t (column1 STRING NOT NULL PRIMARY KEY, column2 INT8 NOT NULL, column3 JSONB NOT NULL)`,
+		},
+		{
+			name: "all-strings",
+			data: `name,color
+apple,red
+`,
+			expected: `CREATE TABLE t (column1 STRING NOT NULL PRIMARY KEY, column2 STRING NOT NULL)`,
+		},
+		{
+			name: "duplicate-keys",
+			data: `x,y
+1,a
+01,a
+`,
+			expected: `CREATE TABLE t (x INT8 NOT NULL, y STRING NOT NULL)`,
+			header:   true,
+		},
+		{
+			name: "timestamps",
+			data: `ts,tz,id
+2022-01-02 03:04:05,2022-01-02 03:04:05+01,a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11
+2022-01-03 03:04:05,2022-01-03 03:04:05-05,a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11
+`,
+			expected: `CREATE TABLE t (ts TIMESTAMP NOT NULL, tz TIMESTAMPTZ NOT NULL, id UUID NOT NULL)`,
+			header:   true,
+		},
+		{
+			name:     "skip-and-delimiter",
+			data:     "# comment\nskipped\nn|v\n1|x\n2|y\n3|z\n",
+			opts:     roachpb.CSVOptions{Comma: '|', Comment: '#', Skip: 1},
+			expected: `CREATE TABLE t (n INT8 NOT NULL PRIMARY KEY, v STRING NOT NULL)`,
+			header:   true,
+		},
+		{
+			name: "sample-rows",
+			data: `n
+1
+2
+x
+`,
+			sampleRows: 2,
+			expected:   `CREATE TABLE t (n INT8 NOT NULL PRIMARY KEY)`,
+			header:     true,
+		},
+		{
+			name: "ragged",
+			data: `1,2
+3
+`,
+			err: "row 2: expected 2 fields, got 1",
+		},
+		{
+			name: "empty",
+			err:  "without any records",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sampleRows := tc.sampleRows
+			if sampleRows == 0 {
+				sampleRows = inferSchemaDefaultSampleRows
+			}
+			schema, err := inferCSVSchema(&evalCtx, strings.NewReader(tc.data), tc.opts, sampleRows)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.header, schema.header)
+			create := schema.createTable(tree.MakeUnqualifiedTableName("t"))
+			require.Equal(t, tc.expected, tree.AsString(create))
+		})
+	}
+}
+
+func TestInferredTableName(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for uri, expected := range map[string]string{
+		"nodelocal://0/foo/bar.csv":            "bar",
+		"s3://bucket/data/orders.csv.gz?AUTH=": "orders",
+		"userfile:///people":                   "people",
+		"nodelocal://0/":                       "imported",
+	} {
+		require.Equal(t, expected, inferredTableName(uri), uri)
+	}
+}
@@ -165,6 +165,128 @@ func TestProtectedTimestampsDuringImportInto(t *testing.T) {
 	})
 }
 
+// TestImportIntoUpsert tests that IMPORT INTO with the upsert option replaces
+// existing rows and keeps the secondary indexes consistent with them, and that
+// the table is online for reads but not for writes during the import.
+func TestImportIntoUpsert(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := testcluster.StartTestCluster(t, 1, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	conn := tc.ServerConn(0)
+	runner := sqlutils.MakeSQLRunner(conn)
+
+	allowResponse := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			return
+		}
+		switch r.URL.Path {
+		case "/update":
+			// Row 2 changes a column of the index on v, row 3 the key of the index
+			// on n, and row 4 is new.
+			_, _ = w.Write([]byte("2,b2,2\n3,c,30\n4,d,4\n"))
+		case "/duplicates":
+			// Row 1 is updated three times, and only the last update is kept.
+			_, _ = w.Write([]byte("1,a1,11\n5,e,5\n1,a2,12\n1,a3,13\n"))
+		case "/block":
+			select {
+			case <-allowResponse:
+			case <-ctx.Done(): // Deal with test failures.
+			}
+			w.WriteHeader(500)
+		}
+	}))
+	defer srv.Close()
+
+	createTable := func(t *testing.T) {
+		runner.Exec(t, `DROP TABLE IF EXISTS t`)
+		runner.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING, n INT, INDEX (v), INDEX (n) STORING (v))`)
+		runner.Exec(t, `INSERT INTO t VALUES (1, 'a', 1), (2, 'b', 2), (3, 'c', 3)`)
+	}
+	// checkIndexes checks that the secondary indexes have exactly the entries of
+	// the rows in the primary index.
+	checkIndexes := func(t *testing.T) {
+		runner.CheckQueryResults(t, `SELECT k, v FROM t@t_v_idx ORDER BY k`,
+			runner.QueryStr(t, `SELECT k, v FROM t@t_pkey ORDER BY k`))
+		runner.CheckQueryResults(t, `SELECT k, v, n FROM t@t_n_idx ORDER BY k`,
+			runner.QueryStr(t, `SELECT k, v, n FROM t@t_pkey ORDER BY k`))
+	}
+
+	t.Run("upsert", func(t *testing.T) {
+		createTable(t)
+		// Importing the same data twice leaves the same rows.
+		for i := 0; i < 2; i++ {
+			runner.Exec(t, `IMPORT INTO t (k, v, n) CSV DATA ($1) WITH upsert`, srv.URL+"/update")
+			runner.CheckQueryResults(t, `SELECT * FROM t ORDER BY k`, [][]string{
+				{"1", "a", "1"}, {"2", "b2", "2"}, {"3", "c", "30"}, {"4", "d", "4"},
+			})
+			checkIndexes(t)
+		}
+		runner.CheckQueryResults(t, `SELECT k FROM t WHERE v = 'b'`, [][]string{})
+		runner.CheckQueryResults(t, `SELECT k FROM t WHERE n = 3`, [][]string{})
+	})
+
+	t.Run("newest-row-wins", func(t *testing.T) {
+		createTable(t)
+		runner.Exec(t, `IMPORT INTO t (k, v, n) CSV DATA ($1) WITH upsert`, srv.URL+"/duplicates")
+		runner.CheckQueryResults(t, `SELECT * FROM t ORDER BY k`, [][]string{
+			{"1", "a3", "13"}, {"2", "b", "2"}, {"3", "c", "3"}, {"5", "e", "5"},
+		})
+		checkIndexes(t)
+	})
+
+	t.Run("online-for-reads-during-import", func(t *testing.T) {
+		createTable(t)
+		importErrCh := make(chan error, 1)
+		go func() {
+			_, err := conn.Exec(`IMPORT INTO t (k, v, n) CSV DATA ($1, $2) WITH upsert`,
+				srv.URL+"/update", srv.URL+"/block")
+			importErrCh <- err
+		}()
+
+		testutils.SucceedsSoon(t, func() error {
+			_, err := conn.Exec(`UPSERT INTO t VALUES (10, 'x', 10)`)
+			if !testutils.IsError(err, `table "t" is read-only: importing`) {
+				return errors.Newf("expected the table to be read-only, got %v", err)
+			}
+			return nil
+		})
+		runner.Exec(t, `SELECT * FROM t`)
+		// The secondary indexes are not consistent with the rows until the
+		// import finishes, so reads cannot use them.
+		runner.ExpectErr(t, `index "t_v_idx" not found`, `SELECT k FROM t@t_v_idx`)
+		runner.CheckQueryResults(t, `SELECT k FROM t WHERE v = 'a'`, [][]string{{"1"}})
+		runner.ExpectErr(t, `table "t" is read-only: importing`, `CREATE INDEX ON t (k, v)`)
+		runner.ExpectErr(t, `table "t" is read-only: importing`, `ALTER TABLE t ADD COLUMN x INT`)
+
+		// The import fails, but is not rolled back, and the table accepts writes
+		// again.
+		close(allowResponse)
+		require.Regexp(t, "error response from server: 500 Internal Server Error", <-importErrCh)
+		testutils.SucceedsSoon(t, func() error {
+			_, err := conn.Exec(`UPSERT INTO t VALUES (10, 'x', 10)`)
+			return err
+		})
+		checkIndexes(t)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		runner.Exec(t, `CREATE TABLE u (k INT PRIMARY KEY, v INT UNIQUE)`)
+		runner.ExpectErr(t, "IMPORT INTO with upsert is not supported for tables with unique secondary indexes",
+			`IMPORT INTO u CSV DATA ($1) WITH upsert`, srv.URL+"/update")
+		runner.Exec(t, `CREATE TABLE f (k INT PRIMARY KEY, v INT, FAMILY (k), FAMILY (v))`)
+		runner.ExpectErr(t, "IMPORT INTO with upsert is not supported for tables with multiple column families",
+			`IMPORT INTO f CSV DATA ($1) WITH upsert`, srv.URL+"/update")
+		runner.ExpectErr(t, "upsert option is only supported by IMPORT INTO",
+			`IMPORT PGDUMP ($1) WITH upsert`, srv.URL+"/update")
+	})
+}
+
 func getFirstStoreReplica(
 	t *testing.T, s serverutils.TestServerInterface, key roachpb.Key,
 ) (*kvserver.Store, *kvserver.Replica) {
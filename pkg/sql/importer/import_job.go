@@ -295,6 +295,10 @@ func (r *importResumer) Resume(ctx context.Context, execCtx interface{}) error {
 		return err
 	}
 
+	if err := r.repairUpsertedTables(ctx, p.ExecCfg()); err != nil {
+		return err
+	}
+
 	// If the table being imported into referenced UDTs, ensure that a concurrent
 	// schema change on any of the typeDescs has not modified the type descriptor. If
 	// it has, it is unsafe to import the data and we fail the import job.
@@ -367,7 +371,7 @@ func (r *importResumer) prepareTablesForIngestion(
 	var desc *descpb.TableDescriptor
 	for i, table := range details.Tables {
 		if !table.IsNew {
-			desc, err = prepareExistingTablesForIngestion(ctx, txn, descsCol, table.Desc)
+			desc, err = prepareExistingTablesForIngestion(ctx, txn, descsCol, table.Desc, details.Upsert)
 			if err != nil {
 				return importDetails, err
 			}
@@ -444,9 +448,13 @@ func (r *importResumer) prepareTablesForIngestion(
 }
 
 // prepareExistingTablesForIngestion prepares descriptors for existing tables
-// being imported into.
+// being imported into. Tables being upserted into stay online for reads.
 func prepareExistingTablesForIngestion(
-	ctx context.Context, txn *kv.Txn, descsCol *descs.Collection, desc *descpb.TableDescriptor,
+	ctx context.Context,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	desc *descpb.TableDescriptor,
+	upsert bool,
 ) (*descpb.TableDescriptor, error) {
 	if len(desc.Mutations) > 0 {
 		return nil, errors.Errorf("cannot IMPORT INTO a table with schema changes in progress -- try again later (pending mutation %s)", desc.Mutations[0].String())
@@ -463,10 +471,17 @@ func prepareExistingTablesForIngestion(
 		return nil, errors.Errorf("another operation is currently operating on the table")
 	}
 
-	// Take the table offline for import.
-	// TODO(dt): audit everywhere we get table descs (leases or otherwise) to
-	// ensure that filtering by state handles IMPORTING correctly.
-	importing.SetOffline("importing")
+	if upsert {
+		// Keep the table online for reads during an upsert, but reject writes
+		// and schema changes: the import relies on being the only writer to
+		// the table to repair its secondary indexes.
+		importing.SetReadOnly("importing")
+	} else {
+		// Take the table offline for import.
+		// TODO(dt): audit everywhere we get table descs (leases or otherwise) to
+		// ensure that filtering by state handles IMPORTING correctly.
+		importing.SetOffline("importing")
+	}
 
 	// TODO(dt): de-validate all the FKs.
 	if err := descsCol.WriteDesc(
@@ -723,7 +738,6 @@ func (r *importResumer) parseBundleSchemaIfNeeded(ctx context.Context, phs inter
 	skipFKs := details.SkipFKs
 	parentID := details.ParentID
 	files := details.URIs
-	format := details.Format
 
 	owner := r.job.Payload().UsernameProto.Decode()
 
@@ -764,7 +778,7 @@ func (r *importResumer) parseBundleSchemaIfNeeded(ctx context.Context, phs inter
 		walltime := p.ExecCfg().Clock.Now().WallTime
 
 		if tableDescs, schemaDescs, err = parseAndCreateBundleTableDescs(
-			ctx, p, details, seqVals, skipFKs, dbDesc, files, format, walltime, owner,
+			ctx, p, details, seqVals, skipFKs, dbDesc, files, &details.Format, walltime, owner,
 			r.job.ID()); err != nil {
 			return err
 		}
@@ -825,7 +839,8 @@ func getPublicSchemaDescForDatabase(
 }
 
 // parseAndCreateBundleTableDescs parses and creates the table
-// descriptors for bundle formats.
+// descriptors for bundle formats, or infers the table descriptor from a
+// CSV file. The format is updated to skip the header of such a file.
 func parseAndCreateBundleTableDescs(
 	ctx context.Context,
 	p sql.JobExecContext,
@@ -834,7 +849,7 @@ func parseAndCreateBundleTableDescs(
 	skipFKs bool,
 	parentDB catalog.DatabaseDescriptor,
 	files []string,
-	format roachpb.IOFileFormat,
+	format *roachpb.IOFileFormat,
 	walltime int64,
 	owner security.SQLUsername,
 	jobID jobspb.JobID,
@@ -903,6 +918,13 @@ func parseAndCreateBundleTableDescs(
 			return nil, nil, logErr
 		}
 
+	case roachpb.IOFileFormat_CSV:
+		evalCtx := &p.ExtendedEvalContext().EvalContext
+		tableDescs, err = inferCSVTableDesc(
+			ctx, reader, evalCtx, p, parentDB, tableName, files[0], details.InferSchemaSampleRows,
+			format, fks, walltime,
+		)
+
 	default:
 		return tableDescs, schemaDescs, errors.Errorf(
 			"non-bundle format %q does not support reading schemas", format.Format.String())
@@ -947,6 +969,9 @@ func (r *importResumer) publishTables(
 			newTableDesc.SetPublic()
 
 			if !tbl.IsNew {
+				// Accept writes again, if the table was upserted into.
+				newTableDesc.SetReadOnly("")
+
 				// NB: This is not using AllNonDropIndexes or directly mutating the
 				// constraints returned by the other usual helpers because we need to
 				// replace the `OutboundFKs` and `Checks` slices of newTableDesc with copies
@@ -1002,6 +1027,28 @@ func (r *importResumer) publishTables(
 	return nil
 }
 
+// repairUpsertedTables repairs the secondary indexes of the tables which an
+// upsert wrote to, see repairIndexesAfterUpsert. The repair must run before
+// the tables accept writes again.
+func (r *importResumer) repairUpsertedTables(
+	ctx context.Context, execCfg *sql.ExecutorConfig,
+) error {
+	details := r.job.Details().(jobspb.ImportDetails)
+	// Nothing was written if no walltime was chosen yet, and the tables may be
+	// written to by others once they are published.
+	if !details.Upsert || details.Walltime == 0 || details.TablesPublished {
+		return nil
+	}
+	walltime := hlc.Timestamp{WallTime: details.Walltime}
+	for _, tbl := range details.Tables {
+		desc := tabledesc.NewBuilder(tbl.Desc).BuildImmutableTable()
+		if err := repairIndexesAfterUpsert(ctx, execCfg.DB, execCfg.Codec, desc, walltime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // writeStubStatisticsForImportedTables writes "stub" statistics for new tables
 // created during an import.
 func (r *importResumer) writeStubStatisticsForImportedTables(
@@ -1347,6 +1394,14 @@ func (r *importResumer) OnFailOrCancel(ctx context.Context, execCtx interface{})
 	details := r.job.Details().(jobspb.ImportDetails)
 	addToFileFormatTelemetry(details.Format.Format.String(), "failed")
 	cfg := execCtx.(sql.JobExecContext).ExecCfg()
+
+	// An upsert is not rolled back: the rows it wrote would be reverted with
+	// RevertRange, which is not MVCC and so is unsafe for tables which were
+	// online for reads during it. Its tables keep the rows it wrote, but their
+	// indexes need to be repaired before they accept writes again.
+	if err := r.repairUpsertedTables(ctx, cfg); err != nil {
+		return errors.Wrap(err, "repairing indexes after partially completed IMPORT")
+	}
 	var jobsToRunAfterTxnCommit []jobspb.JobID
 	if err := sql.DescsTxn(ctx, cfg, func(
 		ctx context.Context, txn *kv.Txn, descsCol *descs.Collection,
@@ -1405,7 +1460,8 @@ func (r *importResumer) dropTables(
 	var revert []catalog.TableDescriptor
 	var empty []catalog.TableDescriptor
 	for _, tbl := range details.Tables {
-		if !tbl.IsNew {
+		// Tables which were upserted into keep the rows that the import wrote.
+		if !tbl.IsNew && !details.Upsert {
 			desc, err := descsCol.GetMutableTableVersionByID(ctx, tbl.Desc.ID, txn)
 			if err != nil {
 				return err
@@ -1481,6 +1537,7 @@ func (r *importResumer) dropTables(
 		} else {
 			// IMPORT did not create this table, so we should not drop it.
 			newTableDesc.SetPublic()
+			newTableDesc.SetReadOnly("")
 		}
 		if err := descsCol.WriteDescToBatch(
 			ctx, false /* kvTrace */, newTableDesc, b,
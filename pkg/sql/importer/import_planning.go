@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
@@ -46,6 +47,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
@@ -75,6 +77,9 @@ const (
 	importOptionDisableGlobMatch = "disable_glob_matching"
 	importOptionSaveRejected     = "experimental_save_rejected"
 	importOptionDetached         = "detached"
+	importOptionUpsert           = "upsert"
+	importOptionInferSchema      = "infer_schema"
+	importOptionSampleRows       = "sample_rows"
 
 	pgCopyDelimiter = "delimiter"
 	pgCopyNull      = "nullif"
@@ -94,6 +99,9 @@ const (
 	avroSchema    = "schema"
 	avroSchemaURI = "schema_uri"
 
+	// Turn on strict validation when importing parquet files.
+	parquetStrict = "strict_validation"
+
 	pgDumpIgnoreAllUnsupported     = "ignore_unsupported_statements"
 	pgDumpIgnoreShuntFileDest      = "log_ignored_statements"
 	pgDumpUnsupportedSchemaStmtLog = "unsupported_schema_stmts"
@@ -112,6 +120,9 @@ var importOptionExpectValues = map[string]sql.KVStringOptValidate{
 	csvRowLimit:     sql.KVStringOptRequireValue,
 	csvStrictQuotes: sql.KVStringOptRequireNoValue,
 
+	importOptionInferSchema: sql.KVStringOptRequireNoValue,
+	importOptionSampleRows:  sql.KVStringOptRequireValue,
+
 	mysqlOutfileRowSep:   sql.KVStringOptRequireValue,
 	mysqlOutfileFieldSep: sql.KVStringOptRequireValue,
 	mysqlOutfileEnclose:  sql.KVStringOptRequireValue,
@@ -125,6 +136,7 @@ var importOptionExpectValues = map[string]sql.KVStringOptValidate{
 	importOptionSkipFKs:          sql.KVStringOptRequireNoValue,
 	importOptionDisableGlobMatch: sql.KVStringOptRequireNoValue,
 	importOptionDetached:         sql.KVStringOptRequireNoValue,
+	importOptionUpsert:           sql.KVStringOptRequireNoValue,
 
 	optMaxRowSize: sql.KVStringOptRequireValue,
 
@@ -160,7 +172,8 @@ func makeStringSet(opts ...string) map[string]struct{} {
 // Options common to all formats.
 var allowedCommonOptions = makeStringSet(
 	importOptionSSTSize, importOptionDecompress, importOptionOversample,
-	importOptionSaveRejected, importOptionDisableGlobMatch, importOptionDetached)
+	importOptionSaveRejected, importOptionDisableGlobMatch, importOptionDetached,
+	importOptionUpsert)
 
 // Format specific allowed options.
 var avroAllowedOptions = makeStringSet(
@@ -168,8 +181,17 @@ var avroAllowedOptions = makeStringSet(
 	avroRecordsSeparatedBy, avroSchema, avroSchemaURI, optMaxRowSize, csvRowLimit,
 )
 
+var parquetAllowedOptions = makeStringSet(parquetStrict, csvRowLimit)
+
 var csvAllowedOptions = makeStringSet(
 	csvDelimiter, csvComment, csvNullIf, csvSkip, csvStrictQuotes, csvRowLimit,
+	importOptionInferSchema, importOptionSampleRows,
+)
+
+// Options allowed by SHOW IMPORT SCHEMA, which only reads CSV files.
+var showImportSchemaAllowedOptions = makeStringSet(
+	csvDelimiter, csvComment, csvNullIf, csvSkip, csvStrictQuotes,
+	importOptionDecompress, importOptionSampleRows,
 )
 
 var mysqlOutAllowedOptions = makeStringSet(
@@ -194,6 +216,7 @@ var allowedIntoFormats = map[string]struct{}{
 	"AVRO":      {},
 	"DELIMITED": {},
 	"PGCOPY":    {},
+	"PARQUET":   {},
 }
 
 // featureImportEnabled is used to enable and disable the IMPORT feature.
@@ -325,6 +348,33 @@ func resolveUDTsUsedByImportInto(
 	return typeDescs, err
 }
 
+// checkImportURIPrivileges checks that the user is allowed to read from the
+// given URIs: certain ExternalStorage URIs require super-user access.
+func checkImportURIPrivileges(ctx context.Context, p sql.PlanHookState, files []string) error {
+	if p.ExecCfg().ExternalIODirConfig.EnableNonAdminImplicitAndArbitraryOutbound {
+		return nil
+	}
+	for _, file := range files {
+		conf, err := cloud.ExternalStorageConfFromURI(file, p.User())
+		if err != nil {
+			// If it is a workload URI, it won't parse as a storage config, but it
+			// also doesn't have any auth concerns so just continue.
+			if _, workloadErr := parseWorkloadConfig(file); workloadErr == nil {
+				continue
+			}
+			return err
+		}
+		if !conf.AccessIsWithExplicitAuth() {
+			err := p.RequireAdminRole(ctx,
+				fmt.Sprintf("IMPORT from the specified %s URI", conf.Provider.String()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // importPlanHook implements sql.PlanHookFn.
 func importPlanHook(
 	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
@@ -385,27 +435,8 @@ func importPlanHook(
 			return err
 		}
 
-		// Certain ExternalStorage URIs require super-user access. Check all the
-		// URIs passed to the IMPORT command.
-		if !p.ExecCfg().ExternalIODirConfig.EnableNonAdminImplicitAndArbitraryOutbound {
-			for _, file := range filenamePatterns {
-				conf, err := cloud.ExternalStorageConfFromURI(file, p.User())
-				if err != nil {
-					// If it is a workload URI, it won't parse as a storage config, but it
-					// also doesn't have any auth concerns so just continue.
-					if _, workloadErr := parseWorkloadConfig(file); workloadErr == nil {
-						continue
-					}
-					return err
-				}
-				if !conf.AccessIsWithExplicitAuth() {
-					err := p.RequireAdminRole(ctx,
-						fmt.Sprintf("IMPORT from the specified %s URI", conf.Provider.String()))
-					if err != nil {
-						return err
-					}
-				}
-			}
+		if err := checkImportURIPrivileges(ctx, p, filenamePatterns); err != nil {
+			return err
 		}
 
 		var files []string
@@ -453,6 +484,10 @@ func importPlanHook(
 		// Typically the SQL grammar means it is only possible to specifying exactly
 		// one pgdump/mysqldump URI, but glob-expansion could have changed that.
 		if importStmt.Bundle && len(files) != 1 {
+			if importStmt.FileFormat == "CSV" {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"files must be imported individually with the %s option", importOptionInferSchema)
+			}
 			return pgerror.New(pgcode.FeatureNotSupported, "SQL dump files must be imported individually")
 		}
 
@@ -520,54 +555,8 @@ func importPlanHook(
 			if err = validateFormatOptions(importStmt.FileFormat, opts, csvAllowedOptions); err != nil {
 				return err
 			}
-			format.Format = roachpb.IOFileFormat_CSV
-			// Set the default CSV separator for the cases when it is not overwritten.
-			format.Csv.Comma = ','
-			if override, ok := opts[csvDelimiter]; ok {
-				comma, err := util.GetSingleRune(override)
-				if err != nil {
-					return pgerror.Wrap(err, pgcode.Syntax, "invalid comma value")
-				}
-				format.Csv.Comma = comma
-			}
-
-			if override, ok := opts[csvComment]; ok {
-				comment, err := util.GetSingleRune(override)
-				if err != nil {
-					return pgerror.Wrap(err, pgcode.Syntax, "invalid comment value")
-				}
-				format.Csv.Comment = comment
-			}
-
-			if override, ok := opts[csvNullIf]; ok {
-				format.Csv.NullEncoding = &override
-			}
-
-			if override, ok := opts[csvSkip]; ok {
-				skip, err := strconv.Atoi(override)
-				if err != nil {
-					return pgerror.Wrapf(err, pgcode.Syntax, "invalid %s value", csvSkip)
-				}
-				if skip < 0 {
-					return pgerror.Newf(pgcode.Syntax, "%s must be >= 0", csvSkip)
-				}
-				format.Csv.Skip = uint32(skip)
-			}
-			if _, ok := opts[csvStrictQuotes]; ok {
-				format.Csv.StrictQuotes = true
-			}
-			if _, ok := opts[importOptionSaveRejected]; ok {
-				format.SaveRejected = true
-			}
-			if override, ok := opts[csvRowLimit]; ok {
-				rowLimit, err := strconv.Atoi(override)
-				if err != nil {
-					return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
-				}
-				if rowLimit <= 0 {
-					return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
-				}
-				format.Csv.RowLimit = int64(rowLimit)
+			if err := parseCSVOptions(opts, &format); err != nil {
+				return err
 			}
 		case "DELIMITED":
 			if err = validateFormatOptions(importStmt.FileFormat, opts, mysqlOutAllowedOptions); err != nil {
@@ -730,6 +719,13 @@ func importPlanHook(
 			if err != nil {
 				return err
 			}
+		case "PARQUET":
+			if err = validateFormatOptions(importStmt.FileFormat, opts, parquetAllowedOptions); err != nil {
+				return err
+			}
+			if err := parseParquetOptions(opts, &format); err != nil {
+				return err
+			}
 		default:
 			return unimplemented.Newf("import.format", "unsupported import format: %q", importStmt.FileFormat)
 		}
@@ -759,17 +755,57 @@ func importPlanHook(
 			skipFKs = true
 		}
 
-		if override, ok := opts[importOptionDecompress]; ok {
-			found := false
-			for name, value := range roachpb.IOFileFormat_Compression_value {
-				if strings.EqualFold(name, override) {
-					format.Compression = roachpb.IOFileFormat_Compression(value)
-					found = true
-					break
-				}
+		var upsert bool
+		if _, ok := opts[importOptionUpsert]; ok {
+			if !importStmt.Into {
+				return errors.Newf("%s option is only supported by IMPORT INTO", importOptionUpsert)
 			}
-			if !found {
-				return unimplemented.Newf("import.compression", "unsupported compression value: %q", override)
+			if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.ImportIntoUpsert) {
+				return errors.Newf("%s option requires the cluster to be upgraded", importOptionUpsert)
+			}
+			upsert = true
+			p.BufferClientNotice(ctx, pgnotice.Newf("IMPORT INTO with %s is not rolled back if it "+
+				"fails or is canceled: the rows it has written remain in the table", importOptionUpsert))
+		}
+
+		// The schema of the table created by IMPORT TABLE ... FROM CSV is
+		// inferred from a sample of the file when the job starts.
+		var inferSchemaSampleRows int64
+		if _, ok := opts[importOptionInferSchema]; ok {
+			if !importStmt.Bundle || format.Format != roachpb.IOFileFormat_CSV {
+				return errors.Newf("%s option is only supported by IMPORT TABLE ... FROM CSV",
+					importOptionInferSchema)
+			}
+			if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.ImportInferSchema) {
+				return errors.Newf("%s option requires the cluster to be upgraded", importOptionInferSchema)
+			}
+			inferSchemaSampleRows, err = parseSampleRowsOption(opts)
+			if err != nil {
+				return err
+			}
+		} else if _, ok := opts[importOptionSampleRows]; ok {
+			return errors.Newf("%s option requires the %s option",
+				importOptionSampleRows, importOptionInferSchema)
+		} else if importStmt.Bundle && format.Format == roachpb.IOFileFormat_CSV {
+			return errors.WithHint(
+				errors.Newf("IMPORT of CSV files into a new table requires the %s option",
+					importOptionInferSchema),
+				"create the table with CREATE TABLE and use IMPORT INTO instead")
+		}
+
+		if err := parseDecompressOption(opts, &format); err != nil {
+			return err
+		}
+
+		// Split large parquet files by row group so that they are imported by
+		// several nodes in parallel. A row limit applies to each file, so files
+		// are not split when there is one.
+		if format.Format == roachpb.IOFileFormat_Parquet && format.Parquet.RowLimit == 0 {
+			files, err = splitParquetFiles(ctx, files, &format.Parquet,
+				parquetSplitSize.Get(&p.ExecCfg().Settings.SV),
+				p.ExecCfg().DistSQLSrv.ExternalStorageFromURI, p.User())
+			if err != nil {
+				return err
 			}
 		}
 
@@ -796,6 +832,12 @@ func importPlanHook(
 				return err
 			}
 
+			if upsert {
+				if err := validateTableForUpsert(found); err != nil {
+					return err
+				}
+			}
+
 			// Validate target columns.
 			var intoCols []string
 			isTargetCol := make(map[string]bool)
@@ -856,6 +898,10 @@ func importPlanHook(
 					}
 					tableName = fmt.Sprintf("%s.%s", table.SchemaName.String(),
 						table.ObjectName.String())
+				} else if format.Format == roachpb.IOFileFormat_CSV {
+					// The name of a table whose schema is inferred is not matched
+					// against the names in a dump, but given to the table as is.
+					tableName = string(table.ObjectName)
 				}
 				tableDetails[0] = jobspb.ImportDetails_Table{
 					Name:  tableName,
@@ -927,6 +973,8 @@ func importPlanHook(
 			SSTSize:               sstSize,
 			Oversample:            oversample,
 			SkipFKs:               skipFKs,
+			Upsert:                upsert,
+			InferSchemaSampleRows: inferSchemaSampleRows,
 			ParseBundleSchema:     importStmt.Bundle,
 			DefaultIntSize:        p.SessionData().DefaultIntSize,
 			DatabasePrimaryRegion: databasePrimaryRegion,
@@ -1088,6 +1136,111 @@ func parseAvroOptions(
 	return nil
 }
 
+func parseCSVOptions(opts map[string]string, format *roachpb.IOFileFormat) error {
+	format.Format = roachpb.IOFileFormat_CSV
+	// Set the default CSV separator for the cases when it is not overwritten.
+	format.Csv.Comma = ','
+	if override, ok := opts[csvDelimiter]; ok {
+		comma, err := util.GetSingleRune(override)
+		if err != nil {
+			return pgerror.Wrap(err, pgcode.Syntax, "invalid comma value")
+		}
+		format.Csv.Comma = comma
+	}
+
+	if override, ok := opts[csvComment]; ok {
+		comment, err := util.GetSingleRune(override)
+		if err != nil {
+			return pgerror.Wrap(err, pgcode.Syntax, "invalid comment value")
+		}
+		format.Csv.Comment = comment
+	}
+
+	if override, ok := opts[csvNullIf]; ok {
+		format.Csv.NullEncoding = &override
+	}
+
+	if override, ok := opts[csvSkip]; ok {
+		skip, err := strconv.Atoi(override)
+		if err != nil {
+			return pgerror.Wrapf(err, pgcode.Syntax, "invalid %s value", csvSkip)
+		}
+		if skip < 0 {
+			return pgerror.Newf(pgcode.Syntax, "%s must be >= 0", csvSkip)
+		}
+		format.Csv.Skip = uint32(skip)
+	}
+	if _, ok := opts[csvStrictQuotes]; ok {
+		format.Csv.StrictQuotes = true
+	}
+	if _, ok := opts[importOptionSaveRejected]; ok {
+		format.SaveRejected = true
+	}
+	if override, ok := opts[csvRowLimit]; ok {
+		rowLimit, err := strconv.Atoi(override)
+		if err != nil {
+			return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
+		}
+		if rowLimit <= 0 {
+			return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
+		}
+		format.Csv.RowLimit = int64(rowLimit)
+	}
+	return nil
+}
+
+// parseSampleRowsOption returns the number of rows of a CSV file that are
+// read to infer its schema.
+func parseSampleRowsOption(opts map[string]string) (int64, error) {
+	override, ok := opts[importOptionSampleRows]
+	if !ok {
+		return inferSchemaDefaultSampleRows, nil
+	}
+	sampleRows, err := strconv.Atoi(override)
+	if err != nil {
+		return 0, pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", importOptionSampleRows)
+	}
+	if sampleRows <= 0 {
+		return 0, pgerror.Newf(pgcode.Syntax, "%s must be > 0", importOptionSampleRows)
+	}
+	return int64(sampleRows), nil
+}
+
+func parseDecompressOption(opts map[string]string, format *roachpb.IOFileFormat) error {
+	override, ok := opts[importOptionDecompress]
+	if !ok {
+		return nil
+	}
+	for name, value := range roachpb.IOFileFormat_Compression_value {
+		if strings.EqualFold(name, override) {
+			format.Compression = roachpb.IOFileFormat_Compression(value)
+			return nil
+		}
+	}
+	return unimplemented.Newf("import.compression", "unsupported compression value: %q", override)
+}
+
+func parseParquetOptions(opts map[string]string, format *roachpb.IOFileFormat) error {
+	format.Format = roachpb.IOFileFormat_Parquet
+	if _, ok := opts[importOptionDecompress]; ok {
+		// Parquet files compress their pages themselves.
+		return errors.Errorf("%s option is not supported for parquet files", importOptionDecompress)
+	}
+	_, format.Parquet.StrictMode = opts[parquetStrict]
+
+	if override, ok := opts[csvRowLimit]; ok {
+		rowLimit, err := strconv.Atoi(override)
+		if err != nil {
+			return pgerror.Wrapf(err, pgcode.Syntax, "invalid numeric %s value", csvRowLimit)
+		}
+		if rowLimit <= 0 {
+			return pgerror.Newf(pgcode.Syntax, "%s must be > 0", csvRowLimit)
+		}
+		format.Parquet.RowLimit = int64(rowLimit)
+	}
+	return nil
+}
+
 type loggerKind int
 
 const (
@@ -1197,6 +1350,103 @@ func (u *unsupportedStmtLogger) flush() error {
 	return nil
 }
 
+var showImportSchemaHeader = colinfo.ResultColumns{
+	{Name: "table_name", Typ: types.String},
+	{Name: "create_statement", Typ: types.String},
+}
+
+// showImportSchemaPlanHook implements sql.PlanHookFn for SHOW IMPORT SCHEMA,
+// which prints the CREATE TABLE statement of a table with the schema inferred
+// from a sample of a CSV file.
+func showImportSchemaPlanHook(
+	ctx context.Context, stmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, []sql.PlanNode, bool, error) {
+	showStmt, ok := stmt.(*tree.ShowImportSchema)
+	if !ok {
+		return nil, nil, nil, false, nil
+	}
+
+	if err := featureflag.CheckEnabled(
+		ctx,
+		p.ExecCfg(),
+		featureImportEnabled,
+		"SHOW IMPORT SCHEMA",
+	); err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	if showStmt.FileFormat != "CSV" {
+		return nil, nil, nil, false, unimplemented.Newf("import.format",
+			"SHOW IMPORT SCHEMA does not support the %s format", showStmt.FileFormat)
+	}
+
+	filesFn, err := p.TypeAsStringArray(ctx, showStmt.Files, "SHOW IMPORT SCHEMA")
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	optsFn, err := p.TypeAsStringOpts(ctx, showStmt.Options, importOptionExpectValues)
+	if err != nil {
+		return nil, nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, _ []sql.PlanNode, resultsCh chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, showStmt.StatementTag())
+		defer span.Finish()
+
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.ImportInferSchema) {
+			return errors.New("SHOW IMPORT SCHEMA requires the cluster to be upgraded")
+		}
+
+		opts, err := optsFn()
+		if err != nil {
+			return err
+		}
+		for opt := range opts {
+			if _, ok := showImportSchemaAllowedOptions[opt]; !ok {
+				return errors.Errorf("invalid option %q specified for SHOW IMPORT SCHEMA", opt)
+			}
+		}
+
+		files, err := filesFn()
+		if err != nil {
+			return err
+		}
+		if err := checkImportURIPrivileges(ctx, p, files); err != nil {
+			return err
+		}
+
+		format := roachpb.IOFileFormat{}
+		if err := parseCSVOptions(opts, &format); err != nil {
+			return err
+		}
+		if err := parseDecompressOption(opts, &format); err != nil {
+			return err
+		}
+		sampleRows, err := parseSampleRowsOption(opts)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			schema, err := readCSVSchema(ctx, &p.ExtendedEvalContext().EvalContext, file, format,
+				sampleRows, p.ExecCfg().DistSQLSrv.ExternalStorageFromURI, p.User())
+			if err != nil {
+				return err
+			}
+			name := inferredTableName(file)
+			create := schema.createTable(tree.MakeUnqualifiedTableName(tree.Name(name)))
+			resultsCh <- tree.Datums{
+				tree.NewDString(name),
+				tree.NewDString(tree.AsString(create)),
+			}
+		}
+		return nil
+	}
+	return fn, showImportSchemaHeader, nil, false, nil
+}
+
 func init() {
 	sql.AddPlanHook("import", importPlanHook)
+	sql.AddPlanHook("show import schema", showImportSchemaPlanHook)
 }
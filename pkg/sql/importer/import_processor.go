@@ -317,6 +317,10 @@ func makeInputConverter(
 		return newAvroInputReader(
 			semaCtx, kvCh, singleTable, spec.Format.Avro, spec.WalltimeNanos,
 			int(spec.ReaderParallelism), evalCtx)
+	case roachpb.IOFileFormat_Parquet:
+		return newParquetInputReader(
+			semaCtx, kvCh, singleTable, singleTableTargetCols, spec.Format.Parquet,
+			spec.WalltimeNanos, int(spec.ReaderParallelism), evalCtx)
 	default:
 		return nil, errors.Errorf(
 			"Requested IMPORT format (%d) not supported by this node", spec.Format.Format)
@@ -350,6 +354,20 @@ func ingestKvs(
 		writeAtBatchTimestamp = false
 	}
 
+	// An upsert shadows the existing version of each key it writes. The table
+	// stays online during it, so the new versions must be written at the
+	// current timestamp rather than below timestamps that may have been read.
+	// When the input has several rows for a key, the adders keep the one added
+	// last, and later flushes write at later timestamps, so the newest row wins.
+	disallowShadowingBelow := writeTS
+	if spec.Upsert {
+		if !writeAtBatchTimestamp {
+			return nil, errors.Newf("IMPORT INTO with upsert requires %s to be enabled "+
+				"on a fully upgraded cluster", importAtNow.Key())
+		}
+		disallowShadowingBelow = hlc.Timestamp{}
+	}
+
 	isPK := make(map[tableAndIndex]bool, len(spec.Tables))
 	for _, t := range spec.Tables {
 		isPK[tableAndIndex{tableID: t.Desc.ID, indexID: t.Desc.PrimaryIndex.ID}] = true
@@ -370,8 +388,9 @@ func ingestKvs(
 		true /* isPKAdder */)
 	pkIndexAdder, err := flowCtx.Cfg.BulkAdder(ctx, flowCtx.Cfg.DB, writeTS, kvserverbase.BulkAdderOptions{
 		Name:                     "pkAdder",
-		DisallowShadowingBelow:   writeTS,
+		DisallowShadowingBelow:   disallowShadowingBelow,
 		SkipDuplicates:           true,
+		ReplaceDuplicates:        spec.Upsert,
 		MinBufferSize:            minBufferSize,
 		MaxBufferSize:            maxBufferSize,
 		StepBufferSize:           stepSize,
@@ -388,8 +407,9 @@ func ingestKvs(
 		false /* isPKAdder */)
 	indexAdder, err := flowCtx.Cfg.BulkAdder(ctx, flowCtx.Cfg.DB, writeTS, kvserverbase.BulkAdderOptions{
 		Name:                     "indexAdder",
-		DisallowShadowingBelow:   writeTS,
+		DisallowShadowingBelow:   disallowShadowingBelow,
 		SkipDuplicates:           true,
+		ReplaceDuplicates:        spec.Upsert,
 		MinBufferSize:            minBufferSize,
 		MaxBufferSize:            maxBufferSize,
 		StepBufferSize:           stepSize,
@@ -267,6 +267,12 @@ func makeImportReaderSpecs(
 				UserProto:             user.EncodeProto(),
 				DatabasePrimaryRegion: details.DatabasePrimaryRegion,
 				InitialSplits:         int32(len(sqlInstanceIDs)),
+				Upsert:                details.Upsert,
+			}
+			if details.Upsert {
+				// The newest row of a key is the one converted last, so the rows of
+				// each file are converted in order by a single worker.
+				spec.ReaderParallelism = 1
 			}
 			inputSpecs = append(inputSpecs, spec)
 		}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
@@ -70,6 +71,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
+	goparquet "github.com/fraugster/parquet-go"
 	"github.com/jackc/pgx/v4"
 	"github.com/linkedin/goavro/v2"
 	"github.com/stretchr/testify/assert"
@@ -6378,6 +6380,170 @@ func TestImportAvro(t *testing.T) {
 	})
 }
 
+func TestImportParquet(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	const (
+		nodes = 3
+	)
+	ctx := context.Background()
+	dir, dirCleanupFn := testutils.TempDir(t)
+	defer dirCleanupFn()
+	args := base.TestServerArgs{ExternalIODir: dir}
+	tc := serverutils.StartNewTestCluster(t, nodes, base.TestClusterArgs{ServerArgs: args})
+	defer tc.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+
+	sqlDB.Exec(t, `CREATE DATABASE foo; SET DATABASE = foo`)
+
+	t.Run("roundtrip-export", func(t *testing.T) {
+		const cols = `i INT8 PRIMARY KEY, s STRING, b BYTES, d DECIMAL, dt DATE, ts TIMESTAMP,
+			tz TIMESTAMPTZ, t TIME, iv INTERVAL, u UUID, j JSONB, f FLOAT8, bo BOOL, ip INET,
+			arr STRING[]`
+		sqlDB.Exec(t, fmt.Sprintf(`CREATE TABLE src (%s)`, cols))
+		sqlDB.Exec(t, `INSERT INTO src VALUES
+			(1, 'a', 'bytes', 1.25, '2022-01-02', '2022-01-02 03:04:05.123456',
+			 '2022-01-02 03:04:05+01', '12:34:56.789', '1 day 2 hours',
+			 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', '{"k": [1, 2]}', 1.5, true, '192.168.0.1',
+			 ARRAY['x', 'y']),
+			(2, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL),
+			(3, '', '', -0.001, '1999-12-31', '1999-12-31 23:59:59', '1999-12-31 23:59:59+00',
+			 '00:00:00', '-3 months', '00000000-0000-0000-0000-000000000000', '[]', -2.25, false,
+			 '::1', ARRAY[]::STRING[])`)
+		sqlDB.Exec(t, `EXPORT INTO PARQUET 'nodelocal://0/roundtrip' FROM TABLE src`)
+
+		sqlDB.Exec(t, fmt.Sprintf(`CREATE TABLE dst (%s)`, cols))
+		sqlDB.Exec(t, `IMPORT INTO dst PARQUET DATA ('nodelocal://0/roundtrip/*.parquet')`)
+		sqlDB.CheckQueryResults(t, `SELECT * FROM dst ORDER BY i`,
+			sqlDB.QueryStr(t, `SELECT * FROM src ORDER BY i`))
+	})
+
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(dir, "groups.parquet"), writeParquetTestFile(t, 6, 10), 0644))
+
+	t.Run("split-row-groups", func(t *testing.T) {
+		// Split the file into an input per row group.
+		sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.import.parquet_split_size = 1`)
+		defer sqlDB.Exec(t, `RESET CLUSTER SETTING bulkio.import.parquet_split_size`)
+
+		sqlDB.Exec(t, parquetTestCreateTable)
+		var importJobID jobspb.JobID
+		var unused interface{}
+		sqlDB.QueryRow(t, `IMPORT INTO test PARQUET DATA ('nodelocal://0/groups.parquet')
+			WITH strict_validation`).Scan(&importJobID, &unused, &unused, &unused, &unused, &unused)
+		sqlDB.CheckQueryResults(t,
+			`SELECT count(*), count(DISTINCT id), min(id), max(id), count(s) FROM test`,
+			[][]string{{"60", "60", "1", "60", "30"}})
+
+		// Each of the row groups was read by its own input.
+		resumePos := jobutils.GetJobProgress(t, sqlDB, importJobID).GetImport().ResumePos
+		require.Equal(t, []int64{10, 10, 10, 10, 10, 10}, resumePos)
+	})
+
+	t.Run("split-several-row-groups", func(t *testing.T) {
+		// Split the file into inputs of two row groups each.
+		data, err := ioutil.ReadFile(filepath.Join(dir, "groups.parquet"))
+		require.NoError(t, err)
+		meta, err := goparquet.ReadFileMetaData(bytes.NewReader(data), true /* extraValidation */)
+		require.NoError(t, err)
+		require.Len(t, meta.RowGroups, 6)
+		splitSize := int64(math.MaxInt64)
+		for i := 0; i+1 < len(meta.RowGroups); i++ {
+			if pair := meta.RowGroups[i].TotalByteSize + meta.RowGroups[i+1].TotalByteSize; pair < splitSize {
+				splitSize = pair
+			}
+		}
+		for _, rg := range meta.RowGroups {
+			require.Less(t, rg.TotalByteSize, splitSize)
+		}
+		sqlDB.Exec(t, `SET CLUSTER SETTING bulkio.import.parquet_split_size = $1`, splitSize)
+		defer sqlDB.Exec(t, `RESET CLUSTER SETTING bulkio.import.parquet_split_size`)
+
+		sqlDB.Exec(t, `CREATE TABLE pairs (id INT8 PRIMARY KEY, s STRING)`)
+		var importJobID jobspb.JobID
+		var unused interface{}
+		sqlDB.QueryRow(t, `IMPORT INTO pairs PARQUET DATA ('nodelocal://0/groups.parquet')`).Scan(
+			&importJobID, &unused, &unused, &unused, &unused, &unused)
+		sqlDB.CheckQueryResults(t, `SELECT count(*), min(id), max(id), count(s) FROM pairs`,
+			[][]string{{"60", "1", "60", "30"}})
+
+		resumePos := jobutils.GetJobProgress(t, sqlDB, importJobID).GetImport().ResumePos
+		require.Equal(t, []int64{20, 20, 20}, resumePos)
+	})
+
+	t.Run("row-limit", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE limited (id INT8 PRIMARY KEY, s STRING)`)
+		sqlDB.Exec(t, `IMPORT INTO limited PARQUET DATA ('nodelocal://0/groups.parquet')
+			WITH row_limit = '15'`)
+		sqlDB.CheckQueryResults(t, `SELECT count(*), max(id) FROM limited`, [][]string{{"15", "15"}})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		sqlDB.Exec(t, `CREATE TABLE errs (id INT8 PRIMARY KEY, arr INT8)`)
+		sqlDB.ExpectErr(t, "decompress option is not supported for parquet files",
+			`IMPORT INTO errs PARQUET DATA ('nodelocal://0/groups.parquet') WITH decompress = 'gzip'`)
+		sqlDB.ExpectErr(t, "cannot convert parquet list arr to int",
+			`IMPORT INTO errs PARQUET DATA ('nodelocal://0/groups.parquet')`)
+	})
+}
+
+func TestImportInferSchema(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	dir, dirCleanupFn := testutils.TempDir(t)
+	defer dirCleanupFn()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "orders.csv"), []byte(`id,customer,amount,placed
+1,alice,10.50,2022-01-02
+2,bob,,2022-01-03
+3,carol,7,2022-01-04
+`), 0644))
+
+	t.Run("show", func(t *testing.T) {
+		sqlDB.CheckQueryResults(t,
+			`SHOW IMPORT SCHEMA FROM CSV 'nodelocal://0/orders.csv' WITH nullif = ''`,
+			[][]string{{"orders", "CREATE TABLE orders (id INT8 NOT NULL PRIMARY KEY, " +
+				"customer STRING NOT NULL, amount DECIMAL, placed DATE NOT NULL)"}})
+		sqlDB.CheckQueryResults(t,
+			`SHOW IMPORT SCHEMA FROM CSV 'nodelocal://0/orders.csv' WITH nullif = '', skip = '1'`,
+			[][]string{{"orders", "CREATE TABLE orders (column1 INT8 NOT NULL PRIMARY KEY, " +
+				"column2 STRING NOT NULL, column3 DECIMAL, column4 DATE NOT NULL)"}})
+	})
+
+	t.Run("import", func(t *testing.T) {
+		sqlDB.Exec(t, `IMPORT TABLE orders FROM CSV 'nodelocal://0/orders.csv'
+			WITH infer_schema, nullif = ''`)
+		sqlDB.CheckQueryResults(t,
+			`SELECT id, customer, amount, placed::STRING FROM orders ORDER BY id`,
+			[][]string{
+				{"1", "alice", "10.50", "2022-01-02"},
+				{"2", "bob", "NULL", "2022-01-03"},
+				{"3", "carol", "7", "2022-01-04"},
+			})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "IMPORT of CSV files into a new table requires the infer_schema option",
+			`IMPORT TABLE other FROM CSV 'nodelocal://0/orders.csv'`)
+		sqlDB.ExpectErr(t, "infer_schema option is only supported by IMPORT TABLE ... FROM CSV",
+			`IMPORT INTO orders CSV DATA ('nodelocal://0/orders.csv') WITH infer_schema`)
+		sqlDB.ExpectErr(t, "sample_rows option requires the infer_schema option",
+			`IMPORT TABLE other FROM CSV 'nodelocal://0/orders.csv' WITH sample_rows = '10'`)
+		sqlDB.ExpectErr(t, "sample_rows must be > 0",
+			`SHOW IMPORT SCHEMA FROM CSV 'nodelocal://0/orders.csv' WITH sample_rows = '0'`)
+		sqlDB.ExpectErr(t, `invalid option "detached" specified for SHOW IMPORT SCHEMA`,
+			`SHOW IMPORT SCHEMA FROM CSV 'nodelocal://0/orders.csv' WITH detached`)
+		sqlDB.ExpectErr(t, "SHOW IMPORT SCHEMA does not support the PGDUMP format",
+			`SHOW IMPORT SCHEMA FROM PGDUMP 'nodelocal://0/orders.csv'`)
+	})
+}
+
 // TestImportClientDisconnect ensures that an import job can complete even if
 // the client connection which started it closes. This test uses a helper
 // subprocess to force a closed client connection without needing to rely
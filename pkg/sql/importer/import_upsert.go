@@ -0,0 +1,474 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// upsertRepairExportSize is the target size of the SSTs in which the repair
+	// of the secondary indexes after an upsert reads the keys written by the
+	// import.
+	upsertRepairExportSize = 16 << 20
+	// upsertRepairBatchKeys is the maximum number of exported keys repaired at
+	// once, which bounds the batches reading the rows they belong to.
+	upsertRepairBatchKeys = 1000
+	// upsertRepairBatchBytes is the size of the mutations in a batch of index
+	// entries above which the repair sends it.
+	upsertRepairBatchBytes = 4 << 20
+)
+
+// validateTableForUpsert checks that the secondary indexes of a table can be
+// repaired after an upsert, see repairIndexesAfterUpsert.
+func validateTableForUpsert(desc catalog.TableDescriptor) error {
+	notSupported := func(what string) error {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"IMPORT INTO with upsert is not supported for tables with %s", what)
+	}
+	if len(desc.GetFamilies()) > 1 {
+		return notSupported("multiple column families")
+	}
+	if len(desc.GetUniqueWithoutIndexConstraints()) > 0 {
+		return notSupported("UNIQUE WITHOUT INDEX constraints")
+	}
+	for _, idx := range desc.PublicNonPrimaryIndexes() {
+		if idx.IsUnique() {
+			return notSupported("unique secondary indexes")
+		}
+		if idx.IsPartial() {
+			return notSupported("partial indexes")
+		}
+		for i := 0; i < idx.NumKeyColumns(); i++ {
+			col, err := desc.FindColumnWithID(idx.GetKeyColumnID(i))
+			if err != nil {
+				return err
+			}
+			if col.IsVirtual() {
+				return notSupported("indexes on virtual columns")
+			}
+		}
+	}
+	return nil
+}
+
+// repairIndexesAfterUpsert brings the secondary indexes of a table which an
+// upsert import wrote to back in line with its primary index.
+//
+// The import writes the primary and secondary index entries of each row
+// through separate bulk adders, without reading the row it replaces. It thus
+// leaves behind the index entries of the replaced rows and, if it failed, may
+// have written only one side of some rows. Every key the import wrote has a
+// version above walltime, so both are repaired by visiting those keys. For a
+// row whose primary index key was written, the index entries of all its
+// versions are deleted, except for the entries of its latest version, which
+// are written again. A secondary index entry that was written is written
+// again if the latest version of its row has it, and deleted otherwise.
+//
+// The table must not be written to by anything but the import, and the repair
+// is idempotent, so it can be retried after a failure.
+func repairIndexesAfterUpsert(
+	ctx context.Context,
+	db *kv.DB,
+	codec keys.SQLCodec,
+	table catalog.TableDescriptor,
+	walltime hlc.Timestamp,
+) error {
+	if len(table.PublicNonPrimaryIndexes()) == 0 {
+		return nil
+	}
+	r, err := makeUpsertIndexRepairer(ctx, db, codec, table, walltime)
+	if err != nil {
+		return err
+	}
+	log.Infof(ctx, "repairing secondary indexes of table %d after upsert", table.GetID())
+	if err := r.exportRevisions(ctx, table.PrimaryIndexSpan(codec), r.repairRows); err != nil {
+		return errors.Wrapf(err, "repairing index entries of rows of table %d", table.GetID())
+	}
+	for _, idx := range r.indexes {
+		span := table.IndexSpan(codec, idx.GetID())
+		if err := r.exportRevisions(ctx, span, func(
+			ctx context.Context, indexKeys []roachpb.Key, revisions [][]roachpb.Value,
+		) error {
+			return r.repairIndexEntries(ctx, idx, indexKeys, revisions)
+		}); err != nil {
+			return errors.Wrapf(err, "repairing entries of index %d of table %d", idx.GetID(), table.GetID())
+		}
+	}
+	return nil
+}
+
+// upsertIndexRepairer holds the state used to repair the secondary indexes of
+// a table after an upsert.
+type upsertIndexRepairer struct {
+	db       *kv.DB
+	codec    keys.SQLCodec
+	table    catalog.TableDescriptor
+	indexes  []catalog.Index
+	walltime hlc.Timestamp
+
+	alloc tree.DatumAlloc
+	// rowFetcher decodes rows from the primary index, colMap maps the IDs of
+	// the columns it fetches to their positions in its rows.
+	rowFetcher row.Fetcher
+	colMap     catalog.TableColMap
+	// pkFetchers decode the primary key of a row from the entries of each
+	// secondary index, pkColMap maps the IDs of the primary key columns to
+	// their positions in the decoded rows.
+	pkFetchers map[descpb.IndexID]*row.Fetcher
+	pkColMap   catalog.TableColMap
+}
+
+func makeUpsertIndexRepairer(
+	ctx context.Context,
+	db *kv.DB,
+	codec keys.SQLCodec,
+	table catalog.TableDescriptor,
+	walltime hlc.Timestamp,
+) (*upsertIndexRepairer, error) {
+	r := &upsertIndexRepairer{
+		db:         db,
+		codec:      codec,
+		table:      table,
+		indexes:    table.PublicNonPrimaryIndexes(),
+		walltime:   walltime,
+		colMap:     catalog.ColumnIDToOrdinalMap(table.PublicColumns()),
+		pkFetchers: make(map[descpb.IndexID]*row.Fetcher),
+	}
+	initFetcher := func(rf *row.Fetcher, index catalog.Index, columnIDs []descpb.ColumnID) error {
+		var spec descpb.IndexFetchSpec
+		if err := rowenc.InitIndexFetchSpec(&spec, codec, table, index, columnIDs); err != nil {
+			return err
+		}
+		if err := rf.Init(
+			ctx,
+			false, /* reverse */
+			descpb.ScanLockingStrength_FOR_NONE,
+			descpb.ScanLockingWaitPolicy_BLOCK,
+			descpb.ScanLockingDurability_BEST_EFFORT,
+			0, /* lockTimeout */
+			&r.alloc,
+			nil, /* memMonitor */
+			&spec,
+		); err != nil {
+			return err
+		}
+		// Virtual columns are not populated, but none is indexed.
+		rf.IgnoreUnexpectedNulls = true
+		return nil
+	}
+	if err := initFetcher(&r.rowFetcher, table.GetPrimaryIndex(), table.PublicColumnIDs()); err != nil {
+		return nil, err
+	}
+	primary := table.GetPrimaryIndex()
+	pkColumnIDs := make([]descpb.ColumnID, primary.NumKeyColumns())
+	for i := range pkColumnIDs {
+		pkColumnIDs[i] = primary.GetKeyColumnID(i)
+		r.pkColMap.Set(pkColumnIDs[i], i)
+	}
+	for _, idx := range r.indexes {
+		rf := &row.Fetcher{}
+		if err := initFetcher(rf, idx, pkColumnIDs); err != nil {
+			return nil, err
+		}
+		r.pkFetchers[idx.GetID()] = rf
+	}
+	return r, nil
+}
+
+// exportRevisions calls fn with the keys in span which have versions above
+// walltime, and their versions above walltime, newest first. The keys are
+// passed in batches of at most upsertRepairBatchKeys keys.
+func (r *upsertIndexRepairer) exportRevisions(
+	ctx context.Context,
+	span roachpb.Span,
+	fn func(ctx context.Context, keys []roachpb.Key, revisions [][]roachpb.Value) error,
+) error {
+	now := r.db.Clock().Now()
+	for {
+		req := &roachpb.ExportRequest{
+			RequestHeader:  roachpb.RequestHeaderFromSpan(span),
+			StartTime:      r.walltime,
+			MVCCFilter:     roachpb.MVCCFilter_All,
+			TargetFileSize: upsertRepairExportSize,
+			ReturnSST:      true,
+		}
+		// The sentinel value of 1 makes the request paginate after each SST.
+		header := roachpb.Header{Timestamp: now, TargetBytes: 1}
+		resp, pErr := kv.SendWrappedWith(ctx, r.db.NonTransactionalSender(), header, req)
+		if pErr != nil {
+			return pErr.GoError()
+		}
+		res := resp.(*roachpb.ExportResponse)
+		for _, file := range res.Files {
+			exported, revisions, err := readRevisions(file.SST)
+			if err != nil {
+				return err
+			}
+			for len(exported) > 0 {
+				n := len(exported)
+				if n > upsertRepairBatchKeys {
+					n = upsertRepairBatchKeys
+				}
+				if err := fn(ctx, exported[:n], revisions[:n]); err != nil {
+					return err
+				}
+				exported, revisions = exported[n:], revisions[n:]
+			}
+		}
+		if res.ResumeSpan == nil {
+			return nil
+		}
+		span = *res.ResumeSpan
+	}
+}
+
+// readRevisions returns the keys in an exported SST along with their
+// versions, newest first.
+func readRevisions(sst []byte) ([]roachpb.Key, [][]roachpb.Value, error) {
+	iter, err := storage.NewMemSSTIterator(sst, false /* verify */)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer iter.Close()
+	var exported []roachpb.Key
+	var revisions [][]roachpb.Value
+	for iter.SeekGE(storage.NilKey); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil {
+			return nil, nil, err
+		} else if !ok {
+			break
+		}
+		key := iter.UnsafeKey()
+		if len(exported) == 0 || !exported[len(exported)-1].Equal(key.Key) {
+			exported = append(exported, key.Key.Clone())
+			revisions = append(revisions, nil)
+		}
+		value := roachpb.Value{RawBytes: append([]byte(nil), iter.UnsafeValue()...), Timestamp: key.Timestamp}
+		revisions[len(revisions)-1] = append(revisions[len(revisions)-1], value)
+	}
+	return exported, revisions, nil
+}
+
+// maybeRunBatch sends b if its mutations have reached upsertRepairBatchBytes,
+// and returns the batch to add further mutations to.
+func (r *upsertIndexRepairer) maybeRunBatch(ctx context.Context, b *kv.Batch) (*kv.Batch, error) {
+	if b.ApproximateMutationBytes() < upsertRepairBatchBytes {
+		return b, nil
+	}
+	if err := r.db.Run(ctx, b); err != nil {
+		return nil, err
+	}
+	return &kv.Batch{}, nil
+}
+
+// decodeRow decodes the row stored under a primary index key, which is nil if
+// the value is a deletion tombstone.
+func (r *upsertIndexRepairer) decodeRow(
+	ctx context.Context, key roachpb.Key, value roachpb.Value,
+) (tree.Datums, error) {
+	if !value.IsPresent() {
+		return nil, nil
+	}
+	kvs := &row.SpanKVFetcher{KVs: []roachpb.KeyValue{{Key: key, Value: value}}}
+	if err := r.rowFetcher.StartScanFrom(ctx, kvs, false /* traceKV */); err != nil {
+		return nil, err
+	}
+	datums, err := r.rowFetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The fetcher reuses the datums of the rows it returns.
+	return append(tree.Datums(nil), datums...), nil
+}
+
+// indexEntries returns the entries of a row in the given secondary indexes.
+func (r *upsertIndexRepairer) indexEntries(
+	datums tree.Datums, indexes []catalog.Index,
+) ([]rowenc.IndexEntry, error) {
+	if datums == nil {
+		return nil, nil
+	}
+	var entries []rowenc.IndexEntry
+	for _, idx := range indexes {
+		idxEntries, err := rowenc.EncodeSecondaryIndex(
+			r.codec, r.table, idx, r.colMap, datums, false, /* includeEmpty */
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, idxEntries...)
+	}
+	return entries, nil
+}
+
+// repairRows repairs the index entries of the rows with the given primary
+// index keys, which were written by the import.
+func (r *upsertIndexRepairer) repairRows(
+	ctx context.Context, rowKeys []roachpb.Key, revisions [][]roachpb.Value,
+) error {
+	// Read the versions of the rows which the import replaced.
+	var replaced []kv.Result
+	if err := r.db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		if err := txn.SetFixedTimestamp(ctx, r.walltime); err != nil {
+			return err
+		}
+		b := txn.NewBatch()
+		for _, key := range rowKeys {
+			b.Get(key)
+		}
+		if err := txn.Run(ctx, b); err != nil {
+			return err
+		}
+		replaced = b.Results
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	b := &kv.Batch{}
+	for i, key := range rowKeys {
+		versions := revisions[i]
+		if v := replaced[i].Rows[0].Value; v != nil {
+			versions = append(versions, *v)
+		}
+		latest, err := r.decodeRow(ctx, key, versions[0])
+		if err != nil {
+			return err
+		}
+		entries, err := r.indexEntries(latest, r.indexes)
+		if err != nil {
+			return err
+		}
+		current := make(map[string]struct{}, len(entries))
+		for j := range entries {
+			current[string(entries[j].Key)] = struct{}{}
+			b.Put(entries[j].Key, &entries[j].Value)
+		}
+		for _, v := range versions[1:] {
+			old, err := r.decodeRow(ctx, key, v)
+			if err != nil {
+				return err
+			}
+			oldEntries, err := r.indexEntries(old, r.indexes)
+			if err != nil {
+				return err
+			}
+			for j := range oldEntries {
+				if _, ok := current[string(oldEntries[j].Key)]; !ok {
+					b.Del(oldEntries[j].Key)
+				}
+			}
+		}
+		if b, err = r.maybeRunBatch(ctx, b); err != nil {
+			return err
+		}
+	}
+	return r.db.Run(ctx, b)
+}
+
+// repairIndexEntries repairs the given entries of a secondary index, which
+// were written by the import or by a previous repair.
+func (r *upsertIndexRepairer) repairIndexEntries(
+	ctx context.Context, idx catalog.Index, indexKeys []roachpb.Key, revisions [][]roachpb.Value,
+) error {
+	// Find the primary index key of the row of each entry. Entries which are
+	// only deleted above walltime were deleted by a previous repair, and are
+	// left alone.
+	primary := r.table.GetPrimaryIndex()
+	prefix := rowenc.MakeIndexKeyPrefix(r.codec, r.table.GetID(), primary.GetID())
+	rf := r.pkFetchers[idx.GetID()]
+	entryKeys := make([]roachpb.Key, 0, len(indexKeys))
+	rowKeys := make([]roachpb.Key, 0, len(indexKeys))
+	for i, key := range indexKeys {
+		var value roachpb.Value
+		for _, v := range revisions[i] {
+			if v.IsPresent() {
+				value = v
+				break
+			}
+		}
+		if !value.IsPresent() {
+			continue
+		}
+		kvs := &row.SpanKVFetcher{KVs: []roachpb.KeyValue{{Key: key, Value: value}}}
+		if err := rf.StartScanFrom(ctx, kvs, false /* traceKV */); err != nil {
+			return err
+		}
+		pk, err := rf.NextRowDecoded(ctx)
+		if err != nil {
+			return err
+		}
+		rowKey, _, err := rowenc.EncodeIndexKey(r.table, primary, r.pkColMap, pk, prefix)
+		if err != nil {
+			return err
+		}
+		entryKeys = append(entryKeys, key)
+		rowKeys = append(rowKeys, keys.MakeFamilyKey(rowKey, 0))
+	}
+	if len(entryKeys) == 0 {
+		return nil
+	}
+
+	rows := &kv.Batch{}
+	for _, key := range rowKeys {
+		rows.Get(key)
+	}
+	if err := r.db.Run(ctx, rows); err != nil {
+		return err
+	}
+
+	b := &kv.Batch{}
+	indexes := []catalog.Index{idx}
+	for i, key := range entryKeys {
+		var latest tree.Datums
+		if v := rows.Results[i].Rows[0].Value; v != nil {
+			var err error
+			if latest, err = r.decodeRow(ctx, rowKeys[i], *v); err != nil {
+				return err
+			}
+		}
+		entries, err := r.indexEntries(latest, indexes)
+		if err != nil {
+			return err
+		}
+		found := false
+		for j := range entries {
+			if entries[j].Key.Equal(key) {
+				b.Put(key, &entries[j].Value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			b.Del(key)
+		}
+		if b, err = r.maybeRunBatch(ctx, b); err != nil {
+			return err
+		}
+	}
+	return r.db.Run(ctx, b)
+}
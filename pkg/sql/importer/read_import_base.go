@@ -385,6 +385,7 @@ type inputConverter interface {
 func formatHasNamedColumns(format roachpb.IOFileFormat_FileFormat) bool {
 	switch format {
 	case roachpb.IOFileFormat_Avro,
+		roachpb.IOFileFormat_Parquet,
 		roachpb.IOFileFormat_Mysqldump,
 		roachpb.IOFileFormat_PgDump:
 		return true
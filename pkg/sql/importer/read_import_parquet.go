@@ -0,0 +1,840 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/geo"
+	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/errors"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+)
+
+// parquetSplitSize is the uncompressed size of the row groups of a parquet
+// file above which IMPORT splits the file into several inputs, each of which
+// reads a contiguous range of its row groups, so that the file is imported by
+// several nodes in parallel.
+var parquetSplitSize = settings.RegisterByteSizeSetting(
+	settings.TenantWritable,
+	"bulkio.import.parquet_split_size",
+	"target uncompressed size of the row groups of a parquet file that a single IMPORT processor reads",
+	128<<20,
+	settings.PositiveInt,
+)
+
+// julianDayOfUnixEpoch is the Julian day of 1970-01-01, which the legacy INT96
+// timestamps of parquet count days from.
+const julianDayOfUnixEpoch = 2440588
+
+// parquetKind is the logical type of a parquet column, as given by either its
+// logical type annotation or, in files written by older writers, its converted
+// type. It determines how the physical values of the column are interpreted.
+type parquetKind int
+
+const (
+	parquetPlain parquetKind = iota
+	// parquetText is STRING, ENUM and JSON, which are parsed as the type of the
+	// column they are imported into.
+	parquetText
+	parquetDate
+	parquetTime
+	parquetTimestamp
+	parquetDecimal
+	parquetUnsigned
+	parquetUUID
+	parquetInterval
+	parquetList
+)
+
+// parquetColumn describes a column of a parquet file that is imported into a
+// column of the table.
+type parquetColumn struct {
+	name     string
+	physical parquet.Type
+	kind     parquetKind
+	// unit is the unit of TIME and TIMESTAMP values.
+	unit time.Duration
+	// utc is set for TIMESTAMP values that are instants in UTC, as opposed to
+	// local date-times.
+	utc bool
+	// scale is the scale of DECIMAL values.
+	scale int32
+	// listName is the name of the repeated group of a LIST, which holds its
+	// elements, or empty if the values of the column are the elements.
+	listName string
+	// elem describes the elements of a LIST.
+	elem *parquetColumn
+}
+
+// makeParquetColumn returns the description of a column of a parquet file.
+func makeParquetColumn(def *parquetschema.ColumnDefinition) (*parquetColumn, error) {
+	col, err := describeParquetField(def)
+	if err != nil {
+		return nil, err
+	}
+	if col.kind != parquetList &&
+		def.SchemaElement.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+		// A repeated column that is not a LIST is a list of its values.
+		elem := *col
+		col.kind = parquetList
+		col.elem = &elem
+	}
+	return col, nil
+}
+
+// describeParquetField returns the description of a field of a parquet file,
+// regardless of whether it is repeated.
+func describeParquetField(def *parquetschema.ColumnDefinition) (*parquetColumn, error) {
+	el := def.SchemaElement
+	col := &parquetColumn{name: el.Name, physical: el.GetType()}
+
+	if lt := el.GetLogicalType(); lt != nil {
+		switch {
+		case lt.IsSetSTRING(), lt.IsSetENUM(), lt.IsSetJSON():
+			col.kind = parquetText
+		case lt.IsSetDATE():
+			col.kind = parquetDate
+		case lt.IsSetTIME():
+			col.kind = parquetTime
+			col.unit = parquetTimeUnit(lt.TIME.GetUnit())
+		case lt.IsSetTIMESTAMP():
+			col.kind = parquetTimestamp
+			col.unit = parquetTimeUnit(lt.TIMESTAMP.GetUnit())
+			col.utc = lt.TIMESTAMP.GetIsAdjustedToUTC()
+		case lt.IsSetDECIMAL():
+			col.kind = parquetDecimal
+			col.scale = lt.DECIMAL.GetScale()
+		case lt.IsSetINTEGER():
+			if !lt.INTEGER.GetIsSigned() {
+				col.kind = parquetUnsigned
+			}
+		case lt.IsSetUUID():
+			col.kind = parquetUUID
+		case lt.IsSetLIST():
+			col.kind = parquetList
+		}
+	} else if el.IsSetConvertedType() {
+		switch el.GetConvertedType() {
+		case parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM, parquet.ConvertedType_JSON:
+			col.kind = parquetText
+		case parquet.ConvertedType_DATE:
+			col.kind = parquetDate
+		case parquet.ConvertedType_TIME_MILLIS:
+			col.kind, col.unit = parquetTime, time.Millisecond
+		case parquet.ConvertedType_TIME_MICROS:
+			col.kind, col.unit = parquetTime, time.Microsecond
+		case parquet.ConvertedType_TIMESTAMP_MILLIS:
+			col.kind, col.unit, col.utc = parquetTimestamp, time.Millisecond, true
+		case parquet.ConvertedType_TIMESTAMP_MICROS:
+			col.kind, col.unit, col.utc = parquetTimestamp, time.Microsecond, true
+		case parquet.ConvertedType_DECIMAL:
+			col.kind = parquetDecimal
+			col.scale = el.GetScale()
+		case parquet.ConvertedType_UINT_8, parquet.ConvertedType_UINT_16,
+			parquet.ConvertedType_UINT_32, parquet.ConvertedType_UINT_64:
+			col.kind = parquetUnsigned
+		case parquet.ConvertedType_INTERVAL:
+			col.kind = parquetInterval
+		case parquet.ConvertedType_LIST:
+			col.kind = parquetList
+		}
+	}
+
+	switch {
+	case col.kind == parquetList:
+		// A list is a group that holds a repeated group of its elements, see
+		// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#lists.
+		// Older writers may instead repeat the element itself.
+		if len(def.Children) != 1 {
+			return nil, errors.Newf("malformed parquet list %s", col.name)
+		}
+		repeated := def.Children[0]
+		col.listName = repeated.SchemaElement.Name
+		elemDef := repeated
+		if len(repeated.Children) == 1 {
+			elemDef = repeated.Children[0]
+		} else if len(repeated.Children) > 1 {
+			return nil, errors.Newf("cannot import parquet list %s of groups", col.name)
+		}
+		elem, err := describeParquetField(elemDef)
+		if err != nil {
+			return nil, err
+		}
+		if elem.kind == parquetList {
+			// CockroachDB does not support arrays of arrays.
+			return nil, errors.Newf("cannot import nested parquet list %s", col.name)
+		}
+		col.elem = elem
+	case len(def.Children) > 0:
+		return nil, errors.Newf("cannot import parquet group %s", col.name)
+	}
+	return col, nil
+}
+
+func parquetTimeUnit(unit *parquet.TimeUnit) time.Duration {
+	switch {
+	case unit == nil:
+		return time.Microsecond
+	case unit.IsSetMILLIS():
+		return time.Millisecond
+	case unit.IsSetNANOS():
+		return time.Nanosecond
+	default:
+		return time.Microsecond
+	}
+}
+
+// toDatum converts a value of the column, as returned by the parquet reader,
+// to a datum of the target type.
+func (c *parquetColumn) toDatum(
+	x interface{}, targetT *types.T, evalCtx *tree.EvalContext,
+) (tree.Datum, error) {
+	if x == nil {
+		// Let the target table schema verify whether nulls are allowed.
+		return tree.DNull, nil
+	}
+	if c.kind == parquetList {
+		return c.listToDatum(x, targetT, evalCtx)
+	}
+
+	var d tree.Datum
+	var err error
+	switch v := x.(type) {
+	case bool:
+		d = tree.MakeDBool(tree.DBool(v))
+	case int32:
+		if c.kind == parquetUnsigned {
+			d, err = c.intToDatum(int64(uint32(v)))
+		} else {
+			d, err = c.intToDatum(int64(v))
+		}
+	case int64:
+		if c.kind == parquetUnsigned && v < 0 {
+			return nil, errors.Newf("unsigned value %d of %s is out of range", uint64(v), c.name)
+		}
+		d, err = c.intToDatum(v)
+	case [12]byte:
+		d, err = int96ToDatum(v)
+	case float32:
+		d = tree.NewDFloat(tree.DFloat(v))
+	case float64:
+		d = tree.NewDFloat(tree.DFloat(v))
+	case []byte:
+		d, err = c.bytesToDatum(v, targetT, evalCtx)
+	default:
+		return nil, errors.Newf("cannot convert %T value of %s to %s", x, c.name, targetT)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if d == tree.DNull || targetT.Equivalent(d.ResolvedType()) {
+		return d, nil
+	}
+	// The physical types of parquet are narrower than the types of the columns
+	// they may be imported into, e.g. an INT64 into a DECIMAL column or a
+	// TIMESTAMP into a TIMESTAMPTZ column, so cast the value as SQL would.
+	d, err = tree.PerformCast(evalCtx, d, targetT)
+	if err != nil {
+		return nil, errors.Wrapf(err, "converting %s", c.name)
+	}
+	return d, nil
+}
+
+func (c *parquetColumn) intToDatum(v int64) (tree.Datum, error) {
+	switch c.kind {
+	case parquetDate:
+		date, err := pgdate.MakeDateFromUnixEpoch(v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDDate(date), nil
+	case parquetTime:
+		return tree.MakeDTime(timeofday.TimeOfDay(v * int64(c.unit) / int64(time.Microsecond))), nil
+	case parquetTimestamp:
+		perSecond := int64(time.Second / c.unit)
+		t := timeutil.Unix(v/perSecond, (v%perSecond)*int64(c.unit))
+		if c.utc {
+			return tree.MakeDTimestampTZ(t, time.Microsecond)
+		}
+		return tree.MakeDTimestamp(t, time.Microsecond)
+	case parquetDecimal:
+		return &tree.DDecimal{Decimal: *apd.New(v, -c.scale)}, nil
+	default:
+		return tree.NewDInt(tree.DInt(v)), nil
+	}
+}
+
+// int96ToDatum converts a legacy INT96 timestamp, which holds the nanoseconds
+// of the day followed by the Julian day, both little-endian, to a TIMESTAMPTZ.
+func int96ToDatum(v [12]byte) (tree.Datum, error) {
+	nanos := int64(binary.LittleEndian.Uint64(v[:8]))
+	days := int64(binary.LittleEndian.Uint32(v[8:])) - julianDayOfUnixEpoch
+	return tree.MakeDTimestampTZ(timeutil.Unix(days*24*60*60, nanos), time.Microsecond)
+}
+
+func (c *parquetColumn) bytesToDatum(
+	v []byte, targetT *types.T, evalCtx *tree.EvalContext,
+) (tree.Datum, error) {
+	switch c.kind {
+	case parquetText:
+		return rowenc.ParseDatumStringAs(targetT, string(v), evalCtx)
+	case parquetDecimal:
+		if c.physical == parquet.Type_BYTE_ARRAY {
+			// EXPORT writes decimals as their text, rather than as the unscaled
+			// value, since they have no fixed scale.
+			return tree.ParseDDecimal(string(v))
+		}
+		// The unscaled value is a big-endian two's complement integer.
+		unscaled := new(big.Int).SetBytes(v)
+		if len(v) > 0 && v[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(v))*8))
+		}
+		var coeff apd.BigInt
+		coeff.SetMathBigInt(unscaled)
+		return &tree.DDecimal{Decimal: *apd.NewWithBigInt(&coeff, -c.scale)}, nil
+	case parquetUUID:
+		return tree.ParseDUuidFromBytes(v)
+	case parquetInterval:
+		// An interval is the months, days and milliseconds, each a little-endian
+		// unsigned 32-bit integer.
+		if len(v) != 12 {
+			return nil, errors.Newf("malformed interval of %d bytes in %s", len(v), c.name)
+		}
+		months := int64(binary.LittleEndian.Uint32(v[:4]))
+		days := int64(binary.LittleEndian.Uint32(v[4:8]))
+		millis := int64(binary.LittleEndian.Uint32(v[8:]))
+		return tree.NewDInterval(
+			duration.MakeDuration(millis*int64(time.Millisecond), days, months),
+			types.DefaultIntervalTypeMetadata,
+		), nil
+	}
+
+	// Raw bytes are imported as is into byte columns and as EWKB into spatial
+	// columns, which is how EXPORT writes them. Otherwise, they are parsed as
+	// the target type.
+	switch targetT.Family() {
+	case types.BytesFamily:
+		return tree.NewDBytes(tree.DBytes(v)), nil
+	case types.GeographyFamily:
+		g, err := geo.ParseGeographyFromEWKB(geopb.EWKB(v))
+		if err != nil {
+			return nil, err
+		}
+		return &tree.DGeography{Geography: g}, nil
+	case types.GeometryFamily:
+		g, err := geo.ParseGeometryFromEWKB(geopb.EWKB(v))
+		if err != nil {
+			return nil, err
+		}
+		return &tree.DGeometry{Geometry: g}, nil
+	default:
+		return rowenc.ParseDatumStringAs(targetT, string(v), evalCtx)
+	}
+}
+
+// listToDatum converts a LIST to an array. The parquet reader returns a list
+// as a map from the name of its repeated group to a slice of maps, each of
+// which maps the name of the element to its value, or, for repeated fields, as
+// a slice of the values.
+func (c *parquetColumn) listToDatum(
+	x interface{}, targetT *types.T, evalCtx *tree.EvalContext,
+) (tree.Datum, error) {
+	if targetT.Family() != types.ArrayFamily {
+		return nil, errors.Newf("cannot convert parquet list %s to %s", c.name, targetT)
+	}
+	contents := targetT.ArrayContents()
+	arr := tree.NewDArray(contents)
+	arr.Array = tree.Datums{}
+	appendElem := func(v interface{}) error {
+		d, err := c.elem.toDatum(v, contents, evalCtx)
+		if err != nil {
+			return err
+		}
+		return arr.Append(d)
+	}
+
+	values := x
+	if c.listName != "" {
+		m, ok := x.(map[string]interface{})
+		if !ok {
+			return nil, errors.Newf("cannot convert %T value of list %s", x, c.name)
+		}
+		values = m[c.listName]
+	}
+	switch v := values.(type) {
+	case nil:
+	case []map[string]interface{}:
+		// The reader returns an empty list as a single map without the element.
+		if len(v) == 1 {
+			if _, ok := v[0][c.elem.name]; !ok {
+				break
+			}
+		}
+		for _, m := range v {
+			if err := appendElem(m[c.elem.name]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		rv := reflect.ValueOf(values)
+		if rv.Kind() != reflect.Slice {
+			return nil, errors.Newf("cannot convert %T value of list %s", values, c.name)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if err := appendElem(rv.Index(i).Interface()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return arr, nil
+}
+
+// parquetConsumer implements importRowConsumer interface.
+type parquetConsumer struct {
+	// columns maps the name of each parquet column that is imported into the
+	// table to its description.
+	columns map[string]*parquetColumn
+	// columnIdx maps the name of each parquet column that is imported into the
+	// table to the index of its visible column.
+	columnIdx map[string]int
+}
+
+var _ importRowConsumer = &parquetConsumer{}
+
+// FillDatums implements importRowStream interface.
+func (p *parquetConsumer) FillDatums(
+	native interface{}, rowIndex int64, conv *row.DatumRowConverter,
+) error {
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		return errors.Newf("unexpected native type; expected map[string]interface{} found %T instead", native)
+	}
+	// The parquet reader omits the columns whose value is null from the row, so
+	// every column that is imported into starts out null.
+	for i := range conv.Datums {
+		if conv.TargetColOrds.Contains(i) {
+			conv.Datums[i] = tree.DNull
+		}
+	}
+	for name, v := range record {
+		col, ok := p.columns[name]
+		if !ok {
+			continue
+		}
+		idx := p.columnIdx[name]
+		datum, err := col.toDatum(v, conv.VisibleColTypes[idx], conv.EvalCtx)
+		if err != nil {
+			return err
+		}
+		conv.Datums[idx] = datum
+	}
+	return nil
+}
+
+// parquetRowStream implements importRowProducer interface. It reads the rows
+// of a contiguous range of the row groups of a parquet file.
+type parquetRowStream struct {
+	reader *goparquet.FileReader
+	// numRows is the number of rows in the row groups that the stream reads.
+	numRows int64
+	read    int64
+	row     map[string]interface{}
+	err     error
+}
+
+var _ importRowProducer = &parquetRowStream{}
+
+// Progress implements importRowProducer interface.
+func (s *parquetRowStream) Progress() float32 {
+	if s.numRows == 0 {
+		return 1
+	}
+	return float32(s.read) / float32(s.numRows)
+}
+
+// Scan implements importRowProducer interface.
+func (s *parquetRowStream) Scan() bool {
+	if s.err != nil || s.read >= s.numRows {
+		return false
+	}
+	s.row, s.err = s.reader.NextRow()
+	if s.err == io.EOF {
+		s.err = errors.Newf("parquet file ended after %d of %d rows", s.read, s.numRows)
+	}
+	if s.err != nil {
+		return false
+	}
+	s.read++
+	return true
+}
+
+// Err implements importRowProducer interface.
+func (s *parquetRowStream) Err() error {
+	return s.err
+}
+
+// Skip implements importRowProducer interface.
+func (s *parquetRowStream) Skip() error {
+	s.row = nil
+	return nil
+}
+
+// Row implements importRowProducer interface.
+func (s *parquetRowStream) Row() (interface{}, error) {
+	res := s.row
+	s.row = nil
+	return res, nil
+}
+
+func newImportParquetPipeline(
+	p *parquetInputReader, input io.ReadSeeker, rowGroups *roachpb.ParquetRowGroups,
+) (importRowProducer, importRowConsumer, error) {
+	// The reader does not expose the row groups of the file, so the footer is
+	// read on its own for their number of rows.
+	meta, err := goparquet.ReadFileMetaData(input, true /* extraValidation */)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := goparquet.NewFileReader(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Map the names of the columns that are imported into to their indexes
+	// among the visible columns.
+	isTarget := make(map[string]bool, len(p.importContext.targetCols))
+	for _, name := range p.importContext.targetCols {
+		isTarget[string(name)] = true
+	}
+	fieldIdxByName := make(map[string]int)
+	for idx, col := range p.importContext.tableDesc.VisibleColumns() {
+		if len(isTarget) == 0 || isTarget[col.GetName()] {
+			fieldIdxByName[col.GetName()] = idx
+		}
+	}
+
+	consumer := &parquetConsumer{
+		columns:   make(map[string]*parquetColumn),
+		columnIdx: make(map[string]int),
+	}
+	matched := make(map[string]bool)
+	for _, def := range reader.GetSchemaDefinition().RootColumn.Children {
+		name := def.SchemaElement.Name
+		field := lexbase.NormalizeName(name)
+		idx, ok := fieldIdxByName[field]
+		if !ok {
+			if p.opts.StrictMode {
+				return nil, nil, errors.Newf("could not find column for parquet column %s", field)
+			}
+			continue
+		}
+		col, err := makeParquetColumn(def)
+		if err != nil {
+			return nil, nil, err
+		}
+		consumer.columns[name] = col
+		consumer.columnIdx[name] = idx
+		matched[field] = true
+	}
+	if p.opts.StrictMode && len(matched) < len(fieldIdxByName) {
+		for _, col := range p.importContext.tableDesc.VisibleColumns() {
+			if _, ok := fieldIdxByName[col.GetName()]; ok && !matched[col.GetName()] {
+				return nil, nil, errors.Newf("column %s is not in the parquet file", col.GetName())
+			}
+		}
+	}
+
+	start, end := 0, len(meta.RowGroups)
+	if rowGroups != nil {
+		start, end = int(rowGroups.Start), int(rowGroups.End)
+		if end > len(meta.RowGroups) {
+			return nil, nil, errors.Newf(
+				"parquet file has %d row groups, expected at least %d", len(meta.RowGroups), end)
+		}
+	}
+	producer := &parquetRowStream{reader: reader}
+	for _, rg := range meta.RowGroups[start:end] {
+		producer.numRows += rg.NumRows
+	}
+	if start < end {
+		if err := reader.SeekToRowGroup(start); err != nil {
+			return nil, nil, err
+		}
+	}
+	return producer, consumer, nil
+}
+
+type parquetInputReader struct {
+	importContext *parallelImportContext
+	opts          roachpb.ParquetOptions
+}
+
+var _ inputConverter = &parquetInputReader{}
+
+func newParquetInputReader(
+	semaCtx *tree.SemaContext,
+	kvCh chan row.KVBatch,
+	tableDesc catalog.TableDescriptor,
+	targetCols tree.NameList,
+	parquetOpts roachpb.ParquetOptions,
+	walltime int64,
+	parallelism int,
+	evalCtx *tree.EvalContext,
+) (*parquetInputReader, error) {
+	return &parquetInputReader{
+		importContext: &parallelImportContext{
+			semaCtx:    semaCtx,
+			walltime:   walltime,
+			numWorkers: parallelism,
+			evalCtx:    evalCtx,
+			tableDesc:  tableDesc,
+			targetCols: targetCols,
+			kvCh:       kvCh,
+		},
+		opts: parquetOpts,
+	}, nil
+}
+
+func (p *parquetInputReader) start(group ctxgroup.Group) {}
+
+// readFiles implements the inputConverter interface. Unlike the other formats,
+// which stream their files from the start, a parquet file is read from its
+// footer, and its row groups are read at the offsets the footer gives, so it
+// is read through a reader that can seek rather than with readInputFiles. The
+// compression of the format does not apply, as parquet files compress their
+// pages themselves.
+func (p *parquetInputReader) readFiles(
+	ctx context.Context,
+	dataFiles map[int32]string,
+	resumePos map[int32]int64,
+	format roachpb.IOFileFormat,
+	makeExternalStorage cloud.ExternalStorageFactory,
+	user security.SQLUsername,
+) error {
+	for dataFileIndex, dataFile := range dataFiles {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := func() error {
+			conf, err := cloud.ExternalStorageConfFromURI(dataFile, user)
+			if err != nil {
+				return err
+			}
+			es, err := makeExternalStorage(ctx, conf)
+			if err != nil {
+				return err
+			}
+			defer es.Close()
+			input, err := newExternalFileReadSeeker(ctx, es)
+			if err != nil {
+				return err
+			}
+			defer input.Close()
+			return p.readFile(ctx, input, dataFileIndex, resumePos[dataFileIndex])
+		}(); err != nil {
+			return errors.Wrapf(err, "%s", dataFile)
+		}
+	}
+	return nil
+}
+
+func (p *parquetInputReader) readFile(
+	ctx context.Context, input io.ReadSeeker, inputIdx int32, resumePos int64,
+) error {
+	var rowGroups *roachpb.ParquetRowGroups
+	if rg, ok := p.opts.InputRowGroups[inputIdx]; ok {
+		rowGroups = &rg
+	}
+	producer, consumer, err := newImportParquetPipeline(p, input, rowGroups)
+	if err != nil {
+		return err
+	}
+
+	fileCtx := &importFileContext{
+		source:   inputIdx,
+		skip:     resumePos,
+		rowLimit: p.opts.RowLimit,
+	}
+	return runParallelImport(ctx, p.importContext, fileCtx, producer, consumer)
+}
+
+// externalFileReadSeeker is an io.ReadSeeker over a file in external storage.
+// A read after a seek reopens the file at the new offset, unless the seek left
+// the offset where the open stream already is.
+type externalFileReadSeeker struct {
+	ctx  context.Context
+	es   cloud.ExternalStorage
+	size int64
+	pos  int64
+	// body is the open stream, which is at bodyPos, or nil.
+	body    ioctx.ReadCloserCtx
+	bodyPos int64
+}
+
+var _ io.ReadSeeker = &externalFileReadSeeker{}
+
+func newExternalFileReadSeeker(
+	ctx context.Context, es cloud.ExternalStorage,
+) (*externalFileReadSeeker, error) {
+	body, size, err := es.ReadFileAt(ctx, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		// Not every storage returns the size of the file when it is opened.
+		if size, err = es.Size(ctx, ""); err != nil {
+			body.Close(ctx)
+			return nil, err
+		}
+	}
+	return &externalFileReadSeeker{ctx: ctx, es: es, size: size, body: body}, nil
+}
+
+// Read implements io.Reader.
+func (r *externalFileReadSeeker) Read(p []byte) (int, error) {
+	if r.pos >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil || r.bodyPos != r.pos {
+		if r.body != nil {
+			r.body.Close(r.ctx)
+			r.body = nil
+		}
+		body, _, err := r.es.ReadFileAt(r.ctx, "", r.pos)
+		if err != nil {
+			return 0, err
+		}
+		r.body, r.bodyPos = body, r.pos
+	}
+	n, err := r.body.Read(r.ctx, p)
+	r.pos += int64(n)
+	r.bodyPos = r.pos
+	return n, err
+}
+
+// Seek implements io.Seeker.
+func (r *externalFileReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.Newf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.Newf("cannot seek to negative offset %d", offset)
+	}
+	r.pos = offset
+	return offset, nil
+}
+
+// Close closes the open stream, if any.
+func (r *externalFileReadSeeker) Close() {
+	if r.body != nil {
+		r.body.Close(r.ctx)
+		r.body = nil
+	}
+}
+
+// splitParquetFiles splits each of the parquet files of an IMPORT whose row
+// groups are larger than splitSize into several inputs, each of which reads a
+// contiguous range of its row groups of about splitSize, so that the file is
+// imported by several nodes in parallel. It returns the inputs of the IMPORT,
+// in which a file that is split appears once per range, and records the row
+// groups of each such input in opts.
+func splitParquetFiles(
+	ctx context.Context,
+	files []string,
+	opts *roachpb.ParquetOptions,
+	splitSize int64,
+	makeExternalStorage cloud.ExternalStorageFromURIFactory,
+	user security.SQLUsername,
+) ([]string, error) {
+	inputs := make([]string, 0, len(files))
+	addInput := func(file string, start, end int) {
+		if opts.InputRowGroups == nil {
+			opts.InputRowGroups = make(map[int32]roachpb.ParquetRowGroups)
+		}
+		opts.InputRowGroups[int32(len(inputs))] = roachpb.ParquetRowGroups{
+			Start: int32(start), End: int32(end),
+		}
+		inputs = append(inputs, file)
+	}
+	for _, file := range files {
+		meta, err := readParquetFileMetaData(ctx, file, makeExternalStorage, user)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading footer of %s", file)
+		}
+		start := 0
+		var size int64
+		for i, rg := range meta.RowGroups {
+			size += rg.TotalByteSize
+			if size >= splitSize && i+1 < len(meta.RowGroups) {
+				addInput(file, start, i+1)
+				start, size = i+1, 0
+			}
+		}
+		if start == 0 {
+			// The file is read by a single input, which reads all its row groups.
+			inputs = append(inputs, file)
+			continue
+		}
+		addInput(file, start, len(meta.RowGroups))
+	}
+	return inputs, nil
+}
+
+func readParquetFileMetaData(
+	ctx context.Context,
+	file string,
+	makeExternalStorage cloud.ExternalStorageFromURIFactory,
+	user security.SQLUsername,
+) (*parquet.FileMetaData, error) {
+	es, err := makeExternalStorage(ctx, file, user)
+	if err != nil {
+		return nil, err
+	}
+	defer es.Close()
+	input, err := newExternalFileReadSeeker(ctx, es)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+	return goparquet.ReadFileMetaData(input, true /* extraValidation */)
+}
@@ -0,0 +1,226 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquetschema"
+	"github.com/stretchr/testify/require"
+)
+
+// parquetTestSchema has a column of each logical type that IMPORT maps onto a
+// CockroachDB type, as written by writers other than EXPORT.
+const parquetTestSchema = `message test {
+  required int64 id;
+  optional int32 d (DATE);
+  optional int64 ts (TIMESTAMP(MILLIS, true));
+  optional int64 t (TIME(MICROS, false));
+  optional int32 dec (DECIMAL(9, 2));
+  optional fixed_len_byte_array(16) u (UUID);
+  optional binary s (STRING);
+  optional int32 small (INT(16, true));
+  optional group arr (LIST) {
+    repeated group list {
+      optional int64 element;
+    }
+  }
+}`
+
+const parquetTestCreateTable = `CREATE TABLE test (
+  id INT8, d DATE, ts TIMESTAMPTZ, t TIME, dec DECIMAL(9, 2), u UUID, s STRING,
+  small INT2, arr INT8[]
+)`
+
+// writeParquetTestFile writes a parquet file with the test schema, with
+// rowsPerGroup rows in each of numGroups row groups.
+func writeParquetTestFile(t *testing.T, numGroups, rowsPerGroup int) []byte {
+	schema, err := parquetschema.ParseSchemaDefinition(parquetTestSchema)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	fw := goparquet.NewFileWriter(&buf, goparquet.WithSchemaDefinition(schema))
+	id := 0
+	for g := 0; g < numGroups; g++ {
+		for i := 0; i < rowsPerGroup; i++ {
+			id++
+			rec := map[string]interface{}{
+				"id":    int64(id),
+				"d":     int32(19000 + id),
+				"ts":    int64(1650000000000 + id),
+				"t":     int64(3600000000 + id),
+				"dec":   int32(-12345),
+				"u":     []byte("0123456789abcdef"),
+				"s":     []byte(fmt.Sprintf("row %d", id)),
+				"small": int32(-id),
+				"arr": map[string]interface{}{
+					"list": []map[string]interface{}{
+						{"element": int64(id)}, {"element": int64(2 * id)},
+					},
+				},
+			}
+			if id%2 == 0 {
+				// Null values are omitted from the row.
+				delete(rec, "s")
+			}
+			require.NoError(t, fw.AddData(rec))
+		}
+		require.NoError(t, fw.FlushRowGroup())
+	}
+	require.NoError(t, fw.Close())
+	return buf.Bytes()
+}
+
+type parquetTestHelper struct {
+	table   catalog.TableDescriptor
+	evalCtx tree.EvalContext
+}
+
+func newParquetTestHelper(ctx context.Context, t *testing.T, create string) *parquetTestHelper {
+	st := cluster.MakeTestingClusterSettings()
+	return &parquetTestHelper{
+		table: descForTable(ctx, t, create, 100, 150, 200, NoFKs).
+			ImmutableCopy().(catalog.TableDescriptor),
+		evalCtx: tree.MakeTestingEvalContext(st),
+	}
+}
+
+func (th *parquetTestHelper) newRowStream(
+	t *testing.T, data []byte, opts roachpb.ParquetOptions, rowGroups *roachpb.ParquetRowGroups,
+) (*testRecordStream, error) {
+	// Ensure datum converter doesn't flush (since
+	// we're using nil kv channel for this test).
+	defer row.TestingSetDatumRowConverterBatchSize(1 << 10)()
+
+	semaCtx := tree.MakeSemaContext()
+	p, err := newParquetInputReader(&semaCtx, nil, th.table, nil, opts, 0, 1, &th.evalCtx)
+	require.NoError(t, err)
+	producer, consumer, err := newImportParquetPipeline(p, bytes.NewReader(data), rowGroups)
+	if err != nil {
+		return nil, err
+	}
+	conv, err := row.NewDatumRowConverter(
+		context.Background(), &semaCtx, th.table, nil, th.evalCtx.Copy(), nil,
+		nil /* seqChunkProvider */, nil, /* metrics */
+	)
+	require.NoError(t, err)
+	return &testRecordStream{producer: producer, consumer: consumer, conv: conv}, nil
+}
+
+func TestParquetLogicalTypes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	th := newParquetTestHelper(ctx, t, parquetTestCreateTable)
+
+	stream, err := th.newRowStream(t, writeParquetTestFile(t, 1, 2), roachpb.ParquetOptions{}, nil)
+	require.NoError(t, err)
+
+	var rows [][]string
+	for stream.producer.Scan() {
+		require.NoError(t, stream.Row())
+		var r []string
+		for _, d := range stream.conv.Datums[:len(th.table.VisibleColumns())] {
+			r = append(r, tree.AsStringWithFlags(d, tree.FmtBareStrings))
+		}
+		rows = append(rows, r)
+	}
+	require.NoError(t, stream.producer.Err())
+	require.Equal(t, [][]string{
+		{"1", "2022-01-09", "2022-04-15 05:20:00.001+00:00", "01:00:00.000001", "-123.45",
+			"30313233-3435-3637-3839-616263646566", "row 1", "-1", "{1,2}"},
+		{"2", "2022-01-10", "2022-04-15 05:20:00.002+00:00", "01:00:00.000002", "-123.45",
+			"30313233-3435-3637-3839-616263646566", "NULL", "-2", "{2,4}"},
+	}, rows)
+}
+
+func TestParquetReadsRowGroups(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	th := newParquetTestHelper(ctx, t, parquetTestCreateTable)
+	data := writeParquetTestFile(t, 4, 3)
+
+	for _, tc := range []struct {
+		rowGroups *roachpb.ParquetRowGroups
+		ids       []int64
+	}{
+		{nil, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+		{&roachpb.ParquetRowGroups{Start: 0, End: 1}, []int64{1, 2, 3}},
+		{&roachpb.ParquetRowGroups{Start: 1, End: 3}, []int64{4, 5, 6, 7, 8, 9}},
+		{&roachpb.ParquetRowGroups{Start: 3, End: 4}, []int64{10, 11, 12}},
+	} {
+		t.Run(fmt.Sprintf("%v", tc.rowGroups), func(t *testing.T) {
+			stream, err := th.newRowStream(t, data, roachpb.ParquetOptions{}, tc.rowGroups)
+			require.NoError(t, err)
+			var ids []int64
+			for stream.producer.Scan() {
+				require.NoError(t, stream.Row())
+				ids = append(ids, int64(tree.MustBeDInt(stream.conv.Datums[0])))
+			}
+			require.NoError(t, stream.producer.Err())
+			require.Equal(t, tc.ids, ids)
+			require.Equal(t, float32(1), stream.producer.Progress())
+		})
+	}
+
+	_, err := th.newRowStream(t, data, roachpb.ParquetOptions{}, &roachpb.ParquetRowGroups{Start: 3, End: 5})
+	require.Regexp(t, "parquet file has 4 row groups, expected at least 5", err)
+}
+
+func TestParquetRelaxedAndStrictImport(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+	data := writeParquetTestFile(t, 1, 1)
+
+	for _, tc := range []struct {
+		name   string
+		create string
+		strict bool
+		err    string
+	}{
+		{"relaxed-tolerates-extra-fields", "CREATE TABLE test (id INT8, s STRING)", false, ""},
+		{"relaxed-tolerates-missing-fields", "CREATE TABLE test (id INT8, s STRING, x INT8)", false, ""},
+		{"strict-matching-fields", parquetTestCreateTable, true, ""},
+		{"strict-returns-error-extra-fields", "CREATE TABLE test (id INT8, s STRING)", true,
+			"could not find column for parquet column d"},
+		{"strict-returns-error-missing-fields", "CREATE TABLE test (" +
+			"id INT8, d DATE, ts TIMESTAMPTZ, t TIME, dec DECIMAL(9, 2), u UUID, s STRING, " +
+			"small INT2, arr INT8[], x INT8)", true, "column x is not in the parquet file"},
+		{"list-into-scalar", "CREATE TABLE test (id INT8, arr INT8)", false,
+			"cannot convert parquet list arr to int"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			th := newParquetTestHelper(ctx, t, tc.create)
+			stream, err := th.newRowStream(t, data, roachpb.ParquetOptions{StrictMode: tc.strict}, nil)
+			if err == nil {
+				require.True(t, stream.producer.Scan())
+				err = stream.Row()
+			}
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Regexp(t, tc.err, err)
+			}
+		})
+	}
+}
@@ -321,6 +321,7 @@ func init() {
 		&tree.Restore{},
 		&tree.CreateChangefeed{},
 		&tree.Import{},
+		&tree.ShowImportSchema{},
 		&tree.ScheduledBackup{},
 		&tree.StreamIngestion{},
 		&tree.ReplicationStream{},
@@ -46,6 +46,11 @@ type Table interface {
 	// that they cannot be mutated.
 	IsMaterializedView() bool
 
+	// ReadOnlyReason returns a user-visible reason for why the table cannot be
+	// mutated right now, or the empty string if it can. Tables are read-only
+	// while an IMPORT INTO in upsert mode is ingesting into them.
+	ReadOnlyReason() string
+
 	// ColumnCount returns the number of columns in the table. This includes
 	// public columns, write-only columns, etc.
 	ColumnCount() int
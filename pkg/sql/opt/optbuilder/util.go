@@ -559,6 +559,11 @@ func (b *Builder) resolveTableForMutation(
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
+	// We can't mutate tables that are read-only, e.g. while being imported into.
+	if reason := tab.ReadOnlyReason(); reason != "" {
+		panic(sqlerrors.NewTableReadOnlyError(string(tab.Name()), reason))
+	}
+
 	return tab, depName, alias, columns
 }
 
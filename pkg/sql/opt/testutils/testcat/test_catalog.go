@@ -659,6 +659,11 @@ func (tt *Table) IsMaterializedView() bool {
 	return false
 }
 
+// ReadOnlyReason is part of the cat.Table interface.
+func (tt *Table) ReadOnlyReason() string {
+	return ""
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (tt *Table) ColumnCount() int {
 	return len(tt.Columns)
@@ -667,7 +667,7 @@ func newOptTable(
 	cols := ot.desc.DeletableColumns()
 	numCols := len(ot.desc.AllColumns())
 	// Add one for each inverted index column.
-	secondaryIndexes := ot.desc.DeletableNonPrimaryIndexes()
+	secondaryIndexes := ot.secondaryIndexes()
 	for _, index := range secondaryIndexes {
 		if index.GetType() == descpb.IndexDescriptor_INVERTED {
 			numCols++
@@ -1053,6 +1053,11 @@ func (ot *optTable) IsMaterializedView() bool {
 	return ot.desc.MaterializedView()
 }
 
+// ReadOnlyReason is part of the cat.Table interface.
+func (ot *optTable) ReadOnlyReason() string {
+	return ot.desc.GetReadOnlyReason()
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optTable) ColumnCount() int {
 	return len(ot.columns)
@@ -1071,14 +1076,32 @@ func (ot *optTable) getCol(i int) catalog.Column {
 	return nil
 }
 
+// secondaryIndexes returns the deletable secondary indexes of the table, or
+// none if the table is read-only. The secondary indexes of a read-only table
+// may not match its primary index: an IMPORT INTO with upsert only repairs them
+// after ingesting its rows, so reads must not be planned on them until then.
+// Writes, which would need them, are rejected while the table is read-only.
+func (ot *optTable) secondaryIndexes() []catalog.Index {
+	if ot.desc.GetReadOnlyReason() != "" {
+		return nil
+	}
+	return ot.desc.DeletableNonPrimaryIndexes()
+}
+
 // IndexCount is part of the cat.Table interface.
 func (ot *optTable) IndexCount() int {
+	if ot.desc.GetReadOnlyReason() != "" {
+		return 1
+	}
 	// Primary index is always present, so count is always >= 1.
 	return len(ot.desc.ActiveIndexes())
 }
 
 // WritableIndexCount is part of the cat.Table interface.
 func (ot *optTable) WritableIndexCount() int {
+	if ot.desc.GetReadOnlyReason() != "" {
+		return 1
+	}
 	// Primary index is always present, so count is always >= 1.
 	return 1 + len(ot.desc.WritableNonPrimaryIndexes())
 }
@@ -1086,7 +1109,7 @@ func (ot *optTable) WritableIndexCount() int {
 // DeletableIndexCount is part of the cat.Table interface.
 func (ot *optTable) DeletableIndexCount() int {
 	// Primary index is always present, so count is always >= 1.
-	return len(ot.desc.DeletableNonPrimaryIndexes()) + 1
+	return len(ot.secondaryIndexes()) + 1
 }
 
 // Index is part of the cat.Table interface.
@@ -1962,6 +1985,11 @@ func (ot *optVirtualTable) IsMaterializedView() bool {
 	return false
 }
 
+// ReadOnlyReason is part of the cat.Table interface.
+func (ot *optVirtualTable) ReadOnlyReason() string {
+	return ""
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optVirtualTable) ColumnCount() int {
 	return len(ot.columns)
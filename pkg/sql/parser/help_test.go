@@ -41,6 +41,7 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER CHANGEFEED 123 DROP ??`, `ALTER CHANGEFEED`},
 
 		{`ALTER BACKUP foo ADD NEW_KMS=bar WITH OLD_KMS=foobar ??`, `ALTER BACKUP`},
+		{`ALTER BACKUP foo SET ??`, `ALTER BACKUP`},
 
 		{`ALTER TABLE IF ??`, `ALTER TABLE`},
 		{`ALTER TABLE blah ??`, `ALTER TABLE`},
@@ -311,6 +312,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`SHOW HISTOGRAM ??`, `SHOW HISTOGRAM`},
 
+		{`SHOW IMPORT ??`, `SHOW IMPORT SCHEMA`},
+		{`SHOW IMPORT SCHEMA FROM CSV 'foo' ??`, `SHOW IMPORT SCHEMA`},
+
 		{`SHOW QUERIES ??`, `SHOW STATEMENTS`},
 		{`SHOW LOCAL QUERIES ??`, `SHOW STATEMENTS`},
 
@@ -801,7 +801,7 @@ func (u *sqlSymUnion) fetchCursor() *tree.FetchCursor {
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
-%token <str> CONFLICT CONNECTION CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
+%token <str> CONFLICT CONNECTION CONSTRAINT CONSTRAINTS CONTAINS CONTINUOUS CONTROLCHANGEFEED CONTROLJOB
 %token <str> CONVERSION CONVERT COPY COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
 %token <str> CROSS CSV CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
@@ -845,12 +845,12 @@ func (u *sqlSymUnion) fetchCursor() *tree.FetchCursor {
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
 %token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MONTH
+%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MIRROR MODIFYCLUSTERSETTING MONTH
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEW_SCHEMA NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMODIFYCLUSTERSETTING
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT NOTHING NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
@@ -889,7 +889,7 @@ func (u *sqlSymUnion) fetchCursor() *tree.FetchCursor {
 %token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLOGGED UNSPLIT
 %token <str> UPDATE UPSERT UNSET UNTIL USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VERIFY_ONLY VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED
 %token <str> VIEWCLUSTERSETTING VIRTUAL VISIBLE VOTERS
 
 %token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
@@ -1108,6 +1108,7 @@ func (u *sqlSymUnion) fetchCursor() *tree.FetchCursor {
 %type <tree.Statement> show_fingerprints_stmt
 %type <tree.Statement> show_grants_stmt
 %type <tree.Statement> show_histogram_stmt
+%type <tree.Statement> show_import_schema_stmt
 %type <tree.Statement> show_indexes_stmt
 %type <tree.Statement> show_partitions_stmt
 %type <tree.Statement> show_jobs_stmt
@@ -2762,6 +2763,9 @@ opt_clear_data:
 //    kms="[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : encrypt backups using KMS
 //    detached: execute backup job asynchronously, without waiting for its completion
 //    incremental_location: specify a different path to store the incremental backup
+//    compact: merge the backup chain in <subdir> into a new full backup
+//    mirror: also write a copy of the backup to each of the given destinations
+//    continuous: keep a log of every revision after the backup, to restore to any time since
 //
 // %SeeAlso: RESTORE, WEBDOCS/backup.html
 backup_stmt:
@@ -2871,6 +2875,18 @@ backup_options:
   {
   $$.val = &tree.BackupOptions{IncrementalStorage: $3.stringOrPlaceholderOptList()}
   }
+| COMPACT
+  {
+    $$.val = &tree.BackupOptions{Compact: true}
+  }
+| MIRROR '=' string_or_placeholder_opt_list
+  {
+    $$.val = &tree.BackupOptions{Mirror: $3.stringOrPlaceholderOptList()}
+  }
+| CONTINUOUS
+  {
+    $$.val = &tree.BackupOptions{Continuous: true}
+  }
 
 
 // %Help: CREATE SCHEDULE FOR BACKUP - backup data periodically
@@ -2944,6 +2960,9 @@ backup_options:
 //     If backups were already created in the destination in which a new schedule references,
 //     this flag must be passed in to acknowledge that the new schedule may be backing up different
 //     objects.
+//   * compact_after=INT:
+//     once the current backup has this many incremental backups, the incremental schedule also
+//     compacts it into a new full backup, which becomes the "current" backup when complete.
 //
 // %SeeAlso: BACKUP
 create_schedule_for_backup_stmt:
@@ -3035,6 +3054,12 @@ opt_with_schedule_options:
 // %Text:
 // RESTORE <targets...> FROM <location...>
 //         [ AS OF SYSTEM TIME <expr> ]
+//         [ WHERE <primary key predicate> ]
+//         [ WITH <option> [= <value>] [, ...] ]
+// or
+// RESTORE TABLE <table> AS <new table name> FROM <location...>
+//         [ AS OF SYSTEM TIME <expr> ]
+//         [ WHERE <primary key predicate> ]
 //         [ WITH <option> [= <value>] [, ...] ]
 // or
 // RESTORE SYSTEM USERS FROM <location...>
@@ -3060,6 +3085,8 @@ opt_with_schedule_options:
 //    skip_localities_check: ignore difference of zone configuration between restore cluster and backup cluster
 //    debug_pause_on: describes the events that the job should pause itself on for debugging purposes.
 //    new_db_name: renames the restored database. only applies to database restores
+//    new_schema: specify the target schema of the restored tables. only applies to table restores
+//    verify_only: read and check every file of the backup without restoring any data
 // %SeeAlso: BACKUP, WEBDOCS/restore.html
 restore_stmt:
   RESTORE FROM list_of_string_or_placeholder_opt_list opt_as_of_clause opt_with_restore_options
@@ -3081,23 +3108,48 @@ restore_stmt:
 		Options: *($7.restoreOptions()),
     }
   }
-| RESTORE targets FROM list_of_string_or_placeholder_opt_list opt_as_of_clause opt_with_restore_options
+| RESTORE targets FROM list_of_string_or_placeholder_opt_list opt_as_of_clause opt_where_clause opt_with_restore_options
   {
     $$.val = &tree.Restore{
     Targets: $2.targetList(),
     From: $4.listOfStringOrPlaceholderOptList(),
     AsOf: $5.asOfClause(),
-    Options: *($6.restoreOptions()),
+    Where: tree.NewWhere(tree.AstWhere, $6.expr()),
+    Options: *($7.restoreOptions()),
     }
   }
-| RESTORE targets FROM string_or_placeholder IN list_of_string_or_placeholder_opt_list opt_as_of_clause opt_with_restore_options
+| RESTORE targets FROM string_or_placeholder IN list_of_string_or_placeholder_opt_list opt_as_of_clause opt_where_clause opt_with_restore_options
   {
     $$.val = &tree.Restore{
       Targets: $2.targetList(),
       Subdir: $4.expr(),
       From: $6.listOfStringOrPlaceholderOptList(),
       AsOf: $7.asOfClause(),
-      Options: *($8.restoreOptions()),
+      Where: tree.NewWhere(tree.AstWhere, $8.expr()),
+      Options: *($9.restoreOptions()),
+    }
+  }
+| RESTORE TABLE table_pattern AS table_name FROM list_of_string_or_placeholder_opt_list opt_as_of_clause opt_where_clause opt_with_restore_options
+  {
+    $$.val = &tree.Restore{
+      Targets: tree.TargetList{Tables: tree.TablePatterns{$3.unresolvedName()}},
+      NewTableName: $5.unresolvedObjectName(),
+      From: $7.listOfStringOrPlaceholderOptList(),
+      AsOf: $8.asOfClause(),
+      Where: tree.NewWhere(tree.AstWhere, $9.expr()),
+      Options: *($10.restoreOptions()),
+    }
+  }
+| RESTORE TABLE table_pattern AS table_name FROM string_or_placeholder IN list_of_string_or_placeholder_opt_list opt_as_of_clause opt_where_clause opt_with_restore_options
+  {
+    $$.val = &tree.Restore{
+      Targets: tree.TargetList{Tables: tree.TablePatterns{$3.unresolvedName()}},
+      NewTableName: $5.unresolvedObjectName(),
+      Subdir: $7.expr(),
+      From: $9.listOfStringOrPlaceholderOptList(),
+      AsOf: $10.asOfClause(),
+      Where: tree.NewWhere(tree.AstWhere, $11.expr()),
+      Options: *($12.restoreOptions()),
     }
   }
 | RESTORE SYSTEM USERS FROM list_of_string_or_placeholder_opt_list opt_as_of_clause opt_with_restore_options
@@ -3231,6 +3283,14 @@ restore_options:
   {
     $$.val = &tree.RestoreOptions{AsTenant: $3.expr()}
   }
+| VERIFY_ONLY
+  {
+    $$.val = &tree.RestoreOptions{VerifyOnly: true}
+  }
+| NEW_SCHEMA '=' string_or_placeholder
+  {
+    $$.val = &tree.RestoreOptions{NewSchemaName: $3.expr()}
+  }
 
 import_format:
   name
@@ -3260,6 +3320,10 @@ alter_unsupported_stmt:
 //        <format> <datafile>
 //        [ WITH <option> [= <value>] [, ...] ]
 //
+// -- Import a CSV file into a new table, inferring its schema from a sample:
+// IMPORT [ TABLE <tablename> FROM ] CSV <datafile>
+//        WITH infer_schema [, sample_rows = '...'] [, <option> [= <value>] [, ...] ]
+//
 // -- Import using specific schema, use only table data from external file:
 // IMPORT TABLE <tablename>
 //        { ( <elements> ) | CREATE USING <schemafile> }
@@ -3281,8 +3345,9 @@ alter_unsupported_stmt:
 //    delimiter = '...'      [CSV, PGCOPY-specific]
 //    nullif = '...'         [CSV, PGCOPY-specific]
 //    comment = '...'        [CSV-specific]
+//    upsert                 [IMPORT INTO-specific, not rolled back on failure]
 //
-// %SeeAlso: CREATE TABLE
+// %SeeAlso: CREATE TABLE, SHOW IMPORT SCHEMA
 import_stmt:
  IMPORT import_format '(' string_or_placeholder ')' opt_with_options
   {
@@ -3853,6 +3918,8 @@ create_stats_option:
 // %Text:
 // CREATE CHANGEFEED
 // FOR <targets> [INTO sink] [WITH <options>]
+// CREATE CHANGEFEED
+// FOR DATABASE <database> INTO sink [WITH <options>]
 //
 // Sink: Data caputre stream stream destination.  Enterprise only.
 create_changefeed_stmt:
@@ -3882,6 +3949,10 @@ changefeed_targets:
   {
     $$.val = tree.TargetList{Tables: $2.tablePatterns()}
   }
+| DATABASE database_name
+  {
+    $$.val = tree.TargetList{Databases: tree.NameList{tree.Name($2)}}
+  }
 
 single_table_pattern_list:
   table_name
@@ -4381,7 +4452,7 @@ explain_option_list:
 // %Help: ALTER CHANGEFEED - alter an existing changefeed
 // %Category: CCL
 // %Text:
-// ALTER CHANGEFEED <job_id> {{ADD|DROP <targets...>} | SET <options...>}...
+// ALTER CHANGEFEED <job_id> {{ADD <targets...> [WITH <options...>]|DROP <targets...>} | SET <options...>}...
 alter_changefeed_stmt:
   ALTER CHANGEFEED a_expr alter_changefeed_cmds
   {
@@ -4403,11 +4474,12 @@ alter_changefeed_cmds:
   }
 
 alter_changefeed_cmd:
-  // ALTER CHANGEFEED <job_id> ADD [TABLE] ...
-  ADD changefeed_targets
+  // ALTER CHANGEFEED <job_id> ADD [TABLE] ... [WITH <options...>]
+  ADD changefeed_targets opt_with_options
   {
     $$.val = &tree.AlterChangefeedAddTarget{
       Targets: $2.targetList(),
+      Options: $3.kvOptions(),
     }
   }
   // ALTER CHANGEFEED <job_id> DROP [TABLE] ...
@@ -4430,17 +4502,24 @@ alter_changefeed_cmd:
     }
   }
 
-// %Help: ALTER BACKUP - alter an existing backup's encryption keys
+// %Help: ALTER BACKUP - alter an existing backup's encryption keys or the rate limits of its jobs
 // %Category: CCL
 // %Text:
 // ALTER BACKUP <location...>
 //        [ ADD NEW_KMS = <kms...> ]
 //        [ WITH OLD_KMS = <kms...> ]
+//        [ SET <option> = <value> [, ...] ]
 // Locations:
 //    "[scheme]://[host]/[path to backup]?[parameters]"
 //
 // KMS:
 //    "[kms_provider]://[kms_host]/[master_key_identifier]?[parameters]" : add new kms keys to backup
+//
+// Options, which apply to the running backups to and restores from the location:
+//    read_rate_limit = '<size>'   : limit the bytes per second a backup reads across the cluster
+//    write_rate_limit = '<size>'  : limit the bytes per second a backup writes across the cluster
+//    ingest_rate_limit = '<size>' : limit the bytes per second a restore ingests across the cluster
+// A limit of '0' removes the limit.
 alter_backup_stmt:
   ALTER BACKUP string_or_placeholder alter_backup_cmds
   {
@@ -4476,6 +4555,12 @@ alter_backup_cmd:
       KMSInfo:	$2.backupKMS(),
     }
 	}
+|	SET kv_option_list
+	{
+    $$.val = &tree.AlterBackupSetOptions{
+      Options:	$2.kvOptions(),
+    }
+	}
 
 backup_kms:
 	NEW_KMS '=' string_or_placeholder_opt_list WITH OLD_KMS '=' string_or_placeholder_opt_list
@@ -5276,7 +5361,7 @@ zone_value:
 // %Category: Group
 // %Text:
 // SHOW BACKUP, SHOW CLUSTER SETTING, SHOW COLUMNS, SHOW CONSTRAINTS,
-// SHOW CREATE, SHOW CREATE SCHEDULES, SHOW DATABASES, SHOW ENUMS, SHOW HISTOGRAM, SHOW INDEXES, SHOW
+// SHOW CREATE, SHOW CREATE SCHEDULES, SHOW DATABASES, SHOW ENUMS, SHOW HISTOGRAM, SHOW IMPORT SCHEMA, SHOW INDEXES, SHOW
 // PARTITIONS, SHOW JOBS, SHOW STATEMENTS, SHOW RANGE, SHOW RANGES, SHOW REGIONS, SHOW SURVIVAL GOAL,
 // SHOW ROLES, SHOW SCHEMAS, SHOW SEQUENCES, SHOW SESSION, SHOW SESSIONS,
 // SHOW STATISTICS, SHOW SYNTAX, SHOW TABLES, SHOW TRACE, SHOW TRANSACTION,
@@ -5295,6 +5380,7 @@ show_stmt:
 | show_fingerprints_stmt
 | show_grants_stmt           // EXTEND WITH HELP: SHOW GRANTS
 | show_histogram_stmt        // EXTEND WITH HELP: SHOW HISTOGRAM
+| show_import_schema_stmt    // EXTEND WITH HELP: SHOW IMPORT SCHEMA
 | show_indexes_stmt          // EXTEND WITH HELP: SHOW INDEXES
 | show_partitions_stmt       // EXTEND WITH HELP: SHOW PARTITIONS
 | show_jobs_stmt             // EXTEND WITH HELP: SHOW JOBS
@@ -5636,9 +5722,35 @@ show_histogram_stmt:
   }
 | SHOW HISTOGRAM error // SHOW HELP: SHOW HISTOGRAM
 
+// %Help: SHOW IMPORT SCHEMA - infer the schema of a file to import
+// %Category: CCL
+// %Text:
+// SHOW IMPORT SCHEMA FROM <format> <datafile>
+//        [ WITH <option> [= <value>] [, ...] ]
+//
+// Formats:
+//    CSV
+//
+// Options:
+//    sample_rows = '...'
+//    delimiter = '...'
+//    nullif = '...'
+//    comment = '...'
+//    skip = '...'
+//
+// %SeeAlso: IMPORT, CREATE TABLE
+show_import_schema_stmt:
+  SHOW IMPORT SCHEMA FROM import_format string_or_placeholder opt_with_options
+  {
+    $$.val = &tree.ShowImportSchema{FileFormat: $5, Files: tree.Exprs{$6.expr()}, Options: $7.kvOptions()}
+  }
+| SHOW IMPORT error // SHOW HELP: SHOW IMPORT SCHEMA
+
 // %Help: SHOW BACKUP - list backup contents
 // %Category: CCL
-// %Text: SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// %Text:
+// SHOW BACKUP [SCHEMAS|FILES|RANGES] <location>
+// SHOW BACKUP CHECK FILES <location> [IN <collection>]
 // %SeeAlso: WEBDOCS/show-backup.html
 show_backup_stmt:
   SHOW BACKUPS IN string_or_placeholder
@@ -5691,6 +5803,23 @@ show_backup_stmt:
       Options: $5.kvOptions(),
     }
   }
+| SHOW BACKUP CHECK FILES string_or_placeholder opt_with_options
+  {
+    $$.val = &tree.ShowBackup{
+      Details: tree.BackupCheckFilesDetails,
+      Path:    $5.expr(),
+      Options: $6.kvOptions(),
+    }
+  }
+| SHOW BACKUP CHECK FILES string_or_placeholder IN string_or_placeholder opt_with_options
+  {
+    $$.val = &tree.ShowBackup{
+      Details: tree.BackupCheckFilesDetails,
+      Path:    $5.expr(),
+      InCollection: $7.expr(),
+      Options: $8.kvOptions(),
+    }
+  }
 | SHOW BACKUP error // SHOW HELP: SHOW BACKUP
 
 // %Help: SHOW CLUSTER SETTING - display cluster settings
@@ -13903,6 +14032,7 @@ unreserved_keyword:
 | CONFIGURE
 | CONNECTION
 | CONSTRAINTS
+| CONTINUOUS
 | CONTROLCHANGEFEED
 | CONTROLJOB
 | CONVERSION
@@ -14029,6 +14159,7 @@ unreserved_keyword:
 | METHOD
 | MINUTE
 | MINVALUE
+| MIRROR
 | MODIFYCLUSTERSETTING
 | MULTILINESTRING
 | MULTILINESTRINGM
@@ -14048,6 +14179,7 @@ unreserved_keyword:
 | NEVER
 | NEW_DB_NAME
 | NEW_KMS
+| NEW_SCHEMA
 | NEXT
 | NO
 | NORMAL
@@ -14232,6 +14364,7 @@ unreserved_keyword:
 | VALIDATE
 | VALUE
 | VARYING
+| VERIFY_ONLY
 | VIEW
 | VIEWACTIVITY
 | VIEWACTIVITYREDACTED
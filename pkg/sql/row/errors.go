@@ -271,6 +271,7 @@ func DecodeRowInfo(
 		false, /* reverse */
 		descpb.ScanLockingStrength_FOR_NONE,
 		descpb.ScanLockingWaitPolicy_BLOCK,
+		descpb.ScanLockingDurability_BEST_EFFORT,
 		0, /* lockTimeout */
 		&tree.DatumAlloc{},
 		nil, /* memMonitor */
@@ -142,6 +142,10 @@ type Fetcher struct {
 	// locks held by other active transactions.
 	lockWaitPolicy descpb.ScanLockingWaitPolicy
 
+	// lockDurability represents the durability of the locks acquired when
+	// fetching rows.
+	lockDurability descpb.ScanLockingDurability
+
 	// lockTimeout specifies the maximum amount of time that the fetcher will
 	// wait while attempting to acquire a lock on a key or while blocking on an
 	// existing lock in order to perform a non-locking read on a key.
@@ -211,6 +215,7 @@ func (rf *Fetcher) Init(
 	reverse bool,
 	lockStrength descpb.ScanLockingStrength,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
+	lockDurability descpb.ScanLockingDurability,
 	lockTimeout time.Duration,
 	alloc *tree.DatumAlloc,
 	memMonitor *mon.BytesMonitor,
@@ -222,6 +227,7 @@ func (rf *Fetcher) Init(
 	rf.reverse = reverse
 	rf.lockStrength = lockStrength
 	rf.lockWaitPolicy = lockWaitPolicy
+	rf.lockDurability = lockDurability
 	rf.lockTimeout = lockTimeout
 	rf.alloc = alloc
 
@@ -367,6 +373,7 @@ func (rf *Fetcher) StartScan(
 		rf.rowLimitToKeyLimit(rowLimitHint),
 		rf.lockStrength,
 		rf.lockWaitPolicy,
+		rf.lockDurability,
 		rf.lockTimeout,
 		rf.kvFetcherMemAcc,
 		forceProductionKVBatchSize,
@@ -468,6 +475,7 @@ func (rf *Fetcher) StartInconsistentScan(
 		rf.rowLimitToKeyLimit(rowLimitHint),
 		rf.lockStrength,
 		rf.lockWaitPolicy,
+		rf.lockDurability,
 		rf.lockTimeout,
 		rf.kvFetcherMemAcc,
 		forceProductionKVBatchSize,
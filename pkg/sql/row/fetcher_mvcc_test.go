@@ -94,6 +94,7 @@ func TestRowFetcherMVCCMetadata(t *testing.T) {
 		false, /* reverse */
 		descpb.ScanLockingStrength_FOR_NONE,
 		descpb.ScanLockingWaitPolicy_BLOCK,
+		descpb.ScanLockingDurability_BEST_EFFORT,
 		0, /* lockTimeout */
 		&tree.DatumAlloc{},
 		nil, /* memMonitor */
@@ -76,6 +76,7 @@ func initFetcher(
 		reverseScan,
 		descpb.ScanLockingStrength_FOR_NONE,
 		descpb.ScanLockingWaitPolicy_BLOCK,
+		descpb.ScanLockingDurability_BEST_EFFORT,
 		0, /* lockTimeout */
 		alloc,
 		memMon,
@@ -709,6 +710,7 @@ func TestRowFetcherReset(t *testing.T) {
 		false, /*reverse*/
 		descpb.ScanLockingStrength_FOR_NONE,
 		descpb.ScanLockingWaitPolicy_BLOCK,
+		descpb.ScanLockingDurability_BEST_EFFORT,
 		0, /* lockTimeout */
 		&da,
 		nil, /* memMonitor */
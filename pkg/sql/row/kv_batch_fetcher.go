@@ -90,6 +90,9 @@ type txnKVFetcher struct {
 	reverse bool
 	// lockStrength represents the locking mode to use when fetching KVs.
 	lockStrength lock.Strength
+	// lockReplicated indicates whether the locks acquired when fetching KVs
+	// should have the Replicated durability.
+	lockReplicated bool
 	// lockWaitPolicy represents the policy to be used for handling conflicting
 	// locks held by other active transactions.
 	lockWaitPolicy lock.WaitPolicy
@@ -210,6 +213,7 @@ func makeKVBatchFetcher(
 	firstBatchKeyLimit rowinfra.KeyLimit,
 	lockStrength descpb.ScanLockingStrength,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
+	lockDurability descpb.ScanLockingDurability,
 	lockTimeout time.Duration,
 	acc *mon.BoundAccount,
 	forceProductionKVBatchSize bool,
@@ -270,6 +274,7 @@ func makeKVBatchFetcher(
 		batchBytesLimit:            batchBytesLimit,
 		firstBatchKeyLimit:         firstBatchKeyLimit,
 		lockStrength:               getKeyLockingStrength(lockStrength),
+		lockReplicated:             getKeyLockingReplicated(lockStrength, lockDurability),
 		lockWaitPolicy:             GetWaitPolicy(lockWaitPolicy),
 		lockTimeout:                lockTimeout,
 		acc:                        acc,
@@ -316,7 +321,7 @@ func (f *txnKVFetcher) fetch(ctx context.Context) error {
 	ba.Header.TargetBytes = int64(f.batchBytesLimit)
 	ba.Header.MaxSpanRequestKeys = int64(f.getBatchKeyLimit())
 	ba.AdmissionHeader = f.requestAdmissionHeader
-	ba.Requests = spansToRequests(f.spans, f.reverse, f.lockStrength)
+	ba.Requests = spansToRequests(f.spans, f.reverse, f.lockStrength, f.lockReplicated)
 
 	if log.ExpensiveLogEnabled(ctx, 2) {
 		log.VEventf(ctx, 2, "Scan %s", f.spans)
@@ -547,9 +552,10 @@ func (f *txnKVFetcher) close(ctx context.Context) {
 // spansToRequests converts the provided spans to the corresponding requests. If
 // a span doesn't have the EndKey set, then a Get request is used for it;
 // otherwise, a Scan (or ReverseScan if reverse is true) request is used with
-// BATCH_RESPONSE format.
+// BATCH_RESPONSE format. If keyLocking is not lock.None, the locks are
+// acquired with the Replicated durability if keyLockingReplicated is true.
 func spansToRequests(
-	spans roachpb.Spans, reverse bool, keyLocking lock.Strength,
+	spans roachpb.Spans, reverse bool, keyLocking lock.Strength, keyLockingReplicated bool,
 ) []roachpb.RequestUnion {
 	reqs := make([]roachpb.RequestUnion, len(spans))
 	// Detect the number of gets vs scans, so we can batch allocate all of the
@@ -578,6 +584,7 @@ func spansToRequests(
 				// single key fetch, which can be served using a GetRequest.
 				gets[curGet].req.Key = spans[i].Key
 				gets[curGet].req.KeyLocking = keyLocking
+				gets[curGet].req.KeyLockingReplicated = keyLockingReplicated
 				gets[curGet].union.Get = &gets[curGet].req
 				reqs[i].Value = &gets[curGet].union
 				curGet++
@@ -587,6 +594,7 @@ func spansToRequests(
 			scans[curScan].req.SetSpan(spans[i])
 			scans[curScan].req.ScanFormat = roachpb.BATCH_RESPONSE
 			scans[curScan].req.KeyLocking = keyLocking
+			scans[curScan].req.KeyLockingReplicated = keyLockingReplicated
 			scans[curScan].union.ReverseScan = &scans[curScan].req
 			reqs[i].Value = &scans[curScan].union
 		}
@@ -601,6 +609,7 @@ func spansToRequests(
 				// single key fetch, which can be served using a GetRequest.
 				gets[curGet].req.Key = spans[i].Key
 				gets[curGet].req.KeyLocking = keyLocking
+				gets[curGet].req.KeyLockingReplicated = keyLockingReplicated
 				gets[curGet].union.Get = &gets[curGet].req
 				reqs[i].Value = &gets[curGet].union
 				curGet++
@@ -610,6 +619,7 @@ func spansToRequests(
 			scans[curScan].req.SetSpan(spans[i])
 			scans[curScan].req.ScanFormat = roachpb.BATCH_RESPONSE
 			scans[curScan].req.KeyLocking = keyLocking
+			scans[curScan].req.KeyLockingReplicated = keyLockingReplicated
 			scans[curScan].union.Scan = &scans[curScan].req
 			reqs[i].Value = &scans[curScan].union
 		}
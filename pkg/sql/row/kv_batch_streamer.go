@@ -67,12 +67,14 @@ func NewTxnKVStreamer(
 	streamer *kvstreamer.Streamer,
 	spans roachpb.Spans,
 	lockStrength descpb.ScanLockingStrength,
+	lockDurability descpb.ScanLockingDurability,
 ) (*TxnKVStreamer, error) {
 	if log.ExpensiveLogEnabled(ctx, 2) {
 		log.VEventf(ctx, 2, "Scan %s", spans)
 	}
 	keyLocking := getKeyLockingStrength(lockStrength)
-	reqs := spansToRequests(spans, false /* reverse */, keyLocking)
+	keyLockingReplicated := getKeyLockingReplicated(lockStrength, lockDurability)
+	reqs := spansToRequests(spans, false /* reverse */, keyLocking, keyLockingReplicated)
 	if err := streamer.Enqueue(ctx, reqs, nil /* enqueueKeys */); err != nil {
 		return nil, err
 	}
@@ -60,6 +60,7 @@ func NewKVFetcher(
 	firstBatchLimit rowinfra.KeyLimit,
 	lockStrength descpb.ScanLockingStrength,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
+	lockDurability descpb.ScanLockingDurability,
 	lockTimeout time.Duration,
 	acc *mon.BoundAccount,
 	forceProductionKVBatchSize bool,
@@ -99,6 +100,7 @@ func NewKVFetcher(
 		firstBatchLimit,
 		lockStrength,
 		lockWaitPolicy,
+		lockDurability,
 		lockTimeout,
 		acc,
 		forceProductionKVBatchSize,
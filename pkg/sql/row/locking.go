@@ -27,9 +27,7 @@ func getKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strengt
 		// Promote to FOR_SHARE.
 		fallthrough
 	case descpb.ScanLockingStrength_FOR_SHARE:
-		// We currently perform no per-key locking when FOR_SHARE is used
-		// because Shared locks have not yet been implemented.
-		return lock.None
+		return lock.Shared
 
 	case descpb.ScanLockingStrength_FOR_NO_KEY_UPDATE:
 		// Promote to FOR_UPDATE.
@@ -44,6 +42,34 @@ func getKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strengt
 	}
 }
 
+// getKeyLockingReplicated returns whether the locks of the provided strength
+// acquired by key-value scans should have the Replicated durability. Only
+// exclusive locks can be replicated, so weaker locking strengths fall back to
+// best-effort, unreplicated locks.
+func getKeyLockingReplicated(
+	lockStrength descpb.ScanLockingStrength, lockDurability descpb.ScanLockingDurability,
+) bool {
+	switch lockDurability {
+	case descpb.ScanLockingDurability_BEST_EFFORT:
+		return false
+
+	case descpb.ScanLockingDurability_GUARANTEED:
+		return getKeyLockingStrength(lockStrength) == lock.Exclusive
+
+	default:
+		panic(errors.AssertionFailedf("unknown locking durability %s", lockDurability))
+	}
+}
+
+// GetLockingDurability returns the configured lock durability to use for
+// key-value scans.
+func GetLockingDurability(durableLockingEnabled bool) descpb.ScanLockingDurability {
+	if durableLockingEnabled {
+		return descpb.ScanLockingDurability_GUARANTEED
+	}
+	return descpb.ScanLockingDurability_BEST_EFFORT
+}
+
 // GetWaitPolicy returns the configured lock wait policy to use for key-value
 // scans.
 func GetWaitPolicy(lockWaitPolicy descpb.ScanLockingWaitPolicy) lock.WaitPolicy {
@@ -201,6 +201,9 @@ func (ib *indexBackfiller) ingestIndexEntries(
 		BatchTimestamp:           ib.spec.ReadAsOf,
 		InitialSplitsIfUnordered: int(ib.spec.InitialSplits),
 		WriteAtBatchTimestamp:    ib.spec.WriteAtBatchTimestamp,
+		// Index backfills are not latency sensitive, so they shouldn't
+		// saturate the disks at the expense of foreground traffic.
+		ElasticAdmission: true,
 	}
 	adder, err := ib.flowCtx.Cfg.BulkAdder(ctx, ib.flowCtx.Cfg.DB, ib.spec.WriteAsOf, opts)
 	if err != nil {
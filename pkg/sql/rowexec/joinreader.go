@@ -383,6 +383,7 @@ func newJoinReader(
 		false, /* reverse */
 		spec.LockingStrength,
 		spec.LockingWaitPolicy,
+		row.GetLockingDurability(flowCtx.EvalCtx.SessionData().DurableLockingEnabled),
 		flowCtx.EvalCtx.SessionData().LockTimeout,
 		&jr.alloc,
 		flowCtx.EvalCtx.Mon,
@@ -830,7 +831,10 @@ func (jr *joinReader) readInput() (
 	// joinReaderStrategy doesn't account for any memory used by the spans.
 	if jr.usesStreamer {
 		var kvBatchFetcher *row.TxnKVStreamer
-		kvBatchFetcher, err = row.NewTxnKVStreamer(jr.Ctx, jr.streamerInfo.Streamer, spans, jr.keyLocking)
+		kvBatchFetcher, err = row.NewTxnKVStreamer(
+			jr.Ctx, jr.streamerInfo.Streamer, spans, jr.keyLocking,
+			row.GetLockingDurability(jr.FlowCtx.EvalCtx.SessionData().DurableLockingEnabled),
+		)
 		if err != nil {
 			jr.MoveToDraining(err)
 			return jrStateUnknown, nil, jr.DrainHelper()
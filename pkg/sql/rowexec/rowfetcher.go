@@ -109,6 +109,7 @@ func makeRowFetcherLegacy(
 		reverseScan,
 		lockStrength,
 		lockWaitPolicy,
+		row.GetLockingDurability(flowCtx.EvalCtx.SessionData().DurableLockingEnabled),
 		flowCtx.EvalCtx.SessionData().LockTimeout,
 		alloc,
 		mon,
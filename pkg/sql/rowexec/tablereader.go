@@ -135,6 +135,7 @@ func newTableReader(
 		spec.Reverse,
 		spec.LockingStrength,
 		spec.LockingWaitPolicy,
+		row.GetLockingDurability(flowCtx.EvalCtx.SessionData().DurableLockingEnabled),
 		flowCtx.EvalCtx.SessionData().LockTimeout,
 		&tr.alloc,
 		flowCtx.EvalCtx.Mon,
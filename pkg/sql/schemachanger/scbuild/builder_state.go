@@ -589,6 +589,9 @@ func (b *builderState) resolveRelation(
 	if rel.IsTemporary() {
 		panic(scerrors.NotImplementedErrorf(nil /* n */, "dropping a temporary table"))
 	}
+	if reason := rel.GetReadOnlyReason(); reason != "" {
+		panic(sqlerrors.NewTableReadOnlyError(rel.GetName(), reason))
+	}
 	// If we own the schema then we can manipulate the underlying relation.
 	b.ensureDescriptor(rel.GetID())
 	c := b.descCache[rel.GetID()]
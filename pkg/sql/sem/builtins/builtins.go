@@ -5964,6 +5964,36 @@ value if you rely on the HLC for accuracy.`,
 		},
 	),
 
+	"crdb_internal.update_tenant_storage_quota": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     categoryMultiTenancy,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"tenant_id", types.Int},
+				{"quota_bytes", types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				sTenID, err := mustBeDIntInTenantRange(args[0])
+				if err != nil {
+					return nil, err
+				}
+				quotaBytes := int64(tree.MustBeDInt(args[1]))
+
+				if err := ctx.Tenant.UpdateTenantStorageQuota(
+					ctx.Context, uint64(sTenID), quotaBytes,
+				); err != nil {
+					return nil, err
+				}
+				return args[0], nil
+			},
+			Info:       "Updates the storage quota (in logical bytes) for the tenant with the provided ID; 0 removes the quota. Must be run by the System tenant.",
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
 	"crdb_internal.compact_engine_span": makeBuiltin(
 		tree.FunctionProperties{
 			Category:         categorySystemRepair,
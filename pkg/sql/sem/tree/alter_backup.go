@@ -70,6 +70,22 @@ func (node *AlterBackupKMS) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.KMSInfo.OldKMSURI)
 }
 
+func (node *AlterBackupSetOptions) alterBackupCmd() {}
+
+var _ AlterBackupCmd = &AlterBackupSetOptions{}
+
+// AlterBackupSetOptions represents an ALTER BACKUP ... SET <options> command,
+// which changes the options of the jobs that are running on the backup.
+type AlterBackupSetOptions struct {
+	Options KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterBackupSetOptions) Format(ctx *FmtCtx) {
+	ctx.WriteString(" SET ")
+	ctx.FormatNode(&node.Options)
+}
+
 // BackupKMS represents possible options used when altering a backup KMS
 type BackupKMS struct {
 	NewKMSURI StringOrPlaceholderOptList
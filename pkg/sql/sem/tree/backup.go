@@ -41,6 +41,9 @@ type BackupOptions struct {
 	Detached               bool
 	EncryptionKMSURI       StringOrPlaceholderOptList
 	IncrementalStorage     StringOrPlaceholderOptList
+	Compact                bool
+	Mirror                 StringOrPlaceholderOptList
+	Continuous             bool
 }
 
 var _ NodeFormatter = &BackupOptions{}
@@ -133,6 +136,8 @@ type RestoreOptions struct {
 	NewDBName                 Expr
 	IncrementalStorage        StringOrPlaceholderOptList
 	AsTenant                  Expr
+	VerifyOnly                bool
+	NewSchemaName             Expr
 }
 
 var _ NodeFormatter = &RestoreOptions{}
@@ -151,8 +156,14 @@ type Restore struct {
 	//   - len(From)==1 implies we'll have to look for incremental backups in planning
 	//   - len(From[0]) > 1 implies the backups are locality aware
 	//   - From[i][0] must be the default locality.
-	From    []StringOrPlaceholderOptList
-	AsOf    AsOfClause
+	From []StringOrPlaceholderOptList
+	AsOf AsOfClause
+	// NewTableName, if set, is the name the single table being restored is
+	// restored under, as in `RESTORE TABLE a AS b`.
+	NewTableName *UnresolvedObjectName
+	// Where, if set, limits the restore of a single table to the rows whose
+	// primary key satisfies it.
+	Where   *Where
 	Options RestoreOptions
 
 	// Subdir may be set by the parser when the SQL query is of the form `RESTORE
@@ -170,6 +181,11 @@ func (node *Restore) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.Targets)
 		ctx.WriteString(" ")
 	}
+	if node.NewTableName != nil {
+		ctx.WriteString("AS ")
+		ctx.FormatNode(node.NewTableName)
+		ctx.WriteString(" ")
+	}
 	ctx.WriteString("FROM ")
 	if node.Subdir != nil {
 		ctx.FormatNode(node.Subdir)
@@ -185,6 +201,10 @@ func (node *Restore) Format(ctx *FmtCtx) {
 		ctx.WriteString(" ")
 		ctx.FormatNode(&node.AsOf)
 	}
+	if node.Where != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Where)
+	}
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
@@ -273,6 +293,22 @@ func (o *BackupOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("incremental_location = ")
 		ctx.FormatNode(&o.IncrementalStorage)
 	}
+
+	if o.Compact {
+		maybeAddSep()
+		ctx.WriteString("compact")
+	}
+
+	if o.Mirror != nil {
+		maybeAddSep()
+		ctx.WriteString("mirror = ")
+		ctx.FormatNode(&o.Mirror)
+	}
+
+	if o.Continuous {
+		maybeAddSep()
+		ctx.WriteString("continuous")
+	}
 }
 
 // CombineWith merges other backup options into this backup options struct.
@@ -312,6 +348,28 @@ func (o *BackupOptions) CombineWith(other *BackupOptions) error {
 		return errors.New("incremental_location option specified multiple times")
 	}
 
+	if o.Compact {
+		if other.Compact {
+			return errors.New("compact option specified multiple times")
+		}
+	} else {
+		o.Compact = other.Compact
+	}
+
+	if o.Mirror == nil {
+		o.Mirror = other.Mirror
+	} else if other.Mirror != nil {
+		return errors.New("mirror option specified multiple times")
+	}
+
+	if o.Continuous {
+		if other.Continuous {
+			return errors.New("continuous option specified multiple times")
+		}
+	} else {
+		o.Continuous = other.Continuous
+	}
+
 	return nil
 }
 
@@ -321,7 +379,10 @@ func (o BackupOptions) IsDefault() bool {
 	return o.CaptureRevisionHistory == options.CaptureRevisionHistory &&
 		o.Detached == options.Detached && cmp.Equal(o.EncryptionKMSURI, options.EncryptionKMSURI) &&
 		o.EncryptionPassphrase == options.EncryptionPassphrase &&
-		cmp.Equal(o.IncrementalStorage, options.IncrementalStorage)
+		cmp.Equal(o.IncrementalStorage, options.IncrementalStorage) &&
+		o.Compact == options.Compact &&
+		cmp.Equal(o.Mirror, options.Mirror) &&
+		o.Continuous == options.Continuous
 }
 
 // Format implements the NodeFormatter interface.
@@ -404,6 +465,17 @@ func (o *RestoreOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("tenant = ")
 		ctx.FormatNode(o.AsTenant)
 	}
+
+	if o.VerifyOnly {
+		maybeAddSep()
+		ctx.WriteString("verify_only")
+	}
+
+	if o.NewSchemaName != nil {
+		maybeAddSep()
+		ctx.WriteString("new_schema = ")
+		ctx.FormatNode(o.NewSchemaName)
+	}
 }
 
 // CombineWith merges other backup options into this backup options struct.
@@ -499,6 +571,20 @@ func (o *RestoreOptions) CombineWith(other *RestoreOptions) error {
 		return errors.New("tenant option specified multiple times")
 	}
 
+	if o.VerifyOnly {
+		if other.VerifyOnly {
+			return errors.New("verify_only specified multiple times")
+		}
+	} else {
+		o.VerifyOnly = other.VerifyOnly
+	}
+
+	if o.NewSchemaName == nil {
+		o.NewSchemaName = other.NewSchemaName
+	} else if other.NewSchemaName != nil {
+		return errors.New("new_schema specified multiple times")
+	}
+
 	return nil
 }
 
@@ -517,5 +603,7 @@ func (o RestoreOptions) IsDefault() bool {
 		o.DebugPauseOn == options.DebugPauseOn &&
 		o.NewDBName == options.NewDBName &&
 		cmp.Equal(o.IncrementalStorage, options.IncrementalStorage) &&
-		o.AsTenant == options.AsTenant
+		o.AsTenant == options.AsTenant &&
+		o.VerifyOnly == options.VerifyOnly &&
+		o.NewSchemaName == options.NewSchemaName
 }
@@ -3507,6 +3507,10 @@ type TenantOperator interface {
 		asOf time.Time,
 		asOfConsumedRequestUnits float64,
 	) error
+
+	// UpdateTenantStorageQuota sets the maximum number of logical bytes the
+	// tenant can store; a quota of 0 removes the limit.
+	UpdateTenantStorageQuota(ctx context.Context, tenantID uint64, quotaBytes int64) error
 }
 
 // JoinTokenCreator is capable of creating and persisting join tokens, allowing
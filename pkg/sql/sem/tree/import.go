@@ -62,3 +62,24 @@ func (node *Import) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.Options)
 	}
 }
+
+// ShowImportSchema represents a SHOW IMPORT SCHEMA statement.
+type ShowImportSchema struct {
+	FileFormat string
+	Files      Exprs
+	Options    KVOptions
+}
+
+var _ Statement = &ShowImportSchema{}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowImportSchema) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW IMPORT SCHEMA FROM ")
+	ctx.WriteString(node.FileFormat)
+	ctx.WriteByte(' ')
+	ctx.FormatNode(&node.Files)
+	if node.Options != nil {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Options)
+	}
+}
@@ -2115,6 +2115,9 @@ func (node *Restore) doc(p *PrettyCfg) pretty.Doc {
 	if node.DescriptorCoverage == RequestedDescriptors {
 		items = append(items, node.Targets.docRow(p))
 	}
+	if node.NewTableName != nil {
+		items = append(items, p.row("AS", p.Doc(node.NewTableName)))
+	}
 	from := make([]pretty.Doc, len(node.From))
 	for i := range node.From {
 		from[i] = p.Doc(&node.From[i])
@@ -2129,6 +2132,9 @@ func (node *Restore) doc(p *PrettyCfg) pretty.Doc {
 	if node.AsOf.Expr != nil {
 		items = append(items, node.AsOf.docRow(p))
 	}
+	if node.Where != nil {
+		items = append(items, node.Where.docRow(p))
+	}
 	if !node.Options.IsDefault() {
 		items = append(items, p.row("WITH", p.Doc(&node.Options)))
 	}
@@ -96,6 +96,8 @@ const (
 	BackupFileDetails
 	// BackupManifestAsJSON displays full backup manifest as json
 	BackupManifestAsJSON
+	// BackupCheckFilesDetails identifies a SHOW BACKUP CHECK FILES statement.
+	BackupCheckFilesDetails
 )
 
 // ShowBackup represents a SHOW BACKUP statement.
@@ -119,6 +121,8 @@ func (node *ShowBackup) Format(ctx *FmtCtx) {
 		ctx.WriteString("RANGES ")
 	} else if node.Details == BackupFileDetails {
 		ctx.WriteString("FILES ")
+	} else if node.Details == BackupCheckFilesDetails {
+		ctx.WriteString("CHECK FILES ")
 	}
 	if node.ShouldIncludeSchemas {
 		ctx.WriteString("SCHEMAS ")
@@ -174,6 +174,7 @@ var _ CCLOnlyStatement = &Restore{}
 var _ CCLOnlyStatement = &CreateChangefeed{}
 var _ CCLOnlyStatement = &AlterChangefeed{}
 var _ CCLOnlyStatement = &Import{}
+var _ CCLOnlyStatement = &ShowImportSchema{}
 var _ CCLOnlyStatement = &Export{}
 var _ CCLOnlyStatement = &ScheduledBackup{}
 var _ CCLOnlyStatement = &StreamIngestion{}
@@ -1371,6 +1372,17 @@ func (*ShowDatabaseIndexes) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowDatabaseIndexes) StatementTag() string { return "SHOW INDEXES FROM DATABASE" }
 
+// StatementReturnType implements the Statement interface.
+func (*ShowImportSchema) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowImportSchema) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowImportSchema) StatementTag() string { return "SHOW IMPORT SCHEMA" }
+
+func (*ShowImportSchema) cclOnlyStatement() {}
+
 // StatementReturnType implements the Statement interface.
 func (*ShowIndexes) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1863,6 +1875,7 @@ func (n *ShowFullTableScans) String() string             { return AsString(n) }
 func (n *ShowGrants) String() string                     { return AsString(n) }
 func (n *ShowHistogram) String() string                  { return AsString(n) }
 func (n *ShowSchedules) String() string                  { return AsString(n) }
+func (n *ShowImportSchema) String() string               { return AsString(n) }
 func (n *ShowIndexes) String() string                    { return AsString(n) }
 func (n *ShowJobs) String() string                       { return AsString(n) }
 func (n *ShowChangefeedJobs) String() string             { return AsString(n) }
@@ -1154,6 +1154,10 @@ func (stmt *ParenSelect) walkStmt(v Visitor) Statement {
 func (stmt *Restore) copyNode() *Restore {
 	stmtCopy := *stmt
 	stmtCopy.From = append([]StringOrPlaceholderOptList(nil), stmt.From...)
+	if stmt.Where != nil {
+		wCopy := *stmt.Where
+		stmtCopy.Where = &wCopy
+	}
 	return &stmtCopy
 }
 
@@ -1169,6 +1173,15 @@ func (stmt *Restore) walkStmt(v Visitor) Statement {
 			ret.AsOf.Expr = e
 		}
 	}
+	if stmt.Where != nil {
+		e, changed := WalkExpr(v, stmt.Where.Expr)
+		if changed {
+			if ret == stmt {
+				ret = stmt.copyNode()
+			}
+			ret.Where.Expr = e
+		}
+	}
 	for i, backup := range stmt.From {
 		for j, expr := range backup {
 			e, changed := WalkExpr(v, expr)
@@ -1200,6 +1213,16 @@ func (stmt *Restore) walkStmt(v Visitor) Statement {
 			ret.Options.IntoDB = intoDB
 		}
 	}
+
+	if stmt.Options.NewSchemaName != nil {
+		newSchema, changed := WalkExpr(v, stmt.Options.NewSchemaName)
+		if changed {
+			if ret == stmt {
+				ret = stmt.copyNode()
+			}
+			ret.Options.NewSchemaName = newSchema
+		}
+	}
 	return ret
 }
 
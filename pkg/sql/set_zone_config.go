@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -97,6 +98,11 @@ var supportedZoneConfigOptions = map[tree.Name]struct {
 		requiredType: types.Int,
 		setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.NumVoters = proto.Int32(int32(tree.MustBeDInt(d))) },
 	},
+	"num_witnesses": {
+		requiredType: types.Int,
+		setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.NumWitnesses = proto.Int32(int32(tree.MustBeDInt(d))) },
+		checkAllowed: checkWitnessReplicasAllowed,
+	},
 	"gc.ttlseconds": {
 		requiredType: types.Int,
 		setter: func(c *zonepb.ZoneConfig, d tree.Datum) {
@@ -127,6 +133,17 @@ var supportedZoneConfigOptions = map[tree.Name]struct {
 			c.NullVoterConstraintsIsEmpty = true
 		},
 	},
+	"witness_constraints": {
+		requiredType: types.String,
+		setter: func(c *zonepb.ZoneConfig, d tree.Datum) {
+			witnessConstraintsList := zonepb.ConstraintsList{
+				Constraints: c.WitnessConstraints,
+			}
+			loadYAML(&witnessConstraintsList, string(tree.MustBeDString(d)))
+			c.WitnessConstraints = witnessConstraintsList.Constraints
+		},
+		checkAllowed: checkWitnessReplicasAllowed,
+	},
 	"lease_preferences": {
 		requiredType: types.String,
 		setter: func(c *zonepb.ZoneConfig, d tree.Datum) {
@@ -136,6 +153,17 @@ var supportedZoneConfigOptions = map[tree.Name]struct {
 	},
 }
 
+// checkWitnessReplicasAllowed returns an error if witness replicas can't be
+// configured yet, since nodes running older versions wouldn't know how to
+// place them.
+func checkWitnessReplicasAllowed(ctx context.Context, execCfg *ExecutorConfig, _ tree.Datum) error {
+	if !execCfg.Settings.Version.IsActive(ctx, clusterversion.WitnessReplicas) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"witness replicas are not supported until the cluster version is finalized")
+	}
+	return nil
+}
+
 // zoneOptionKeys contains the keys from suportedZoneConfigOptions in
 // deterministic order. Needed to make the event log output
 // deterministic.
@@ -876,6 +904,11 @@ func accumulateUniqueConstraints(zone *zonepb.ZoneConfig) []zonepb.Constraint {
 			addToValidate(constraint)
 		}
 	}
+	for _, constraints := range zone.WitnessConstraints {
+		for _, constraint := range constraints.Constraints {
+			addToValidate(constraint)
+		}
+	}
 	for _, leasePreferences := range zone.LeasePreferences {
 		for _, constraint := range leasePreferences.Constraints {
 			addToValidate(constraint)
@@ -896,7 +929,8 @@ func validateZoneAttrsAndLocalities(
 	ctx context.Context, execCfg *ExecutorConfig, zone *zonepb.ZoneConfig,
 ) error {
 	// Avoid RPCs to the Node/Region server if we don't have anything to validate.
-	if len(zone.Constraints) == 0 && len(zone.VoterConstraints) == 0 && len(zone.LeasePreferences) == 0 {
+	if len(zone.Constraints) == 0 && len(zone.VoterConstraints) == 0 &&
+		len(zone.WitnessConstraints) == 0 && len(zone.LeasePreferences) == 0 {
 		return nil
 	}
 	if execCfg.Codec.ForSystemTenant() {
@@ -189,6 +189,14 @@ func zoneConfigToSQL(zs *tree.ZoneSpecifier, zone *zonepb.ZoneConfig) (string, e
 		return "", err
 	}
 	voterConstraints = strings.TrimSpace(voterConstraints)
+	witnessConstraints, err := yamlMarshalFlow(zonepb.ConstraintsList{
+		Constraints: zone.WitnessConstraints,
+		Inherited:   false,
+	})
+	if err != nil {
+		return "", err
+	}
+	witnessConstraints = strings.TrimSpace(witnessConstraints)
 	prefs, err := yamlMarshalFlow(zone.LeasePreferences)
 	if err != nil {
 		return "", err
@@ -239,6 +247,12 @@ func zoneConfigToSQL(zs *tree.ZoneSpecifier, zone *zonepb.ZoneConfig) (string, e
 		maybeWriteComma(f)
 		f.Printf("\tvoter_constraints = %s", lexbase.EscapeSQLString(voterConstraints))
 	}
+	if zone.NumWitnesses != nil {
+		maybeWriteComma(f)
+		f.Printf("\tnum_witnesses = %d", *zone.NumWitnesses)
+		maybeWriteComma(f)
+		f.Printf("\twitness_constraints = %s", lexbase.EscapeSQLString(witnessConstraints))
+	}
 	if !zone.InheritedLeasePreferences {
 		maybeWriteComma(f)
 		f.Printf("\tlease_preferences = %s", lexbase.EscapeSQLString(prefs))
@@ -162,6 +162,13 @@ func NewUndefinedRelationError(name tree.NodeFormatter) error {
 		"relation %q does not exist", tree.ErrString(name))
 }
 
+// NewTableReadOnlyError creates an error for a write to or a schema change of
+// a table that is currently read-only.
+func NewTableReadOnlyError(name, reason string) error {
+	return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+		"table %q is read-only: %s", name, reason)
+}
+
 // NewColumnAlreadyExistsError creates an error for a preexisting column.
 func NewColumnAlreadyExistsError(name, relation string) error {
 	return pgerror.Newf(pgcode.DuplicateColumn, "column %q of relation %q already exists", name, relation)
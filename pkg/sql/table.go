@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
@@ -245,6 +246,10 @@ func (p *planner) writeSchemaChange(
 		return errors.Errorf("no schema changes allowed on table %q as it is being dropped",
 			tableDesc.Name)
 	}
+	if reason := tableDesc.GetReadOnlyReason(); reason != "" {
+		// We don't allow schema changes on a table which is read-only.
+		return sqlerrors.NewTableReadOnlyError(tableDesc.Name, reason)
+	}
 	if !tableDesc.IsNew() {
 		if err := p.createOrUpdateSchemaChangeJob(ctx, tableDesc, jobDesc, mutationID); err != nil {
 			return err
@@ -264,6 +269,10 @@ func (p *planner) writeSchemaChangeToBatch(
 		return errors.Errorf("no schema changes allowed on table %q as it is being dropped",
 			tableDesc.Name)
 	}
+	if reason := tableDesc.GetReadOnlyReason(); reason != "" {
+		// We don't allow schema changes on a table which is read-only.
+		return sqlerrors.NewTableReadOnlyError(tableDesc.Name, reason)
+	}
 	return p.writeTableDescToBatch(ctx, tableDesc, b)
 }
 
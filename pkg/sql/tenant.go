@@ -572,3 +572,19 @@ func (p *planner) UpdateTenantResourceLimits(
 		availableRU, refillRate, maxBurstRU, asOf, asOfConsumedRequestUnits,
 	)
 }
+
+// UpdateTenantStorageQuota implements the tree.TenantOperator interface.
+func (p *planner) UpdateTenantStorageQuota(
+	ctx context.Context, tenantID uint64, quotaBytes int64,
+) error {
+	const op = "update-storage-quota"
+	if err := rejectIfCantCoordinateMultiTenancy(p.execCfg.Codec, op); err != nil {
+		return err
+	}
+	if err := rejectIfSystemTenant(tenantID, op); err != nil {
+		return err
+	}
+	return p.ExecCfg().TenantUsageServer.ReconfigureStorageQuota(
+		ctx, p.Txn(), roachpb.MakeTenantID(tenantID), quotaBytes,
+	)
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -1871,6 +1872,28 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	// CockroachDB extension.
+	`enable_durable_locking`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`enable_durable_locking`),
+		Set: func(ctx context.Context, m sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("enable_durable_locking", s)
+			if err != nil {
+				return err
+			}
+			if b && !m.settings.Version.IsActive(ctx, clusterversion.ReplicatedLocks) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"version %v must be finalized to use enable_durable_locking",
+					clusterversion.ByKey(clusterversion.ReplicatedLocks))
+			}
+			m.SetDurableLockingEnabled(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().DurableLockingEnabled), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
 	// TODO(harding): Remove this when costing scans based on average column size
 	// is fully supported.
 	// CockroachDB extension.
@@ -194,6 +194,19 @@ type MVCCIterator interface {
 	// SupportsPrev returns true if MVCCIterator implementation supports reverse
 	// iteration with Prev() or SeekLT().
 	SupportsPrev() bool
+
+	// HasPointAndRange returns whether the current iterator position has a
+	// point key and/or a range key. If Valid() returns true, one of these will
+	// be true. Iterators that were not created with IterOptions.KeyTypes
+	// including range keys always return true, false.
+	HasPointAndRange() (bool, bool)
+	// RangeBounds returns the range bounds for the current range key fragment,
+	// if any. The returned span is only valid until the next positioning call.
+	RangeBounds() roachpb.Span
+	// RangeKeys returns all range key fragments (at different timestamps) at
+	// the current iterator position, ordered by descending timestamp. The
+	// returned keys and values are only valid until the next positioning call.
+	RangeKeys() []MVCCRangeKeyValue
 }
 
 // EngineIterator is an iterator over key-value pairs where the key is
@@ -309,8 +322,28 @@ type IterOptions struct {
 	// use such an iterator is to use it in concert with an iterator without
 	// timestamp hints, as done by MVCCIncrementalIterator.
 	MinTimestampHint, MaxTimestampHint hlc.Timestamp
+	// KeyTypes specifies the types of keys to surface: point and/or range keys.
+	// Use HasPointAndRange() to determine which key type is present at a given
+	// iterator position, and RangeBounds() and RangeKeys() to access range
+	// keys. Defaults to IterKeyTypePointsOnly.
+	//
+	// Range keys are only supported by MVCCKeyIterKind iterators, and iterators
+	// that surface them are never reused by a Reader.
+	KeyTypes IterKeyType
 }
 
+// IterKeyType configures which types of keys an iterator should surface.
+type IterKeyType = pebble.IterKeyType
+
+const (
+	// IterKeyTypePointsOnly iterates over point keys only.
+	IterKeyTypePointsOnly = pebble.IterKeyTypePointsOnly
+	// IterKeyTypePointsAndRanges iterates over both point and range keys.
+	IterKeyTypePointsAndRanges = pebble.IterKeyTypePointsAndRanges
+	// IterKeyTypeRangesOnly iterates over only range keys.
+	IterKeyTypeRangesOnly = pebble.IterKeyTypeRangesOnly
+)
+
 // MVCCIterKind is used to inform Reader about the kind of iteration desired
 // by the caller.
 type MVCCIterKind int
@@ -575,6 +608,37 @@ type Writer interface {
 	// returns.
 	ClearIterRange(iter MVCCIterator, start, end roachpb.Key) error
 
+	// ExperimentalClearMVCCRangeKey deletes an MVCC range key from start
+	// (inclusive) to end (exclusive) at the given timestamp. For any range key
+	// that straddles the start and end boundaries, only the segments within
+	// the boundaries will be cleared. Range keys at other timestamps are
+	// unaffected. Clears are idempotent.
+	//
+	// This method is primarily intended for MVCC garbage collection and
+	// similar internal use.
+	//
+	// This method is EXPERIMENTAL: range keys are under active development, and
+	// have severe limitations including being ignored by all KV and MVCC APIs
+	// except those that explicitly handle them. They must only be written as
+	// MVCC range tombstones via MVCCDeleteRangeUsingTombstone.
+	ExperimentalClearMVCCRangeKey(rangeKey MVCCRangeKey) error
+
+	// ExperimentalClearAllMVCCRangeKeys deletes all MVCC range keys (i.e. all
+	// versions) from start (inclusive) to end (exclusive). For any range key
+	// that straddles the start and end boundaries, only the segments within the
+	// boundaries will be cleared. Clears are idempotent.
+	//
+	// This method is EXPERIMENTAL, see ExperimentalClearMVCCRangeKey.
+	ExperimentalClearAllMVCCRangeKeys(start, end roachpb.Key) error
+
+	// ExperimentalPutMVCCRangeKey writes a value to an MVCC range key. It is
+	// currently only used for range tombstones, which have an empty value.
+	// The range key is not checked for conflicts with other writes, callers
+	// must use MVCCDeleteRangeUsingTombstone for that.
+	//
+	// This method is EXPERIMENTAL, see ExperimentalClearMVCCRangeKey.
+	ExperimentalPutMVCCRangeKey(rangeKey MVCCRangeKey, value []byte) error
+
 	// Merge is a high-performance write operation used for values which are
 	// accumulated over several writes. Multiple values can be merged
 	// sequentially into a single key; a subsequent read will return a "merged"
@@ -902,7 +966,8 @@ func Scan(reader Reader, start, end roachpb.Key, max int64) ([]MVCCKeyValue, err
 }
 
 // ScanIntents scans intents using only the separated intents lock table. It
-// does not take interleaved intents into account at all.
+// does not take interleaved intents into account at all. Replicated locks that
+// are not intents are ignored.
 func ScanIntents(
 	ctx context.Context, reader Reader, start, end roachpb.Key, maxIntents int64, targetBytes int64,
 ) ([]roachpb.Intent, error) {
@@ -935,6 +1000,9 @@ func ScanIntents(
 		if err != nil {
 			return nil, err
 		}
+		if !isIntentLockTableKeyVersion(key.Version) {
+			continue
+		}
 		lockedKey, err := keys.DecodeLockTableSingleKey(key.Key)
 		if err != nil {
 			return nil, err
@@ -174,9 +174,10 @@ func (k EngineKey) ToLockTableKey() (LockTableKey, error) {
 	key := LockTableKey{Key: lockedKey}
 	switch len(k.Version) {
 	case engineKeyVersionLockTableLen:
-		key.Strength = lock.Strength(k.Version[0])
-		if key.Strength < lock.None || key.Strength > lock.Exclusive {
-			return LockTableKey{}, errors.Errorf("unknown strength %d", key.Strength)
+		var ok bool
+		key.Strength, ok = getReplicatedLockStrengthForByte(k.Version[0])
+		if !ok {
+			return LockTableKey{}, errors.Errorf("unknown strength %d", k.Version[0])
 		}
 		key.TxnUUID = k.Version[1:]
 	default:
@@ -250,6 +251,43 @@ func (m EngineKeyFormatter) Format(f fmt.State, c rune) {
 	m.key.Format(f, c)
 }
 
+// The byte that represents the strength of a lock in the version of a lock
+// table key. Intents were stored under the byte value of lock.Exclusive before
+// Replicated locks could be acquired without writing an intent, so they keep
+// that encoding. Older binaries cannot decode replicatedLockExclusiveByte, so
+// it is only written once the ReplicatedLocks cluster version is active.
+const (
+	replicatedLockExclusiveByte byte = 2
+	replicatedLockIntentByte    byte = 3
+)
+
+// getByteForReplicatedLockStrength returns the byte that encodes the provided
+// lock strength in a lock table key. Only Exclusive locks and intents can be
+// stored in the replicated lock table.
+func getByteForReplicatedLockStrength(str lock.Strength) (byte, bool) {
+	switch str {
+	case lock.Exclusive:
+		return replicatedLockExclusiveByte, true
+	case lock.Intent:
+		return replicatedLockIntentByte, true
+	default:
+		return 0, false
+	}
+}
+
+// getReplicatedLockStrengthForByte is the inverse of
+// getByteForReplicatedLockStrength.
+func getReplicatedLockStrengthForByte(b byte) (lock.Strength, bool) {
+	switch b {
+	case replicatedLockExclusiveByte:
+		return lock.Exclusive, true
+	case replicatedLockIntentByte:
+		return lock.Intent, true
+	default:
+		return 0, false
+	}
+}
+
 // LockTableKey is a key representing a lock in the lock table.
 type LockTableKey struct {
 	Key      roachpb.Key
@@ -266,7 +304,8 @@ func (lk LockTableKey) ToEngineKey(buf []byte) (EngineKey, []byte) {
 	if len(lk.TxnUUID) != uuid.Size {
 		panic("invalid TxnUUID")
 	}
-	if lk.Strength != lock.Exclusive {
+	strByte, ok := getByteForReplicatedLockStrength(lk.Strength)
+	if !ok {
 		panic("unsupported lock strength")
 	}
 	// The first term in estimatedLen is for LockTableSingleKey.
@@ -284,7 +323,7 @@ func (lk LockTableKey) ToEngineKey(buf []byte) (EngineKey, []byte) {
 		// estimatedLen was an underestimate.
 		k.Version = make([]byte, engineKeyVersionLockTableLen)
 	}
-	k.Version[0] = byte(lk.Strength)
+	k.Version[0] = strByte
 	copy(k.Version[1:], lk.TxnUUID)
 	return k, buf
 }
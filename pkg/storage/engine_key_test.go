@@ -34,11 +34,11 @@ func TestLockTableKeyEncodeDecode(t *testing.T) {
 		key LockTableKey
 	}{
 		{key: LockTableKey{Key: roachpb.Key("foo"), Strength: lock.Exclusive, TxnUUID: uuid1[:]}},
-		{key: LockTableKey{Key: roachpb.Key("a"), Strength: lock.Exclusive, TxnUUID: uuid2[:]}},
+		{key: LockTableKey{Key: roachpb.Key("a"), Strength: lock.Intent, TxnUUID: uuid2[:]}},
 		// Causes a doubly-local range local key.
 		{key: LockTableKey{
 			Key:      keys.RangeDescriptorKey(roachpb.RKey("baz")),
-			Strength: lock.Exclusive,
+			Strength: lock.Intent,
 			TxnUUID:  uuid1[:]}},
 	}
 	buf := make([]byte, 100)
@@ -150,7 +150,7 @@ func TestEngineKeyValidate(t *testing.T) {
 		{
 			key: LockTableKey{
 				Key:      keys.RangeDescriptorKey(roachpb.RKey("bar")),
-				Strength: lock.Exclusive,
+				Strength: lock.Intent,
 				TxnUUID:  uuid1[:],
 			},
 		},
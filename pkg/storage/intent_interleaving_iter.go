@@ -41,7 +41,9 @@ const (
 //   However, for a particular roachpb.Key there will be at most one intent,
 //   either interleaved or separated.
 // - An intent will have a corresponding provisional value.
-// - The only single key locks in the lock table key space are intents.
+// - The only single key locks in the lock table key space that are visible
+//   to this iterator are intents. Replicated locks that are not intents are
+//   hidden by a lockTableIntentIter.
 //
 // Semantically, the functionality is equivalent to merging two MVCCIterators:
 // - A MVCCIterator on the MVCC key space.
@@ -152,6 +154,9 @@ func newIntentInterleavingIterator(reader Reader, opts IterOptions) MVCCIterator
 	if !opts.MinTimestampHint.IsEmpty() || !opts.MaxTimestampHint.IsEmpty() {
 		panic("intentInterleavingIter must not be used with timestamp hints")
 	}
+	if opts.KeyTypes != IterKeyTypePointsOnly {
+		panic("intentInterleavingIter must not be used with range keys")
+	}
 	var lowerIsLocal, upperIsLocal bool
 	var constraint intentInterleavingIterConstraint
 	if opts.LowerBound != nil {
@@ -223,7 +228,7 @@ func newIntentInterleavingIterator(reader Reader, opts IterOptions) MVCCIterator
 	}
 	// Note that we can reuse intentKeyBuf, intentLimitKeyBuf after
 	// NewEngineIterator returns.
-	intentIter := reader.NewEngineIterator(intentOpts)
+	intentIter := newLockTableIntentIter(reader.NewEngineIterator(intentOpts))
 
 	// The creation of these iterators can race with concurrent mutations, which
 	// may make them inconsistent with each other. So we clone here, to ensure
@@ -350,7 +355,7 @@ func (i *intentInterleavingIter) SeekIntentGE(key roachpb.Key, txnUUID uuid.UUID
 	var engineKey EngineKey
 	engineKey, i.intentKeyBuf = LockTableKey{
 		Key:      key,
-		Strength: lock.Exclusive,
+		Strength: lock.Intent,
 		TxnUUID:  txnUUID[:],
 	}.ToEngineKey(i.intentKeyBuf)
 	var limitKey roachpb.Key
@@ -971,6 +976,22 @@ func (i *intentInterleavingIter) SupportsPrev() bool {
 	return true
 }
 
+// HasPointAndRange implements the MVCCIterator interface. Range keys are not
+// supported by intentInterleavingIter, so this always returns true, false.
+func (i *intentInterleavingIter) HasPointAndRange() (bool, bool) {
+	return true, false
+}
+
+// RangeBounds implements the MVCCIterator interface.
+func (i *intentInterleavingIter) RangeBounds() roachpb.Span {
+	return roachpb.Span{}
+}
+
+// RangeKeys implements the MVCCIterator interface.
+func (i *intentInterleavingIter) RangeKeys() []MVCCRangeKeyValue {
+	return nil
+}
+
 // newMVCCIteratorByCloningEngineIter assumes MVCCKeyIterKind and no timestamp
 // hints. It uses pebble.Iterator.Clone to ensure that the two iterators see
 // the identical engine state.
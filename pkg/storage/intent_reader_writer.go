@@ -44,7 +44,7 @@ func (idw intentDemuxWriter) ClearIntent(
 	var engineKey EngineKey
 	engineKey, buf = LockTableKey{
 		Key:      key,
-		Strength: lock.Exclusive,
+		Strength: lock.Intent,
 		TxnUUID:  txnUUID[:],
 	}.ToEngineKey(buf)
 	if txnDidNotUpdateMeta {
@@ -62,7 +62,7 @@ func (idw intentDemuxWriter) PutIntent(
 	var engineKey EngineKey
 	engineKey, buf = LockTableKey{
 		Key:      key,
-		Strength: lock.Exclusive,
+		Strength: lock.Intent,
 		TxnUUID:  txnUUID[:],
 	}.ToEngineKey(buf)
 	return buf, idw.w.PutEngineKey(engineKey, value)
@@ -136,7 +136,8 @@ func (imr *intentInterleavingReader) MVCCGet(key MVCCKey) ([]byte, error) {
 	// Get is not efficient, but this function is deprecated and only used for
 	// tests, so we don't care.
 	ltKey, _ := keys.LockTableSingleKey(key.Key, nil)
-	iter := imr.wrappableReader.NewEngineIterator(IterOptions{Prefix: true, LowerBound: ltKey})
+	iter := newLockTableIntentIter(
+		imr.wrappableReader.NewEngineIterator(IterOptions{Prefix: true, LowerBound: ltKey}))
 	defer iter.Close()
 	valid, err := iter.SeekEngineKeyGE(EngineKey{Key: ltKey})
 	if !valid || err != nil {
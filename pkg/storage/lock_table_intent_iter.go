@@ -0,0 +1,166 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storage
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/pebble"
+)
+
+// lockTableIntentIter wraps an EngineIterator over the lock table key space
+// and hides all of the locks in it that are not intents. Replicated locks that
+// were acquired without writing an intent (see MVCCAcquireLock) have no
+// provisional value, so they must be invisible to users of the lock table
+// that interpret its contents as intents, like the intentInterleavingIter.
+//
+// Replicated locks that are not intents are expected to be rare, so skipping
+// over them is not optimized.
+type lockTableIntentIter struct {
+	EngineIterator
+}
+
+var _ EngineIterator = &lockTableIntentIter{}
+
+func newLockTableIntentIter(iter EngineIterator) *lockTableIntentIter {
+	return &lockTableIntentIter{EngineIterator: iter}
+}
+
+// isIntentLockTableKeyVersion returns whether the version of a lock table key
+// encodes an intent.
+func isIntentLockTableKeyVersion(version []byte) bool {
+	return len(version) == engineKeyVersionLockTableLen && version[0] == replicatedLockIntentByte
+}
+
+func (i *lockTableIntentIter) atIntent() (bool, error) {
+	key, err := i.EngineIterator.UnsafeEngineKey()
+	if err != nil {
+		return false, err
+	}
+	return isIntentLockTableKeyVersion(key.Version), nil
+}
+
+// skipForward steps the iterator forward until it is positioned at an intent
+// or is exhausted.
+func (i *lockTableIntentIter) skipForward(valid bool, err error) (bool, error) {
+	for valid && err == nil {
+		var isIntent bool
+		if isIntent, err = i.atIntent(); err != nil || isIntent {
+			break
+		}
+		valid, err = i.EngineIterator.NextEngineKey()
+	}
+	if err != nil {
+		return false, err
+	}
+	return valid, nil
+}
+
+// skipBackward steps the iterator backward until it is positioned at an
+// intent or is exhausted.
+func (i *lockTableIntentIter) skipBackward(valid bool, err error) (bool, error) {
+	for valid && err == nil {
+		var isIntent bool
+		if isIntent, err = i.atIntent(); err != nil || isIntent {
+			break
+		}
+		valid, err = i.EngineIterator.PrevEngineKey()
+	}
+	if err != nil {
+		return false, err
+	}
+	return valid, nil
+}
+
+// skipForwardWithLimit is like skipForward, but respects the limit.
+func (i *lockTableIntentIter) skipForwardWithLimit(
+	state pebble.IterValidityState, err error, limit roachpb.Key,
+) (pebble.IterValidityState, error) {
+	for state == pebble.IterValid && err == nil {
+		var isIntent bool
+		if isIntent, err = i.atIntent(); err != nil || isIntent {
+			break
+		}
+		state, err = i.EngineIterator.NextEngineKeyWithLimit(limit)
+	}
+	if err != nil {
+		return pebble.IterExhausted, err
+	}
+	return state, nil
+}
+
+// skipBackwardWithLimit is like skipBackward, but respects the limit.
+func (i *lockTableIntentIter) skipBackwardWithLimit(
+	state pebble.IterValidityState, err error, limit roachpb.Key,
+) (pebble.IterValidityState, error) {
+	for state == pebble.IterValid && err == nil {
+		var isIntent bool
+		if isIntent, err = i.atIntent(); err != nil || isIntent {
+			break
+		}
+		state, err = i.EngineIterator.PrevEngineKeyWithLimit(limit)
+	}
+	if err != nil {
+		return pebble.IterExhausted, err
+	}
+	return state, nil
+}
+
+// SeekEngineKeyGE implements the EngineIterator interface.
+func (i *lockTableIntentIter) SeekEngineKeyGE(key EngineKey) (valid bool, err error) {
+	return i.skipForward(i.EngineIterator.SeekEngineKeyGE(key))
+}
+
+// SeekEngineKeyLT implements the EngineIterator interface.
+func (i *lockTableIntentIter) SeekEngineKeyLT(key EngineKey) (valid bool, err error) {
+	return i.skipBackward(i.EngineIterator.SeekEngineKeyLT(key))
+}
+
+// NextEngineKey implements the EngineIterator interface.
+func (i *lockTableIntentIter) NextEngineKey() (valid bool, err error) {
+	return i.skipForward(i.EngineIterator.NextEngineKey())
+}
+
+// PrevEngineKey implements the EngineIterator interface.
+func (i *lockTableIntentIter) PrevEngineKey() (valid bool, err error) {
+	return i.skipBackward(i.EngineIterator.PrevEngineKey())
+}
+
+// SeekEngineKeyGEWithLimit implements the EngineIterator interface.
+func (i *lockTableIntentIter) SeekEngineKeyGEWithLimit(
+	key EngineKey, limit roachpb.Key,
+) (state pebble.IterValidityState, err error) {
+	state, err = i.EngineIterator.SeekEngineKeyGEWithLimit(key, limit)
+	return i.skipForwardWithLimit(state, err, limit)
+}
+
+// SeekEngineKeyLTWithLimit implements the EngineIterator interface.
+func (i *lockTableIntentIter) SeekEngineKeyLTWithLimit(
+	key EngineKey, limit roachpb.Key,
+) (state pebble.IterValidityState, err error) {
+	state, err = i.EngineIterator.SeekEngineKeyLTWithLimit(key, limit)
+	return i.skipBackwardWithLimit(state, err, limit)
+}
+
+// NextEngineKeyWithLimit implements the EngineIterator interface.
+func (i *lockTableIntentIter) NextEngineKeyWithLimit(
+	limit roachpb.Key,
+) (state pebble.IterValidityState, err error) {
+	state, err = i.EngineIterator.NextEngineKeyWithLimit(limit)
+	return i.skipForwardWithLimit(state, err, limit)
+}
+
+// PrevEngineKeyWithLimit implements the EngineIterator interface.
+func (i *lockTableIntentIter) PrevEngineKeyWithLimit(
+	limit roachpb.Key,
+) (state pebble.IterValidityState, err error) {
+	state, err = i.EngineIterator.PrevEngineKeyWithLimit(limit)
+	return i.skipBackwardWithLimit(state, err, limit)
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/uncertainty"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
@@ -33,6 +34,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
 )
@@ -724,15 +726,20 @@ func newMVCCIterator(reader Reader, inlineMeta bool, opts IterOptions) MVCCItera
 func MVCCGet(
 	ctx context.Context, reader Reader, key roachpb.Key, timestamp hlc.Timestamp, opts MVCCGetOptions,
 ) (*roachpb.Value, *roachpb.Intent, error) {
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(reader, key, nil, timestamp)
+	if err != nil {
+		return nil, nil, err
+	}
 	iter := newMVCCIterator(reader, timestamp.IsEmpty(), IterOptions{Prefix: true})
 	defer iter.Close()
-	value, intent, err := mvccGet(ctx, iter, key, timestamp, opts)
+	value, intent, err := mvccGet(ctx, iter, rangeTombstones, key, timestamp, opts)
 	return value.ToPointer(), intent, err
 }
 
 func mvccGet(
 	ctx context.Context,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
 	opts MVCCGetOptions,
@@ -754,15 +761,17 @@ func mvccGet(
 	// specify an empty key for the end key which will ensure we don't retrieve a
 	// key different than the start key. This is a bit of a hack.
 	*mvccScanner = pebbleMVCCScanner{
-		parent:           iter,
-		memAccount:       opts.MemoryAccount,
-		start:            key,
-		ts:               timestamp,
-		maxKeys:          1,
-		inconsistent:     opts.Inconsistent,
-		tombstones:       opts.Tombstones,
-		failOnMoreRecent: opts.FailOnMoreRecent,
-		keyBuf:           mvccScanner.keyBuf,
+		parent:            iter,
+		memAccount:        opts.MemoryAccount,
+		start:             key,
+		ts:                timestamp,
+		maxKeys:           1,
+		inconsistent:      opts.Inconsistent,
+		tombstones:        opts.Tombstones,
+		failOnMoreRecent:  opts.FailOnMoreRecent,
+		keyBuf:            mvccScanner.keyBuf,
+		rangeTombstones:   rangeTombstones,
+		rangeTombstoneBuf: mvccScanner.rangeTombstoneBuf,
 	}
 
 	mvccScanner.init(opts.Txn, opts.Uncertainty, 0)
@@ -993,12 +1002,20 @@ func MVCCPut(
 	// If we're not tracking stats for the key and we're writing a non-versioned
 	// key we can utilize a blind put to avoid reading any existing value.
 	var iter MVCCIterator
+	var rangeTombstones *MVCCRangeTombstones
 	blind := ms == nil && timestamp.IsEmpty()
 	if !blind {
+		if err := mvccCheckWriteForReplicatedLockConflicts(rw, key, timestamp, txn); err != nil {
+			return err
+		}
+		var err error
+		if rangeTombstones, err = maybeReadMVCCRangeTombstones(rw, key, nil, timestamp); err != nil {
+			return err
+		}
 		iter = rw.NewMVCCIterator(MVCCKeyAndIntentsIterKind, IterOptions{Prefix: true})
 		defer iter.Close()
 	}
-	return mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, value, txn, nil /* valueFn */)
+	return mvccPutUsingIter(ctx, rw, iter, rangeTombstones, ms, key, timestamp, value, txn, nil /* valueFn */)
 }
 
 // MVCCBlindPut is a fast-path of MVCCPut. See the MVCCPut comments for details
@@ -1020,7 +1037,7 @@ func MVCCBlindPut(
 	value roachpb.Value,
 	txn *roachpb.Transaction,
 ) error {
-	return mvccPutUsingIter(ctx, writer, nil, ms, key, timestamp, value, txn, nil /* valueFn */)
+	return mvccPutUsingIter(ctx, writer, nil, nil, ms, key, timestamp, value, txn, nil /* valueFn */)
 }
 
 // MVCCDelete marks the key deleted so that it will not be returned in
@@ -1037,22 +1054,31 @@ func MVCCDelete(
 	timestamp hlc.Timestamp,
 	txn *roachpb.Transaction,
 ) error {
+	if err := mvccCheckWriteForReplicatedLockConflicts(rw, key, timestamp, txn); err != nil {
+		return err
+	}
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(rw, key, nil, timestamp)
+	if err != nil {
+		return err
+	}
 	iter := newMVCCIterator(rw, timestamp.IsEmpty(), IterOptions{Prefix: true})
 	defer iter.Close()
 
-	return mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, noValue, txn, nil /* valueFn */)
+	return mvccPutUsingIter(ctx, rw, iter, rangeTombstones, ms, key, timestamp, noValue, txn, nil /* valueFn */)
 }
 
 var noValue = roachpb.Value{}
 
 // mvccPutUsingIter sets the value for a specified key using the provided
-// MVCCIterator. The function takes a value and a valueFn, only one of which
-// should be provided. If the valueFn is nil, value's raw bytes will be set
-// for the key, else the bytes provided by the valueFn will be used.
+// MVCCIterator and the MVCC range tombstones covering the key. The function
+// takes a value and a valueFn, only one of which should be provided. If the
+// valueFn is nil, value's raw bytes will be set for the key, else the bytes
+// provided by the valueFn will be used.
 func mvccPutUsingIter(
 	ctx context.Context,
 	writer Writer,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	ms *enginepb.MVCCStats,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -1070,7 +1096,7 @@ func mvccPutUsingIter(
 
 	buf := newPutBuffer()
 
-	err := mvccPutInternal(ctx, writer, iter, ms, key, timestamp, rawBytes,
+	err := mvccPutInternal(ctx, writer, iter, rangeTombstones, ms, key, timestamp, rawBytes,
 		txn, buf, valueFn)
 
 	// Using defer would be more convenient, but it is measurably slower.
@@ -1088,6 +1114,7 @@ func mvccPutUsingIter(
 func maybeGetValue(
 	ctx context.Context,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	key roachpb.Key,
 	value []byte,
 	exists bool,
@@ -1101,7 +1128,7 @@ func maybeGetValue(
 	var exVal optionalValue
 	if exists {
 		var err error
-		exVal, _, err = mvccGet(ctx, iter, key, readTimestamp, MVCCGetOptions{Tombstones: true})
+		exVal, _, err = mvccGet(ctx, iter, rangeTombstones, key, readTimestamp, MVCCGetOptions{Tombstones: true})
 		if err != nil {
 			return nil, err
 		}
@@ -1156,6 +1183,7 @@ func MVCCScanDecodeKeyValues(repr [][]byte, fn func(key MVCCKey, rawBytes []byte
 func replayTransactionalWrite(
 	ctx context.Context,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	meta *enginepb.MVCCMetadata,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -1170,7 +1198,7 @@ func replayTransactionalWrite(
 		// This is a special case. This is when the intent hasn't made it
 		// to the intent history yet. We must now assert the value written
 		// in the intent to the value we're trying to write.
-		exVal, _, err := mvccGet(ctx, iter, key, timestamp, MVCCGetOptions{Txn: txn, Tombstones: true})
+		exVal, _, err := mvccGet(ctx, iter, rangeTombstones, key, timestamp, MVCCGetOptions{Txn: txn, Tombstones: true})
 		if err != nil {
 			return err
 		}
@@ -1216,7 +1244,7 @@ func replayTransactionalWrite(
 			// last committed value on the key. Since we want the last committed
 			// value on the key, we must make an inconsistent read so we ignore
 			// our previous intents here.
-			exVal, _, err = mvccGet(ctx, iter, key, timestamp, MVCCGetOptions{Inconsistent: true, Tombstones: true})
+			exVal, _, err = mvccGet(ctx, iter, rangeTombstones, key, timestamp, MVCCGetOptions{Inconsistent: true, Tombstones: true})
 			if err != nil {
 				return err
 			}
@@ -1266,6 +1294,7 @@ func mvccPutInternal(
 	ctx context.Context,
 	writer Writer,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	ms *enginepb.MVCCStats,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -1302,7 +1331,7 @@ func mvccPutInternal(
 			return errors.Errorf("%q: inline writes not allowed within transactions", metaKey)
 		}
 		var metaKeySize, metaValSize int64
-		if value, err = maybeGetValue(ctx, iter, key, value, ok, timestamp, valueFn); err != nil {
+		if value, err = maybeGetValue(ctx, iter, rangeTombstones, key, value, ok, timestamp, valueFn); err != nil {
 			return err
 		}
 		if value == nil {
@@ -1356,6 +1385,20 @@ func mvccPutInternal(
 
 	var maybeTooOldErr error
 	var prevValSize int64
+	if !ok || buf.meta.Txn == nil {
+		// An MVCC range tombstone at or above the read timestamp is handled like
+		// a committed point tombstone (see below): we write above it and return
+		// a write-too-old error. Intents can't exist below range tombstones, since
+		// writing a range tombstone checks for conflicting intents, so we don't
+		// have to consider them when replacing our own intent.
+		if rangeTS, found := rangeTombstones.Newest(key); found && readTimestamp.LessEq(rangeTS) {
+			writeTimestamp.Forward(rangeTS.Next())
+			maybeTooOldErr = roachpb.NewWriteTooOldError(readTimestamp, writeTimestamp, key)
+			if txn == nil {
+				readTimestamp = writeTimestamp
+			}
+		}
+	}
 	if ok {
 		// There is existing metadata for this key; ensure our write is permitted.
 		meta = &buf.meta
@@ -1376,7 +1419,7 @@ func mvccPutInternal(
 				// The transaction has executed at this sequence before. This is merely a
 				// replay of the transactional write. Assert that all is in order and return
 				// early.
-				return replayTransactionalWrite(ctx, iter, meta, key, readTimestamp, value, txn, valueFn)
+				return replayTransactionalWrite(ctx, iter, rangeTombstones, meta, key, readTimestamp, value, txn, valueFn)
 			}
 
 			// We're overwriting the intent that was present at this key, before we do
@@ -1408,7 +1451,7 @@ func mvccPutInternal(
 				if !enginepb.TxnSeqIsIgnored(meta.Txn.Sequence, txn.IgnoredSeqNums) {
 					// Seqnum of last write is not ignored. Retrieve the value
 					// using a consistent read.
-					exVal, _, err = mvccGet(ctx, iter, key, readTimestamp, MVCCGetOptions{Txn: txn, Tombstones: true})
+					exVal, _, err = mvccGet(ctx, iter, rangeTombstones, key, readTimestamp, MVCCGetOptions{Txn: txn, Tombstones: true})
 					if err != nil {
 						return err
 					}
@@ -1429,7 +1472,7 @@ func mvccPutInternal(
 				//
 				// Since we want the last committed value on the key, we must make
 				// an inconsistent read so we ignore our previous intents here.
-				exVal, _, err = mvccGet(ctx, iter, key, readTimestamp, MVCCGetOptions{Inconsistent: true, Tombstones: true})
+				exVal, _, err = mvccGet(ctx, iter, rangeTombstones, key, readTimestamp, MVCCGetOptions{Inconsistent: true, Tombstones: true})
 				if err != nil {
 					return err
 				}
@@ -1541,11 +1584,11 @@ func mvccPutInternal(
 			if txn == nil {
 				readTimestamp = writeTimestamp
 			}
-			if value, err = maybeGetValue(ctx, iter, key, value, ok, readTimestamp, valueFn); err != nil {
+			if value, err = maybeGetValue(ctx, iter, rangeTombstones, key, value, ok, readTimestamp, valueFn); err != nil {
 				return err
 			}
 		} else {
-			if value, err = maybeGetValue(ctx, iter, key, value, ok, readTimestamp, valueFn); err != nil {
+			if value, err = maybeGetValue(ctx, iter, rangeTombstones, key, value, ok, readTimestamp, valueFn); err != nil {
 				return err
 			}
 		}
@@ -1622,8 +1665,20 @@ func mvccPutInternal(
 
 	// Update MVCC stats.
 	if ms != nil {
+		origMeta := meta
+		if meta != nil && meta.Txn == nil && !meta.Deleted {
+			// If the existing version was deleted by an MVCC range tombstone, it
+			// must be accounted for as if it had a point tombstone at the range
+			// tombstone's timestamp.
+			if deletedAt, found := rangeTombstones.DeletedAt(key, meta.Timestamp.ToTimestamp()); found {
+				deletedMeta := *meta
+				deletedMeta.Deleted = true
+				deletedMeta.Timestamp = deletedAt.ToLegacyTimestamp()
+				origMeta = &deletedMeta
+			}
+		}
 		ms.Add(updateStatsOnPut(key, prevValSize, origMetaKeySize, origMetaValSize,
-			metaKeySize, metaValSize, meta, newMeta))
+			metaKeySize, metaValSize, origMeta, newMeta))
 	}
 
 	// Log the logical MVCC operation.
@@ -1659,12 +1714,19 @@ func MVCCIncrement(
 	txn *roachpb.Transaction,
 	inc int64,
 ) (int64, error) {
+	if err := mvccCheckWriteForReplicatedLockConflicts(rw, key, timestamp, txn); err != nil {
+		return 0, err
+	}
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(rw, key, nil, timestamp)
+	if err != nil {
+		return 0, err
+	}
 	iter := newMVCCIterator(rw, timestamp.IsEmpty(), IterOptions{Prefix: true})
 	defer iter.Close()
 
 	var int64Val int64
 	var newInt64Val int64
-	err := mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, noValue, txn, func(value optionalValue) ([]byte, error) {
+	err = mvccPutUsingIter(ctx, rw, iter, rangeTombstones, ms, key, timestamp, noValue, txn, func(value optionalValue) ([]byte, error) {
 		if value.IsPresent() {
 			var err error
 			if int64Val, err = value.GetInt(); err != nil {
@@ -1731,10 +1793,17 @@ func MVCCConditionalPut(
 	allowIfDoesNotExist CPutMissingBehavior,
 	txn *roachpb.Transaction,
 ) error {
+	if err := mvccCheckWriteForReplicatedLockConflicts(rw, key, timestamp, txn); err != nil {
+		return err
+	}
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(rw, key, nil, timestamp)
+	if err != nil {
+		return err
+	}
 	iter := newMVCCIterator(rw, timestamp.IsEmpty(), IterOptions{Prefix: true})
 	defer iter.Close()
 
-	return mvccConditionalPutUsingIter(ctx, rw, iter, ms, key, timestamp, value, expVal, allowIfDoesNotExist, txn)
+	return mvccConditionalPutUsingIter(ctx, rw, iter, rangeTombstones, ms, key, timestamp, value, expVal, allowIfDoesNotExist, txn)
 }
 
 // MVCCBlindConditionalPut is a fast-path of MVCCConditionalPut. See the
@@ -1757,13 +1826,14 @@ func MVCCBlindConditionalPut(
 	allowIfDoesNotExist CPutMissingBehavior,
 	txn *roachpb.Transaction,
 ) error {
-	return mvccConditionalPutUsingIter(ctx, writer, nil, ms, key, timestamp, value, expVal, allowIfDoesNotExist, txn)
+	return mvccConditionalPutUsingIter(ctx, writer, nil, nil, ms, key, timestamp, value, expVal, allowIfDoesNotExist, txn)
 }
 
 func mvccConditionalPutUsingIter(
 	ctx context.Context,
 	writer Writer,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	ms *enginepb.MVCCStats,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -1773,7 +1843,7 @@ func mvccConditionalPutUsingIter(
 	txn *roachpb.Transaction,
 ) error {
 	return mvccPutUsingIter(
-		ctx, writer, iter, ms, key, timestamp, noValue, txn,
+		ctx, writer, iter, rangeTombstones, ms, key, timestamp, noValue, txn,
 		func(existVal optionalValue) ([]byte, error) {
 			if expValPresent, existValPresent := len(expBytes) != 0, existVal.IsPresent(); expValPresent && existValPresent {
 				if !bytes.Equal(expBytes, existVal.TagAndDataBytes()) {
@@ -1809,9 +1879,16 @@ func MVCCInitPut(
 	failOnTombstones bool,
 	txn *roachpb.Transaction,
 ) error {
+	if err := mvccCheckWriteForReplicatedLockConflicts(rw, key, timestamp, txn); err != nil {
+		return err
+	}
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(rw, key, nil, timestamp)
+	if err != nil {
+		return err
+	}
 	iter := newMVCCIterator(rw, timestamp.IsEmpty(), IterOptions{Prefix: true})
 	defer iter.Close()
-	return mvccInitPutUsingIter(ctx, rw, iter, ms, key, timestamp, value, failOnTombstones, txn)
+	return mvccInitPutUsingIter(ctx, rw, iter, rangeTombstones, ms, key, timestamp, value, failOnTombstones, txn)
 }
 
 // MVCCBlindInitPut is a fast-path of MVCCInitPut. See the MVCCInitPut
@@ -1832,13 +1909,14 @@ func MVCCBlindInitPut(
 	failOnTombstones bool,
 	txn *roachpb.Transaction,
 ) error {
-	return mvccInitPutUsingIter(ctx, rw, nil, ms, key, timestamp, value, failOnTombstones, txn)
+	return mvccInitPutUsingIter(ctx, rw, nil, nil, ms, key, timestamp, value, failOnTombstones, txn)
 }
 
 func mvccInitPutUsingIter(
 	ctx context.Context,
 	rw ReadWriter,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	ms *enginepb.MVCCStats,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -1847,7 +1925,7 @@ func mvccInitPutUsingIter(
 	txn *roachpb.Transaction,
 ) error {
 	return mvccPutUsingIter(
-		ctx, rw, iter, ms, key, timestamp, noValue, txn,
+		ctx, rw, iter, rangeTombstones, ms, key, timestamp, noValue, txn,
 		func(existVal optionalValue) ([]byte, error) {
 			if failOnTombstones && existVal.IsTombstone() {
 				// We found a tombstone and failOnTombstones is true: fail.
@@ -2181,7 +2259,16 @@ func MVCCDeleteRange(
 	if err != nil {
 		return nil, nil, 0, err
 	}
+	for _, kv := range res.KVs {
+		if err := mvccCheckWriteForReplicatedLockConflicts(rw, kv.Key, timestamp, txn); err != nil {
+			return nil, nil, 0, err
+		}
+	}
 
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(rw, key, endKey, timestamp)
+	if err != nil {
+		return nil, nil, 0, err
+	}
 	buf := newPutBuffer()
 	defer buf.release()
 	iter := newMVCCIterator(rw, timestamp.IsEmpty(), IterOptions{Prefix: true})
@@ -2189,7 +2276,7 @@ func MVCCDeleteRange(
 
 	var keys []roachpb.Key
 	for i, kv := range res.KVs {
-		if err := mvccPutInternal(ctx, rw, iter, ms, kv.Key, timestamp, nil, txn, buf, nil); err != nil {
+		if err := mvccPutInternal(ctx, rw, iter, rangeTombstones, ms, kv.Key, timestamp, nil, txn, buf, nil); err != nil {
 			return nil, nil, 0, err
 		}
 		if returnKeys {
@@ -2202,6 +2289,105 @@ func MVCCDeleteRange(
 	return keys, res.ResumeSpan, res.NumKeys, nil
 }
 
+// MVCCDeleteRangeUsingTombstone deletes the given MVCC keyspan at the given
+// timestamp using an MVCC range tombstone, rather than a point tombstone for
+// each key. The write itself is O(1) regardless of the number of keys deleted,
+// although the span must still be scanned to check for conflicts and to update
+// MVCC stats.
+//
+// The operation is non-transactional. It returns a WriteIntentError with up to
+// maxIntents intents (or other replicated locks) if any exist in the span, and
+// a WriteTooOldError if the span contains point or range keys at or above the
+// timestamp.
+//
+// This function is EXPERIMENTAL. Callers must check CanUseMVCCRangeTombstones
+// before writing range tombstones.
+func MVCCDeleteRangeUsingTombstone(
+	ctx context.Context,
+	rw ReadWriter,
+	ms *enginepb.MVCCStats,
+	startKey, endKey roachpb.Key,
+	timestamp hlc.Timestamp,
+	maxIntents int64,
+) error {
+	rangeKey := MVCCRangeKey{StartKey: startKey, EndKey: endKey, Timestamp: timestamp}
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	if startKey.Compare(keys.LocalMax) < 0 {
+		return errors.Errorf("can't write MVCC range tombstone across local keyspace %s", rangeKey)
+	}
+
+	// Check for any overlapping intents and other replicated locks, and return
+	// them to be resolved.
+	if locks, err := mvccScanReplicatedLocks(ctx, rw, startKey, endKey, maxIntents); err != nil {
+		return err
+	} else if len(locks) > 0 {
+		return &roachpb.WriteIntentError{Intents: locks}
+	}
+
+	// Check for any newer range tombstones. Existing range tombstones below the
+	// timestamp are fine: they're retained, and shadowed by the new one.
+	rangeTombstones, err := ReadMVCCRangeTombstones(rw, startKey, endKey)
+	if err != nil {
+		return err
+	}
+	for _, rk := range rangeTombstones.RangeKeys() {
+		if timestamp.LessEq(rk.Timestamp) {
+			return roachpb.NewWriteTooOldError(timestamp, rk.Timestamp.Next(), rk.StartKey)
+		}
+	}
+
+	// Scan the latest version of each key, checking for newer versions and
+	// removing any live keys from the stats. The deleted versions become
+	// non-live at the range tombstone's timestamp, just like they would with a
+	// point tombstone, but unlike a point tombstone the range tombstone doesn't
+	// contribute to the stats itself.
+	var delta enginepb.MVCCStats
+	delta.AgeTo(timestamp.WallTime)
+	iter := rw.NewMVCCIterator(MVCCKeyIterKind, IterOptions{
+		LowerBound: startKey,
+		UpperBound: endKey,
+	})
+	defer iter.Close()
+	for iter.SeekGE(MVCCKey{Key: startKey}); ; iter.NextKey() {
+		if ok, err := iter.Valid(); err != nil {
+			return err
+		} else if !ok {
+			break
+		}
+		key := iter.UnsafeKey()
+		if key.Timestamp.IsEmpty() {
+			return errors.Errorf("can't write MVCC range tombstone across inline key %s", key.Key)
+		}
+		if timestamp.LessEq(key.Timestamp) {
+			return roachpb.NewWriteTooOldError(timestamp, key.Timestamp.Next(), key.Key.Clone())
+		}
+		if len(iter.UnsafeValue()) == 0 {
+			continue // already deleted by a point tombstone
+		}
+		if _, found := rangeTombstones.DeletedAt(key.Key, key.Timestamp); found {
+			continue // already deleted by a range tombstone
+		}
+		delta.LiveBytes -= MVCCVersionTimestampSize + int64(len(iter.UnsafeValue()))
+		delta.LiveBytes -= int64(MVCCKey{Key: key.Key}.EncodedSize())
+		delta.LiveCount--
+	}
+
+	if err := rw.ExperimentalPutMVCCRangeKey(rangeKey, nil); err != nil {
+		return err
+	}
+	if ms != nil {
+		ms.Add(delta)
+	}
+	rw.LogLogicalOp(MVCCDeleteRangeOpType, MVCCLogicalOpDetails{
+		Key:       startKey,
+		EndKey:    endKey,
+		Timestamp: timestamp,
+	})
+	return nil
+}
+
 func recordIteratorStats(traceSpan *tracing.Span, iteratorStats IteratorStats) {
 	stats := iteratorStats.Stats
 	if traceSpan != nil {
@@ -2221,6 +2407,7 @@ func recordIteratorStats(traceSpan *tracing.Span, iteratorStats IteratorStats) {
 func mvccScanToBytes(
 	ctx context.Context,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	key, endKey roachpb.Key,
 	timestamp hlc.Timestamp,
 	opts MVCCScanOptions,
@@ -2264,6 +2451,8 @@ func mvccScanToBytes(
 		tombstones:             opts.Tombstones,
 		failOnMoreRecent:       opts.FailOnMoreRecent,
 		keyBuf:                 mvccScanner.keyBuf,
+		rangeTombstones:        rangeTombstones,
+		rangeTombstoneBuf:      mvccScanner.rangeTombstoneBuf,
 	}
 
 	var trackLastOffsets int
@@ -2305,11 +2494,12 @@ func mvccScanToBytes(
 func mvccScanToKvs(
 	ctx context.Context,
 	iter MVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
 	key, endKey roachpb.Key,
 	timestamp hlc.Timestamp,
 	opts MVCCScanOptions,
 ) (MVCCScanResult, error) {
-	res, err := mvccScanToBytes(ctx, iter, key, endKey, timestamp, opts)
+	res, err := mvccScanToBytes(ctx, iter, rangeTombstones, key, endKey, timestamp, opts)
 	if err != nil {
 		return MVCCScanResult{}, err
 	}
@@ -2480,9 +2670,13 @@ func MVCCScan(
 	timestamp hlc.Timestamp,
 	opts MVCCScanOptions,
 ) (MVCCScanResult, error) {
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(reader, key, endKey, timestamp)
+	if err != nil {
+		return MVCCScanResult{}, err
+	}
 	iter := newMVCCIterator(reader, timestamp.IsEmpty(), IterOptions{LowerBound: key, UpperBound: endKey})
 	defer iter.Close()
-	return mvccScanToKvs(ctx, iter, key, endKey, timestamp, opts)
+	return mvccScanToKvs(ctx, iter, rangeTombstones, key, endKey, timestamp, opts)
 }
 
 // MVCCScanToBytes is like MVCCScan, but it returns the results in a byte array.
@@ -2493,9 +2687,13 @@ func MVCCScanToBytes(
 	timestamp hlc.Timestamp,
 	opts MVCCScanOptions,
 ) (MVCCScanResult, error) {
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(reader, key, endKey, timestamp)
+	if err != nil {
+		return MVCCScanResult{}, err
+	}
 	iter := newMVCCIterator(reader, timestamp.IsEmpty(), IterOptions{LowerBound: key, UpperBound: endKey})
 	defer iter.Close()
-	return mvccScanToBytes(ctx, iter, key, endKey, timestamp, opts)
+	return mvccScanToBytes(ctx, iter, rangeTombstones, key, endKey, timestamp, opts)
 }
 
 // MVCCScanAsTxn constructs a temporary transaction from the given transaction
@@ -2536,6 +2734,10 @@ func MVCCIterate(
 	opts MVCCScanOptions,
 	f func(roachpb.KeyValue) error,
 ) ([]roachpb.Intent, error) {
+	rangeTombstones, err := maybeReadMVCCRangeTombstones(reader, key, endKey, timestamp)
+	if err != nil {
+		return nil, err
+	}
 	iter := newMVCCIterator(
 		reader, timestamp.IsEmpty(), IterOptions{LowerBound: key, UpperBound: endKey})
 	defer iter.Close()
@@ -2546,7 +2748,7 @@ func MVCCIterate(
 		opts := opts
 		opts.MaxKeys = maxKeysPerScan
 		res, err := mvccScanToKvs(
-			ctx, iter, key, endKey, timestamp, opts)
+			ctx, iter, rangeTombstones, key, endKey, timestamp, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -2616,7 +2818,13 @@ func MVCCResolveWriteIntent(
 	ok, err := mvccResolveWriteIntent(ctx, rw, iterAndBuf.iter, ms, intent, iterAndBuf.buf)
 	// Using defer would be more convenient, but it is measurably slower.
 	iterAndBuf.Cleanup()
-	return ok, err
+	if err != nil || !intent.Status.IsFinalized() {
+		return ok, err
+	}
+	// The transaction is finalized, so also release any replicated locks that
+	// it acquired on the key without writing an intent.
+	released, err := mvccReleaseReplicatedLocks(rw, ms, intent.Key, intent.Txn.ID)
+	return ok || released, err
 }
 
 // unsafeNextVersion positions the iterator at the successor to latestKey. If this value
@@ -2713,6 +2921,14 @@ func (s *separatedIntentAndVersionIter) initIntentKey() {
 	}
 }
 
+// atIntent returns whether the iterator is positioned at an intent in the
+// lock table, as opposed to a replicated lock that has no provisional value.
+// REQUIRES: the iterator is valid and positioned in the lock table.
+func (s *separatedIntentAndVersionIter) atIntent() bool {
+	engineKey, err := s.engineIter.UnsafeEngineKey()
+	return err == nil && isIntentLockTableKeyVersion(engineKey.Version)
+}
+
 func (s *separatedIntentAndVersionIter) Valid() (bool, error) {
 	if s.atMVCCIter {
 		return s.mvccIter.Valid()
@@ -3314,6 +3530,9 @@ func MVCCResolveWriteIntentRange(
 	// ConsistentIterators() since we want the two iterators to be mutually
 	// consistent (and production code will have consistent iterators).
 	//
+	// Replicated locks that are not intents are only visible to, and released
+	// by, this path.
+	//
 	// TODO(sumeer): when removing the slow path, use
 	// newMVCCIteratorByCloningEngineIter for the inconsistent iterators case.
 	if rw.ConsistentIterators() {
@@ -3371,7 +3590,27 @@ func MVCCResolveWriteIntentRange(
 			if meta.Txn == nil {
 				return 0, nil, errors.Errorf("intent with no txn")
 			}
-			if intent.Txn.ID == meta.Txn.ID {
+			if intent.Txn.ID == meta.Txn.ID && !sepIter.atIntent() {
+				// A replicated lock without a provisional value. It is released
+				// once the transaction is finalized and is otherwise left in
+				// place.
+				if intent.Status.IsFinalized() {
+					engineKey, err := sepIter.engineIter.EngineKey()
+					if err != nil {
+						return 0, nil, err
+					}
+					if err := rw.ClearEngineKey(engineKey); err != nil {
+						return 0, nil, err
+					}
+					if ms != nil {
+						ms.LockBytes -= replicatedLockBytes(engineKey, sepIter.engineIter.UnsafeValue())
+						ms.LockCount--
+					}
+					num++
+				}
+				sepIter.nextEngineKey()
+				continue
+			} else if intent.Txn.ID == meta.Txn.ID {
 				// Stash the parsed meta so don't need to parse it again in
 				// mvccResolveWriteIntent. This parsing can be ~10% of the
 				// resolution cost in some benchmarks.
@@ -3429,6 +3668,203 @@ func MVCCResolveWriteIntentRange(
 	return num, nil, nil
 }
 
+// MVCCAcquireLock acquires a replicated lock with the provided strength on the
+// key on behalf of the transaction. Unlike an intent, a replicated lock does
+// not have a provisional value, so it is invisible to MVCC reads. It is stored
+// in the lock table key space alongside intents, so it is replicated through
+// Raft and survives lease transfers, and it is released when the transaction's
+// locks are resolved (see MVCCResolveWriteIntent and
+// MVCCResolveWriteIntentRange).
+//
+// A WriteIntentError is returned if another transaction holds an intent or a
+// replicated lock on the key. Acquiring a lock on a key that the transaction
+// has already locked or written to is a no-op. Only Exclusive locks are
+// currently supported.
+//
+// The lock is accounted for in ms under LockBytes and LockCount.
+//
+// Nodes running binaries that predate the ReplicatedLocks cluster version
+// cannot decode replicated locks that are not intents, so callers must check
+// that it is active before acquiring one.
+func MVCCAcquireLock(
+	ctx context.Context,
+	rw ReadWriter,
+	txn *roachpb.Transaction,
+	str lock.Strength,
+	key roachpb.Key,
+	ms *enginepb.MVCCStats,
+) error {
+	if len(key) == 0 {
+		return emptyKeyError()
+	}
+	if txn == nil {
+		return errors.AssertionFailedf("cannot acquire replicated lock on %s without a transaction", key)
+	}
+	if str != lock.Exclusive {
+		return errors.AssertionFailedf("unsupported replicated lock strength %s", str)
+	}
+	held, err := mvccCheckForReplicatedLockConflicts(rw, key, txn)
+	if err != nil || held {
+		return err
+	}
+	meta := enginepb.MVCCMetadata{
+		Txn:       &txn.TxnMeta,
+		Timestamp: txn.WriteTimestamp.ToLegacyTimestamp(),
+	}
+	val, err := protoutil.Marshal(&meta)
+	if err != nil {
+		return err
+	}
+	engineKey, _ := LockTableKey{Key: key, Strength: str, TxnUUID: txn.ID[:]}.ToEngineKey(nil)
+	if err := rw.PutEngineKey(engineKey, val); err != nil {
+		return err
+	}
+	if ms != nil {
+		ms.LockBytes += replicatedLockBytes(engineKey, val)
+		ms.LockCount++
+	}
+	return nil
+}
+
+// replicatedLockBytes returns the size of a replicated lock that is not an
+// intent, as accounted for in MVCCStats.LockBytes.
+func replicatedLockBytes(engineKey EngineKey, value []byte) int64 {
+	return int64(engineKey.EncodedLen() + len(value))
+}
+
+// MVCCCheckForReplicatedLockConflicts returns a WriteIntentError if a
+// transaction other than txn holds a replicated lock, including an intent, on
+// the key. Replicated locks that are not intents are invisible to MVCC reads,
+// so locking reads that do not acquire replicated locks themselves use this to
+// respect them.
+func MVCCCheckForReplicatedLockConflicts(
+	reader Reader, key roachpb.Key, txn *roachpb.Transaction,
+) error {
+	_, err := mvccCheckForReplicatedLockConflicts(reader, key, txn)
+	return err
+}
+
+// mvccCheckWriteForReplicatedLockConflicts checks that a write to the key does
+// not conflict with a replicated lock held by another transaction. Replicated
+// locks that are not intents are hidden from the MVCCIterators used by writes,
+// so they are checked for separately. This must be done before the write's
+// iterator is created, since it may use the same cached engine iterator.
+// Inline writes are never transactional and skip the check, as do blind writes,
+// whose callers guarantee that the key does not exist.
+func mvccCheckWriteForReplicatedLockConflicts(
+	reader Reader, key roachpb.Key, timestamp hlc.Timestamp, txn *roachpb.Transaction,
+) error {
+	if timestamp.IsEmpty() {
+		return nil
+	}
+	_, err := mvccCheckForReplicatedLockConflicts(reader, key, txn)
+	return err
+}
+
+// mvccCheckForReplicatedLockConflicts scans the replicated locks, including
+// intents, held on the key. It returns a WriteIntentError if any of them is
+// held by a transaction other than txn, which may be nil for non-transactional
+// requests. Otherwise, it returns whether txn holds any replicated lock on the
+// key.
+func mvccCheckForReplicatedLockConflicts(
+	reader Reader, key roachpb.Key, txn *roachpb.Transaction,
+) (held bool, err error) {
+	ltKey, _ := keys.LockTableSingleKey(key, nil)
+	iter := reader.NewEngineIterator(IterOptions{Prefix: true, LowerBound: ltKey})
+	defer iter.Close()
+	var meta enginepb.MVCCMetadata
+	var valid bool
+	for valid, err = iter.SeekEngineKeyGE(EngineKey{Key: ltKey}); valid; valid, err = iter.NextEngineKey() {
+		if err := protoutil.Unmarshal(iter.UnsafeValue(), &meta); err != nil {
+			return false, err
+		}
+		if meta.Txn == nil {
+			return false, errors.Errorf("replicated lock on %s with no txn", key)
+		}
+		if txn == nil || meta.Txn.ID != txn.ID {
+			return false, &roachpb.WriteIntentError{Intents: []roachpb.Intent{
+				roachpb.MakeIntent(meta.Txn, key),
+			}}
+		}
+		held = true
+	}
+	return held, err
+}
+
+// mvccScanReplicatedLocks returns up to max replicated locks, including
+// intents, held on keys in [start,end). The locks are returned as intents, for
+// use in a WriteIntentError. A max of zero disables the limit.
+func mvccScanReplicatedLocks(
+	ctx context.Context, reader Reader, start, end roachpb.Key, max int64,
+) ([]roachpb.Intent, error) {
+	ltStart, _ := keys.LockTableSingleKey(start, nil)
+	ltEnd, _ := keys.LockTableSingleKey(end, nil)
+	iter := reader.NewEngineIterator(IterOptions{LowerBound: ltStart, UpperBound: ltEnd})
+	defer iter.Close()
+	var locks []roachpb.Intent
+	var meta enginepb.MVCCMetadata
+	valid, err := iter.SeekEngineKeyGE(EngineKey{Key: ltStart})
+	for ; valid; valid, err = iter.NextEngineKey() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if max != 0 && int64(len(locks)) >= max {
+			break
+		}
+		engineKey, err := iter.UnsafeEngineKey()
+		if err != nil {
+			return nil, err
+		}
+		lockedKey, err := keys.DecodeLockTableSingleKey(engineKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		if err := protoutil.Unmarshal(iter.UnsafeValue(), &meta); err != nil {
+			return nil, err
+		}
+		if meta.Txn == nil {
+			return nil, errors.Errorf("replicated lock on %s with no txn", lockedKey)
+		}
+		locks = append(locks, roachpb.MakeIntent(meta.Txn, lockedKey.Clone()))
+	}
+	return locks, err
+}
+
+// mvccReleaseReplicatedLocks releases the replicated locks that are not
+// intents held by the transaction on the key. Intents are resolved separately
+// by mvccResolveWriteIntent. Returns whether any lock was released.
+func mvccReleaseReplicatedLocks(
+	rw ReadWriter, ms *enginepb.MVCCStats, key roachpb.Key, txnID uuid.UUID,
+) (bool, error) {
+	ltKey, _ := keys.LockTableSingleKey(key, nil)
+	iter := rw.NewEngineIterator(IterOptions{Prefix: true, LowerBound: ltKey})
+	defer iter.Close()
+	var released bool
+	valid, err := iter.SeekEngineKeyGE(EngineKey{Key: ltKey})
+	for ; valid; valid, err = iter.NextEngineKey() {
+		engineKey, err := iter.EngineKey()
+		if err != nil {
+			return false, err
+		}
+		lockKey, err := engineKey.ToLockTableKey()
+		if err != nil {
+			return false, err
+		}
+		if lockKey.Strength == lock.Intent || !bytes.Equal(lockKey.TxnUUID, txnID[:]) {
+			continue
+		}
+		if err := rw.ClearEngineKey(engineKey); err != nil {
+			return false, err
+		}
+		if ms != nil {
+			ms.LockBytes -= replicatedLockBytes(engineKey, iter.UnsafeValue())
+			ms.LockCount--
+		}
+		released = true
+	}
+	return released, err
+}
+
 // MVCCGarbageCollect creates an iterator on the ReadWriter. In parallel
 // it iterates through the keys listed for garbage collection by the
 // keys slice. The iterator is seeked in turn to each listed
@@ -3468,6 +3904,13 @@ func MVCCGarbageCollect(
 		return iKey.Less(jKey)
 	})
 
+	// Versions deleted by MVCC range tombstones at or below the GC timestamp
+	// are garbage, just like versions deleted by point tombstones.
+	rangeTombstones, err := ReadMVCCRangeTombstones(rw, keys[0].Key, keys[len(keys)-1].Key.Next())
+	if err != nil {
+		return err
+	}
+
 	// Bound the iterator appropriately for the set of keys we'll be garbage
 	// collecting.
 	iter := rw.NewMVCCIterator(MVCCKeyAndIntentsIterKind, IterOptions{
@@ -3499,12 +3942,24 @@ func MVCCGarbageCollect(
 		// being removed. We had this faulty functionality at some point; it
 		// should no longer be necessary since the higher levels already make
 		// sure each individual GCRequest does bounded work.
+		//
+		// The latest value may also have been deleted by a range tombstone, in
+		// which case it became non-live at the range tombstone's timestamp.
+		latestNonLiveNanos := timestamp.WallTime
 		if meta.Timestamp.ToTimestamp().LessEq(gcKey.Timestamp) {
+			deleted, deletedNanos := meta.Deleted, meta.Timestamp.WallTime
+			if !deleted && !inlinedValue && meta.Txn == nil {
+				if deletedAt, ok := rangeTombstones.DeletedAt(gcKey.Key, meta.Timestamp.ToTimestamp()); ok &&
+					deletedAt.LessEq(gcKey.Timestamp) {
+					deleted, deletedNanos = true, deletedAt.WallTime
+					latestNonLiveNanos = deletedAt.WallTime
+				}
+			}
 			// For version keys, don't allow GC'ing the meta key if it's
 			// not marked deleted. However, for inline values we allow it;
 			// they are internal and GCing them directly saves the extra
 			// deletion step.
-			if !meta.Deleted && !inlinedValue {
+			if !deleted && !inlinedValue {
 				return errors.Errorf("request to GC non-deleted, latest value of %q", gcKey.Key)
 			}
 			if meta.Txn != nil {
@@ -3515,7 +3970,7 @@ func MVCCGarbageCollect(
 					updateStatsForInline(ms, gcKey.Key, metaKeySize, metaValSize, 0, 0)
 					ms.AgeTo(timestamp.WallTime)
 				} else {
-					ms.Add(updateStatsOnGC(gcKey.Key, metaKeySize, metaValSize, meta, meta.Timestamp.WallTime))
+					ms.Add(updateStatsOnGC(gcKey.Key, metaKeySize, metaValSize, meta, deletedNanos))
 				}
 			}
 			if !implicitMeta {
@@ -3538,7 +3993,7 @@ func MVCCGarbageCollect(
 		// and better commented version of this logic. The below block will set
 		// prevNanos to the appropriate value and position the iterator at the
 		// first garbage version.
-		prevNanos := timestamp.WallTime
+		prevNanos := latestNonLiveNanos
 		{
 
 			var foundPrevNanos bool
@@ -3629,11 +4084,15 @@ func MVCCGarbageCollect(
 				// when it's a deletion.
 				valSize := int64(len(iter.UnsafeValue()))
 
-				// A non-deletion becomes non-live when its newer neighbor shows up.
+				// A non-deletion becomes non-live when its newer neighbor shows up,
+				// or when it is deleted by a range tombstone if that happens first.
 				// A deletion tombstone becomes non-live right when it is created.
 				fromNS := prevNanos
 				if valSize == 0 {
 					fromNS = unsafeIterKey.Timestamp.WallTime
+				} else if deletedAt, ok := rangeTombstones.DeletedAt(gcKey.Key, unsafeIterKey.Timestamp); ok &&
+					deletedAt.WallTime < fromNS {
+					fromNS = deletedAt.WallTime
 				}
 
 				ms.Add(updateStatsOnGC(gcKey.Key, MVCCVersionTimestampSize,
@@ -3650,6 +4109,48 @@ func MVCCGarbageCollect(
 	return nil
 }
 
+// MVCCGarbageCollectRangeKeys removes the given MVCC range tombstones. The
+// caller must have already garbage collected all point key versions covered by
+// them, which is verified here: removing a range tombstone while it still
+// covers a point key would resurrect that key.
+//
+// Range tombstones do not contribute to MVCC stats, so the stats are not
+// updated.
+func MVCCGarbageCollectRangeKeys(
+	ctx context.Context, rw ReadWriter, rangeKeys []MVCCRangeKey,
+) error {
+	for _, rk := range rangeKeys {
+		if err := rk.Validate(); err != nil {
+			return err
+		}
+		iter := rw.NewMVCCIterator(MVCCKeyAndIntentsIterKind, IterOptions{
+			KeyTypes:   IterKeyTypePointsOnly,
+			LowerBound: rk.StartKey,
+			UpperBound: rk.EndKey,
+		})
+		for iter.SeekGE(MVCCKey{Key: rk.StartKey}); ; iter.Next() {
+			if ok, err := iter.Valid(); err != nil {
+				iter.Close()
+				return err
+			} else if !ok {
+				break
+			}
+			if key := iter.UnsafeKey(); key.IsValue() && key.Timestamp.LessEq(rk.Timestamp) {
+				iter.Close()
+				return errors.Errorf("range tombstone %s still covers point key %s", rk, key)
+			}
+		}
+		iter.Close()
+		if err := rw.ExperimentalClearMVCCRangeKey(rk); err != nil {
+			return err
+		}
+	}
+	if len(rangeKeys) > 0 {
+		log.Eventf(ctx, "done with GC evaluation for %d range keys", len(rangeKeys))
+	}
+	return nil
+}
+
 // MVCCFindSplitKey finds a key from the given span such that the left side of
 // the split is roughly targetSize bytes. The returned key will never be chosen
 // from the key ranges listed in keys.NoSplitSpans.
@@ -3772,6 +4273,57 @@ func ComputeStatsForRange(
 	start, end roachpb.Key,
 	nowNanos int64,
 	callbacks ...func(MVCCKey, []byte) error,
+) (enginepb.MVCCStats, error) {
+	return ComputeStatsForRangeWithRangeTombstones(iter, nil, start, end, nowNanos, callbacks...)
+}
+
+// ComputeReplicatedLockStatsForRange scans the lock table of the keys from
+// start to end and computes the LockBytes and LockCount stats of the replicated
+// locks that are not intents. Intents are accounted for by
+// ComputeStatsForRange, through an iterator which interleaves them with the
+// MVCC keyspace.
+func ComputeReplicatedLockStatsForRange(
+	reader Reader, start, end roachpb.Key,
+) (enginepb.MVCCStats, error) {
+	var ms enginepb.MVCCStats
+	ltStart, _ := keys.LockTableSingleKey(start, nil)
+	ltEnd, _ := keys.LockTableSingleKey(end, nil)
+	iter := reader.NewEngineIterator(IterOptions{LowerBound: ltStart, UpperBound: ltEnd})
+	defer iter.Close()
+	valid, err := iter.SeekEngineKeyGE(EngineKey{Key: ltStart})
+	for ; valid; valid, err = iter.NextEngineKey() {
+		engineKey, err := iter.UnsafeEngineKey()
+		if err != nil {
+			return enginepb.MVCCStats{}, err
+		}
+		lockKey, err := engineKey.ToLockTableKey()
+		if err != nil {
+			return enginepb.MVCCStats{}, err
+		}
+		if lockKey.Strength == lock.Intent {
+			continue
+		}
+		ms.LockBytes += replicatedLockBytes(engineKey, iter.UnsafeValue())
+		ms.LockCount++
+	}
+	if err != nil {
+		return enginepb.MVCCStats{}, err
+	}
+	return ms, nil
+}
+
+// ComputeStatsForRangeWithRangeTombstones is like ComputeStatsForRange, but
+// also takes into account the given MVCC range tombstones, which must cover the
+// span being computed (see ReadMVCCRangeTombstones). A version deleted by a
+// range tombstone is accounted for as if it had a point tombstone at the range
+// tombstone's timestamp. The range tombstones themselves don't contribute to
+// the stats.
+func ComputeStatsForRangeWithRangeTombstones(
+	iter SimpleMVCCIterator,
+	rangeTombstones *MVCCRangeTombstones,
+	start, end roachpb.Key,
+	nowNanos int64,
+	callbacks ...func(MVCCKey, []byte) error,
 ) (enginepb.MVCCStats, error) {
 	var ms enginepb.MVCCStats
 	// Only some callers are providing an MVCCIterator. The others don't have
@@ -3836,6 +4388,14 @@ func ComputeStatsForRange(
 			meta.ValBytes = int64(len(unsafeValue))
 			meta.Deleted = len(unsafeValue) == 0
 			meta.Timestamp.WallTime = unsafeKey.Timestamp.WallTime
+			if !meta.Deleted {
+				// A version deleted by a range tombstone is accounted for as if it
+				// was deleted at the range tombstone's timestamp.
+				if deletedAt, ok := rangeTombstones.DeletedAt(unsafeKey.Key, unsafeKey.Timestamp); ok {
+					meta.Deleted = true
+					meta.Timestamp.WallTime = deletedAt.WallTime
+				}
+			}
 		}
 
 		if !isValue || implicitMeta {
@@ -3906,6 +4466,12 @@ func ComputeStatsForRange(
 						"(meta: %s)", len(unsafeValue), meta.ValBytes, &meta)
 				}
 				accrueGCAgeNanos = meta.Timestamp.WallTime
+				if implicitMeta {
+					// The implicit meta's timestamp may be that of a range tombstone
+					// deleting the version, see above. Older versions are shadowed by
+					// this version regardless.
+					accrueGCAgeNanos = unsafeKey.Timestamp.WallTime
+				}
 			} else {
 				// Overwritten value. Is it a deletion tombstone?
 				isTombstone := len(unsafeValue) == 0
@@ -3914,8 +4480,14 @@ func ComputeStatsForRange(
 					ms.GCBytesAge += totalBytes * (nowNanos/1e9 - unsafeKey.Timestamp.WallTime/1e9)
 				} else {
 					// The kv pair is an overwritten value, so it became non-live when the closest more
-					// recent value was written.
-					ms.GCBytesAge += totalBytes * (nowNanos/1e9 - accrueGCAgeNanos/1e9)
+					// recent value was written, or when it was deleted by a range tombstone if
+					// that happened first.
+					nonLiveNanos := accrueGCAgeNanos
+					if deletedAt, ok := rangeTombstones.DeletedAt(unsafeKey.Key, unsafeKey.Timestamp); ok &&
+						deletedAt.WallTime < nonLiveNanos {
+						nonLiveNanos = deletedAt.WallTime
+					}
+					ms.GCBytesAge += totalBytes * (nowNanos/1e9 - nonLiveNanos/1e9)
 				}
 				// Update for the next version we may end up looking at.
 				accrueGCAgeNanos = unsafeKey.Timestamp.WallTime
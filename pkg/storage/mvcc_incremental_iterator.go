@@ -66,7 +66,9 @@ import (
 // no delete in the subset of sstables used by timeBoundIter that deletes
 // k@t#n1, so the timeBoundIter will see k@t.
 type MVCCIncrementalIterator struct {
-	iter MVCCIterator
+	reader Reader
+	endKey roachpb.Key
+	iter   MVCCIterator
 
 	// A time-bound iterator cannot be used by itself due to a bug in the time-
 	// bound iterator (#28358). This was historically augmented with an iterator
@@ -180,6 +182,8 @@ func NewMVCCIncrementalIterator(
 	}
 
 	return &MVCCIncrementalIterator{
+		reader:        reader,
+		endKey:        opts.EndKey,
 		iter:          iter,
 		startTime:     opts.StartTime,
 		endTime:       opts.EndTime,
@@ -546,6 +550,17 @@ func (i *MVCCIncrementalIterator) NextKeyIgnoringTime() {
 	}
 }
 
+// RangeTombstones returns the MVCC range tombstones between startKey and the
+// iterator's end key with timestamps in the iterator's time bounds. These are
+// not surfaced by the iterator itself, so callers that need to handle deletions
+// must read them separately. Point key versions at or below a range tombstone
+// are deleted by it, see MVCCRangeTombstones.
+func (i *MVCCIncrementalIterator) RangeTombstones(
+	startKey roachpb.Key,
+) (*MVCCRangeTombstones, error) {
+	return readMVCCRangeTombstones(i.reader, startKey, i.endKey, i.startTime, i.endTime)
+}
+
 // NumCollectedIntents returns number of intents encountered during iteration.
 // This is only the case when intent aggregation is enabled, otherwise it is
 // always 0.
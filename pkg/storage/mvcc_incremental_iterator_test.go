@@ -19,6 +19,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -496,6 +497,121 @@ func assertEqualKVs(
 // the method NextIgnoreTime(). This method is supposed to return all the KVs
 // (versions and new keys) that would be encountered in a non-incremental
 // iteration.
+// TestMVCCIncrementalIteratorRangeTombstones verifies that the MVCC range
+// tombstones in the time bounds of an MVCCIncrementalIterator are returned by
+// it, and that ExportMVCCToSst writes them to the exported SST whenever it
+// would write point tombstones, while skipping the point keys they delete
+// otherwise.
+func TestMVCCIncrementalIteratorRangeTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	e := NewDefaultInMemForTesting()
+	defer e.Close()
+	require.NoError(t, e.SetMinVersion(
+		clusterversion.ByKey(clusterversion.EnsurePebbleFormatVersionRangeKeys)))
+
+	ts1, ts2, ts3 := hlc.Timestamp{WallTime: 1}, hlc.Timestamp{WallTime: 2}, hlc.Timestamp{WallTime: 3}
+	require.NoError(t, MVCCPut(ctx, e, nil, testKey1, ts1, value1, nil))
+	require.NoError(t, MVCCPut(ctx, e, nil, testKey2, ts1, value2, nil))
+	require.NoError(t, MVCCDeleteRangeUsingTombstone(ctx, e, nil, testKey1, testKey3, ts2, 0))
+	require.NoError(t, MVCCPut(ctx, e, nil, testKey3, ts3, value3, nil))
+	rangeTombstone := MVCCRangeKey{StartKey: testKey1, EndKey: testKey3, Timestamp: ts2}
+
+	t.Run("iterator", func(t *testing.T) {
+		for _, tc := range []struct {
+			startTime, endTime hlc.Timestamp
+			expected           []MVCCRangeKey
+		}{
+			{startTime: hlc.Timestamp{}, endTime: ts3, expected: []MVCCRangeKey{rangeTombstone}},
+			{startTime: ts1, endTime: ts2, expected: []MVCCRangeKey{rangeTombstone}},
+			{startTime: ts2, endTime: ts3, expected: nil},
+			{startTime: hlc.Timestamp{}, endTime: ts1, expected: nil},
+		} {
+			t.Run(fmt.Sprintf("%s-%s", tc.startTime, tc.endTime), func(t *testing.T) {
+				iter := NewMVCCIncrementalIterator(e, MVCCIncrementalIterOptions{
+					EndKey:    keyMax,
+					StartTime: tc.startTime,
+					EndTime:   tc.endTime,
+				})
+				defer iter.Close()
+				rangeTombstones, err := iter.RangeTombstones(localMax)
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, rangeTombstones.RangeKeys())
+			})
+		}
+	})
+
+	// export returns the point keys and range tombstones exported between the
+	// given times.
+	export := func(t *testing.T, startTime, endTime hlc.Timestamp, revisions bool) ([]MVCCKey, []MVCCRangeKey) {
+		sstFile := &MemFile{}
+		_, _, _, err := e.ExportMVCCToSst(ctx, ExportOptions{
+			StartKey:           MVCCKey{Key: localMax},
+			EndKey:             keyMax,
+			StartTS:            startTime,
+			EndTS:              endTime,
+			ExportAllRevisions: revisions,
+		}, sstFile)
+		require.NoError(t, err)
+		if sstFile.Data() == nil {
+			return nil, nil
+		}
+		sst, err := NewMemSSTIterator(sstFile.Data(), false /* verify */)
+		require.NoError(t, err)
+		defer sst.Close()
+		var pointKeys []MVCCKey
+		for sst.SeekGE(MVCCKey{}); ; sst.Next() {
+			ok, err := sst.Valid()
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			pointKeys = append(pointKeys, MVCCKey{
+				Key:       sst.UnsafeKey().Key.Clone(),
+				Timestamp: sst.UnsafeKey().Timestamp,
+			})
+		}
+		rangeTombstones, err := ReadSSTRangeTombstones(sst, localMax, keyMax)
+		require.NoError(t, err)
+		return pointKeys, rangeTombstones.RangeKeys()
+	}
+
+	t.Run("export-full", func(t *testing.T) {
+		// The versions deleted by the range tombstone are skipped, like versions
+		// deleted by point tombstones, and so is the range tombstone.
+		pointKeys, rangeKeys := export(t, hlc.Timestamp{}, ts3, false /* revisions */)
+		require.Equal(t, []MVCCKey{{Key: testKey3, Timestamp: ts3}}, pointKeys)
+		require.Empty(t, rangeKeys)
+	})
+
+	t.Run("export-incremental", func(t *testing.T) {
+		pointKeys, rangeKeys := export(t, ts1, ts3, false /* revisions */)
+		require.Equal(t, []MVCCKey{{Key: testKey3, Timestamp: ts3}}, pointKeys)
+		require.Equal(t, []MVCCRangeKey{rangeTombstone}, rangeKeys)
+	})
+
+	t.Run("export-all-revisions", func(t *testing.T) {
+		pointKeys, rangeKeys := export(t, hlc.Timestamp{}, ts3, true /* revisions */)
+		require.Equal(t, []MVCCKey{
+			{Key: testKey1, Timestamp: ts1},
+			{Key: testKey2, Timestamp: ts1},
+			{Key: testKey3, Timestamp: ts3},
+		}, pointKeys)
+		require.Equal(t, []MVCCRangeKey{rangeTombstone}, rangeKeys)
+	})
+
+	t.Run("export-below-range-tombstone", func(t *testing.T) {
+		pointKeys, rangeKeys := export(t, hlc.Timestamp{}, ts1, false /* revisions */)
+		require.Equal(t, []MVCCKey{
+			{Key: testKey1, Timestamp: ts1},
+			{Key: testKey2, Timestamp: ts1},
+		}, pointKeys)
+		require.Empty(t, rangeKeys)
+	})
+}
+
 func TestMVCCIncrementalIteratorNextIgnoringTime(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -327,3 +327,69 @@ func decodeMVCCTimestampSuffix(encodedTS []byte) (hlc.Timestamp, error) {
 	}
 	return decodeMVCCTimestamp(encodedTS[:encodedLen-1])
 }
+
+// MVCCRangeKey is a versioned key span, written as a Pebble range key. It
+// applies to all point keys in [StartKey, EndKey) with timestamps at or below
+// Timestamp.
+//
+// Range keys are currently only used as MVCC range tombstones, i.e. they must
+// have an empty value, and delete all point key versions below them. See
+// MVCCDeleteRangeUsingTombstone.
+type MVCCRangeKey struct {
+	StartKey  roachpb.Key
+	EndKey    roachpb.Key
+	Timestamp hlc.Timestamp
+}
+
+// Clone returns a copy of the range key.
+func (k MVCCRangeKey) Clone() MVCCRangeKey {
+	// k is already a copy, but byte slices must be cloned.
+	k.StartKey = k.StartKey.Clone()
+	k.EndKey = k.EndKey.Clone()
+	return k
+}
+
+// Span returns the key span of the range key.
+func (k MVCCRangeKey) Span() roachpb.Span {
+	return roachpb.Span{Key: k.StartKey, EndKey: k.EndKey}
+}
+
+// String formats the range key.
+func (k MVCCRangeKey) String() string {
+	return fmt.Sprintf("%s/%s", k.Span(), k.Timestamp)
+}
+
+// Validate returns an error if the range key is invalid.
+func (k MVCCRangeKey) Validate() (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "invalid range key %s", k)
+	}()
+
+	switch {
+	case len(k.StartKey) == 0:
+		return errors.Errorf("no start key")
+	case len(k.EndKey) == 0:
+		return errors.Errorf("no end key")
+	case k.Timestamp.IsEmpty():
+		return errors.Errorf("no timestamp")
+	case k.StartKey.Compare(k.EndKey) >= 0:
+		return errors.Errorf("start key %s is at or after end key %s", k.StartKey, k.EndKey)
+	default:
+		return nil
+	}
+}
+
+// MVCCRangeKeyValue contains the raw bytes of the value for a range key.
+type MVCCRangeKeyValue struct {
+	Key   MVCCRangeKey
+	Value []byte
+}
+
+// Clone returns a copy of the range key and value.
+func (kv MVCCRangeKeyValue) Clone() MVCCRangeKeyValue {
+	kv.Key = kv.Key.Clone()
+	if kv.Value != nil {
+		kv.Value = append([]byte(nil), kv.Value...)
+	}
+	return kv
+}
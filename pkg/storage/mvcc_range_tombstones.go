@@ -0,0 +1,216 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// MVCCRangeTombstonesEnabled controls whether MVCC range tombstones may be
+// written. Range tombstones are always understood by reads, GC and rangefeeds
+// regardless of this setting, since they may have been written while it was
+// enabled.
+var MVCCRangeTombstonesEnabled = settings.RegisterBoolSetting(
+	settings.TenantReadOnly,
+	"storage.mvcc.range_tombstones.enabled",
+	"experimental: enables the use of MVCC range tombstones for bulk deletions",
+	false,
+)
+
+// CanUseMVCCRangeTombstones returns true if the caller can write MVCC range
+// tombstones. This requires all nodes in the cluster to support range keys at
+// the Pebble layer, and the MVCCRangeTombstonesEnabled setting to be enabled.
+func CanUseMVCCRangeTombstones(ctx context.Context, st *cluster.Settings) bool {
+	return st.Version.IsActive(ctx, clusterversion.EnablePebbleFormatVersionRangeKeys) &&
+		MVCCRangeTombstonesEnabled.Get(&st.SV)
+}
+
+// MVCCRangeTombstones is an in-memory view of the MVCC range tombstones that
+// overlap a key span, as returned by ReadMVCCRangeTombstones. A range tombstone
+// at timestamp T deletes all point key versions at or below T within its key
+// span, as if each of them had a point tombstone at T.
+//
+// Pebble fragments overlapping range keys at their boundaries, so the range
+// tombstones are kept as non-overlapping fragments sorted by key, each with the
+// timestamps of all range tombstones covering it in descending order. A nil
+// *MVCCRangeTombstones is valid and contains no range tombstones, which allows
+// callers to cheaply skip them in the common case.
+type MVCCRangeTombstones struct {
+	fragments []mvccRangeTombstoneFragment
+}
+
+// mvccRangeTombstoneFragment is a span covered by a stack of range tombstones.
+type mvccRangeTombstoneFragment struct {
+	span       roachpb.Span
+	timestamps []hlc.Timestamp // descending
+}
+
+// ReadMVCCRangeTombstones reads the MVCC range tombstones overlapping
+// [start,end) from the reader, truncated to the span. If end is empty, the
+// range tombstones covering the single key start are read. It returns nil if
+// there are no range tombstones in the span.
+//
+// Range tombstones can only be written to the global keyspace, so local key
+// spans are skipped without reading from the engine.
+//
+// NB: the range tombstones are read with a separate iterator. For readers where
+// ConsistentIterators() is false, this may observe a different engine state
+// than the caller's other iterators, so callers must hold latches that prevent
+// concurrent writes to the span.
+func ReadMVCCRangeTombstones(reader Reader, start, end roachpb.Key) (*MVCCRangeTombstones, error) {
+	return readMVCCRangeTombstones(reader, start, end, hlc.Timestamp{}, hlc.MaxTimestamp)
+}
+
+// readMVCCRangeTombstones is like ReadMVCCRangeTombstones, but only returns
+// range tombstones with timestamps in (minTS,maxTS].
+func readMVCCRangeTombstones(
+	reader Reader, start, end roachpb.Key, minTS, maxTS hlc.Timestamp,
+) (*MVCCRangeTombstones, error) {
+	if len(end) == 0 {
+		end = start.Next()
+	}
+	if end.Compare(keys.LocalMax) <= 0 {
+		return nil, nil
+	}
+	if start.Compare(keys.LocalMax) < 0 {
+		start = keys.LocalMax
+	}
+	iter := reader.NewMVCCIterator(MVCCKeyIterKind, IterOptions{
+		KeyTypes:   IterKeyTypeRangesOnly,
+		LowerBound: start,
+		UpperBound: end,
+	})
+	defer iter.Close()
+
+	var t *MVCCRangeTombstones
+	for iter.SeekGE(MVCCKey{Key: start}); ; iter.Next() {
+		if ok, err := iter.Valid(); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+		if _, hasRange := iter.HasPointAndRange(); !hasRange {
+			continue
+		}
+		var timestamps []hlc.Timestamp
+		for _, rkv := range iter.RangeKeys() {
+			if len(rkv.Value) != 0 {
+				return nil, errors.AssertionFailedf("unsupported MVCC range key value at %s", rkv.Key)
+			}
+			if ts := rkv.Key.Timestamp; minTS.Less(ts) && ts.LessEq(maxTS) {
+				timestamps = append(timestamps, ts)
+			}
+		}
+		if len(timestamps) == 0 {
+			continue
+		}
+		bounds := iter.RangeBounds()
+		span := roachpb.Span{Key: bounds.Key.Clone(), EndKey: bounds.EndKey.Clone()}
+		if span.Key.Compare(start) < 0 {
+			span.Key = start
+		}
+		if span.EndKey.Compare(end) > 0 {
+			span.EndKey = end
+		}
+		if t == nil {
+			t = &MVCCRangeTombstones{}
+		}
+		t.fragments = append(t.fragments, mvccRangeTombstoneFragment{
+			span:       span,
+			timestamps: timestamps,
+		})
+	}
+	return t, nil
+}
+
+// covering returns the timestamps of the range tombstones covering the given
+// key, in descending order.
+func (t *MVCCRangeTombstones) covering(key roachpb.Key) []hlc.Timestamp {
+	if t == nil {
+		return nil
+	}
+	i := sort.Search(len(t.fragments), func(i int) bool {
+		return key.Compare(t.fragments[i].span.EndKey) < 0
+	})
+	if i < len(t.fragments) && t.fragments[i].span.Key.Compare(key) <= 0 {
+		return t.fragments[i].timestamps
+	}
+	return nil
+}
+
+// Empty returns true if there are no range tombstones.
+func (t *MVCCRangeTombstones) Empty() bool {
+	return t == nil || len(t.fragments) == 0
+}
+
+// Newest returns the timestamp of the newest range tombstone covering the
+// given key, if any.
+func (t *MVCCRangeTombstones) Newest(key roachpb.Key) (hlc.Timestamp, bool) {
+	if timestamps := t.covering(key); len(timestamps) > 0 {
+		return timestamps[0], true
+	}
+	return hlc.Timestamp{}, false
+}
+
+// DeletedAt returns the timestamp at which a point key version at the given key
+// and timestamp was deleted by a range tombstone, i.e. the timestamp of the
+// oldest covering range tombstone at or above the version's timestamp, if any.
+func (t *MVCCRangeTombstones) DeletedAt(key roachpb.Key, ts hlc.Timestamp) (hlc.Timestamp, bool) {
+	timestamps := t.covering(key)
+	for i := len(timestamps) - 1; i >= 0; i-- {
+		if ts.LessEq(timestamps[i]) {
+			return timestamps[i], true
+		}
+	}
+	return hlc.Timestamp{}, false
+}
+
+// RangeKeys returns the range tombstones as MVCC range keys, ordered by start
+// key and then by descending timestamp. Range tombstones are returned as
+// fragments, so a range tombstone that was written across a key span may be
+// returned as several adjacent range keys.
+func (t *MVCCRangeTombstones) RangeKeys() []MVCCRangeKey {
+	if t == nil {
+		return nil
+	}
+	var rangeKeys []MVCCRangeKey
+	for _, frag := range t.fragments {
+		for _, ts := range frag.timestamps {
+			rangeKeys = append(rangeKeys, MVCCRangeKey{
+				StartKey:  frag.span.Key,
+				EndKey:    frag.span.EndKey,
+				Timestamp: ts,
+			})
+		}
+	}
+	return rangeKeys
+}
+
+// maybeReadMVCCRangeTombstones is like ReadMVCCRangeTombstones, but skips
+// reading range tombstones for inline operations, i.e. when the timestamp is
+// empty, since range tombstones only apply to MVCC versions.
+func maybeReadMVCCRangeTombstones(
+	reader Reader, start, end roachpb.Key, timestamp hlc.Timestamp,
+) (*MVCCRangeTombstones, error) {
+	if timestamp.IsEmpty() {
+		return nil, nil
+	}
+	return ReadMVCCRangeTombstones(reader, start, end)
+}
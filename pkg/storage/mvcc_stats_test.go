@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
@@ -1362,6 +1363,56 @@ func TestMVCCStatsSysPutPut(t *testing.T) {
 	}
 }
 
+// TestMVCCStatsReplicatedLocks verifies that replicated locks which are not
+// intents are accounted for in LockBytes and LockCount when they are acquired
+// and released, in agreement with ComputeReplicatedLockStatsForRange.
+func TestMVCCStatsReplicatedLocks(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	engine := NewDefaultInMemForTesting()
+	defer engine.Close()
+
+	ms := &enginepb.MVCCStats{}
+	assertLockStats := func(t *testing.T, debug string, count int64) {
+		t.Helper()
+		computed, err := ComputeReplicatedLockStatsForRange(engine, keys.LocalMax, roachpb.KeyMax)
+		require.NoError(t, err)
+		require.Equal(t, computed, *ms, debug)
+		require.Equal(t, count, ms.LockCount, debug)
+		if count > 0 {
+			require.NotZero(t, ms.LockBytes, debug)
+		}
+	}
+
+	for _, key := range []roachpb.Key{testKey1, testKey2, testKey3} {
+		require.NoError(t, MVCCAcquireLock(ctx, engine, txn1, lock.Exclusive, key, ms))
+	}
+	assertLockStats(t, "after acquiring locks", 3)
+
+	// Acquiring a held lock again doesn't change the stats.
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn1, lock.Exclusive, testKey1, ms))
+	assertLockStats(t, "after acquiring a held lock", 3)
+
+	// Resolving the intents of a pending transaction leaves its locks in place.
+	_, err := MVCCResolveWriteIntent(ctx, engine, ms,
+		roachpb.MakeLockUpdate(txn1, roachpb.Span{Key: testKey1}))
+	require.NoError(t, err)
+	assertLockStats(t, "after resolving a pending transaction", 3)
+
+	// Resolving the intents of a finalized transaction releases its locks.
+	_, err = MVCCResolveWriteIntent(ctx, engine, ms,
+		roachpb.MakeLockUpdate(txn1Commit, roachpb.Span{Key: testKey1}))
+	require.NoError(t, err)
+	assertLockStats(t, "after resolving testKey1", 2)
+
+	_, _, err = MVCCResolveWriteIntentRange(ctx, engine, ms,
+		roachpb.MakeLockUpdate(txn1Commit, roachpb.Span{Key: testKey1, EndKey: testKey4}), 0)
+	require.NoError(t, err)
+	assertLockStats(t, "after resolving the range", 0)
+}
+
 var mvccStatsTests = []struct {
 	name string
 	fn   func(MVCCIterator, roachpb.Key, roachpb.Key, int64) (enginepb.MVCCStats, error)
@@ -23,7 +23,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/bootstrap"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -1914,6 +1916,79 @@ func TestMVCCDeleteRangeOldTimestamp(t *testing.T) {
 	}
 }
 
+func TestMVCCDeleteRangeUsingTombstone(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	for _, engineImpl := range mvccEngineImpls {
+		t.Run(engineImpl.name, func(t *testing.T) {
+			engine := engineImpl.create()
+			defer engine.Close()
+			require.NoError(t, engine.SetMinVersion(
+				clusterversion.ByKey(clusterversion.EnsurePebbleFormatVersionRangeKeys)))
+
+			var ms enginepb.MVCCStats
+			require.NoError(t, MVCCPut(ctx, engine, &ms, testKey1, hlc.Timestamp{WallTime: 1}, value1, nil))
+			require.NoError(t, MVCCPut(ctx, engine, &ms, testKey2, hlc.Timestamp{WallTime: 1}, value2, nil))
+			require.NoError(t, MVCCDelete(ctx, engine, &ms, testKey3, hlc.Timestamp{WallTime: 1}, nil))
+			require.NoError(t, MVCCPut(ctx, engine, &ms, testKey4, hlc.Timestamp{WallTime: 1}, value4, nil))
+			require.NoError(t, MVCCPut(ctx, engine, &ms, testKey2, hlc.Timestamp{WallTime: 3}, value3, nil))
+
+			// Deleting below an existing version is a WriteTooOld error.
+			err := MVCCDeleteRangeUsingTombstone(
+				ctx, engine, &ms, testKey1, testKey4, hlc.Timestamp{WallTime: 2}, 0)
+			require.IsType(t, (*roachpb.WriteTooOldError)(nil), err)
+
+			// Delete [testKey1,testKey4) at time 5, leaving testKey4 live.
+			require.NoError(t, MVCCDeleteRangeUsingTombstone(
+				ctx, engine, &ms, testKey1, testKey4, hlc.Timestamp{WallTime: 5}, 0))
+
+			// Reads see the range tombstone at and above its timestamp, but not
+			// below it.
+			val, _, err := MVCCGet(ctx, engine, testKey2, hlc.Timestamp{WallTime: 4}, MVCCGetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, value3.RawBytes, val.RawBytes)
+			val, _, err = MVCCGet(ctx, engine, testKey2, hlc.Timestamp{WallTime: 5}, MVCCGetOptions{})
+			require.NoError(t, err)
+			require.Nil(t, val)
+			val, _, err = MVCCGet(ctx, engine, testKey2, hlc.Timestamp{WallTime: 5},
+				MVCCGetOptions{Tombstones: true})
+			require.NoError(t, err)
+			require.NotNil(t, val)
+			require.False(t, val.IsPresent())
+
+			res, err := MVCCScan(ctx, engine, testKey1, keyMax, hlc.Timestamp{WallTime: 6}, MVCCScanOptions{})
+			require.NoError(t, err)
+			require.Len(t, res.KVs, 1)
+			require.Equal(t, testKey4, res.KVs[0].Key)
+
+			// Writes below the range tombstone are pushed above it.
+			err = MVCCPut(ctx, engine, &ms, testKey1, hlc.Timestamp{WallTime: 4}, value1, nil)
+			require.IsType(t, (*roachpb.WriteTooOldError)(nil), err)
+			require.NoError(t, MVCCPut(ctx, engine, &ms, testKey1, hlc.Timestamp{WallTime: 7}, value1, nil))
+			val, _, err = MVCCGet(ctx, engine, testKey1, hlc.Timestamp{WallTime: 7}, MVCCGetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, value1.RawBytes, val.RawBytes)
+
+			// The incrementally updated stats must match the computed stats.
+			rangeTombstones, err := ReadMVCCRangeTombstones(engine, roachpb.KeyMin, keyMax)
+			require.NoError(t, err)
+			require.Equal(t, []MVCCRangeKey{{
+				StartKey:  testKey1,
+				EndKey:    testKey4,
+				Timestamp: hlc.Timestamp{WallTime: 5},
+			}}, rangeTombstones.RangeKeys())
+			iter := engine.NewMVCCIterator(MVCCKeyAndIntentsIterKind, IterOptions{UpperBound: keyMax})
+			defer iter.Close()
+			expMS, err := ComputeStatsForRangeWithRangeTombstones(
+				iter, rangeTombstones, roachpb.KeyMin, keyMax, ms.LastUpdateNanos)
+			require.NoError(t, err)
+			require.Equal(t, expMS, ms)
+		})
+	}
+}
+
 func TestMVCCDeleteRangeInline(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -2899,6 +2974,93 @@ func TestMVCCResolveTxn(t *testing.T) {
 	}
 }
 
+// TestMVCCAcquireReplicatedLock verifies that replicated locks acquired
+// without writing an intent are invisible to MVCC reads, conflict with writes
+// and lock acquisitions by other transactions, and are released when the
+// transaction that holds them is resolved.
+func TestMVCCAcquireReplicatedLock(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	engine := NewDefaultInMemForTesting()
+	defer engine.Close()
+	batch := engine.NewBatch()
+	defer batch.Close()
+
+	requireWriteIntentError := func(t *testing.T, err error) {
+		t.Helper()
+		var wiErr *roachpb.WriteIntentError
+		require.True(t, errors.As(err, &wiErr), "expected WriteIntentError, found %v", err)
+		require.Len(t, wiErr.Intents, 1)
+		require.Equal(t, txn1ID, wiErr.Intents[0].Txn.ID)
+	}
+
+	// txn1 locks testKey1, testKey2 and testKey3. Acquiring the lock again is
+	// a no-op.
+	for _, key := range []roachpb.Key{testKey1, testKey2, testKey3} {
+		require.NoError(t, MVCCAcquireLock(ctx, batch, txn1, lock.Exclusive, key, nil))
+	}
+	require.NoError(t, MVCCAcquireLock(ctx, batch, txn1, lock.Exclusive, testKey1, nil))
+	// Only Exclusive locks can be replicated.
+	require.Error(t, MVCCAcquireLock(ctx, batch, txn1, lock.Shared, testKey4, nil))
+
+	// The locks are not visible to MVCC reads, since they have no provisional
+	// value.
+	val, intent, err := MVCCGet(ctx, batch, testKey1, hlc.MaxTimestamp, MVCCGetOptions{})
+	require.NoError(t, err)
+	require.Nil(t, val)
+	require.Nil(t, intent)
+	res, err := MVCCScan(ctx, batch, testKey1, testKey4, hlc.MaxTimestamp, MVCCScanOptions{})
+	require.NoError(t, err)
+	require.Empty(t, res.KVs)
+	require.Empty(t, res.Intents)
+	intents, err := ScanIntents(ctx, batch, testKey1, testKey4, 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, intents)
+
+	// Other transactions and non-transactional writes conflict with the locks.
+	requireWriteIntentError(t, MVCCAcquireLock(ctx, batch, txn2, lock.Exclusive, testKey1, nil))
+	requireWriteIntentError(t, MVCCPut(ctx, batch, nil, testKey1, txn2.ReadTimestamp, value2, txn2))
+	requireWriteIntentError(t, MVCCPut(ctx, batch, nil, testKey1, txn2TS, value2, nil))
+
+	// The lock holder can write to the locked key.
+	require.NoError(t, MVCCPut(ctx, batch, nil, testKey1, txn1.ReadTimestamp, value1, txn1))
+
+	// Resolving the transaction's intents without finalizing it leaves the
+	// locks in place.
+	pushed := txn1.Clone()
+	pushed.WriteTimestamp = txn2TS
+	num, _, err := MVCCResolveWriteIntentRange(ctx, batch, nil,
+		roachpb.MakeLockUpdate(pushed, roachpb.Span{Key: testKey1, EndKey: testKey4}), 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), num)
+	requireWriteIntentError(t, MVCCAcquireLock(ctx, batch, txn2, lock.Exclusive, testKey2, nil))
+
+	// Committing the transaction releases the locks. A point resolution
+	// releases the lock on that key only.
+	committed := pushed.Clone()
+	committed.Status = roachpb.COMMITTED
+	ok, err := MVCCResolveWriteIntent(ctx, batch, nil,
+		roachpb.MakeLockUpdate(committed, roachpb.Span{Key: testKey3}))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, MVCCAcquireLock(ctx, batch, txn2, lock.Exclusive, testKey3, nil))
+	requireWriteIntentError(t, MVCCAcquireLock(ctx, batch, txn2, lock.Exclusive, testKey2, nil))
+
+	num, _, err = MVCCResolveWriteIntentRange(ctx, batch, nil,
+		roachpb.MakeLockUpdate(committed, roachpb.Span{Key: testKey1, EndKey: testKey3}), 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), num)
+	require.NoError(t, MVCCAcquireLock(ctx, batch, txn2, lock.Exclusive, testKey1, nil))
+	require.NoError(t, MVCCAcquireLock(ctx, batch, txn2, lock.Exclusive, testKey2, nil))
+
+	// The committed value written by txn1 is visible.
+	val, _, err = MVCCGet(ctx, batch, testKey1, hlc.MaxTimestamp, MVCCGetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, value1.RawBytes, val.RawBytes)
+}
+
 // TestMVCCResolveNewerIntent verifies that resolving a newer intent
 // than the committing transaction aborts the intent.
 func TestMVCCResolveNewerIntent(t *testing.T) {
@@ -1133,6 +1133,41 @@ func (p *Pebble) clearRange(start, end MVCCKey) error {
 	return p.db.DeleteRange(bufStart, bufEnd, pebble.Sync)
 }
 
+// ExperimentalClearMVCCRangeKey implements the Engine interface.
+func (p *Pebble) ExperimentalClearMVCCRangeKey(rangeKey MVCCRangeKey) error {
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	return p.db.Experimental().RangeKeyUnset(
+		EncodeMVCCKeyPrefix(rangeKey.StartKey),
+		EncodeMVCCKeyPrefix(rangeKey.EndKey),
+		EncodeMVCCTimestampSuffix(rangeKey.Timestamp),
+		pebble.Sync)
+}
+
+// ExperimentalClearAllMVCCRangeKeys implements the Engine interface.
+func (p *Pebble) ExperimentalClearAllMVCCRangeKeys(start, end roachpb.Key) error {
+	rangeKey := MVCCRangeKey{StartKey: start, EndKey: end, Timestamp: hlc.MinTimestamp}
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	return p.db.Experimental().RangeKeyDelete(
+		EncodeMVCCKeyPrefix(start), EncodeMVCCKeyPrefix(end), pebble.Sync)
+}
+
+// ExperimentalPutMVCCRangeKey implements the Engine interface.
+func (p *Pebble) ExperimentalPutMVCCRangeKey(rangeKey MVCCRangeKey, value []byte) error {
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	return p.db.Experimental().RangeKeySet(
+		EncodeMVCCKeyPrefix(rangeKey.StartKey),
+		EncodeMVCCKeyPrefix(rangeKey.EndKey),
+		EncodeMVCCTimestampSuffix(rangeKey.Timestamp),
+		value,
+		pebble.Sync)
+}
+
 // ClearIterRange implements the Engine interface.
 func (p *Pebble) ClearIterRange(iter MVCCIterator, start, end roachpb.Key) error {
 	// Write all the tombstones in one batch.
@@ -1630,6 +1665,10 @@ func (p *Pebble) SetMinVersion(version roachpb.Version) error {
 	formatVers := pebble.FormatMostCompatible
 	// Cases are ordered from newer to older versions.
 	switch {
+	case !version.Less(clusterversion.ByKey(clusterversion.EnsurePebbleFormatVersionRangeKeys)):
+		if formatVers < pebble.FormatRangeKeys {
+			formatVers = pebble.FormatRangeKeys
+		}
 	case !version.Less(clusterversion.ByKey(clusterversion.PebbleFormatBlockPropertyCollector)):
 		if formatVers < pebble.FormatBlockPropertyCollector {
 			formatVers = pebble.FormatBlockPropertyCollector
@@ -1812,8 +1851,9 @@ func (p *pebbleReadOnly) NewMVCCIterator(iterKind MVCCIterKind, opts IterOptions
 		return iter
 	}
 
-	if !opts.MinTimestampHint.IsEmpty() {
-		// MVCCIterators that specify timestamp bounds cannot be cached.
+	if !opts.MinTimestampHint.IsEmpty() || opts.KeyTypes != IterKeyTypePointsOnly {
+		// MVCCIterators that specify timestamp bounds or range keys cannot be
+		// cached.
 		iter := MVCCIterator(newPebbleIterator(p.parent.db, nil, opts, p.durability))
 		if util.RaceEnabled {
 			iter = wrapInUnsafeIter(iter)
@@ -1883,11 +1923,14 @@ func (p *pebbleReadOnly) NewEngineIterator(opts IterOptions) EngineIterator {
 
 // checkOptionsForIterReuse checks that the options are appropriate for
 // iterators that are reusable, and panics if not. This includes disallowing
-// any timestamp hints.
+// any timestamp hints and range keys.
 func checkOptionsForIterReuse(opts IterOptions) {
 	if !opts.MinTimestampHint.IsEmpty() || !opts.MaxTimestampHint.IsEmpty() {
 		panic("iterator with timestamp hints cannot be reused")
 	}
+	if opts.KeyTypes != IterKeyTypePointsOnly {
+		panic("iterator with range keys cannot be reused")
+	}
 	if !opts.Prefix && len(opts.UpperBound) == 0 && len(opts.LowerBound) == 0 {
 		panic("iterator must set prefix or upper bound or lower bound")
 	}
@@ -1956,6 +1999,18 @@ func (p *pebbleReadOnly) ClearIterRange(iter MVCCIterator, start, end roachpb.Ke
 	panic("not implemented")
 }
 
+func (p *pebbleReadOnly) ExperimentalClearMVCCRangeKey(rangeKey MVCCRangeKey) error {
+	panic("not implemented")
+}
+
+func (p *pebbleReadOnly) ExperimentalClearAllMVCCRangeKeys(start, end roachpb.Key) error {
+	panic("not implemented")
+}
+
+func (p *pebbleReadOnly) ExperimentalPutMVCCRangeKey(rangeKey MVCCRangeKey, value []byte) error {
+	panic("not implemented")
+}
+
 func (p *pebbleReadOnly) Merge(key MVCCKey, value []byte) error {
 	panic("not implemented")
 }
@@ -2152,6 +2207,16 @@ func pebbleExportToSst(
 			IntentPolicy:                        MVCCIncrementalIterIntentPolicyAggregate,
 		})
 	defer iter.Close()
+
+	// Read the MVCC range tombstones in the export's time bounds. Point keys
+	// covered by them are deleted and are skipped just like point tombstones
+	// below, while the range tombstones themselves are written to the SST
+	// whenever point tombstones would be.
+	rangeTombstones, err := iter.RangeTombstones(options.StartKey.Key)
+	if err != nil {
+		return roachpb.BulkOpSummary{}, MVCCKey{}, err
+	}
+
 	var curKey roachpb.Key // only used if exportAllRevisions
 	var resumeKey roachpb.Key
 	var resumeTS hlc.Timestamp
@@ -2209,9 +2274,14 @@ func pebbleExportToSst(
 		}
 
 		// Skip tombstone (len=0) records when start time is zero (non-incremental)
-		// and we are not exporting all versions.
+		// and we are not exporting all versions. This includes values deleted by
+		// range tombstones.
 		skipTombstones := !options.ExportAllRevisions && options.StartTS.IsEmpty()
-		if len(unsafeValue) > 0 || !skipTombstones {
+		deleted := len(unsafeValue) == 0
+		if !deleted && skipTombstones && !unsafeKey.Timestamp.IsEmpty() {
+			_, deleted = rangeTombstones.DeletedAt(unsafeKey.Key, unsafeKey.Timestamp)
+		}
+		if !deleted || !skipTombstones {
 			if err := rows.Count(unsafeKey.Key); err != nil {
 				return roachpb.BulkOpSummary{}, MVCCKey{}, errors.Wrapf(err, "decoding %s", unsafeKey)
 			}
@@ -2271,6 +2341,27 @@ func pebbleExportToSst(
 		return roachpb.BulkOpSummary{}, MVCCKey{}, err
 	}
 
+	// Write the range tombstones in the exported key span, truncating them to
+	// the resume key if the export was paginated.
+	if skipRangeTombstones := !options.ExportAllRevisions && options.StartTS.IsEmpty(); !skipRangeTombstones {
+		exportEnd := options.EndKey
+		if len(resumeKey) > 0 {
+			exportEnd = resumeKey
+		}
+		for _, rk := range rangeTombstones.RangeKeys() {
+			if rk.StartKey.Compare(exportEnd) >= 0 {
+				break
+			}
+			if rk.EndKey.Compare(exportEnd) > 0 {
+				rk.EndKey = exportEnd
+			}
+			if err := sstWriter.ExperimentalPutMVCCRangeKey(rk, nil /* value */); err != nil {
+				return roachpb.BulkOpSummary{}, MVCCKey{}, errors.Wrapf(err, "adding range key %s", rk)
+			}
+			rows.BulkOpSummary.DataSize += int64(len(rk.StartKey) + len(rk.EndKey))
+		}
+	}
+
 	if rows.BulkOpSummary.DataSize == 0 {
 		// If no records were added to the sstable, skip completing it and return a
 		// nil slice – the export code will discard it anyway (based on 0 DataSize).
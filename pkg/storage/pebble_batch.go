@@ -207,8 +207,9 @@ func (p *pebbleBatch) NewMVCCIterator(iterKind MVCCIterKind, opts IterOptions) M
 		return iter
 	}
 
-	if !opts.MinTimestampHint.IsEmpty() {
-		// MVCCIterators that specify timestamp bounds cannot be cached.
+	if !opts.MinTimestampHint.IsEmpty() || opts.KeyTypes != IterKeyTypePointsOnly {
+		// MVCCIterators that specify timestamp bounds or range keys cannot be
+		// cached.
 		iter := MVCCIterator(newPebbleIterator(p.batch, nil, opts, StandardDurability))
 		if util.RaceEnabled {
 			iter = wrapInUnsafeIter(iter)
@@ -386,6 +387,41 @@ func (p *pebbleBatch) clearRange(start, end MVCCKey) error {
 	return p.batch.DeleteRange(p.buf, buf2, nil)
 }
 
+// ExperimentalClearMVCCRangeKey implements the Batch interface.
+func (p *pebbleBatch) ExperimentalClearMVCCRangeKey(rangeKey MVCCRangeKey) error {
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	return p.batch.Experimental().RangeKeyUnset(
+		EncodeMVCCKeyPrefix(rangeKey.StartKey),
+		EncodeMVCCKeyPrefix(rangeKey.EndKey),
+		EncodeMVCCTimestampSuffix(rangeKey.Timestamp),
+		nil)
+}
+
+// ExperimentalClearAllMVCCRangeKeys implements the Batch interface.
+func (p *pebbleBatch) ExperimentalClearAllMVCCRangeKeys(start, end roachpb.Key) error {
+	rangeKey := MVCCRangeKey{StartKey: start, EndKey: end, Timestamp: hlc.MinTimestamp}
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	return p.batch.Experimental().RangeKeyDelete(
+		EncodeMVCCKeyPrefix(start), EncodeMVCCKeyPrefix(end), nil)
+}
+
+// ExperimentalPutMVCCRangeKey implements the Batch interface.
+func (p *pebbleBatch) ExperimentalPutMVCCRangeKey(rangeKey MVCCRangeKey, value []byte) error {
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	return p.batch.Experimental().RangeKeySet(
+		EncodeMVCCKeyPrefix(rangeKey.StartKey),
+		EncodeMVCCKeyPrefix(rangeKey.EndKey),
+		EncodeMVCCTimestampSuffix(rangeKey.Timestamp),
+		value,
+		nil)
+}
+
 // Clear implements the Batch interface.
 func (p *pebbleBatch) ClearIterRange(iter MVCCIterator, start, end roachpb.Key) error {
 	// Note that this method has the side effect of modifying iter's bounds.
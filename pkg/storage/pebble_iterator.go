@@ -63,6 +63,8 @@ type pebbleIterator struct {
 	// Stat tracking the number of sstables encountered during time-bound
 	// iteration. Only used for MVCCIterator.
 	timeBoundNumSSTables int
+	// Reusable buffer for range keys returned by RangeKeys().
+	rangeKeysBuf []MVCCRangeKeyValue
 }
 
 var _ MVCCIterator = &pebbleIterator{}
@@ -113,6 +115,7 @@ func (p *pebbleIterator) init(
 		keyBuf:        p.keyBuf,
 		lowerBoundBuf: p.lowerBoundBuf,
 		upperBoundBuf: p.upperBoundBuf,
+		rangeKeysBuf:  p.rangeKeysBuf,
 		prefix:        opts.Prefix,
 		reusable:      p.reusable,
 	}
@@ -143,7 +146,11 @@ func (p *pebbleIterator) init(
 		p.options.UpperBound = p.upperBoundBuf[0]
 	}
 
-	doClone := iterToClone != nil
+	p.options.KeyTypes = opts.KeyTypes
+
+	// A cloned iterator inherits the key types of the iterator it was cloned
+	// from, so iterators that surface range keys must be created from scratch.
+	doClone := iterToClone != nil && opts.KeyTypes == IterKeyTypePointsOnly
 	if !opts.MaxTimestampHint.IsEmpty() {
 		doClone = false
 		encodedMinTS := string(encodeMVCCTimestamp(opts.MinTimestampHint))
@@ -794,6 +801,63 @@ func (p *pebbleIterator) SupportsPrev() bool {
 	return true
 }
 
+// HasPointAndRange implements the MVCCIterator interface.
+func (p *pebbleIterator) HasPointAndRange() (bool, bool) {
+	if p.options.KeyTypes == IterKeyTypePointsOnly {
+		return true, false
+	}
+	return p.iter.HasPointAndRange()
+}
+
+// RangeBounds implements the MVCCIterator interface.
+func (p *pebbleIterator) RangeBounds() roachpb.Span {
+	if p.options.KeyTypes == IterKeyTypePointsOnly {
+		return roachpb.Span{}
+	}
+	start, end := p.iter.RangeBounds()
+
+	// NB: Like UnsafeKey(), we have no way to surface decoding errors here, so
+	// we silently return empty bounds.
+	startKey, err := DecodeMVCCKey(start)
+	if err != nil {
+		return roachpb.Span{}
+	}
+	endKey, err := DecodeMVCCKey(end)
+	if err != nil {
+		return roachpb.Span{}
+	}
+	return roachpb.Span{Key: startKey.Key, EndKey: endKey.Key}
+}
+
+// RangeKeys implements the MVCCIterator interface.
+func (p *pebbleIterator) RangeKeys() []MVCCRangeKeyValue {
+	if p.options.KeyTypes == IterKeyTypePointsOnly {
+		return nil
+	}
+	rangeKeys := p.iter.RangeKeys()
+	if len(rangeKeys) == 0 {
+		return nil
+	}
+	bounds := p.RangeBounds()
+	p.rangeKeysBuf = p.rangeKeysBuf[:0]
+	for _, rangeKey := range rangeKeys {
+		timestamp, err := decodeMVCCTimestampSuffix(rangeKey.Suffix)
+		if err != nil {
+			// NB: Silently skip undecodable range keys, see RangeBounds().
+			continue
+		}
+		p.rangeKeysBuf = append(p.rangeKeysBuf, MVCCRangeKeyValue{
+			Key: MVCCRangeKey{
+				StartKey:  bounds.Key,
+				EndKey:    bounds.EndKey,
+				Timestamp: timestamp,
+			},
+			Value: rangeKey.Value,
+		})
+	}
+	return p.rangeKeysBuf
+}
+
 // GetRawIter is part of the EngineIterator interface.
 func (p *pebbleIterator) GetRawIter() *pebble.Iterator {
 	return p.iter
@@ -817,6 +881,7 @@ func (p *pebbleIterator) destroy() {
 		keyBuf:        p.keyBuf,
 		lowerBoundBuf: p.lowerBoundBuf,
 		upperBoundBuf: p.upperBoundBuf,
+		rangeKeysBuf:  p.rangeKeysBuf[:0],
 		reusable:      p.reusable,
 	}
 }
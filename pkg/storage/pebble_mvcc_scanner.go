@@ -338,6 +338,12 @@ type pebbleMVCCScanner struct {
 	isGet                    bool
 	keyBuf                   []byte
 	savedBuf                 []byte
+	// rangeTombstones are the MVCC range tombstones overlapping the scan span,
+	// if any. Point key versions covered by a visible range tombstone are
+	// treated as if they had a point tombstone at the range tombstone's
+	// timestamp. rangeTombstoneBuf is used to encode those synthesized keys.
+	rangeTombstones   *MVCCRangeTombstones
+	rangeTombstoneBuf []byte
 	// cur* variables store the "current" record we're pointing to. Updated in
 	// updateCurrent. Note that the timestamp can be clobbered in the case of
 	// adding an intent from the intent history but is otherwise meaningful.
@@ -370,7 +376,8 @@ var pebbleMVCCScannerPool = sync.Pool{
 func (p *pebbleMVCCScanner) release() {
 	// Discard most memory references before placing in pool.
 	*p = pebbleMVCCScanner{
-		keyBuf: p.keyBuf,
+		keyBuf:            p.keyBuf,
+		rangeTombstoneBuf: p.rangeTombstoneBuf,
 	}
 	pebbleMVCCScannerPool.Put(p)
 }
@@ -899,6 +906,39 @@ func (p *pebbleMVCCScanner) advanceKeyAtNewKey(key []byte) bool {
 func (p *pebbleMVCCScanner) addAndAdvance(
 	ctx context.Context, key roachpb.Key, rawKey []byte, val []byte,
 ) bool {
+	// Check whether the version is deleted by an MVCC range tombstone. Range
+	// tombstones above the read timestamp are not visible, but must be checked
+	// for write-too-old and uncertainty conflicts like point versions. Range
+	// tombstones below the version's timestamp don't affect it.
+	//
+	// NB: range tombstones are only considered for keys that have point
+	// versions, since there is nothing to delete otherwise.
+	if p.rangeTombstones != nil && !p.curUnsafeKey.Timestamp.IsEmpty() {
+		for _, ts := range p.rangeTombstones.covering(key) {
+			if ts.Less(p.curUnsafeKey.Timestamp) {
+				break
+			}
+			if ts.LessEq(p.ts) && !(p.failOnMoreRecent && ts.EqOrdering(p.ts)) {
+				// The version is deleted by a visible range tombstone, so emit a
+				// point tombstone at the range tombstone's timestamp instead.
+				p.rangeTombstoneBuf = EncodeMVCCKeyToBuf(
+					p.rangeTombstoneBuf[:0], MVCCKey{Key: key, Timestamp: ts})
+				rawKey, val = p.rangeTombstoneBuf, nil
+				break
+			}
+			if p.failOnMoreRecent {
+				p.mostRecentTS.Forward(ts)
+				if len(p.mostRecentKey) == 0 {
+					p.mostRecentKey = append(p.mostRecentKey, key...)
+				}
+				return p.advanceKey()
+			}
+			if p.checkUncertainty && p.uncertainty.IsUncertain(ts) {
+				return p.uncertaintyError(ts)
+			}
+		}
+	}
+
 	// Don't include deleted versions len(val) == 0, unless we've been instructed
 	// to include tombstones in the results.
 	if len(val) == 0 && !p.tombstones {
@@ -12,9 +12,12 @@ package storage
 
 import (
 	"bytes"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/sstable"
 	"github.com/cockroachdb/pebble/vfs"
 )
@@ -158,3 +161,79 @@ func (r *sstIterator) UnsafeKey() MVCCKey {
 func (r *sstIterator) UnsafeValue() []byte {
 	return r.value
 }
+
+// ReadSSTRangeTombstones reads the MVCC range tombstones overlapping
+// [start,end) from the SST that iter iterates over, truncated to the span. The
+// iterator must have been returned by NewSSTIterator or NewMemSSTIterator. It
+// returns nil if there are no range tombstones in the span.
+//
+// sstIterator only surfaces point keys, so readers of SSTs that may contain
+// range tombstones, such as those written by ExportMVCCToSst, must read them
+// separately with this.
+func ReadSSTRangeTombstones(iter SimpleMVCCIterator, start, end roachpb.Key) (*MVCCRangeTombstones, error) {
+	r, ok := iter.(*sstIterator)
+	if !ok {
+		return nil, errors.AssertionFailedf("cannot read range tombstones from a %T", iter)
+	}
+	rangeIter, err := r.sst.NewRawRangeKeyIter()
+	if err != nil {
+		return nil, err
+	}
+	if rangeIter == nil {
+		// The SST has no range key block.
+		return nil, nil
+	}
+	defer rangeIter.Close()
+
+	var t *MVCCRangeTombstones
+	for s := rangeIter.SeekGE(EncodeMVCCKeyPrefix(start)); s != nil; s = rangeIter.Next() {
+		startKey, err := DecodeMVCCKey(s.Start)
+		if err != nil {
+			return nil, err
+		}
+		endKey, err := DecodeMVCCKey(s.End)
+		if err != nil {
+			return nil, err
+		}
+		span := roachpb.Span{Key: startKey.Key.Clone(), EndKey: endKey.Key.Clone()}
+		if span.Key.Compare(end) >= 0 {
+			break
+		}
+		if span.Key.Compare(start) < 0 {
+			span.Key = start
+		}
+		if span.EndKey.Compare(end) > 0 {
+			span.EndKey = end
+		}
+
+		var timestamps []hlc.Timestamp
+		for _, k := range s.Keys {
+			if k.Kind() != pebble.InternalKeyKindRangeKeySet {
+				continue
+			}
+			if len(k.Value) != 0 {
+				return nil, errors.AssertionFailedf("unsupported MVCC range key value at %s", span)
+			}
+			ts, err := decodeMVCCTimestampSuffix(k.Suffix)
+			if err != nil {
+				return nil, err
+			}
+			timestamps = append(timestamps, ts)
+		}
+		if len(timestamps) == 0 {
+			continue
+		}
+		sort.Slice(timestamps, func(i, j int) bool { return timestamps[j].Less(timestamps[i]) })
+		if t == nil {
+			t = &MVCCRangeTombstones{}
+		}
+		t.fragments = append(t.fragments, mvccRangeTombstoneFragment{
+			span:       span,
+			timestamps: timestamps,
+		})
+	}
+	if err := rangeIter.Error(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
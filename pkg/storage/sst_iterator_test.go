@@ -17,12 +17,14 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/pebble/vfs"
+	"github.com/stretchr/testify/require"
 )
 
 func runTestSSTIterator(t *testing.T, iter SimpleMVCCIterator, allKVs []MVCCKeyValue) {
@@ -130,3 +132,44 @@ func TestSSTIterator(t *testing.T) {
 		runTestSSTIterator(t, iter, allKVs)
 	})
 }
+
+func TestReadSSTRangeTombstones(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	sstFile := &MemFile{}
+	sst := MakeBackupSSTWriter(ctx, st, sstFile)
+	defer sst.Close()
+	require.NoError(t, sst.PutMVCC(MVCCKey{Key: roachpb.Key("c"), Timestamp: hlc.Timestamp{WallTime: 1}}, []byte("c")))
+	for _, rk := range []MVCCRangeKey{
+		{StartKey: roachpb.Key("b"), EndKey: roachpb.Key("d"), Timestamp: hlc.Timestamp{WallTime: 5}},
+		{StartKey: roachpb.Key("e"), EndKey: roachpb.Key("g"), Timestamp: hlc.Timestamp{WallTime: 7}},
+	} {
+		require.NoError(t, sst.ExperimentalPutMVCCRangeKey(rk, nil))
+	}
+	require.NoError(t, sst.Finish())
+
+	iter, err := NewMemSSTIterator(sstFile.Data(), false)
+	require.NoError(t, err)
+	defer iter.Close()
+
+	tombstones, err := ReadSSTRangeTombstones(iter, roachpb.Key("c"), roachpb.Key("f"))
+	require.NoError(t, err)
+	require.Equal(t, []MVCCRangeKey{
+		{StartKey: roachpb.Key("c"), EndKey: roachpb.Key("d"), Timestamp: hlc.Timestamp{WallTime: 5}},
+		{StartKey: roachpb.Key("e"), EndKey: roachpb.Key("f"), Timestamp: hlc.Timestamp{WallTime: 7}},
+	}, tombstones.RangeKeys())
+
+	ts, ok := tombstones.DeletedAt(roachpb.Key("c"), hlc.Timestamp{WallTime: 1})
+	require.True(t, ok)
+	require.Equal(t, hlc.Timestamp{WallTime: 5}, ts)
+	_, ok = tombstones.DeletedAt(roachpb.Key("d"), hlc.Timestamp{WallTime: 1})
+	require.False(t, ok)
+
+	// Spans without range tombstones read none.
+	tombstones, err = ReadSSTRangeTombstones(iter, roachpb.Key("x"), roachpb.Key("z"))
+	require.NoError(t, err)
+	require.True(t, tombstones.Empty())
+}
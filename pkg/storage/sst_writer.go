@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble/sstable"
@@ -63,6 +64,8 @@ func MakeIngestionWriterOptions(ctx context.Context, cs *cluster.Settings) sstab
 	format := sstable.TableFormatRocksDBv2
 	// Cases are ordered from newer to older versions.
 	switch {
+	case cs.Version.IsActive(ctx, clusterversion.EnablePebbleFormatVersionRangeKeys):
+		format = sstable.TableFormatPebblev2 // Range keys.
 	case cs.Version.IsActive(ctx, clusterversion.EnablePebbleFormatVersionBlockProperties):
 		format = sstable.TableFormatPebblev1 // Block properties.
 	}
@@ -77,11 +80,15 @@ func MakeIngestionWriterOptions(ctx context.Context, cs *cluster.Settings) sstab
 
 // MakeBackupSSTWriter creates a new SSTWriter tailored for backup SSTs which
 // are typically only ever iterated in their entirety.
-func MakeBackupSSTWriter(_ context.Context, _ *cluster.Settings, f io.Writer) SSTWriter {
+func MakeBackupSSTWriter(ctx context.Context, cs *cluster.Settings, f io.Writer) SSTWriter {
 	// By default, take a conservative approach and assume we don't have newer
 	// table features available. Upgrade to an appropriate version only if the
-	// cluster supports it.
-	opts := DefaultPebbleOptions().MakeWriterOptions(0, sstable.TableFormatRocksDBv2)
+	// cluster supports it. Range keys are needed to export MVCC range tombstones.
+	format := sstable.TableFormatRocksDBv2
+	if cs.Version.IsActive(ctx, clusterversion.EnablePebbleFormatVersionRangeKeys) {
+		format = sstable.TableFormatPebblev2 // Range keys.
+	}
+	opts := DefaultPebbleOptions().MakeWriterOptions(0, format)
 	// Don't need BlockPropertyCollectors for backups.
 	opts.BlockPropertyCollectors = nil
 
@@ -146,6 +153,50 @@ func (fw *SSTWriter) clearRange(start, end MVCCKey) error {
 	return fw.fw.DeleteRange(fw.scratch, EncodeMVCCKey(end))
 }
 
+// ExperimentalClearMVCCRangeKey implements the Writer interface.
+func (fw *SSTWriter) ExperimentalClearMVCCRangeKey(rangeKey MVCCRangeKey) error {
+	if fw.fw == nil {
+		return errors.New("cannot call ExperimentalClearMVCCRangeKey on a closed writer")
+	}
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	fw.DataSize += int64(len(rangeKey.StartKey)) + int64(len(rangeKey.EndKey))
+	return fw.fw.RangeKeyUnset(
+		EncodeMVCCKeyPrefix(rangeKey.StartKey),
+		EncodeMVCCKeyPrefix(rangeKey.EndKey),
+		EncodeMVCCTimestampSuffix(rangeKey.Timestamp))
+}
+
+// ExperimentalClearAllMVCCRangeKeys implements the Writer interface.
+func (fw *SSTWriter) ExperimentalClearAllMVCCRangeKeys(start, end roachpb.Key) error {
+	if fw.fw == nil {
+		return errors.New("cannot call ExperimentalClearAllMVCCRangeKeys on a closed writer")
+	}
+	rangeKey := MVCCRangeKey{StartKey: start, EndKey: end, Timestamp: hlc.MinTimestamp}
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	fw.DataSize += int64(len(start)) + int64(len(end))
+	return fw.fw.RangeKeyDelete(EncodeMVCCKeyPrefix(start), EncodeMVCCKeyPrefix(end))
+}
+
+// ExperimentalPutMVCCRangeKey implements the Writer interface.
+func (fw *SSTWriter) ExperimentalPutMVCCRangeKey(rangeKey MVCCRangeKey, value []byte) error {
+	if fw.fw == nil {
+		return errors.New("cannot call ExperimentalPutMVCCRangeKey on a closed writer")
+	}
+	if err := rangeKey.Validate(); err != nil {
+		return err
+	}
+	fw.DataSize += int64(len(rangeKey.StartKey)) + int64(len(rangeKey.EndKey)) + int64(len(value))
+	return fw.fw.RangeKeySet(
+		EncodeMVCCKeyPrefix(rangeKey.StartKey),
+		EncodeMVCCKeyPrefix(rangeKey.EndKey),
+		EncodeMVCCTimestampSuffix(rangeKey.Timestamp),
+		value)
+}
+
 // Put puts a kv entry into the sstable being built. An error is returned if it
 // is not greater than any previously added entry (according to the comparator
 // configured during writer creation). `Close` cannot have been called.
@@ -589,6 +589,13 @@ var charts = []sectionDescription{
 					"range.snapshots.applied-non-voter",
 				},
 			},
+			{
+				Title: "Delegated Snapshots",
+				Metrics: []string{
+					"range.snapshots.delegate.successes",
+					"range.snapshots.delegate.failures",
+				},
+			},
 		},
 	},
 	{
@@ -624,6 +631,10 @@ var charts = []sectionDescription{
 				Title:   "QPS",
 				Metrics: []string{"rebalancing.queriespersecond"},
 			},
+			{
+				Title:   "CPU",
+				Metrics: []string{"rebalancing.cpunanospersecond"},
+			},
 		},
 	},
 	{
@@ -3005,6 +3016,9 @@ var charts = []sectionDescription{
 					"admission.requested.kv-stores",
 					"admission.admitted.kv-stores",
 					"admission.errored.kv-stores",
+					"admission.requested.kv-elastic-stores",
+					"admission.admitted.kv-elastic-stores",
+					"admission.errored.kv-elastic-stores",
 					"admission.requested.sql-kv-response",
 					"admission.admitted.sql-kv-response",
 					"admission.errored.sql-kv-response",
@@ -3024,6 +3038,7 @@ var charts = []sectionDescription{
 				Metrics: []string{
 					"admission.wait_queue_length.kv",
 					"admission.wait_queue_length.kv-stores",
+					"admission.wait_queue_length.kv-elastic-stores",
 					"admission.wait_queue_length.sql-kv-response",
 					"admission.wait_queue_length.sql-sql-response",
 					"admission.wait_queue_length.sql-leaf-start",
@@ -3035,6 +3050,7 @@ var charts = []sectionDescription{
 				Metrics: []string{
 					"admission.wait_sum.kv",
 					"admission.wait_sum.kv-stores",
+					"admission.wait_sum.kv-elastic-stores",
 					"admission.wait_sum.sql-kv-response",
 					"admission.wait_sum.sql-sql-response",
 					"admission.wait_sum.sql-leaf-start",
@@ -3046,6 +3062,7 @@ var charts = []sectionDescription{
 				Metrics: []string{
 					"admission.wait_durations.kv",
 					"admission.wait_durations.kv-stores",
+					"admission.wait_durations.kv-elastic-stores",
 					"admission.wait_durations.sql-kv-response",
 					"admission.wait_durations.sql-sql-response",
 					"admission.wait_durations.sql-leaf-start",
@@ -3067,6 +3084,12 @@ var charts = []sectionDescription{
 					"admission.granter.io_tokens_exhausted_duration.kv",
 				},
 			},
+			{
+				Title: "Elastic Disk Bandwidth Tokens Exhausted Duration Sum",
+				Metrics: []string{
+					"admission.granter.disk_bandwidth_tokens_exhausted_duration.kv-elastic",
+				},
+			},
 		},
 	},
 }
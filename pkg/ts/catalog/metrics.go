@@ -41,56 +41,57 @@ var _ = `
 `
 
 var histogramMetricsNames = map[string]struct{}{
-	"sql.txn.latency.internal":                  {},
-	"sql.conn.latency":                          {},
-	"sql.mem.sql.session.max":                   {},
-	"sql.stats.flush.duration":                  {},
-	"changefeed.checkpoint_hist_nanos":          {},
-	"admission.wait_durations.sql-sql-response": {},
-	"admission.wait_durations.sql-kv-response":  {},
-	"sql.exec.latency":                          {},
-	"sql.stats.mem.max.internal":                {},
-	"admission.wait_durations.sql-leaf-start":   {},
-	"sql.disk.distsql.max":                      {},
-	"txn.restarts":                              {},
-	"sql.stats.flush.duration.internal":         {},
-	"sql.distsql.exec.latency":                  {},
-	"sql.mem.internal.txn.max":                  {},
-	"changefeed.emit_hist_nanos":                {},
-	"changefeed.flush_hist_nanos":               {},
-	"sql.service.latency":                       {},
-	"round-trip-latency":                        {},
-	"admission.wait_durations.kv":               {},
-	"sql.mem.distsql.max":                       {},
-	"kv.prober.write.latency":                   {},
-	"exec.latency":                              {},
-	"admission.wait_durations.sql-root-start":   {},
-	"sql.mem.bulk.max":                          {},
-	"sql.distsql.flows.queue_wait":              {},
-	"sql.txn.latency":                           {},
-	"sql.mem.root.max":                          {},
-	"admission.wait_durations.kv-stores":        {},
-	"sql.stats.mem.max":                         {},
-	"sql.distsql.service.latency.internal":      {},
-	"sql.stats.reported.mem.max.internal":       {},
-	"sql.stats.reported.mem.max":                {},
-	"sql.exec.latency.internal":                 {},
-	"sql.mem.internal.session.max":              {},
-	"sql.distsql.exec.latency.internal":         {},
-	"kv.prober.read.latency":                    {},
-	"sql.distsql.service.latency":               {},
-	"sql.service.latency.internal":              {},
-	"sql.mem.sql.txn.max":                       {},
-	"liveness.heartbeatlatency":                 {},
-	"txn.durations":                             {},
-	"raft.process.handleready.latency":          {},
-	"raft.process.commandcommit.latency":        {},
-	"raft.process.logcommit.latency":            {},
-	"raft.scheduler.latency":                    {},
-	"txnwaitqueue.pusher.wait_time":             {},
-	"txnwaitqueue.query.wait_time":              {},
-	"raft.process.applycommitted.latency":       {},
-	"sql.stats.txn_stats_collection.duration":   {},
+	"sql.txn.latency.internal":                   {},
+	"sql.conn.latency":                           {},
+	"sql.mem.sql.session.max":                    {},
+	"sql.stats.flush.duration":                   {},
+	"changefeed.checkpoint_hist_nanos":           {},
+	"admission.wait_durations.sql-sql-response":  {},
+	"admission.wait_durations.sql-kv-response":   {},
+	"sql.exec.latency":                           {},
+	"sql.stats.mem.max.internal":                 {},
+	"admission.wait_durations.sql-leaf-start":    {},
+	"sql.disk.distsql.max":                       {},
+	"txn.restarts":                               {},
+	"sql.stats.flush.duration.internal":          {},
+	"sql.distsql.exec.latency":                   {},
+	"sql.mem.internal.txn.max":                   {},
+	"changefeed.emit_hist_nanos":                 {},
+	"changefeed.flush_hist_nanos":                {},
+	"sql.service.latency":                        {},
+	"round-trip-latency":                         {},
+	"admission.wait_durations.kv":                {},
+	"sql.mem.distsql.max":                        {},
+	"kv.prober.write.latency":                    {},
+	"exec.latency":                               {},
+	"admission.wait_durations.sql-root-start":    {},
+	"sql.mem.bulk.max":                           {},
+	"sql.distsql.flows.queue_wait":               {},
+	"sql.txn.latency":                            {},
+	"sql.mem.root.max":                           {},
+	"admission.wait_durations.kv-stores":         {},
+	"admission.wait_durations.kv-elastic-stores": {},
+	"sql.stats.mem.max":                          {},
+	"sql.distsql.service.latency.internal":       {},
+	"sql.stats.reported.mem.max.internal":        {},
+	"sql.stats.reported.mem.max":                 {},
+	"sql.exec.latency.internal":                  {},
+	"sql.mem.internal.session.max":               {},
+	"sql.distsql.exec.latency.internal":          {},
+	"kv.prober.read.latency":                     {},
+	"sql.distsql.service.latency":                {},
+	"sql.service.latency.internal":               {},
+	"sql.mem.sql.txn.max":                        {},
+	"liveness.heartbeatlatency":                  {},
+	"txn.durations":                              {},
+	"raft.process.handleready.latency":           {},
+	"raft.process.commandcommit.latency":         {},
+	"raft.process.logcommit.latency":             {},
+	"raft.scheduler.latency":                     {},
+	"txnwaitqueue.pusher.wait_time":              {},
+	"txnwaitqueue.query.wait_time":               {},
+	"raft.process.applycommitted.latency":        {},
+	"sql.stats.txn_stats_collection.duration":    {},
 }
 
 func allInternalTSMetricsNames() []string {
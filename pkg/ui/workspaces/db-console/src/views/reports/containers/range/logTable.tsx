@@ -44,6 +44,13 @@ function printLogEventType(
     case protos.cockroach.kv.kvserver.storagepb.RangeLogEventType
       .unsafe_quorum_recovery:
       return "Unsafe Quorum Recovery";
+    case protos.cockroach.kv.kvserver.storagepb.RangeLogEventType.repair_range:
+      return "Repair Range";
+    case protos.cockroach.kv.kvserver.storagepb.RangeLogEventType.add_witness:
+      return "Add Witness";
+    case protos.cockroach.kv.kvserver.storagepb.RangeLogEventType
+      .remove_witness:
+      return "Remove Witness";
     default:
       return "Unknown";
   }
@@ -0,0 +1,282 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package admission
+
+import (
+	"context"
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/redact"
+)
+
+// ProvisionedBandwidth is the provisioned disk bandwidth of each store, used
+// when the store does not supply its own value in DiskStats.
+var ProvisionedBandwidth = settings.RegisterByteSizeSetting(
+	settings.SystemOnly,
+	"admission.store.provisioned_bandwidth",
+	"the provisioned bandwidth (in bytes/s) of the disk backing each store, used to throttle "+
+		"elastic work like bulk jobs and GC when disk bandwidth utilization is high; "+
+		"0 disables the throttling",
+	0, settings.NonNegativeInt).WithPublic()
+
+// diskLoadLevel is a coarse classification of the disk bandwidth
+// utilization of a store over an adjustmentInterval.
+type diskLoadLevel int8
+
+const (
+	// diskLoadLow implies no need to throttle elastic work.
+	diskLoadLow diskLoadLevel = iota
+	// diskLoadModerate implies no need to start throttling elastic work, but
+	// if it is already being throttled, the throttling can be relaxed
+	// gradually.
+	diskLoadModerate
+	// diskLoadHigh implies that elastic work should be throttled.
+	diskLoadHigh
+	// diskLoadOverload implies that elastic work should be throttled
+	// aggressively, since the disk is close to (or beyond) its provisioned
+	// bandwidth and foreground work will start to suffer.
+	diskLoadOverload
+)
+
+// The utilization thresholds at which we transition into the next
+// diskLoadLevel. Cloud block devices (like EBS, GCP PD) often show high
+// latency well before the provisioned bandwidth is reached, so the
+// thresholds are conservative.
+const (
+	diskLoadLowUtilThreshold      = 0.3
+	diskLoadModerateUtilThreshold = 0.7
+	diskLoadHighUtilThreshold     = 0.95
+)
+
+func diskLoadLevelForUtil(util float64) diskLoadLevel {
+	switch {
+	case util < diskLoadLowUtilThreshold:
+		return diskLoadLow
+	case util < diskLoadModerateUtilThreshold:
+		return diskLoadModerate
+	case util < diskLoadHighUtilThreshold:
+		return diskLoadHigh
+	default:
+		return diskLoadOverload
+	}
+}
+
+// SafeValue implements the redact.SafeValue interface.
+func (l diskLoadLevel) SafeValue() {}
+
+func (l diskLoadLevel) String() string {
+	switch l {
+	case diskLoadLow:
+		return "low"
+	case diskLoadModerate:
+		return "moderate"
+	case diskLoadHigh:
+		return "high"
+	case diskLoadOverload:
+		return "overload"
+	default:
+		return "unknown"
+	}
+}
+
+var _ redact.SafeValue = diskLoadLevel(0)
+
+// minElasticDiskBandwidthTokens is the minimum number of tokens given to
+// elastic work in an adjustmentInterval, so that it is never completely
+// starved, i.e., at least one admission per second.
+const minElasticDiskBandwidthTokens = adjustmentInterval
+
+// granterWithDiskBandwidthTokens is used to abstract kvElasticGranter for
+// testing.
+type granterWithDiskBandwidthTokens interface {
+	// setAvailableDiskBandwidthTokensLocked bounds the available tokens that
+	// can be granted to elastic work. The same caveats apply as in
+	// granterWithIOTokens.setAvailableIOTokensLocked. This method needs to be
+	// called periodically.
+	setAvailableDiskBandwidthTokensLocked(tokens int64)
+}
+
+// diskBandwidthLimiter adjusts tokens in kvElasticGranter based on the
+// observed read and write bandwidth of the disk backing a store, relative to
+// its provisioned bandwidth. Unlike the ioLoadListener, which protects the
+// health of the LSM, the goal here is to prevent elastic work from
+// saturating the disk, since cloud disks with a provisioned bandwidth
+// throttle all IO once that bandwidth is exceeded, which hurts foreground
+// work. Regular KVWork is never throttled by this limiter.
+//
+// The disk bandwidth includes reads and writes due to flushes and
+// compactions, whose size we cannot attribute to individual admitted work.
+// So the limiter does not try to model bytes per work, and instead uses the
+// observed load level to multiplicatively adjust the number of elastic
+// admissions, relative to what elastic work was recently admitted. This is
+// a feedback loop with a delay of one adjustmentInterval.
+type diskBandwidthLimiter struct {
+	storeID          int32
+	settings         *cluster.Settings
+	elasticRequester requester
+	mu               struct {
+		// Used when changing state in kvElasticGranter. This is a pointer since
+		// it is the same as GrantCoordinator.mu.
+		*syncutil.Mutex
+		granter granterWithDiskBandwidthTokens
+	}
+
+	// Cumulative stats used to compute interval stats.
+	statsInitialized     bool
+	elasticAdmittedCount uint64
+	bytesRead            uint64
+	bytesWritten         uint64
+	// The number of intervals since the cumulative stats were last installed,
+	// minus one. It is non-zero when the disk stats were unavailable in some
+	// intervals, in which case the next interval stats span multiple
+	// intervals.
+	skippedIntervals int
+	// Exponentially smoothed per interval value.
+	smoothedElasticAdmit float64
+
+	// The state computed in the last call to adjustTokens.
+	utilization float64
+	loadLevel   diskLoadLevel
+
+	// totalTokens represents the tokens to give out until the next call to
+	// adjustTokens. They are given out with smoothing, like in
+	// ioLoadListener.
+	totalTokens     int64
+	tokensAllocated int64
+}
+
+// diskStatsTick is called every adjustmentInterval seconds, and decides the
+// token allocations until the next call.
+func (d *diskBandwidthLimiter) diskStatsTick(ctx context.Context, ds DiskStats) {
+	if ds.Unavailable {
+		// Keep the cumulative stats as the baseline for the next interval with
+		// stats, and keep giving out the tokens of the last interval.
+		if d.statsInitialized {
+			d.skippedIntervals++
+			d.tokensAllocated = 0
+		}
+		return
+	}
+	if !d.statsInitialized {
+		d.statsInitialized = true
+		// Initialize cumulative stats.
+		d.elasticAdmittedCount = d.elasticRequester.getAdmittedCount()
+		d.bytesRead = ds.BytesRead
+		d.bytesWritten = ds.BytesWritten
+		// No initial limit, i.e, the first interval is unlimited.
+		d.totalTokens = unlimitedTokens
+		return
+	}
+	d.adjustTokens(ctx, ds)
+}
+
+// allocateTokensTick gives out 1/adjustmentInterval of the totalTokens every
+// 1s.
+func (d *diskBandwidthLimiter) allocateTokensTick() {
+	toAllocate := tokensToAllocateForTick(d.totalTokens, d.tokensAllocated)
+	if toAllocate > 0 {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.tokensAllocated += toAllocate
+		d.mu.granter.setAvailableDiskBandwidthTokensLocked(toAllocate)
+	}
+}
+
+// adjustTokens computes a new value of totalTokens (and resets
+// tokensAllocated).
+func (d *diskBandwidthLimiter) adjustTokens(ctx context.Context, ds DiskStats) {
+	d.tokensAllocated = 0
+	// Grab the cumulative stats.
+	elasticAdmittedCount := d.elasticRequester.getAdmittedCount()
+	// Compute the stats for the interval. These are simple delta computations
+	// over cumulative stats, so should not be negative.
+	var admitted uint64
+	if elasticAdmittedCount < d.elasticAdmittedCount {
+		log.Warningf(ctx, "elastic admitted count decreased from %d to %d",
+			d.elasticAdmittedCount, elasticAdmittedCount)
+	} else {
+		admitted = elasticAdmittedCount - d.elasticAdmittedCount
+	}
+	var bytesRead, bytesWritten uint64
+	if ds.BytesRead < d.bytesRead || ds.BytesWritten < d.bytesWritten {
+		log.Warningf(ctx, "disk bytes decreased from (read: %d, written: %d) to (%d, %d)",
+			d.bytesRead, d.bytesWritten, ds.BytesRead, ds.BytesWritten)
+	} else {
+		bytesRead = ds.BytesRead - d.bytesRead
+		bytesWritten = ds.BytesWritten - d.bytesWritten
+	}
+	// The interval stats span multiple intervals if the disk stats were
+	// unavailable in some of them. Since the smoothing is per interval, we
+	// treat them as having been evenly spread across those intervals.
+	intervals := float64(1 + d.skippedIntervals)
+	const alpha = 0.5
+	d.smoothedElasticAdmit = alpha*float64(admitted)/intervals + (1-alpha)*d.smoothedElasticAdmit
+
+	provisionedBandwidth := ds.ProvisionedBandwidth
+	if provisionedBandwidth <= 0 {
+		provisionedBandwidth = ProvisionedBandwidth.Get(&d.settings.SV)
+	}
+	if provisionedBandwidth <= 0 {
+		// Disk bandwidth based admission control is disabled.
+		d.utilization = 0
+		d.loadLevel = diskLoadLow
+		d.totalTokens = unlimitedTokens
+	} else {
+		d.utilization = float64(bytesRead+bytesWritten) /
+			(float64(provisionedBandwidth) * adjustmentInterval * intervals)
+		d.loadLevel = diskLoadLevelForUtil(d.utilization)
+		var tokens float64
+		switch d.loadLevel {
+		case diskLoadLow:
+			tokens = unlimitedTokens
+		case diskLoadModerate:
+			if d.totalTokens == unlimitedTokens {
+				// Not already throttling, and no need to start.
+				tokens = unlimitedTokens
+			} else {
+				// Relax the throttling gradually. We don't want the tokens to grow
+				// without bound when elastic work is not using them, since a
+				// sudden burst of elastic work would then go unthrottled for an
+				// interval, so cap them relative to what was admitted.
+				tokens = math.Min(1.1*float64(d.totalTokens), 2*d.smoothedElasticAdmit)
+			}
+		case diskLoadHigh:
+			tokens = 0.9 * d.smoothedElasticAdmit
+		case diskLoadOverload:
+			tokens = 0.5 * d.smoothedElasticAdmit
+		}
+		if tokens < minElasticDiskBandwidthTokens {
+			tokens = minElasticDiskBandwidthTokens
+		}
+		if tokens >= float64(unlimitedTokens) {
+			// Also avoids overflow.
+			d.totalTokens = unlimitedTokens
+		} else {
+			d.totalTokens = int64(tokens)
+		}
+		if d.loadLevel >= diskLoadHigh {
+			log.Infof(ctx,
+				"disk bandwidth load %s on store %d (util %.2f, read %d, written %d, provisioned %d/s): "+
+					"elastic admitted: %d, smoothed %f, tokens: %d",
+				d.loadLevel, d.storeID, d.utilization, bytesRead, bytesWritten, provisionedBandwidth,
+				admitted, d.smoothedElasticAdmit, d.totalTokens)
+		}
+	}
+	// Install the latest cumulative stats.
+	d.elasticAdmittedCount = elasticAdmittedCount
+	d.bytesRead = ds.BytesRead
+	d.bytesWritten = ds.BytesWritten
+	d.skippedIntervals = 0
+}
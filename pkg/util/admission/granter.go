@@ -231,6 +231,15 @@ type WorkKind int8
 //   appear. The typical solution to this is to put a max on the number of
 //   slots low priority can use. This would be viable if we did not allow
 //   arbitrary int8 values to be set for WorkPriority.
+//
+// KVElasticWork is placed last, and is only used in the per-store
+// GrantCoordinators. It represents elastic KV work (see IsElasticWork), which
+// is subject to the same IO tokens as KVWork, and additionally to disk
+// bandwidth tokens. Unlike the store's KVWork, it includes reads, like the
+// exports done by backups, since they consume disk bandwidth too. Elastic
+// reads don't add to L0, but still take IO tokens, which only throttles them
+// further when the LSM is overloaded. Since KVWork precedes it, regular work
+// gets the first claim on IO tokens when both are waiting.
 
 const (
 	// KVWork represents requests submitted to the KV layer, from the same node
@@ -251,6 +260,12 @@ const (
 	// SQLStatementRootStartWork represents the start of root-level processing
 	// for a SQL statement.
 	SQLStatementRootStartWork
+	// KVElasticWork represents elastic KV writes to a store, like those due to
+	// bulk jobs and GC. It is only used in the per-store GrantCoordinators,
+	// and is throttled based on the disk bandwidth utilization of the store.
+	// This work also passes through the KVWork queue of the regular
+	// GrantCoordinator for CPU admission.
+	KVElasticWork
 	numWorkKinds
 )
 
@@ -266,6 +281,8 @@ func workKindString(workKind WorkKind) redact.RedactableString {
 		return "sql-leaf-start"
 	case SQLStatementRootStartWork:
 		return "sql-root-start"
+	case KVElasticWork:
+		return "kv-elastic"
 	default:
 		panic(errors.AssertionFailedf("unknown WorkKind"))
 	}
@@ -554,6 +571,99 @@ func (sg *kvGranter) setAvailableIOTokensLocked(tokens int64) {
 	}
 }
 
+// kvElasticGranter implements granterWithLockedCalls. It is used in the
+// per-store GrantCoordinators for grants to KVElasticWork. Each grant
+// consumes a disk bandwidth token, and a slot and IO token from the kvGranter
+// of the same store, so that elastic work does not escape the IO token
+// accounting done for regular KVWork.
+type kvElasticGranter struct {
+	coord     *GrantCoordinator
+	requester requester
+	// kvGranter is the granter for KVWork in the same GrantCoordinator.
+	kvGranter *kvGranter
+
+	diskBandwidthTokensEnabled bool
+	// Like availableIOTokens in kvGranter, these are all burst tokens.
+	availableDiskBandwidthTokens int64
+
+	// Metric pointers can be nil.
+	diskBandwidthTokensExhaustedDurationMetric *metric.Counter
+	exhaustedStart                             time.Time
+}
+
+var _ granterWithLockedCalls = &kvElasticGranter{}
+
+func (eg *kvElasticGranter) getPairedRequester() requester {
+	return eg.requester
+}
+
+func (eg *kvElasticGranter) grantKind() grantKind {
+	return slot
+}
+
+func (eg *kvElasticGranter) tryGet() bool {
+	return eg.coord.tryGet(KVElasticWork)
+}
+
+func (eg *kvElasticGranter) tryGetLocked() grantResult {
+	if eg.diskBandwidthTokensEnabled && eg.availableDiskBandwidthTokens <= 0 {
+		return grantFailLocal
+	}
+	res := eg.kvGranter.tryGetLocked()
+	if res == grantSuccess {
+		eg.takeDiskBandwidthTokenLocked()
+	}
+	return res
+}
+
+func (eg *kvElasticGranter) returnGrant() {
+	eg.coord.returnGrant(KVElasticWork)
+}
+
+func (eg *kvElasticGranter) returnGrantLocked() {
+	// The disk bandwidth token is not returned, just like the IO token
+	// consumed in kvGranter.
+	eg.kvGranter.returnGrantLocked()
+}
+
+func (eg *kvElasticGranter) tookWithoutPermission() {
+	eg.coord.tookWithoutPermission(KVElasticWork)
+}
+
+func (eg *kvElasticGranter) tookWithoutPermissionLocked() {
+	eg.kvGranter.tookWithoutPermissionLocked()
+	eg.takeDiskBandwidthTokenLocked()
+}
+
+func (eg *kvElasticGranter) continueGrantChain(grantChainID grantChainID) {
+	eg.coord.continueGrantChain(KVElasticWork, grantChainID)
+}
+
+func (eg *kvElasticGranter) takeDiskBandwidthTokenLocked() {
+	if eg.diskBandwidthTokensEnabled {
+		eg.availableDiskBandwidthTokens--
+		if eg.availableDiskBandwidthTokens == 0 {
+			eg.exhaustedStart = timeutil.Now()
+		}
+	}
+}
+
+func (eg *kvElasticGranter) setAvailableDiskBandwidthTokensLocked(tokens int64) {
+	wasExhausted := eg.diskBandwidthTokensEnabled && eg.availableDiskBandwidthTokens <= 0
+	eg.diskBandwidthTokensEnabled = true
+	if eg.availableDiskBandwidthTokens < 0 {
+		// Negative because of tookWithoutPermission.
+		eg.availableDiskBandwidthTokens += tokens
+	} else {
+		eg.availableDiskBandwidthTokens = tokens
+	}
+	if wasExhausted && eg.availableDiskBandwidthTokens > 0 &&
+		eg.diskBandwidthTokensExhaustedDurationMetric != nil {
+		exhaustedMicros := timeutil.Since(eg.exhaustedStart).Microseconds()
+		eg.diskBandwidthTokensExhaustedDurationMetric.Inc(exhaustedMicros)
+	}
+}
+
 // GrantCoordinator is the top-level object that coordinates grants across
 // different WorkKinds (for more context see the comment in doc.go, and the
 // comment where WorkKind is declared). Typically there will one
@@ -575,11 +685,12 @@ type GrantCoordinator struct {
 	// The WorkQueues behaving as requesters in each granterWithLockedCalls.
 	// This is kept separately only to service GetWorkQueue calls.
 	queues [numWorkKinds]requester
-	// The cpu fields can be nil, and the IO field can be nil, since a
+	// The cpu fields can be nil, and the IO fields can be nil, since a
 	// GrantCoordinator typically handles one of these two resources.
 	cpuOverloadIndicator cpuOverloadIndicator
 	cpuLoadListener      CPULoadListener
 	ioLoadListener       *ioLoadListener
+	diskBandwidthLimiter *diskBandwidthLimiter
 
 	// The latest value of GOMAXPROCS, received via CPULoad. Only initialized if
 	// the cpu resource is being handled by this GrantCoordinator.
@@ -724,12 +835,16 @@ func NewGrantCoordinators(
 	metricStructs = appendMetricStructsForQueues(metricStructs, coord)
 
 	storeWorkQueueMetrics := makeWorkQueueMetrics(string(workKindString(KVWork)) + "-stores")
-	metricStructs = append(metricStructs, storeWorkQueueMetrics)
+	storeElasticWorkQueueMetrics :=
+		makeWorkQueueMetrics(string(workKindString(KVElasticWork)) + "-stores")
+	metricStructs = append(metricStructs, storeWorkQueueMetrics, storeElasticWorkQueueMetrics)
 	storeCoordinators := &StoreGrantCoordinators{
 		settings:                    st,
 		makeRequesterFunc:           makeRequester,
 		kvIOTokensExhaustedDuration: metrics.KVIOTokensExhaustedDuration,
-		workQueueMetrics:            storeWorkQueueMetrics,
+		kvElasticDiskBandwidthTokensExhaustedDuration: metrics.KVElasticDiskBandwidthTokensExhaustedDuration,
+		workQueueMetrics:        storeWorkQueueMetrics,
+		elasticWorkQueueMetrics: storeElasticWorkQueueMetrics,
 	}
 
 	return GrantCoordinators{Stores: storeCoordinators, Regular: coord}, metricStructs
@@ -824,15 +939,18 @@ func appendMetricStructsForQueues(ms []metric.Struct, coord *GrantCoordinator) [
 }
 
 // pebbleMetricsTick is called every adjustmentInterval seconds and passes
-// through to the ioLoadListener, so that it can adjust the plan for future IO
-// token allocations.
-func (coord *GrantCoordinator) pebbleMetricsTick(ctx context.Context, m pebble.Metrics) {
-	coord.ioLoadListener.pebbleMetricsTick(ctx, m)
+// through to the ioLoadListener and diskBandwidthLimiter, so that they can
+// adjust the plan for future IO token allocations.
+func (coord *GrantCoordinator) pebbleMetricsTick(ctx context.Context, m StoreMetrics) {
+	coord.ioLoadListener.pebbleMetricsTick(ctx, *m.Metrics)
+	coord.diskBandwidthLimiter.diskStatsTick(ctx, m.DiskStats)
 }
 
-// allocateIOTokensTick tells the ioLoadListener to allocate tokens.
+// allocateIOTokensTick tells the ioLoadListener and diskBandwidthLimiter to
+// allocate tokens.
 func (coord *GrantCoordinator) allocateIOTokensTick() {
 	coord.ioLoadListener.allocateTokensTick()
+	coord.diskBandwidthLimiter.allocateTokensTick()
 	coord.mu.Lock()
 	defer coord.mu.Unlock()
 	if !coord.grantChainActive {
@@ -1117,6 +1235,9 @@ func (coord *GrantCoordinator) SafeFormat(s redact.SafePrinter, verb rune) {
 	newlineStr := redact.RedactableString("\n")
 	curSep := spaceStr
 	for i := range coord.granters {
+		if coord.granters[i] == nil {
+			continue
+		}
 		kind := WorkKind(i)
 		switch kind {
 		case KVWork:
@@ -1125,6 +1246,12 @@ func (coord *GrantCoordinator) SafeFormat(s redact.SafePrinter, verb rune) {
 			if g.ioTokensEnabled {
 				s.Printf(" io-avail: %d", g.availableIOTokens)
 			}
+		case KVElasticWork:
+			g := coord.granters[i].(*kvElasticGranter)
+			if g.diskBandwidthTokensEnabled {
+				s.Printf("%s%s: disk-bw-avail: %d", curSep, workKindString(kind),
+					g.availableDiskBandwidthTokens)
+			}
 		case SQLStatementLeafStartWork, SQLStatementRootStartWork:
 			g := coord.granters[i].(*slotGranter)
 			s.Printf("%s%s: used: %d, total: %d", curSep, workKindString(kind), g.usedSlots, g.totalSlots)
@@ -1146,11 +1273,13 @@ func (coord *GrantCoordinator) SafeFormat(s redact.SafePrinter, verb rune) {
 type StoreGrantCoordinators struct {
 	ambientCtx log.AmbientContext
 
-	settings                    *cluster.Settings
-	makeRequesterFunc           makeRequesterFunc
-	kvIOTokensExhaustedDuration *metric.Counter
+	settings                                      *cluster.Settings
+	makeRequesterFunc                             makeRequesterFunc
+	kvIOTokensExhaustedDuration                   *metric.Counter
+	kvElasticDiskBandwidthTokensExhaustedDuration *metric.Counter
 	// These metrics are shared by WorkQueues across stores.
-	workQueueMetrics WorkQueueMetrics
+	workQueueMetrics        WorkQueueMetrics
+	elasticWorkQueueMetrics WorkQueueMetrics
 
 	gcMap                 map[int32]*GrantCoordinator
 	pebbleMetricsProvider PebbleMetricsProvider
@@ -1172,7 +1301,7 @@ func (sgc *StoreGrantCoordinators) SetPebbleMetricsProvider(
 	for _, m := range metrics {
 		gc := sgc.initGrantCoordinator(m.StoreID)
 		sgc.gcMap[m.StoreID] = gc
-		gc.pebbleMetricsTick(startupCtx, *m.Metrics)
+		gc.pebbleMetricsTick(startupCtx, m)
 		gc.allocateIOTokensTick()
 	}
 
@@ -1195,7 +1324,7 @@ func (sgc *StoreGrantCoordinators) SetPebbleMetricsProvider(
 					}
 					for _, m := range metrics {
 						if gc, ok := sgc.gcMap[m.StoreID]; ok {
-							gc.pebbleMetricsTick(ctx, *m.Metrics)
+							gc.pebbleMetricsTick(ctx, m)
 						} else {
 							log.Warningf(ctx,
 								"seeing metrics for unknown storeID %d", m.StoreID)
@@ -1258,13 +1387,38 @@ func (sgc *StoreGrantCoordinators) initGrantCoordinator(storeID int32) *GrantCoo
 	}
 	coord.ioLoadListener.mu.Mutex = &coord.mu
 	coord.ioLoadListener.mu.kvGranter = coord.granters[KVWork].(*kvGranter)
+
+	eg := &kvElasticGranter{
+		coord:     coord,
+		kvGranter: kvg,
+		diskBandwidthTokensExhaustedDurationMetric: sgc.kvElasticDiskBandwidthTokensExhaustedDuration,
+	}
+	opts = makeWorkQueueOptions(KVElasticWork)
+	opts.metrics = &sgc.elasticWorkQueueMetrics
+	coord.queues[KVElasticWork] = sgc.makeRequesterFunc(
+		sgc.ambientCtx, KVElasticWork, eg, sgc.settings, opts)
+	eg.requester = coord.queues[KVElasticWork]
+	coord.granters[KVElasticWork] = eg
+	coord.diskBandwidthLimiter = &diskBandwidthLimiter{
+		storeID:          storeID,
+		settings:         sgc.settings,
+		elasticRequester: coord.queues[KVElasticWork],
+	}
+	coord.diskBandwidthLimiter.mu.Mutex = &coord.mu
+	coord.diskBandwidthLimiter.mu.granter = eg
 	return coord
 }
 
 // TryGetQueueForStore returns a WorkQueue for the given storeID, or nil if
-// the storeID is not known.
-func (sgc *StoreGrantCoordinators) TryGetQueueForStore(storeID int32) *WorkQueue {
+// the storeID is not known. The returned WorkQueue is for KVElasticWork if
+// work at the given priority is elastic, else for KVWork.
+func (sgc *StoreGrantCoordinators) TryGetQueueForStore(
+	storeID int32, priority WorkPriority,
+) *WorkQueue {
 	if granter, ok := sgc.gcMap[storeID]; ok {
+		if IsElasticWork(priority) {
+			return granter.GetWorkQueue(KVElasticWork)
+		}
 		return granter.GetWorkQueue(KVWork)
 	}
 	return nil
@@ -1404,6 +1558,26 @@ type PebbleMetricsProvider interface {
 type StoreMetrics struct {
 	StoreID int32
 	*pebble.Metrics
+	DiskStats DiskStats
+}
+
+// DiskStats provide low-level stats about the disk resources used for a
+// store. We assume that the disk is not shared across multiple stores.
+// However, the stats may also include traffic unrelated to the store (e.g.
+// logging), in which case they can overestimate the load due to the store,
+// which results in more conservative throttling of elastic work.
+type DiskStats struct {
+	// Unavailable is true if the stats of the disk could not be read, in which
+	// case the other fields are ignored.
+	Unavailable bool
+	// BytesRead is the cumulative bytes read.
+	BytesRead uint64
+	// BytesWritten is the cumulative bytes written.
+	BytesWritten uint64
+	// ProvisionedBandwidth is the total provisioned bandwidth in bytes/s. When
+	// 0, the value of the admission.store.provisioned_bandwidth cluster
+	// setting is used.
+	ProvisionedBandwidth int64
 }
 
 // granterWithIOTokens is used to abstract kvGranter for testing.
@@ -1490,6 +1664,29 @@ const unlimitedTokens = math.MaxInt64
 // compactions).
 const adjustmentInterval = 15
 
+// tokensToAllocateForTick returns the tokens to give out in a 1s tick, when
+// totalTokens are to be given out over adjustmentInterval ticks, and
+// tokensAllocated have already been given out in the preceding ticks.
+func tokensToAllocateForTick(totalTokens int64, tokensAllocated int64) int64 {
+	var toAllocate int64
+	// unlimitedTokens==MaxInt64, so avoid overflow in the rounding up
+	// calculation.
+	if totalTokens >= unlimitedTokens-(adjustmentInterval-1) {
+		toAllocate = totalTokens / adjustmentInterval
+	} else {
+		// Round up so that we don't accumulate tokens to give in a burst on the
+		// last tick.
+		toAllocate = (totalTokens + adjustmentInterval - 1) / adjustmentInterval
+		if toAllocate < 0 {
+			panic(errors.AssertionFailedf("toAllocate is negative %d", toAllocate))
+		}
+		if toAllocate+tokensAllocated > totalTokens {
+			toAllocate = totalTokens - tokensAllocated
+		}
+	}
+	return toAllocate
+}
+
 // pebbleMetricsTicks is called every adjustmentInterval seconds, and decides
 // the token allocations until the next call.
 func (io *ioLoadListener) pebbleMetricsTick(ctx context.Context, m pebble.Metrics) {
@@ -1509,22 +1706,7 @@ func (io *ioLoadListener) pebbleMetricsTick(ctx context.Context, m pebble.Metric
 // allocateTokensTick gives out 1/adjustmentInterval of the totalTokens every
 // 1s.
 func (io *ioLoadListener) allocateTokensTick() {
-	var toAllocate int64
-	// unlimitedTokens==MaxInt64, so avoid overflow in the rounding up
-	// calculation.
-	if io.totalTokens >= unlimitedTokens-(adjustmentInterval-1) {
-		toAllocate = io.totalTokens / adjustmentInterval
-	} else {
-		// Round up so that we don't accumulate tokens to give in a burst on the
-		// last tick.
-		toAllocate = (io.totalTokens + adjustmentInterval - 1) / adjustmentInterval
-		if toAllocate < 0 {
-			panic(errors.AssertionFailedf("toAllocate is negative %d", toAllocate))
-		}
-		if toAllocate+io.tokensAllocated > io.totalTokens {
-			toAllocate = io.totalTokens - io.tokensAllocated
-		}
-	}
+	toAllocate := tokensToAllocateForTick(io.totalTokens, io.tokensAllocated)
 	if toAllocate > 0 {
 		io.mu.Lock()
 		defer io.mu.Unlock()
@@ -1661,15 +1843,22 @@ var (
 		Measurement: "Microseconds",
 		Unit:        metric.Unit_COUNT,
 	}
+	kvElasticDiskBandwidthTokensExhaustedDuration = metric.Metadata{
+		Name:        "admission.granter.disk_bandwidth_tokens_exhausted_duration.kv-elastic",
+		Help:        "Total duration when disk bandwidth tokens for elastic work were exhausted, in micros",
+		Measurement: "Microseconds",
+		Unit:        metric.Unit_COUNT,
+	}
 )
 
 // GranterMetrics are metrics associated with a GrantCoordinator.
 type GranterMetrics struct {
-	KVTotalSlots                *metric.Gauge
-	KVUsedSlots                 *metric.Gauge
-	KVIOTokensExhaustedDuration *metric.Counter
-	SQLLeafStartUsedSlots       *metric.Gauge
-	SQLRootStartUsedSlots       *metric.Gauge
+	KVTotalSlots                                  *metric.Gauge
+	KVUsedSlots                                   *metric.Gauge
+	KVIOTokensExhaustedDuration                   *metric.Counter
+	KVElasticDiskBandwidthTokensExhaustedDuration *metric.Counter
+	SQLLeafStartUsedSlots                         *metric.Gauge
+	SQLRootStartUsedSlots                         *metric.Gauge
 }
 
 // MetricStruct implements the metric.Struct interface.
@@ -1680,6 +1869,8 @@ func makeGranterMetrics() GranterMetrics {
 		KVTotalSlots:                metric.NewGauge(totalSlots),
 		KVUsedSlots:                 metric.NewGauge(addName(string(workKindString(KVWork)), usedSlots)),
 		KVIOTokensExhaustedDuration: metric.NewCounter(kvIOTokensExhaustedDuration),
+		KVElasticDiskBandwidthTokensExhaustedDuration: metric.NewCounter(
+			kvElasticDiskBandwidthTokensExhaustedDuration),
 		SQLLeafStartUsedSlots: metric.NewGauge(
 			addName(string(workKindString(SQLStatementLeafStartWork)), usedSlots)),
 		SQLRootStartUsedSlots: metric.NewGauge(
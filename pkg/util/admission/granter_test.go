@@ -81,6 +81,7 @@ func (tr *testRequester) getAdmittedCount() uint64 {
 //
 // init-grant-coordinator min-cpu=<int> max-cpu=<int> sql-kv-tokens=<int>
 //   sql-sql-tokens=<int> sql-leaf=<int> sql-root=<int>
+// init-store-grant-coordinator
 // set-has-waiting-requests work=<kind> v=<true|false>
 // set-return-value-from-granted work=<kind> v=<true|false>
 // try-get work=<kind>
@@ -89,6 +90,7 @@ func (tr *testRequester) getAdmittedCount() uint64 {
 // continue-grant-chain work=<kind>
 // cpu-load runnable=<int> procs=<int> [infrequent=<bool>]
 // set-io-tokens tokens=<int>
+// set-disk-bw-tokens tokens=<int>
 func TestGranterBasic(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -105,6 +107,18 @@ func TestGranterBasic(t *testing.T) {
 	}
 	settings := cluster.MakeTestingClusterSettings()
 	KVSlotAdjusterOverloadThreshold.Override(context.Background(), &settings.SV, 1)
+	makeTestRequester := func(
+		_ log.AmbientContext, workKind WorkKind, granter granter, _ *cluster.Settings,
+		opts workQueueOptions) requester {
+		req := &testRequester{
+			workKind:   workKind,
+			granter:    granter,
+			usesTokens: opts.usesTokens,
+			buf:        &buf,
+		}
+		requesters[workKind] = req
+		return req
+	}
 	datadriven.RunTest(t, testutils.TestDataPath(t, "granter"), func(t *testing.T, d *datadriven.TestData) string {
 		switch d.Cmd {
 		case "init-grant-coordinator":
@@ -116,23 +130,24 @@ func TestGranterBasic(t *testing.T) {
 			d.ScanArgs(t, "sql-sql-tokens", &opts.SQLSQLResponseBurstTokens)
 			d.ScanArgs(t, "sql-leaf", &opts.SQLStatementLeafStartWorkSlots)
 			d.ScanArgs(t, "sql-root", &opts.SQLStatementRootStartWorkSlots)
-			opts.makeRequesterFunc = func(
-				_ log.AmbientContext, workKind WorkKind, granter granter, _ *cluster.Settings,
-				opts workQueueOptions) requester {
-				req := &testRequester{
-					workKind:   workKind,
-					granter:    granter,
-					usesTokens: opts.usesTokens,
-					buf:        &buf,
-				}
-				requesters[workKind] = req
-				return req
-			}
+			opts.makeRequesterFunc = makeTestRequester
 			delayForGrantChainTermination = 0
 			coords, _ := NewGrantCoordinators(ambientCtx, opts)
 			coord = coords.Regular
 			return flushAndReset()
 
+		case "init-store-grant-coordinator":
+			opts := Options{
+				Settings:          settings,
+				makeRequesterFunc: makeTestRequester,
+			}
+			coords, _ := NewGrantCoordinators(ambientCtx, opts)
+			// Construct the GrantCoordinator for a single store directly, instead
+			// of via SetPebbleMetricsProvider, so that there is no goroutine
+			// periodically setting the tokens.
+			coord = coords.Stores.initGrantCoordinator(1)
+			return flushAndReset()
+
 		case "set-has-waiting-requests":
 			var v bool
 			d.ScanArgs(t, "v", &v)
@@ -187,6 +202,18 @@ func TestGranterBasic(t *testing.T) {
 			coord.testingTryGrant()
 			return flushAndReset()
 
+		case "set-disk-bw-tokens":
+			var tokens int
+			d.ScanArgs(t, "tokens", &tokens)
+			// Similar to set-io-tokens, we are not using a real
+			// diskBandwidthLimiter.
+			coord.mu.Lock()
+			coord.granters[KVElasticWork].(*kvElasticGranter).setAvailableDiskBandwidthTokensLocked(
+				int64(tokens))
+			coord.mu.Unlock()
+			coord.testingTryGrant()
+			return flushAndReset()
+
 		default:
 			return fmt.Sprintf("unknown command: %s", d.Cmd)
 		}
@@ -199,6 +226,8 @@ func scanWorkKind(t *testing.T, d *datadriven.TestData) WorkKind {
 	switch kindStr {
 	case "kv":
 		return KVWork
+	case "kv-elastic":
+		return KVElasticWork
 	case "sql-kv-response":
 		return SQLKVResponseWork
 	case "sql-sql-response":
@@ -241,6 +270,8 @@ func TestStoreCoordinators(t *testing.T) {
 	// All the KVWork requesters. The first one is for all KVWork and the
 	// remaining are the per-store ones.
 	var requesters []*testRequester
+	// The KVElasticWork requesters, which are only created for stores.
+	var elasticRequesters []*testRequester
 	opts := Options{
 		Settings: settings,
 		makeRequesterFunc: func(
@@ -254,6 +285,8 @@ func TestStoreCoordinators(t *testing.T) {
 			}
 			if workKind == KVWork {
 				requesters = append(requesters, req)
+			} else if workKind == KVElasticWork {
+				elasticRequesters = append(elasticRequesters, req)
 			}
 			return req
 		},
@@ -269,8 +302,9 @@ func TestStoreCoordinators(t *testing.T) {
 	// Setting the metrics provider will cause the initialization of two
 	// GrantCoordinators for the two stores.
 	storeCoords.SetPebbleMetricsProvider(context.Background(), &mp)
-	// Now we have 1+2 = 3 KVWork requesters.
+	// Now we have 1+2 = 3 KVWork requesters, and 2 KVElasticWork requesters.
 	require.Equal(t, 3, len(requesters))
+	require.Equal(t, 2, len(elasticRequesters))
 	// Confirm that the store IDs are as expected.
 	var actualStores []int32
 	for s := range storeCoords.gcMap {
@@ -285,8 +319,14 @@ func TestStoreCoordinators(t *testing.T) {
 	for i := range requesters {
 		requesters[i].tryGet()
 	}
+	// The elastic requesters also have unlimited disk bandwidth tokens at this
+	// point in time.
+	for i := range elasticRequesters {
+		elasticRequesters[i].tryGet()
+	}
 	require.Equal(t,
-		"kv: tryGet returned false\nkv: tryGet returned true\nkv: tryGet returned true\n",
+		"kv: tryGet returned false\nkv: tryGet returned true\nkv: tryGet returned true\n"+
+			"kv-elastic: tryGet returned true\nkv-elastic: tryGet returned true\n",
 		buf.String())
 	coords.Close()
 }
@@ -467,6 +507,87 @@ func TestBadIOLoadListenerStats(t *testing.T) {
 	}
 }
 
+type testGranterWithDiskBandwidthTokens struct {
+	buf strings.Builder
+}
+
+func (g *testGranterWithDiskBandwidthTokens) setAvailableDiskBandwidthTokensLocked(tokens int64) {
+	fmt.Fprintf(&g.buf, "setAvailableDiskBandwidthTokens: %s", tokensFor1sToString(tokens))
+}
+
+// TestDiskBandwidthLimiter is a datadriven test with the following commands.
+// set-state sets the state for token calculation and then ticks
+// adjustmentInterval times to cause tokens to be set in the
+// testGranterWithDiskBandwidthTokens. The admitted, read and written values
+// are cumulative. When provisioned is not specified, the
+// admission.store.provisioned_bandwidth setting is used. When unavailable is
+// specified, the disk stats are unavailable.
+// set-provisioned-bandwidth-setting bandwidth=<int>
+// set-state admitted=<int> read=<int> written=<int> [provisioned=<int>]
+// set-state admitted=<int> unavailable
+func TestDiskBandwidthLimiter(t *testing.T) {
+	req := &testRequesterForIOLL{}
+	granter := &testGranterWithDiskBandwidthTokens{}
+	var dbl *diskBandwidthLimiter
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	datadriven.RunTest(t, testutils.TestDataPath(t, "disk_bandwidth_limiter"),
+		func(t *testing.T, d *datadriven.TestData) string {
+			switch d.Cmd {
+			case "set-provisioned-bandwidth-setting":
+				var bandwidth int
+				d.ScanArgs(t, "bandwidth", &bandwidth)
+				ProvisionedBandwidth.Override(ctx, &st.SV, int64(bandwidth))
+				return ""
+
+			case "set-state":
+				d.ScanArgs(t, "admitted", &req.admittedCount)
+				var ds DiskStats
+				if d.HasArg("unavailable") {
+					ds.Unavailable = true
+				} else {
+					d.ScanArgs(t, "read", &ds.BytesRead)
+					d.ScanArgs(t, "written", &ds.BytesWritten)
+				}
+				if d.HasArg("provisioned") {
+					var provisioned int
+					d.ScanArgs(t, "provisioned", &provisioned)
+					ds.ProvisionedBandwidth = int64(provisioned)
+				}
+				if dbl == nil {
+					dbl = &diskBandwidthLimiter{
+						settings:         st,
+						elasticRequester: req,
+					}
+					// See the comment in TestIOLoadListener.
+					dbl.mu.Mutex = &syncutil.Mutex{}
+					dbl.mu.granter = granter
+				}
+				dbl.diskStatsTick(ctx, ds)
+				var buf strings.Builder
+				fmt.Fprintf(&buf, "admitted: %d, read: %d, written: %d,\nutil: %.2f, load: %s, "+
+					"smoothed-admit: %.2f,\ntokens: %s, tokens-allocated: %s\n", dbl.elasticAdmittedCount,
+					dbl.bytesRead, dbl.bytesWritten, dbl.utilization, dbl.loadLevel,
+					dbl.smoothedElasticAdmit, tokensForIntervalToString(dbl.totalTokens),
+					tokensFor1sToString(dbl.tokensAllocated))
+				for i := 0; i < adjustmentInterval; i++ {
+					dbl.allocateTokensTick()
+					// The granter is not called when there are no tokens to allocate.
+					fmt.Fprintf(&buf, "tick: %d", i)
+					if granter.buf.Len() > 0 {
+						fmt.Fprintf(&buf, ", %s", granter.buf.String())
+					}
+					buf.WriteString("\n")
+					granter.buf.Reset()
+				}
+				return buf.String()
+
+			default:
+				return fmt.Sprintf("unknown command: %s", d.Cmd)
+			}
+		})
+}
+
 // TODO(sumeer):
 // - Test metrics
 // - Test GrantCoordinator with multi-tenant configurations
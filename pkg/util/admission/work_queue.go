@@ -71,6 +71,7 @@ var admissionControlEnabledSettings = [numWorkKinds]*settings.BoolSetting{
 	KVWork:             KVAdmissionControlEnabled,
 	SQLKVResponseWork:  SQLKVResponseAdmissionControlEnabled,
 	SQLSQLResponseWork: SQLSQLResponseAdmissionControlEnabled,
+	KVElasticWork:      KVAdmissionControlEnabled,
 }
 
 // EpochLIFOEnabled controls whether the adaptive epoch-LIFO scheme is enabled
@@ -134,6 +135,10 @@ const (
 	TTLLowPri WorkPriority = -100
 	// UserLowPri is low priority work from user submissions (SQL).
 	UserLowPri WorkPriority = -50
+	// BulkNormalPri is bulk priority work from bulk jobs (backups, index
+	// backfills etc.) and from internal KV activities like GC, which could
+	// be run due to user submissions or be automatic.
+	BulkNormalPri WorkPriority = -30
 	// NormalPri is normal priority work.
 	NormalPri WorkPriority = 0
 	// UserHighPri is high priority work from user submissions (SQL).
@@ -149,11 +154,22 @@ const (
 var _ = LowPri
 var _ = TTLLowPri
 var _ = UserLowPri
+var _ = BulkNormalPri
 var _ = NormalPri
 var _ = UserHighPri
 var _ = LockingPri
 var _ = HighPri
 
+// IsElasticWork returns true iff work at the given priority is elastic, i.e.,
+// it is not latency sensitive and can tolerate being throttled to use a
+// fraction of the resources it could otherwise consume. Elastic work is
+// subject to store admission control even when it only reads, since reads
+// also consume disk bandwidth, and is queued in a separate WorkQueue (see
+// KVElasticWork), so that throttling it does not delay regular work.
+func IsElasticWork(pri WorkPriority) bool {
+	return pri < NormalPri
+}
+
 // WorkInfo provides information that is used to order work within an
 // WorkQueue. The WorkKind is not included as a field since an WorkQueue deals
 // with a single WorkKind.
@@ -270,7 +286,7 @@ type workQueueOptions struct {
 
 func makeWorkQueueOptions(workKind WorkKind) workQueueOptions {
 	switch workKind {
-	case KVWork:
+	case KVWork, KVElasticWork:
 		return workQueueOptions{usesTokens: false, tiedToRange: true}
 	case SQLKVResponseWork, SQLSQLResponseWork:
 		return workQueueOptions{usesTokens: true, tiedToRange: false}
@@ -486,7 +502,8 @@ func (q *WorkQueue) Admit(ctx context.Context, info WorkInfo) (enabled bool, err
 		tenant = newTenantInfo(tenantID)
 		q.mu.tenants[tenantID] = tenant
 	}
-	if info.BypassAdmission && roachpb.IsSystemTenantID(tenantID) && q.workKind == KVWork {
+	if info.BypassAdmission && roachpb.IsSystemTenantID(tenantID) &&
+		(q.workKind == KVWork || q.workKind == KVElasticWork) {
 		tenant.used++
 		if isInTenantHeap(tenant) {
 			q.mu.tenantHeap.fix(tenant)
@@ -0,0 +1,95 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package grunning measures the CPU time spent by the calling goroutine over
+// a section of code.
+//
+// The Go runtime does not expose per-goroutine running time, so the
+// measurement uses the CPU clock of the OS thread the goroutine is running on.
+// For the thread clock to only account for the measured goroutine, the
+// goroutine is locked to its thread for the duration of the measurement: it
+// can't be rescheduled onto a different thread, and no other goroutine can run
+// on its thread until the measurement ends. This avoids the bias of a plain
+// thread clock sample, which either has to discard measurements taken across
+// a thread migration (which are more likely for longer, more expensive
+// sections) or attribute the CPU time of unrelated goroutines to the measured
+// one.
+//
+// The measurement includes runtime work done on the goroutine's behalf, such
+// as GC assists. Since the goroutine is locked to its thread, blocking during
+// a measurement parks the thread and requires a thread handoff to resume, so
+// it should only be used around sections that rarely block. Sections that may
+// block, for example on disk I/O, can use StartUnlocked instead, at the cost
+// of a less accurate measurement.
+package grunning
+
+import (
+	"runtime"
+	"time"
+)
+
+// Measurement is an in-progress CPU time measurement started by Start.
+type Measurement struct {
+	tid    int
+	start  time.Duration
+	ok     bool
+	locked bool
+}
+
+// Supported returns true if CPU time measurements are supported on this
+// platform. When unsupported, Measurement.Elapsed always returns false.
+func Supported() bool {
+	return supported
+}
+
+// Start begins measuring the CPU time spent by the calling goroutine, locking
+// it to its current OS thread. Elapsed must be called exactly once on the
+// returned Measurement, from the same goroutine, to end the measurement and
+// unlock the goroutine.
+func Start() Measurement {
+	if !supported {
+		return Measurement{}
+	}
+	runtime.LockOSThread()
+	tid, start, ok := threadCPUTime()
+	return Measurement{tid: tid, start: start, ok: ok, locked: true}
+}
+
+// StartUnlocked is like Start, but does not lock the calling goroutine to its
+// OS thread, so the measured section can block without tying up the thread.
+// The measurement is discarded if the goroutine migrates to a different thread
+// before Elapsed is called, and it includes the CPU time of any goroutine that
+// ran on the thread while the measured one was descheduled.
+func StartUnlocked() Measurement {
+	if !supported {
+		return Measurement{}
+	}
+	tid, start, ok := threadCPUTime()
+	return Measurement{tid: tid, start: start, ok: ok}
+}
+
+// Elapsed ends the measurement and returns the CPU time spent by the calling
+// goroutine since the call to Start or StartUnlocked that returned the
+// receiver. The boolean is false if no measurement could be taken, either
+// because the platform does not support it or because an unlocked measurement
+// migrated threads.
+func (m Measurement) Elapsed() (time.Duration, bool) {
+	if !supported {
+		return 0, false
+	}
+	tid, end, ok := threadCPUTime()
+	if m.locked {
+		runtime.UnlockOSThread()
+	}
+	if !m.ok || !ok || tid != m.tid || end < m.start {
+		return 0, false
+	}
+	return end - m.start, true
+}
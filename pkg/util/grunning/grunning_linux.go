@@ -0,0 +1,33 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+//go:build linux
+// +build linux
+
+package grunning
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const supported = true
+
+// threadCPUTime returns the ID of the OS thread the calling goroutine is
+// currently running on, along with that thread's CPU clock.
+func threadCPUTime() (tid int, cpu time.Duration, ok bool) {
+	tid = unix.Gettid()
+	var ts unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_THREAD_CPUTIME_ID, &ts); err != nil {
+		return 0, 0, false
+	}
+	return tid, time.Duration(ts.Nano()), true
+}
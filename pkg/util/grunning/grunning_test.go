@@ -0,0 +1,125 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package grunning
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElapsed(t *testing.T) {
+	if !Supported() {
+		m := Start()
+		_, ok := m.Elapsed()
+		require.False(t, ok)
+		return
+	}
+
+	m := Start()
+	deadline := time.Now().Add(50 * time.Millisecond)
+	x := 0
+	for time.Now().Before(deadline) {
+		x++
+	}
+	elapsed, ok := m.Elapsed()
+	require.True(t, ok)
+	require.Greater(t, elapsed, time.Duration(0))
+	// The loop above busy-waits for 50ms of wall time, so the measured CPU
+	// time can't reasonably exceed a generous multiple of that.
+	require.Less(t, elapsed, 5*time.Second)
+	require.NotZero(t, x)
+}
+
+// TestElapsedExcludesOtherGoroutines verifies that the CPU time of goroutines
+// that run while the measured goroutine is blocked is not attributed to it.
+func TestElapsedExcludesOtherGoroutines(t *testing.T) {
+	if !Supported() {
+		skip.IgnoreLint(t, "CPU time measurements are not supported on this platform")
+	}
+
+	// With a single P, the busy goroutine below would run on the measured
+	// goroutine's thread while it sleeps, unless it is locked to it.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	m := Start()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		deadline := time.Now().Add(100 * time.Millisecond)
+		for time.Now().Before(deadline) {
+		}
+	}()
+	<-done
+	elapsed, ok := m.Elapsed()
+	require.True(t, ok)
+	require.Less(t, elapsed, 50*time.Millisecond)
+}
+
+func TestElapsedUnlocked(t *testing.T) {
+	m := StartUnlocked()
+	deadline := time.Now().Add(50 * time.Millisecond)
+	x := 0
+	for time.Now().Before(deadline) {
+		x++
+	}
+	elapsed, ok := m.Elapsed()
+	require.NotZero(t, x)
+	if !Supported() {
+		require.False(t, ok)
+		return
+	}
+	// The goroutine may have migrated threads during the loop, in which case
+	// the measurement is discarded.
+	if ok {
+		require.Greater(t, elapsed, time.Duration(0))
+		require.Less(t, elapsed, 5*time.Second)
+	}
+}
+
+// BenchmarkMeasurement measures the overhead of CPU time measurements around a
+// section of code that takes about as long as evaluating a point read.
+func BenchmarkMeasurement(b *testing.B) {
+	work := func() int {
+		x := 0
+		for i := 0; i < 1000; i++ {
+			x += i * i
+		}
+		return x
+	}
+	for _, tc := range []struct {
+		name  string
+		start func() Measurement
+	}{
+		{name: "none"},
+		{name: "unlocked", start: StartUnlocked},
+		{name: "locked", start: Start},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				var sink int
+				for pb.Next() {
+					if tc.start == nil {
+						sink += work()
+						continue
+					}
+					m := tc.start()
+					sink += work()
+					_, _ = m.Elapsed()
+				}
+				_ = sink
+			})
+		})
+	}
+}